@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// exportArchive writes a whole project (tasks, groups, milestones, history
+// and settings) as a JSONL or tar.gz archive
+func exportArchive(projectName, format, output string) error {
+	archive, err := data.ExportProject(projectName)
+	if err != nil {
+		return err
+	}
+	if state, err := config.LoadState(); err == nil {
+		if ps, ok := state.Projects[projectName]; ok {
+			archive.Settings = ps
+		}
+	}
+
+	if output == "" || output == "-" {
+		return archive.Write(os.Stdout, format)
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if err := archive.Write(f, format); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d task(s), %d history entries to %s\n", len(archive.Tasks), len(archive.History), output)
+	return nil
+}
+
+// runImport merges a project archive into a project
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	projectName := fs.String("project", "", "target project (default: the archived project's name)")
+	strategy := fs.String("strategy", data.ImportKeep, "on conflicts: keep (local wins), overwrite (archive wins) or replace (project becomes the archive)")
+	format := fs.String("format", "", "jsonl or tar.gz (default: from the file name)")
+	dryRun := fs.Bool("dry-run", false, "show what would change without writing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cctasks import [--project <project>] [--strategy keep|overwrite|replace] [--dry-run] <archive>")
+	}
+	path := fs.Arg(0)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	if *format == "" {
+		*format = data.ArchiveFormatForPath(path)
+	}
+	if *format == "" {
+		*format = sniffArchiveFormat(r)
+	}
+
+	archive, err := data.ReadArchive(r, *format)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if *projectName == "" {
+		*projectName = archive.Project
+	}
+	if *projectName == "" {
+		return fmt.Errorf("archive has no project name; pass --project")
+	}
+
+	result, err := data.ImportProject(*projectName, archive, *strategy, *dryRun)
+	if err != nil {
+		return err
+	}
+	if archive.Settings != nil && !*dryRun {
+		if state, err := config.LoadState(); err == nil {
+			state.MergeProject(*projectName, *archive.Settings, *strategy != data.ImportKeep)
+			state.Save() // best-effort
+		}
+	}
+
+	prefix := "Imported"
+	if *dryRun {
+		prefix = "Would import"
+	}
+	fmt.Printf("%s into %s: %d added, %d updated, %d kept", prefix, *projectName, result.Added, result.Updated, result.Kept)
+	if result.Trashed > 0 {
+		fmt.Printf(", %d moved to trash", result.Trashed)
+	}
+	fmt.Printf("; %d group(s), %d milestone(s), %d history entries changed\n", result.Groups, result.Milestones, result.History)
+	return nil
+}
+
+// sniffArchiveFormat tells tar.gz from JSONL by the gzip magic bytes
+func sniffArchiveFormat(r *bufio.Reader) string {
+	magic, err := r.Peek(2)
+	if err != nil && err != io.EOF {
+		return data.FormatJSONL
+	}
+	if strings.HasPrefix(string(magic), "\x1f\x8b") {
+		return data.FormatTarGz
+	}
+	return data.FormatJSONL
+}
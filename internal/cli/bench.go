@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runBench creates a large fake project for performance testing and reports
+// how long it takes to load
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	tasks := fs.Int("tasks", 10000, "number of tasks")
+	groups := fs.Int("groups", 8, "number of groups")
+	deps := fs.Float64("deps", 0.1, "share of tasks blocked by an earlier task (0-1)")
+	seed := fs.Int64("seed", 1, "random seed; the same seed generates the same tasks")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *tasks < 0 || *groups < 0 || *deps < 0 || *deps > 1 {
+		return fmt.Errorf("usage: cctasks bench [--tasks N] [--groups N] [--deps 0-1] [--seed N] <project>")
+	}
+	projectName := fs.Arg(0)
+
+	start := time.Now()
+	taskList, groupList := data.SyntheticProject(data.SyntheticOptions{Tasks: *tasks, Groups: *groups, Deps: *deps, Seed: *seed})
+	if err := data.WriteProject(projectName, taskList, groupList); err != nil {
+		return err
+	}
+	fmt.Printf("Created %s with %d task(s) and %d group(s) in %v\n", projectName, len(taskList), len(groupList), time.Since(start).Round(time.Millisecond))
+
+	start = time.Now()
+	store, err := data.LoadTasks(projectName)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Loaded %d task(s) in %v\n", len(store.Tasks), time.Since(start).Round(time.Millisecond))
+	return nil
+}
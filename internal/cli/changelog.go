@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runChangelog prints the tasks completed in a period as a CHANGELOG section
+func runChangelog(args []string) error {
+	fs := flag.NewFlagSet("changelog", flag.ContinueOnError)
+	projectName := fs.String("project", "", "project name")
+	since := fs.String("since", "", "tasks completed on or after this date (YYYY-MM-DD)")
+	sinceTag := fs.Bool("since-tag", false, "tasks completed since the last git tag of --repo")
+	repo := fs.String("repo", ".", "git repository whose tags --since-tag uses")
+	until := fs.String("until", "", "tasks completed on or before this date (YYYY-MM-DD, default: now)")
+	title := fs.String("title", "", "section heading (default: Unreleased)")
+	by := fs.String("by", "group", "sections by group or tag")
+	tmpl := fs.String("template", "", "entry template (default: templates.changelog, or \"- {{.Subject}} (#{{.ID}})\")")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *projectName == "" && fs.NArg() == 1 {
+		*projectName = fs.Arg(0)
+	}
+	if *projectName == "" || (*by != "group" && *by != "tag") || (*since != "" && *sinceTag) {
+		return fmt.Errorf("usage: cctasks changelog --project <project> [--since YYYY-MM-DD | --since-tag [--repo dir]] [--until YYYY-MM-DD] [--title T] [--by group|tag] [--template T]")
+	}
+
+	opts := data.ChangelogOptions{Title: *title, ByTag: *by == "tag", Template: *tmpl}
+	if opts.Template == "" {
+		opts.Template = config.Current().Templates.Changelog
+	}
+	if *since != "" {
+		t, err := time.ParseInLocation(data.DateFormat, *since, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since date %q (want YYYY-MM-DD)", *since)
+		}
+		opts.Since = t
+	}
+	if *sinceTag {
+		tag, t, err := data.LastTag(*repo)
+		if err != nil {
+			return fmt.Errorf("last tag: %w", err)
+		}
+		opts.Since = t
+		fmt.Fprintf(os.Stderr, "Since %s (%s)\n", tag, t.Local().Format(data.DateFormat))
+	}
+	if *until != "" {
+		t, err := time.ParseInLocation(data.DateFormat, *until, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --until date %q (want YYYY-MM-DD)", *until)
+		}
+		opts.Until = t.AddDate(0, 0, 1).Add(-time.Nanosecond) // the whole day
+	}
+
+	store, err := data.LoadTasks(*projectName)
+	if err != nil {
+		return err
+	}
+	groups, err := data.LoadGroups(*projectName)
+	if err != nil {
+		return err
+	}
+	opts.Groups = groups.GetGroupNames()
+
+	entries, err := store.CompletedTasks()
+	if err != nil {
+		return err
+	}
+	return data.WriteChangelog(os.Stdout, entries, opts)
+}
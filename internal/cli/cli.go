@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/logging"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// Command is a non-interactive subcommand (e.g. "cctasks validate")
+type Command struct {
+	Name  string
+	Usage string
+	Run   func(args []string) error
+}
+
+// commands lists all available subcommands
+var commands = []Command{
+	{Name: "validate", Usage: "validate [project...]  Check task files against the schema", Run: runValidate},
+	{Name: "prune", Usage: "prune [--dry-run] [--keep N] [--days N] [project...]  Delete old backup snapshots", Run: runPrune},
+	{Name: "renumber", Usage: "renumber [--dry-run] <project>  Renumber task IDs sequentially", Run: runRenumber},
+	{Name: "convert", Usage: "convert --to files|single <project>  Convert between one file per task and the single tasks.json of older Claude Code versions", Run: runConvert},
+	{Name: "migrate", Usage: "migrate [--dry-run] [--to N] [project...]  Upgrade task files to a newer Claude Code schema version (backed up first)", Run: runMigrate},
+	{Name: "remote", Usage: "remote list|push|restore [--remote name] [--snapshot NAME] [project...]  List, upload or restore backup snapshots on a remote target", Run: runRemote},
+	{Name: "encrypt", Usage: "encrypt <project>  Encrypt a project's task files with a passphrase (asked for, or $CCTASKS_PASSPHRASE)", Run: runEncrypt},
+	{Name: "decrypt", Usage: "decrypt <project>  Turn an encrypted project back into plain task files", Run: runDecrypt},
+	{Name: "next", Usage: "next --project <project> [--start]  Suggest the next unblocked task", Run: runNext},
+	{Name: "export", Usage: "export --project <project> [--format csv|tsv|jsonl|tar.gz] [--columns id,subject,...] [--output file]  Export tasks as a table or the whole project as an archive", Run: runExport},
+	{Name: "import", Usage: "import [--project <project>] [--strategy keep|overwrite|replace] [--dry-run] <archive>  Import a project archive", Run: runImport},
+	{Name: "render", Usage: "render --project <project> [--width N] [--height N] [--completed]  Print the task list once", Run: runRender},
+	{Name: "status", Usage: "status [--project <project>] [--format template]  Print a one-line summary for tmux or shell prompts", Run: runStatus},
+	{Name: "changelog", Usage: "changelog --project <project> [--since date|--since-tag] [--until date] [--title T] [--by group|tag] [--template T]  Print completed tasks as a CHANGELOG section", Run: runChangelog},
+	{Name: "ical", Usage: "ical [--project <project>] [--kind event|todo] [--output file]  Export due dates as an iCalendar feed", Run: runICal},
+	{Name: "serve", Usage: "serve [--addr host:port] [--token T] [--slack-secret S] [--project P]  Serve calendar feeds and Slack slash commands over HTTP", Run: runServe},
+	{Name: "script", Usage: "script [--dry-run] <file>  Replay a script of project/filter/set/list/export commands", Run: runScript},
+	{Name: "seed", Usage: "seed --project <project> [--tasks N] [--groups N] [--deps 0-1] [--seed N]  Create a realistic fake project for demos and screenshots", Run: runSeed},
+	{Name: "bench", Usage: "bench [--tasks N] [--groups N] [--deps 0-1] [--seed N] <project>  Create a large fake project for performance testing", Run: runBench},
+}
+
+// ApplyGlobalFlags applies flags accepted before or after any command
+// (--dir <path>, --debug, --no-color, --ascii, --low-power, --cpuprofile <file>
+// and --memprofile <file>) and returns the remaining arguments
+func ApplyGlobalFlags(args []string) ([]string, error) {
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if name, value, next, err := valueFlag(args, i, "dir", "cpuprofile", "memprofile"); name != "" {
+			if err != nil {
+				return nil, err
+			}
+			switch name {
+			case "dir":
+				config.SetTasksDirOverride(value)
+			case "cpuprofile":
+				cpuProfilePath = value
+			case "memprofile":
+				memProfilePath = value
+			}
+			i = next
+			continue
+		}
+		switch {
+		case arg == "--debug" || arg == "-debug":
+			if err := logging.Enable(); err != nil {
+				return nil, fmt.Errorf("enable debug log: %w", err)
+			}
+		case arg == "--no-color" || arg == "-no-color":
+			ui.SetNoColor(true)
+		case arg == "--ascii" || arg == "-ascii":
+			ui.SetASCII(true)
+		case arg == "--low-power" || arg == "-low-power":
+			config.Current().LowPower = true
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, nil
+}
+
+// valueFlag matches a flag taking a value at args[i]: "--name value" or
+// "--name=value", with one or two dashes. It returns the flag's name ("" if
+// none of names matches), its value and the index of the last argument used.
+func valueFlag(args []string, i int, names ...string) (string, string, int, error) {
+	arg, ok := strings.CutPrefix(args[i], "--")
+	if !ok {
+		if arg, ok = strings.CutPrefix(args[i], "-"); !ok {
+			return "", "", i, nil
+		}
+	}
+	for _, name := range names {
+		if arg == name {
+			if i+1 >= len(args) {
+				return name, "", i, fmt.Errorf("flag needs an argument: --%s", name)
+			}
+			return name, args[i+1], i + 1, nil
+		}
+		if value, ok := strings.CutPrefix(arg, name+"="); ok {
+			return name, value, i, nil
+		}
+	}
+	return "", "", i, nil
+}
+
+// Run executes the subcommand named by args[0].
+// It returns false if args do not name a subcommand, so the TUI should start.
+func Run(args []string) (bool, error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+
+	if args[0] == "help" || args[0] == "--help" || args[0] == "-h" {
+		printUsage()
+		return true, nil
+	}
+
+	for _, cmd := range commands {
+		if cmd.Name == args[0] {
+			return true, cmd.Run(args[1:])
+		}
+	}
+	return false, nil
+}
+
+// printUsage prints the list of subcommands
+func printUsage() {
+	var b strings.Builder
+	b.WriteString("Usage: cctasks [command]\n")
+	b.WriteString("       cctasks [--project <project>] [--task <id>] [project]\n\n")
+	b.WriteString("Global flags:\n")
+	b.WriteString("  --dir <path>           Tasks directory (default: $CCTASKS_DIR, config \"tasksDir\", ~/.claude/tasks)\n")
+	b.WriteString("  --debug                Write a debug log to ~/.config/cctasks/log/cctasks.log (Ctrl+G shows it in the TUI)\n")
+	b.WriteString("  --no-color             Use bold/underline/reverse instead of colors and words instead of status icons ($NO_COLOR)\n")
+	b.WriteString("  --ascii                Draw ASCII instead of Unicode glyphs (config \"ascii\")\n")
+	b.WriteString("  --low-power            Poll for changes less often, slowing further when idle (config \"lowPower\")\n")
+	b.WriteString("  --cpuprofile <file>    Write a CPU profile (go tool pprof) to file\n")
+	b.WriteString("  --memprofile <file>    Write a heap profile to file on exit\n\n")
+	b.WriteString("Without a command, cctasks starts the interactive TUI, optionally\n")
+	b.WriteString("opening a project's task list or a task's detail view directly.\n\n")
+	b.WriteString("Commands:\n")
+	for _, cmd := range commands {
+		b.WriteString("  " + cmd.Usage + "\n")
+	}
+	b.WriteString("  help                   Show this help\n")
+	fmt.Fprint(os.Stdout, b.String())
+}
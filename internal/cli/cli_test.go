@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+// setupCLI points the tasks directory at a temp dir holding project "app":
+// #1 completed, #2 pending and #5 pending, blocked by #2
+func setupCLI(t *testing.T) string {
+	t.Helper()
+	tasksDir := testutil.IsolateHome(t)
+	config.SetCurrent(config.Default())
+	t.Cleanup(func() { config.SetCurrent(nil) })
+
+	tasks := []data.Task{
+		{ID: "1", Subject: "Write spec", Status: "completed", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "2", Subject: "Build parser", Status: "pending", Blocks: []string{"5"}, BlockedBy: []string{}},
+		{ID: "5", Subject: "Ship release", Status: "pending", Blocks: []string{}, BlockedBy: []string{"2"}},
+	}
+	if err := data.WriteProject("app", tasks, nil); err != nil {
+		t.Fatal(err)
+	}
+	return tasksDir
+}
+
+// runCLI runs a subcommand and returns what it printed to stdout
+func runCLI(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- string(out)
+	}()
+
+	ok, runErr := Run(args)
+	os.Stdout = stdout
+	w.Close()
+	out := <-done
+	if !ok {
+		t.Fatalf("%q is not a command", args)
+	}
+	return out, runErr
+}
+
+// loadTask returns a task of a project as saved on disk
+func loadTask(t *testing.T, projectName, id string) *data.Task {
+	t.Helper()
+	store, err := data.LoadTasks(projectName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store.GetTask(id)
+}
+
+func TestRun(t *testing.T) {
+	setupCLI(t)
+
+	for _, args := range [][]string{nil, {"app"}, {"--task", "2"}} {
+		if ok, err := Run(args); ok || err != nil {
+			t.Errorf("Run(%q) = %v, %v; want the TUI to start", args, ok, err)
+		}
+	}
+	out, err := runCLI(t, "help")
+	if err != nil || !strings.Contains(out, "Commands:") || !strings.Contains(out, "renumber [--dry-run] <project>") {
+		t.Errorf("help = %q, %v", out, err)
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	tasksDir := setupCLI(t)
+
+	out, err := runCLI(t, "validate")
+	if err != nil || out != "1 project(s) OK\n" {
+		t.Errorf("validate = %q, %v", out, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tasksDir, "app", "7.json"), []byte(`{"id":"7"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runCLI(t, "validate", "app")
+	if err == nil || !strings.Contains(err.Error(), "problem(s) found") {
+		t.Errorf("validate error = %v, want problems found", err)
+	}
+	if !strings.Contains(out, "app/7.json") {
+		t.Errorf("validate = %q, want the broken file listed", out)
+	}
+}
+
+func TestExportCommand(t *testing.T) {
+	setupCLI(t)
+
+	out, err := runCLI(t, "export", "--project", "app", "--columns", "id,subject,status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "id,subject,status\n1,Write spec,completed\n2,Build parser,pending\n5,Ship release,pending\n"; out != want {
+		t.Errorf("export = %q, want %q", out, want)
+	}
+
+	// The format follows the output file's extension
+	path := filepath.Join(t.TempDir(), "app.tsv")
+	if _, err := runCLI(t, "export", "--status", "pending", "--columns", "id,subject", "--output", path, "app"); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "id\tsubject\n2\tBuild parser\n5\tShip release\n"; string(content) != want {
+		t.Errorf("%s = %q, want %q", path, content, want)
+	}
+
+	if _, err := runCLI(t, "export", "--project", "app", "--columns", "id,nope"); err == nil {
+		t.Error("Expected an unknown column to be refused")
+	}
+	if _, err := runCLI(t, "export"); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Errorf("export without a project = %v, want usage", err)
+	}
+}
+
+func TestExportImportArchive(t *testing.T) {
+	tasksDir := setupCLI(t)
+
+	path := filepath.Join(t.TempDir(), "app.tar.gz")
+	if _, err := runCLI(t, "export", "--project", "app", "--output", path); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runCLI(t, "import", "--project", "copy", "--dry-run", path)
+	if err != nil || !strings.HasPrefix(out, "Would import into copy: 3 added") {
+		t.Errorf("import --dry-run = %q, %v", out, err)
+	}
+	if _, err := os.Stat(filepath.Join(tasksDir, "copy")); err == nil {
+		t.Error("Expected --dry-run to write nothing")
+	}
+
+	out, err = runCLI(t, "import", "--project", "copy", path)
+	if err != nil || !strings.HasPrefix(out, "Imported into copy: 3 added") {
+		t.Errorf("import = %q, %v", out, err)
+	}
+	if task := loadTask(t, "copy", "5"); task == nil || task.Subject != "Ship release" || len(task.BlockedBy) != 1 {
+		t.Errorf("Expected #5 imported with its blocker, got %+v", task)
+	}
+}
+
+func TestNextCommand(t *testing.T) {
+	setupCLI(t)
+
+	out, err := runCLI(t, "next", "--project", "app")
+	if err != nil || out != "#2 Build parser\n  unblocks: 1 task(s)\n" {
+		t.Errorf("next = %q, %v", out, err)
+	}
+	if task := loadTask(t, "app", "2"); task.Status != "pending" {
+		t.Errorf("Expected next without --start to change nothing, got %s", task.Status)
+	}
+
+	out, err = runCLI(t, "next", "--project", "app", "--start")
+	if err != nil || !strings.HasSuffix(out, "Marked #2 in_progress\n") {
+		t.Errorf("next --start = %q, %v", out, err)
+	}
+	if task := loadTask(t, "app", "2"); task.Status != "in_progress" {
+		t.Errorf("Expected #2 saved in_progress, got %s", task.Status)
+	}
+
+	// #5 is still blocked by the in_progress #2
+	out, err = runCLI(t, "next", "app")
+	if err != nil || out != "No unblocked pending tasks\n" {
+		t.Errorf("next = %q, %v", out, err)
+	}
+}
+
+func TestStatusCommand(t *testing.T) {
+	setupCLI(t)
+
+	out, err := runCLI(t, "status", "--project", "app", "--format", "{pending}/{completed} {open}")
+	if err != nil || out != "2/1 2\n" {
+		t.Errorf("status = %q, %v", out, err)
+	}
+	if _, err := runCLI(t, "status"); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Errorf("status without a project = %v, want usage", err)
+	}
+}
+
+func TestRenumberCommand(t *testing.T) {
+	tasksDir := setupCLI(t)
+	state, _ := config.LoadState()
+	ps := state.Project("app")
+	ps.RecentViewed = []string{"5", "2"}
+	ps.TaskList = &config.TaskListState{CursorTaskID: "5"}
+	if err := state.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCLI(t, "renumber", "--dry-run", "app")
+	if err != nil || out != "#5 -> #3\n1 task(s) would be renumbered\n" {
+		t.Errorf("renumber --dry-run = %q, %v", out, err)
+	}
+	if _, err := os.Stat(filepath.Join(tasksDir, "app", "5.json")); err != nil {
+		t.Errorf("Expected --dry-run to keep 5.json: %v", err)
+	}
+
+	out, err = runCLI(t, "renumber", "app")
+	if err != nil || out != "#5 -> #3\n1 task(s) renumbered\n" {
+		t.Errorf("renumber = %q, %v", out, err)
+	}
+	if _, err := os.Stat(filepath.Join(tasksDir, "app", "5.json")); err == nil {
+		t.Error("Expected 5.json renamed")
+	}
+	if task := loadTask(t, "app", "3"); task == nil || task.Subject != "Ship release" || task.BlockedBy[0] != "2" {
+		t.Errorf("Expected #3 to be the renumbered #5, got %+v", task)
+	}
+	if task := loadTask(t, "app", "2"); task.Blocks[0] != "3" {
+		t.Errorf("Expected #2 to block #3, got %v", task.Blocks)
+	}
+
+	// The recently viewed tasks and the cursor follow the new IDs
+	state, _ = config.LoadState()
+	ps = state.Project("app")
+	if strings.Join(ps.RecentViewed, ",") != "3,2" || ps.TaskList.CursorTaskID != "3" {
+		t.Errorf("Expected state renumbered to 3, got recent %v, cursor %s", ps.RecentViewed, ps.TaskList.CursorTaskID)
+	}
+}
+
+func TestConvertCommand(t *testing.T) {
+	tasksDir := setupCLI(t)
+
+	out, err := runCLI(t, "convert", "--to", data.LayoutSingle, "app")
+	if err != nil || !strings.HasPrefix(out, "3 task(s) of app converted to the single layout") {
+		t.Errorf("convert = %q, %v", out, err)
+	}
+	if layout := data.DetectLayout(filepath.Join(tasksDir, "app")); layout != data.LayoutSingle {
+		t.Errorf("layout = %s, want single", layout)
+	}
+	if task := loadTask(t, "app", "5"); task == nil || task.Subject != "Ship release" {
+		t.Errorf("Expected #5 readable from tasks.json, got %+v", task)
+	}
+
+	if _, err := runCLI(t, "convert", "app"); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Errorf("convert without --to = %v, want usage", err)
+	}
+}
+
+func TestSeedCommand(t *testing.T) {
+	setupCLI(t)
+
+	out, err := runCLI(t, "seed", "--project", "demo", "--tasks", "12", "--groups", "3")
+	if err != nil || !strings.HasPrefix(out, "Created demo with 12 task(s) in 3 group(s)") {
+		t.Errorf("seed = %q, %v", out, err)
+	}
+	store, err := data.LoadTasks("demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Tasks) != 12 || len(store.Problems) != 0 {
+		t.Errorf("Expected 12 valid tasks, got %d with problems %v", len(store.Tasks), store.Problems)
+	}
+
+	if _, err := runCLI(t, "seed", "--project", "app"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("seed over an existing project = %v, want already exists", err)
+	}
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runConvert rewrites a project's tasks in another file layout
+func runConvert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	to := fs.String("to", "", "layout to convert to: files (one file per task) or single (tasks.json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *to == "" {
+		return fmt.Errorf("usage: cctasks convert --to %s <project>", strings.Join(data.Layouts, "|"))
+	}
+	projectName := fs.Arg(0)
+
+	n, err := data.ConvertLayout(projectName, *to)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d task(s) of %s converted to the %s layout (backed up first)\n", n, projectName, *to)
+	return nil
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runEncrypt encrypts a project's task files with a passphrase
+func runEncrypt(args []string) error {
+	fs := flag.NewFlagSet("encrypt", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cctasks encrypt <project>")
+	}
+	projectName := fs.Arg(0)
+	if data.ProjectCommitted(projectName) {
+		fmt.Fprintf(os.Stderr, "Warning: git history is enabled; earlier commits keep the plain task files of %s (rewrite the history, e.g. with git filter-repo, to remove them)\n", projectName)
+	}
+
+	passphrase, err := readPassphrase("New passphrase: ", true)
+	if err != nil {
+		return err
+	}
+	n, err := data.EncryptProject(projectName, passphrase)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d task file(s) of %s encrypted (backed up first); the passphrase cannot be recovered if lost\n", n, projectName)
+	return nil
+}
+
+// runDecrypt turns an encrypted project back into plain task files
+func runDecrypt(args []string) error {
+	fs := flag.NewFlagSet("decrypt", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cctasks decrypt <project>")
+	}
+	projectName := fs.Arg(0)
+
+	passphrase, err := readPassphrase("Passphrase: ", false)
+	if err != nil {
+		return err
+	}
+	n, err := data.DecryptProject(projectName, passphrase)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d task file(s) of %s decrypted (backed up first)\n", n, projectName)
+	return nil
+}
+
+// readPassphrase returns $CCTASKS_PASSPHRASE, or reads a passphrase from the
+// terminal without echoing it, asking twice when confirm is set
+func readPassphrase(prompt string, confirm bool) (string, error) {
+	if passphrase := os.Getenv(data.PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no terminal to ask for the passphrase; set $%s", data.PassphraseEnv)
+	}
+	read := func(prompt string) (string, error) {
+		fmt.Fprint(os.Stderr, prompt)
+		passphrase, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return string(passphrase), err
+	}
+	passphrase, err := read(prompt)
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("the passphrase must not be empty")
+	}
+	if confirm {
+		again, err := read("Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", errors.New("the passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
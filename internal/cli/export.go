@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runExport writes a project's tasks as CSV or TSV for spreadsheets, or the
+// whole project as a JSONL or tar.gz archive
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	projectName := fs.String("project", "", "project name")
+	format := fs.String("format", "", "csv, tsv, jsonl or tar.gz (default: from --output extension, or csv)")
+	columns := fs.String("columns", "", "comma-separated columns (default: id,subject,status,group,owner,due,tags)")
+	output := fs.String("output", "", "output file (default: stdout)")
+	status := fs.String("status", "", "only export tasks with this status")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *projectName == "" && fs.NArg() == 1 {
+		*projectName = fs.Arg(0)
+	}
+	if *projectName == "" {
+		return fmt.Errorf("usage: cctasks export --project <project> [--format csv|tsv|jsonl|tar.gz] [--columns id,subject,...] [--output file] [--status s]")
+	}
+
+	if *format == "" {
+		*format = data.ArchiveFormatForPath(*output)
+	}
+	if *format == data.FormatJSONL || *format == data.FormatTarGz {
+		return exportArchive(*projectName, *format, *output)
+	}
+
+	cols, err := data.ParseExportColumns(*columns)
+	if err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = data.FormatForPath(*output)
+	}
+
+	store, err := data.LoadTasks(*projectName)
+	if err != nil {
+		return err
+	}
+	tasks := store.Tasks
+	if *status != "" {
+		tasks = store.GetTasksByStatus(*status)
+	}
+
+	if *output == "" || *output == "-" {
+		return store.WriteTable(os.Stdout, tasks, cols, *format)
+	}
+	if err := store.WriteTableFile(*output, tasks, cols, *format); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d task(s) to %s\n", len(tasks), *output)
+	return nil
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/server"
+)
+
+// runICal writes an iCalendar feed of task due dates
+func runICal(args []string) error {
+	fs := flag.NewFlagSet("ical", flag.ContinueOnError)
+	projectName := fs.String("project", "", "project name (default: all unarchived projects)")
+	kind := fs.String("kind", data.ICalEvent, "event (all-day events) or todo (VTODO entries)")
+	output := fs.String("output", "", "output file (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *projectName == "" && fs.NArg() == 1 {
+		*projectName = fs.Arg(0)
+	}
+
+	tasks, err := server.CalendarTasks(*projectName)
+	if err != nil {
+		return err
+	}
+	if *output == "" || *output == "-" {
+		return data.WriteICal(os.Stdout, tasks, *kind, time.Now())
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := data.WriteICal(f, tasks, *kind, time.Now()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote calendar to %s\n", *output)
+	return nil
+}
+
+// runServe starts serve mode (calendar feeds and Slack slash commands)
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8765", "listen address")
+	token := fs.String("token", "", "require ?token=<token> on calendar feeds")
+	slackSecret := fs.String("slack-secret", os.Getenv("CCTASKS_SLACK_SECRET"), "Slack signing secret; enables /slack (default $CCTASKS_SLACK_SECRET)")
+	slackProject := fs.String("project", "", "project of Slack commands that name none")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Serving on http://%s (calendar: /calendar.ics, /calendar/<project>.ics)\n", *addr)
+	if *slackSecret != "" {
+		fmt.Fprintf(os.Stderr, "Slack slash commands: http://%s/slack\n", *addr)
+	}
+	opts := server.Options{Token: *token, SlackSecret: *slackSecret, SlackProject: *slackProject}
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.Handler(opts),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
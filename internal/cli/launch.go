@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// LaunchOptions selects what the TUI opens on start
+type LaunchOptions struct {
+	Project string // open this project's task list, skipping the project picker
+	TaskID  string // open this task's detail view (requires Project)
+}
+
+// ParseLaunchArgs parses the TUI arguments: [--project <name>] [--task <id>] [project]
+func ParseLaunchArgs(args []string) (LaunchOptions, error) {
+	var opts LaunchOptions
+
+	fs := flag.NewFlagSet("cctasks", flag.ContinueOnError)
+	fs.StringVar(&opts.Project, "project", "", "project to open")
+	fs.StringVar(&opts.TaskID, "task", "", "task ID to open")
+
+	// Allow flags after the positional project (flag stops at the first non-flag)
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return opts, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	switch {
+	case len(positional) > 1:
+		return opts, fmt.Errorf("usage: cctasks [--project <project>] [--task <id>] [project]")
+	case len(positional) == 1:
+		if opts.Project != "" && opts.Project != positional[0] {
+			return opts, fmt.Errorf("conflicting projects: %s and %s", opts.Project, positional[0])
+		}
+		opts.Project = positional[0]
+	}
+
+	if opts.TaskID != "" {
+		// "#12" as shown in the task list works too
+		id := strings.TrimPrefix(opts.TaskID, "#")
+		if id == "" || strings.ContainsAny(id, "/\\ \t") {
+			return opts, fmt.Errorf("invalid task ID: %q", opts.TaskID)
+		}
+		opts.TaskID = id
+		if opts.Project == "" {
+			return opts, fmt.Errorf("--task requires a project")
+		}
+	}
+	if opts.Project != "" {
+		dir, err := config.GetProjectDir(opts.Project)
+		if err != nil {
+			return opts, err
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return opts, fmt.Errorf("project not found: %s", opts.Project)
+		}
+	}
+	return opts, nil
+}
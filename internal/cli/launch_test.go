@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestParseLaunchArgs(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+	teamDir := t.TempDir()
+	cfg := config.Default()
+	cfg.Roots = []config.RootConfig{{Name: "team", Path: teamDir}}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	for _, dir := range []string{filepath.Join(tasksDir, "api"), filepath.Join(tasksDir, "web"), filepath.Join(teamDir, "web")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    LaunchOptions
+		wantErr string
+	}{
+		{name: "no args", args: nil},
+		{name: "positional", args: []string{"api"}, want: LaunchOptions{Project: "api"}},
+		{name: "project flag", args: []string{"--project", "api"}, want: LaunchOptions{Project: "api"}},
+		{name: "task before positional", args: []string{"--task", "3", "api"}, want: LaunchOptions{Project: "api", TaskID: "3"}},
+		{name: "task after positional", args: []string{"api", "--task", "3"}, want: LaunchOptions{Project: "api", TaskID: "3"}},
+		{name: "task with hash", args: []string{"api", "--task=#3"}, want: LaunchOptions{Project: "api", TaskID: "3"}},
+		{name: "same project twice", args: []string{"--project", "api", "api"}, want: LaunchOptions{Project: "api"}},
+		{name: "root project", args: []string{"team/web", "--task", "1"}, want: LaunchOptions{Project: "team/web", TaskID: "1"}},
+		{name: "conflicting projects", args: []string{"--project", "api", "web"}, wantErr: "conflicting projects"},
+		{name: "two positionals", args: []string{"api", "web"}, wantErr: "usage"},
+		{name: "task without project", args: []string{"--task", "3"}, wantErr: "--task requires a project"},
+		{name: "unknown project", args: []string{"nope"}, wantErr: "project not found: nope"},
+		{name: "unknown project flag", args: []string{"--project", "nope", "--task", "1"}, wantErr: "project not found: nope"},
+		{name: "unknown root", args: []string{"other/web"}, wantErr: "unknown tasks root"},
+		{name: "empty task", args: []string{"api", "--task", "#"}, wantErr: "invalid task ID"},
+		{name: "task with path", args: []string{"api", "--task", "../3"}, wantErr: "invalid task ID"},
+		{name: "task with space", args: []string{"api", "--task", "3 4"}, wantErr: "invalid task ID"},
+		{name: "missing flag value", args: []string{"api", "--task"}, wantErr: "flag needs an argument"},
+		{name: "unknown flag", args: []string{"--verbose", "api"}, wantErr: "flag provided but not defined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLaunchArgs(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseLaunchArgs(%q) error = %v, want %q", tt.args, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLaunchArgs(%q) error = %v", tt.args, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLaunchArgs(%q) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
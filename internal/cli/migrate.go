@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runMigrate upgrades the task files of the given projects (all projects if
+// none) to a newer Claude Code schema version
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "list the migrations without changing any files")
+	to := fs.Int("to", data.CurrentSchema, "schema version to migrate to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	projectNames := fs.Args()
+	if len(projectNames) == 0 {
+		projects, err := data.ListProjects()
+		if err != nil {
+			return err
+		}
+		for _, p := range projects {
+			projectNames = append(projectNames, p.Name)
+		}
+	}
+
+	migrated, current := 0, 0
+	for _, name := range projectNames {
+		plan, err := data.PlanMigration(name, *to)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if len(plan.Steps) == 0 {
+			current++
+			continue
+		}
+		for _, step := range plan.Steps {
+			fmt.Printf("%s: schema %d -> %d: %s\n", name, step.From, step.To, step.Description)
+		}
+		if !*dryRun {
+			if err := plan.Run(); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+		migrated++
+	}
+
+	if *dryRun {
+		fmt.Printf("%d project(s) would be migrated, %d already at schema %d\n", migrated, current, *to)
+	} else {
+		fmt.Printf("%d project(s) migrated (backed up first), %d already at schema %d\n", migrated, current, *to)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runNext prints the suggested next task of a project
+func runNext(args []string) error {
+	fs := flag.NewFlagSet("next", flag.ContinueOnError)
+	projectName := fs.String("project", "", "project name")
+	start := fs.Bool("start", false, "mark the suggested task in_progress")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *projectName == "" && fs.NArg() == 1 {
+		*projectName = fs.Arg(0)
+	}
+	if *projectName == "" {
+		return fmt.Errorf("usage: cctasks next --project <project> [--start]")
+	}
+
+	store, err := data.LoadTasks(*projectName)
+	if err != nil {
+		return err
+	}
+
+	next := data.NextTask(store.Tasks)
+	if next == nil {
+		fmt.Println("No unblocked pending tasks")
+		return nil
+	}
+
+	fmt.Printf("#%s %s\n", data.DisplayID(*next), next.Subject)
+	if priority := data.GetTaskPriority(*next); priority != "" {
+		fmt.Printf("  priority: %s\n", priority)
+	}
+	if n := data.BlockedCount(store.Tasks, next.ID); n > 0 {
+		fmt.Printf("  unblocks: %d task(s)\n", n)
+	}
+
+	if *start {
+		task := store.GetTask(next.ID)
+		task.Status = "in_progress"
+		if err := store.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("Marked #%s in_progress\n", data.DisplayID(*next))
+	}
+	return nil
+}
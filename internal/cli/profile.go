@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// Profile paths set by --cpuprofile and --memprofile
+var cpuProfilePath, memProfilePath string
+
+// StartProfiling starts the CPU profile requested by --cpuprofile. The
+// returned function stops it and writes the heap profile requested by
+// --memprofile; call it before exiting.
+func StartProfiling() (func(), error) {
+	var cpuFile *os.File
+	if cpuProfilePath != "" {
+		f, err := os.Create(cpuProfilePath)
+		if err != nil {
+			return nil, fmt.Errorf("create CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("start CPU profile: %w", err)
+		}
+		cpuFile = f
+	}
+
+	return func() {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			cpuFile.Close()
+		}
+		if memProfilePath != "" {
+			if err := writeHeapProfile(memProfilePath); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}, nil
+}
+
+// writeHeapProfile writes a heap profile of the live objects to path
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create memory profile: %w", err)
+	}
+	defer f.Close()
+	runtime.GC() // up-to-date statistics
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		return fmt.Errorf("write memory profile: %w", err)
+	}
+	return nil
+}
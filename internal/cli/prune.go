@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runPrune deletes backup snapshots outside the retention policy
+func runPrune(args []string) error {
+	fs := flag.NewFlagSet("prune", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "list snapshots that would be deleted without deleting them")
+	keep := fs.Int("keep", config.Current().Backup.KeepLast, "keep at most N snapshots per project (0 = unlimited)")
+	days := fs.Int("days", config.Current().Backup.MaxAgeDays, "delete snapshots older than N days (0 = never)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	projectNames := fs.Args()
+	if len(projectNames) == 0 {
+		projects, err := data.ListProjects()
+		if err != nil {
+			return err
+		}
+		for _, p := range projects {
+			projectNames = append(projectNames, p.Name)
+		}
+	}
+
+	policy := config.BackupConfig{KeepLast: *keep, MaxAgeDays: *days}
+	total := 0
+	for _, name := range projectNames {
+		removed, err := data.PruneSnapshots(name, policy, *dryRun)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		for _, s := range removed {
+			fmt.Printf("%s/%s\n", name, s.Name)
+		}
+		total += len(removed)
+	}
+
+	if *dryRun {
+		fmt.Printf("%d snapshot(s) would be deleted\n", total)
+	} else {
+		fmt.Printf("%d snapshot(s) deleted\n", total)
+	}
+	return nil
+}
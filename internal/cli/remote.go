@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+const remoteUsage = "usage: cctasks remote list|push|restore [--remote name] ..."
+
+// runRemote lists, uploads and restores backup snapshots on the remote
+// targets of the backup config
+func runRemote(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf(remoteUsage)
+	}
+	action := args[0]
+	fs := flag.NewFlagSet("remote "+action, flag.ContinueOnError)
+	label := fs.String("remote", "", "remote to use (by name or type; may be omitted when only one is configured)")
+	snapshot := fs.String("snapshot", "", "snapshot to restore (default: the newest)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	remote, ok := config.Current().Backup.Remote(*label)
+	if !ok {
+		if len(config.Current().Backup.Remotes) == 0 {
+			return fmt.Errorf("no remote backup targets are configured (backup.remotes in the config)")
+		}
+		if *label == "" {
+			return fmt.Errorf("%d remotes are configured; choose one with --remote", len(config.Current().Backup.Remotes))
+		}
+		return fmt.Errorf("no remote %q in the backup config", *label)
+	}
+
+	switch action {
+	case "list":
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: cctasks remote list [--remote name] <project>")
+		}
+		names, err := data.ListRemoteSnapshots(fs.Arg(0), remote)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		fmt.Printf("%d snapshot(s) of %s on %s\n", len(names), fs.Arg(0), remote.Label())
+		return nil
+
+	case "push":
+		projectNames := fs.Args()
+		if len(projectNames) == 0 {
+			projects, err := data.ListProjects()
+			if err != nil {
+				return err
+			}
+			for _, p := range projects {
+				projectNames = append(projectNames, p.Name)
+			}
+		}
+		for _, name := range projectNames {
+			pushed, err := data.PushLatestSnapshot(name, remote)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			fmt.Printf("%s: uploaded %s to %s\n", name, pushed.Name, remote.Label())
+		}
+		return nil
+
+	case "restore":
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: cctasks remote restore [--remote name] [--snapshot NAME] <project>")
+		}
+		name, err := data.RestoreFromRemote(fs.Arg(0), remote, *snapshot)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s restored from %s on %s (the previous files were backed up first)\n", fs.Arg(0), name, remote.Label())
+		return nil
+	}
+	return fmt.Errorf(remoteUsage)
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/jss826/cctasks/internal/model"
+)
+
+// runRender prints a project's task list view once, without the alt screen
+// or input, for tmux popups, cron mail or CI logs
+func runRender(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	projectName := fs.String("project", "", "project name")
+	width := fs.Int("width", 0, "output width (default: terminal width, or 80)")
+	height := fs.Int("height", 0, "output height (default: fit all tasks)")
+	completed := fs.Bool("completed", false, "include completed tasks")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *projectName == "" && fs.NArg() == 1 {
+		*projectName = fs.Arg(0)
+	}
+	if *projectName == "" {
+		return fmt.Errorf("usage: cctasks render --project <project> [--width N] [--height N] [--completed]")
+	}
+
+	if *width <= 0 {
+		*width = 80
+		if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+			*width = w
+		}
+	}
+
+	out, err := model.RenderTaskList(*projectName, *width, *height, *completed)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+	return nil
+}
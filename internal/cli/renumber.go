@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runRenumber renumbers a project's task IDs sequentially
+func runRenumber(args []string) error {
+	fs := flag.NewFlagSet("renumber", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "show the new IDs without changing any files")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cctasks renumber [--dry-run] <project>")
+	}
+	projectName := fs.Arg(0)
+
+	store, err := data.LoadTasks(projectName)
+	if err != nil {
+		return err
+	}
+	if len(store.Problems) > 0 {
+		return fmt.Errorf("%s has %d problem(s); run 'cctasks validate %s' first", projectName, len(store.Problems), projectName)
+	}
+
+	plan := data.RenumberPlan(store.Tasks)
+	for _, c := range plan {
+		fmt.Printf("#%s -> #%s\n", c.From, c.To)
+	}
+
+	if *dryRun {
+		fmt.Printf("%d task(s) would be renumbered\n", len(plan))
+		return nil
+	}
+	if err := store.Renumber(plan); err != nil {
+		return err
+	}
+	if state, err := config.LoadState(); err == nil {
+		if ps, ok := state.Projects[projectName]; ok {
+			ids := make(map[string]string, len(plan))
+			for _, c := range plan {
+				ids[c.From] = c.To
+			}
+			ps.RenameTasks(ids)
+			state.Save() // best-effort, like import
+		}
+	}
+	fmt.Printf("%d task(s) renumbered\n", len(plan))
+	return nil
+}
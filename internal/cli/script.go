@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runScript replays an automation script against the task stores without the TUI
+func runScript(args []string) error {
+	fs := flag.NewFlagSet("script", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "show what would change without saving or exporting")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cctasks script [--dry-run] <file>")
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	steps, err := data.ParseScript(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+	run := data.ScriptRun{Out: os.Stdout, DryRun: *dryRun}
+	if err := run.Run(steps); err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runStatus prints a one-line project summary for tmux status lines and
+// shell prompts
+func runStatus(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	projectName := fs.String("project", "", "project name (default: the project open when cctasks last quit)")
+	format := fs.String("format", data.DefaultStatusFormat, "line template, e.g. '#[fg=yellow]{in_progress} {subject}'")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *projectName == "" && fs.NArg() == 1 {
+		*projectName = fs.Arg(0)
+	}
+	if *projectName == "" {
+		if state, err := config.LoadState(); err == nil {
+			*projectName = state.LastProject
+		}
+	}
+	if *projectName == "" {
+		return fmt.Errorf("usage: cctasks status --project <project> [--format template]")
+	}
+
+	store, err := data.LoadTasks(*projectName)
+	if err != nil {
+		return err
+	}
+	history, _ := store.History() // without history, the first in_progress task is active
+	active := data.LatestInProgress(store.Tasks, history)
+	line, err := data.FormatStatusLine(*format, data.StatusValues(*projectName, store.Tasks, active))
+	if err != nil {
+		return err
+	}
+	fmt.Println(line)
+	return nil
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// runValidate checks task files of the given projects (all projects if none)
+func runValidate(args []string) error {
+	projectNames := args
+	if len(projectNames) == 0 {
+		projects, err := data.ListProjects()
+		if err != nil {
+			return err
+		}
+		for _, p := range projects {
+			projectNames = append(projectNames, p.Name)
+		}
+	}
+
+	total := 0
+	for _, name := range projectNames {
+		problems, err := data.ValidateProject(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		for _, p := range problems {
+			fmt.Printf("%s/%s\n", name, p)
+		}
+		total += len(problems)
+	}
+
+	if total > 0 {
+		return fmt.Errorf("%d problem(s) found", total)
+	}
+	fmt.Printf("%d project(s) OK\n", len(projectNames))
+	return nil
+}
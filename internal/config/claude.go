@@ -0,0 +1,77 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TaskListEnv is the Claude Code environment variable naming a project's task list
+const TaskListEnv = "CLAUDE_CODE_TASK_LIST_ID"
+
+// ClaudeSettingsPath returns <repoDir>/.claude/settings.local.json
+func ClaudeSettingsPath(repoDir string) string {
+	return filepath.Join(repoDir, ".claude", "settings.local.json")
+}
+
+// ValidateProjectName checks that name can be used as a task list ID and directory
+func ValidateProjectName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("enter a project name")
+	case name == "." || name == "..":
+		return fmt.Errorf("%q is not a valid project name", name)
+	case strings.ContainsAny(name, `/\`):
+		return fmt.Errorf("project name must not contain / or \\")
+	case strings.TrimSpace(name) != name:
+		return fmt.Errorf("project name must not start or end with spaces")
+	}
+	return nil
+}
+
+// TaskListSnippet returns the settings.local.json snippet that points
+// Claude Code at the project's task list
+func TaskListSnippet(project string) string {
+	snippet := map[string]interface{}{"env": map[string]string{TaskListEnv: project}}
+	b, _ := json.MarshalIndent(snippet, "", "  ")
+	return string(b)
+}
+
+// WriteTaskListID sets the task list ID in <repoDir>/.claude/settings.local.json,
+// keeping the file's other settings, and returns the file's path. An invalid
+// existing file is left untouched.
+func WriteTaskListID(repoDir, project string) (string, error) {
+	path := ClaudeSettingsPath(repoDir)
+	settings := map[string]interface{}{}
+	if b, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(b, &settings); err != nil {
+			return path, fmt.Errorf("%s is not valid JSON: %w", path, err)
+		}
+		if settings == nil {
+			settings = map[string]interface{}{}
+		}
+	} else if !os.IsNotExist(err) {
+		return path, err
+	}
+
+	env, ok := settings["env"].(map[string]interface{})
+	if !ok {
+		if _, exists := settings["env"]; exists {
+			return path, fmt.Errorf(`"env" in %s is not an object`, path)
+		}
+		env = map[string]interface{}{}
+	}
+	env[TaskListEnv] = project
+	settings["env"] = env
+
+	b, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return path, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return path, err
+	}
+	return path, os.WriteFile(path, append(b, '\n'), 0644)
+}
@@ -0,0 +1,345 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds user settings loaded from ~/.config/cctasks/config.json
+type Config struct {
+	TasksDir      string                 `json:"tasksDir"` // primary tasks directory (default ~/.claude/tasks)
+	Roots         []RootConfig           `json:"roots"`    // additional tasks directories, e.g. a team-shared one
+	Backup        BackupConfig           `json:"backup"`
+	Git           GitConfig              `json:"git"`
+	Trash         TrashConfig            `json:"trash"`
+	Estimates     EstimatesConfig        `json:"estimates"`
+	IDs           IDsConfig              `json:"ids"`
+	TaskList      TaskListConfig         `json:"taskList"`
+	Templates     TemplatesConfig        `json:"templates"`
+	Webhooks      []WebhookConfig        `json:"webhooks"`
+	Hooks         []HookConfig           `json:"hooks"`
+	RequireReason []string               `json:"requireReason"` // status changes that need a reason: "reopen"
+	Review        ReviewConfig           `json:"review"`
+	Collaboration CollaborationConfig    `json:"collaboration"`
+	AgentOwners   []string               `json:"agentOwners"` // owner patterns of agent tasks (default "claude*", "agent*")
+	Owners        map[string]OwnerConfig `json:"owners"`      // badges of owners by name (others get initials and a color from their name)
+	Roster        []string               `json:"roster"`      // owners offered first when assigning a task (A), in order
+	Language      string                 `json:"language"`    // UI language: "en", "ja", or "" to follow $LANG
+	ASCII         bool                   `json:"ascii"`       // draw ASCII instead of Unicode glyphs (○ ● ✓ ▼ █)
+	LowPower      bool                   `json:"lowPower"`    // poll less often to save battery
+}
+
+// RootConfig is an additional directory of projects shown in its own section
+type RootConfig struct {
+	Name string `json:"name"` // section label and project name prefix ("<name>/<project>")
+	Path string `json:"path"` // directory; on the host with ssh
+	SSH  string `json:"ssh"`  // "[user@]host" to browse Path on a remote host, read-only
+	Git  bool   `json:"git"`  // also auto-commit this root when git history is enabled
+}
+
+// BackupConfig controls backup snapshots and their retention
+type BackupConfig struct {
+	KeepLast   int            `json:"keepLast"`   // keep at most N snapshots per project (0 = unlimited)
+	MaxAgeDays int            `json:"maxAgeDays"` // delete snapshots older than N days (0 = never)
+	Remotes    []RemoteConfig `json:"remotes"`    // also upload new snapshots to these targets
+}
+
+// Remote backup target types
+const (
+	RemoteS3     = "s3"     // S3-compatible object storage
+	RemoteWebDAV = "webdav" // WebDAV server
+	RemoteRsync  = "rsync"  // rsync over ssh
+)
+
+// RemoteConfig is a remote target new backup snapshots are uploaded to, as
+// <project>/<timestamp>/ under its location
+type RemoteConfig struct {
+	Name     string   `json:"name"`     // label used by "cctasks remote" (default: the type)
+	Type     string   `json:"type"`     // "s3", "webdav" or "rsync"
+	URL      string   `json:"url"`      // s3: endpoint and bucket (https://host/bucket); webdav: collection URL
+	Prefix   string   `json:"prefix"`   // s3: key prefix inside the bucket
+	Region   string   `json:"region"`   // s3: signing region (default us-east-1)
+	User     string   `json:"user"`     // s3: access key ($AWS_ACCESS_KEY_ID); webdav: user name
+	Secret   string   `json:"secret"`   // s3: secret key ($AWS_SECRET_ACCESS_KEY); webdav: password ($CCTASKS_WEBDAV_PASSWORD)
+	Target   string   `json:"target"`   // rsync: destination such as user@host:backups/cctasks
+	Projects []string `json:"projects"` // only back up these projects (empty = all)
+}
+
+// Label returns the name the remote is referred to by
+func (r RemoteConfig) Label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Type
+}
+
+// Credentials returns the user and secret of the remote, falling back to
+// the environment variables of its type
+func (r RemoteConfig) Credentials() (user, secret string) {
+	user, secret = r.User, r.Secret
+	switch r.Type {
+	case RemoteS3:
+		if user == "" {
+			user = os.Getenv("AWS_ACCESS_KEY_ID")
+		}
+		if secret == "" {
+			secret = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		}
+	case RemoteWebDAV:
+		if secret == "" {
+			secret = os.Getenv("CCTASKS_WEBDAV_PASSWORD")
+		}
+	}
+	return user, secret
+}
+
+// Remote returns the remote target with a label, or the only one configured
+// when label is ""
+func (c BackupConfig) Remote(label string) (RemoteConfig, bool) {
+	if label == "" {
+		if len(c.Remotes) == 1 {
+			return c.Remotes[0], true
+		}
+		return RemoteConfig{}, false
+	}
+	for _, r := range c.Remotes {
+		if r.Label() == label {
+			return r, true
+		}
+	}
+	return RemoteConfig{}, false
+}
+
+// GitConfig controls git-backed history of the tasks directory
+type GitConfig struct {
+	Enabled bool `json:"enabled"` // auto-commit ~/.claude/tasks after each save
+}
+
+// TrashConfig controls the trash of deleted tasks
+type TrashConfig struct {
+	RetentionDays int `json:"retentionDays"` // purge trashed tasks after N days (0 = never)
+}
+
+// EstimatesConfig controls how task estimates are displayed and scheduled
+type EstimatesConfig struct {
+	Unit        string  `json:"unit"`        // suffix shown after estimates, e.g. "h" or "pt"
+	HoursPerDay float64 `json:"hoursPerDay"` // timeline length of an estimate in hours (unit "h" only)
+}
+
+// IDsConfig controls how IDs of new tasks are generated
+type IDsConfig struct {
+	Format string `json:"format"` // "sequential" (default) or "uuid" with a short sequential alias
+}
+
+// TaskListConfig controls the task list layout
+type TaskListConfig struct {
+	Columns       []string          `json:"columns"`       // visible columns in order: id, subject, group, owner, due, status (empty = defaults)
+	Groups        string            `json:"groups"`        // groups when a project opens: "collapsed" (default) or "expanded"
+	ProjectGroups map[string]string `json:"projectGroups"` // per-project override of groups
+	AutoAdvance   bool              `json:"autoAdvance"`   // after completing a task, move to the next unblocked open one
+	StaleAfter    string            `json:"staleAfter"`    // in_progress tasks untouched this long are stale: "3d" (default), "36h", "0" = never
+	SidebarFrom   int               `json:"sidebarFrom"`   // terminal width from which the project sidebar is shown (0 = 160, -1 = never)
+}
+
+// defaultSidebarFrom is the terminal width from which the project sidebar is shown
+const defaultSidebarFrom = 160
+
+// SidebarMinWidth returns the terminal width from which the task list shows
+// the project sidebar, or 0 when it is never shown
+func (c TaskListConfig) SidebarMinWidth() int {
+	switch {
+	case c.SidebarFrom < 0:
+		return 0
+	case c.SidebarFrom == 0:
+		return defaultSidebarFrom
+	}
+	return c.SidebarFrom
+}
+
+// defaultStaleAfter is how long an in_progress task may go untouched
+const defaultStaleAfter = 3 * 24 * time.Hour
+
+// StaleDuration returns how long an in_progress task may go untouched before
+// it is stale, or 0 when stale tasks are not flagged. StaleAfter takes a Go
+// duration or a number of days ("3d"); an invalid value falls back to the
+// default.
+func (c TaskListConfig) StaleDuration() time.Duration {
+	value := strings.TrimSpace(c.StaleAfter)
+	switch {
+	case value == "":
+		return defaultStaleAfter
+	case value == "0":
+		return 0
+	case strings.HasSuffix(value, "d"):
+		if days, err := strconv.ParseFloat(strings.TrimSuffix(value, "d"), 64); err == nil && days >= 0 {
+			return time.Duration(days * float64(24*time.Hour))
+		}
+	default:
+		if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return defaultStaleAfter
+}
+
+// GroupsCollapsed reports whether a project's groups start collapsed
+func (c TaskListConfig) GroupsCollapsed(projectName string) bool {
+	groups := c.Groups
+	if g, ok := c.ProjectGroups[projectName]; ok {
+		groups = g
+	}
+	return groups != "expanded"
+}
+
+// TemplatesConfig holds Go text/templates replacing the built-in task layouts
+type TemplatesConfig struct {
+	Row       string `json:"row"`       // one task list row (empty = columns from taskList)
+	Detail    string `json:"detail"`    // the fields above the detail view's description (empty = built-in)
+	Changelog string `json:"changelog"` // one entry of cctasks changelog (empty = "- {{.Subject}} (#{{.ID}})")
+}
+
+// WebhookConfig is a URL notified when tasks change
+type WebhookConfig struct {
+	URL      string   `json:"url"`
+	Events   []string `json:"events"`   // "created", "completed", "blocked", "assigned" (empty = all but "assigned")
+	Projects []string `json:"projects"` // only notify for these projects (empty = all)
+}
+
+// ReviewConfig controls the review workflow (the needs_review status)
+type ReviewConfig struct {
+	Enabled  bool   `json:"enabled"`  // route tasks completed outside cctasks to needs_review
+	Reviewer string `json:"reviewer"` // name recorded on approvals (default $USER)
+}
+
+// OwnerConfig is the badge of a task owner
+type OwnerConfig struct {
+	Initials string `json:"initials"` // up to 2 characters (default: from the name)
+	Color    string `json:"color"`    // badge color, e.g. "#3b82f6" (default: picked from the name)
+}
+
+// CollaborationConfig turns on collaboration mode for projects shared with
+// others through a synced drive (Dropbox, NFS): changes are signed with the
+// user's name, others' recent changes are shown, the project is polled for
+// changes and saving merges with changes made on disk meanwhile
+type CollaborationConfig struct {
+	Projects []string `json:"projects"` // projects in collaboration mode ("*" = all)
+	User     string   `json:"user"`     // name shown to the others on your changes (default $USER)
+	Poll     string   `json:"poll"`     // how often to check for changes, e.g. "2s" (default)
+}
+
+// defaultCollaborationPoll is how often a shared project is checked for changes
+const defaultCollaborationPoll = 2 * time.Second
+
+// Enabled reports whether a project is in collaboration mode
+func (c CollaborationConfig) Enabled(projectName string) bool {
+	for _, name := range c.Projects {
+		if name == "*" || name == projectName {
+			return true
+		}
+	}
+	return false
+}
+
+// PollInterval returns how often a shared project is checked for changes;
+// an invalid or too short Poll falls back to the default
+func (c CollaborationConfig) PollInterval() time.Duration {
+	if d, err := time.ParseDuration(strings.TrimSpace(c.Poll)); err == nil && d >= 500*time.Millisecond {
+		return d
+	}
+	return defaultCollaborationPoll
+}
+
+// HookConfig is a shell command run when tasks change or a project is opened
+type HookConfig struct {
+	Command  string   `json:"command"`  // run with sh -c (cmd /C on Windows); the event JSON is on stdin
+	Events   []string `json:"events"`   // "created", "completed", "blocked", "assigned", "opened" (empty = all but "assigned")
+	Projects []string `json:"projects"` // only run for these projects (empty = all)
+}
+
+// current is the config used by the running application
+var current *Config
+
+// Default returns the built-in default configuration
+func Default() *Config {
+	return &Config{
+		Backup: BackupConfig{
+			KeepLast:   50,
+			MaxAgeDays: 30,
+		},
+		Trash: TrashConfig{
+			RetentionDays: 30,
+		},
+		Estimates: EstimatesConfig{
+			Unit:        "h",
+			HoursPerDay: 8,
+		},
+	}
+}
+
+// GetConfigDir returns the path to ~/.config/cctasks/
+func GetConfigDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".config", "cctasks"), nil
+}
+
+// GetConfigFilePath returns the path to ~/.config/cctasks/config.json
+func GetConfigFilePath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "config.json"), nil
+}
+
+// GetLogFilePath returns the path to ~/.config/cctasks/log/cctasks.log
+func GetLogFilePath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "log", "cctasks.log"), nil
+}
+
+// Load reads the config file, falling back to defaults for missing values
+func Load() (*Config, error) {
+	cfg := Default()
+
+	path, err := GetConfigFilePath()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return Default(), err
+	}
+	return cfg, nil
+}
+
+// Current returns the active config, loading it on first use
+func Current() *Config {
+	if current == nil {
+		cfg, _ := Load() // invalid config falls back to defaults
+		current = cfg
+	}
+	return current
+}
+
+// SetCurrent replaces the active config (used by flags and tests)
+func SetCurrent(cfg *Config) {
+	current = cfg
+}
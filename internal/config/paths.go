@@ -1,12 +1,32 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
-// GetTasksDir returns the path to ~/.claude/tasks/
+// TasksDirEnv is the environment variable overriding the tasks directory
+const TasksDirEnv = "CCTASKS_DIR"
+
+// tasksDirOverride is the tasks directory given on the command line (--dir)
+var tasksDirOverride string
+
+// SetTasksDirOverride sets the tasks directory from the command line,
+// taking precedence over CCTASKS_DIR and the config file
+func SetTasksDirOverride(dir string) {
+	tasksDirOverride = dir
+}
+
+// GetTasksDir returns the primary tasks directory: --dir, then $CCTASKS_DIR,
+// then "tasksDir" in the config file, then ~/.claude/tasks/
 func GetTasksDir() (string, error) {
+	for _, dir := range []string{tasksDirOverride, os.Getenv(TasksDirEnv), Current().TasksDir} {
+		if dir != "" {
+			return expandHome(dir)
+		}
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -14,26 +34,125 @@ func GetTasksDir() (string, error) {
 	return filepath.Join(homeDir, ".claude", "tasks"), nil
 }
 
-// GetBackupDir returns the path to ~/.claude/tasks_backup/
-func GetBackupDir() (string, error) {
+// Root is a directory containing project directories
+type Root struct {
+	Name string // "" for the primary tasks directory
+	Path string // local directory; for ssh roots the mirror of the remote one
+	SSH  string // "[user@]host" of a remote root ("" for local roots)
+	// Remote is the directory on the host of an ssh root
+	Remote string
+}
+
+// Location describes where the root's projects are, "host:path" for ssh roots
+func (r Root) Location() string {
+	if r.SSH != "" {
+		return r.SSH + ":" + r.Remote
+	}
+	return r.Path
+}
+
+// GetRoots returns the primary tasks directory followed by the additional
+// roots from the config file. Projects in additional roots are named
+// "<root>/<project>".
+func GetRoots() ([]Root, error) {
+	primary, err := GetTasksDir()
+	if err != nil {
+		return nil, err
+	}
+	roots := []Root{{Path: primary}}
+	for _, rc := range Current().Roots {
+		if rc.Name == "" || rc.Path == "" || strings.Contains(rc.Name, "/") {
+			continue // unusable entry
+		}
+		if rc.SSH != "" {
+			mirror, err := GetSSHMirrorDir(rc.Name)
+			if err != nil {
+				return nil, err
+			}
+			roots = append(roots, Root{Name: rc.Name, Path: mirror, SSH: rc.SSH, Remote: rc.Path})
+			continue
+		}
+		path, err := expandHome(rc.Path)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, Root{Name: rc.Name, Path: path})
+	}
+	return roots, nil
+}
+
+// GetSSHMirrorDir returns the path to ~/.config/cctasks/ssh/<root>/, the
+// local copy of an ssh root's tasks directory
+func GetSSHMirrorDir(rootName string) (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "ssh", rootName), nil
+}
+
+// SplitProjectName splits "<root>/<project>" into its root and project parts;
+// names without a root prefix belong to the primary tasks directory
+func SplitProjectName(projectName string) (root, name string) {
+	if i := strings.Index(projectName, "/"); i > 0 {
+		return projectName[:i], projectName[i+1:]
+	}
+	return "", projectName
+}
+
+// expandHome expands a leading "~" to the user's home directory
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path, nil
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(homeDir, ".claude", "tasks_backup"), nil
+	return filepath.Join(homeDir, path[1:]), nil
+}
+
+// GetBackupDir returns the backup directory next to the primary tasks
+// directory: ~/.claude/tasks_backup/ by default
+func GetBackupDir() (string, error) {
+	tasksDir, err := GetTasksDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Clean(tasksDir) + "_backup", nil
 }
 
-// GetBackupProjectDir returns the path to a specific project's backup directory
+// GetBackupProjectDir returns the path to a specific project's backup
+// directory. Projects on additional roots are backed up as "<root>@<project>",
+// apart from a primary project named like the root.
 func GetBackupProjectDir(projectName string) (string, error) {
 	backupDir, err := GetBackupDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(backupDir, projectName), nil
+	rootName, name := SplitProjectName(projectName)
+	if rootName == "" {
+		return filepath.Join(backupDir, name), nil
+	}
+	return filepath.Join(backupDir, rootName+"@"+name), nil
 }
 
 // GetProjectDir returns the path to a specific project's tasks directory
 func GetProjectDir(projectName string) (string, error) {
+	rootName, name := SplitProjectName(projectName)
+	if rootName != "" {
+		roots, err := GetRoots()
+		if err != nil {
+			return "", err
+		}
+		for _, root := range roots {
+			if root.Name == rootName {
+				return filepath.Join(root.Path, name), nil
+			}
+		}
+		return "", fmt.Errorf("unknown tasks root: %s", rootName)
+	}
+
 	tasksDir, err := GetTasksDir()
 	if err != nil {
 		return "", err
@@ -58,3 +177,12 @@ func GetGroupsFilePath(projectName string) (string, error) {
 	}
 	return filepath.Join(projectDir, "_groups.json"), nil
 }
+
+// GetMilestonesFilePath returns the path to the _milestones.json file for a project
+func GetMilestonesFilePath(projectName string) (string, error) {
+	projectDir, err := GetProjectDir(projectName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(projectDir, "_milestones.json"), nil
+}
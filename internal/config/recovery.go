@@ -0,0 +1,92 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// EditRecovery is the content of an edit form saved when cctasks crashed,
+// offered for restoring on the next launch
+type EditRecovery struct {
+	Project     string    `json:"project"`
+	TaskID      string    `json:"taskId,omitempty"` // empty for a new task
+	IsNew       bool      `json:"isNew,omitempty"`
+	Subject     string    `json:"subject"`
+	Description string    `json:"description,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	Group       string    `json:"group,omitempty"`
+	Owner       string    `json:"owner,omitempty"`
+	Blocks      string    `json:"blocks,omitempty"`    // as typed in the form
+	BlockedBy   string    `json:"blockedBy,omitempty"` // as typed in the form
+	Estimate    string    `json:"estimate,omitempty"`
+	Milestone   string    `json:"milestone,omitempty"`
+	SavedAt     time.Time `json:"savedAt"`
+}
+
+// GetCrashDir returns the path to ~/.config/cctasks/crash/
+func GetCrashDir() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "crash"), nil
+}
+
+// GetRecoveryFilePath returns the path to ~/.config/cctasks/crash/recovery.json
+func GetRecoveryFilePath() (string, error) {
+	crashDir, err := GetCrashDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(crashDir, "recovery.json"), nil
+}
+
+// LoadRecovery reads the saved edit form; it returns nil if there is none
+func LoadRecovery() (*EditRecovery, error) {
+	path, err := GetRecoveryFilePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var r EditRecovery
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Save writes the edit form to the recovery file
+func (r *EditRecovery) Save() error {
+	path, err := GetRecoveryFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ClearRecovery deletes the recovery file once it was restored or discarded
+func ClearRecovery() error {
+	path, err := GetRecoveryFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
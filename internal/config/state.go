@@ -0,0 +1,186 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// RecentLimit is the number of tasks kept in each recent list
+const RecentLimit = 10
+
+// State holds UI state persisted between runs in ~/.config/cctasks/state.json
+type State struct {
+	LastProject string                   `json:"lastProject,omitempty"` // project open when cctasks last quit
+	ProjectSort string                   `json:"projectSort,omitempty"` // project list order: "", "modified" or "count"
+	ProjectAge  int                      `json:"projectAge,omitempty"`  // hide projects untouched for more than N days (0 = show all)
+	Projects    map[string]*ProjectState `json:"projects,omitempty"`
+	path        string                   // file the state was loaded from
+}
+
+// ProjectState holds per-project UI state
+type ProjectState struct {
+	Favorite     bool           `json:"favorite,omitempty"`     // starred on the project list
+	Archived     bool           `json:"archived,omitempty"`     // hidden from the project list
+	RecentViewed []string       `json:"recentViewed,omitempty"` // task IDs, most recent first
+	TaskList     *TaskListState `json:"taskList,omitempty"`     // task list view as last left
+
+	// Unparsable task files no longer listed as problems, by file name, with
+	// the content hash they were ignored with
+	IgnoredFiles map[string]string `json:"ignoredFiles,omitempty"`
+}
+
+// TaskListState is the task list's cursor, filters and layout
+type TaskListState struct {
+	CursorTaskID    string          `json:"cursorTaskId,omitempty"`
+	CursorGroup     string          `json:"cursorGroup,omitempty"` // set when the cursor was on a group header
+	StatusFilter    string          `json:"statusFilter,omitempty"`
+	GroupFilter     string          `json:"groupFilter,omitempty"`
+	AuthorFilter    string          `json:"authorFilter,omitempty"` // "", "agent" or "human"
+	Milestone       string          `json:"milestone,omitempty"`
+	Search          string          `json:"search,omitempty"`
+	ShowCompleted   bool            `json:"showCompleted,omitempty"`
+	SortMode        string          `json:"sortMode,omitempty"`
+	Density         string          `json:"density,omitempty"`     // "", "compact" or "comfortable"
+	ShowPreview     bool            `json:"showPreview,omitempty"` // description previews under task rows
+	CollapsedGroups map[string]bool `json:"collapsedGroups,omitempty"`
+}
+
+// GetStateFilePath returns the path to ~/.config/cctasks/state.json
+func GetStateFilePath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "state.json"), nil
+}
+
+// LoadState reads the state file; a missing or invalid file yields empty state
+func LoadState() (*State, error) {
+	path, err := GetStateFilePath()
+	if err != nil {
+		return &State{}, err
+	}
+	return loadStateFile(path)
+}
+
+// loadStateFile reads state from path
+func loadStateFile(path string) (*State, error) {
+	state := &State{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return &State{path: path}, err
+	}
+	return state, nil
+}
+
+// Save writes the state file
+func (s *State) Save() error {
+	path := s.path
+	if path == "" {
+		var err error
+		if path, err = GetStateFilePath(); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Project returns the state of a project, creating it if needed
+func (s *State) Project(name string) *ProjectState {
+	if s.Projects == nil {
+		s.Projects = make(map[string]*ProjectState)
+	}
+	ps, ok := s.Projects[name]
+	if !ok {
+		ps = &ProjectState{}
+		s.Projects[name] = ps
+	}
+	return ps
+}
+
+// IsFavorite reports whether a project is starred, without creating its state
+func (s *State) IsFavorite(name string) bool {
+	ps, ok := s.Projects[name]
+	return ok && ps.Favorite
+}
+
+// IsArchived reports whether a project is archived, without creating its state
+func (s *State) IsArchived(name string) bool {
+	ps, ok := s.Projects[name]
+	return ok && ps.Archived
+}
+
+// MergeProject applies per-project settings imported from an archive. With
+// overwrite they replace the local settings; otherwise the favorite and
+// archived flags are added and missing list state is filled in.
+func (s *State) MergeProject(name string, imported ProjectState, overwrite bool) {
+	ps := s.Project(name)
+	if overwrite {
+		*ps = imported
+		return
+	}
+	ps.Favorite = ps.Favorite || imported.Favorite
+	ps.Archived = ps.Archived || imported.Archived
+	if len(ps.RecentViewed) == 0 {
+		ps.RecentViewed = imported.RecentViewed
+	}
+	if ps.TaskList == nil {
+		ps.TaskList = imported.TaskList
+	}
+	if ps.IgnoredFiles == nil {
+		ps.IgnoredFiles = imported.IgnoredFiles
+	}
+}
+
+// IgnoreFile stops listing an unparsable task file until its content changes
+func (p *ProjectState) IgnoreFile(name, hash string) {
+	if p.IgnoredFiles == nil {
+		p.IgnoredFiles = make(map[string]string)
+	}
+	p.IgnoredFiles[name] = hash
+}
+
+// RenameTasks follows renumbered task IDs (old ID to new) in the recently
+// viewed list and the task list cursor
+func (p *ProjectState) RenameTasks(ids map[string]string) {
+	for i, id := range p.RecentViewed {
+		if newID, ok := ids[id]; ok {
+			p.RecentViewed[i] = newID
+		}
+	}
+	if p.TaskList != nil {
+		if newID, ok := ids[p.TaskList.CursorTaskID]; ok {
+			p.TaskList.CursorTaskID = newID
+		}
+	}
+}
+
+// AddRecentViewed moves a task ID to the front of the recently viewed list
+func (p *ProjectState) AddRecentViewed(id string) {
+	p.RecentViewed = pushRecent(p.RecentViewed, id)
+}
+
+// pushRecent moves id to the front of list, dropping duplicates and entries beyond RecentLimit
+func pushRecent(list []string, id string) []string {
+	result := []string{id}
+	for _, existing := range list {
+		if existing != id && len(result) < RecentLimit {
+			result = append(result, existing)
+		}
+	}
+	return result
+}
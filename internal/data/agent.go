@@ -0,0 +1,35 @@
+package data
+
+import (
+	"path"
+	"strings"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// defaultAgentOwners are the owner patterns of agent tasks when the config
+// sets none
+var defaultAgentOwners = []string{"claude*", "agent*"}
+
+// IsAgentTask reports whether a task is managed by an agent (Claude Code)
+// rather than a person: metadata "agent" decides when set, otherwise an
+// owner matching the agent owner patterns or an activeForm (which Claude
+// Code fills in and cctasks never does) marks an agent task
+func IsAgentTask(task Task) bool {
+	if agent, ok := task.Metadata["agent"].(bool); ok {
+		return agent
+	}
+	if task.Owner != "" {
+		patterns := config.Current().AgentOwners
+		if len(patterns) == 0 {
+			patterns = defaultAgentOwners
+		}
+		owner := strings.ToLower(task.Owner)
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(strings.ToLower(pattern), owner); ok {
+				return true
+			}
+		}
+	}
+	return task.ActiveForm != ""
+}
@@ -0,0 +1,36 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+func TestIsAgentTask(t *testing.T) {
+	cfg := config.Default()
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	tests := []struct {
+		name string
+		task Task
+		want bool
+	}{
+		{"plain task", Task{Subject: "Write docs"}, false},
+		{"human owner", Task{Owner: "alice"}, false},
+		{"agent owner", Task{Owner: "Claude"}, true},
+		{"activeForm", Task{Owner: "alice", ActiveForm: "Writing docs"}, true},
+		{"metadata agent", Task{Metadata: map[string]interface{}{"agent": true}}, true},
+		{"metadata human", Task{ActiveForm: "Writing docs", Metadata: map[string]interface{}{"agent": false}}, false},
+	}
+	for _, tt := range tests {
+		if got := IsAgentTask(tt.task); got != tt.want {
+			t.Errorf("%s: IsAgentTask = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	cfg.AgentOwners = []string{"researcher", "tester-*"}
+	if IsAgentTask(Task{Owner: "claude"}) || !IsAgentTask(Task{Owner: "tester-2"}) {
+		t.Error("configured owner patterns should replace the defaults")
+	}
+}
@@ -0,0 +1,596 @@
+package data
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// Project archive formats
+const (
+	FormatJSONL = "jsonl"
+	FormatTarGz = "tar.gz"
+)
+
+// archiveVersion is the version of the archive layout written by ExportProject
+const archiveVersion = 1
+
+// Import strategies for tasks, groups and milestones that exist on both sides
+const (
+	ImportKeep      = "keep"      // keep the local version
+	ImportOverwrite = "overwrite" // take the archived version
+	ImportReplace   = "replace"   // make the project exactly the archive (local-only tasks go to the trash)
+)
+
+// ImportStrategies lists the valid import strategies
+var ImportStrategies = []string{ImportKeep, ImportOverwrite, ImportReplace}
+
+// ProjectArchive is the full content of a project for moving between machines
+type ProjectArchive struct {
+	Project    string
+	ExportedAt time.Time
+	Tasks      []json.RawMessage // task files as written on disk
+	Groups     []TaskGroup
+	Milestones []Milestone
+	History    []HistoryEntry
+	Settings   *config.ProjectState // per-project UI settings from state.json
+}
+
+// archiveRecord is one line of a JSONL archive
+type archiveRecord struct {
+	Type       string               `json:"type"` // project, task, group, milestone, history, settings
+	Version    int                  `json:"version,omitempty"`
+	Project    string               `json:"project,omitempty"`
+	ExportedAt *time.Time           `json:"exportedAt,omitempty"`
+	Task       json.RawMessage      `json:"task,omitempty"`
+	Group      *TaskGroup           `json:"group,omitempty"`
+	Milestone  *Milestone           `json:"milestone,omitempty"`
+	History    *HistoryEntry        `json:"history,omitempty"`
+	Settings   *config.ProjectState `json:"settings,omitempty"`
+}
+
+// archiveManifest is manifest.json of a tar.gz archive
+type archiveManifest struct {
+	Version    int       `json:"version"`
+	Project    string    `json:"project"`
+	ExportedAt time.Time `json:"exportedAt"`
+}
+
+// ArchiveFormatForPath returns the archive format of a file name, or ""
+func ArchiveFormatForPath(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".jsonl"):
+		return FormatJSONL
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return FormatTarGz
+	}
+	return ""
+}
+
+// ExportProject collects a project's tasks, groups, milestones and history
+func ExportProject(projectName string) (*ProjectArchive, error) {
+	store, err := LoadTasks(projectName)
+	if err != nil {
+		return nil, err
+	}
+	a := &ProjectArchive{Project: projectName, ExportedAt: time.Now()}
+	for _, task := range store.Tasks {
+		raw, err := store.RawTaskJSON(task.ID)
+		if err != nil {
+			return nil, err
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			return nil, err
+		}
+		a.Tasks = append(a.Tasks, compact.Bytes())
+	}
+
+	groups, err := LoadGroups(projectName)
+	if err != nil {
+		return nil, err
+	}
+	a.Groups = groups.Groups
+	milestones, err := LoadMilestones(projectName)
+	if err != nil {
+		return nil, err
+	}
+	a.Milestones = milestones.Milestones
+	if a.History, err = store.History(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Write writes the archive in the given format
+func (a *ProjectArchive) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatJSONL:
+		return a.writeJSONL(w)
+	case FormatTarGz:
+		return a.writeTarGz(w)
+	}
+	return fmt.Errorf("unknown archive format %q (expected jsonl or tar.gz)", format)
+}
+
+// writeJSONL writes one record per line, starting with the project header
+func (a *ProjectArchive) writeJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	exportedAt := a.ExportedAt
+	records := []archiveRecord{{Type: "project", Version: archiveVersion, Project: a.Project, ExportedAt: &exportedAt}}
+	for _, task := range a.Tasks {
+		records = append(records, archiveRecord{Type: "task", Task: task})
+	}
+	for i := range a.Groups {
+		records = append(records, archiveRecord{Type: "group", Group: &a.Groups[i]})
+	}
+	for i := range a.Milestones {
+		records = append(records, archiveRecord{Type: "milestone", Milestone: &a.Milestones[i]})
+	}
+	for i := range a.History {
+		records = append(records, archiveRecord{Type: "history", History: &a.History[i]})
+	}
+	if a.Settings != nil {
+		records = append(records, archiveRecord{Type: "settings", Settings: a.Settings})
+	}
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTarGz writes the project files as they are laid out on disk, plus
+// manifest.json and settings.json
+func (a *ProjectArchive) writeTarGz(w io.Writer) error {
+	files := map[string][]byte{}
+	var err error
+	if files["manifest.json"], err = json.MarshalIndent(archiveManifest{archiveVersion, a.Project, a.ExportedAt}, "", "  "); err != nil {
+		return err
+	}
+	for _, raw := range a.Tasks {
+		id, err := rawTaskID(raw)
+		if err != nil {
+			return err
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, raw, "", "  "); err != nil {
+			return err
+		}
+		files["tasks/"+id+".json"] = indented.Bytes()
+	}
+	if files["tasks/_groups.json"], err = json.MarshalIndent(groupsFile{Groups: a.Groups}, "", "  "); err != nil {
+		return err
+	}
+	if files["tasks/_milestones.json"], err = json.MarshalIndent(milestonesFile{Milestones: a.Milestones}, "", "  "); err != nil {
+		return err
+	}
+	var history bytes.Buffer
+	enc := json.NewEncoder(&history)
+	for _, entry := range a.History {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	files["tasks/"+HistoryFileName] = history.Bytes()
+	if a.Settings != nil {
+		if files["settings.json"], err = json.MarshalIndent(a.Settings, "", "  "); err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), ModTime: a.ExportedAt}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// ReadArchive reads an archive written by Write
+func ReadArchive(r io.Reader, format string) (*ProjectArchive, error) {
+	switch format {
+	case FormatJSONL:
+		return readJSONL(r)
+	case FormatTarGz:
+		return readTarGz(r)
+	}
+	return nil, fmt.Errorf("unknown archive format %q (expected jsonl or tar.gz)", format)
+}
+
+// readJSONL reads a JSONL archive
+func readJSONL(r io.Reader) (*ProjectArchive, error) {
+	a := &ProjectArchive{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record archiveRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		switch record.Type {
+		case "project":
+			if record.Version > archiveVersion {
+				return nil, fmt.Errorf("archive version %d is newer than supported (%d)", record.Version, archiveVersion)
+			}
+			a.Project = record.Project
+			if record.ExportedAt != nil {
+				a.ExportedAt = *record.ExportedAt
+			}
+		case "task":
+			a.Tasks = append(a.Tasks, record.Task)
+		case "group":
+			if record.Group != nil {
+				a.Groups = append(a.Groups, *record.Group)
+			}
+		case "milestone":
+			if record.Milestone != nil {
+				a.Milestones = append(a.Milestones, *record.Milestone)
+			}
+		case "history":
+			if record.History != nil {
+				a.History = append(a.History, *record.History)
+			}
+		case "settings":
+			a.Settings = record.Settings
+		default:
+			return nil, fmt.Errorf("line %d: unknown record type %q", line, record.Type)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if line == 0 {
+		return nil, fmt.Errorf("empty archive")
+	}
+	return a, nil
+}
+
+// readTarGz reads a tar.gz archive
+func readTarGz(r io.Reader) (*ProjectArchive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	a := &ProjectArchive{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		name := path.Clean(hdr.Name)
+		switch {
+		case name == "manifest.json":
+			var m archiveManifest
+			if err := json.Unmarshal(content, &m); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			if m.Version > archiveVersion {
+				return nil, fmt.Errorf("archive version %d is newer than supported (%d)", m.Version, archiveVersion)
+			}
+			a.Project, a.ExportedAt = m.Project, m.ExportedAt
+		case name == "settings.json":
+			a.Settings = &config.ProjectState{}
+			if err := json.Unmarshal(content, a.Settings); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		case name == "tasks/_groups.json":
+			var gf groupsFile
+			if err := json.Unmarshal(content, &gf); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			a.Groups = gf.Groups
+		case name == "tasks/_milestones.json":
+			var mf milestonesFile
+			if err := json.Unmarshal(content, &mf); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			a.Milestones = mf.Milestones
+		case name == "tasks/"+HistoryFileName:
+			dec := json.NewDecoder(bytes.NewReader(content))
+			for dec.More() {
+				var entry HistoryEntry
+				if err := dec.Decode(&entry); err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+				a.History = append(a.History, entry)
+			}
+		case strings.HasPrefix(name, "tasks/") && strings.HasSuffix(name, ".json"):
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, content); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			a.Tasks = append(a.Tasks, compact.Bytes())
+		}
+	}
+	return a, nil
+}
+
+// rawTaskID returns the id field of a raw task
+func rawTaskID(raw json.RawMessage) (string, error) {
+	var t struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return "", err
+	}
+	if t.ID == "" || strings.ContainsAny(t.ID, `/\`) || strings.HasPrefix(t.ID, ".") || strings.HasPrefix(t.ID, "_") {
+		return "", fmt.Errorf("invalid task id %q in archive", t.ID)
+	}
+	return t.ID, nil
+}
+
+// sameTaskJSON reports whether the task file on disk has the same content as raw
+func sameTaskJSON(store *TaskStore, id string, raw json.RawMessage) bool {
+	local, err := store.RawTaskJSON(id)
+	if err != nil {
+		return false
+	}
+	var a, b bytes.Buffer
+	return json.Compact(&a, local) == nil && json.Compact(&b, raw) == nil && bytes.Equal(a.Bytes(), b.Bytes())
+}
+
+// ImportResult summarizes what an import changed
+type ImportResult struct {
+	Added      int // tasks only in the archive
+	Updated    int // tasks on both sides taken from the archive
+	Kept       int // tasks on both sides kept as they were (or identical)
+	Trashed    int // local-only tasks moved to the trash (replace)
+	Groups     int // groups added or updated
+	Milestones int // milestones added or updated
+	History    int // history entries added
+}
+
+// ImportProject merges an archive into a project. Tasks, groups and
+// milestones present on both sides are resolved by strategy (matching by task
+// ID and by name); history entries are merged without duplicates. The
+// project is snapshotted first. With dryRun, nothing is written.
+func ImportProject(projectName string, a *ProjectArchive, strategy string, dryRun bool) (ImportResult, error) {
+	var result ImportResult
+	if !containsString(ImportStrategies, strategy) {
+		return result, fmt.Errorf("unknown strategy %q (expected %s)", strategy, strings.Join(ImportStrategies, ", "))
+	}
+	if !dryRun {
+		if err := checkProjectWritable(projectName); err != nil {
+			return result, err
+		}
+	}
+
+	store, err := LoadTasks(projectName)
+	if err != nil {
+		return result, err
+	}
+	if store.Layout() == LayoutSingle {
+		return result, fmt.Errorf("%s keeps its tasks in %s; run 'cctasks convert --to %s %s' first", projectName, LegacyTasksFile, LayoutFiles, projectName)
+	}
+	if store.key != nil {
+		return result, fmt.Errorf("%s is encrypted; run 'cctasks decrypt %s' first", projectName, projectName)
+	}
+	projectDir, err := store.dir()
+	if err != nil {
+		return result, err
+	}
+	if !dryRun {
+		if err := os.MkdirAll(projectDir, 0755); err != nil {
+			return result, err
+		}
+		SnapshotProject(projectName) // best-effort, like other backups
+	}
+
+	// Tasks
+	archived := make(map[string]bool, len(a.Tasks))
+	for _, raw := range a.Tasks {
+		id, err := rawTaskID(raw)
+		if err != nil {
+			return result, err
+		}
+		archived[id] = true
+		if store.GetTask(id) != nil {
+			if strategy == ImportKeep || sameTaskJSON(store, id, raw) {
+				result.Kept++
+				continue
+			}
+			result.Updated++
+		} else {
+			result.Added++
+		}
+		if dryRun {
+			continue
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, raw, "", "  "); err != nil {
+			return result, fmt.Errorf("task %s: %w", id, err)
+		}
+		filePath, err := store.TaskFilePath(id)
+		if err != nil {
+			return result, err
+		}
+		if err := os.WriteFile(filePath, indented.Bytes(), 0644); err != nil {
+			return result, err
+		}
+	}
+	if strategy == ImportReplace {
+		now := time.Now()
+		for _, task := range store.Tasks {
+			if archived[task.ID] {
+				continue
+			}
+			result.Trashed++
+			if !dryRun {
+				if err := store.moveToTrash(task, now); err != nil {
+					return result, err
+				}
+			}
+		}
+	}
+
+	// Groups and milestones
+	groups, err := LoadGroups(projectName)
+	if err != nil {
+		return result, err
+	}
+	groups.Groups, result.Groups = mergeByName(groups.Groups, a.Groups, strategy, func(g TaskGroup) string { return g.Name })
+	milestones, err := LoadMilestones(projectName)
+	if err != nil {
+		return result, err
+	}
+	milestones.Milestones, result.Milestones = mergeByName(milestones.Milestones, a.Milestones, strategy, func(m Milestone) string { return m.Name })
+	sortMilestones(milestones.Milestones)
+
+	// History
+	local, err := store.History()
+	if err != nil {
+		return result, err
+	}
+	history, added := mergeHistory(local, a.History)
+	result.History = added
+
+	if dryRun {
+		return result, nil
+	}
+	if result.Groups > 0 {
+		if err := groups.Save(); err != nil {
+			return result, err
+		}
+	}
+	if result.Milestones > 0 {
+		if err := milestones.Save(); err != nil {
+			return result, err
+		}
+	}
+	if added > 0 {
+		if err := writeHistory(projectDir, history); err != nil {
+			return result, err
+		}
+	}
+	if config.Current().Git.Enabled {
+		commitProject(projectDir, "import "+a.Project) // best-effort like backups
+	}
+	return result, nil
+}
+
+// mergeByName merges archived items into local ones by name and returns the
+// result with the number of items added or replaced
+func mergeByName[T comparable](local, archived []T, strategy string, name func(T) string) ([]T, int) {
+	if strategy == ImportReplace {
+		// Count archived items that differ plus local items that go away
+		changed := 0
+		byName := make(map[string]T, len(archived))
+		for _, item := range archived {
+			byName[name(item)] = item
+		}
+		for _, item := range local {
+			if archivedItem, ok := byName[name(item)]; !ok || archivedItem != item {
+				changed++
+			}
+			delete(byName, name(item))
+		}
+		changed += len(byName)
+		return append([]T(nil), archived...), changed
+	}
+
+	merged := append([]T(nil), local...)
+	index := make(map[string]int, len(merged))
+	for i, item := range merged {
+		index[name(item)] = i
+	}
+	changed := 0
+	for _, item := range archived {
+		i, ok := index[name(item)]
+		switch {
+		case !ok:
+			index[name(item)] = len(merged)
+			merged = append(merged, item)
+			changed++
+		case strategy == ImportOverwrite && merged[i] != item:
+			merged[i] = item
+			changed++
+		}
+	}
+	return merged, changed
+}
+
+// mergeHistory returns the union of two history logs in time order and the
+// number of entries added from archived
+func mergeHistory(local, archived []HistoryEntry) ([]HistoryEntry, int) {
+	// Compare times as instants, whatever zone they were written in
+	key := func(e HistoryEntry) HistoryEntry {
+		e.Time = e.Time.UTC()
+		return e
+	}
+	seen := make(map[HistoryEntry]bool, len(local))
+	for _, entry := range local {
+		seen[key(entry)] = true
+	}
+	merged := append([]HistoryEntry(nil), local...)
+	added := 0
+	for _, entry := range archived {
+		if seen[key(entry)] {
+			continue
+		}
+		seen[key(entry)] = true
+		merged = append(merged, entry)
+		added++
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+	return merged, added
+}
+
+// writeHistory rewrites the project's history log
+func writeHistory(projectDir string, entries []HistoryEntry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filepath.Join(projectDir, HistoryFileName), buf.Bytes(), 0644)
+}
@@ -0,0 +1,148 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+// writeArchiveProject creates a project with the given raw task files and groups
+func writeArchiveProject(t *testing.T, tasksDir, project string, tasks map[string]string, groups []TaskGroup) {
+	t.Helper()
+	projectDir := filepath.Join(tasksDir, project)
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for id, content := range tasks {
+		if err := os.WriteFile(filepath.Join(projectDir, id+".json"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := NewGroupStoreForTest(projectDir, groups); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProjectArchiveRoundTrip(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+
+	// Fields cctasks does not know about must survive the round trip
+	writeArchiveProject(t, tasksDir, "src", map[string]string{
+		"1": `{"id":"1","subject":"Ship","status":"pending","futureField":{"x":1}}`,
+	}, []TaskGroup{{Name: "Backend", Color: "#8b5cf6"}})
+	history := HistoryEntry{Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Kind: ChangeCreated, TaskID: "1", Subject: "Ship", To: "pending"}
+	if err := appendHistory(filepath.Join(tasksDir, "src"), nil, []HistoryEntry{history}); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := ExportProject("src")
+	if err != nil {
+		t.Fatalf("ExportProject failed: %v", err)
+	}
+	archive.Settings = &config.ProjectState{Favorite: true}
+
+	for _, format := range []string{FormatJSONL, FormatTarGz} {
+		var buf bytes.Buffer
+		if err := archive.Write(&buf, format); err != nil {
+			t.Fatalf("%s: Write failed: %v", format, err)
+		}
+		got, err := ReadArchive(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: ReadArchive failed: %v", format, err)
+		}
+		if got.Project != "src" || len(got.Tasks) != 1 || !strings.Contains(string(got.Tasks[0]), `"futureField":{"x":1}`) {
+			t.Errorf("%s: project/tasks not preserved: %q %q", format, got.Project, got.Tasks)
+		}
+		if len(got.Groups) != 1 || got.Groups[0].Name != "Backend" {
+			t.Errorf("%s: groups = %+v", format, got.Groups)
+		}
+		if len(got.History) != 1 || !got.History[0].Time.Equal(history.Time) {
+			t.Errorf("%s: history = %+v", format, got.History)
+		}
+		if got.Settings == nil || !got.Settings.Favorite {
+			t.Errorf("%s: settings not preserved", format)
+		}
+	}
+}
+
+func TestImportProjectStrategies(t *testing.T) {
+	archive := &ProjectArchive{
+		Project: "src",
+		Tasks: []json.RawMessage{
+			json.RawMessage(`{"id":"1","subject":"Archived one","status":"completed"}`),
+			json.RawMessage(`{"id":"3","subject":"Only in archive","status":"pending"}`),
+		},
+		Groups: []TaskGroup{{Name: "Backend", Color: "#000000"}, {Name: "Docs", Color: "#ffffff"}},
+		History: []HistoryEntry{
+			{Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Kind: ChangeCreated, TaskID: "3", To: "pending"},
+		},
+	}
+
+	tests := []struct {
+		strategy    string
+		want        ImportResult
+		subject1    string
+		has2        bool
+		backendHex  string
+		groupsCount int
+	}{
+		{ImportKeep, ImportResult{Added: 1, Kept: 1, Groups: 1, History: 1}, "Local one", true, "#8b5cf6", 2},
+		{ImportOverwrite, ImportResult{Added: 1, Updated: 1, Groups: 2, History: 1}, "Archived one", true, "#000000", 2},
+		{ImportReplace, ImportResult{Added: 1, Updated: 1, Trashed: 1, Groups: 2, History: 1}, "Archived one", false, "#000000", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			tasksDir := testutil.IsolateHome(t)
+			writeArchiveProject(t, tasksDir, "dst", map[string]string{
+				"1": `{"id":"1","subject":"Local one","status":"pending"}`,
+				"2": `{"id":"2","subject":"Local two","status":"pending"}`,
+			}, []TaskGroup{{Name: "Backend", Color: "#8b5cf6"}})
+
+			// A dry run reports the same result without writing
+			dry, err := ImportProject("dst", archive, tt.strategy, true)
+			if err != nil || dry != tt.want {
+				t.Fatalf("dry run = %+v, %v; want %+v", dry, err, tt.want)
+			}
+			if _, err := os.Stat(filepath.Join(tasksDir, "dst", "3.json")); !os.IsNotExist(err) {
+				t.Fatal("dry run wrote files")
+			}
+
+			got, err := ImportProject("dst", archive, tt.strategy, false)
+			if err != nil || got != tt.want {
+				t.Fatalf("ImportProject = %+v, %v; want %+v", got, err, tt.want)
+			}
+
+			store, err := LoadTasks("dst")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if task := store.GetTask("1"); task == nil || task.Subject != tt.subject1 {
+				t.Errorf("task 1 = %+v, want subject %q", task, tt.subject1)
+			}
+			if (store.GetTask("2") != nil) != tt.has2 || store.GetTask("3") == nil {
+				t.Errorf("tasks after import: %+v", store.Tasks)
+			}
+			groups, _ := LoadGroups("dst")
+			if len(groups.Groups) != tt.groupsCount || groups.GetGroupColor("Backend") != tt.backendHex {
+				t.Errorf("groups = %+v", groups.Groups)
+			}
+
+			// Importing again changes nothing
+			again, err := ImportProject("dst", archive, tt.strategy, false)
+			if err != nil || again.History != 0 || again.Added != 0 || again.Updated != 0 {
+				t.Errorf("second import = %+v, %v", again, err)
+			}
+		})
+	}
+
+	if _, err := ImportProject("dst", archive, "merge-ish", true); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
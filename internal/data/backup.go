@@ -0,0 +1,288 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// SnapshotTimeFormat is the directory name format of backup snapshots
+const SnapshotTimeFormat = "2006-01-02T15-04-05"
+
+// snapshotHashFile stores the content hash of a snapshot for change detection
+const snapshotHashFile = ".hash"
+
+// Snapshot is a timestamped copy of a project's files
+type Snapshot struct {
+	Name string    // directory name (timestamp)
+	Path string    // full path to the snapshot directory
+	Time time.Time // creation time parsed from the name
+}
+
+// ListSnapshots returns a project's backup snapshots, newest first
+func ListSnapshots(projectName string) ([]Snapshot, error) {
+	backupDir, err := config.GetBackupProjectDir(projectName)
+	if err != nil {
+		return nil, err
+	}
+	return listSnapshotsIn(backupDir)
+}
+
+// listSnapshotsIn returns the snapshots in backupDir, newest first
+func listSnapshotsIn(backupDir string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(backupDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Snapshot{}, nil
+		}
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue // legacy single-copy backup files
+		}
+		t, err := time.ParseInLocation(SnapshotTimeFormat, entry.Name(), time.Local)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, Snapshot{
+			Name: entry.Name(),
+			Path: filepath.Join(backupDir, entry.Name()),
+			Time: t,
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.After(snapshots[j].Time)
+	})
+	return snapshots, nil
+}
+
+// SnapshotProject creates a snapshot of a project if its files changed since the last one
+func SnapshotProject(projectName string) error {
+	projectDir, err := config.GetProjectDir(projectName)
+	if err != nil {
+		return err
+	}
+	backupDir, err := config.GetBackupProjectDir(projectName)
+	if err != nil {
+		return err
+	}
+	snapshot, err := takeSnapshot(projectDir, backupDir, time.Now())
+	if snapshot != nil {
+		uploadSnapshot(projectName, *snapshot)
+	}
+	return err
+}
+
+// MigrateBackupLayout moves the backups of projects on additional roots from
+// "<root>/<project>", the layout of earlier versions (inside the backups of
+// the primary project named like the root), to "<root>@<project>". Backups
+// already in place are left alone; errors are returned together after
+// trying every project.
+func MigrateBackupLayout() error {
+	roots, err := config.GetRoots()
+	if err != nil {
+		return err
+	}
+	backupDir, err := config.GetBackupDir()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, root := range roots {
+		if root.Name == "" {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(backupDir, root.Name))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		for _, entry := range entries {
+			// Snapshots of the primary project sit there too
+			if !entry.IsDir() || isSnapshotName(entry.Name()) {
+				continue
+			}
+			dst := filepath.Join(backupDir, root.Name+"@"+entry.Name())
+			if _, err := os.Stat(dst); err == nil {
+				continue
+			}
+			if err := os.Rename(filepath.Join(backupDir, root.Name, entry.Name()), dst); err != nil {
+				errs = append(errs, fmt.Errorf("moving the backups of %s/%s: %w", root.Name, entry.Name(), err))
+				continue
+			}
+			slog.Debug("backups moved", "project", root.Name+"/"+entry.Name(), "dir", dst)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isSnapshotName reports whether a backup directory entry is a snapshot
+func isSnapshotName(name string) bool {
+	_, err := time.ParseInLocation(SnapshotTimeFormat, name, time.Local)
+	return err == nil
+}
+
+// snapshotStore snapshots a store's project directory, ignoring errors.
+// Stores without a project name are not backed up.
+func snapshotStore(projectName, projectDir string) {
+	if projectName == "" {
+		return
+	}
+	backupDir, err := config.GetBackupProjectDir(projectName)
+	if err != nil {
+		return
+	}
+	if snapshot, _ := takeSnapshot(projectDir, backupDir, time.Now()); snapshot != nil {
+		uploadSnapshot(projectName, *snapshot)
+	}
+}
+
+// snapshotDir copies the project files in projectDir into a new timestamped
+// directory under backupDir, skipping if nothing changed, then prunes old snapshots
+func snapshotDir(projectDir, backupDir string, now time.Time) error {
+	_, err := takeSnapshot(projectDir, backupDir, now)
+	return err
+}
+
+// takeSnapshot does the work of snapshotDir and returns the new snapshot,
+// or nil when nothing changed
+func takeSnapshot(projectDir, backupDir string, now time.Time) (*Snapshot, error) {
+	files, hash, err := readProjectFiles(projectDir)
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+
+	snapshots, err := listSnapshotsIn(backupDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip if content is identical to the latest snapshot
+	if len(snapshots) > 0 {
+		latest, err := os.ReadFile(filepath.Join(snapshots[0].Path, snapshotHashFile))
+		if err == nil && string(latest) == hash {
+			return nil, nil
+		}
+	}
+
+	name := now.Format(SnapshotTimeFormat)
+	if len(snapshots) > 0 && snapshots[0].Name == name {
+		// Same second as the latest snapshot: overwrite it
+		if err := os.RemoveAll(snapshots[0].Path); err != nil {
+			return nil, err
+		}
+		snapshots = snapshots[1:]
+	}
+
+	snapshotPath := filepath.Join(backupDir, name)
+	if err := os.MkdirAll(snapshotPath, 0755); err != nil {
+		return nil, err
+	}
+	for filename, content := range files {
+		if err := os.WriteFile(filepath.Join(snapshotPath, filename), content, 0644); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.WriteFile(filepath.Join(snapshotPath, snapshotHashFile), []byte(hash), 0644); err != nil {
+		return nil, err
+	}
+
+	snapshot := Snapshot{Name: name, Path: snapshotPath, Time: now}
+	snapshots = append([]Snapshot{snapshot}, snapshots...)
+	_, err = pruneSnapshotList(snapshots, config.Current().Backup, now)
+	return &snapshot, err
+}
+
+// readProjectFiles reads the top-level JSON files of a project and returns them with a content hash
+func readProjectFiles(projectDir string) (map[string][]byte, string, error) {
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+
+	files := make(map[string][]byte)
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(projectDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		files[entry.Name()] = content
+		names = append(names, entry.Name())
+	}
+
+	// Hash names and contents in a stable order
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write(files[name])
+		h.Write([]byte{0})
+	}
+	return files, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// PruneSnapshots deletes a project's snapshots outside the retention policy
+// and returns the removed snapshots. With dryRun, nothing is deleted.
+func PruneSnapshots(projectName string, policy config.BackupConfig, dryRun bool) ([]Snapshot, error) {
+	snapshots, err := ListSnapshots(projectName)
+	if err != nil {
+		return nil, err
+	}
+	expired := expiredSnapshots(snapshots, policy, time.Now())
+	if dryRun {
+		return expired, nil
+	}
+	return pruneSnapshotList(snapshots, policy, time.Now())
+}
+
+// pruneSnapshotList deletes expired snapshots from a newest-first list
+func pruneSnapshotList(snapshots []Snapshot, policy config.BackupConfig, now time.Time) ([]Snapshot, error) {
+	expired := expiredSnapshots(snapshots, policy, now)
+	for _, s := range expired {
+		if err := os.RemoveAll(s.Path); err != nil {
+			return nil, err
+		}
+	}
+	return expired, nil
+}
+
+// expiredSnapshots returns snapshots outside the retention policy.
+// The newest snapshot is always kept.
+func expiredSnapshots(snapshots []Snapshot, policy config.BackupConfig, now time.Time) []Snapshot {
+	var expired []Snapshot
+	for i, s := range snapshots {
+		if i == 0 {
+			continue
+		}
+		tooMany := policy.KeepLast > 0 && i >= policy.KeepLast
+		tooOld := policy.MaxAgeDays > 0 && now.Sub(s.Time) > time.Duration(policy.MaxAgeDays)*24*time.Hour
+		if tooMany || tooOld {
+			expired = append(expired, s)
+		}
+	}
+	return expired
+}
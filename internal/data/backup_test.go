@@ -0,0 +1,152 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestSnapshotDir(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	projectDir := filepath.Join(tmpDir, "project")
+	backupDir := filepath.Join(tmpDir, "backup")
+	os.MkdirAll(projectDir, 0755)
+	os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(`{"id":"1"}`), 0644)
+	os.WriteFile(filepath.Join(projectDir, "_groups.json"), []byte(`{"groups":[]}`), 0644)
+
+	now := time.Date(2025, 6, 1, 10, 30, 0, 0, time.Local)
+	if err := snapshotDir(projectDir, backupDir, now); err != nil {
+		t.Fatalf("snapshotDir failed: %v", err)
+	}
+
+	// Unchanged content should not create a new snapshot
+	if err := snapshotDir(projectDir, backupDir, now.Add(time.Minute)); err != nil {
+		t.Fatalf("snapshotDir failed: %v", err)
+	}
+	snapshots, _ := listSnapshotsIn(backupDir)
+	if len(snapshots) != 1 {
+		t.Fatalf("Expected 1 snapshot, got %d", len(snapshots))
+	}
+	if snapshots[0].Name != "2025-06-01T10-30-00" {
+		t.Errorf("Expected snapshot name '2025-06-01T10-30-00', got '%s'", snapshots[0].Name)
+	}
+	if _, err := os.Stat(filepath.Join(snapshots[0].Path, "_groups.json")); err != nil {
+		t.Error("Expected _groups.json to be included in snapshot")
+	}
+
+	// Changed content creates a new snapshot
+	os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(`{"id":"1","status":"completed"}`), 0644)
+	if err := snapshotDir(projectDir, backupDir, now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("snapshotDir failed: %v", err)
+	}
+	snapshots, _ = listSnapshotsIn(backupDir)
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+	}
+	content, _ := os.ReadFile(filepath.Join(snapshots[0].Path, "1.json"))
+	if string(content) != `{"id":"1","status":"completed"}` {
+		t.Errorf("Expected newest snapshot first, got content %s", content)
+	}
+}
+
+func TestExpiredSnapshots(t *testing.T) {
+	now := time.Date(2025, 6, 30, 0, 0, 0, 0, time.Local)
+	var snapshots []Snapshot
+	for i := 0; i < 5; i++ {
+		snapshots = append(snapshots, Snapshot{Name: string(rune('a' + i)), Time: now.AddDate(0, 0, -i*10)})
+	}
+
+	tests := []struct {
+		policy   config.BackupConfig
+		expected int
+	}{
+		{config.BackupConfig{}, 0},
+		{config.BackupConfig{KeepLast: 3}, 2},
+		{config.BackupConfig{MaxAgeDays: 15}, 3},
+		{config.BackupConfig{KeepLast: 4, MaxAgeDays: 25}, 2},
+		{config.BackupConfig{KeepLast: 1, MaxAgeDays: 1}, 4},
+	}
+
+	for _, tt := range tests {
+		expired := expiredSnapshots(snapshots, tt.policy, now)
+		if len(expired) != tt.expected {
+			t.Errorf("policy %+v: expected %d expired, got %d", tt.policy, tt.expected, len(expired))
+		}
+	}
+
+	// The newest snapshot is always kept, even if it is too old
+	old := []Snapshot{{Name: "a", Time: now.AddDate(-1, 0, 0)}}
+	if expired := expiredSnapshots(old, config.BackupConfig{MaxAgeDays: 1}, now); len(expired) != 0 {
+		t.Errorf("Expected newest snapshot to be kept, got %d expired", len(expired))
+	}
+}
+
+func TestSnapshotProjectOnAdditionalRoot(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+	teamDir := t.TempDir()
+	cfg := config.Default()
+	cfg.Roots = []config.RootConfig{{Name: "team", Path: teamDir}}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	for _, dir := range []string{filepath.Join(tasksDir, "team"), filepath.Join(teamDir, "api")} {
+		os.MkdirAll(dir, 0755)
+		os.WriteFile(filepath.Join(dir, "1.json"), []byte(`{"id":"1","subject":"`+filepath.Base(dir)+`"}`), 0644)
+	}
+	for _, project := range []string{"team", "team/api"} {
+		if err := SnapshotProject(project); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backupDir, _ := config.GetBackupDir()
+	if backupDir != tasksDir+"_backup" {
+		t.Errorf("backup dir = %s, want it next to the tasks dir", backupDir)
+	}
+	for project, dir := range map[string]string{"team": "team", "team/api": "team@api"} {
+		snapshots, err := ListSnapshots(project)
+		if err != nil || len(snapshots) != 1 || filepath.Dir(snapshots[0].Path) != filepath.Join(backupDir, dir) {
+			t.Errorf("%s: snapshots = %+v, %v; want one in %s", project, snapshots, err, dir)
+		}
+	}
+}
+
+func TestMigrateBackupLayout(t *testing.T) {
+	testutil.IsolateHome(t)
+	cfg := config.Default()
+	cfg.Roots = []config.RootConfig{{Name: "team", Path: t.TempDir()}}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	// Earlier layout: team/api next to the snapshots of the primary project "team"
+	backupDir, _ := config.GetBackupDir()
+	for _, dir := range []string{"team/2025-06-01T10-30-00", "team/api/2025-06-01T10-30-00"} {
+		if err := os.MkdirAll(filepath.Join(backupDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := MigrateBackupLayout(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, project := range []string{"team", "team/api"} {
+		if snapshots, err := ListSnapshots(project); err != nil || len(snapshots) != 1 {
+			t.Errorf("%s: snapshots = %+v, %v; want one", project, snapshots, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(backupDir, "team", "api")); !os.IsNotExist(err) {
+		t.Errorf("old backup dir should be moved, stat err = %v", err)
+	}
+	if err := MigrateBackupLayout(); err != nil {
+		t.Errorf("second run should do nothing, got %v", err)
+	}
+}
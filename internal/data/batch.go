@@ -0,0 +1,153 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BatchField is a task field that can be changed on many tasks at once
+type BatchField string
+
+const (
+	BatchGroup    BatchField = "group"
+	BatchOwner    BatchField = "owner"
+	BatchStatus   BatchField = "status"
+	BatchPriority BatchField = "priority"
+	BatchAddTag   BatchField = "tag"
+	BatchStart    BatchField = "startDate"
+	BatchDue      BatchField = "dueDate"
+	BatchEstimate BatchField = "estimate"
+)
+
+// BatchFields lists the batch-editable fields in display order
+var BatchFields = []BatchField{BatchGroup, BatchOwner, BatchStatus, BatchPriority, BatchAddTag, BatchStart, BatchDue, BatchEstimate}
+
+// Label returns the display name of the field
+func (f BatchField) Label() string {
+	switch f {
+	case BatchGroup:
+		return "Group"
+	case BatchOwner:
+		return "Owner"
+	case BatchStatus:
+		return "Status"
+	case BatchPriority:
+		return "Priority"
+	case BatchAddTag:
+		return "Add tag"
+	case BatchStart:
+		return "Start date"
+	case BatchDue:
+		return "Due date"
+	case BatchEstimate:
+		return "Estimate"
+	}
+	return string(f)
+}
+
+// BatchChange sets one field to the same value on many tasks
+type BatchChange struct {
+	Field BatchField
+	Value string
+}
+
+// Validate checks that the value is allowed for the field
+func (c BatchChange) Validate() error {
+	switch c.Field {
+	case BatchStatus:
+		if !IsValidStatus(c.Value) {
+			return fmt.Errorf("invalid status %q", c.Value)
+		}
+	case BatchPriority:
+		if !IsValidPriority(c.Value) {
+			return fmt.Errorf("invalid priority %q", c.Value)
+		}
+	case BatchAddTag:
+		if strings.TrimSpace(c.Value) == "" {
+			return fmt.Errorf("tag must not be empty")
+		}
+	case BatchStart, BatchDue:
+		return ValidateDate(strings.TrimSpace(c.Value))
+	case BatchEstimate:
+		_, err := ParseEstimate(c.Value)
+		return err
+	case BatchGroup, BatchOwner:
+		// empty clears the field
+	default:
+		return fmt.Errorf("unknown field %q", c.Field)
+	}
+	return nil
+}
+
+// Changes reports whether applying the change would modify task
+func (c BatchChange) Changes(task Task) bool {
+	clone := cloneTask(task)
+	return c.apply(&clone)
+}
+
+// apply modifies task and reports whether anything changed
+func (c BatchChange) apply(task *Task) bool {
+	value := strings.TrimSpace(c.Value)
+	switch c.Field {
+	case BatchGroup:
+		if GetTaskGroup(*task) == value {
+			return false
+		}
+		SetTaskGroup(task, value)
+	case BatchOwner:
+		if task.Owner == value {
+			return false
+		}
+		task.Owner = value
+	case BatchStatus:
+		if task.Status == value {
+			return false
+		}
+		task.Status = value
+	case BatchPriority:
+		if GetTaskPriority(*task) == value {
+			return false
+		}
+		SetTaskPriority(task, value)
+	case BatchAddTag:
+		tags := GetTaskTags(*task)
+		if containsString(tags, value) {
+			return false
+		}
+		SetTaskTags(task, append(append([]string{}, tags...), value))
+	case BatchStart:
+		if GetTaskStart(*task) == value {
+			return false
+		}
+		SetTaskStart(task, value)
+	case BatchDue:
+		if GetTaskDue(*task) == value {
+			return false
+		}
+		SetTaskDue(task, value)
+	case BatchEstimate:
+		estimate, _ := ParseEstimate(value)
+		if GetTaskEstimate(*task) == estimate {
+			return false
+		}
+		SetTaskEstimate(task, estimate)
+	default:
+		return false
+	}
+	return true
+}
+
+// ApplyBatch applies a change to the tasks with the given IDs and returns
+// how many were modified. Call Save to write the result.
+func (s *TaskStore) ApplyBatch(ids []string, change BatchChange) (int, error) {
+	if err := change.Validate(); err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, id := range ids {
+		if task := s.GetTask(id); task != nil && change.apply(task) {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,61 @@
+package data
+
+import "testing"
+
+func TestApplyBatch(t *testing.T) {
+	store := &TaskStore{Tasks: []Task{
+		{ID: "1", Subject: "A", Status: "pending"},
+		{ID: "2", Subject: "B", Status: "completed"},
+		{ID: "3", Subject: "C", Status: "pending", Metadata: map[string]interface{}{"tags": []interface{}{"ui"}}},
+	}}
+
+	count, err := store.ApplyBatch([]string{"1", "2"}, BatchChange{Field: BatchStatus, Value: "in_progress"})
+	if err != nil {
+		t.Fatalf("ApplyBatch failed: %v", err)
+	}
+	if count != 2 || store.GetTask("1").Status != "in_progress" || store.GetTask("2").Status != "in_progress" {
+		t.Errorf("Expected 2 tasks set to in_progress, got count=%d", count)
+	}
+	if store.GetTask("3").Status != "pending" {
+		t.Error("Expected task outside the selection to be unchanged")
+	}
+
+	// Adding a tag skips tasks that already have it
+	count, _ = store.ApplyBatch([]string{"1", "3"}, BatchChange{Field: BatchAddTag, Value: "ui"})
+	if count != 1 {
+		t.Errorf("Expected 1 task changed by tag, got %d", count)
+	}
+	if tags := GetTaskTags(*store.GetTask("1")); len(tags) != 1 || tags[0] != "ui" {
+		t.Errorf("Expected tags [ui], got %v", tags)
+	}
+
+	store.ApplyBatch([]string{"1"}, BatchChange{Field: BatchPriority, Value: "high"})
+	if p := GetTaskPriority(*store.GetTask("1")); p != "high" {
+		t.Errorf("Expected priority high, got '%s'", p)
+	}
+
+	store.ApplyBatch([]string{"1", "2"}, BatchChange{Field: BatchGroup, Value: "Backend"})
+	if g := GetTaskGroup(*store.GetTask("2")); g != "Backend" {
+		t.Errorf("Expected group Backend, got '%s'", g)
+	}
+}
+
+func TestBatchChangeValidate(t *testing.T) {
+	tests := []struct {
+		change BatchChange
+		valid  bool
+	}{
+		{BatchChange{Field: BatchStatus, Value: "completed"}, true},
+		{BatchChange{Field: BatchStatus, Value: "done"}, false},
+		{BatchChange{Field: BatchPriority, Value: ""}, true},
+		{BatchChange{Field: BatchPriority, Value: "urgent"}, false},
+		{BatchChange{Field: BatchAddTag, Value: " "}, false},
+		{BatchChange{Field: BatchOwner, Value: ""}, true},
+		{BatchChange{Field: "unknown", Value: "x"}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.change.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Validate(%+v): expected valid=%v, got err=%v", tt.change, tt.valid, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package data
+
+// GetTaskBlockedReason returns why the task is flagged as blocked by something
+// outside the project (metadata "blocked"), or "" if it is not flagged
+func GetTaskBlockedReason(task Task) string {
+	if task.Metadata == nil {
+		return ""
+	}
+	reason, _ := task.Metadata["blocked"].(string)
+	return reason
+}
+
+// SetTaskBlockedReason flags the task as blocked for the given reason; an
+// empty reason clears the flag
+func SetTaskBlockedReason(task *Task, reason string) {
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	if reason == "" {
+		delete(task.Metadata, "blocked")
+	} else {
+		task.Metadata["blocked"] = reason
+	}
+}
+
+// BlockedTasks returns the IDs of open tasks that are blocked: by an open
+// task in BlockedBy (or Blocks of another task), or by the manual flag
+func BlockedTasks(tasks []Task) map[string]bool {
+	blocked := make(map[string]bool)
+	for id, deps := range openBlockers(tasks) {
+		if len(deps) > 0 {
+			blocked[id] = true
+		}
+	}
+	for _, task := range tasks {
+		if task.Status != "completed" && GetTaskBlockedReason(task) != "" {
+			blocked[task.ID] = true
+		}
+	}
+	return blocked
+}
+
+// WaitingTasks returns the open tasks that task id is holding up, in task
+// order: those it blocks while it is open. only lists the ones waiting for
+// nothing else, which completing the task unblocks.
+func WaitingTasks(tasks []Task, id string) (waiting, only []string) {
+	blockers := openBlockers(tasks)
+	for _, task := range tasks {
+		if deps := blockers[task.ID]; deps[id] {
+			waiting = append(waiting, task.ID)
+			if len(deps) == 1 {
+				only = append(only, task.ID)
+			}
+		}
+	}
+	return waiting, only
+}
@@ -0,0 +1,52 @@
+package data
+
+import "testing"
+
+func TestBlockedTasks(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Status: "in_progress", Blocks: []string{"2"}},
+		{ID: "2", Status: "pending", BlockedBy: []string{"1"}},
+		{ID: "3", Status: "pending"},
+		{ID: "4", Status: "pending"},
+		{ID: "5", Status: "completed"},
+	}
+	SetTaskBlockedReason(&tasks[2], "Waiting for the vendor API key")
+	SetTaskBlockedReason(&tasks[4], "Stale flag")
+
+	blocked := BlockedTasks(tasks)
+	if !blocked["2"] || !blocked["3"] || blocked["1"] || blocked["4"] || blocked["5"] {
+		t.Errorf("blocked = %v, want 2 (dependency) and 3 (flag)", blocked)
+	}
+	if next := NextTask(tasks); next == nil || next.ID != "4" {
+		t.Errorf("NextTask = %v, want #4 (skipping the flagged #3)", next)
+	}
+
+	SetTaskBlockedReason(&tasks[2], "")
+	if GetTaskBlockedReason(tasks[2]) != "" || BlockedTasks(tasks)["3"] {
+		t.Error("an empty reason should clear the flag")
+	}
+}
+
+func TestWaitingTasks(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Status: "in_progress", Blocks: []string{"2"}},
+		{ID: "2", Status: "pending"},
+		{ID: "3", Status: "pending", BlockedBy: []string{"1", "4"}},
+		{ID: "4", Status: "pending"},
+		{ID: "5", Status: "completed", BlockedBy: []string{"1"}},
+	}
+
+	waiting, only := WaitingTasks(tasks, "1")
+	if len(waiting) != 2 || waiting[0] != "2" || waiting[1] != "3" {
+		t.Errorf("Expected 2 and 3 waiting for 1, got %v", waiting)
+	}
+	if len(only) != 1 || only[0] != "2" {
+		t.Errorf("Expected only 2 waiting for nothing else, got %v", only)
+	}
+
+	// A completed task holds nothing up
+	tasks[0].Status = "completed"
+	if waiting, _ := WaitingTasks(tasks, "1"); len(waiting) != 0 {
+		t.Errorf("Expected no tasks waiting for a completed task, got %v", waiting)
+	}
+}
@@ -0,0 +1,175 @@
+package data
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultChangelogEntry is the entry template used when none is configured
+const DefaultChangelogEntry = "- {{.Subject}} (#{{.ID}})"
+
+// ChangelogEntry is a completed task as a changelog entry template sees it
+type ChangelogEntry struct {
+	ID          string // display ID, without "#"
+	Subject     string
+	Description string
+	Owner       string
+	Group       string
+	Tags        []string
+	Completed   string // completion date (YYYY-MM-DD)
+
+	completedAt time.Time
+}
+
+// ChangelogOptions selects and lays out the tasks of a changelog section
+type ChangelogOptions struct {
+	Title    string    // section heading, e.g. "v1.2.0" (default "Unreleased")
+	Since    time.Time // completed after (zero = from the start)
+	Until    time.Time // completed before (zero = now)
+	ByTag    bool      // sections by first tag instead of by group
+	Template string    // entry template (default DefaultChangelogEntry)
+	Groups   []string  // group names in display order
+}
+
+// CompletedTasks returns the completed tasks with when they were completed:
+// the last change to completed in the history log, or else when the task's
+// file last changed (tasks completed before the log was kept)
+func (s *TaskStore) CompletedTasks() ([]ChangelogEntry, error) {
+	history, err := s.History()
+	if err != nil {
+		return nil, err
+	}
+	completedAt := make(map[string]time.Time)
+	for _, entry := range history {
+		if entry.To == "completed" {
+			completedAt[entry.TaskID] = entry.Time
+		}
+	}
+
+	var entries []ChangelogEntry
+	for _, task := range s.Tasks {
+		if task.Status != "completed" {
+			continue
+		}
+		at, ok := completedAt[task.ID]
+		if !ok {
+			at = s.TaskModTime(task.ID)
+		}
+		entries = append(entries, ChangelogEntry{
+			ID:          DisplayID(task),
+			Subject:     task.Subject,
+			Description: task.Description,
+			Owner:       task.Owner,
+			Group:       GetTaskGroup(task),
+			Tags:        GetTaskTags(task),
+			Completed:   at.Local().Format(DateFormat),
+			completedAt: at,
+		})
+	}
+	return entries, nil
+}
+
+// WriteChangelog writes the tasks completed in the options' period as a
+// Markdown CHANGELOG section, with a subsection per group (or tag) and one
+// line per task rendered with the entry template
+func WriteChangelog(w io.Writer, entries []ChangelogEntry, opts ChangelogOptions) error {
+	source := opts.Template
+	if source == "" {
+		source = DefaultChangelogEntry
+	}
+	tmpl, err := template.New("entry").Funcs(template.FuncMap{"join": strings.Join}).Parse(source)
+	if err != nil {
+		return fmt.Errorf("invalid changelog template: %w", err)
+	}
+	until := opts.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	// Entries of the period by section, oldest first
+	var selected []ChangelogEntry
+	for _, entry := range entries {
+		if entry.completedAt.After(opts.Since) && !entry.completedAt.After(until) {
+			selected = append(selected, entry)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].completedAt.Before(selected[j].completedAt)
+	})
+	sections := make(map[string][]ChangelogEntry)
+	for _, entry := range selected {
+		name := changelogSection(entry, opts.ByTag)
+		sections[name] = append(sections[name], entry)
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = "Unreleased"
+	}
+	fmt.Fprintf(w, "## %s - %s\n", title, until.Local().Format(DateFormat))
+	if len(selected) == 0 {
+		fmt.Fprintln(w, "\nNo tasks completed.")
+		return nil
+	}
+	for _, name := range changelogSectionOrder(sections, opts) {
+		fmt.Fprintf(w, "\n### %s\n\n", name)
+		for _, entry := range sections[name] {
+			var b strings.Builder
+			if err := tmpl.Execute(&b, entry); err != nil {
+				return fmt.Errorf("changelog template: %w", err)
+			}
+			fmt.Fprintln(w, strings.TrimRight(b.String(), "\n"))
+		}
+	}
+	return nil
+}
+
+// changelogOther is the section of tasks without a group (or tag)
+const changelogOther = "Other"
+
+// changelogSection returns the section an entry is listed under
+func changelogSection(entry ChangelogEntry, byTag bool) string {
+	if byTag {
+		if len(entry.Tags) > 0 {
+			return entry.Tags[0]
+		}
+		return changelogOther
+	}
+	if entry.Group != "" {
+		return entry.Group
+	}
+	return changelogOther
+}
+
+// changelogSectionOrder orders sections as the project's groups (tags
+// alphabetically), with Other last
+func changelogSectionOrder(sections map[string][]ChangelogEntry, opts ChangelogOptions) []string {
+	rank := make(map[string]int)
+	if !opts.ByTag {
+		for i, name := range opts.Groups {
+			rank[name] = i + 1
+		}
+	}
+	var names []string
+	for name := range sections {
+		if name != changelogOther {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ri, rj := rank[names[i]], rank[names[j]]
+		if ri != rj {
+			// Known groups first, in order
+			return ri != 0 && (rj == 0 || ri < rj)
+		}
+		return names[i] < names[j]
+	})
+	if _, ok := sections[changelogOther]; ok {
+		names = append(names, changelogOther)
+	}
+	return names
+}
@@ -0,0 +1,102 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompletedTasks(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewTaskStoreForTest(dir, []Task{
+		{ID: "1", Subject: "Login", Status: "completed", Metadata: map[string]interface{}{"group": "Backend"}},
+		{ID: "2", Subject: "Settings", Status: "completed"},
+		{ID: "3", Subject: "Search", Status: "in_progress"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Task 1 was completed on Oct 3; task 2 has no history, so its file time counts
+	completed := time.Date(2026, 10, 3, 12, 0, 0, 0, time.Local)
+	line, _ := json.Marshal(HistoryEntry{Time: completed, Kind: ChangeStatus, TaskID: "1", From: "in_progress", To: "completed"})
+	if err := os.WriteFile(filepath.Join(dir, HistoryFileName), append(line, '\n'), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fileTime := time.Date(2026, 10, 5, 9, 0, 0, 0, time.Local)
+	if err := os.Chtimes(filepath.Join(dir, "2.json"), fileTime, fileTime); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := store.CompletedTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 completed tasks, got %+v", entries)
+	}
+	if entries[0].Completed != "2026-10-03" || entries[0].Group != "Backend" {
+		t.Errorf("Expected task 1 completed 2026-10-03 in Backend, got %+v", entries[0])
+	}
+	if entries[1].Completed != "2026-10-05" {
+		t.Errorf("Expected task 2 dated by its file, got %+v", entries[1])
+	}
+}
+
+func TestWriteChangelog(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 12, 0, 0, 0, time.Local) }
+	entries := []ChangelogEntry{
+		{ID: "1", Subject: "Old fix", Group: "Backend", completedAt: day(1)},
+		{ID: "2", Subject: "Login", Group: "Backend", Tags: []string{"feature"}, completedAt: day(4)},
+		{ID: "3", Subject: "Dark mode", Group: "Frontend", Tags: []string{"feature"}, completedAt: day(3)},
+		{ID: "4", Subject: "Typo", completedAt: day(5)},
+		{ID: "5", Subject: "Future", Group: "Backend", completedAt: day(9)},
+	}
+	opts := ChangelogOptions{
+		Title:  "v1.2.0",
+		Since:  day(2),
+		Until:  day(6),
+		Groups: []string{"Frontend", "Backend"},
+	}
+
+	var b strings.Builder
+	if err := WriteChangelog(&b, entries, opts); err != nil {
+		t.Fatal(err)
+	}
+	want := `## v1.2.0 - 2026-10-06
+
+### Frontend
+
+- Dark mode (#3)
+
+### Backend
+
+- Login (#2)
+
+### Other
+
+- Typo (#4)
+`
+	if b.String() != want {
+		t.Errorf("Unexpected changelog:\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	// Sections by tag, with a custom entry template
+	opts.ByTag = true
+	opts.Template = "* {{.Subject}} ({{.Group}})"
+	b.Reset()
+	if err := WriteChangelog(&b, entries, opts); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "### feature\n\n* Dark mode (Frontend)\n* Login (Backend)\n\n### Other\n\n* Typo ()\n") {
+		t.Errorf("Unexpected changelog by tag:\n%s", b.String())
+	}
+
+	opts.Template = "{{.Nope"
+	if err := WriteChangelog(&b, entries, opts); err == nil {
+		t.Error("Expected an invalid template to be rejected")
+	}
+}
@@ -0,0 +1,230 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind describes what happened to a task
+type ChangeKind string
+
+const (
+	ChangeCreated ChangeKind = "created"
+	ChangeDeleted ChangeKind = "deleted"
+	ChangeStatus  ChangeKind = "status"  // status changed (From/To hold the statuses)
+	ChangeUpdated ChangeKind = "updated" // other fields changed
+
+	// ChangeAssigned is an owner change; only recorded in the history
+	// (From/To hold the owners), DiffTasks reports it as another kind
+	ChangeAssigned ChangeKind = "assigned"
+)
+
+// Change is a single task-level difference between two task lists
+type Change struct {
+	Kind    ChangeKind
+	TaskID  string
+	Subject string
+	From    string // previous status (ChangeStatus only)
+	To      string // new status (ChangeStatus only)
+}
+
+// DiffTasks compares two task lists and returns the changes, ordered by task ID
+func DiffTasks(old, new []Task) []Change {
+	oldByID := make(map[string]Task, len(old))
+	for _, task := range old {
+		oldByID[task.ID] = task
+	}
+	newByID := make(map[string]Task, len(new))
+	for _, task := range new {
+		newByID[task.ID] = task
+	}
+
+	var changes []Change
+	for _, task := range new {
+		prev, ok := oldByID[task.ID]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Kind: ChangeCreated, TaskID: task.ID, Subject: task.Subject})
+		case prev.Status != task.Status:
+			changes = append(changes, Change{Kind: ChangeStatus, TaskID: task.ID, Subject: task.Subject, From: prev.Status, To: task.Status})
+		case !tasksEqual(prev, task):
+			changes = append(changes, Change{Kind: ChangeUpdated, TaskID: task.ID, Subject: task.Subject})
+		}
+	}
+	for _, task := range old {
+		if _, ok := newByID[task.ID]; !ok {
+			changes = append(changes, Change{Kind: ChangeDeleted, TaskID: task.ID, Subject: task.Subject})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		idI, _ := strconv.Atoi(changes[i].TaskID)
+		idJ, _ := strconv.Atoi(changes[j].TaskID)
+		return idI < idJ
+	})
+	return changes
+}
+
+// Verb returns a short imperative verb for the change (e.g. "complete")
+func (c Change) Verb() string {
+	switch c.Kind {
+	case ChangeCreated:
+		return "create"
+	case ChangeDeleted:
+		return "delete"
+	case ChangeStatus:
+		switch c.To {
+		case "completed":
+			return "complete"
+		case "in_progress":
+			return "start"
+		case "pending":
+			return "reopen"
+		}
+		return "set " + c.To
+	default:
+		return "update"
+	}
+}
+
+// String formats the change as "complete #12: Fix login"
+func (c Change) String() string {
+	return fmt.Sprintf("%s #%s: %s", c.Verb(), c.TaskID, c.Subject)
+}
+
+// DescribeChanges builds a commit-style message: a summary line plus one line per change
+func DescribeChanges(changes []Change) string {
+	if len(changes) == 0 {
+		return ""
+	}
+	if len(changes) == 1 {
+		return changes[0].String()
+	}
+
+	lines := make([]string, 0, len(changes)+2)
+	lines = append(lines, fmt.Sprintf("update %d tasks", len(changes)), "")
+	for _, c := range changes {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FieldChange is a difference in one field of a task
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// DiffTaskFields compares two versions of a task field by field. Metadata
+// keys are compared individually; unknown top-level fields are not shown.
+func DiffTaskFields(old, new Task) []FieldChange {
+	var changes []FieldChange
+	add := func(field, a, b string) {
+		if a != b {
+			changes = append(changes, FieldChange{Field: field, Old: a, New: b})
+		}
+	}
+	add("Subject", old.Subject, new.Subject)
+	add("Status", old.Status, new.Status)
+	add("Description", old.Description, new.Description)
+	add("ActiveForm", old.ActiveForm, new.ActiveForm)
+	add("Owner", old.Owner, new.Owner)
+	add("Blocks", strings.Join(old.Blocks, ", "), strings.Join(new.Blocks, ", "))
+	add("BlockedBy", strings.Join(old.BlockedBy, ", "), strings.Join(new.BlockedBy, ", "))
+
+	keys := make(map[string]bool)
+	for key := range old.Metadata {
+		keys[key] = true
+	}
+	for key := range new.Metadata {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		add(key, metadataText(old.Metadata, key), metadataText(new.Metadata, key))
+	}
+	return changes
+}
+
+// metadataText formats a metadata value for display ("" when absent)
+func metadataText(metadata map[string]interface{}, key string) string {
+	value, ok := metadata[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if values, ok := value.([]interface{}); ok {
+		parts := make([]string, len(values))
+		for i, v := range values {
+			parts[i] = fmt.Sprint(v)
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprint(value)
+}
+
+// cloneTask returns a copy of task that shares no slices or maps with it
+func cloneTask(task Task) Task {
+	clone := task
+	if task.Blocks != nil {
+		clone.Blocks = append([]string{}, task.Blocks...)
+	}
+	if task.BlockedBy != nil {
+		clone.BlockedBy = append([]string{}, task.BlockedBy...)
+	}
+	if task.Metadata != nil {
+		clone.Metadata = make(map[string]interface{}, len(task.Metadata))
+		for k, v := range task.Metadata {
+			clone.Metadata[k] = v
+		}
+	}
+	return clone
+}
+
+// cloneTasks deep-copies a task list (see cloneTask)
+func cloneTasks(tasks []Task) []Task {
+	clones := make([]Task, len(tasks))
+	for i, task := range tasks {
+		clones[i] = cloneTask(task)
+	}
+	return clones
+}
+
+// tasksEqual reports whether two tasks have identical contents.
+// Nil and empty slices/maps are treated as equal.
+func tasksEqual(a, b Task) bool {
+	if a.ID != b.ID || a.Subject != b.Subject || a.Description != b.Description ||
+		a.ActiveForm != b.ActiveForm || a.Status != b.Status || a.Owner != b.Owner {
+		return false
+	}
+	if !stringSlicesEqual(a.Blocks, b.Blocks) || !stringSlicesEqual(a.BlockedBy, b.BlockedBy) {
+		return false
+	}
+	if (len(a.Metadata) > 0 || len(b.Metadata) > 0) && !reflect.DeepEqual(a.Metadata, b.Metadata) {
+		return false
+	}
+	if (len(a.Extra) > 0 || len(b.Extra) > 0) && !reflect.DeepEqual(a.Extra, b.Extra) {
+		return false
+	}
+	return true
+}
+
+// stringSlicesEqual compares two string slices element by element
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
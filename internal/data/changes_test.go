@@ -0,0 +1,90 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffTasks(t *testing.T) {
+	old := []Task{
+		{ID: "1", Subject: "Keep", Status: "pending", Blocks: nil},
+		{ID: "2", Subject: "Fix login", Status: "in_progress"},
+		{ID: "3", Subject: "Rename me", Status: "pending"},
+		{ID: "4", Subject: "Remove me", Status: "pending"},
+	}
+	new := []Task{
+		{ID: "1", Subject: "Keep", Status: "pending", Blocks: []string{}},
+		{ID: "2", Subject: "Fix login", Status: "completed"},
+		{ID: "3", Subject: "Renamed", Status: "pending"},
+		{ID: "5", Subject: "Brand new", Status: "pending"},
+	}
+
+	changes := DiffTasks(old, new)
+	expected := []string{
+		"complete #2: Fix login",
+		"update #3: Renamed",
+		"delete #4: Remove me",
+		"create #5: Brand new",
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %v", len(expected), len(changes), changes)
+	}
+	for i, c := range changes {
+		if c.String() != expected[i] {
+			t.Errorf("Change %d: expected %q, got %q", i, expected[i], c.String())
+		}
+	}
+}
+
+func TestDiffTasksMetadataChange(t *testing.T) {
+	task := Task{ID: "1", Subject: "Task", Metadata: map[string]interface{}{"group": "A"}}
+	old := cloneTasks([]Task{task})
+
+	SetTaskGroup(&task, "B")
+	changes := DiffTasks(old, []Task{task})
+	if len(changes) != 1 || changes[0].Kind != ChangeUpdated {
+		t.Errorf("Expected one update for a group change, got %v", changes)
+	}
+}
+
+func TestDescribeChanges(t *testing.T) {
+	if msg := DescribeChanges(nil); msg != "" {
+		t.Errorf("Expected empty message, got %q", msg)
+	}
+
+	single := []Change{{Kind: ChangeStatus, TaskID: "12", Subject: "Fix login", To: "completed"}}
+	if msg := DescribeChanges(single); msg != "complete #12: Fix login" {
+		t.Errorf("Unexpected single-change message: %q", msg)
+	}
+
+	multi := append(single, Change{Kind: ChangeCreated, TaskID: "13", Subject: "Next"})
+	msg := DescribeChanges(multi)
+	if !strings.HasPrefix(msg, "update 2 tasks\n\n") || !strings.Contains(msg, "create #13: Next") {
+		t.Errorf("Unexpected multi-change message: %q", msg)
+	}
+}
+
+func TestDiffTaskFields(t *testing.T) {
+	old := Task{ID: "1", Subject: "Fix login", Status: "pending", Blocks: []string{"2"},
+		Metadata: map[string]interface{}{"group": "Backend", "tags": []interface{}{"auth"}}}
+	new := Task{ID: "1", Subject: "Fix login", Status: "in_progress", Blocks: []string{"2", "3"},
+		Metadata: map[string]interface{}{"tags": []interface{}{"auth", "urgent"}}}
+
+	var got []string
+	for _, c := range DiffTaskFields(old, new) {
+		got = append(got, c.Field+": "+c.Old+" -> "+c.New)
+	}
+	want := []string{
+		"Status: pending -> in_progress",
+		"Blocks: 2 -> 2, 3",
+		"group: Backend -> ",
+		"tags: auth -> auth, urgent",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("DiffTaskFields =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if changes := DiffTaskFields(old, cloneTask(old)); len(changes) != 0 {
+		t.Errorf("Expected no changes for identical tasks, got %v", changes)
+	}
+}
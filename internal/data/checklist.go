@@ -0,0 +1,58 @@
+package data
+
+// ChecklistItem is one step of a task's checklist (metadata "checklist"),
+// a lighter alternative to splitting the task into subtasks
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// GetTaskChecklist returns the checklist in task metadata (items without
+// text are skipped)
+func GetTaskChecklist(task Task) []ChecklistItem {
+	if task.Metadata == nil {
+		return nil
+	}
+	switch items := task.Metadata["checklist"].(type) {
+	case []ChecklistItem:
+		return items
+	case []interface{}:
+		var result []ChecklistItem
+		for _, raw := range items {
+			fields, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			text, _ := fields["text"].(string)
+			done, _ := fields["done"].(bool)
+			if text != "" {
+				result = append(result, ChecklistItem{Text: text, Done: done})
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// SetTaskChecklist sets the checklist in task metadata, removing it when empty
+func SetTaskChecklist(task *Task, items []ChecklistItem) {
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	if len(items) == 0 {
+		delete(task.Metadata, "checklist")
+	} else {
+		task.Metadata["checklist"] = items
+	}
+}
+
+// ChecklistProgress returns the number of done and total checklist items
+func ChecklistProgress(task Task) (done, total int) {
+	items := GetTaskChecklist(task)
+	for _, item := range items {
+		if item.Done {
+			done++
+		}
+	}
+	return done, len(items)
+}
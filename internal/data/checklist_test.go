@@ -0,0 +1,44 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTaskChecklist(t *testing.T) {
+	var task Task
+	if err := json.Unmarshal([]byte(`{"id":"1","subject":"Release","status":"pending","metadata":{"checklist":[
+		{"text":"Tag the commit","done":true},
+		{"text":"Publish binaries"},
+		{"done":true},
+		"stray"
+	]}}`), &task); err != nil {
+		t.Fatal(err)
+	}
+	items := GetTaskChecklist(task)
+	if len(items) != 2 || items[0] != (ChecklistItem{Text: "Tag the commit", Done: true}) || items[1].Done {
+		t.Fatalf("checklist = %+v", items)
+	}
+	if done, total := ChecklistProgress(task); done != 1 || total != 2 {
+		t.Errorf("progress = %d/%d, want 1/2", done, total)
+	}
+
+	items = append(items, ChecklistItem{Text: "Announce"})
+	SetTaskChecklist(&task, items)
+	b, err := json.Marshal(task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reloaded Task
+	if err := json.Unmarshal(b, &reloaded); err != nil {
+		t.Fatal(err)
+	}
+	if got := GetTaskChecklist(reloaded); len(got) != 3 || got[2].Text != "Announce" {
+		t.Errorf("checklist after round-trip = %+v", got)
+	}
+
+	SetTaskChecklist(&task, nil)
+	if _, ok := task.Metadata["checklist"]; ok {
+		t.Error("an empty checklist should be removed from metadata")
+	}
+}
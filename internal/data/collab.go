@@ -0,0 +1,354 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// ConflictsDirName is the folder inside a project directory keeping the
+// versions of task files that lost a field in a collaboration merge
+const ConflictsDirName = "_conflicts"
+
+// presenceWindow is how far back the changes of collaborators are shown
+const presenceWindow = 24 * time.Hour
+
+// Collaborating reports whether a project is in collaboration mode
+func Collaborating(projectName string) bool {
+	return config.Current().Collaboration.Enabled(projectName)
+}
+
+// CollaborationUser returns the name recorded on changes in collaboration mode
+func CollaborationUser() string {
+	if name := config.Current().Collaboration.User; name != "" {
+		return name
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
+
+// collaborating reports whether the store's project is in collaboration mode
+func (s *TaskStore) collaborating() bool {
+	return s.ProjectName != "" && Collaborating(s.ProjectName)
+}
+
+// Presence is the latest change a collaborator made to the project
+type Presence struct {
+	User   string
+	Action string // "created", "started", "completed", "assigned", "deleted" or "edited"
+	TaskID string
+	Time   time.Time
+}
+
+// Presence returns the latest change of each other collaborator in the last
+// day, newest first, as of the load (nil outside collaboration mode)
+func (s *TaskStore) Presence() []Presence {
+	return s.presence
+}
+
+// loadPresence reads the collaborators' latest changes from the history log
+func (s *TaskStore) loadPresence(now time.Time) {
+	history, err := s.History()
+	if err != nil {
+		return
+	}
+	s.presence = collaborators(history, CollaborationUser(), now)
+}
+
+// collaborators returns the latest change of each user other than me within
+// presenceWindow, newest first
+func collaborators(history []HistoryEntry, me string, now time.Time) []Presence {
+	var presence []Presence
+	seen := make(map[string]bool)
+	for i := len(history) - 1; i >= 0; i-- {
+		e := history[i]
+		if now.Sub(e.Time) > presenceWindow {
+			break
+		}
+		user := strings.ToLower(e.User)
+		if e.External || user == "" || user == strings.ToLower(me) || seen[user] {
+			continue
+		}
+		seen[user] = true
+		presence = append(presence, Presence{User: e.User, Action: presenceAction(e), TaskID: e.TaskID, Time: e.Time})
+	}
+	return presence
+}
+
+// presenceAction names what a history entry did to its task
+func presenceAction(e HistoryEntry) string {
+	switch e.Kind {
+	case ChangeCreated:
+		return "created"
+	case ChangeDeleted:
+		return "deleted"
+	case ChangeAssigned:
+		return "assigned"
+	case ChangeStatus:
+		switch e.To {
+		case "completed":
+			return "completed"
+		case "in_progress":
+			return "started"
+		}
+	}
+	return "edited"
+}
+
+// reconcile prepares a save in collaboration mode, so nobody's change is
+// overwritten: new tasks whose ID someone else took meanwhile get the next
+// free ID, and tasks whose file someone else changed since the load are
+// merged with that version. Only the one-file-per-task layout is reconciled.
+func (s *TaskStore) reconcile() error {
+	if s.layout == LayoutSingle || s.files == nil {
+		return nil
+	}
+	projectDir, err := s.dir()
+	if err != nil {
+		return err
+	}
+	saved := make(map[string]bool, len(s.saved))
+	for _, task := range s.saved {
+		saved[task.ID] = true
+	}
+
+	for _, task := range s.dirtyTasks() {
+		path, err := s.TaskFilePath(task.ID)
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // not on disk: nothing to overwrite
+		}
+		if !saved[task.ID] {
+			if IsUUID(task.ID) {
+				continue
+			}
+			id := strconv.Itoa(max(s.nextTaskNumber(), maxFileNumber(projectDir)+1))
+			slog.Debug("task ID taken by a collaborator", "project", s.ProjectName, "id", task.ID, "new", id)
+			s.changeID(task.ID, id)
+			continue
+		}
+		if stamp, ok := s.files[filepath.Base(path)]; ok && stamp.equal(stampOf(info)) {
+			continue // unchanged since the load
+		}
+		if err := s.mergeFromDisk(task, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeFromDisk merges a task's file as someone else saved it into the
+// store; where both changed a field, ours is kept and their file is copied
+// to the conflicts folder
+func (s *TaskStore) mergeFromDisk(ours Task, path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil // gone meanwhile: ours is written
+	}
+	plain, err := openTaskData(s.key, raw)
+	if err != nil {
+		return err
+	}
+	var theirs Task
+	if err := json.Unmarshal(plain, &theirs); err != nil {
+		return nil // unparsable: ours replaces it, as before collaboration mode
+	}
+
+	var base Task
+	for _, task := range s.saved {
+		if task.ID == ours.ID {
+			base = task
+		}
+	}
+	merged, conflicts, err := mergeTask(base, ours, theirs)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		kept, err := keepConflict(filepath.Dir(path), ours.ID, raw, time.Now())
+		if err != nil {
+			return err
+		}
+		slog.Debug("collaboration conflict", "project", s.ProjectName, "task", ours.ID, "fields", conflicts, "kept", kept)
+	}
+	s.UpdateTask(merged)
+
+	// Their changes are theirs: the history and notifications of this save
+	// cover ours only
+	for i := range s.saved {
+		if s.saved[i].ID == theirs.ID {
+			s.saved[i] = theirs
+		}
+	}
+	return nil
+}
+
+// keepConflict copies a task file that lost a merge to the conflicts folder
+func keepConflict(projectDir, id string, content []byte, now time.Time) (string, error) {
+	dir := filepath.Join(projectDir, ConflictsDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.json", id, now.Format("20060102-150405")))
+	return path, os.WriteFile(path, content, 0644)
+}
+
+// mergeTask merges the changes from base in ours and theirs field by field,
+// metadata keys separately. Where both changed a field differently, ours
+// wins and the field is listed in conflicts.
+func mergeTask(base, ours, theirs Task) (Task, []string, error) {
+	b, err := taskFieldMap(base)
+	if err != nil {
+		return Task{}, nil, err
+	}
+	o, err := taskFieldMap(ours)
+	if err != nil {
+		return Task{}, nil, err
+	}
+	t, err := taskFieldMap(theirs)
+	if err != nil {
+		return Task{}, nil, err
+	}
+
+	keys := make(map[string]bool)
+	for _, m := range []map[string]json.RawMessage{b, o, t} {
+		for key := range m {
+			keys[key] = true
+		}
+	}
+	merged := make(map[string]json.RawMessage)
+	var conflicts []string
+	for key := range keys {
+		bv, inBase := b[key]
+		ov, inOurs := o[key]
+		tv, inTheirs := t[key]
+		take, ok := ov, inOurs
+		switch {
+		case inOurs == inBase && bytes.Equal(ov, bv):
+			take, ok = tv, inTheirs // only they changed it
+		case inTheirs == inBase && bytes.Equal(tv, bv):
+		case inOurs == inTheirs && bytes.Equal(ov, tv):
+		default:
+			conflicts = append(conflicts, key)
+		}
+		if ok {
+			merged[key] = take
+		}
+	}
+
+	task, err := taskFromFieldMap(merged)
+	return task, conflicts, err
+}
+
+// taskFieldMap flattens a task's JSON into its fields, with the metadata
+// keys as "metadata.<key>"
+func taskFieldMap(task Task) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	if raw, ok := fields["metadata"]; ok {
+		delete(fields, "metadata")
+		var metadata map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &metadata); err != nil {
+			return nil, err
+		}
+		for key, value := range metadata {
+			fields["metadata."+key] = value
+		}
+	}
+	return fields, nil
+}
+
+// taskFromFieldMap builds a task back from taskFieldMap's fields
+func taskFromFieldMap(fields map[string]json.RawMessage) (Task, error) {
+	top := make(map[string]json.RawMessage)
+	metadata := make(map[string]json.RawMessage)
+	for key, value := range fields {
+		if name, ok := strings.CutPrefix(key, "metadata."); ok {
+			metadata[name] = value
+		} else {
+			top[key] = value
+		}
+	}
+	if len(metadata) > 0 {
+		raw, err := json.Marshal(metadata)
+		if err != nil {
+			return Task{}, err
+		}
+		top["metadata"] = raw
+	}
+	b, err := json.Marshal(top)
+	if err != nil {
+		return Task{}, err
+	}
+	var task Task
+	err = json.Unmarshal(b, &task)
+	return task, err
+}
+
+// changeID gives a task a new ID, updating the references to it
+func (s *TaskStore) changeID(oldID, newID string) {
+	for i := range s.Tasks {
+		task := &s.Tasks[i]
+		if task.ID == oldID {
+			task.ID = newID
+		}
+		for j, id := range task.Blocks {
+			if id == oldID {
+				task.Blocks[j] = newID
+			}
+		}
+		for j, id := range task.BlockedBy {
+			if id == oldID {
+				task.BlockedBy[j] = newID
+			}
+		}
+	}
+}
+
+// maxFileNumber returns the highest numeric task file name in a directory
+func maxFileNumber(dir string) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+	maxNum := 0
+	for _, entry := range entries {
+		if n, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ".json")); err == nil {
+			maxNum = max(maxNum, n)
+		}
+	}
+	return maxNum
+}
+
+// writeFileAtomic writes a file through a temporary file renamed over it, so
+// a sync client or a collaborator never reads a half-written task
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestMergeTask(t *testing.T) {
+	base := Task{ID: "1", Subject: "Draft", Status: "pending", Metadata: map[string]interface{}{"group": "Docs", "priority": "low"}}
+	ours := base
+	ours.Subject = "Write the draft"
+	ours.Metadata = map[string]interface{}{"group": "Docs", "priority": "high"}
+	theirs := base
+	theirs.Status = "in_progress"
+	theirs.Owner = "bob"
+	theirs.Metadata = map[string]interface{}{"group": "Docs", "priority": "medium", "estimate": 2.0}
+
+	merged, conflicts, err := mergeTask(base, ours, theirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Subject != "Write the draft" || merged.Status != "in_progress" || merged.Owner != "bob" {
+		t.Errorf("merged = %+v", merged)
+	}
+	if GetTaskPriority(merged) != "high" || GetTaskEstimate(merged) != 2 || GetTaskGroup(merged) != "Docs" {
+		t.Errorf("merged metadata = %+v", merged.Metadata)
+	}
+	if !reflect.DeepEqual(conflicts, []string{"metadata.priority"}) {
+		t.Errorf("conflicts = %v, want the priority both changed", conflicts)
+	}
+}
+
+func TestCollaborationSave(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+	cfg := config.Default()
+	cfg.Collaboration = config.CollaborationConfig{Projects: []string{"shared"}, User: "alice"}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	projectDir := filepath.Join(tasksDir, "shared")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	setup, err := LoadTasks("shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setup.AddTask(Task{Subject: "Shared task", Status: "pending"})
+	if err := setup.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Alice and Bob both have the project open
+	mine, err := LoadTasks("shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	theirs, err := LoadTasks("shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Collaboration.User = "bob"
+	theirs.Tasks[0].Status = "in_progress"
+	theirs.AddTask(Task{Subject: "Bob's task", Status: "pending"})
+	if err := theirs.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Collaboration.User = "alice"
+	mine.Tasks[0].Subject = "Shared task, renamed"
+	mine.AddTask(Task{Subject: "Alice's task", Status: "pending"})
+	if err := mine.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := LoadTasks("shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task := store.GetTask("1"); task == nil || task.Subject != "Shared task, renamed" || task.Status != "in_progress" {
+		t.Errorf("task 1 = %+v, want both changes", task)
+	}
+	if store.GetTask("2") == nil || store.GetTask("2").Subject != "Bob's task" {
+		t.Errorf("Bob's task was overwritten: %+v", store.GetTask("2"))
+	}
+	if store.GetTask("3") == nil || store.GetTask("3").Subject != "Alice's task" {
+		t.Errorf("Alice's task should get the next free ID: %+v", store.Tasks)
+	}
+
+	// Alice's history covers her own changes only; Bob's are his
+	history, _ := store.History()
+	users := make(map[string]int)
+	for _, e := range history {
+		users[e.User]++
+	}
+	if users["bob"] != 2 || users["alice"] != 3 {
+		t.Errorf("history by user = %v, want bob 2 (status, create), alice 3 (setup create, rename, create)", users)
+	}
+
+	// Reloading does not log Bob's changes again as external ones
+	store.RecordExternalChanges(mine)
+	if again, _ := store.History(); len(again) != len(history) {
+		t.Errorf("history after the reload has %d entries, want %d", len(again), len(history))
+	}
+
+	if presence := store.Presence(); len(presence) != 1 || presence[0].User != "bob" || presence[0].TaskID != "2" || presence[0].Action != "created" {
+		t.Errorf("presence = %+v, want Bob creating #2", presence)
+	}
+}
+
+func TestCollaborators(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	history := []HistoryEntry{
+		{Time: now.Add(-48 * time.Hour), Kind: ChangeUpdated, TaskID: "1", User: "carol"},
+		{Time: now.Add(-3 * time.Hour), Kind: ChangeStatus, TaskID: "2", To: "completed", User: "bob"},
+		{Time: now.Add(-2 * time.Hour), Kind: ChangeUpdated, TaskID: "3", User: "Alice"},
+		{Time: now.Add(-time.Hour), Kind: ChangeUpdated, TaskID: "4", User: "bob"},
+		{Time: now.Add(-time.Minute), Kind: ChangeStatus, TaskID: "5", To: "completed", External: true},
+	}
+	got := collaborators(history, "alice", now)
+	want := []Presence{{User: "bob", Action: "edited", TaskID: "4", Time: now.Add(-time.Hour)}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("collaborators = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,137 @@
+package data
+
+import "fmt"
+
+// AddDependency records that blockerID blocks blockedID, updating both tasks
+func (s *TaskStore) AddDependency(blockerID, blockedID string) error {
+	if blockerID == blockedID {
+		return fmt.Errorf("task #%s cannot depend on itself", blockerID)
+	}
+	blocker, blocked := s.GetTask(blockerID), s.GetTask(blockedID)
+	if blocker == nil {
+		return fmt.Errorf("task not found: %s", blockerID)
+	}
+	if blocked == nil {
+		return fmt.Errorf("task not found: %s", blockedID)
+	}
+	if s.dependsOn(blockerID, blockedID) {
+		return fmt.Errorf("#%s already depends on #%s; adding this would create a cycle", blockerID, blockedID)
+	}
+
+	if !containsString(blocker.Blocks, blockedID) {
+		blocker.Blocks = append(blocker.Blocks, blockedID)
+	}
+	if !containsString(blocked.BlockedBy, blockerID) {
+		blocked.BlockedBy = append(blocked.BlockedBy, blockerID)
+	}
+	return nil
+}
+
+// RemoveDependency removes the blockerID -> blockedID edge from both tasks
+func (s *TaskStore) RemoveDependency(blockerID, blockedID string) {
+	if blocker := s.GetTask(blockerID); blocker != nil {
+		blocker.Blocks = removeFromSlice(blocker.Blocks, blockedID)
+	}
+	if blocked := s.GetTask(blockedID); blocked != nil {
+		blocked.BlockedBy = removeFromSlice(blocked.BlockedBy, blockerID)
+	}
+}
+
+// dependsOn reports whether task id is (transitively) blocked by target
+func (s *TaskStore) dependsOn(id, target string) bool {
+	visited := make(map[string]bool)
+	stack := []string{id}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		task := s.GetTask(current)
+		if task == nil {
+			continue
+		}
+		for _, dep := range task.BlockedBy {
+			if dep == target {
+				return true
+			}
+			stack = append(stack, dep)
+		}
+		// Also follow edges recorded only on the blocker side
+		for _, other := range s.Tasks {
+			if other.ID != current && containsString(other.Blocks, current) {
+				if other.ID == target {
+					return true
+				}
+				stack = append(stack, other.ID)
+			}
+		}
+	}
+	return false
+}
+
+// DependencyCycle reports whether giving task id the dependency lists blocks
+// and blockedBy would create a cycle, counting the edges other tasks already
+// record for id; id is "" for a task that is not added yet.
+func (s *TaskStore) DependencyCycle(id string, blocks, blockedBy []string) bool {
+	if id == "" {
+		id = "\x00new"
+	}
+	planned := &TaskStore{}
+	for _, task := range s.Tasks {
+		if task.ID == id {
+			continue
+		}
+		planned.Tasks = append(planned.Tasks, task)
+	}
+	planned.Tasks = append(planned.Tasks, Task{ID: id, Blocks: blocks, BlockedBy: blockedBy})
+	return planned.dependsOn(id, id)
+}
+
+// DependencyChain returns the tasks task id transitively depends on (its
+// BlockedBy ancestors) when upstream is set, or else the tasks transitively
+// depending on it (its Blocks descendants), in file order and without id.
+// Edges recorded on either side count.
+func (s *TaskStore) DependencyChain(id string, upstream bool) []string {
+	// next maps a task to its neighbours in the chosen direction
+	next := make(map[string][]string)
+	for _, task := range s.Tasks {
+		for _, dep := range task.BlockedBy {
+			if upstream {
+				next[task.ID] = append(next[task.ID], dep)
+			} else {
+				next[dep] = append(next[dep], task.ID)
+			}
+		}
+		for _, dep := range task.Blocks {
+			if upstream {
+				next[dep] = append(next[dep], task.ID)
+			} else {
+				next[task.ID] = append(next[task.ID], dep)
+			}
+		}
+	}
+
+	reached := map[string]bool{id: true}
+	stack := []string{id}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, other := range next[current] {
+			if !reached[other] {
+				reached[other] = true
+				stack = append(stack, other)
+			}
+		}
+	}
+
+	var chain []string
+	for _, task := range s.Tasks {
+		if reached[task.ID] && task.ID != id {
+			chain = append(chain, task.ID)
+		}
+	}
+	return chain
+}
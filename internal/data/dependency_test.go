@@ -0,0 +1,99 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddRemoveDependency(t *testing.T) {
+	store := &TaskStore{Tasks: []Task{
+		{ID: "1", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "2", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "3", Blocks: []string{}, BlockedBy: []string{}},
+	}}
+
+	if err := store.AddDependency("1", "2"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if !containsString(store.GetTask("1").Blocks, "2") || !containsString(store.GetTask("2").BlockedBy, "1") {
+		t.Error("Expected edge recorded on both tasks")
+	}
+
+	// Adding twice does not duplicate
+	store.AddDependency("1", "2")
+	if len(store.GetTask("1").Blocks) != 1 {
+		t.Errorf("Expected no duplicate edge, got %v", store.GetTask("1").Blocks)
+	}
+
+	store.AddDependency("2", "3")
+	if err := store.AddDependency("3", "1"); err == nil {
+		t.Error("Expected cycle to be rejected")
+	}
+	if err := store.AddDependency("1", "1"); err == nil {
+		t.Error("Expected self-dependency to be rejected")
+	}
+	if err := store.AddDependency("1", "99"); err == nil {
+		t.Error("Expected unknown task to be rejected")
+	}
+
+	store.RemoveDependency("1", "2")
+	if len(store.GetTask("1").Blocks) != 0 || len(store.GetTask("2").BlockedBy) != 0 {
+		t.Error("Expected edge removed from both tasks")
+	}
+}
+
+func TestDependencyCycle(t *testing.T) {
+	store := &TaskStore{Tasks: []Task{
+		{ID: "1", Blocks: []string{"2"}, BlockedBy: []string{}},
+		{ID: "2", Blocks: []string{}, BlockedBy: []string{"1"}},
+		{ID: "3", Blocks: []string{}, BlockedBy: []string{}},
+	}}
+
+	if !store.DependencyCycle("1", []string{"2"}, []string{"2"}) {
+		t.Error("Expected blocking and waiting for the same task to be a cycle")
+	}
+	if !store.DependencyCycle("3", []string{"1"}, []string{"2"}) {
+		t.Error("Expected 1 -> 2 -> 3 -> 1 to be a cycle")
+	}
+	if store.DependencyCycle("3", []string{}, []string{"2"}) {
+		t.Error("Expected 2 -> 3 not to be a cycle")
+	}
+	if !store.DependencyCycle("", []string{"1"}, []string{"2"}) {
+		t.Error("Expected a new task between 2 and 1 to be a cycle")
+	}
+
+	// The edge recorded only by 2 still counts
+	if !store.DependencyCycle("1", []string{}, []string{"2"}) {
+		t.Error("Expected the edge recorded by 2 to count")
+	}
+}
+
+func TestDependencyChain(t *testing.T) {
+	// 1 -> 2 -> 4 and 3 -> 4 -> 5; the 3 -> 4 edge is recorded on 3 only
+	store := &TaskStore{Tasks: []Task{
+		{ID: "1", Blocks: []string{"2"}},
+		{ID: "2", Blocks: []string{"4"}, BlockedBy: []string{"1"}},
+		{ID: "3", Blocks: []string{"4"}},
+		{ID: "4", Blocks: []string{"5"}, BlockedBy: []string{"2"}},
+		{ID: "5", BlockedBy: []string{"4"}},
+		{ID: "6"},
+	}}
+
+	tests := []struct {
+		id       string
+		upstream bool
+		want     string
+	}{
+		{"4", true, "1,2,3"},
+		{"4", false, "5"},
+		{"1", false, "2,4,5"},
+		{"1", true, ""},
+		{"6", false, ""},
+	}
+	for _, tt := range tests {
+		got := strings.Join(store.DependencyChain(tt.id, tt.upstream), ",")
+		if got != tt.want {
+			t.Errorf("DependencyChain(%s, %v) = %q, want %q", tt.id, tt.upstream, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package data
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// EncryptionFileName holds the key derivation parameters of an encrypted
+// project. It is backed up with the task files, so snapshots of an
+// encrypted project can be decrypted with the same passphrase.
+const EncryptionFileName = "_encryption.json"
+
+// PassphraseEnv is the environment variable unlocking encrypted projects
+// without a prompt, e.g. for subcommands and scripts
+const PassphraseEnv = "CCTASKS_PASSPHRASE"
+
+// sealedFormat marks the content of an encrypted file
+const sealedFormat = "cctasks-aes256gcm-v1"
+
+// encryptionCheck is sealed into the header to verify passphrases
+const encryptionCheck = "cctasks"
+
+// kdfIterations is the PBKDF2-HMAC-SHA256 work factor of new projects
+var kdfIterations = 600000
+
+var (
+	// ErrLocked is returned when loading an encrypted project that has not
+	// been unlocked with its passphrase
+	ErrLocked = errors.New("project is encrypted; enter its passphrase or set $CCTASKS_PASSPHRASE")
+	// ErrWrongPassphrase is returned when a passphrase does not unlock a project
+	ErrWrongPassphrase = errors.New("wrong passphrase")
+)
+
+// encryptionHeader is the content of EncryptionFileName
+type encryptionHeader struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Check      []byte `json:"check"` // encryptionCheck sealed with the key
+}
+
+// sealedFile is the content of an encrypted file: still JSON, so tools
+// listing the project do not choke on it
+type sealedFile struct {
+	Format string `json:"cctasksEncrypted"`
+	Nonce  []byte `json:"nonce"`
+	Data   []byte `json:"data"`
+}
+
+// projectKeys caches the keys of unlocked projects by project directory for
+// the rest of the process
+var projectKeys = struct {
+	sync.Mutex
+	keys map[string][]byte
+}{keys: map[string][]byte{}}
+
+// IsEncrypted reports whether a project directory is encrypted
+func IsEncrypted(projectDir string) bool {
+	_, err := os.Stat(filepath.Join(projectDir, EncryptionFileName))
+	return err == nil
+}
+
+// IsProjectEncrypted reports whether a project is encrypted
+func IsProjectEncrypted(projectName string) bool {
+	projectDir, err := config.GetProjectDir(projectName)
+	return err == nil && IsEncrypted(projectDir)
+}
+
+// UnlockProject checks a passphrase of an encrypted project and keeps its
+// key, so the project can be loaded until cctasks exits
+func UnlockProject(projectName, passphrase string) error {
+	projectDir, err := config.GetProjectDir(projectName)
+	if err != nil {
+		return err
+	}
+	key, err := unlockDir(projectDir, passphrase)
+	if err != nil {
+		return err
+	}
+	projectKeys.Lock()
+	projectKeys.keys[projectDir] = key
+	projectKeys.Unlock()
+	return nil
+}
+
+// unlockDir derives the key of an encrypted project directory and checks it
+func unlockDir(projectDir, passphrase string) ([]byte, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, EncryptionFileName))
+	if err != nil {
+		return nil, err
+	}
+	var header encryptionHeader
+	if err := json.Unmarshal(content, &header); err != nil {
+		return nil, fmt.Errorf("%s: %w", EncryptionFileName, err)
+	}
+	if header.Version != 1 || header.KDF != "pbkdf2-sha256" || header.Iterations <= 0 {
+		return nil, fmt.Errorf("%s: unsupported encryption (version %d, kdf %q)", EncryptionFileName, header.Version, header.KDF)
+	}
+	key := deriveKey(passphrase, header.Salt, header.Iterations)
+	check, err := openSealed(key, header.Check)
+	if err != nil || string(check) != encryptionCheck {
+		return nil, ErrWrongPassphrase
+	}
+	return key, nil
+}
+
+// projectKey returns the key of an encrypted project directory: the one it
+// was unlocked with, or derived from $CCTASKS_PASSPHRASE. It returns
+// ErrLocked when neither is available.
+func projectKey(projectDir string) ([]byte, error) {
+	projectKeys.Lock()
+	key := projectKeys.keys[projectDir]
+	projectKeys.Unlock()
+	if key != nil {
+		return key, nil
+	}
+	passphrase := os.Getenv(PassphraseEnv)
+	if passphrase == "" {
+		return nil, ErrLocked
+	}
+	key, err := unlockDir(projectDir, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("$%s: %w", PassphraseEnv, err)
+	}
+	projectKeys.Lock()
+	projectKeys.keys[projectDir] = key
+	projectKeys.Unlock()
+	return key, nil
+}
+
+// EncryptProject encrypts the task files and history of a project with a
+// passphrase and returns the number of task files encrypted. The project
+// is backed up first; then the task files in its backup snapshots are
+// encrypted too and uploaded again to the remotes, replacing the plain ones.
+func EncryptProject(projectName, passphrase string) (int, error) {
+	if passphrase == "" {
+		return 0, errors.New("the passphrase must not be empty")
+	}
+	if err := checkProjectWritable(projectName); err != nil {
+		return 0, err
+	}
+	projectDir, err := config.GetProjectDir(projectName)
+	if err != nil {
+		return 0, err
+	}
+	if IsEncrypted(projectDir) {
+		return 0, fmt.Errorf("%s is already encrypted", projectName)
+	}
+	if DetectLayout(projectDir) == LayoutSingle {
+		return 0, fmt.Errorf("%s keeps its tasks in %s; run 'cctasks convert --to %s %s' first", projectName, LegacyTasksFile, LayoutFiles, projectName)
+	}
+	if err := SnapshotProject(projectName); err != nil {
+		return 0, err
+	}
+	store, err := LoadTasks(projectName)
+	if err != nil {
+		return 0, err
+	}
+	if len(store.Unparsable) > 0 {
+		return 0, fmt.Errorf("%d task file(s) could not be parsed; fix or remove them first", len(store.Unparsable))
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return 0, err
+	}
+	header := encryptionHeader{Version: 1, KDF: "pbkdf2-sha256", Iterations: kdfIterations, Salt: salt}
+	key := deriveKey(passphrase, salt, header.Iterations)
+	if header.Check, err = seal(key, []byte(encryptionCheck)); err != nil {
+		return 0, err
+	}
+	content, err := json.MarshalIndent(header, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, EncryptionFileName), content, 0644); err != nil {
+		return 0, err
+	}
+	n, err := rewriteProjectFiles(projectDir, store, func(content []byte) ([]byte, error) {
+		return seal(key, content)
+	})
+	if err != nil {
+		return n, err
+	}
+	if err := encryptSnapshots(projectName, key, content); err != nil {
+		return n, fmt.Errorf("encrypting the backups: %w", err)
+	}
+	return n, nil
+}
+
+// encryptSnapshots encrypts the task files of a project's snapshots and adds
+// the encryption header to them, so they are restored as an encrypted
+// project; each snapshot is uploaded again to the remotes
+func encryptSnapshots(projectName string, key, header []byte) error {
+	snapshots, err := ListSnapshots(projectName)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		entries, err := os.ReadDir(snapshot.Path)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() || strings.HasPrefix(name, "_") || !strings.HasSuffix(name, ".json") {
+				continue // groups and milestones stay plain, as in the project
+			}
+			path := filepath.Join(snapshot.Path, name)
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if isSealed(content) {
+				continue
+			}
+			sealed, err := seal(key, content)
+			if err != nil {
+				return err
+			}
+			if err := writeFileAtomic(path, sealed, 0644); err != nil {
+				return err
+			}
+		}
+		if err := os.WriteFile(filepath.Join(snapshot.Path, EncryptionFileName), header, 0644); err != nil {
+			return err
+		}
+		uploadSnapshot(projectName, snapshot)
+	}
+	return nil
+}
+
+// DecryptProject turns an encrypted project back into plain task files and
+// returns the number of task files decrypted. The project is backed up first.
+func DecryptProject(projectName, passphrase string) (int, error) {
+	if err := checkProjectWritable(projectName); err != nil {
+		return 0, err
+	}
+	projectDir, err := config.GetProjectDir(projectName)
+	if err != nil {
+		return 0, err
+	}
+	if !IsEncrypted(projectDir) {
+		return 0, fmt.Errorf("%s is not encrypted", projectName)
+	}
+	if err := UnlockProject(projectName, passphrase); err != nil {
+		return 0, err
+	}
+	if err := SnapshotProject(projectName); err != nil {
+		return 0, err
+	}
+	store, err := LoadTasks(projectName)
+	if err != nil {
+		return 0, err
+	}
+	key := store.key
+	n, err := rewriteProjectFiles(projectDir, store, func(content []byte) ([]byte, error) {
+		return openTaskData(key, content)
+	})
+	if err != nil {
+		return n, err
+	}
+	projectKeys.Lock()
+	delete(projectKeys.keys, projectDir)
+	projectKeys.Unlock()
+	return n, os.Remove(filepath.Join(projectDir, EncryptionFileName))
+}
+
+// rewriteProjectFiles passes the task files, trashed tasks and history lines
+// of a loaded project through convert and writes them back, returning the
+// number of task files. Each file is replaced atomically, so a crash halfway
+// leaves every file either converted or as it was, never truncated.
+func rewriteProjectFiles(projectDir string, store *TaskStore, convert func([]byte) ([]byte, error)) (int, error) {
+	rewrite := func(path string) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		converted, err := convert(content)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		return writeFileAtomic(path, converted, 0644)
+	}
+
+	for name := range store.files {
+		if err := rewrite(filepath.Join(projectDir, name)); err != nil {
+			return 0, err
+		}
+	}
+	trashDir := filepath.Join(projectDir, TrashDirName)
+	if entries, err := os.ReadDir(trashDir); err == nil {
+		for _, entry := range entries {
+			if _, ok := parseTrashFileName(entry.Name()); ok && !entry.IsDir() {
+				if err := rewrite(filepath.Join(trashDir, entry.Name())); err != nil {
+					return 0, err
+				}
+			}
+		}
+	}
+
+	historyPath := filepath.Join(projectDir, HistoryFileName)
+	if content, err := os.ReadFile(historyPath); err == nil {
+		var buf bytes.Buffer
+		for _, line := range strings.Split(strings.TrimRight(string(content), "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			converted, err := convert([]byte(line))
+			if err != nil {
+				return 0, fmt.Errorf("%s: %w", HistoryFileName, err)
+			}
+			buf.Write(converted)
+			buf.WriteByte('\n')
+		}
+		if err := writeFileAtomic(historyPath, buf.Bytes(), 0644); err != nil {
+			return 0, err
+		}
+	}
+	return len(store.files), nil
+}
+
+// isSealed reports whether content is an encrypted file
+func isSealed(content []byte) bool {
+	if !bytes.Contains(content, []byte(sealedFormat)) {
+		return false
+	}
+	var f sealedFile
+	return json.Unmarshal(content, &f) == nil && f.Format == sealedFormat
+}
+
+// seal encrypts content with AES-256-GCM under a random nonce
+func seal(key, content []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// One line, so that history lines can be sealed too
+	return json.Marshal(sealedFile{
+		Format: sealedFormat,
+		Nonce:  nonce,
+		Data:   gcm.Seal(nil, nonce, content, []byte(sealedFormat)),
+	})
+}
+
+// openSealed decrypts the content of an encrypted file
+func openSealed(key, content []byte) ([]byte, error) {
+	var f sealedFile
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, err
+	}
+	if f.Format != sealedFormat {
+		return nil, fmt.Errorf("unknown encryption format %q", f.Format)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.Nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Data, []byte(sealedFormat))
+	if err != nil {
+		return nil, errors.New("cannot decrypt (wrong key or corrupted file)")
+	}
+	return plain, nil
+}
+
+// openTaskData returns the plain content of a task file: encrypted content
+// is decrypted with key, other content is returned as it is (e.g. a task
+// Claude Code wrote into an encrypted project, encrypted on its next save)
+func openTaskData(key, content []byte) ([]byte, error) {
+	if !isSealed(content) {
+		return content, nil
+	}
+	if key == nil {
+		return nil, ErrLocked
+	}
+	return openSealed(key, content)
+}
+
+// sealTaskData encrypts content with key, or returns it as it is without one
+func sealTaskData(key, content []byte) ([]byte, error) {
+	if key == nil {
+		return content, nil
+	}
+	return seal(key, content)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// deriveKey derives the AES-256 key of a project from its passphrase with
+// PBKDF2-HMAC-SHA256
+func deriveKey(passphrase string, salt []byte, iterations int) []byte {
+	return pbkdf2.Key([]byte(passphrase), salt, iterations, 32, sha256.New)
+}
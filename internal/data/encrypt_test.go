@@ -0,0 +1,148 @@
+package data
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestDeriveKey(t *testing.T) {
+	key := deriveKey("password", []byte("salt"), 4096)
+	if got, want := hex.EncodeToString(key), "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"; got != want {
+		t.Errorf("deriveKey = %s, want %s", got, want)
+	}
+}
+
+func TestEncryptProject(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+	t.Setenv(PassphraseEnv, "")
+	prev := kdfIterations
+	kdfIterations = 1000
+	t.Cleanup(func() { kdfIterations = prev })
+
+	projectDir := filepath.Join(tasksDir, "proj")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	store, err := LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.AddTask(Task{Subject: "Secret plan", Status: "pending"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := EncryptProject("proj", "hunter2")
+	if err != nil || n != 1 {
+		t.Fatalf("EncryptProject = %d, %v", n, err)
+	}
+	for _, name := range []string{"1.json", HistoryFileName} {
+		content, _ := os.ReadFile(filepath.Join(projectDir, name))
+		if strings.Contains(string(content), "Secret plan") || !isSealedLines(content) {
+			t.Errorf("%s is not encrypted: %s", name, content)
+		}
+	}
+	if tmp, _ := filepath.Glob(filepath.Join(projectDir, ".*.tmp")); len(tmp) > 0 {
+		t.Errorf("temp files left behind: %v", tmp)
+	}
+
+	// The backups taken before are encrypted too
+	snapshots, err := ListSnapshots("proj")
+	if err != nil || len(snapshots) == 0 {
+		t.Fatalf("ListSnapshots = %v, %v", snapshots, err)
+	}
+	for _, snapshot := range snapshots {
+		content, err := os.ReadFile(filepath.Join(snapshot.Path, "1.json"))
+		if err != nil || !isSealed(content) {
+			t.Errorf("snapshot %s: 1.json = %s, %v; want it encrypted", snapshot.Name, content, err)
+		}
+		if !IsEncrypted(snapshot.Path) {
+			t.Errorf("snapshot %s has no encryption header", snapshot.Name)
+		}
+	}
+
+	// The key is derived with the iterations recorded in the header
+	headerPath := filepath.Join(projectDir, EncryptionFileName)
+	raw, _ := os.ReadFile(headerPath)
+	var header encryptionHeader
+	if err := json.Unmarshal(raw, &header); err != nil || header.Iterations != kdfIterations {
+		t.Fatalf("header = %+v, %v; want %d iterations", header, err, kdfIterations)
+	}
+	header.Iterations++
+	altered, _ := json.Marshal(header)
+	if err := os.WriteFile(headerPath, altered, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := unlockDir(projectDir, "hunter2"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("unlockDir with other iterations = %v, want ErrWrongPassphrase", err)
+	}
+	if err := os.WriteFile(headerPath, raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadTasks("proj"); !errors.Is(err, ErrLocked) {
+		t.Fatalf("LoadTasks of a locked project = %v, want ErrLocked", err)
+	}
+	if err := UnlockProject("proj", "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("UnlockProject(wrong) = %v", err)
+	}
+	if err := UnlockProject("proj", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Loading and saving are transparent once unlocked
+	store, err = LoadTasks("proj")
+	if err != nil || len(store.Tasks) != 1 || store.Tasks[0].Subject != "Secret plan" || len(store.Problems) != 0 {
+		t.Fatalf("LoadTasks after unlocking = %+v, %v", store, err)
+	}
+	store.Tasks[0].Status = "completed"
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(filepath.Join(projectDir, "1.json"))
+	if !isSealed(content) {
+		t.Errorf("Saving wrote a plain task file: %s", content)
+	}
+	if history, err := store.History(); err != nil || len(history) != 2 {
+		t.Errorf("History = %+v, %v; want the creation and the completion", history, err)
+	}
+	if raw, err := store.RawTaskJSON("1"); err != nil || !strings.Contains(string(raw), `"completed"`) {
+		t.Errorf("RawTaskJSON = %s, %v", raw, err)
+	}
+
+	if _, err := DecryptProject("proj", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	if IsEncrypted(projectDir) {
+		t.Error("The encryption header should be removed")
+	}
+	content, _ = os.ReadFile(filepath.Join(projectDir, "1.json"))
+	if !strings.Contains(string(content), "Secret plan") {
+		t.Errorf("1.json after decrypting = %s", content)
+	}
+	store, err = LoadTasks("proj")
+	if err != nil || len(store.Tasks) != 1 {
+		t.Fatalf("LoadTasks after decrypting = %+v, %v", store, err)
+	}
+	if history, _ := store.History(); len(history) != 2 {
+		t.Errorf("History after decrypting = %+v", history)
+	}
+}
+
+// isSealedLines reports whether every line of content is encrypted
+func isSealedLines(content []byte) bool {
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	for _, line := range lines {
+		if !isSealed([]byte(line)) {
+			return false
+		}
+	}
+	return len(lines) > 0
+}
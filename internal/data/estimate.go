@@ -0,0 +1,79 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// GetTaskEstimate returns the estimate (hours or points) from task metadata
+func GetTaskEstimate(task Task) float64 {
+	if task.Metadata == nil {
+		return 0
+	}
+	switch v := task.Metadata["estimate"].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
+
+// SetTaskEstimate sets the estimate in task metadata (0 removes it)
+func SetTaskEstimate(task *Task, estimate float64) {
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	if estimate <= 0 {
+		delete(task.Metadata, "estimate")
+	} else {
+		task.Metadata["estimate"] = estimate
+	}
+}
+
+// ParseEstimate parses a user-entered estimate such as "3", "1.5" or "4h"
+func ParseEstimate(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	unit := config.Current().Estimates.Unit
+	s = strings.TrimSpace(strings.TrimSuffix(s, unit))
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, fmt.Errorf("invalid estimate %q (expected a non-negative number)", s)
+	}
+	return f, nil
+}
+
+// FormatEstimate formats an estimate with the configured unit, e.g. "4.5h"
+func FormatEstimate(estimate float64) string {
+	return strconv.FormatFloat(estimate, 'f', -1, 64) + config.Current().Estimates.Unit
+}
+
+// RemainingEstimate sums the estimates of tasks that are not completed
+func RemainingEstimate(tasks []Task) float64 {
+	total := 0.0
+	for _, task := range tasks {
+		if task.Status != "completed" {
+			total += GetTaskEstimate(task)
+		}
+	}
+	return total
+}
+
+// TotalEstimate sums the estimates of all tasks
+func TotalEstimate(tasks []Task) float64 {
+	total := 0.0
+	for _, task := range tasks {
+		total += GetTaskEstimate(task)
+	}
+	return total
+}
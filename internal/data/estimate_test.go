@@ -0,0 +1,50 @@
+package data
+
+import "testing"
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		valid    bool
+	}{
+		{"", 0, true},
+		{"4", 4, true},
+		{"1.5", 1.5, true},
+		{"4h", 4, true},
+		{"abc", 0, false},
+		{"-2", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseEstimate(tt.input)
+		if (err == nil) != tt.valid || got != tt.expected {
+			t.Errorf("ParseEstimate(%q) = %v, %v; expected %v (valid=%v)", tt.input, got, err, tt.expected, tt.valid)
+		}
+	}
+}
+
+func TestRemainingEstimate(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Status: "pending", Metadata: map[string]interface{}{"estimate": 3.0}},
+		{ID: "2", Status: "in_progress", Metadata: map[string]interface{}{"estimate": 1.5}},
+		{ID: "3", Status: "completed", Metadata: map[string]interface{}{"estimate": 2.0}},
+		{ID: "4", Status: "pending"},
+	}
+	if got := RemainingEstimate(tasks); got != 4.5 {
+		t.Errorf("Expected remaining 4.5, got %v", got)
+	}
+	if got := TotalEstimate(tasks); got != 6.5 {
+		t.Errorf("Expected total 6.5, got %v", got)
+	}
+}
+
+func TestTaskDurationDaysFromEstimate(t *testing.T) {
+	task := Task{ID: "1"}
+	if d := TaskDurationDays(task); d != 1 {
+		t.Errorf("Expected 1 day without estimate, got %d", d)
+	}
+	SetTaskEstimate(&task, 20)
+	if d := TaskDurationDays(task); d != 3 {
+		t.Errorf("Expected 3 days for 20h at 8h/day, got %d", d)
+	}
+}
@@ -0,0 +1,150 @@
+package data
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Table export formats
+const (
+	FormatCSV = "csv"
+	FormatTSV = "tsv"
+)
+
+// ExportColumns lists the columns available to table exports
+var ExportColumns = []string{
+	"id", "subject", "status", "group", "owner", "due", "tags",
+	"priority", "start", "estimate", "milestone", "blockedBy", "blocks", "description",
+}
+
+// DefaultExportColumns are exported when no columns are given
+var DefaultExportColumns = []string{"id", "subject", "status", "group", "owner", "due", "tags"}
+
+// ParseExportColumns parses a comma-separated column list; empty means the defaults
+func ParseExportColumns(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultExportColumns, nil
+	}
+	var columns []string
+	for _, part := range strings.Split(s, ",") {
+		column := strings.TrimSpace(part)
+		if column == "" {
+			continue
+		}
+		if !isExportColumn(column) {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", column, strings.Join(ExportColumns, ", "))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// isExportColumn reports whether name is a known export column
+func isExportColumn(name string) bool {
+	for _, c := range ExportColumns {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatForPath guesses the table format from a file extension (CSV by default)
+func FormatForPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".tsv") {
+		return FormatTSV
+	}
+	return FormatCSV
+}
+
+// WriteTable writes tasks as CSV or TSV with a header row of column names
+func (s *TaskStore) WriteTable(w io.Writer, tasks []Task, columns []string, format string) error {
+	cw := csv.NewWriter(w)
+	switch format {
+	case FormatCSV:
+	case FormatTSV:
+		cw.Comma = '\t'
+	default:
+		return fmt.Errorf("unknown format %q (expected csv or tsv)", format)
+	}
+
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = s.exportValue(task, column)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTableFile writes tasks to a CSV or TSV file
+func (s *TaskStore) WriteTableFile(path string, tasks []Task, columns []string, format string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := s.WriteTable(f, tasks, columns, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// exportValue returns a task's value for an export column
+func (s *TaskStore) exportValue(task Task, column string) string {
+	switch column {
+	case "id":
+		return DisplayID(task)
+	case "subject":
+		return task.Subject
+	case "status":
+		return task.Status
+	case "group":
+		return GetTaskGroup(task)
+	case "owner":
+		return task.Owner
+	case "due":
+		return GetTaskDue(task)
+	case "tags":
+		return strings.Join(GetTaskTags(task), ", ")
+	case "priority":
+		return GetTaskPriority(task)
+	case "start":
+		return GetTaskStart(task)
+	case "estimate":
+		if estimate := GetTaskEstimate(task); estimate > 0 {
+			return strconv.FormatFloat(estimate, 'f', -1, 64)
+		}
+		return ""
+	case "milestone":
+		return GetTaskMilestone(task)
+	case "blockedBy":
+		return s.displayRefs(task.BlockedBy)
+	case "blocks":
+		return s.displayRefs(task.Blocks)
+	case "description":
+		return task.Description
+	}
+	return ""
+}
+
+// displayRefs joins task references as shown in the UI
+func (s *TaskStore) displayRefs(ids []string) string {
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		refs[i] = s.DisplayRef(id)
+	}
+	return strings.Join(refs, ", ")
+}
@@ -0,0 +1,53 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteTable(t *testing.T) {
+	store := &TaskStore{Tasks: []Task{
+		{ID: "1", Subject: "Write docs, then ship", Status: "pending", Owner: "ana",
+			Metadata: map[string]interface{}{"group": "Docs", "dueDate": "2026-03-01", "tags": []interface{}{"a", "b"}}},
+		{ID: "2", Subject: "Review", Status: "completed", BlockedBy: []string{"1"}},
+	}}
+
+	var b strings.Builder
+	if err := store.WriteTable(&b, store.Tasks, DefaultExportColumns, FormatCSV); err != nil {
+		t.Fatalf("WriteTable failed: %v", err)
+	}
+	want := "id,subject,status,group,owner,due,tags\n" +
+		"1,\"Write docs, then ship\",pending,Docs,ana,2026-03-01,\"a, b\"\n" +
+		"2,Review,completed,,,,\n"
+	if b.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	if err := store.WriteTable(&b, store.Tasks[1:], []string{"id", "blockedBy"}, FormatTSV); err != nil {
+		t.Fatalf("WriteTable failed: %v", err)
+	}
+	if b.String() != "id\tblockedBy\n2\t1\n" {
+		t.Errorf("TSV = %q", b.String())
+	}
+
+	if err := store.WriteTable(&b, nil, DefaultExportColumns, "xlsx"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestParseExportColumns(t *testing.T) {
+	cols, err := ParseExportColumns(" id, subject ,due")
+	if err != nil || strings.Join(cols, ",") != "id,subject,due" {
+		t.Errorf("ParseExportColumns = %v, %v", cols, err)
+	}
+	if cols, _ := ParseExportColumns(""); len(cols) != len(DefaultExportColumns) {
+		t.Errorf("empty columns should give the defaults, got %v", cols)
+	}
+	if _, err := ParseExportColumns("id,colour"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+	if FormatForPath("out.TSV") != FormatTSV || FormatForPath("out.txt") != FormatCSV {
+		t.Error("FormatForPath should pick TSV by extension only")
+	}
+}
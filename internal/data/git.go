@@ -0,0 +1,204 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// LogEntry is one commit in a task's git history
+type LogEntry struct {
+	Hash    string
+	Time    time.Time
+	Subject string
+}
+
+// gitLogFormat separates hash, unix time and subject with unit separators
+const gitLogFormat = "%H\x1f%ct\x1f%s"
+
+// runGit runs git in dir and returns its trimmed stdout
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// ensureGitRepo initializes a git repository in dir if there is none
+func ensureGitRepo(dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	_, err := runGit(dir, "init", "--quiet")
+	return err
+}
+
+// commitIdentityArgs returns -c flags providing a commit identity when none is configured
+func commitIdentityArgs(dir string) []string {
+	if email, err := runGit(dir, "config", "user.email"); err == nil && email != "" {
+		return nil
+	}
+	return []string{"-c", "user.name=cctasks", "-c", "user.email=cctasks@localhost"}
+}
+
+// commitProject commits all changes in a project directory of the git-backed tasks root
+func commitProject(projectDir, message string) error {
+	root := filepath.Dir(projectDir)
+	if err := ensureGitRepo(root); err != nil {
+		return err
+	}
+
+	project := filepath.Base(projectDir)
+	if _, err := runGit(root, "add", "--all", "--", project); err != nil {
+		return err
+	}
+
+	// Nothing staged for this project: skip the commit
+	status, err := runGit(root, "status", "--porcelain", "--", project)
+	if err != nil || status == "" {
+		return err
+	}
+
+	args := append(commitIdentityArgs(root), "commit", "--quiet", "-m", message, "--", project)
+	_, err = runGit(root, args...)
+	return err
+}
+
+// commitChanges records changes in git if git-backed history is enabled
+func (s *TaskStore) commitChanges(changes []Change) {
+	if !config.Current().Git.Enabled || len(changes) == 0 || s.ProjectName == "" || !autoCommitRoot(s.ProjectName) {
+		return
+	}
+	projectDir, err := s.dir()
+	if err != nil {
+		return
+	}
+	message := DescribeChanges(changes)
+	if s.key != nil {
+		message = fmt.Sprintf("update %d encrypted task(s)", len(changes)) // keep subjects out of the log
+	}
+	commitProject(projectDir, message) // best-effort like backups
+}
+
+// autoCommitRoot reports whether the root holding a project is auto-committed:
+// the primary tasks directory always, additional roots (often shared with
+// others) only when opted in with "git" in their config entry
+func autoCommitRoot(projectName string) bool {
+	rootName, _ := config.SplitProjectName(projectName)
+	if rootName == "" {
+		return true
+	}
+	for _, rc := range config.Current().Roots {
+		if rc.Name == rootName {
+			return rc.Git && rc.SSH == ""
+		}
+	}
+	return false
+}
+
+// GitHistoryEnabled reports whether git-backed history is turned on in config
+func GitHistoryEnabled() bool {
+	return config.Current().Git.Enabled
+}
+
+// ProjectCommitted reports whether a project's saves are committed to git, so
+// that earlier versions of its files stay in the repository's history
+func ProjectCommitted(projectName string) bool {
+	return GitHistoryEnabled() && autoCommitRoot(projectName)
+}
+
+// TaskLog returns the git history of a task file, newest first
+func TaskLog(projectName, taskID string) ([]LogEntry, error) {
+	projectDir, err := config.GetProjectDir(projectName)
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Dir(projectDir)
+	if _, err := os.Stat(filepath.Join(root, ".git")); err != nil {
+		return []LogEntry{}, nil
+	}
+
+	path := taskGitPath(projectDir, taskID)
+	out, err := runGit(root, "log", "--follow", "--format="+gitLogFormat, "--", path)
+	if err != nil {
+		return nil, err
+	}
+	return parseGitLog(out), nil
+}
+
+// TaskDiff returns the patch a commit applied to a task file
+func TaskDiff(projectName, taskID, hash string) (string, error) {
+	projectDir, err := config.GetProjectDir(projectName)
+	if err != nil {
+		return "", err
+	}
+	path := taskGitPath(projectDir, taskID)
+	return runGit(filepath.Dir(projectDir), "show", "--format=", "--no-color", hash, "--", path)
+}
+
+// taskGitPath returns the path of a task's file relative to the directory
+// holding the project (the git repository): tasks.json for every task in the
+// single-file layout. Projects on additional roots are named "<root>/<name>"
+// but sit in their root as <name>.
+func taskGitPath(projectDir, taskID string) string {
+	project := filepath.Base(projectDir)
+	if DetectLayout(projectDir) == LayoutSingle {
+		return filepath.Join(project, LegacyTasksFile)
+	}
+	return filepath.Join(project, taskID+".json")
+}
+
+// LastTag returns the newest tag reachable from HEAD in the git repository
+// at dir (a code repository, not the tasks directory) and when its commit
+// was made
+func LastTag(dir string) (string, time.Time, error) {
+	tag, err := runGit(dir, "describe", "--tags", "--abbrev=0")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	out, err := runGit(dir, "log", "-1", "--format=%ct", tag)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	var unix int64
+	if _, err := fmt.Sscanf(out, "%d", &unix); err != nil {
+		return "", time.Time{}, fmt.Errorf("commit time of %s: %q", tag, out)
+	}
+	return tag, time.Unix(unix, 0), nil
+}
+
+// parseGitLog parses output produced with gitLogFormat
+func parseGitLog(out string) []LogEntry {
+	entries := []LogEntry{}
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, "\x1f", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		var unix int64
+		fmt.Sscanf(parts[1], "%d", &unix)
+		entries = append(entries, LogEntry{
+			Hash:    parts[0],
+			Time:    time.Unix(unix, 0),
+			Subject: parts[2],
+		})
+	}
+	return entries
+}
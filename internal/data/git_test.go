@@ -0,0 +1,117 @@
+package data
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestCommitProject(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	projectDir := filepath.Join(tmpDir, "myproject")
+	os.MkdirAll(projectDir, 0755)
+	os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(`{"id":"1"}`), 0644)
+
+	if err := commitProject(projectDir, "create #1: First"); err != nil {
+		t.Fatalf("commitProject failed: %v", err)
+	}
+
+	// No changes: no new commit
+	if err := commitProject(projectDir, "nothing"); err != nil {
+		t.Fatalf("commitProject without changes failed: %v", err)
+	}
+
+	os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(`{"id":"1","status":"completed"}`), 0644)
+	if err := commitProject(projectDir, "complete #1: First"); err != nil {
+		t.Fatalf("commitProject failed: %v", err)
+	}
+
+	out, err := runGit(tmpDir, "log", "--format="+gitLogFormat, "--", "myproject/1.json")
+	if err != nil {
+		t.Fatalf("git log failed: %v", err)
+	}
+	entries := parseGitLog(out)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(entries))
+	}
+	if entries[0].Subject != "complete #1: First" || entries[1].Subject != "create #1: First" {
+		t.Errorf("Unexpected commit subjects: %q, %q", entries[0].Subject, entries[1].Subject)
+	}
+}
+
+func TestTaskLogOnAdditionalRoot(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	testutil.IsolateHome(t)
+	teamDir := t.TempDir()
+	cfg := config.Default()
+	cfg.Roots = []config.RootConfig{{Name: "team", Path: teamDir}}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	projectDir := filepath.Join(teamDir, "api")
+	os.MkdirAll(projectDir, 0755)
+	os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(`{"id":"1"}`), 0644)
+	if err := commitProject(projectDir, "create #1: First"); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(`{"id":"1","status":"completed"}`), 0644)
+	if err := commitProject(projectDir, "complete #1: First"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := TaskLog("team/api", "1")
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("TaskLog = %+v, %v; want both commits", entries, err)
+	}
+	diff, err := TaskDiff("team/api", "1", entries[0].Hash)
+	if err != nil || !strings.Contains(diff, `+{"id":"1","status":"completed"}`) {
+		t.Errorf("TaskDiff = %q, %v", diff, err)
+	}
+}
+
+func TestCommitChangesOnlyOnOptedInRoots(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	tasksDir := testutil.IsolateHome(t)
+	teamDir, ownDir := t.TempDir(), t.TempDir()
+	cfg := config.Default()
+	cfg.Git.Enabled = true
+	cfg.Roots = []config.RootConfig{{Name: "team", Path: teamDir}, {Name: "own", Path: ownDir, Git: true}}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	for project, root := range map[string]string{"app": tasksDir, "team/api": teamDir, "own/api": ownDir} {
+		os.MkdirAll(filepath.Join(root, filepath.Base(project)), 0755)
+		store, err := LoadTasks(project)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store.AddTask(Task{Subject: "First"})
+		if err := store.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for root, want := range map[string]bool{tasksDir: true, teamDir: false, ownDir: true} {
+		if _, err := os.Stat(filepath.Join(root, ".git")); (err == nil) != want {
+			t.Errorf("%s: git repo created = %v, want %v", root, err == nil, want)
+		}
+	}
+}
@@ -104,40 +104,22 @@ func LoadGroups(projectName string) (*GroupStore, error) {
 		lastModTime: modTime,
 	}
 
-	// Backup groups file (only if source is newer)
-	store.backupGroupsFile(data, modTime)
-
 	return store, nil
 }
 
-// backupGroupsFile backs up groups file if source is newer
-func (s *GroupStore) backupGroupsFile(data []byte, srcModTime time.Time) {
-	backupDir, err := config.GetBackupProjectDir(s.ProjectName)
-	if err != nil {
-		return
-	}
-
-	backupPath := filepath.Join(backupDir, "_groups.json")
-
-	// Check if backup is up-to-date
-	dstInfo, err := os.Stat(backupPath)
-	if err == nil && !srcModTime.After(dstInfo.ModTime()) {
-		return // Backup is up-to-date, skip
-	}
-
-	if err := os.MkdirAll(backupDir, 0755); err != nil {
-		return
-	}
-
-	os.WriteFile(backupPath, data, 0644)
-}
-
 // Save saves groups to the project's _groups.json
 func (s *GroupStore) Save() error {
-	groupsFilePath, err := config.GetGroupsFilePath(s.ProjectName)
-	if err != nil {
+	if err := checkProjectWritable(s.ProjectName); err != nil {
 		return err
 	}
+	groupsFilePath := s.filePath
+	if groupsFilePath == "" {
+		var err error
+		groupsFilePath, err = config.GetGroupsFilePath(s.ProjectName)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Ensure directory exists
 	dir := filepath.Dir(groupsFilePath)
@@ -155,31 +137,12 @@ func (s *GroupStore) Save() error {
 		return err
 	}
 
-	// Backup: write only if content differs
-	s.backupGroupsData(data)
-	return nil
-}
-
-// backupGroupsData backs up groups data to backup directory if content differs
-func (s *GroupStore) backupGroupsData(data []byte) {
-	backupDir, err := config.GetBackupProjectDir(s.ProjectName)
-	if err != nil {
-		return
-	}
-
-	if err := os.MkdirAll(backupDir, 0755); err != nil {
-		return
+	// Backup: snapshot only if content differs from the latest snapshot
+	snapshotStore(s.ProjectName, dir)
+	if config.Current().Git.Enabled && s.ProjectName != "" {
+		commitProject(dir, "update groups") // best-effort like backups
 	}
-
-	backupPath := filepath.Join(backupDir, "_groups.json")
-
-	// Check if backup exists and has same content
-	existing, err := os.ReadFile(backupPath)
-	if err == nil && string(existing) == string(data) {
-		return // Same content, skip write
-	}
-
-	os.WriteFile(backupPath, data, 0644)
+	return nil
 }
 
 // NeedsReload checks if the groups file has been modified since last load
@@ -0,0 +1,136 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// Kinds of tasks directory health issues
+const (
+	HealthMissing    = "missing"    // the directory does not exist
+	HealthNotDir     = "not_dir"    // the path is a file
+	HealthUnreadable = "unreadable" // the directory or a task file cannot be read
+	HealthReadOnly   = "read_only"  // files cannot be created in the directory
+	HealthMalformed  = "malformed"  // a task file is not valid JSON
+)
+
+// HealthIssue is a problem with a tasks directory found on launch
+type HealthIssue struct {
+	Kind    string
+	Path    string
+	Message string
+}
+
+// Fixable reports whether FixHealthIssue can repair the issue: missing
+// directories are created and permissions granted to the owner
+func (h HealthIssue) Fixable() bool {
+	switch h.Kind {
+	case HealthMissing, HealthUnreadable, HealthReadOnly:
+		return true
+	}
+	return false
+}
+
+// CheckHealth checks that every tasks directory exists, is a readable and
+// writable directory, and that the task files of its projects are valid JSON
+func CheckHealth() ([]HealthIssue, error) {
+	roots, err := config.GetRoots()
+	if err != nil {
+		return nil, err
+	}
+	var issues []HealthIssue
+	for _, root := range roots {
+		issues = append(issues, checkRoot(root.Path)...)
+	}
+	return issues, nil
+}
+
+// checkRoot checks one tasks directory and its projects
+func checkRoot(dir string) []HealthIssue {
+	info, err := os.Stat(dir)
+	switch {
+	case os.IsNotExist(err):
+		return []HealthIssue{{Kind: HealthMissing, Path: dir, Message: "tasks directory does not exist"}}
+	case err != nil:
+		return []HealthIssue{{Kind: HealthUnreadable, Path: dir, Message: err.Error()}}
+	case !info.IsDir():
+		return []HealthIssue{{Kind: HealthNotDir, Path: dir, Message: "not a directory"}}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return []HealthIssue{{Kind: HealthUnreadable, Path: dir, Message: err.Error()}}
+	}
+	var issues []HealthIssue
+	if err := checkWritable(dir); err != nil {
+		issues = append(issues, HealthIssue{Kind: HealthReadOnly, Path: dir, Message: err.Error()})
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			issues = append(issues, checkProjectDir(filepath.Join(dir, entry.Name()))...)
+		}
+	}
+	return issues
+}
+
+// checkWritable reports whether a file can be created in dir
+func checkWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".cctasks-check-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// checkProjectDir checks that a project directory and its task files can be
+// read, and that the task files are valid JSON
+func checkProjectDir(dir string) []HealthIssue {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return []HealthIssue{{Kind: HealthUnreadable, Path: dir, Message: err.Error()}}
+	}
+	var issues []HealthIssue
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			issues = append(issues, HealthIssue{Kind: HealthUnreadable, Path: path, Message: err.Error()})
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(content, &value); err != nil {
+			issues = append(issues, HealthIssue{Kind: HealthMalformed, Path: path, Message: fmt.Sprintf("invalid JSON: %v", err)})
+		}
+	}
+	return issues
+}
+
+// FixHealthIssue repairs a fixable issue: it creates a missing directory, or
+// gives the owner read and write access (and search access to directories)
+func FixHealthIssue(issue HealthIssue) error {
+	switch issue.Kind {
+	case HealthMissing:
+		return os.MkdirAll(issue.Path, 0755)
+	case HealthUnreadable, HealthReadOnly:
+		info, err := os.Stat(issue.Path)
+		if err != nil {
+			return err
+		}
+		mode := info.Mode().Perm() | 0600
+		if info.IsDir() {
+			mode |= 0700
+		}
+		return os.Chmod(issue.Path, mode)
+	}
+	return fmt.Errorf("%s cannot be fixed automatically", issue.Path)
+}
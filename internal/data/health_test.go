@@ -0,0 +1,63 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+func TestCheckHealth(t *testing.T) {
+	tasksDir := filepath.Join(t.TempDir(), "tasks")
+	config.SetTasksDirOverride(tasksDir)
+	defer config.SetTasksDirOverride("")
+
+	// A missing tasks directory is created by the fix
+	issues, err := CheckHealth()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Kind != HealthMissing || !issues[0].Fixable() {
+		t.Fatalf("Expected one fixable missing issue, got %+v", issues)
+	}
+	if err := FixHealthIssue(issues[0]); err != nil {
+		t.Fatal(err)
+	}
+	if issues, _ := CheckHealth(); len(issues) != 0 {
+		t.Errorf("Expected no issues after the fix, got %+v", issues)
+	}
+
+	// Malformed task files are reported with their path
+	writeArchiveProject(t, tasksDir, "app", map[string]string{
+		"1": `{"id": "1", "subject": "Fine", "status": "pending"}`,
+		"2": `{"id": "2", "subject": `,
+	}, nil)
+	issues, _ = CheckHealth()
+	if len(issues) != 1 || issues[0].Kind != HealthMalformed || issues[0].Path != filepath.Join(tasksDir, "app", "2.json") {
+		t.Fatalf("Expected 2.json to be malformed, got %+v", issues)
+	}
+	if issues[0].Fixable() {
+		t.Error("Expected malformed files to need a manual fix")
+	}
+
+	// Permissions are not enforced for root or on Windows
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		return
+	}
+	if err := os.Chmod(tasksDir, 0500); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(tasksDir, 0755)
+	issues, _ = CheckHealth()
+	if len(issues) != 2 || issues[0].Kind != HealthReadOnly {
+		t.Fatalf("Expected a read-only tasks directory, got %+v", issues)
+	}
+	if err := FixHealthIssue(issues[0]); err != nil {
+		t.Fatal(err)
+	}
+	if issues, _ := CheckHealth(); len(issues) != 1 {
+		t.Errorf("Expected only the malformed file left, got %+v", issues)
+	}
+}
@@ -0,0 +1,436 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// HistoryFileName is the per-project append-only log of task changes (one JSON object per line)
+const HistoryFileName = "_history.jsonl"
+
+// HistoryEntry is one recorded task change
+type HistoryEntry struct {
+	Time     time.Time  `json:"time"`
+	Kind     ChangeKind `json:"kind"`
+	TaskID   string     `json:"taskId"`
+	Subject  string     `json:"subject,omitempty"`
+	From     string     `json:"from,omitempty"`     // previous status (status changes and deletions) or owner (assignments)
+	To       string     `json:"to,omitempty"`       // new status (status changes and creations) or owner (assignments)
+	Group    string     `json:"group,omitempty"`    // task group at the time of the change
+	Reason   string     `json:"reason,omitempty"`   // why the status changed (see RequiresReason)
+	External bool       `json:"external,omitempty"` // change made outside cctasks (detected on reload)
+	User     string     `json:"user,omitempty"`     // who made the change (collaboration mode)
+}
+
+// historyEntries converts changes between two task lists into history
+// entries, with an assignment entry after each change of a task's owner
+func historyEntries(old, new []Task, changes []Change, now time.Time, external bool) []HistoryEntry {
+	oldByID := make(map[string]Task, len(old))
+	for _, task := range old {
+		oldByID[task.ID] = task
+	}
+	newByID := make(map[string]Task, len(new))
+	for _, task := range new {
+		newByID[task.ID] = task
+	}
+
+	entries := make([]HistoryEntry, 0, len(changes))
+	for _, c := range changes {
+		entry := HistoryEntry{
+			Time:     now,
+			Kind:     c.Kind,
+			TaskID:   c.TaskID,
+			Subject:  c.Subject,
+			From:     c.From,
+			To:       c.To,
+			External: external,
+		}
+		switch c.Kind {
+		case ChangeCreated:
+			entry.To = newByID[c.TaskID].Status
+			entry.Group = GetTaskGroup(newByID[c.TaskID])
+		case ChangeDeleted:
+			entry.From = oldByID[c.TaskID].Status
+			entry.Group = GetTaskGroup(oldByID[c.TaskID])
+		default:
+			entry.Group = GetTaskGroup(newByID[c.TaskID])
+		}
+		entries = append(entries, entry)
+
+		prev, existed := oldByID[c.TaskID]
+		task, exists := newByID[c.TaskID]
+		if existed && exists && prev.Owner != task.Owner {
+			entries = append(entries, HistoryEntry{
+				Time:     now,
+				Kind:     ChangeAssigned,
+				TaskID:   c.TaskID,
+				Subject:  c.Subject,
+				From:     prev.Owner,
+				To:       task.Owner,
+				Group:    entry.Group,
+				External: external,
+			})
+		}
+	}
+	return entries
+}
+
+// appendHistory appends entries to the project's history log, each line
+// encrypted with key in an encrypted project
+func appendHistory(projectDir string, key []byte, entries []HistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	f, err := os.OpenFile(filepath.Join(projectDir, HistoryFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if line, err = sealTaskData(key, line); err != nil {
+			return err
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordHistory logs the changes from old to the current tasks (errors are ignored; history is best-effort)
+func (s *TaskStore) recordHistory(old []Task, changes []Change, now time.Time, external bool) {
+	if len(changes) == 0 {
+		return
+	}
+	projectDir, err := s.dir()
+	if err != nil {
+		return
+	}
+	entries := historyEntries(old, s.Tasks, changes, now, external)
+	for i := range entries {
+		if entries[i].Kind == ChangeStatus {
+			entries[i].Reason = s.reasons[entries[i].TaskID]
+		}
+		if !external && s.collaborating() {
+			entries[i].User = CollaborationUser()
+		}
+	}
+	appendHistory(projectDir, s.key, entries)
+}
+
+// ReasonReopen is the requireReason config value for moving a completed task back
+const ReasonReopen = "reopen"
+
+// RequiresReason reports whether the config asks for a reason when a task's
+// status changes from one status to another
+func RequiresReason(from, to string) bool {
+	reopen := from == "completed" && to != "completed"
+	return reopen && containsString(config.Current().RequireReason, ReasonReopen)
+}
+
+// SetStatusReason records why a task's status is changing; the reason is
+// written to the history with the change on the next save
+func (s *TaskStore) SetStatusReason(taskID, reason string) {
+	if s.reasons == nil {
+		s.reasons = make(map[string]string)
+	}
+	s.reasons[taskID] = reason
+}
+
+// LastStatusReason returns the newest history entry of a task that has a reason
+func (s *TaskStore) LastStatusReason(taskID string) (HistoryEntry, bool) {
+	history, err := s.History()
+	if err != nil {
+		return HistoryEntry{}, false
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].TaskID == taskID && history[i].Reason != "" {
+			return history[i], true
+		}
+	}
+	return HistoryEntry{}, false
+}
+
+// RecordExternalChanges logs changes made on disk since prev was loaded,
+// e.g. by Claude Code while cctasks was open, and notifies webhooks and hooks
+// of them. With the review workflow on, tasks completed there are moved to
+// needs_review. Changes another cctasks already logged, having saved them
+// itself or noticed them first, are left out, so every open instance does
+// not notify them again.
+func (s *TaskStore) RecordExternalChanges(prev *TaskStore) {
+	if s == nil || prev == nil || prev.ProjectName != s.ProjectName {
+		return
+	}
+	changes := DiffTasks(prev.saved, s.Tasks)
+	if len(changes) > 0 {
+		recorded := s.recordedSince(prev.loadedAt)
+		var unrecorded []Change
+		for _, c := range changes {
+			if !recorded[changeKey{c.TaskID, c.Kind}] {
+				unrecorded = append(unrecorded, c)
+			}
+		}
+		changes = unrecorded
+	}
+	s.recordHistory(prev.saved, changes, time.Now(), true)
+	s.notifyWebhooks(prev.saved, changes, true)
+	s.runHooks(prev.saved, changes, true)
+	if ReviewEnabled() && s.routeToReview(changes) {
+		if err := s.Save(); err != nil {
+			slog.Debug("routing to review failed", "project", s.ProjectName, "err", err)
+		}
+	}
+}
+
+// changeKey identifies a kind of change of a task in the history log
+type changeKey struct {
+	taskID string
+	kind   ChangeKind
+}
+
+// recordedSince returns the changes of tasks logged after since, by any
+// cctasks: another open instance or a collaborator's. Stores with no load
+// time (built in memory) have nothing to leave out.
+func (s *TaskStore) recordedSince(since time.Time) map[changeKey]bool {
+	if since.IsZero() {
+		return nil
+	}
+	history, err := s.History()
+	if err != nil {
+		return nil
+	}
+	recorded := make(map[changeKey]bool)
+	for _, e := range history {
+		if e.Time.After(since) {
+			recorded[changeKey{e.TaskID, e.Kind}] = true
+		}
+	}
+	return recorded
+}
+
+// History returns the project's history log, oldest first (malformed lines are skipped)
+func (s *TaskStore) History() ([]HistoryEntry, error) {
+	projectDir, err := s.dir()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(projectDir, HistoryFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []HistoryEntry{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := []HistoryEntry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line, err := openTaskData(s.key, scanner.Bytes())
+		if err != nil {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
+
+// openDelta returns how the entry changed the number of open (not completed) tasks
+func (e HistoryEntry) openDelta() int {
+	isOpen := func(status string) int {
+		if status == "completed" {
+			return 0
+		}
+		return 1
+	}
+	switch e.Kind {
+	case ChangeCreated:
+		return isOpen(e.To)
+	case ChangeDeleted:
+		return -isOpen(e.From)
+	case ChangeStatus:
+		return isOpen(e.To) - isOpen(e.From)
+	}
+	return 0
+}
+
+// Burndown returns the number of open tasks at the end of each of the last
+// days days (oldest first, the last value being now). Counts are reconstructed
+// backwards from the current tasks using the history log. match filters tasks
+// by group; nil counts every task.
+func Burndown(tasks []Task, history []HistoryEntry, days int, now time.Time, match func(group string) bool) []int {
+	if days <= 0 {
+		return nil
+	}
+	if match == nil {
+		match = func(string) bool { return true }
+	}
+
+	open := 0
+	for _, task := range tasks {
+		if task.Status != "completed" && match(GetTaskGroup(task)) {
+			open++
+		}
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	counts := make([]int, days)
+	counts[days-1] = open
+
+	// Walk the history newest first, undoing changes made after each day's end
+	i := len(history) - 1
+	for day := days - 2; day >= 0; day-- {
+		dayEnd := today.AddDate(0, 0, day-days+2)
+		for ; i >= 0 && !history[i].Time.Before(dayEnd); i-- {
+			if match(history[i].Group) {
+				open -= history[i].openDelta()
+			}
+		}
+		counts[day] = max(open, 0)
+	}
+	return counts
+}
+
+// CompletionPace returns how many tasks were completed per day on average
+// over the last days days (today included), according to the history log.
+// match filters tasks by group; nil counts every task.
+func CompletionPace(history []HistoryEntry, days int, now time.Time, match func(group string) bool) float64 {
+	if days <= 0 {
+		return 0
+	}
+	if match == nil {
+		match = func(string) bool { return true }
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	start := today.AddDate(0, 0, 1-days)
+
+	completed := 0
+	for _, entry := range history {
+		if entry.isCompletion() && !entry.Time.Before(start) && !entry.Time.After(now) && match(entry.Group) {
+			completed++
+		}
+	}
+	return float64(completed) / float64(days)
+}
+
+// isCompletion reports whether the entry completed a task
+func (e HistoryEntry) isCompletion() bool {
+	return e.Kind == ChangeStatus && e.To == "completed" && e.From != "completed"
+}
+
+// ActivityHeatmap returns the number of tasks completed on each day of the
+// last weeks weeks, as one column of seven days (Sunday first) per week,
+// oldest week first. The last column is the current week; its days after
+// today are -1.
+func ActivityHeatmap(history []HistoryEntry, weeks int, now time.Time) [][7]int {
+	if weeks <= 0 {
+		return nil
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	start := today.AddDate(0, 0, -int(today.Weekday())-7*(weeks-1))
+
+	cells := make([][7]int, weeks)
+	for day := 0; day < 7*weeks; day++ {
+		if start.AddDate(0, 0, day).After(today) {
+			cells[day/7][day%7] = -1
+		}
+	}
+	for _, entry := range history {
+		if !entry.isCompletion() {
+			continue
+		}
+		t := entry.Time.In(now.Location())
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location())
+		if date.Before(start) || date.After(today) {
+			continue
+		}
+		// Count days by calendar date so DST changes do not shift them
+		day := 0
+		for d := start; d.Before(date); d = d.AddDate(0, 0, 1) {
+			day++
+		}
+		cells[day/7][day%7]++
+	}
+	return cells
+}
+
+// FinishETA returns in how many days open tasks are done at pace tasks per
+// day, rounded up; ok is false when nothing is being completed
+func FinishETA(open int, pace float64) (days int, ok bool) {
+	if pace <= 0 {
+		return 0, false
+	}
+	return int(math.Ceil(float64(open) / pace)), true
+}
+
+// RecentlyModified returns up to limit existing tasks from the history log,
+// most recently changed first
+func RecentlyModified(tasks []Task, history []HistoryEntry, limit int) []Task {
+	byID := make(map[string]Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	var result []Task
+	seen := make(map[string]bool)
+	for i := len(history) - 1; i >= 0 && len(result) < limit; i-- {
+		id := history[i].TaskID
+		task, ok := byID[id]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, task)
+	}
+	return result
+}
+
+// LatestInProgress returns the in_progress task that was most recently moved
+// to in_progress according to the history log, falling back to the first
+// in_progress task when the log has no record of it; nil if none is in progress
+func LatestInProgress(tasks []Task, history []HistoryEntry) *Task {
+	byID := make(map[string]int, len(tasks))
+	var first *Task
+	for i := range tasks {
+		if tasks[i].Status == "in_progress" {
+			byID[tasks[i].ID] = i
+			if first == nil {
+				first = &tasks[i]
+			}
+		}
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].To != "in_progress" {
+			continue
+		}
+		if idx, ok := byID[history[i].TaskID]; ok {
+			return &tasks[idx]
+		}
+	}
+	return first
+}
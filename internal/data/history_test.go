@@ -0,0 +1,334 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestSaveAppendsHistory(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewTaskStoreForTest(tmpDir, []Task{
+		{ID: "1", Subject: "Task 1", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	store.Tasks[0].Status = "completed"
+	task := Task{Subject: "Task 2", Status: "pending"}
+	SetTaskGroup(&task, "Backend")
+	store.AddTask(task)
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := store.DeleteTask("2"); err != nil {
+		t.Fatalf("DeleteTask failed: %v", err)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	history, err := store.History()
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 history entries, got %d: %+v", len(history), history)
+	}
+	if history[0].Kind != ChangeStatus || history[0].From != "pending" || history[0].To != "completed" {
+		t.Errorf("Unexpected status entry: %+v", history[0])
+	}
+	if history[1].Kind != ChangeCreated || history[1].To != "pending" || history[1].Group != "Backend" {
+		t.Errorf("Unexpected created entry: %+v", history[1])
+	}
+	if history[2].Kind != ChangeDeleted || history[2].From != "pending" || history[2].Group != "Backend" {
+		t.Errorf("Unexpected deleted entry: %+v", history[2])
+	}
+
+	// Saving without changes records nothing
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if history, _ := store.History(); len(history) != 3 {
+		t.Errorf("Expected no new entries, got %d", len(history))
+	}
+}
+
+func TestRecordExternalChangesOnce(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+	projectDir := filepath.Join(tasksDir, "proj")
+	os.MkdirAll(projectDir, 0755)
+	path := filepath.Join(projectDir, "1.json")
+	os.WriteFile(path, []byte(`{"id":"1","subject":"Task 1","status":"pending"}`), 0644)
+
+	// Two cctasks have the project open
+	first, err := LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Claude Code completes the task; the first instance to reload logs it
+	os.WriteFile(path, []byte(`{"id":"1","subject":"Task 1","status":"completed"}`), 0644)
+	reload := func(prev *TaskStore) *TaskStore {
+		store, err := LoadTasks("proj")
+		if err != nil {
+			t.Fatal(err)
+		}
+		store.RecordExternalChanges(prev)
+		return store
+	}
+	first = reload(first)
+	second = reload(second)
+	history, _ := first.History()
+	if len(history) != 1 || !history[0].External || history[0].To != "completed" {
+		t.Fatalf("history = %+v, want the completion logged once", history)
+	}
+
+	// A change saved by one instance is not logged again by the other
+	first.Tasks[0].Subject = "Task 1, renamed"
+	if err := first.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reload(second)
+	history, _ = first.History()
+	if len(history) != 2 || history[1].External {
+		t.Errorf("history = %+v, want the rename logged once by its save", history)
+	}
+}
+
+func TestAssignmentHistory(t *testing.T) {
+	store, err := NewTaskStoreForTest(t.TempDir(), []Task{
+		{ID: "1", Subject: "Task 1", Status: "pending", Owner: "alice"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Tasks[0].Owner = "bob"
+	store.Tasks[0].Status = "in_progress"
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := store.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[0].Kind != ChangeStatus {
+		t.Fatalf("history = %+v, want the status change and the assignment", history)
+	}
+	if e := history[1]; e.Kind != ChangeAssigned || e.From != "alice" || e.To != "bob" || e.TaskID != "1" {
+		t.Errorf("assignment entry = %+v", e)
+	}
+	if delta := history[1].openDelta(); delta != 0 {
+		t.Errorf("an assignment changed the open count by %d", delta)
+	}
+}
+
+func TestStatusReason(t *testing.T) {
+	cfg := config.Default()
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	if RequiresReason("completed", "pending") {
+		t.Error("reasons are off by default")
+	}
+	cfg.RequireReason = []string{ReasonReopen}
+	if !RequiresReason("completed", "pending") || !RequiresReason("completed", "in_progress") || RequiresReason("pending", "completed") {
+		t.Error("only reopening a completed task should need a reason")
+	}
+
+	store, err := NewTaskStoreForTest(t.TempDir(), []Task{
+		{ID: "1", Subject: "Task 1", Status: "completed", Blocks: []string{}, BlockedBy: []string{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetStatusReason("1", "Regressed in 2.1")
+	store.Tasks[0].Status = "pending"
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	store.Tasks[0].Status = "completed"
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := store.History()
+	if err != nil || len(history) != 2 {
+		t.Fatalf("history = %+v, %v", history, err)
+	}
+	if history[1].Reason != "" {
+		t.Errorf("reason should only be recorded once, got %q", history[1].Reason)
+	}
+	entry, ok := store.LastStatusReason("1")
+	if !ok || entry.Reason != "Regressed in 2.1" || entry.From != "completed" || entry.To != "pending" {
+		t.Errorf("last reason = %+v, %v", entry, ok)
+	}
+}
+
+func TestBurndown(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+	day := func(offset int) time.Time {
+		return time.Date(2024, 3, 10+offset, 12, 0, 0, 0, time.UTC)
+	}
+
+	tasks := []Task{
+		{ID: "1", Status: "completed"},
+		{ID: "2", Status: "completed"},
+		{ID: "3", Status: "pending"},
+		{ID: "4", Status: "in_progress"},
+	}
+	SetTaskGroup(&tasks[3], "UI")
+
+	history := []HistoryEntry{
+		{Time: day(-4), Kind: ChangeCreated, TaskID: "1", To: "pending"},
+		{Time: day(-4), Kind: ChangeCreated, TaskID: "2", To: "pending"},
+		{Time: day(-4), Kind: ChangeCreated, TaskID: "3", To: "pending"},
+		{Time: day(-3), Kind: ChangeCreated, TaskID: "4", To: "pending", Group: "UI"},
+		{Time: day(-2), Kind: ChangeStatus, TaskID: "1", From: "pending", To: "completed"},
+		{Time: day(-1), Kind: ChangeCreated, TaskID: "5", To: "pending"},
+		{Time: day(0), Kind: ChangeStatus, TaskID: "2", From: "in_progress", To: "completed"},
+		{Time: day(0), Kind: ChangeDeleted, TaskID: "5", From: "pending"},
+	}
+
+	got := Burndown(tasks, history, 6, now, nil)
+	want := []int{0, 3, 4, 3, 4, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Burndown = %v, want %v", got, want)
+	}
+
+	ui := func(group string) bool { return group == "UI" }
+	got = Burndown(tasks, history, 6, now, ui)
+	want = []int{0, 0, 1, 1, 1, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Burndown(UI) = %v, want %v", got, want)
+	}
+}
+
+func TestCompletionPace(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+	day := func(offset int) time.Time {
+		return time.Date(2024, 3, 10+offset, 12, 0, 0, 0, time.UTC)
+	}
+
+	history := []HistoryEntry{
+		{Time: day(-10), Kind: ChangeStatus, TaskID: "1", From: "pending", To: "completed"},
+		{Time: day(-3), Kind: ChangeStatus, TaskID: "2", From: "pending", To: "completed", Group: "UI"},
+		{Time: day(-2), Kind: ChangeStatus, TaskID: "3", From: "in_progress", To: "completed"},
+		{Time: day(-1), Kind: ChangeStatus, TaskID: "3", From: "completed", To: "pending"},
+		{Time: day(0), Kind: ChangeStatus, TaskID: "4", From: "pending", To: "completed", Group: "UI"},
+		{Time: day(0), Kind: ChangeCreated, TaskID: "5", To: "completed"},
+	}
+
+	// Three completions in the last 4 days (the one 10 days ago is too old)
+	if pace := CompletionPace(history, 4, now, nil); pace != 0.75 {
+		t.Errorf("CompletionPace = %v, want 0.75", pace)
+	}
+	ui := func(group string) bool { return group == "UI" }
+	if pace := CompletionPace(history, 4, now, ui); pace != 0.5 {
+		t.Errorf("CompletionPace(UI) = %v, want 0.5", pace)
+	}
+
+	if days, ok := FinishETA(5, 0.75); !ok || days != 7 {
+		t.Errorf("FinishETA(5, 0.75) = %d, %v, want 7, true", days, ok)
+	}
+	if _, ok := FinishETA(5, 0); ok {
+		t.Error("Expected no ETA without completions")
+	}
+}
+
+func TestActivityHeatmap(t *testing.T) {
+	// A Wednesday; the current week started on Sunday the 3rd
+	now := time.Date(2024, 3, 6, 15, 0, 0, 0, time.UTC)
+	history := []HistoryEntry{
+		{Time: now.AddDate(0, 0, -14), Kind: ChangeStatus, TaskID: "1", From: "pending", To: "completed"},
+		{Time: now.AddDate(0, 0, -1), Kind: ChangeStatus, TaskID: "2", From: "pending", To: "completed"},
+		{Time: now.AddDate(0, 0, -1), Kind: ChangeStatus, TaskID: "3", From: "in_progress", To: "completed"},
+		{Time: now, Kind: ChangeStatus, TaskID: "4", From: "pending", To: "completed"},
+		{Time: now, Kind: ChangeStatus, TaskID: "4", From: "completed", To: "completed"},
+		{Time: now, Kind: ChangeCreated, TaskID: "5", To: "completed"},
+		{Time: now.AddDate(0, 0, -30), Kind: ChangeStatus, TaskID: "6", From: "pending", To: "completed"},
+	}
+
+	cells := ActivityHeatmap(history, 2, now)
+	want := [][7]int{
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 2, 1, -1, -1, -1},
+	}
+	if !reflect.DeepEqual(cells, want) {
+		t.Errorf("ActivityHeatmap = %v, want %v", cells, want)
+	}
+
+	// The completion two weeks ago shows once the heatmap reaches back to it
+	if cells := ActivityHeatmap(history, 3, now); cells[0][3] != 1 {
+		t.Errorf("Expected 1 completion three weeks back, got %v", cells[0])
+	}
+	if cells := ActivityHeatmap(history, 0, now); cells != nil {
+		t.Errorf("Expected no cells for 0 weeks, got %v", cells)
+	}
+}
+
+func TestRecentlyModified(t *testing.T) {
+	tasks := []Task{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	history := []HistoryEntry{
+		{Kind: ChangeCreated, TaskID: "1"},
+		{Kind: ChangeCreated, TaskID: "2"},
+		{Kind: ChangeCreated, TaskID: "4"},
+		{Kind: ChangeStatus, TaskID: "1"},
+		{Kind: ChangeDeleted, TaskID: "4"},
+		{Kind: ChangeUpdated, TaskID: "3"},
+	}
+
+	var got []string
+	for _, task := range RecentlyModified(tasks, history, 10) {
+		got = append(got, task.ID)
+	}
+	// Newest first, no duplicates, deleted tasks skipped
+	if want := []string{"3", "1", "2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RecentlyModified = %v, want %v", got, want)
+	}
+
+	if n := len(RecentlyModified(tasks, history, 2)); n != 2 {
+		t.Errorf("Expected limit of 2, got %d", n)
+	}
+}
+
+func TestLatestInProgress(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Status: "in_progress"},
+		{ID: "2", Status: "in_progress"},
+		{ID: "3", Status: "completed"},
+	}
+	history := []HistoryEntry{
+		{Kind: ChangeStatus, TaskID: "1", To: "in_progress"},
+		{Kind: ChangeStatus, TaskID: "2", To: "in_progress"},
+		{Kind: ChangeStatus, TaskID: "3", To: "in_progress"}, // since completed
+		{Kind: ChangeStatus, TaskID: "3", From: "in_progress", To: "completed"},
+	}
+
+	if got := LatestInProgress(tasks, history); got == nil || got.ID != "2" {
+		t.Errorf("Expected task 2, got %+v", got)
+	}
+	// Without history the first in-progress task is used
+	if got := LatestInProgress(tasks, nil); got == nil || got.ID != "1" {
+		t.Errorf("Expected task 1 without history, got %+v", got)
+	}
+	if got := LatestInProgress(tasks[2:], history); got != nil {
+		t.Errorf("Expected nil with nothing in progress, got %+v", got)
+	}
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// HookOpened is the hook event fired when a project is opened in the TUI
+// (hooks also receive the webhook events created, completed, blocked and,
+// when listed in events, assigned)
+const HookOpened = "opened"
+
+// hookTimeout stops a hook command that hangs
+const hookTimeout = 30 * time.Second
+
+// HookEvent is the JSON written to a hook command's stdin
+type HookEvent struct {
+	Event    string    `json:"event"`
+	Project  string    `json:"project"`
+	Task     *Task     `json:"task,omitempty"` // the whole task as saved (absent for "opened")
+	External bool      `json:"external"`       // changed outside cctasks (e.g. by Claude Code)
+	Time     time.Time `json:"time"`
+
+	PreviousOwner string `json:"previousOwner,omitempty"` // assigned events
+}
+
+// pendingHooks tracks hook commands still running
+var pendingHooks sync.WaitGroup
+
+// runHooks runs the configured hooks for the changes from old in the
+// background. Each hook gets the events of one save in order; failures are
+// only logged, like webhooks.
+func (s *TaskStore) runHooks(old []Task, changes []Change, external bool) {
+	hooks := config.Current().Hooks
+	if len(hooks) == 0 || len(changes) == 0 || s.ProjectName == "" {
+		return
+	}
+	var events []HookEvent
+	for _, e := range WebhookEvents(s.ProjectName, old, s.Tasks, changes, external, time.Now()) {
+		event := HookEvent{Event: e.Event, Project: e.Project, External: e.External, Time: e.Time, PreviousOwner: e.PreviousOwner}
+		if task := s.GetTask(e.Task.ID); task != nil {
+			copied := *task
+			event.Task = &copied
+		}
+		events = append(events, event)
+	}
+	dir, _ := s.dir()
+	startHooks(hooks, s.ProjectName, dir, events)
+}
+
+// RunOpenedHooks runs the hooks for the "opened" event of a project
+func RunOpenedHooks(projectName string) {
+	hooks := config.Current().Hooks
+	if len(hooks) == 0 {
+		return
+	}
+	dir, _ := config.GetProjectDir(projectName)
+	startHooks(hooks, projectName, dir, []HookEvent{{Event: HookOpened, Project: projectName, Time: time.Now()}})
+}
+
+// startHooks runs each matching hook for its events, in a goroutine per hook
+func startHooks(hooks []config.HookConfig, project, dir string, events []HookEvent) {
+	for _, hook := range hooks {
+		if hook.Command == "" || !matchesFilter(hook.Projects, project) {
+			continue
+		}
+		var matched []HookEvent
+		for _, event := range events {
+			if matchesEvent(hook.Events, event.Event) {
+				matched = append(matched, event)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		pendingHooks.Add(1)
+		go func(command string) {
+			defer pendingHooks.Done()
+			for _, event := range matched {
+				runHook(command, dir, event)
+			}
+		}(hook.Command)
+	}
+}
+
+// runHook runs one hook command with the event on stdin and in environment
+// variables. Output is captured so it cannot draw over the TUI.
+func runHook(command, dir string, event HookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	shell := hookShell(command)
+	cmd := exec.CommandContext(ctx, shell[0], shell[1:]...)
+	if _, err := os.Stat(dir); err == nil {
+		cmd.Dir = dir
+	}
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(), "CCTASKS_EVENT="+event.Event, "CCTASKS_PROJECT="+event.Project)
+	if event.Task != nil {
+		cmd.Env = append(cmd.Env, "CCTASKS_TASK_ID="+event.Task.ID)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		slog.Debug("hook failed", "command", command, "event", event.Event, "err", err, "output", string(out))
+		return
+	}
+	slog.Debug("hook ran", "command", command, "event", event.Event)
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package data
+
+// hookShell returns the command line running a hook command
+func hookShell(command string) []string {
+	return []string{"sh", "-c", command}
+}
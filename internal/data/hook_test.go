@@ -0,0 +1,76 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+func TestRunHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hook commands below need sh")
+	}
+	out := filepath.Join(t.TempDir(), "events.jsonl")
+	cfg := config.Default()
+	cfg.Hooks = []config.HookConfig{
+		{Command: "cat >> '" + out + "'; echo >> '" + out + "'", Events: []string{WebhookCreated, WebhookCompleted}},
+		{Command: "echo \"$CCTASKS_EVENT $CCTASKS_TASK_ID\" >> '" + out + ".env'"},
+		{Command: "touch '" + out + ".other'", Projects: []string{"other"}},
+	}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	store, err := NewTaskStoreForTest(t.TempDir(), []Task{
+		{ID: "1", Subject: "Task 1", Status: "pending"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Tasks[0].Status = "completed"
+	store.AddTask(Task{Subject: "Task 2", Status: "pending", Owner: "alice"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	WaitWebhooks(5 * time.Second)
+
+	content, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []HookEvent
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		var e HookEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid hook input %q: %v", line, err)
+		}
+		events = append(events, e)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(events), events)
+	}
+	for _, e := range events {
+		if e.Project != "test" || e.Task == nil || e.External {
+			t.Errorf("event = %+v", e)
+		}
+	}
+	if created := events[1]; created.Event != WebhookCreated || created.Task.Owner != "alice" {
+		t.Errorf("created event = %+v, want the whole new task", created)
+	}
+
+	env, err := os.ReadFile(out + ".env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(env)); got != "completed 1\ncreated 2" {
+		t.Errorf("environment = %q", got)
+	}
+	if _, err := os.Stat(out + ".other"); !os.IsNotExist(err) {
+		t.Error("hook for another project should not run")
+	}
+}
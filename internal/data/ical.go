@@ -0,0 +1,132 @@
+package data
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Calendar component written for each task with a due date
+const (
+	ICalEvent = "event" // all-day VEVENT on the due date (shown by most calendar apps)
+	ICalTodo  = "todo"  // VTODO with a due date (for apps with task lists)
+)
+
+// icalPriorities maps task priorities to iCalendar PRIORITY values
+var icalPriorities = map[string]int{"high": 1, "medium": 5, "low": 9}
+
+// icalStatuses maps task statuses to VTODO STATUS values
+var icalStatuses = map[string]string{
+	"pending":     "NEEDS-ACTION",
+	"in_progress": "IN-PROCESS",
+	"completed":   "COMPLETED",
+}
+
+// WriteICal writes tasks with due dates as an iCalendar feed. Completed
+// tasks are written as completed VTODOs and left out of event feeds.
+func WriteICal(w io.Writer, tasks []ProjectTask, kind string, now time.Time) error {
+	if kind != ICalEvent && kind != ICalTodo {
+		return fmt.Errorf("unknown calendar kind %q (expected event or todo)", kind)
+	}
+
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		b.WriteString(foldICalLine(fmt.Sprintf(format, args...)))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//cctasks//cctasks//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:cctasks")
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, pt := range tasks {
+		task := pt.Task
+		due, err := time.Parse(DateFormat, GetTaskDue(task))
+		if err != nil {
+			continue // no (valid) due date
+		}
+		if kind == ICalEvent && task.Status == "completed" {
+			continue
+		}
+
+		component := "VEVENT"
+		if kind == ICalTodo {
+			component = "VTODO"
+		}
+		line("BEGIN:%s", component)
+		line("UID:%s", icalUID(pt))
+		line("DTSTAMP:%s", stamp)
+		line("SUMMARY:%s", escapeICalText(fmt.Sprintf("#%s %s", DisplayID(task), task.Subject)))
+		if kind == ICalEvent {
+			line("DTSTART;VALUE=DATE:%s", due.Format("20060102"))
+			line("DTEND;VALUE=DATE:%s", due.AddDate(0, 0, 1).Format("20060102"))
+			line("TRANSP:TRANSPARENT")
+		} else {
+			if start, err := time.Parse(DateFormat, GetTaskStart(task)); err == nil {
+				line("DTSTART;VALUE=DATE:%s", start.Format("20060102"))
+			}
+			line("DUE;VALUE=DATE:%s", due.Format("20060102"))
+			line("STATUS:%s", icalStatuses[task.Status])
+		}
+		if p, ok := icalPriorities[GetTaskPriority(task)]; ok {
+			line("PRIORITY:%d", p)
+		}
+		description := fmt.Sprintf("Project: %s\nStatus: %s", pt.Project, task.Status)
+		if task.Owner != "" {
+			description += "\nOwner: " + task.Owner
+		}
+		if task.Description != "" {
+			description += "\n\n" + task.Description
+		}
+		line("DESCRIPTION:%s", escapeICalText(description))
+		categories := []string{pt.Project}
+		if group := GetTaskGroup(task); group != "" {
+			categories = append(categories, group)
+		}
+		for i, c := range categories {
+			categories[i] = escapeICalText(c)
+		}
+		line("CATEGORIES:%s", strings.Join(categories, ","))
+		line("END:%s", component)
+	}
+	line("END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// icalUID returns a stable UID for a task
+func icalUID(pt ProjectTask) string {
+	return strings.ReplaceAll(pt.Project, "/", ".") + "-" + pt.Task.ID + "@cctasks"
+}
+
+// escapeICalText escapes a TEXT value (RFC 5545 3.3.11)
+func escapeICalText(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, ";", `\;`)
+	s = strings.ReplaceAll(s, ",", `\,`)
+	s = strings.ReplaceAll(s, "\r\n", `\n`)
+	return strings.ReplaceAll(s, "\n", `\n`)
+}
+
+// foldICalLine folds a content line to at most 75 octets per line,
+// without splitting UTF-8 characters (RFC 5545 3.1)
+func foldICalLine(s string) string {
+	const limit = 75
+	var b strings.Builder
+	lineLen := 0
+	for _, r := range s {
+		size := utf8.RuneLen(r)
+		if lineLen+size > limit {
+			b.WriteString("\r\n ")
+			lineLen = 1
+		}
+		b.WriteRune(r)
+		lineLen += size
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package data
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICal(t *testing.T) {
+	tasks := []ProjectTask{
+		{Project: "web", Task: Task{ID: "1", Subject: "Ship, then rest; ok", Status: "in_progress",
+			Metadata: map[string]interface{}{"group": "Release", "dueDate": "2026-03-01", "startDate": "2026-02-20", "priority": "high"}}},
+		{Project: "web", Task: Task{ID: "2", Subject: "Done", Status: "completed",
+			Metadata: map[string]interface{}{"dueDate": "2026-03-02"}}},
+		{Project: "web", Task: Task{ID: "3", Subject: "No due date", Status: "pending"}},
+	}
+	now := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+
+	var b strings.Builder
+	if err := WriteICal(&b, tasks, ICalEvent, now); err != nil {
+		t.Fatalf("WriteICal failed: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:web-1@cctasks\r\n",
+		"DTSTAMP:20260201T090000Z\r\n",
+		`SUMMARY:#1 Ship\, then rest\; ok` + "\r\n",
+		"DTSTART;VALUE=DATE:20260301\r\n",
+		"DTEND;VALUE=DATE:20260302\r\n",
+		"PRIORITY:1\r\n",
+		"CATEGORIES:web,Release\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("event feed missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "BEGIN:VEVENT"); n != 1 {
+		t.Errorf("event feed has %d events, want 1 (no completed or undated tasks)", n)
+	}
+
+	b.Reset()
+	if err := WriteICal(&b, tasks, ICalTodo, now); err != nil {
+		t.Fatalf("WriteICal failed: %v", err)
+	}
+	out = b.String()
+	for _, want := range []string{
+		"DTSTART;VALUE=DATE:20260220\r\n",
+		"DUE;VALUE=DATE:20260301\r\n",
+		"STATUS:IN-PROCESS\r\n",
+		"STATUS:COMPLETED\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("todo feed missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "BEGIN:VTODO"); n != 2 {
+		t.Errorf("todo feed has %d todos, want 2", n)
+	}
+
+	if err := WriteICal(&b, tasks, "journal", now); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("あ", 40)
+	folded := foldICalLine(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("folded line has %d octets: %q", len(part), part)
+		}
+	}
+	if strings.ReplaceAll(folded, "\r\n ", "") != line {
+		t.Errorf("unfolding %q does not give the original line", folded)
+	}
+}
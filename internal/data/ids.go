@@ -0,0 +1,112 @@
+package data
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// aliasKey is the metadata key of the short sequential alias of UUID tasks
+const aliasKey = "alias"
+
+// newUUID returns a random (version 4) UUID
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err) // crypto/rand does not fail on supported platforms
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// IsUUID reports whether id has the canonical UUID form
+func IsUUID(id string) bool {
+	if len(id) != 36 {
+		return false
+	}
+	for i, c := range id {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// GetTaskAlias returns the short alias of a task with a UUID, or ""
+func GetTaskAlias(task Task) string {
+	return metadataString(task, aliasKey)
+}
+
+// DisplayID returns the ID shown to the user: the alias of a UUID task,
+// otherwise the ID itself
+func DisplayID(task Task) string {
+	if alias := GetTaskAlias(task); alias != "" {
+		return alias
+	}
+	return task.ID
+}
+
+// taskNumber returns the sequential number of a task (its numeric ID or
+// alias), or 0 if it has none
+func taskNumber(task Task) int {
+	if n, err := strconv.Atoi(task.ID); err == nil {
+		return n
+	}
+	n, _ := strconv.Atoi(GetTaskAlias(task))
+	return n
+}
+
+// lessTaskNumber orders tasks by their sequential number, then by ID
+func lessTaskNumber(a, b Task) bool {
+	if na, nb := taskNumber(a), taskNumber(b); na != nb {
+		return na < nb
+	}
+	return a.ID < b.ID
+}
+
+// nextTaskNumber returns the next unused sequential number
+func (s *TaskStore) nextTaskNumber() int {
+	maxNum := 0
+	for _, task := range s.Tasks {
+		maxNum = max(maxNum, taskNumber(task))
+	}
+	return maxNum + 1
+}
+
+// useUUIDs reports whether new tasks get UUIDs
+func useUUIDs() bool {
+	return config.Current().IDs.Format == "uuid"
+}
+
+// ResolveTaskRef returns the ID of the task referenced by an ID or alias;
+// unknown references are returned unchanged
+func (s *TaskStore) ResolveTaskRef(ref string) string {
+	if s.GetTask(ref) != nil {
+		return ref
+	}
+	for _, task := range s.Tasks {
+		if GetTaskAlias(task) == ref {
+			return task.ID
+		}
+	}
+	return ref
+}
+
+// DisplayRef returns the display ID of the task with the given ID (the ID
+// itself if there is no such task)
+func (s *TaskStore) DisplayRef(id string) string {
+	if task := s.GetTask(id); task != nil {
+		return DisplayID(*task)
+	}
+	return id
+}
@@ -0,0 +1,71 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+func TestAddTaskWithUUIDs(t *testing.T) {
+	cfg := config.Default()
+	cfg.IDs.Format = "uuid"
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	store, err := NewTaskStoreForTest(t.TempDir(), []Task{
+		{ID: "1", Subject: "Existing", Status: "pending"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := store.AddTask(Task{Subject: "New"})
+	if !IsUUID(id) {
+		t.Fatalf("Expected a UUID, got %q", id)
+	}
+	task := store.GetTask(id)
+	if DisplayID(*task) != "2" {
+		t.Errorf("Expected alias 2, got %q", DisplayID(*task))
+	}
+
+	// Aliases resolve to the stable ID; plain IDs resolve to themselves
+	if got := store.ResolveTaskRef("2"); got != id {
+		t.Errorf("ResolveTaskRef(2) = %q, want %q", got, id)
+	}
+	if got := store.ResolveTaskRef("1"); got != "1" {
+		t.Errorf("ResolveTaskRef(1) = %q, want 1", got)
+	}
+	if got := store.DisplayRef(id); got != "2" {
+		t.Errorf("DisplayRef = %q, want 2", got)
+	}
+
+	// The next alias (and sequential ID, once UUIDs are turned off) follows it
+	if next := store.AddTask(Task{Subject: "Next"}); DisplayID(*store.GetTask(next)) != "3" {
+		t.Errorf("Expected alias 3, got %q", DisplayID(*store.GetTask(next)))
+	}
+	config.SetCurrent(config.Default())
+	if next := store.AddTask(Task{Subject: "Sequential"}); next != "4" {
+		t.Errorf("Expected sequential ID 4, got %q", next)
+	}
+
+	// UUIDs pass validation and are never renumbered
+	if !isValidTaskID(id) {
+		t.Errorf("Expected %q to be a valid task ID", id)
+	}
+	for _, c := range RenumberPlan(store.Tasks) {
+		if c.From == id {
+			t.Errorf("UUID task must keep its ID, got %+v", c)
+		}
+	}
+}
+
+func TestIsUUID(t *testing.T) {
+	if !IsUUID(newUUID()) {
+		t.Error("Expected newUUID to produce a UUID")
+	}
+	for _, id := range []string{"", "12", "0f8fad5b-d9cb-469f-a165-70867728950", "0f8fad5b-d9cb-469f-a165-70867728950z"} {
+		if IsUUID(id) {
+			t.Errorf("IsUUID(%q) = true, want false", id)
+		}
+	}
+}
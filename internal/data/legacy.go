@@ -0,0 +1,275 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LegacyTasksFile is the single file holding all of a project's tasks in the
+// layout of older Claude Code versions
+const LegacyTasksFile = "tasks.json"
+
+// Layouts of a project directory's task files
+const (
+	LayoutFiles  = "files"  // one <id>.json file per task
+	LayoutSingle = "single" // every task in tasks.json
+)
+
+// Layouts lists the task file layouts
+var Layouts = []string{LayoutFiles, LayoutSingle}
+
+// DetectLayout reports how a project directory stores its tasks: in
+// tasks.json when that file exists, otherwise in one file per task
+func DetectLayout(projectDir string) string {
+	if info, err := os.Stat(filepath.Join(projectDir, LegacyTasksFile)); err == nil && !info.IsDir() {
+		return LayoutSingle
+	}
+	return LayoutFiles
+}
+
+// singleFile is the shape of a tasks.json: a bare array of tasks, or an
+// object holding them under "tasks" whose other fields are kept
+type singleFile struct {
+	wrapped bool
+	fields  map[string]json.RawMessage // the object's other fields
+	raw     map[string]json.RawMessage // each task as last read or written, by ID
+	broken  []json.RawMessage          // entries that are not tasks, written back as they were
+}
+
+// parseSingleFile reads the tasks of a tasks.json. Entries that are not
+// tasks are kept for saving and reported as problems, like the schema
+// problems of the tasks; err is set when the file itself cannot be parsed.
+func parseSingleFile(content []byte) (f *singleFile, tasks []Task, problems []Problem, err error) {
+	f = &singleFile{raw: make(map[string]json.RawMessage)}
+	var entries []json.RawMessage
+	if trimmed := bytes.TrimLeft(content, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(content, &f.fields); err != nil {
+			return nil, nil, nil, err
+		}
+		f.wrapped = true
+		if list, ok := f.fields["tasks"]; ok {
+			if err := json.Unmarshal(list, &entries); err != nil {
+				return nil, nil, nil, fmt.Errorf("field \"tasks\" must be an array: %w", err)
+			}
+			delete(f.fields, "tasks")
+		}
+	} else if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, nil, nil, err
+	}
+
+	for i, entry := range entries {
+		var task Task
+		if err := json.Unmarshal(entry, &task); err != nil {
+			f.broken = append(f.broken, entry)
+			problems = append(problems, Problem{File: LegacyTasksFile, Message: fmt.Sprintf("entry %d: %v", i+1, err)})
+			continue
+		}
+		for _, p := range ValidateTaskData(task.ID+".json", entry) {
+			problems = append(problems, singleFileProblem(p))
+		}
+		f.raw[task.ID] = entry
+		tasks = append(tasks, task)
+	}
+	return f, tasks, problems, nil
+}
+
+// singleFileProblem moves a problem reported for a task's own file to
+// tasks.json, naming the task in the message instead
+func singleFileProblem(p Problem) Problem {
+	if p.File == LegacyTasksFile {
+		return p
+	}
+	id := strings.TrimSuffix(p.File, ".json")
+	return Problem{File: LegacyTasksFile, Message: fmt.Sprintf("#%s: %s", id, p.Message)}
+}
+
+// marshal returns the content of a tasks.json holding tasks, in the shape
+// the file was read in
+func (f *singleFile) marshal(tasks []Task) ([]byte, error) {
+	f.raw = make(map[string]json.RawMessage, len(tasks))
+	entries := make([]json.RawMessage, 0, len(tasks)+len(f.broken))
+	for _, task := range tasks {
+		entry, err := json.Marshal(task)
+		if err != nil {
+			return nil, err
+		}
+		f.raw[task.ID] = entry
+		entries = append(entries, entry)
+	}
+	entries = append(entries, f.broken...)
+
+	var value interface{} = entries
+	if f.wrapped {
+		obj := make(map[string]json.RawMessage, len(f.fields)+1)
+		for key, field := range f.fields {
+			obj[key] = field
+		}
+		list, err := json.Marshal(entries)
+		if err != nil {
+			return nil, err
+		}
+		obj["tasks"] = list
+		value = obj
+	}
+	return json.MarshalIndent(value, "", "  ")
+}
+
+// Layout reports how the project stores its tasks: LayoutFiles or LayoutSingle
+func (s *TaskStore) Layout() string {
+	if s.layout == "" {
+		return LayoutFiles
+	}
+	return s.layout
+}
+
+// saveSingleFile writes every task to tasks.json. A tasks.json that could
+// not be parsed on load is not overwritten.
+func (s *TaskStore) saveSingleFile() error {
+	if s.single == nil {
+		return fmt.Errorf("%s could not be parsed; fix or restore it before saving", LegacyTasksFile)
+	}
+	filePath, err := s.FilePath(LegacyTasksFile)
+	if err != nil {
+		return err
+	}
+	content, err := s.single.marshal(s.Tasks)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return err
+	}
+	// Our own write is not an external change
+	if info, err := os.Stat(filePath); err == nil && s.files != nil {
+		s.files[LegacyTasksFile] = stampOf(info)
+	}
+	return nil
+}
+
+// singleTaskJSON returns a task's entry of tasks.json pretty-printed
+func (s *TaskStore) singleTaskJSON(id string) ([]byte, error) {
+	if s.single == nil {
+		return s.RawFile(LegacyTasksFile)
+	}
+	raw, ok := s.single.raw[id]
+	if !ok {
+		return nil, fmt.Errorf("task %s is not in %s", id, LegacyTasksFile)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return raw, err
+	}
+	return buf.Bytes(), nil
+}
+
+// scanSingleFile counts the tasks of a tasks.json by status, like scanProject
+func scanSingleFile(dir string, project Project) Project {
+	path := filepath.Join(dir, LegacyTasksFile)
+	if info, err := os.Stat(path); err == nil && info.ModTime().After(project.ModTime) {
+		project.ModTime = info.ModTime()
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return project
+	}
+	_, tasks, _, err := parseSingleFile(content)
+	if err != nil {
+		return project
+	}
+	project.TaskCount = len(tasks)
+	for _, task := range tasks {
+		switch task.Status {
+		case "pending":
+			project.Pending++
+		case "in_progress":
+			project.InProgress++
+		case "completed":
+			project.Completed++
+		}
+	}
+	return project
+}
+
+// validateSingleFile checks the tasks of a tasks.json, like validateDir
+func validateSingleFile(dir string) ([]Problem, error) {
+	content, err := os.ReadFile(filepath.Join(dir, LegacyTasksFile))
+	if err != nil {
+		return []Problem{{File: LegacyTasksFile, Message: err.Error()}}, nil
+	}
+	_, tasks, problems, err := parseSingleFile(content)
+	if err != nil {
+		return []Problem{{File: LegacyTasksFile, Message: fmt.Sprintf("invalid JSON: %v", err)}}, nil
+	}
+	for _, p := range ValidateReferences(tasks) {
+		problems = append(problems, singleFileProblem(p))
+	}
+	return problems, nil
+}
+
+// ConvertLayout rewrites a project's tasks in another layout: from one file
+// per task into tasks.json, for older Claude Code versions, or back. The
+// project is backed up first; projects with task files that cannot be
+// parsed are left alone. It returns the number of tasks converted.
+func ConvertLayout(projectName, layout string) (int, error) {
+	if !containsString(Layouts, layout) {
+		return 0, fmt.Errorf("unknown layout %q (expected %s)", layout, strings.Join(Layouts, ", "))
+	}
+	if err := checkProjectWritable(projectName); err != nil {
+		return 0, err
+	}
+	store, err := LoadTasks(projectName)
+	if err != nil {
+		return 0, err
+	}
+	if store.key != nil {
+		return 0, fmt.Errorf("%s is encrypted; run 'cctasks decrypt %s' first", projectName, projectName)
+	}
+	if store.Layout() == layout {
+		return 0, fmt.Errorf("%s already uses the %s layout", projectName, layout)
+	}
+	if len(store.Unparsable) > 0 || (store.single != nil && len(store.single.broken) > 0) {
+		return 0, fmt.Errorf("%s has task files that cannot be parsed; run 'cctasks validate %s' and fix them first", projectName, projectName)
+	}
+	projectDir, err := store.dir()
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		return 0, err
+	}
+	if err := SnapshotProject(projectName); err != nil {
+		return 0, fmt.Errorf("backup: %w", err)
+	}
+
+	// Write the new layout first, then remove the old files
+	switch layout {
+	case LayoutSingle:
+		store.layout, store.single = LayoutSingle, &singleFile{}
+		if err := store.saveSingleFile(); err != nil {
+			return 0, err
+		}
+		for name := range store.files {
+			if name == LegacyTasksFile {
+				continue
+			}
+			if err := os.Remove(filepath.Join(projectDir, name)); err != nil && !os.IsNotExist(err) {
+				return 0, err
+			}
+		}
+	case LayoutFiles:
+		store.layout, store.single = LayoutFiles, nil
+		for _, task := range store.Tasks {
+			if err := store.saveTask(task); err != nil {
+				return 0, err
+			}
+		}
+		if err := os.Remove(filepath.Join(projectDir, LegacyTasksFile)); err != nil && !os.IsNotExist(err) {
+			return 0, err
+		}
+	}
+	return len(store.Tasks), nil
+}
@@ -0,0 +1,144 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+// setupLegacyProject creates a project "proj" holding content in tasks.json
+func setupLegacyProject(t *testing.T, content string) string {
+	t.Helper()
+	tasksDir := testutil.IsolateHome(t)
+
+	projectDir := filepath.Join(tasksDir, "proj")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, LegacyTasksFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return projectDir
+}
+
+func TestLoadTasksSingleFile(t *testing.T) {
+	projectDir := setupLegacyProject(t, `[
+		{"id":"1","subject":"Ship","status":"pending","blocks":["2"],"blockedBy":[]},
+		{"id":"2","subject":"Test","status":"completed","blocks":[],"blockedBy":["1"]},
+		42
+	]`)
+
+	store, err := LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.Layout() != LayoutSingle || len(store.Tasks) != 2 {
+		t.Fatalf("layout = %s, tasks = %d; want single, 2", store.Layout(), len(store.Tasks))
+	}
+	if len(store.Problems) != 1 || store.Problems[0].File != LegacyTasksFile || !strings.HasPrefix(store.Problems[0].Message, "entry 3:") {
+		t.Errorf("Problems = %+v, want the entry that is not a task", store.Problems)
+	}
+	if raw, err := store.RawTaskJSON("2"); err != nil || !strings.Contains(string(raw), `"subject": "Test"`) {
+		t.Errorf("RawTaskJSON(2) = %s, %v", raw, err)
+	}
+
+	// Saving rewrites tasks.json, keeping the entry that is not a task
+	task := *store.GetTask("1")
+	task.Subject = "Ship it"
+	if err := store.UpdateTask(task); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	// (the directory mtime is reset since saving may create the history file)
+	if err := os.Chtimes(projectDir, store.lastModTime, store.lastModTime); err != nil {
+		t.Fatal(err)
+	}
+	if store.NeedsReload() {
+		t.Error("Our own save should not need a reload")
+	}
+	if err := store.DeleteTask("2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(filepath.Join(projectDir, LegacyTasksFile))
+	var entries []json.RawMessage
+	if err := json.Unmarshal(content, &entries); err != nil || len(entries) != 2 || string(entries[1]) != "42" {
+		t.Fatalf("tasks.json = %s, want the renamed task and 42", content)
+	}
+	if !strings.Contains(string(entries[0]), `"Ship it"`) {
+		t.Errorf("saved task = %s", entries[0])
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "1.json")); !os.IsNotExist(err) {
+		t.Error("Saving a single-file project should not create task files")
+	}
+	trash, err := store.ListTrash()
+	if err != nil || len(trash) != 1 || trash[0].Task.ID != "2" {
+		t.Errorf("trash = %+v, %v; want task 2", trash, err)
+	}
+}
+
+func TestLoadTasksSingleFileWrapped(t *testing.T) {
+	projectDir := setupLegacyProject(t, `{"version":1,"tasks":[{"id":"1","subject":"Ship","status":"pending"}]}`)
+
+	store, err := LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Tasks) != 1 || len(store.Problems) != 0 {
+		t.Fatalf("tasks = %d, problems = %+v", len(store.Tasks), store.Problems)
+	}
+	store.Tasks[0].Status = "in_progress"
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(filepath.Join(projectDir, LegacyTasksFile))
+	var file struct {
+		Version int    `json:"version"`
+		Tasks   []Task `json:"tasks"`
+	}
+	if err := json.Unmarshal(content, &file); err != nil || file.Version != 1 || len(file.Tasks) != 1 || file.Tasks[0].Status != "in_progress" {
+		t.Errorf("tasks.json = %s, want the object kept with the new status", content)
+	}
+}
+
+func TestConvertLayout(t *testing.T) {
+	projectDir := setupLegacyProject(t, `[{"id":"1","subject":"Ship","status":"pending"},{"id":"2","subject":"Test","status":"pending"}]`)
+
+	if _, err := ConvertLayout("proj", LayoutSingle); err == nil {
+		t.Error("Converting to the layout in use should fail")
+	}
+
+	n, err := ConvertLayout("proj", LayoutFiles)
+	if err != nil || n != 2 {
+		t.Fatalf("ConvertLayout(files) = %d, %v", n, err)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, LegacyTasksFile)); !os.IsNotExist(err) {
+		t.Error("tasks.json should be removed")
+	}
+	store, err := LoadTasks("proj")
+	if err != nil || store.Layout() != LayoutFiles || len(store.Tasks) != 2 {
+		t.Fatalf("after converting to files: %v, %+v", err, store)
+	}
+
+	if _, err := ConvertLayout("proj", LayoutSingle); err != nil {
+		t.Fatalf("ConvertLayout(single) failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "1.json")); !os.IsNotExist(err) {
+		t.Error("task files should be removed")
+	}
+	store, err = LoadTasks("proj")
+	if err != nil || store.Layout() != LayoutSingle || len(store.Tasks) != 2 {
+		t.Fatalf("after converting to single: %v, %+v", err, store)
+	}
+	if snapshots, _ := ListSnapshots("proj"); len(snapshots) == 0 {
+		t.Error("Converting should back the project up first")
+	}
+}
@@ -0,0 +1,133 @@
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+// MergeTasks combines two tasks into the one with the lower ID and returns it.
+// Descriptions are concatenated, dependencies and tags are unioned, and
+// references to the absorbed task are redirected to the surviving one.
+// The project is saved, and only then is the absorbed task moved to the
+// trash; when saving fails, the store is left as it was.
+func (s *TaskStore) MergeTasks(idA, idB string) (*Task, error) {
+	if idA == idB {
+		return nil, fmt.Errorf("cannot merge task #%s with itself", idA)
+	}
+	a, b := s.GetTask(idA), s.GetTask(idB)
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("task not found")
+	}
+
+	keep, absorb := *a, *b
+	if lessTaskNumber(*b, *a) {
+		keep, absorb = absorb, keep
+	}
+
+	merged := cloneTask(keep)
+	merged.Description = mergeDescriptions(keep, absorb)
+	if merged.ActiveForm == "" {
+		merged.ActiveForm = absorb.ActiveForm
+	}
+	if merged.Owner == "" {
+		merged.Owner = absorb.Owner
+	}
+	if GetTaskGroup(merged) == "" {
+		SetTaskGroup(&merged, GetTaskGroup(absorb))
+	}
+	SetTaskTags(&merged, unionStrings(GetTaskTags(keep), GetTaskTags(absorb)))
+
+	// Union dependencies, dropping links between the two merged tasks
+	skip := map[string]bool{keep.ID: true, absorb.ID: true}
+	merged.Blocks = withoutIDs(unionStrings(keep.Blocks, absorb.Blocks), skip)
+	merged.BlockedBy = withoutIDs(unionStrings(keep.BlockedBy, absorb.BlockedBy), skip)
+
+	// Redirect references from the absorbed task to the surviving one
+	var tasks []Task
+	for _, task := range s.Tasks {
+		switch task.ID {
+		case keep.ID:
+			tasks = append(tasks, merged)
+		case absorb.ID:
+			// removed
+		default:
+			task.Blocks = redirectID(task.Blocks, absorb.ID, keep.ID)
+			task.BlockedBy = redirectID(task.BlockedBy, absorb.ID, keep.ID)
+			tasks = append(tasks, task)
+		}
+	}
+	prev := s.Tasks
+	s.Tasks = tasks
+
+	now := time.Now()
+	if s.layout == LayoutSingle {
+		// Saving drops the absorbed task from tasks.json: copy it to the trash first
+		if err := s.moveToTrash(absorb, now); err != nil {
+			s.Tasks = prev
+			return nil, err
+		}
+	}
+	if err := s.Save(); err != nil {
+		s.Tasks = prev
+		return nil, err
+	}
+	if s.layout != LayoutSingle {
+		if err := s.moveToTrash(absorb, now); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetTask(keep.ID), nil
+}
+
+// mergeDescriptions concatenates two descriptions, noting where the second came from
+func mergeDescriptions(keep, absorb Task) string {
+	note := fmt.Sprintf("(merged from #%s: %s)", DisplayID(absorb), absorb.Subject)
+	if absorb.Description != "" {
+		note += "\n" + absorb.Description
+	}
+	if keep.Description == "" {
+		return note
+	}
+	return keep.Description + "\n\n" + note
+}
+
+// unionStrings returns the items of a followed by the items of b not in a
+func unionStrings(a, b []string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, item := range append(append([]string{}, a...), b...) {
+		if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// withoutIDs returns ids without the ones in skip
+func withoutIDs(ids []string, skip map[string]bool) []string {
+	result := []string{}
+	for _, id := range ids {
+		if !skip[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// redirectID replaces from with to in ids, avoiding duplicates
+func redirectID(ids []string, from, to string) []string {
+	if !containsString(ids, from) {
+		return ids
+	}
+	result := []string{}
+	for _, id := range ids {
+		if id == from {
+			id = to
+		}
+		if !containsString(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
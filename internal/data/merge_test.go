@@ -0,0 +1,112 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestMergeTasks(t *testing.T) {
+	testutil.IsolateHome(t)
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewTaskStoreForTest(tmpDir, []Task{
+		{ID: "1", Subject: "Base", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "2", Subject: "Login", Description: "First", Status: "pending", Blocks: []string{"4"}, BlockedBy: []string{},
+			Metadata: map[string]interface{}{"tags": []interface{}{"auth"}}},
+		{ID: "3", Subject: "Login again", Description: "Second", Status: "pending", Blocks: []string{"2"}, BlockedBy: []string{"1"},
+			Metadata: map[string]interface{}{"tags": []interface{}{"auth", "ui"}, "group": "Frontend"}},
+		{ID: "4", Subject: "Deploy", Status: "pending", Blocks: []string{}, BlockedBy: []string{"2"}},
+		{ID: "5", Subject: "Docs", Status: "pending", Blocks: []string{"3"}, BlockedBy: []string{}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	merged, err := store.MergeTasks("3", "2")
+	if err != nil {
+		t.Fatalf("MergeTasks failed: %v", err)
+	}
+
+	if merged.ID != "2" {
+		t.Errorf("Expected lower ID '2' to survive, got '%s'", merged.ID)
+	}
+	if merged.Description != "First\n\n(merged from #3: Login again)\nSecond" {
+		t.Errorf("Unexpected description: %q", merged.Description)
+	}
+	if len(merged.Blocks) != 1 || merged.Blocks[0] != "4" {
+		t.Errorf("Expected blocks [4] without self-reference, got %v", merged.Blocks)
+	}
+	if len(merged.BlockedBy) != 1 || merged.BlockedBy[0] != "1" {
+		t.Errorf("Expected blockedBy [1], got %v", merged.BlockedBy)
+	}
+	if tags := GetTaskTags(*merged); len(tags) != 2 || tags[0] != "auth" || tags[1] != "ui" {
+		t.Errorf("Expected tags [auth ui], got %v", tags)
+	}
+	if group := GetTaskGroup(*merged); group != "Frontend" {
+		t.Errorf("Expected group from absorbed task, got '%s'", group)
+	}
+
+	if store.GetTask("3") != nil {
+		t.Error("Expected task 3 to be removed")
+	}
+	if docs := store.GetTask("5"); len(docs.Blocks) != 1 || docs.Blocks[0] != "2" {
+		t.Errorf("Expected reference to #3 redirected to #2, got %v", docs.Blocks)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "3.json")); !os.IsNotExist(err) {
+		t.Error("Expected 3.json to be moved to the trash")
+	}
+	items, _ := store.ListTrash()
+	if len(items) != 1 || items[0].Task.ID != "3" {
+		t.Errorf("Expected absorbed task in trash, got %+v", items)
+	}
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "2.json"))
+	if !strings.Contains(string(content), "merged from #3") {
+		t.Errorf("Expected the merged task saved, got %s", content)
+	}
+}
+
+func TestMergeTasksSaveFails(t *testing.T) {
+	testutil.IsolateHome(t)
+	tmpDir := t.TempDir()
+	store, err := NewTaskStoreForTest(tmpDir, []Task{
+		{ID: "1", Subject: "Login", Status: "pending"},
+		{ID: "2", Subject: "Login again", Description: "Only copy", Status: "pending"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1.json cannot be written
+	os.Remove(filepath.Join(tmpDir, "1.json"))
+	if err := os.Mkdir(filepath.Join(tmpDir, "1.json"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.MergeTasks("1", "2"); err == nil {
+		t.Fatal("Expected the failed save reported")
+	}
+	if content, err := os.ReadFile(filepath.Join(tmpDir, "2.json")); err != nil || !strings.Contains(string(content), "Only copy") {
+		t.Errorf("Expected the absorbed task kept in place, got %s, %v", content, err)
+	}
+	if store.GetTask("2") == nil {
+		t.Error("Expected the store left as it was")
+	}
+	if items, _ := store.ListTrash(); len(items) != 0 {
+		t.Errorf("Expected nothing in the trash, got %+v", items)
+	}
+}
+
+func TestMergeTasksSelf(t *testing.T) {
+	store := &TaskStore{Tasks: []Task{{ID: "1"}}}
+	if _, err := store.MergeTasks("1", "1"); err == nil {
+		t.Error("Expected error when merging a task with itself")
+	}
+}
@@ -0,0 +1,109 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// Task schema versions of a project directory, in the order Claude Code
+// used them
+const (
+	SchemaSingleFile = 1 // every task in tasks.json
+	SchemaTaskFiles  = 2 // one <id>.json file per task
+)
+
+// CurrentSchema is the schema version of current Claude Code
+const CurrentSchema = SchemaTaskFiles
+
+// Migration upgrades a project directory from one schema version to the next
+type Migration struct {
+	From        int
+	To          int
+	Description string
+	Apply       func(projectName string) error
+}
+
+// migrations lists the converters between schema versions, oldest first.
+// When Claude Code changes its task layout again, add a schema version, a
+// converter from the previous one, and teach DetectSchema to tell it apart.
+var migrations = []Migration{
+	{
+		From:        SchemaSingleFile,
+		To:          SchemaTaskFiles,
+		Description: "split tasks.json into one file per task",
+		Apply: func(projectName string) error {
+			_, err := ConvertLayout(projectName, LayoutFiles)
+			return err
+		},
+	},
+}
+
+// DetectSchema reports the schema version of a project directory
+func DetectSchema(projectDir string) int {
+	if DetectLayout(projectDir) == LayoutSingle {
+		return SchemaSingleFile
+	}
+	return SchemaTaskFiles
+}
+
+// MigrationPlan is the steps upgrading a project to a schema version
+type MigrationPlan struct {
+	Project string
+	From    int
+	To      int
+	Steps   []Migration
+}
+
+// PlanMigration returns the steps upgrading a project to schema version to;
+// a project already there gets a plan without steps
+func PlanMigration(projectName string, to int) (MigrationPlan, error) {
+	projectDir, err := config.GetProjectDir(projectName)
+	if err != nil {
+		return MigrationPlan{}, err
+	}
+	plan := MigrationPlan{Project: projectName, From: DetectSchema(projectDir), To: to}
+	plan.Steps, err = planSteps(migrations, plan.From, to)
+	return plan, err
+}
+
+// planSteps chains the migrations leading from one schema version to another
+func planSteps(all []Migration, from, to int) ([]Migration, error) {
+	if to < from {
+		return nil, fmt.Errorf("schema version %d is older than the project's version %d; downgrades are not supported", to, from)
+	}
+	var steps []Migration
+	for version := from; version < to; {
+		next := -1
+		for i, m := range all {
+			if m.From == version && m.To <= to {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, fmt.Errorf("no migration from schema version %d to %d", version, to)
+		}
+		steps = append(steps, all[next])
+		version = all[next].To
+	}
+	return steps, nil
+}
+
+// Run applies the plan: the project is backed up first, then the steps run
+// in order. It stops at the first failing step, leaving the project at the
+// version reached; the backup keeps the files as they were.
+func (p MigrationPlan) Run() error {
+	if len(p.Steps) == 0 {
+		return nil
+	}
+	if err := SnapshotProject(p.Project); err != nil {
+		return fmt.Errorf("backup: %w", err)
+	}
+	for _, step := range p.Steps {
+		if err := step.Apply(p.Project); err != nil {
+			return fmt.Errorf("schema %d -> %d: %w", step.From, step.To, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlanSteps(t *testing.T) {
+	all := []Migration{
+		{From: 1, To: 2, Description: "a"},
+		{From: 2, To: 3, Description: "b"},
+		{From: 3, To: 4, Description: "c"},
+	}
+
+	steps, err := planSteps(all, 1, 3)
+	if err != nil || len(steps) != 2 || steps[0].Description != "a" || steps[1].Description != "b" {
+		t.Errorf("planSteps(1, 3) = %+v, %v; want a then b", steps, err)
+	}
+	if steps, err := planSteps(all, 4, 4); err != nil || len(steps) != 0 {
+		t.Errorf("planSteps(4, 4) = %+v, %v; want no steps", steps, err)
+	}
+	if _, err := planSteps(all, 3, 2); err == nil {
+		t.Error("planSteps should refuse downgrades")
+	}
+	if _, err := planSteps(all, 1, 5); err == nil {
+		t.Error("planSteps should fail without a migration to the target version")
+	}
+}
+
+func TestMigrateSingleFileProject(t *testing.T) {
+	projectDir := setupLegacyProject(t, `[{"id":"1","subject":"Ship","status":"pending"}]`)
+
+	plan, err := PlanMigration("proj", CurrentSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.From != SchemaSingleFile || len(plan.Steps) != 1 {
+		t.Fatalf("plan = %+v, want one step from the single-file schema", plan)
+	}
+	if err := plan.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, "1.json")); err != nil {
+		t.Errorf("Expected 1.json after migrating: %v", err)
+	}
+	if DetectSchema(projectDir) != CurrentSchema {
+		t.Errorf("schema = %d, want %d", DetectSchema(projectDir), CurrentSchema)
+	}
+	if snapshots, _ := ListSnapshots("proj"); len(snapshots) == 0 {
+		t.Error("Migrating should back the project up first")
+	}
+
+	if plan, err := PlanMigration("proj", CurrentSchema); err != nil || len(plan.Steps) != 0 {
+		t.Errorf("PlanMigration after migrating = %+v, %v; want no steps", plan, err)
+	}
+}
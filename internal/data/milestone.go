@@ -0,0 +1,269 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// Milestone is a named sprint or release with an optional date range
+type Milestone struct {
+	Name  string `json:"name"`
+	Start string `json:"start,omitempty"` // YYYY-MM-DD
+	End   string `json:"end,omitempty"`   // YYYY-MM-DD
+}
+
+// MilestoneStore handles milestone persistence
+type MilestoneStore struct {
+	ProjectName string
+	Milestones  []Milestone
+	filePath    string    // cached file path
+	lastModTime time.Time // last modification time
+}
+
+// milestonesFile represents the JSON structure of _milestones.json
+type milestonesFile struct {
+	Milestones []Milestone `json:"milestones"`
+}
+
+// NewMilestoneStoreForTest creates a MilestoneStore for testing with a custom directory
+func NewMilestoneStoreForTest(dir string, milestones []Milestone) (*MilestoneStore, error) {
+	store := &MilestoneStore{
+		Milestones: milestones,
+		filePath:   filepath.Join(dir, "_milestones.json"),
+	}
+	if err := store.Save(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// LoadMilestones loads milestones from a project's _milestones.json
+func LoadMilestones(projectName string) (*MilestoneStore, error) {
+	filePath, err := config.GetMilestonesFilePath(projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &MilestoneStore{
+				ProjectName: projectName,
+				Milestones:  []Milestone{},
+				filePath:    filePath,
+			}, nil
+		}
+		return nil, err
+	}
+
+	var modTime time.Time
+	if fileInfo, err := os.Stat(filePath); err == nil {
+		modTime = fileInfo.ModTime()
+	}
+
+	var mf milestonesFile
+	if err := json.Unmarshal(data, &mf); err != nil {
+		return nil, err
+	}
+	sortMilestones(mf.Milestones)
+
+	return &MilestoneStore{
+		ProjectName: projectName,
+		Milestones:  mf.Milestones,
+		filePath:    filePath,
+		lastModTime: modTime,
+	}, nil
+}
+
+// Save saves milestones to the project's _milestones.json
+func (s *MilestoneStore) Save() error {
+	if err := checkProjectWritable(s.ProjectName); err != nil {
+		return err
+	}
+	filePath := s.filePath
+	if filePath == "" {
+		var err error
+		filePath, err = config.GetMilestonesFilePath(s.ProjectName)
+		if err != nil {
+			return err
+		}
+	}
+
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(milestonesFile{Milestones: s.Milestones}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return err
+	}
+
+	// Backup: snapshot only if content differs from the latest snapshot
+	snapshotStore(s.ProjectName, dir)
+	if config.Current().Git.Enabled && s.ProjectName != "" {
+		commitProject(dir, "update milestones") // best-effort like backups
+	}
+	return nil
+}
+
+// NeedsReload checks if the milestones file has been modified since last load
+func (s *MilestoneStore) NeedsReload() bool {
+	if s.filePath == "" {
+		return false
+	}
+	fileInfo, err := os.Stat(s.filePath)
+	if err != nil {
+		return false
+	}
+	return fileInfo.ModTime().After(s.lastModTime)
+}
+
+// Validate checks the milestone's name and dates
+func (m Milestone) Validate() error {
+	if m.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if err := ValidateDate(m.Start); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+	if err := ValidateDate(m.End); err != nil {
+		return fmt.Errorf("end: %w", err)
+	}
+	if m.Start != "" && m.End != "" && m.End < m.Start {
+		return fmt.Errorf("end is before start")
+	}
+	return nil
+}
+
+// DaysRemaining returns the days left until the milestone's end date
+// (negative once it has passed); ok is false if no end date is set
+func (m Milestone) DaysRemaining(now time.Time) (days int, ok bool) {
+	if m.End == "" {
+		return 0, false
+	}
+	end, err := time.ParseInLocation(DateFormat, m.End, now.Location())
+	if err != nil {
+		return 0, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return int(math.Round(end.Sub(today).Hours() / 24)), true
+}
+
+// GetMilestone returns a milestone by name
+func (s *MilestoneStore) GetMilestone(name string) *Milestone {
+	for i := range s.Milestones {
+		if s.Milestones[i].Name == name {
+			return &s.Milestones[i]
+		}
+	}
+	return nil
+}
+
+// AddMilestone adds a new milestone, keeping milestones ordered by date
+func (s *MilestoneStore) AddMilestone(milestone Milestone) error {
+	if s.GetMilestone(milestone.Name) != nil {
+		return fmt.Errorf("milestone already exists: %s", milestone.Name)
+	}
+	s.Milestones = append(s.Milestones, milestone)
+	sortMilestones(s.Milestones)
+	return nil
+}
+
+// UpdateMilestone replaces the milestone named name
+func (s *MilestoneStore) UpdateMilestone(name string, updated Milestone) error {
+	if updated.Name != name && s.GetMilestone(updated.Name) != nil {
+		return fmt.Errorf("milestone already exists: %s", updated.Name)
+	}
+	existing := s.GetMilestone(name)
+	if existing == nil {
+		return fmt.Errorf("milestone not found: %s", name)
+	}
+	*existing = updated
+	sortMilestones(s.Milestones)
+	return nil
+}
+
+// DeleteMilestone removes a milestone by name
+func (s *MilestoneStore) DeleteMilestone(name string) bool {
+	for i := range s.Milestones {
+		if s.Milestones[i].Name == name {
+			s.Milestones = append(s.Milestones[:i], s.Milestones[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetMilestoneNames returns the milestone names in order
+func (s *MilestoneStore) GetMilestoneNames() []string {
+	names := make([]string, len(s.Milestones))
+	for i, m := range s.Milestones {
+		names[i] = m.Name
+	}
+	return names
+}
+
+// sortMilestones orders milestones by end date (undated last), then start date, then name
+func sortMilestones(milestones []Milestone) {
+	sort.SliceStable(milestones, func(i, j int) bool {
+		a, b := milestones[i], milestones[j]
+		if (a.End == "") != (b.End == "") {
+			return a.End != ""
+		}
+		if a.End != b.End {
+			return a.End < b.End
+		}
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return a.Name < b.Name
+	})
+}
+
+// GetTaskMilestone returns the task's milestone name from metadata
+func GetTaskMilestone(task Task) string {
+	return metadataString(task, "milestone")
+}
+
+// SetTaskMilestone sets the task's milestone in metadata (empty clears it)
+func SetTaskMilestone(task *Task, name string) {
+	setMetadataString(task, "milestone", name)
+}
+
+// MilestoneProgress counts the tasks assigned to a milestone and how many are completed
+func MilestoneProgress(tasks []Task, name string) (completed, total int) {
+	for _, task := range tasks {
+		if GetTaskMilestone(task) != name {
+			continue
+		}
+		total++
+		if task.Status == "completed" {
+			completed++
+		}
+	}
+	return completed, total
+}
+
+// RenameTaskMilestone moves tasks from one milestone name to another (empty clears it)
+// and returns the number of tasks changed
+func (s *TaskStore) RenameTaskMilestone(from, to string) int {
+	changed := 0
+	for i := range s.Tasks {
+		if GetTaskMilestone(s.Tasks[i]) == from {
+			SetTaskMilestone(&s.Tasks[i], to)
+			changed++
+		}
+	}
+	return changed
+}
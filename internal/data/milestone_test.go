@@ -0,0 +1,106 @@
+package data
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMilestoneStore(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewMilestoneStoreForTest(tmpDir, []Milestone{})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	for _, ms := range []Milestone{
+		{Name: "Backlog"},
+		{Name: "Sprint 2", Start: "2024-03-15", End: "2024-03-28"},
+		{Name: "Sprint 1", Start: "2024-03-01", End: "2024-03-14"},
+	} {
+		if err := store.AddMilestone(ms); err != nil {
+			t.Fatalf("AddMilestone(%s) failed: %v", ms.Name, err)
+		}
+	}
+	if err := store.AddMilestone(Milestone{Name: "Sprint 1"}); err == nil {
+		t.Error("Expected error for duplicate milestone")
+	}
+
+	// Ordered by end date, undated last
+	want := []string{"Sprint 1", "Sprint 2", "Backlog"}
+	if got := store.GetMilestoneNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMilestoneNames = %v, want %v", got, want)
+	}
+
+	if err := store.UpdateMilestone("Sprint 2", Milestone{Name: "Sprint 1"}); err == nil {
+		t.Error("Expected error when renaming onto an existing milestone")
+	}
+	if err := store.UpdateMilestone("Backlog", Milestone{Name: "Later"}); err != nil {
+		t.Errorf("UpdateMilestone failed: %v", err)
+	}
+	if !store.DeleteMilestone("Later") || store.GetMilestone("Later") != nil {
+		t.Error("Expected Later to be deleted")
+	}
+}
+
+func TestMilestoneValidate(t *testing.T) {
+	tests := []struct {
+		milestone Milestone
+		valid     bool
+	}{
+		{Milestone{Name: "Sprint 1"}, true},
+		{Milestone{Name: "Sprint 1", Start: "2024-03-01", End: "2024-03-14"}, true},
+		{Milestone{Name: ""}, false},
+		{Milestone{Name: "Sprint 1", End: "2024-13-01"}, false},
+		{Milestone{Name: "Sprint 1", Start: "2024-03-14", End: "2024-03-01"}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.milestone.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Validate(%+v) = %v, want valid=%v", tt.milestone, err, tt.valid)
+		}
+	}
+}
+
+func TestMilestoneDaysRemaining(t *testing.T) {
+	now := time.Date(2024, 3, 10, 18, 0, 0, 0, time.Local)
+	if days, ok := (Milestone{End: "2024-03-14"}).DaysRemaining(now); !ok || days != 4 {
+		t.Errorf("Expected 4 days remaining, got %d (ok=%v)", days, ok)
+	}
+	if days, ok := (Milestone{End: "2024-03-08"}).DaysRemaining(now); !ok || days != -2 {
+		t.Errorf("Expected -2 days remaining, got %d (ok=%v)", days, ok)
+	}
+	if _, ok := (Milestone{}).DaysRemaining(now); ok {
+		t.Error("Expected ok=false without an end date")
+	}
+}
+
+func TestMilestoneProgressAndRename(t *testing.T) {
+	store := &TaskStore{Tasks: []Task{
+		{ID: "1", Status: "completed"},
+		{ID: "2", Status: "pending"},
+		{ID: "3", Status: "completed"},
+	}}
+	SetTaskMilestone(&store.Tasks[0], "Sprint 1")
+	SetTaskMilestone(&store.Tasks[1], "Sprint 1")
+
+	if completed, total := MilestoneProgress(store.Tasks, "Sprint 1"); completed != 1 || total != 2 {
+		t.Errorf("MilestoneProgress = %d/%d, want 1/2", completed, total)
+	}
+
+	if n := store.RenameTaskMilestone("Sprint 1", "Sprint A"); n != 2 {
+		t.Errorf("Expected 2 tasks renamed, got %d", n)
+	}
+	if GetTaskMilestone(store.Tasks[1]) != "Sprint A" {
+		t.Errorf("Expected task 2 in Sprint A, got %q", GetTaskMilestone(store.Tasks[1]))
+	}
+	store.RenameTaskMilestone("Sprint A", "")
+	if _, ok := store.Tasks[0].Metadata["milestone"]; ok {
+		t.Error("Expected milestone metadata to be removed")
+	}
+}
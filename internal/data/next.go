@@ -0,0 +1,133 @@
+package data
+
+import "sort"
+
+// PriorityRank orders priorities for sorting: high < medium (or unset) < low
+func PriorityRank(priority string) int {
+	switch priority {
+	case "high":
+		return 0
+	case "low":
+		return 2
+	}
+	return 1
+}
+
+// openBlockers returns, for each non-completed task, the IDs of the
+// non-completed tasks blocking it. Edges are read from both Blocks and BlockedBy.
+func openBlockers(tasks []Task) map[string]map[string]bool {
+	open := make(map[string]bool)
+	for _, task := range tasks {
+		if task.Status != "completed" {
+			open[task.ID] = true
+		}
+	}
+
+	blockers := make(map[string]map[string]bool)
+	for id := range open {
+		blockers[id] = make(map[string]bool)
+	}
+	for _, task := range tasks {
+		if !open[task.ID] {
+			continue
+		}
+		for _, dep := range task.BlockedBy {
+			if open[dep] && dep != task.ID {
+				blockers[task.ID][dep] = true
+			}
+		}
+		for _, blocked := range task.Blocks {
+			if open[blocked] && blocked != task.ID {
+				blockers[blocked][task.ID] = true
+			}
+		}
+	}
+	return blockers
+}
+
+// TopoOrder returns the non-completed tasks in dependency order: a task comes
+// after everything blocking it. Among ready tasks, higher priority and then
+// lower ID come first. Tasks in a dependency cycle are appended at the end.
+func TopoOrder(tasks []Task) []Task {
+	blockers := openBlockers(tasks)
+	byID := make(map[string]Task)
+	for _, task := range tasks {
+		if _, ok := blockers[task.ID]; ok {
+			byID[task.ID] = task
+		}
+	}
+
+	remaining := make(map[string]int)
+	dependents := make(map[string][]string)
+	for id, deps := range blockers {
+		remaining[id] = len(deps)
+		for dep := range deps {
+			dependents[dep] = append(dependents[dep], id)
+		}
+	}
+
+	less := func(a, b Task) bool {
+		if ra, rb := PriorityRank(GetTaskPriority(a)), PriorityRank(GetTaskPriority(b)); ra != rb {
+			return ra < rb
+		}
+		return lessTaskNumber(a, b)
+	}
+
+	var ready []Task
+	for id, n := range remaining {
+		if n == 0 {
+			ready = append(ready, byID[id])
+		}
+	}
+
+	var order []Task
+	done := make(map[string]bool)
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return less(ready[i], ready[j]) })
+		task := ready[0]
+		ready = ready[1:]
+		order = append(order, task)
+		done[task.ID] = true
+		for _, id := range dependents[task.ID] {
+			remaining[id]--
+			if remaining[id] == 0 {
+				ready = append(ready, byID[id])
+			}
+		}
+	}
+
+	// Cycles: append leftovers in priority order
+	var cyclic []Task
+	for id := range byID {
+		if !done[id] {
+			cyclic = append(cyclic, byID[id])
+		}
+	}
+	sort.Slice(cyclic, func(i, j int) bool { return less(cyclic[i], cyclic[j]) })
+	return append(order, cyclic...)
+}
+
+// NextTask suggests the pending task to work on next: the first task in
+// topological order that has no open blockers and is not flagged blocked.
+// Returns nil if there is none.
+func NextTask(tasks []Task) *Task {
+	blocked := BlockedTasks(tasks)
+	for _, task := range TopoOrder(tasks) {
+		if task.Status == "pending" && !blocked[task.ID] {
+			t := task
+			return &t
+		}
+	}
+	return nil
+}
+
+// BlockedCount returns how many open tasks are blocked directly by id
+func BlockedCount(tasks []Task, id string) int {
+	count := 0
+	for _, deps := range openBlockers(tasks) {
+		if deps[id] {
+			count++
+		}
+	}
+	return count
+}
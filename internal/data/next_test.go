@@ -0,0 +1,61 @@
+package data
+
+import "testing"
+
+func TestTopoOrder(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Status: "pending", BlockedBy: []string{"2"}},
+		{ID: "2", Status: "pending"},
+		{ID: "3", Status: "pending", Metadata: map[string]interface{}{"priority": "high"}},
+		{ID: "4", Status: "completed"},
+		{ID: "5", Status: "pending", Blocks: []string{"2"}},
+	}
+
+	var ids []string
+	for _, task := range TopoOrder(tasks) {
+		ids = append(ids, task.ID)
+	}
+	expected := []string{"3", "5", "2", "1"}
+	if len(ids) != len(expected) {
+		t.Fatalf("Expected order %v, got %v", expected, ids)
+	}
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Fatalf("Expected order %v, got %v", expected, ids)
+		}
+	}
+}
+
+func TestTopoOrderCycle(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Status: "pending", BlockedBy: []string{"2"}},
+		{ID: "2", Status: "pending", BlockedBy: []string{"1"}},
+		{ID: "3", Status: "pending"},
+	}
+	order := TopoOrder(tasks)
+	if len(order) != 3 || order[0].ID != "3" {
+		t.Errorf("Expected all tasks with #3 first, got %v", order)
+	}
+}
+
+func TestNextTask(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Status: "in_progress"},
+		{ID: "2", Status: "pending", BlockedBy: []string{"1"}, Metadata: map[string]interface{}{"priority": "high"}},
+		{ID: "3", Status: "pending", Metadata: map[string]interface{}{"priority": "low"}},
+		{ID: "4", Status: "pending", BlockedBy: []string{"5"}},
+		{ID: "5", Status: "completed"},
+	}
+
+	next := NextTask(tasks)
+	if next == nil || next.ID != "4" {
+		t.Fatalf("Expected #4 (unblocked, medium priority), got %v", next)
+	}
+	if n := BlockedCount(tasks, "1"); n != 1 {
+		t.Errorf("Expected #1 to block 1 open task, got %d", n)
+	}
+
+	if next := NextTask([]Task{{ID: "1", Status: "completed"}}); next != nil {
+		t.Errorf("Expected no suggestion, got %v", next)
+	}
+}
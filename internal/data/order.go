@@ -0,0 +1,51 @@
+package data
+
+import "sort"
+
+// GetTaskOrder returns the manual position of a task in its group (metadata
+// "order"), or 0 when it has none
+func GetTaskOrder(task Task) float64 {
+	if task.Metadata == nil {
+		return 0
+	}
+	switch order := task.Metadata["order"].(type) {
+	case float64:
+		return order
+	case int:
+		return float64(order)
+	}
+	return 0
+}
+
+// SetTaskOrder sets the manual position in task metadata (0 removes it)
+func SetTaskOrder(task *Task, order int) {
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	if order == 0 {
+		delete(task.Metadata, "order")
+	} else {
+		task.Metadata["order"] = float64(order) // as decoded from JSON, so unchanged positions compare equal
+	}
+}
+
+// SortManual sorts tasks by their manual position; tasks without one follow
+// in their current order
+func SortManual(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := GetTaskOrder(tasks[i]), GetTaskOrder(tasks[j])
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+}
+
+// SetManualOrder numbers the tasks in ids 1, 2, ... in that order
+func (s *TaskStore) SetManualOrder(ids []string) {
+	for i, id := range ids {
+		if task := s.GetTask(id); task != nil {
+			SetTaskOrder(task, i+1)
+		}
+	}
+}
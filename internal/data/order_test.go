@@ -0,0 +1,33 @@
+package data
+
+import "testing"
+
+func TestSortManual(t *testing.T) {
+	tasks := []Task{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}}
+	SetTaskOrder(&tasks[2], 1)
+	SetTaskOrder(&tasks[0], 2)
+	tasks[3].Metadata = map[string]interface{}{"order": 1.5} // as decoded from JSON
+
+	SortManual(tasks)
+	var got string
+	for _, task := range tasks {
+		got += task.ID
+	}
+	if got != "3412" {
+		t.Errorf("manual order = %s, want 3412 (unordered #2 last)", got)
+	}
+}
+
+func TestSetManualOrder(t *testing.T) {
+	store, err := NewTaskStoreForTest(t.TempDir(), []Task{
+		{ID: "1", Subject: "A", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "2", Subject: "B", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SetManualOrder([]string{"2", "1"})
+	if GetTaskOrder(*store.GetTask("2")) != 1 || GetTaskOrder(*store.GetTask("1")) != 2 {
+		t.Errorf("orders = %v, %v", store.GetTask("1").Metadata, store.GetTask("2").Metadata)
+	}
+}
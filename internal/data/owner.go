@@ -0,0 +1,146 @@
+package data
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// OwnerBadge is the initials and color identifying a task owner in lists
+type OwnerBadge struct {
+	Initials string
+	Color    string
+}
+
+// GetOwnerBadge returns the badge of an owner: as configured in owners, or
+// initials from the name and a color picked by hashing it, so each owner
+// keeps the same color across projects and sessions
+func GetOwnerBadge(owner string) OwnerBadge {
+	badge := OwnerBadge{Initials: ownerInitials(owner), Color: ownerColor(owner)}
+	cfg, ok := config.Current().Owners[owner]
+	if !ok {
+		// Names differing only in case are the same person
+		for name, c := range config.Current().Owners {
+			if strings.EqualFold(name, owner) {
+				cfg, ok = c, true
+				break
+			}
+		}
+	}
+	if ok {
+		if initials := []rune(strings.TrimSpace(cfg.Initials)); len(initials) > 0 {
+			badge.Initials = strings.ToUpper(string(initials[:min(len(initials), 2)]))
+		}
+		if cfg.Color != "" {
+			badge.Color = cfg.Color
+		}
+	}
+	return badge
+}
+
+// ownerInitials returns the first letters of the first two words of a name
+// ("Jane Doe", "jane.doe" -> "JD"), or its first two letters ("alice" -> "AL")
+func ownerInitials(owner string) string {
+	words := strings.FieldsFunc(owner, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var initials []rune
+	switch {
+	case len(words) >= 2:
+		initials = []rune{[]rune(words[0])[0], []rune(words[1])[0]}
+	case len(words) == 1:
+		letters := []rune(words[0])
+		initials = letters[:min(len(letters), 2)]
+	default:
+		return "?"
+	}
+	return strings.ToUpper(string(initials))
+}
+
+// ownerColor picks a preset color by hashing an owner's name
+func ownerColor(owner string) string {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(owner)))
+	return DefaultColors[h.Sum32()%uint32(len(DefaultColors))]
+}
+
+// OwnerWorkload is the task counts of one owner
+type OwnerWorkload struct {
+	Owner      string // "" for unassigned tasks
+	Pending    int
+	InProgress int // in_progress and needs_review
+	Completed  int
+	Remaining  float64 // estimate of the open tasks
+}
+
+// Open returns the number of tasks the owner has yet to finish
+func (w OwnerWorkload) Open() int {
+	return w.Pending + w.InProgress
+}
+
+// OwnerWorkloads counts the tasks of each owner, busiest first (most open
+// tasks, then by name), with unassigned tasks last
+func OwnerWorkloads(tasks []Task) []OwnerWorkload {
+	byOwner := make(map[string]*OwnerWorkload)
+	for _, task := range tasks {
+		w, ok := byOwner[task.Owner]
+		if !ok {
+			w = &OwnerWorkload{Owner: task.Owner}
+			byOwner[task.Owner] = w
+		}
+		switch task.Status {
+		case "pending":
+			w.Pending++
+			w.Remaining += GetTaskEstimate(task)
+		case "in_progress", StatusNeedsReview:
+			w.InProgress++
+			w.Remaining += GetTaskEstimate(task)
+		case "completed":
+			w.Completed++
+		}
+	}
+
+	workloads := make([]OwnerWorkload, 0, len(byOwner))
+	for _, w := range byOwner {
+		workloads = append(workloads, *w)
+	}
+	sort.Slice(workloads, func(i, j int) bool {
+		a, b := workloads[i], workloads[j]
+		if (a.Owner == "") != (b.Owner == "") {
+			return b.Owner == ""
+		}
+		if a.Open() != b.Open() {
+			return a.Open() > b.Open()
+		}
+		return a.Owner < b.Owner
+	})
+	return workloads
+}
+
+// AssignableOwners returns the owners offered when assigning a task: the
+// configured roster in order, then the other owners of tasks by name (names
+// differing only in case are listed once)
+func AssignableOwners(tasks []Task) []string {
+	var owners []string
+	seen := make(map[string]bool)
+	add := func(owner string) {
+		owner = strings.TrimSpace(owner)
+		if owner == "" || seen[strings.ToLower(owner)] {
+			return
+		}
+		seen[strings.ToLower(owner)] = true
+		owners = append(owners, owner)
+	}
+	for _, owner := range config.Current().Roster {
+		add(owner)
+	}
+	rostered := len(owners)
+	for _, task := range tasks {
+		add(task.Owner)
+	}
+	sort.Strings(owners[rostered:])
+	return owners
+}
@@ -0,0 +1,65 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+func TestGetOwnerBadge(t *testing.T) {
+	cfg := config.Default()
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	for owner, want := range map[string]string{"Jane Doe": "JD", "jane.doe": "JD", "alice": "AL", "x": "X", "": "?"} {
+		if got := GetOwnerBadge(owner).Initials; got != want {
+			t.Errorf("initials of %q = %q, want %q", owner, got, want)
+		}
+	}
+	if GetOwnerBadge("alice").Color != GetOwnerBadge("Alice").Color {
+		t.Error("The color should not depend on case")
+	}
+
+	cfg.Owners = map[string]config.OwnerConfig{"Alice": {Initials: "a", Color: "#123456"}, "bob": {Color: "#654321"}}
+	if badge := GetOwnerBadge("alice"); badge.Initials != "A" || badge.Color != "#123456" {
+		t.Errorf("configured badge = %+v", badge)
+	}
+	if badge := GetOwnerBadge("bob"); badge.Initials != "BO" || badge.Color != "#654321" {
+		t.Errorf("badge with a configured color only = %+v", badge)
+	}
+}
+
+func TestOwnerWorkloads(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Owner: "bob", Status: "completed"},
+		{ID: "2", Owner: "alice", Status: "pending"},
+		{ID: "3", Owner: "alice", Status: "in_progress", Metadata: map[string]interface{}{"estimate": 2.0}},
+		{ID: "4", Status: "pending"},
+		{ID: "5", Owner: "bob", Status: "pending"},
+	}
+	got := OwnerWorkloads(tasks)
+	if len(got) != 3 || got[0].Owner != "alice" || got[1].Owner != "bob" || got[2].Owner != "" {
+		t.Fatalf("OwnerWorkloads order = %+v, want alice, bob, unassigned", got)
+	}
+	if got[0].Open() != 2 || got[0].InProgress != 1 || got[0].Remaining != 2 {
+		t.Errorf("alice = %+v", got[0])
+	}
+	if got[1].Open() != 1 || got[1].Completed != 1 {
+		t.Errorf("bob = %+v", got[1])
+	}
+}
+
+func TestAssignableOwners(t *testing.T) {
+	cfg := config.Default()
+	cfg.Roster = []string{"carol", "Alice", " "}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	tasks := []Task{{ID: "1", Owner: "dave"}, {ID: "2", Owner: "alice"}, {ID: "3"}, {ID: "4", Owner: "bob"}}
+	got := AssignableOwners(tasks)
+	want := []string{"carol", "Alice", "bob", "dave"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AssignableOwners = %v, want %v", got, want)
+	}
+}
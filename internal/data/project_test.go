@@ -0,0 +1,123 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestListProjectsMultipleRoots(t *testing.T) {
+	primary := testutil.IsolateHome(t) // keeps backups of LoadTasks out of the real home too
+	shared := t.TempDir()
+	writeTask := func(dir, project string) {
+		projectDir := filepath.Join(dir, project)
+		if err := os.MkdirAll(projectDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(`{"id":"1","subject":"Task","status":"pending"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeTask(primary, "beta")
+	writeTask(primary, "alpha")
+	writeTask(shared, "team-project")
+
+	cfg := config.Default()
+	cfg.Roots = []config.RootConfig{{Name: "team", Path: shared}}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	projects, err := ListProjects()
+	if err != nil {
+		t.Fatalf("ListProjects failed: %v", err)
+	}
+	var names []string
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	want := []string{"alpha", "beta", "team/team-project"}
+	if len(names) != len(want) {
+		t.Fatalf("ListProjects = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("ListProjects = %v, want %v", names, want)
+			break
+		}
+	}
+	if projects[2].Root != "team" {
+		t.Errorf("Expected root 'team', got %q", projects[2].Root)
+	}
+
+	// Qualified names resolve into their root
+	store, err := LoadTasks("team/team-project")
+	if err != nil {
+		t.Fatalf("LoadTasks failed: %v", err)
+	}
+	if len(store.Tasks) != 1 {
+		t.Errorf("Expected 1 task from the shared root, got %d", len(store.Tasks))
+	}
+	if _, err := config.GetProjectDir("unknown/project"); err == nil {
+		t.Error("Expected error for an unknown root")
+	}
+}
+
+func TestScanProjectCountsStatuses(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"1.json":         `{"id":"1","status":"pending"}`,
+		"2.json":         `{"id":"2","status":"pending"}`,
+		"3.json":         `{"id":"3","status":"in_progress"}`,
+		"4.json":         `{"id":"4","status":"completed"}`,
+		"5.json":         `not json`,
+		"_groups.json":   `{"groups":[]}`,
+		"notes.txt":      `ignored`,
+		"_history.jsonl": ``,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	project := scanProject(dir)
+	if project.TaskCount != 5 {
+		t.Errorf("TaskCount = %d, want 5", project.TaskCount)
+	}
+	if project.Pending != 2 || project.InProgress != 1 || project.Completed != 1 {
+		t.Errorf("counts = %d/%d/%d, want 2/1/1", project.Pending, project.InProgress, project.Completed)
+	}
+	if project.ModTime.IsZero() {
+		t.Error("Expected a modification time")
+	}
+}
+
+func TestLoadAllTasks(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+	for _, project := range []string{"alpha", "beta"} {
+		projectDir := filepath.Join(tasksDir, project)
+		if err := os.MkdirAll(projectDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range []string{"1", "2"} {
+			content := `{"id":"` + id + `","subject":"` + project + `","status":"pending"}`
+			if err := os.WriteFile(filepath.Join(projectDir, id+".json"), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	tasks, err := LoadAllTasks([]Project{{Name: "alpha"}, {Name: "beta"}, {Name: "missing/project"}})
+	if err == nil {
+		t.Error("Expected an error for the unknown root")
+	}
+	if len(tasks) != 4 {
+		t.Fatalf("Expected 4 tasks, got %d", len(tasks))
+	}
+	if tasks[0].Project != "alpha" || tasks[3].Project != "beta" || tasks[3].Task.ID != "2" {
+		t.Errorf("Unexpected order: %+v", tasks)
+	}
+}
@@ -0,0 +1,240 @@
+package data
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// RemoteTarget stores backup snapshots off the machine, each as
+// <project>/<snapshot name>/ holding the snapshot's files
+type RemoteTarget interface {
+	// Upload copies a local snapshot directory to the target
+	Upload(projectName string, snapshot Snapshot) error
+	// List returns the names of a project's snapshots on the target, in any order
+	List(projectName string) ([]string, error)
+	// Download copies a snapshot from the target into the directory dest
+	Download(projectName, name, dest string) error
+}
+
+// remoteClient sends the requests of the S3 and WebDAV targets
+var remoteClient = &http.Client{Timeout: 60 * time.Second}
+
+// pendingUploads tracks snapshot uploads still running
+var pendingUploads sync.WaitGroup
+
+// NewRemoteTarget returns the target of a remote backup configuration
+func NewRemoteTarget(cfg config.RemoteConfig) (RemoteTarget, error) {
+	switch cfg.Type {
+	case config.RemoteS3:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("remote %s: url is required", cfg.Label())
+		}
+		user, secret := cfg.Credentials()
+		region := cfg.Region
+		if region == "" {
+			region = "us-east-1"
+		}
+		return &s3Target{endpoint: strings.TrimRight(cfg.URL, "/"), prefix: strings.Trim(cfg.Prefix, "/"), region: region, accessKey: user, secretKey: secret}, nil
+	case config.RemoteWebDAV:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("remote %s: url is required", cfg.Label())
+		}
+		user, secret := cfg.Credentials()
+		return &webdavTarget{base: strings.TrimRight(cfg.URL, "/"), user: user, password: secret}, nil
+	case config.RemoteRsync:
+		if cfg.Target == "" {
+			return nil, fmt.Errorf("remote %s: target is required", cfg.Label())
+		}
+		return &rsyncTarget{dest: strings.TrimRight(cfg.Target, "/")}, nil
+	}
+	return nil, fmt.Errorf("remote %s: unknown type %q (expected %s, %s or %s)", cfg.Label(), cfg.Type, config.RemoteS3, config.RemoteWebDAV, config.RemoteRsync)
+}
+
+// uploadSnapshot uploads a new snapshot to the configured remotes in the
+// background (errors are only logged; like local backups, remote ones are
+// best-effort)
+func uploadSnapshot(projectName string, snapshot Snapshot) {
+	for _, remote := range config.Current().Backup.Remotes {
+		if !matchesFilter(remote.Projects, projectName) {
+			continue
+		}
+		target, err := NewRemoteTarget(remote)
+		if err != nil {
+			slog.Debug("remote backup skipped", "err", err)
+			continue
+		}
+		pendingUploads.Add(1)
+		go func(label string) {
+			defer pendingUploads.Done()
+			if err := target.Upload(projectName, snapshot); err != nil {
+				slog.Debug("remote backup failed", "remote", label, "project", projectName, "snapshot", snapshot.Name, "err", err)
+				return
+			}
+			slog.Debug("remote backup uploaded", "remote", label, "project", projectName, "snapshot", snapshot.Name)
+		}(remote.Label())
+	}
+}
+
+// WaitUploads waits up to timeout for snapshot uploads still running, so
+// the backup of the last save reaches the remotes when cctasks exits
+func WaitUploads(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		pendingUploads.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
+
+// PushLatestSnapshot uploads a project's newest local snapshot to a remote
+// and waits for it, returning the snapshot uploaded
+func PushLatestSnapshot(projectName string, remote config.RemoteConfig) (Snapshot, error) {
+	target, err := NewRemoteTarget(remote)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshots, err := ListSnapshots(projectName)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if len(snapshots) == 0 {
+		return Snapshot{}, fmt.Errorf("%s has no backup snapshots yet", projectName)
+	}
+	return snapshots[0], target.Upload(projectName, snapshots[0])
+}
+
+// ListRemoteSnapshots returns a project's snapshots on a remote, newest first
+func ListRemoteSnapshots(projectName string, remote config.RemoteConfig) ([]string, error) {
+	target, err := NewRemoteTarget(remote)
+	if err != nil {
+		return nil, err
+	}
+	names, err := target.List(projectName)
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []string
+	for _, name := range names {
+		if _, err := time.ParseInLocation(SnapshotTimeFormat, name, time.Local); err == nil {
+			snapshots = append(snapshots, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(snapshots))) // the names sort by time
+	return snapshots, nil
+}
+
+// RestoreFromRemote makes a project's files match one of its snapshots on a
+// remote (the newest when name is ""), and returns the snapshot restored.
+// The project is backed up first, so the restore can be undone from the
+// local snapshots; the downloaded snapshot is kept among them until pruned.
+func RestoreFromRemote(projectName string, remote config.RemoteConfig, name string) (string, error) {
+	if err := checkProjectWritable(projectName); err != nil {
+		return "", err
+	}
+	target, err := NewRemoteTarget(remote)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		names, err := ListRemoteSnapshots(projectName, remote)
+		if err != nil {
+			return "", err
+		}
+		if len(names) == 0 {
+			return "", fmt.Errorf("remote %s has no snapshots of %s", remote.Label(), projectName)
+		}
+		name = names[0]
+	}
+	if _, err := time.ParseInLocation(SnapshotTimeFormat, name, time.Local); err != nil {
+		return "", fmt.Errorf("invalid snapshot name %q", name)
+	}
+
+	projectDir, err := config.GetProjectDir(projectName)
+	if err != nil {
+		return "", err
+	}
+	backupDir, err := config.GetBackupProjectDir(projectName)
+	if err != nil {
+		return "", err
+	}
+	if err := SnapshotProject(projectName); err != nil {
+		return "", fmt.Errorf("backup: %w", err)
+	}
+
+	// Download next to the local snapshots, unless it is one of them
+	dest := filepath.Join(backupDir, name)
+	if _, err := os.Stat(dest); os.IsNotExist(err) {
+		tmp := dest + ".download"
+		os.RemoveAll(tmp)
+		if err := os.MkdirAll(tmp, 0755); err != nil {
+			return "", err
+		}
+		if err := target.Download(projectName, name, tmp); err != nil {
+			os.RemoveAll(tmp)
+			return "", err
+		}
+		if err := os.Rename(tmp, dest); err != nil {
+			return "", err
+		}
+	}
+	return name, restoreSnapshotDir(dest, projectDir)
+}
+
+// restoreSnapshotDir makes the top-level JSON files of projectDir match a
+// snapshot: files are copied back and files missing from it are removed
+func restoreSnapshotDir(snapshotPath, projectDir string) error {
+	files, _, err := readProjectFiles(snapshotPath)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("snapshot %s holds no project files", filepath.Base(snapshotPath))
+	}
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		return err
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(projectDir, name), content, 0644); err != nil {
+			return err
+		}
+	}
+	current, _, err := readProjectFiles(projectDir)
+	if err != nil {
+		return err
+	}
+	for name := range current {
+		if _, ok := files[name]; !ok {
+			if err := os.Remove(filepath.Join(projectDir, name)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// snapshotFiles returns the names of the files in a local snapshot directory
+func snapshotFiles(snapshot Snapshot) ([]string, error) {
+	entries, err := os.ReadDir(snapshot.Path)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
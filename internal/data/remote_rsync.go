@@ -0,0 +1,57 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// rsyncTarget stores snapshots with rsync, usually over ssh
+// (user@host:path); ssh keys and options come from the user's ssh config
+type rsyncTarget struct {
+	dest string
+}
+
+func (t *rsyncTarget) Upload(projectName string, snapshot Snapshot) error {
+	_, err := runRsync("-a", "--mkpath", snapshot.Path+"/", t.dest+"/"+projectName+"/"+snapshot.Name+"/")
+	return err
+}
+
+func (t *rsyncTarget) List(projectName string) ([]string, error) {
+	out, err := runRsync("--list-only", t.dest+"/"+projectName+"/")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(out, "\n") {
+		// drwxr-xr-x          4,096 2024/01/02 03:04:05 2024-01-02T03-04-05
+		fields := strings.Fields(line)
+		if len(fields) < 5 || !strings.HasPrefix(fields[0], "d") || fields[len(fields)-1] == "." {
+			continue
+		}
+		names = append(names, fields[len(fields)-1])
+	}
+	return names, nil
+}
+
+func (t *rsyncTarget) Download(projectName, name, dest string) error {
+	_, err := runRsync("-a", t.dest+"/"+projectName+"/"+name+"/", dest+"/")
+	return err
+}
+
+// runRsync runs rsync and returns its trimmed stdout, with stderr in the error
+func runRsync(args ...string) (string, error) {
+	cmd := exec.Command("rsync", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("rsync: %s", msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
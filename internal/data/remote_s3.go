@@ -0,0 +1,222 @@
+package data
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// s3Target stores snapshots in an S3-compatible bucket, addressed
+// path-style (endpoint is https://host/bucket) so that MinIO and other
+// self-hosted servers work without DNS setup
+type s3Target struct {
+	endpoint  string
+	prefix    string
+	region    string
+	accessKey string
+	secretKey string
+}
+
+// s3ListResult is the part of a ListObjectsV2 response used here
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// key returns the object key of a path below the prefix
+func (t *s3Target) key(parts ...string) string {
+	if t.prefix != "" {
+		parts = append([]string{t.prefix}, parts...)
+	}
+	return path.Join(parts...)
+}
+
+func (t *s3Target) Upload(projectName string, snapshot Snapshot) error {
+	names, err := snapshotFiles(snapshot)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(snapshot.Path, name))
+		if err != nil {
+			return err
+		}
+		if _, err := t.do(http.MethodPut, t.key(projectName, snapshot.Name, name), nil, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *s3Target) List(projectName string) ([]string, error) {
+	prefixes, _, err := t.list(t.key(projectName) + "/")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, p := range prefixes {
+		names = append(names, path.Base(strings.TrimSuffix(p, "/")))
+	}
+	return names, nil
+}
+
+func (t *s3Target) Download(projectName, name, dest string) error {
+	_, keys, err := t.list(t.key(projectName, name) + "/")
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("snapshot %s not found", name)
+	}
+	for _, key := range keys {
+		content, err := t.do(http.MethodGet, key, nil, nil)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dest, path.Base(key)), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// list returns the sub-prefixes and object keys directly below prefix
+func (t *s3Target) list(prefix string) (prefixes, keys []string, err error) {
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}, "delimiter": {"/"}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		body, err := t.do(http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		var result s3ListResult
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, nil, fmt.Errorf("s3 list: %w", err)
+		}
+		for _, p := range result.CommonPrefixes {
+			prefixes = append(prefixes, p.Prefix)
+		}
+		for _, c := range result.Contents {
+			keys = append(keys, c.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return prefixes, keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// do sends a signed request for an object key ("" for the bucket) and
+// returns the response body
+func (t *s3Target) do(method, key string, query url.Values, body []byte) ([]byte, error) {
+	u, err := url.Parse(t.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		u.Path = strings.TrimRight(u.Path, "/") + "/" + key
+	}
+	u.RawQuery = canonicalQuery(query)
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if t.accessKey != "" {
+		signS3Request(req, body, t.accessKey, t.secretKey, t.region, time.Now().UTC())
+	}
+	resp, err := remoteClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("s3 %s %s: %s", method, u.Path, resp.Status)
+	}
+	return content, nil
+}
+
+// signS3Request adds AWS Signature Version 4 headers to an S3 request
+func signS3Request(req *http.Request, body []byte, accessKey, secretKey, region string, now time.Time) {
+	const service = "s3"
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := contentHash(body)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	// Sign the host and every x-amz-* and range header
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "range" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	var names []string
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := day + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + contentHash([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes a query string the way SigV4 expects: sorted by
+// name, with spaces as %20 rather than +
+func canonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
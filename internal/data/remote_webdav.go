@@ -0,0 +1,163 @@
+package data
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// webdavTarget stores snapshots as collections on a WebDAV server
+type webdavTarget struct {
+	base     string
+	user     string
+	password string
+}
+
+// webdavMultistatus is the part of a PROPFIND response used here
+type webdavMultistatus struct {
+	Responses []struct {
+		Href       string    `xml:"href"`
+		Collection *struct{} `xml:"propstat>prop>resourcetype>collection"`
+	} `xml:"response"`
+}
+
+func (t *webdavTarget) Upload(projectName string, snapshot Snapshot) error {
+	names, err := snapshotFiles(snapshot)
+	if err != nil {
+		return err
+	}
+	for _, dir := range []string{projectName, projectName + "/" + snapshot.Name} {
+		if err := t.mkcol(dir); err != nil {
+			return err
+		}
+	}
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(snapshot.Path, name))
+		if err != nil {
+			return err
+		}
+		if _, err := t.do(http.MethodPut, projectName+"/"+snapshot.Name+"/"+name, nil, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *webdavTarget) List(projectName string) ([]string, error) {
+	dirs, _, err := t.propfind(projectName + "/")
+	return dirs, err
+}
+
+func (t *webdavTarget) Download(projectName, name, dest string) error {
+	dir := projectName + "/" + name + "/"
+	_, files, err := t.propfind(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("snapshot %s not found", name)
+	}
+	for _, file := range files {
+		content, err := t.do(http.MethodGet, dir+file, nil, nil)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dest, file), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mkcol creates a collection, which may already exist
+func (t *webdavTarget) mkcol(dir string) error {
+	_, err := t.do("MKCOL", dir+"/", nil, nil)
+	if statusErr, ok := err.(*webdavStatusError); ok && statusErr.code == http.StatusMethodNotAllowed {
+		return nil // already exists
+	}
+	return err
+}
+
+// propfind returns the names of the collections and files in a collection
+func (t *webdavTarget) propfind(dir string) (dirs, files []string, err error) {
+	body, err := t.do("PROPFIND", dir, map[string]string{"Depth": "1", "Content-Type": "application/xml"},
+		[]byte(`<?xml version="1.0" encoding="utf-8"?><propfind xmlns="DAV:"><prop><resourcetype/></prop></propfind>`))
+	if err != nil {
+		if statusErr, ok := err.(*webdavStatusError); ok && statusErr.code == http.StatusNotFound {
+			return nil, nil, nil // nothing uploaded yet
+		}
+		return nil, nil, err
+	}
+	var result webdavMultistatus
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, nil, fmt.Errorf("webdav propfind: %w", err)
+	}
+	for _, r := range result.Responses {
+		href, err := url.PathUnescape(r.Href)
+		if err != nil {
+			href = r.Href
+		}
+		href = strings.TrimSuffix(href, "/")
+		if strings.HasSuffix(href, strings.TrimSuffix(dir, "/")) {
+			continue // the collection itself (hrefs may be paths or full URLs)
+		}
+		name := path.Base(href)
+		if r.Collection != nil {
+			dirs = append(dirs, name)
+		} else {
+			files = append(files, name)
+		}
+	}
+	return dirs, files, nil
+}
+
+// webdavStatusError is a WebDAV response with an unexpected status
+type webdavStatusError struct {
+	method string
+	path   string
+	code   int
+	status string
+}
+
+func (e *webdavStatusError) Error() string {
+	return fmt.Sprintf("webdav %s %s: %s", e.method, e.path, e.status)
+}
+
+// do sends a request for a path below the base URL and returns the response body
+func (t *webdavTarget) do(method, rel string, headers map[string]string, body []byte) ([]byte, error) {
+	u, err := url.Parse(t.base)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + "/" + rel
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	if t.user != "" || t.password != "" {
+		req.SetBasicAuth(t.user, t.password)
+	}
+	resp, err := remoteClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &webdavStatusError{method: method, path: u.Path, code: resp.StatusCode, status: resp.Status}
+	}
+	return content, nil
+}
@@ -0,0 +1,237 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// IDChange is a task ID rewritten by renumbering
+type IDChange struct {
+	From string
+	To   string
+}
+
+// RenumberPlan returns the ID changes that make task IDs sequential (1, 2, 3, ...)
+// while keeping their numeric order. Tasks that keep their ID are not included;
+// tasks with UUIDs always keep theirs, since UUIDs are meant to be stable.
+// The numeric aliases of UUID tasks are skipped, so no ID equals an alias.
+func RenumberPlan(tasks []Task) []IDChange {
+	var ids []string
+	aliases := make(map[string]bool)
+	for _, task := range tasks {
+		if !IsUUID(task.ID) {
+			ids = append(ids, task.ID)
+		} else if alias := GetTaskAlias(task); alias != "" {
+			aliases[alias] = true
+		}
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		idI, _ := strconv.Atoi(ids[i])
+		idJ, _ := strconv.Atoi(ids[j])
+		return idI < idJ
+	})
+
+	var plan []IDChange
+	n := 0
+	for _, id := range ids {
+		n++
+		for aliases[strconv.Itoa(n)] {
+			n++
+		}
+		newID := strconv.Itoa(n)
+		if id != newID {
+			plan = append(plan, IDChange{From: id, To: newID})
+		}
+	}
+	return plan
+}
+
+// Renumber applies a renumbering plan: task IDs and all Blocks/BlockedBy
+// references are rewritten, and task files are renamed accordingly. The
+// history and the dependencies of trashed tasks follow the new IDs.
+func (s *TaskStore) Renumber(plan []IDChange) error {
+	if len(plan) == 0 {
+		return nil
+	}
+	if err := checkProjectWritable(s.ProjectName); err != nil {
+		return err
+	}
+
+	mapping := make(map[string]string, len(plan))
+	for _, c := range plan {
+		mapping[c.From] = c.To
+	}
+	rename := func(ids []string) []string {
+		for i, id := range ids {
+			if newID, ok := mapping[id]; ok {
+				ids[i] = newID
+			}
+		}
+		return ids
+	}
+
+	for i := range s.Tasks {
+		task := &s.Tasks[i]
+		if newID, ok := mapping[task.ID]; ok {
+			task.ID = newID
+		}
+		task.Blocks = rename(task.Blocks)
+		task.BlockedBy = rename(task.BlockedBy)
+	}
+	sort.Slice(s.Tasks, func(i, j int) bool {
+		return lessTaskNumber(s.Tasks[i], s.Tasks[j])
+	})
+
+	projectDir, err := s.dir()
+	if err != nil {
+		return err
+	}
+
+	if s.layout == LayoutSingle {
+		if err := s.saveSingleFile(); err != nil {
+			return err
+		}
+		return s.finishRenumber(projectDir, mapping)
+	}
+
+	// Write all tasks first, then remove files of IDs that are no longer used
+	current := make(map[string]bool, len(s.Tasks))
+	for _, task := range s.Tasks {
+		if err := s.saveTask(task); err != nil {
+			return err
+		}
+		current[task.ID] = true
+	}
+	for _, c := range plan {
+		if current[c.From] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(projectDir, c.From+".json")); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return s.finishRenumber(projectDir, mapping)
+}
+
+// finishRenumber records the renumbered tasks as saved, renames them in the
+// history and the trash, then backs them up and commits them
+func (s *TaskStore) finishRenumber(projectDir string, mapping map[string]string) error {
+	s.saved = cloneTasks(s.Tasks)
+	if err := renumberHistory(projectDir, s.key, mapping); err != nil {
+		return fmt.Errorf("renumber history: %w", err)
+	}
+	if err := renumberTrash(projectDir, s.key, mapping); err != nil {
+		return fmt.Errorf("renumber trash: %w", err)
+	}
+	s.snapshot()
+	if config.Current().Git.Enabled && s.ProjectName != "" {
+		commitProject(projectDir, fmt.Sprintf("renumber %d tasks", len(mapping))) // best-effort like backups
+	}
+	return nil
+}
+
+// renumberHistory rewrites the task IDs of history entries. Other lines,
+// including ones that can't be read, are kept as they are.
+func renumberHistory(projectDir string, key []byte, mapping map[string]string) error {
+	path := filepath.Join(projectDir, HistoryFileName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	lines := bytes.Split(bytes.TrimSuffix(content, []byte("\n")), []byte("\n"))
+	changed := false
+	for i, line := range lines {
+		plain, err := openTaskData(key, line)
+		if err != nil {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(plain, &entry); err != nil {
+			continue
+		}
+		newID, ok := mapping[entry.TaskID]
+		if !ok {
+			continue
+		}
+		entry.TaskID = newID
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if lines[i], err = sealTaskData(key, line); err != nil {
+			return err
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return writeFileAtomic(path, append(bytes.Join(lines, []byte("\n")), '\n'), 0644)
+}
+
+// renumberTrash rewrites the dependencies of trashed tasks, so a restored
+// task links to the tasks it was linked to. Trashed tasks keep their own ID;
+// RestoreFromTrash gives them a new one when it is taken.
+func renumberTrash(projectDir string, key []byte, mapping map[string]string) error {
+	trashDir := filepath.Join(projectDir, TrashDirName)
+	entries, err := os.ReadDir(trashDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if _, ok := parseTrashFileName(entry.Name()); entry.IsDir() || !ok {
+			continue
+		}
+		path := filepath.Join(trashDir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		plain, err := openTaskData(key, content)
+		if err != nil {
+			continue
+		}
+		var task Task
+		if err := json.Unmarshal(plain, &task); err != nil {
+			continue
+		}
+		changed := false
+		for _, ids := range [][]string{task.Blocks, task.BlockedBy} {
+			for i, id := range ids {
+				if newID, ok := mapping[id]; ok {
+					ids[i] = newID
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			continue
+		}
+		if content, err = json.MarshalIndent(task, "", "  "); err != nil {
+			return err
+		}
+		if content, err = sealTaskData(key, content); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
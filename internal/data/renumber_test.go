@@ -0,0 +1,142 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenumberPlan(t *testing.T) {
+	tasks := []Task{{ID: "2"}, {ID: "10"}, {ID: "5"}}
+	plan := RenumberPlan(tasks)
+
+	expected := []IDChange{{From: "2", To: "1"}, {From: "5", To: "2"}, {From: "10", To: "3"}}
+	if len(plan) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(expected), len(plan), plan)
+	}
+	for i, c := range expected {
+		if plan[i] != c {
+			t.Errorf("Change %d: expected %+v, got %+v", i, c, plan[i])
+		}
+	}
+
+	if plan := RenumberPlan([]Task{{ID: "1"}, {ID: "2"}}); len(plan) != 0 {
+		t.Errorf("Expected no changes for sequential IDs, got %+v", plan)
+	}
+
+	// The alias of a UUID task counts as taken
+	uuidTask := Task{ID: "0f8fad5b-d9cb-469f-a165-70867728950e", Metadata: map[string]interface{}{"alias": "2"}}
+	plan = RenumberPlan([]Task{{ID: "1"}, {ID: "5"}, uuidTask})
+	if len(plan) != 1 || plan[0] != (IDChange{From: "5", To: "3"}) {
+		t.Errorf("Expected 5 to become 3 past the alias, got %+v", plan)
+	}
+}
+
+func TestRenumber(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewTaskStoreForTest(tmpDir, []Task{
+		{ID: "2", Subject: "A", Status: "pending", Blocks: []string{"7"}, BlockedBy: []string{}},
+		{ID: "3", Subject: "B", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "7", Subject: "C", Status: "pending", Blocks: []string{}, BlockedBy: []string{"2"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	if err := store.Renumber(RenumberPlan(store.Tasks)); err != nil {
+		t.Fatalf("Renumber failed: %v", err)
+	}
+
+	reloadedStore, err := loadTasksFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	reloaded := reloadedStore.Tasks
+	if len(reloaded) != 3 {
+		t.Fatalf("Expected 3 task files, got %d", len(reloaded))
+	}
+	for i, subject := range []string{"A", "B", "C"} {
+		if reloaded[i].Subject != subject {
+			t.Errorf("Task %d: expected subject %s, got %s", i+1, subject, reloaded[i].Subject)
+		}
+	}
+	if reloaded[0].Blocks[0] != "3" || reloaded[2].BlockedBy[0] != "1" {
+		t.Errorf("Expected references rewritten to 1 -> 3, got blocks=%v blockedBy=%v", reloaded[0].Blocks, reloaded[2].BlockedBy)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "7.json")); !os.IsNotExist(err) {
+		t.Error("Expected 7.json to be removed")
+	}
+}
+
+func TestRenumberHistoryAndTrash(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewTaskStoreForTest(tmpDir, []Task{
+		{ID: "2", Subject: "A", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "5", Subject: "B", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	store.ProjectName = ""
+	now := time.Now()
+	history := []HistoryEntry{
+		{Time: now, Kind: ChangeCreated, TaskID: "2", Subject: "A"},
+		{Time: now, Kind: ChangeCreated, TaskID: "5", Subject: "B"},
+		{Time: now, Kind: ChangeDeleted, TaskID: "1", Subject: "Gone"},
+	}
+	if err := appendHistory(tmpDir, nil, history); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := os.OpenFile(filepath.Join(tmpDir, HistoryFileName), os.O_APPEND|os.O_WRONLY, 0644)
+	f.WriteString("not json\n")
+	f.Close()
+	// Deleted #1 blocked #5, so restoring it should link to #5 under its new ID
+	gone := Task{ID: "1", Subject: "Gone", Status: "pending", Blocks: []string{"5"}, BlockedBy: []string{}}
+	if err := store.saveTask(gone); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.moveToTrash(gone, now); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Renumber(RenumberPlan(store.Tasks)); err != nil {
+		t.Fatalf("Renumber failed: %v", err)
+	}
+
+	entries, err := store.History()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.TaskID)
+	}
+	if strings.Join(ids, ",") != "1,2,1" {
+		t.Errorf("Expected history IDs 1,2,1 (the deleted #1 kept), got %v", ids)
+	}
+	if content, _ := os.ReadFile(filepath.Join(tmpDir, HistoryFileName)); !strings.HasSuffix(string(content), "not json\n") {
+		t.Error("Expected unreadable history lines kept")
+	}
+
+	items, err := store.ListTrash()
+	if err != nil || len(items) != 1 {
+		t.Fatalf("ListTrash = %v, %v", items, err)
+	}
+	if items[0].Task.ID != "1" || items[0].Task.Blocks[0] != "2" {
+		t.Errorf("Expected trashed #1 to block the renumbered #2, got %+v", items[0].Task)
+	}
+	restored, err := store.RestoreFromTrash(items[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task := store.GetTask("2"); !containsString(task.BlockedBy, restored) {
+		t.Errorf("Expected #2 blocked by the restored #%s, got %v", restored, task.BlockedBy)
+	}
+}
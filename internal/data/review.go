@@ -0,0 +1,98 @@
+package data
+
+import (
+	"os"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// StatusNeedsReview is the status of finished work awaiting review. It is
+// only valid when the review workflow is enabled (config review.enabled).
+const StatusNeedsReview = "needs_review"
+
+// Review results recorded in metadata "reviewResult"
+const (
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// ReviewEnabled reports whether the review workflow is on
+func ReviewEnabled() bool {
+	return config.Current().Review.Enabled
+}
+
+// Statuses returns the task statuses in workflow order: ValidStatuses, with
+// needs_review before completed when the review workflow is on
+func Statuses() []string {
+	if !ReviewEnabled() {
+		return ValidStatuses
+	}
+	return []string{"pending", "in_progress", StatusNeedsReview, "completed"}
+}
+
+// Reviewer returns the name recorded on reviews: the configured reviewer,
+// or the login name
+func Reviewer() string {
+	if name := config.Current().Review.Reviewer; name != "" {
+		return name
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
+
+// TaskReview is the last review of a task
+type TaskReview struct {
+	Result   string // ReviewApproved or ReviewRejected
+	Reviewer string
+	Time     time.Time
+	Note     string // why the work was rejected
+}
+
+// GetTaskReview returns the last review recorded on the task, if any
+func GetTaskReview(task Task) (TaskReview, bool) {
+	review := TaskReview{
+		Result:   metadataString(task, "reviewResult"),
+		Reviewer: metadataString(task, "reviewedBy"),
+		Note:     metadataString(task, "reviewNote"),
+	}
+	review.Time, _ = time.Parse(time.RFC3339, metadataString(task, "reviewedAt"))
+	return review, review.Result != ""
+}
+
+// ApproveTask completes a task under review and records the reviewer
+func ApproveTask(task *Task, reviewer string, now time.Time) {
+	task.Status = "completed"
+	setTaskReview(task, TaskReview{Result: ReviewApproved, Reviewer: reviewer, Time: now})
+}
+
+// RejectTask sends a task under review back to pending with the reason
+func RejectTask(task *Task, reviewer, note string, now time.Time) {
+	task.Status = "pending"
+	setTaskReview(task, TaskReview{Result: ReviewRejected, Reviewer: reviewer, Time: now, Note: note})
+}
+
+func setTaskReview(task *Task, review TaskReview) {
+	setMetadataString(task, "reviewResult", review.Result)
+	setMetadataString(task, "reviewedBy", review.Reviewer)
+	setMetadataString(task, "reviewedAt", review.Time.UTC().Format(time.RFC3339))
+	setMetadataString(task, "reviewNote", review.Note)
+}
+
+// routeToReview moves tasks that were completed outside cctasks to
+// needs_review and reports whether any changed
+func (s *TaskStore) routeToReview(changes []Change) bool {
+	routed := false
+	for _, c := range changes {
+		if c.Kind != ChangeStatus || c.To != "completed" {
+			continue
+		}
+		if task := s.GetTask(c.TaskID); task != nil && task.Status == "completed" {
+			task.Status = StatusNeedsReview
+			routed = true
+		}
+	}
+	return routed
+}
@@ -0,0 +1,84 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestReviewStatuses(t *testing.T) {
+	cfg := config.Default()
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	if IsValidStatus(StatusNeedsReview) || len(Statuses()) != 3 {
+		t.Error("needs_review should be invalid while the review workflow is off")
+	}
+	cfg.Review.Enabled = true
+	if !IsValidStatus(StatusNeedsReview) || Statuses()[2] != StatusNeedsReview {
+		t.Errorf("Statuses() = %v, want needs_review before completed", Statuses())
+	}
+}
+
+func TestExternalCompletionRoutedToReview(t *testing.T) {
+	testutil.IsolateHome(t)
+	cfg := config.Default()
+	cfg.Review.Enabled = true
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	dir := t.TempDir()
+	prev, err := NewTaskStoreForTest(dir, []Task{
+		{ID: "1", Subject: "Task 1", Status: "in_progress", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "2", Subject: "Task 2", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks := cloneTasks(prev.Tasks)
+	tasks[0].Status = "completed"
+	tasks[1].Status = "in_progress"
+	store := &TaskStore{ProjectName: "test", Tasks: tasks, saved: cloneTasks(tasks), projectDir: dir}
+
+	store.RecordExternalChanges(prev)
+	if got := store.GetTask("1").Status; got != StatusNeedsReview {
+		t.Errorf("externally completed task status = %q, want needs_review", got)
+	}
+	if got := store.GetTask("2").Status; got != "in_progress" {
+		t.Errorf("other changes should be kept, got %q", got)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved Task
+	if err := json.Unmarshal(raw, &saved); err != nil || saved.Status != StatusNeedsReview {
+		t.Errorf("routed status should be saved, got %q (%v)", saved.Status, err)
+	}
+}
+
+func TestApproveRejectTask(t *testing.T) {
+	now := time.Date(2026, 5, 1, 9, 30, 0, 0, time.UTC)
+	task := Task{ID: "1", Status: StatusNeedsReview}
+	if _, ok := GetTaskReview(task); ok {
+		t.Error("a new task has no review")
+	}
+
+	RejectTask(&task, "alice", "Tests are missing", now)
+	review, ok := GetTaskReview(task)
+	if task.Status != "pending" || !ok || review.Result != ReviewRejected || review.Note != "Tests are missing" {
+		t.Errorf("after reject: status %q, review %+v", task.Status, review)
+	}
+
+	ApproveTask(&task, "bob", now.Add(time.Hour))
+	review, _ = GetTaskReview(task)
+	if task.Status != "completed" || review.Result != ReviewApproved || review.Reviewer != "bob" ||
+		!review.Time.Equal(now.Add(time.Hour)) || review.Note != "" {
+		t.Errorf("after approve: status %q, review %+v", task.Status, review)
+	}
+}
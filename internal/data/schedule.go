@@ -0,0 +1,146 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// DateFormat is the format of start and due dates in task metadata
+const DateFormat = "2006-01-02"
+
+// GetTaskStart returns the start date (YYYY-MM-DD) from task metadata
+func GetTaskStart(task Task) string {
+	return metadataString(task, "startDate")
+}
+
+// SetTaskStart sets the start date in task metadata
+func SetTaskStart(task *Task, date string) {
+	setMetadataString(task, "startDate", date)
+}
+
+// GetTaskDue returns the due date (YYYY-MM-DD) from task metadata
+func GetTaskDue(task Task) string {
+	return metadataString(task, "dueDate")
+}
+
+// SetTaskDue sets the due date in task metadata
+func SetTaskDue(task *Task, date string) {
+	setMetadataString(task, "dueDate", date)
+}
+
+// ValidateDate checks that s is empty or a YYYY-MM-DD date
+func ValidateDate(s string) error {
+	if s == "" {
+		return nil
+	}
+	if _, err := time.ParseInLocation(DateFormat, s, time.Local); err != nil {
+		return fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", s)
+	}
+	return nil
+}
+
+// metadataString returns a string value from task metadata
+func metadataString(task Task, key string) string {
+	if task.Metadata == nil {
+		return ""
+	}
+	if value, ok := task.Metadata[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// setMetadataString sets a string value in task metadata, removing it when empty
+func setMetadataString(task *Task, key, value string) {
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	if value == "" {
+		delete(task.Metadata, key)
+	} else {
+		task.Metadata[key] = value
+	}
+}
+
+// TaskDurationDays returns the planned length of a task in days. Estimates in
+// hours are converted using the configured hours per day; otherwise one day.
+func TaskDurationDays(task Task) int {
+	cfg := config.Current().Estimates
+	estimate := GetTaskEstimate(task)
+	if cfg.Unit != "h" || cfg.HoursPerDay <= 0 || estimate <= 0 {
+		return 1
+	}
+	return int(math.Ceil(estimate / cfg.HoursPerDay))
+}
+
+// TaskSpan returns the scheduled days of a task (inclusive). A task with only
+// a start or only a due date spans its duration from that date.
+func TaskSpan(task Task) (start, end time.Time, ok bool) {
+	startDate, startErr := time.ParseInLocation(DateFormat, GetTaskStart(task), time.Local)
+	dueDate, dueErr := time.ParseInLocation(DateFormat, GetTaskDue(task), time.Local)
+	days := TaskDurationDays(task)
+
+	switch {
+	case startErr == nil && dueErr == nil:
+		if dueDate.Before(startDate) {
+			return dueDate, startDate, true
+		}
+		return startDate, dueDate, true
+	case startErr == nil:
+		return startDate, startDate.AddDate(0, 0, days-1), true
+	case dueErr == nil:
+		return dueDate.AddDate(0, 0, -(days - 1)), dueDate, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// ScheduleIssues finds scheduling problems among dated tasks.
+// overlaps holds tasks whose span overlaps another task with the same owner;
+// violations holds tasks scheduled to start before a blocker ends.
+func ScheduleIssues(tasks []Task) (overlaps, violations map[string]bool) {
+	overlaps = make(map[string]bool)
+	violations = make(map[string]bool)
+
+	type span struct {
+		task       Task
+		start, end time.Time
+	}
+	spans := make(map[string]span)
+	var dated []span
+	for _, task := range tasks {
+		if start, end, ok := TaskSpan(task); ok {
+			s := span{task, start, end}
+			spans[task.ID] = s
+			dated = append(dated, s)
+		}
+	}
+
+	for i, a := range dated {
+		if a.task.Owner == "" {
+			continue
+		}
+		for _, b := range dated[i+1:] {
+			if a.task.Owner == b.task.Owner && !a.start.After(b.end) && !b.start.After(a.end) {
+				overlaps[a.task.ID] = true
+				overlaps[b.task.ID] = true
+			}
+		}
+	}
+
+	for _, s := range dated {
+		for _, dep := range s.task.BlockedBy {
+			if blocker, ok := spans[dep]; ok && !s.start.After(blocker.end) {
+				violations[s.task.ID] = true
+			}
+		}
+		for _, blocked := range s.task.Blocks {
+			if b, ok := spans[blocked]; ok && !b.start.After(s.end) {
+				violations[blocked] = true
+			}
+		}
+	}
+	return overlaps, violations
+}
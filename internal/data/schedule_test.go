@@ -0,0 +1,57 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskSpan(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.ParseInLocation(DateFormat, s, time.Local)
+		return d
+	}
+
+	task := Task{ID: "1"}
+	if _, _, ok := TaskSpan(task); ok {
+		t.Error("Expected no span without dates")
+	}
+
+	SetTaskStart(&task, "2025-03-01")
+	SetTaskDue(&task, "2025-03-05")
+	start, end, ok := TaskSpan(task)
+	if !ok || !start.Equal(day("2025-03-01")) || !end.Equal(day("2025-03-05")) {
+		t.Errorf("Unexpected span %v - %v", start, end)
+	}
+
+	SetTaskStart(&task, "")
+	start, end, _ = TaskSpan(task)
+	if !start.Equal(day("2025-03-05")) || !end.Equal(day("2025-03-05")) {
+		t.Errorf("Expected single-day span on due date, got %v - %v", start, end)
+	}
+}
+
+func TestScheduleIssues(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Owner: "alice", Blocks: []string{"2"}, Metadata: map[string]interface{}{"startDate": "2025-03-01", "dueDate": "2025-03-05"}},
+		{ID: "2", Owner: "bob", Metadata: map[string]interface{}{"startDate": "2025-03-04", "dueDate": "2025-03-06"}},
+		{ID: "3", Owner: "alice", Metadata: map[string]interface{}{"startDate": "2025-03-05", "dueDate": "2025-03-07"}},
+		{ID: "4", Owner: "bob", Metadata: map[string]interface{}{"startDate": "2025-03-10"}},
+	}
+
+	overlaps, violations := ScheduleIssues(tasks)
+	if !overlaps["1"] || !overlaps["3"] || overlaps["2"] || overlaps["4"] {
+		t.Errorf("Expected overlaps {1,3}, got %v", overlaps)
+	}
+	if !violations["2"] || len(violations) != 1 {
+		t.Errorf("Expected violation {2}, got %v", violations)
+	}
+}
+
+func TestValidateDate(t *testing.T) {
+	if err := ValidateDate("2025-02-30"); err == nil {
+		t.Error("Expected invalid date error")
+	}
+	if err := ValidateDate(""); err != nil {
+		t.Errorf("Expected empty date to be valid, got %v", err)
+	}
+}
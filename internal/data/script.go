@@ -0,0 +1,291 @@
+package data
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ScriptStep is one command of an automation script (cctasks script)
+type ScriptStep struct {
+	Line    int
+	Command string
+	Args    []string
+}
+
+// scriptCommands lists the commands a script may use
+var scriptCommands = map[string]bool{"project": true, "filter": true, "set": true, "list": true, "export": true}
+
+// scriptFilterKeys lists the keys accepted by the filter command
+var scriptFilterKeys = []string{"id", "status", "group", "owner", "priority", "tag", "milestone", "search"}
+
+// ParseScript reads an automation script: one command per line, arguments
+// separated by spaces (double quotes keep spaces in a value), and lines
+// starting with # ignored. Commands:
+//
+//	project <name>          open a project; changes to the previous one are saved
+//	filter [key=value ...]  select the project's tasks matching every key
+//	set <field>=<value>     change a field on the selected tasks (see BatchFields)
+//	list                    print the selected tasks
+//	export <file> [columns=a,b,...] [format=csv|tsv]
+func ParseScript(r io.Reader) ([]ScriptStep, error) {
+	var steps []ScriptStep
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields, err := splitScriptArgs(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		step := ScriptStep{Line: line, Command: fields[0], Args: fields[1:]}
+		if err := step.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		steps = append(steps, step)
+	}
+	return steps, scanner.Err()
+}
+
+// splitScriptArgs splits a line at spaces outside double quotes and removes the quotes
+func splitScriptArgs(text string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	inField, quoted := false, false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inField = true
+		case (r == ' ' || r == '\t') && !quoted:
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+// validate checks the command and its arguments before anything runs
+func (s ScriptStep) validate() error {
+	if !scriptCommands[s.Command] {
+		return fmt.Errorf("unknown command %q", s.Command)
+	}
+	switch s.Command {
+	case "project":
+		if len(s.Args) != 1 {
+			return fmt.Errorf("usage: project <name>")
+		}
+	case "filter":
+		for _, arg := range s.Args {
+			key, _, ok := strings.Cut(arg, "=")
+			if !ok || !containsString(scriptFilterKeys, key) {
+				return fmt.Errorf("invalid filter %q (keys: %s)", arg, strings.Join(scriptFilterKeys, ", "))
+			}
+		}
+	case "set":
+		if len(s.Args) != 1 {
+			return fmt.Errorf("usage: set <field>=<value>")
+		}
+		if _, err := parseScriptChange(s.Args[0]); err != nil {
+			return err
+		}
+	case "list":
+		if len(s.Args) != 0 {
+			return fmt.Errorf("usage: list")
+		}
+	case "export":
+		if len(s.Args) == 0 {
+			return fmt.Errorf("usage: export <file> [columns=a,b,...] [format=csv|tsv]")
+		}
+		if _, _, err := parseScriptExport(s.Args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseScriptChange parses the argument of set
+func parseScriptChange(arg string) (BatchChange, error) {
+	field, value, ok := strings.Cut(arg, "=")
+	change := BatchChange{Field: BatchField(field), Value: value}
+	if !ok {
+		return change, fmt.Errorf("usage: set <field>=<value>")
+	}
+	return change, change.Validate()
+}
+
+// parseScriptExport parses the options of export
+func parseScriptExport(args []string) (columns []string, format string, err error) {
+	format = FormatForPath(args[0])
+	columns = DefaultExportColumns
+	for _, arg := range args[1:] {
+		key, value, _ := strings.Cut(arg, "=")
+		switch key {
+		case "columns":
+			if columns, err = ParseExportColumns(value); err != nil {
+				return nil, "", err
+			}
+		case "format":
+			if value != FormatCSV && value != FormatTSV {
+				return nil, "", fmt.Errorf("unknown format %q (expected csv or tsv)", value)
+			}
+			format = value
+		default:
+			return nil, "", fmt.Errorf("invalid export option %q", arg)
+		}
+	}
+	return columns, format, nil
+}
+
+// ScriptRun executes script steps against the task stores
+type ScriptRun struct {
+	Out    io.Writer // progress and list output
+	DryRun bool      // report changes without saving or exporting
+
+	store    *TaskStore
+	selected []string // IDs of the selected tasks
+	changed  int      // tasks modified since the project was opened
+}
+
+// Run executes the steps in order. Changes to a project are saved when the
+// script opens another project or ends; an error stops the script without
+// saving the project it was changing.
+func (r *ScriptRun) Run(steps []ScriptStep) error {
+	for _, step := range steps {
+		if err := r.step(step); err != nil {
+			return fmt.Errorf("line %d: %s: %w", step.Line, step.Command, err)
+		}
+	}
+	return r.save()
+}
+
+func (r *ScriptRun) step(step ScriptStep) error {
+	if step.Command != "project" && r.store == nil {
+		return fmt.Errorf("no project selected (start the script with: project <name>)")
+	}
+	switch step.Command {
+	case "project":
+		if err := r.save(); err != nil {
+			return err
+		}
+		store, err := LoadTasks(step.Args[0])
+		if err != nil {
+			return err
+		}
+		r.store, r.changed = store, 0
+		r.selected = taskIDs(store.Tasks)
+		fmt.Fprintf(r.Out, "project %s: %d task(s)\n", store.ProjectName, len(store.Tasks))
+
+	case "filter":
+		r.selected = nil
+		for _, task := range r.store.Tasks {
+			if matchesScriptFilter(task, step.Args) {
+				r.selected = append(r.selected, task.ID)
+			}
+		}
+		fmt.Fprintf(r.Out, "filter %s: %d task(s)\n", strings.Join(step.Args, " "), len(r.selected))
+
+	case "set":
+		change, _ := parseScriptChange(step.Args[0])
+		count, err := r.store.ApplyBatch(r.selected, change)
+		if err != nil {
+			return err
+		}
+		r.changed += count
+		fmt.Fprintf(r.Out, "set %s: %d task(s) changed\n", step.Args[0], count)
+
+	case "list":
+		for _, id := range r.selected {
+			if task := r.store.GetTask(id); task != nil {
+				fmt.Fprintf(r.Out, "#%s\t%s\t%s\n", DisplayID(*task), task.Status, task.Subject)
+			}
+		}
+
+	case "export":
+		columns, format, _ := parseScriptExport(step.Args)
+		var tasks []Task
+		for _, id := range r.selected {
+			if task := r.store.GetTask(id); task != nil {
+				tasks = append(tasks, *task)
+			}
+		}
+		if !r.DryRun {
+			if err := r.store.WriteTableFile(step.Args[0], tasks, columns, format); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(r.Out, "export %s: %d task(s)\n", step.Args[0], len(tasks))
+	}
+	return nil
+}
+
+// save writes the open project's changes, unless this is a dry run
+func (r *ScriptRun) save() error {
+	if r.store == nil || r.changed == 0 {
+		return nil
+	}
+	if r.DryRun {
+		fmt.Fprintf(r.Out, "dry run: %d change(s) to %s not saved\n", r.changed, r.store.ProjectName)
+		return nil
+	}
+	if err := r.store.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(r.Out, "saved %s\n", r.store.ProjectName)
+	r.changed = 0
+	return nil
+}
+
+// matchesScriptFilter reports whether a task matches every key=value filter
+func matchesScriptFilter(task Task, filters []string) bool {
+	for _, filter := range filters {
+		key, value, _ := strings.Cut(filter, "=")
+		var ok bool
+		switch key {
+		case "id":
+			ok = containsString(strings.Split(value, ","), task.ID) || containsString(strings.Split(value, ","), DisplayID(task))
+		case "status":
+			ok = task.Status == value
+		case "group":
+			ok = GetTaskGroup(task) == value
+		case "owner":
+			ok = task.Owner == value
+		case "priority":
+			ok = GetTaskPriority(task) == value
+		case "tag":
+			ok = containsString(GetTaskTags(task), value)
+		case "milestone":
+			ok = GetTaskMilestone(task) == value
+		case "search":
+			ok = MatchesSearch(task, value)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// taskIDs returns the IDs of tasks in order
+func taskIDs(tasks []Task) []string {
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
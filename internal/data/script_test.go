@@ -0,0 +1,105 @@
+package data
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestParseScript(t *testing.T) {
+	steps, err := ParseScript(strings.NewReader(`# housekeeping
+project demo
+
+filter group=Docs search="getting started"
+set owner=alice
+export out.csv columns=id,subject
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 4 || steps[1].Line != 4 {
+		t.Fatalf("steps = %+v", steps)
+	}
+	if want := []string{"group=Docs", "search=getting started"}; !reflect.DeepEqual(steps[1].Args, want) {
+		t.Errorf("filter args = %q, want %q", steps[1].Args, want)
+	}
+
+	for script, want := range map[string]string{
+		"project a\nfrobnicate":    "line 2: unknown command",
+		"project a b":              "line 1: usage: project",
+		"filter colour=red":        "line 1: invalid filter",
+		"set status=finished":      "line 1: invalid status",
+		"set owner":                "line 1: usage: set",
+		"export out.csv format=md": "line 1: unknown format",
+		`filter search="open`:      "line 1: unterminated quote",
+	} {
+		if _, err := ParseScript(strings.NewReader(script)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseScript(%q) error = %v, want %q", script, err, want)
+		}
+	}
+}
+
+func TestScriptRun(t *testing.T) {
+	testutil.IsolateHome(t)
+
+	tasks := []Task{
+		{ID: "1", Subject: "Write docs", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "2", Subject: "Fix login", Status: "pending", Owner: "bob", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "3", Subject: "Old docs", Status: "completed", Blocks: []string{}, BlockedBy: []string{}},
+	}
+	if err := WriteProject("demo", tasks, nil); err != nil {
+		t.Fatal(err)
+	}
+	csvPath := filepath.Join(t.TempDir(), "out.csv")
+	script := "project demo\nfilter status=pending search=DOCS\nset owner=alice\nexport " + csvPath + " columns=id,owner\n"
+
+	run := func(dryRun bool) string {
+		steps, err := ParseScript(strings.NewReader(script))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if err := (&ScriptRun{Out: &out, DryRun: dryRun}).Run(steps); err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+
+	out := run(true)
+	if !strings.Contains(out, "filter status=pending search=DOCS: 1 task(s)") || !strings.Contains(out, "not saved") {
+		t.Errorf("dry run output:\n%s", out)
+	}
+	if store, _ := LoadTasks("demo"); store.GetTask("1").Owner != "" {
+		t.Error("dry run should not save")
+	}
+	if _, err := os.Stat(csvPath); !os.IsNotExist(err) {
+		t.Error("dry run should not export")
+	}
+
+	run(false)
+	store, err := LoadTasks("demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.GetTask("1").Owner != "alice" || store.GetTask("2").Owner != "bob" {
+		t.Errorf("owners = %q, %q", store.GetTask("1").Owner, store.GetTask("2").Owner)
+	}
+	content, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(content); !strings.Contains(got, "1,alice") || strings.Contains(got, "2,") {
+		t.Errorf("export = %q", got)
+	}
+
+	// Commands before a project, and errors, stop the script
+	steps, _ := ParseScript(strings.NewReader("filter status=pending"))
+	if err := (&ScriptRun{Out: &bytes.Buffer{}}).Run(steps); err == nil || !strings.Contains(err.Error(), "no project selected") {
+		t.Errorf("error = %v, want no project selected", err)
+	}
+}
@@ -0,0 +1,191 @@
+package data
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchMatch is a field of a task where a search query was found
+type SearchMatch struct {
+	Field string // subject, description, activeForm, owner, tags, a metadata key or another JSON field
+	Text  string // the field's text
+	Start int    // byte offsets of the match in Text
+	End   int
+}
+
+// searchField is a field's name and text as searched
+type searchField struct {
+	name string
+	text string
+}
+
+// searchFields returns the searchable text of a task, field by field:
+// the known fields first, then metadata and unknown JSON fields (such as
+// comments added by other tools) by key
+func searchFields(task Task) []searchField {
+	fields := []searchField{
+		{"subject", task.Subject},
+		{"description", task.Description},
+		{"activeForm", task.ActiveForm},
+		{"owner", task.Owner},
+		{"tags", strings.Join(GetTaskTags(task), ", ")},
+	}
+	keys := make([]string, 0, len(task.Metadata))
+	for key := range task.Metadata {
+		if key != "tags" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fields = append(fields, searchField{key, searchValueText(task.Metadata[key])})
+	}
+
+	keys = keys[:0]
+	for key := range task.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var value interface{}
+		if json.Unmarshal(task.Extra[key], &value) == nil {
+			fields = append(fields, searchField{key, searchValueText(value)})
+		}
+	}
+	return fields
+}
+
+// searchValueText flattens a JSON value to the text searched: strings as they
+// are, lists and objects as their strings joined by ", " (numbers such as
+// manual order positions are left out)
+func searchValueText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case []interface{}:
+		var parts []string
+		for _, item := range v {
+			if text := searchValueText(item); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var parts []string
+		for _, key := range keys {
+			if text := searchValueText(v[key]); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
+
+// SearchTask returns the fields of a task containing query, ignoring case,
+// in field order (subject and description first)
+func SearchTask(task Task, query string) []SearchMatch {
+	if query == "" {
+		return nil
+	}
+	var matches []SearchMatch
+	for _, field := range searchFields(task) {
+		if start, end := IndexFold(field.text, query); start >= 0 {
+			matches = append(matches, SearchMatch{Field: field.name, Text: field.text, Start: start, End: end})
+		}
+	}
+	return matches
+}
+
+// MatchesSearch reports whether any field of a task contains query, ignoring case
+func MatchesSearch(task Task, query string) bool {
+	if query == "" {
+		return true
+	}
+	for _, field := range searchFields(task) {
+		if start, _ := IndexFold(field.text, query); start >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchText returns the lower-cased text of every searchable field of a
+// task, one field per line, for callers that search the same tasks over and
+// over: a lower-cased query is contained in it if MatchesSearch would find it
+// (the line breaks keep a query from matching across fields)
+func SearchText(task Task) string {
+	var b strings.Builder
+	for _, field := range searchFields(task) {
+		b.WriteString(strings.ToLower(field.text))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// IndexFold returns the byte offsets of the first match of query in text,
+// ignoring case, or -1, -1
+func IndexFold(text, query string) (start, end int) {
+	if query == "" {
+		return 0, 0
+	}
+	runes := utf8.RuneCountInString(query)
+	for i := 0; i < len(text); {
+		// Compare as many runes of text as the query has
+		j, n := i, 0
+		for n < runes && j < len(text) {
+			_, size := utf8.DecodeRuneInString(text[j:])
+			j += size
+			n++
+		}
+		if strings.EqualFold(text[i:j], query) {
+			return i, j
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	return -1, -1
+}
+
+// FuzzyScore reports whether the runes of query appear in text in order,
+// ignoring case, and how well they match: runes right after the previous
+// match and at the start of a word ("my-app" has words "my" and "app") score
+// higher, and skipped runes between matches lower, so "ma" prefers "my-app"
+// to "format"
+func FuzzyScore(text, query string) (score int, ok bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return 0, true
+	}
+	matched := 0
+	prev := -1          // index of the previous matched rune
+	var last rune = ' ' // rune before the current one
+	for i, r := range []rune(strings.ToLower(text)) {
+		if matched < len(q) && r == q[matched] {
+			score++
+			switch {
+			case matched > 0 && i == prev+1:
+				score += 5
+			case matched > 0:
+				score -= i - prev - 1
+			}
+			if !unicode.IsLetter(last) && !unicode.IsDigit(last) {
+				score += 4
+			}
+			prev = i
+			matched++
+		}
+		last = r
+	}
+	return score, matched == len(q)
+}
@@ -0,0 +1,102 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSearchTask(t *testing.T) {
+	task := Task{
+		ID:          "1",
+		Subject:     "Fix login",
+		Description: "Session expires too early",
+		ActiveForm:  "Fixing login",
+		Owner:       "alice",
+		Metadata: map[string]interface{}{
+			"tags":     []interface{}{"auth", "urgent"},
+			"priority": "high",
+			"order":    float64(3),
+		},
+		Extra: map[string]json.RawMessage{
+			"comments": json.RawMessage(`[{"author":"bob","text":"Reproduced on Safari"}]`),
+		},
+	}
+
+	for _, tt := range []struct {
+		query, field, hit string
+	}{
+		{"LOGIN", "subject", "login"},
+		{"expires", "description", "expires"},
+		{"Alice", "owner", "alice"},
+		{"urg", "tags", "urg"},
+		{"high", "priority", "high"},
+		{"safari", "comments", "Safari"},
+	} {
+		matches := SearchTask(task, tt.query)
+		if len(matches) == 0 {
+			t.Errorf("%q: no match", tt.query)
+			continue
+		}
+		m := matches[0]
+		if m.Field != tt.field || m.Text[m.Start:m.End] != tt.hit {
+			t.Errorf("%q: got %s %q, want %s %q", tt.query, m.Field, m.Text[m.Start:m.End], tt.field, tt.hit)
+		}
+	}
+
+	// Subject first, then the other fields containing the query
+	if matches := SearchTask(task, "fix"); len(matches) != 2 || matches[1].Field != "activeForm" {
+		t.Errorf("expected subject and activeForm matches, got %+v", matches)
+	}
+	if MatchesSearch(task, "3") {
+		t.Error("numbers in metadata should not be searched")
+	}
+	if !MatchesSearch(task, "") || MatchesSearch(task, "logout") {
+		t.Error("unexpected MatchesSearch result")
+	}
+}
+
+func TestIndexFold(t *testing.T) {
+	for _, tt := range []struct {
+		text, query string
+		start, end  int
+	}{
+		{"Hello World", "world", 6, 11},
+		{"日本語のテスト", "テスト", 12, 21},
+		{"ÉCOLE", "école", 0, 6},
+		{"abc", "abcd", -1, -1},
+	} {
+		start, end := IndexFold(tt.text, tt.query)
+		if start != tt.start || end != tt.end {
+			t.Errorf("IndexFold(%q, %q) = %d, %d, want %d, %d", tt.text, tt.query, start, end, tt.start, tt.end)
+		}
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	for _, tt := range []struct {
+		text, query string
+		ok          bool
+	}{
+		{"my-app", "ma", true},
+		{"My-App", "mapp", true},
+		{"cctasks", "cts", true},
+		{"cctasks", "stc", false},
+		{"anything", "", true},
+	} {
+		if _, ok := FuzzyScore(tt.text, tt.query); ok != tt.ok {
+			t.Errorf("FuzzyScore(%q, %q) ok = %v, want %v", tt.text, tt.query, ok, tt.ok)
+		}
+	}
+
+	// Word starts and consecutive runes rank higher
+	app, _ := FuzzyScore("my-app", "ma")
+	format, _ := FuzzyScore("format", "ma")
+	if app <= format {
+		t.Errorf("Expected my-app (%d) to outrank format (%d) for \"ma\"", app, format)
+	}
+	prefix, _ := FuzzyScore("backend", "back")
+	scattered, _ := FuzzyScore("b-a-c-k", "back")
+	if prefix <= scattered {
+		t.Errorf("Expected backend (%d) to outrank b-a-c-k (%d) for \"back\"", prefix, scattered)
+	}
+}
@@ -0,0 +1,369 @@
+package data
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// ErrReadOnlyRoot is returned when changing a project on an ssh root
+var ErrReadOnlyRoot = errors.New("projects on an ssh root are read-only")
+
+// sshSyncInterval is how often the open project on an ssh root is refreshed,
+// and how old a full sync of a root may be before listing the projects
+// starts another
+const sshSyncInterval = 5 * time.Second
+
+// sshRoots tracks the ssh roots synced in the background and their last
+// sync error
+var sshRoots = struct {
+	sync.Mutex
+	watchers map[string]*sshWatcher
+	errs     map[string]error
+	done     chan struct{} // closed by StopSSHSync
+	synced   chan struct{} // signaled when a full sync changed a mirror or its error
+}{watchers: make(map[string]*sshWatcher), errs: make(map[string]error), done: make(chan struct{}), synced: make(chan struct{}, 1)}
+
+// sshWatcher syncs the mirror of one ssh root in the background: the whole
+// root when the projects are listed, and every sshSyncInterval the project
+// open on it, if any
+type sshWatcher struct {
+	root     config.Root
+	full     chan struct{} // requests a sync of the whole root
+	project  string        // name inside the root of the open project ("" when none is)
+	lastFull time.Time     // when the last full sync ended
+}
+
+// watchSSHRoots requests a background sync of each ssh root whose last full
+// sync is older than sshSyncInterval. It does not wait for the syncs; the
+// projects listed are those in the mirrors as they are now.
+func watchSSHRoots(roots []config.Root) {
+	for _, root := range roots {
+		if root.SSH == "" {
+			continue
+		}
+		sshRoots.Lock()
+		w := startSSHWatcher(root)
+		if time.Since(w.lastFull) >= sshSyncInterval {
+			w.requestFull()
+		}
+		sshRoots.Unlock()
+	}
+}
+
+// watchSSHProject makes the project the one kept up to date on its ssh root,
+// if it is on one; the other roots stop refreshing their open project
+func watchSSHProject(projectName string) {
+	root, ok := sshRootOf(projectName)
+	sshRoots.Lock()
+	defer sshRoots.Unlock()
+	_, name := config.SplitProjectName(projectName)
+	for rootName, w := range sshRoots.watchers {
+		if !ok || rootName != root.Name {
+			w.project = ""
+		}
+	}
+	if ok {
+		w := startSSHWatcher(root)
+		if w.project != name {
+			w.requestFull() // don't wait for the first refresh of a newly opened project
+		}
+		w.project = name
+	}
+}
+
+// startSSHWatcher returns the watcher of an ssh root, starting it on first
+// use. sshRoots must be locked.
+func startSSHWatcher(root config.Root) *sshWatcher {
+	if w, ok := sshRoots.watchers[root.Name]; ok {
+		return w
+	}
+	w := &sshWatcher{root: root, full: make(chan struct{}, 1)}
+	sshRoots.watchers[root.Name] = w
+	go w.run(sshRoots.done)
+	return w
+}
+
+// requestFull asks for a sync of the whole root, unless one is pending already
+func (w *sshWatcher) requestFull() {
+	select {
+	case w.full <- struct{}{}:
+	default:
+	}
+}
+
+// run syncs the root when asked to and its open project periodically, until
+// done is closed
+func (w *sshWatcher) run(done <-chan struct{}) {
+	for {
+		interval := sshSyncInterval
+		if config.Current().LowPower {
+			interval *= 2
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-done:
+			timer.Stop()
+			return
+		case <-w.full:
+			timer.Stop()
+			syncSSHRootLogged(w.root, "")
+			sshRoots.Lock()
+			w.lastFull = time.Now()
+			sshRoots.Unlock()
+		case <-timer.C:
+			sshRoots.Lock()
+			project := w.project
+			sshRoots.Unlock()
+			if project != "" {
+				syncSSHRootLogged(w.root, project)
+			}
+		}
+	}
+}
+
+// StopSSHSync stops syncing the ssh roots in the background
+func StopSSHSync() {
+	sshRoots.Lock()
+	defer sshRoots.Unlock()
+	select {
+	case <-sshRoots.done:
+	default:
+		close(sshRoots.done)
+	}
+}
+
+// SSHSynced returns a channel signaled when a background sync of a whole ssh
+// root changed its mirror or its error, so the project list can be refreshed
+func SSHSynced() <-chan struct{} {
+	return sshRoots.synced
+}
+
+// sshRootOf returns the ssh root a project is on
+func sshRootOf(projectName string) (config.Root, bool) {
+	rootName, _ := config.SplitProjectName(projectName)
+	if rootName == "" {
+		return config.Root{}, false
+	}
+	roots, err := config.GetRoots()
+	if err != nil {
+		return config.Root{}, false
+	}
+	for _, root := range roots {
+		if root.Name == rootName && root.SSH != "" {
+			return root, true
+		}
+	}
+	return config.Root{}, false
+}
+
+// IsReadOnly reports whether a project is on an ssh root, so it can be
+// browsed but not changed
+func IsReadOnly(projectName string) bool {
+	_, ok := sshRootOf(projectName)
+	return ok
+}
+
+// checkProjectWritable returns ErrReadOnlyRoot for a project on an ssh root, for
+// the functions writing to a project directory
+func checkProjectWritable(projectName string) error {
+	if IsReadOnly(projectName) {
+		return ErrReadOnlyRoot
+	}
+	return nil
+}
+
+// SSHRootError returns the error of the last sync of an ssh root, nil when
+// it succeeded or the root is not synced
+func SSHRootError(rootName string) error {
+	sshRoots.Lock()
+	defer sshRoots.Unlock()
+	return sshRoots.errs[rootName]
+}
+
+// syncSSHRootLogged syncs an ssh root, or only one of its projects, keeping
+// the error for SSHRootError
+func syncSSHRootLogged(root config.Root, project string) {
+	changed, err := syncSSHRoot(root, project)
+	if err != nil {
+		slog.Debug("ssh root sync failed", "root", root.Name, "host", root.SSH, "project", project, "err", err)
+	}
+	sshRoots.Lock()
+	prev := sshRoots.errs[root.Name]
+	sshRoots.errs[root.Name] = err
+	sshRoots.Unlock()
+	if project == "" && (changed || fmt.Sprint(prev) != fmt.Sprint(err)) {
+		select {
+		case sshRoots.synced <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// syncSSHRoot copies the remote tasks directory of an ssh root, or only one
+// project in it, into its mirror with tar over the system ssh, so keys,
+// agents and host aliases come from the user's ssh config. The mirror is
+// left alone when ssh fails. It reports whether the mirror changed.
+func syncSSHRoot(root config.Root, project string) (bool, error) {
+	dir := "."
+	if project != "" {
+		dir = shellQuote("./" + project)
+	}
+	script := "cd " + remoteDirArg(root.Remote) + " && tar cf - --exclude=" + TrashDirName + " --exclude=" + ConflictsDirName + " " + dir
+	cmd := exec.Command("ssh", "-o", "BatchMode=yes", "-o", "ConnectTimeout=10", root.SSH, script)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return false, fmt.Errorf("ssh: %s", msg)
+	}
+	return updateMirror(root.Path, project, &stdout)
+}
+
+// remoteDirArg quotes a remote directory for the remote shell, keeping a
+// leading "~/" expandable
+func remoteDirArg(dir string) string {
+	if dir == "~" {
+		return `"$HOME"`
+	}
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		return `"$HOME"/` + shellQuote(rest)
+	}
+	return shellQuote(dir)
+}
+
+// shellQuote quotes a string for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// updateMirror brings a mirror directory in line with a tar stream of the
+// remote tasks directory, or of one project in it. Only the task, group and
+// history files of projects are taken; files whose size and modification
+// time match are not rewritten, so unchanged projects don't look changed to
+// NeedsReload. It reports whether anything was written or removed.
+func updateMirror(dir, project string, r io.Reader) (bool, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return false, err
+	}
+	changed := false
+	keep := make(map[string]bool)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return changed, err
+		}
+		name, ok := mirroredName(hdr)
+		if !ok || (project != "" && strings.SplitN(name, "/", 2)[0] != project) {
+			continue
+		}
+		keep[name] = true
+		if hdr.Typeflag == tar.TypeDir {
+			if _, err := os.Stat(filepath.Join(dir, name)); os.IsNotExist(err) {
+				changed = true
+			}
+			if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+				return changed, err
+			}
+			continue
+		}
+		keep[path.Dir(name)] = true
+
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if info, err := os.Stat(target); err == nil && info.Size() == hdr.Size && info.ModTime().Equal(hdr.ModTime) {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return changed, err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return changed, err
+		}
+		if err := writeFileAtomic(target, content, 0644); err != nil {
+			return changed, err
+		}
+		changed = true
+		if err := os.Chtimes(target, hdr.ModTime, hdr.ModTime); err != nil {
+			return changed, err
+		}
+	}
+	pruned, err := pruneMirror(dir, project, keep)
+	return changed || pruned, err
+}
+
+// mirroredName returns the slash-separated path of a tar entry inside the
+// mirror: project directories and the .json and .jsonl files directly in
+// them. Hidden files, such as half-written temporary files, are skipped.
+func mirroredName(hdr *tar.Header) (string, bool) {
+	name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+	parts := strings.Split(name, "/")
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." || strings.HasPrefix(part, ".") {
+			return "", false
+		}
+	}
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return name, len(parts) == 1
+	case tar.TypeReg:
+		ext := path.Ext(name)
+		return name, len(parts) == 2 && (ext == ".json" || ext == ".jsonl")
+	}
+	return "", false
+}
+
+// pruneMirror removes what is no longer on the remote host from a mirror,
+// or from one project in it, and reports whether anything was removed
+func pruneMirror(dir, project string, keep map[string]bool) (bool, error) {
+	projects, err := os.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+	pruned := false
+	for _, entry := range projects {
+		if project != "" && entry.Name() != project {
+			continue
+		}
+		if !entry.IsDir() || !keep[entry.Name()] {
+			if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+				return pruned, err
+			}
+			pruned = true
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return pruned, err
+		}
+		for _, file := range files {
+			if !keep[entry.Name()+"/"+file.Name()] {
+				if err := os.RemoveAll(filepath.Join(dir, entry.Name(), file.Name())); err != nil {
+					return pruned, err
+				}
+				pruned = true
+			}
+		}
+	}
+	return pruned, nil
+}
@@ -0,0 +1,142 @@
+package data
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+// tarOf builds a tar stream like the one read from the remote host
+func tarOf(t *testing.T, modTime time.Time, entries map[string]string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range entries {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), ModTime: modTime, Typeflag: tar.TypeReg}
+		if content == "/" {
+			hdr = &tar.Header{Name: name, Mode: 0755, ModTime: modTime, Typeflag: tar.TypeDir}
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(content)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestUpdateMirror(t *testing.T) {
+	mirror := t.TempDir()
+	modTime := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	changed, err := updateMirror(mirror, "", tarOf(t, modTime, map[string]string{
+		"./":                     "/",
+		"./build/":               "/",
+		"./build/1.json":         `{"id":"1"}`,
+		"./build/_history.jsonl": "{}\n",
+		"./build/.2.json.tmp":    "partial",
+		"./build/notes.txt":      "not a task",
+		"./build/sub/3.json":     `{"id":"3"}`,
+		"./empty/":               "/",
+		"./stray.json":           "{}",
+		"./../escape/1.json":     "{}",
+		"./build/_trash/4.json":  "{}",
+		"./other/1.json":         `{"id":"1"}`,
+		"./other/_groups.json":   `{"groups":[]}`,
+	}))
+	if err != nil || !changed {
+		t.Fatalf("updateMirror = %v, %v; want a change", changed, err)
+	}
+
+	for _, name := range []string{"build/1.json", "build/_history.jsonl", "other/1.json", "other/_groups.json"} {
+		info, err := os.Stat(filepath.Join(mirror, name))
+		if err != nil {
+			t.Errorf("%s not mirrored: %v", name, err)
+			continue
+		}
+		if !info.ModTime().Equal(modTime) {
+			t.Errorf("%s mod time = %v, want the remote %v", name, info.ModTime(), modTime)
+		}
+	}
+	for _, name := range []string{"build/.2.json.tmp", "build/notes.txt", "build/sub", "stray.json", "build/_trash"} {
+		if _, err := os.Stat(filepath.Join(mirror, name)); err == nil {
+			t.Errorf("%s should not be mirrored", name)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(mirror, "empty")); err != nil || !info.IsDir() {
+		t.Errorf("empty project not mirrored: %v", err)
+	}
+
+	// Syncing one project leaves the others alone; unchanged files are no change
+	changed, err = updateMirror(mirror, "build", tarOf(t, modTime, map[string]string{
+		"./build/":               "/",
+		"./build/1.json":         `{"id":"1"}`,
+		"./build/_history.jsonl": "{}\n",
+		"./other/2.json":         `{"id":"2"}`,
+	}))
+	if err != nil || changed {
+		t.Fatalf("updateMirror of an unchanged project = %v, %v; want no change", changed, err)
+	}
+	for _, name := range []string{"other/1.json", "empty"} {
+		if _, err := os.Stat(filepath.Join(mirror, name)); err != nil {
+			t.Errorf("%s should be kept by a project sync: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(mirror, "other", "2.json")); err == nil {
+		t.Error("a project sync should only take files of that project")
+	}
+
+	// The next sync drops what is gone on the host
+	_, err = updateMirror(mirror, "", tarOf(t, modTime, map[string]string{
+		"./build/1.json": `{"id":"1","subject":"changed"}`,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(mirror, "build", "1.json")); string(content) != `{"id":"1","subject":"changed"}` {
+		t.Errorf("build/1.json = %q, want the changed task", content)
+	}
+	for _, name := range []string{"build/_history.jsonl", "other", "empty"} {
+		if _, err := os.Stat(filepath.Join(mirror, name)); err == nil {
+			t.Errorf("%s should be pruned", name)
+		}
+	}
+}
+
+func TestSSHRootReadOnly(t *testing.T) {
+	testutil.IsolateHome(t)
+	cfg := config.Default()
+	cfg.Roots = []config.RootConfig{{Name: "build", Path: "~/.claude/tasks", SSH: "ci@build"}}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	if !IsReadOnly("build/app") || IsReadOnly("app") {
+		t.Error("only projects on the ssh root should be read-only")
+	}
+	store := &TaskStore{ProjectName: "build/app", Tasks: []Task{{ID: "1", Subject: "Build"}}}
+	if err := store.Save(); !errors.Is(err, ErrReadOnlyRoot) {
+		t.Errorf("Save() = %v, want ErrReadOnlyRoot", err)
+	}
+	if err := store.PurgeFromTrash(TrashItem{File: "1.20250601T103000.json"}); !errors.Is(err, ErrReadOnlyRoot) {
+		t.Errorf("PurgeFromTrash() = %v, want ErrReadOnlyRoot", err)
+	}
+
+	if got := remoteDirArg("~/.claude/tasks"); got != `"$HOME"/'.claude/tasks'` {
+		t.Errorf("remoteDirArg = %s", got)
+	}
+	if got := remoteDirArg("/srv/it's"); got != `'/srv/it'\''s'` {
+		t.Errorf("remoteDirArg = %s", got)
+	}
+}
@@ -0,0 +1,22 @@
+package data
+
+import "time"
+
+// StaleTasks returns the in_progress tasks whose files have not changed for
+// longer than after, as of now: work that was started and then abandoned.
+// Nothing is stale when after is 0.
+func (s *TaskStore) StaleTasks(after time.Duration, now time.Time) map[string]bool {
+	stale := make(map[string]bool)
+	if after <= 0 {
+		return stale
+	}
+	for _, task := range s.Tasks {
+		if task.Status != "in_progress" {
+			continue
+		}
+		if modTime := s.TaskModTime(task.ID); !modTime.IsZero() && now.Sub(modTime) > after {
+			stale[task.ID] = true
+		}
+	}
+	return stale
+}
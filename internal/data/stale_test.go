@@ -0,0 +1,39 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStaleTasks(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewTaskStoreForTest(dir, []Task{
+		{ID: "1", Status: "in_progress"},
+		{ID: "2", Status: "in_progress"},
+		{ID: "3", Status: "pending"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Tasks 1 and 3 were last touched five days ago
+	old := time.Now().Add(-5 * 24 * time.Hour)
+	for _, id := range []string{"1", "3"} {
+		if err := os.Chtimes(filepath.Join(dir, id+".json"), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stale := store.StaleTasks(3*24*time.Hour, time.Now())
+	if len(stale) != 1 || !stale["1"] {
+		t.Errorf("Expected only in_progress task 1 stale, got %v", stale)
+	}
+	if stale := store.StaleTasks(7*24*time.Hour, time.Now()); len(stale) != 0 {
+		t.Errorf("Expected nothing stale after 7 days, got %v", stale)
+	}
+	if stale := store.StaleTasks(0, time.Now()); len(stale) != 0 {
+		t.Errorf("Expected no stale tasks when disabled, got %v", stale)
+	}
+}
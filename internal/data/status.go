@@ -0,0 +1,143 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// DefaultStatusFormat is the status line template used without --format
+const DefaultStatusFormat = "{in_progress}▶ {pending}○ {completed}✓{?subject} #{id} {subject:40}{/subject}"
+
+// StatusValues returns the values of status line placeholders: task counts
+// of the project and fields of the active (in_progress) task, "" without one
+func StatusValues(project string, tasks []Task, active *Task) map[string]string {
+	counts := make(map[string]int)
+	for _, task := range tasks {
+		counts[task.Status]++
+	}
+	blocked := 0
+	for _, deps := range openBlockers(tasks) {
+		if len(deps) > 0 {
+			blocked++
+		}
+	}
+	percent := 0
+	if len(tasks) > 0 {
+		percent = counts["completed"] * 100 / len(tasks)
+	}
+
+	values := map[string]string{
+		"project":     project,
+		"total":       strconv.Itoa(len(tasks)),
+		"pending":     strconv.Itoa(counts["pending"]),
+		"in_progress": strconv.Itoa(counts["in_progress"]),
+		"completed":   strconv.Itoa(counts["completed"]),
+		"open":        strconv.Itoa(len(tasks) - counts["completed"]),
+		"blocked":     strconv.Itoa(blocked),
+		"percent":     strconv.Itoa(percent),
+		"id":          "",
+		"subject":     "",
+		"active":      "",
+		"owner":       "",
+		"group":       "",
+	}
+	if active != nil {
+		values["id"] = DisplayID(*active)
+		values["subject"] = active.Subject
+		values["active"] = active.ActiveForm
+		if values["active"] == "" {
+			values["active"] = active.Subject
+		}
+		values["owner"] = active.Owner
+		values["group"] = GetTaskGroup(*active)
+	}
+	return values
+}
+
+// FormatStatusLine expands a status line template:
+//
+//	{name}            value of a placeholder (see StatusValues)
+//	{name:N}          value truncated to N columns
+//	{?name}...{/name} section shown only when the value is not empty or "0"
+//	{{ and }}         literal braces
+//
+// Everything else, such as tmux "#[fg=yellow]" styles, is copied as is.
+func FormatStatusLine(format string, values map[string]string) (string, error) {
+	var b strings.Builder
+	var sections []string // open {?name} sections
+	hidden := 0           // number of open sections whose value is empty
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if (c == '{' || c == '}') && i+1 < len(format) && format[i+1] == c {
+			if hidden == 0 {
+				b.WriteByte(c)
+			}
+			i++
+			continue
+		}
+		if c == '}' {
+			return "", fmt.Errorf("unmatched } at offset %d (use }} for a literal brace)", i)
+		}
+		if c != '{' {
+			if hidden == 0 {
+				b.WriteByte(c)
+			}
+			continue
+		}
+
+		end := strings.IndexByte(format[i:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unclosed { at offset %d (use {{ for a literal brace)", i)
+		}
+		tag := format[i+1 : i+end]
+		i += end
+
+		switch {
+		case strings.HasPrefix(tag, "?"):
+			name := tag[1:]
+			value, ok := values[name]
+			if !ok {
+				return "", fmt.Errorf("unknown placeholder %q", name)
+			}
+			sections = append(sections, name)
+			if hidden > 0 || value == "" || value == "0" {
+				hidden++
+			}
+		case strings.HasPrefix(tag, "/"):
+			name := tag[1:]
+			if len(sections) == 0 || sections[len(sections)-1] != name {
+				return "", fmt.Errorf("{/%s} does not close an open {?%s}", name, name)
+			}
+			sections = sections[:len(sections)-1]
+			if hidden > 0 {
+				hidden--
+			}
+		default:
+			name, width := tag, 0
+			if colon := strings.IndexByte(tag, ':'); colon >= 0 {
+				n, err := strconv.Atoi(tag[colon+1:])
+				if err != nil || n <= 0 {
+					return "", fmt.Errorf("invalid width in {%s}", tag)
+				}
+				name, width = tag[:colon], n
+			}
+			value, ok := values[name]
+			if !ok {
+				return "", fmt.Errorf("unknown placeholder %q", name)
+			}
+			if width > 0 {
+				value = runewidth.Truncate(value, width, "…")
+			}
+			if hidden == 0 {
+				b.WriteString(value)
+			}
+		}
+	}
+	if len(sections) > 0 {
+		return "", fmt.Errorf("{?%s} is not closed with {/%s}", sections[len(sections)-1], sections[len(sections)-1])
+	}
+	return b.String(), nil
+}
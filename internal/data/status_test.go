@@ -0,0 +1,44 @@
+package data
+
+import "testing"
+
+func TestFormatStatusLine(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Subject: "Design the schema", Status: "completed"},
+		{ID: "2", Subject: "Implement the parser", ActiveForm: "Implementing the parser", Status: "in_progress"},
+		{ID: "3", Subject: "Write tests", Status: "pending", BlockedBy: []string{"2"}},
+		{ID: "4", Subject: "Release", Status: "pending"},
+	}
+	values := StatusValues("web", tasks, &tasks[1])
+	idle := StatusValues("web", tasks[:1], nil)
+
+	tests := []struct {
+		format string
+		values map[string]string
+		want   string
+	}{
+		{"#[fg=yellow]{in_progress} {subject}", values, "#[fg=yellow]1 Implement the parser"},
+		{"{open}/{total} {percent}% blocked:{blocked}", values, "3/4 25% blocked:1"},
+		{"{active} #{id}", values, "Implementing the parser #2"},
+		{"{subject:10}", values, "Implement…"},
+		{"{project}{?subject}: {subject}{/subject}", idle, "web"},
+		{"{?in_progress}{?blocked}!{/blocked}{in_progress}{/in_progress}", values, "!1"},
+		{"{{literal}}", values, "{literal}"},
+	}
+	for _, tt := range tests {
+		got, err := FormatStatusLine(tt.format, tt.values)
+		if err != nil {
+			t.Errorf("FormatStatusLine(%q) error: %v", tt.format, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FormatStatusLine(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+
+	for _, format := range []string{"{nope}", "{?subject}x", "{/subject}", "{subject", "}", "{subject:x}"} {
+		if _, err := FormatStatusLine(format, values); err == nil {
+			t.Errorf("FormatStatusLine(%q) should fail", format)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// SyntheticOptions controls a generated project
+type SyntheticOptions struct {
+	Tasks  int
+	Groups int
+	Deps   float64 // share of tasks blocked by an earlier task
+	Seed   int64
+}
+
+var (
+	syntheticVerbs = []string{"Add", "Fix", "Refactor", "Test", "Document", "Remove", "Migrate", "Optimize"}
+	syntheticNouns = []string{"login flow", "search index", "API client", "config loader", "cache layer", "export", "settings page", "parser"}
+	syntheticAreas = []string{"Backend", "Frontend", "Infra", "Docs", "QA", "Design", "Mobile", "Data"}
+)
+
+// SyntheticProject generates fake tasks and groups, the same for the same
+// seed apart from due dates, which are relative to today. Tasks only depend
+// on earlier tasks, so there are no cycles.
+func SyntheticProject(opts SyntheticOptions) ([]Task, []TaskGroup) {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	groups := syntheticGroups(opts.Groups)
+
+	today := time.Now()
+	tasks := make([]Task, opts.Tasks)
+	for i := range tasks {
+		id := strconv.Itoa(i + 1)
+		task := Task{
+			ID:          id,
+			Subject:     fmt.Sprintf("%s %s #%s", syntheticVerbs[rng.Intn(len(syntheticVerbs))], syntheticNouns[rng.Intn(len(syntheticNouns))], id),
+			Description: "Generated task for performance testing.",
+			Status:      []string{"pending", "pending", "in_progress", "completed", "completed"}[rng.Intn(5)],
+			Blocks:      []string{},
+			BlockedBy:   []string{},
+		}
+		if len(groups) > 0 {
+			SetTaskGroup(&task, groups[rng.Intn(len(groups))].Name)
+		}
+		if rng.Intn(3) == 0 {
+			SetTaskPriority(&task, Priorities[rng.Intn(len(Priorities))])
+		}
+		if rng.Intn(4) == 0 {
+			SetTaskDue(&task, today.AddDate(0, 0, rng.Intn(60)-14).Format(DateFormat))
+		}
+		if i > 0 && rng.Float64() < opts.Deps {
+			blocker := rng.Intn(i)
+			task.BlockedBy = append(task.BlockedBy, tasks[blocker].ID)
+			tasks[blocker].Blocks = append(tasks[blocker].Blocks, id)
+		}
+		tasks[i] = task
+	}
+	return tasks, groups
+}
+
+// demoVerbs pairs each verb with its -ing form for activeForm
+var demoVerbs = [][2]string{
+	{"Add", "Adding"}, {"Fix", "Fixing"}, {"Refactor", "Refactoring"}, {"Write tests for", "Writing tests for"},
+	{"Document", "Documenting"}, {"Redesign", "Redesigning"}, {"Speed up", "Speeding up"},
+	{"Clean up", "Cleaning up"}, {"Review", "Reviewing"}, {"Set up", "Setting up"},
+}
+
+// demoNouns lists task objects per area, in the order of syntheticAreas
+var demoNouns = [][]string{
+	{"the user API", "auth middleware", "the rate limiter", "the job queue", "the billing service", "webhook delivery"},
+	{"the settings page", "the dashboard", "the login form", "dark mode", "the search bar", "the onboarding flow"},
+	{"the CI pipeline", "the staging cluster", "nightly backups", "TLS certificates", "log shipping", "autoscaling"},
+	{"the API reference", "the getting started guide", "the changelog", "the architecture overview", "the FAQ", "the migration guide"},
+	{"the regression suite", "load tests", "flaky end-to-end tests", "test fixtures", "smoke tests", "the coverage report"},
+	{"the icon set", "the color palette", "empty states", "mobile layouts", "design tokens", "error screens"},
+	{"push notifications", "offline mode", "the app store listing", "deep links", "crash reporting", "biometric login"},
+	{"event tracking", "the daily report", "the ETL job", "the metrics dashboard", "data retention", "the schema migration"},
+}
+
+var (
+	demoOwners = []string{"alice", "bob", "carol", "dave"}
+	demoTags   = []string{"bug", "ux", "perf", "security", "tech-debt"}
+	demoWhy    = []string{
+		"Users reported problems here in the last release.",
+		"Needed before the next milestone can ship.",
+		"Blocks follow-up work in other areas.",
+		"Small change, but easy to get wrong; add tests.",
+		"Agreed on in the weekly planning meeting.",
+	}
+)
+
+// DemoProject generates a realistic-looking project for screenshots and
+// demos: readable subjects, chains of dependent tasks within each group,
+// and statuses that respect dependencies (earlier tasks are more likely
+// done, and a task is only started once its blockers are completed).
+func DemoProject(opts SyntheticOptions) ([]Task, []TaskGroup) {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	groups := syntheticGroups(opts.Groups)
+
+	today := time.Now()
+	tasks := make([]Task, opts.Tasks)
+	lastInGroup := make(map[int]int) // group index -> index of its latest task
+	for i := range tasks {
+		id := strconv.Itoa(i + 1)
+		area := 0
+		if len(groups) > 0 {
+			area = rng.Intn(len(groups))
+		}
+		verb := demoVerbs[rng.Intn(len(demoVerbs))]
+		nouns := demoNouns[area%len(demoNouns)]
+		noun := nouns[rng.Intn(len(nouns))]
+		task := Task{
+			ID:          id,
+			Subject:     verb[0] + " " + noun,
+			Description: demoWhy[rng.Intn(len(demoWhy))],
+			Blocks:      []string{},
+			BlockedBy:   []string{},
+		}
+		if len(groups) > 0 {
+			SetTaskGroup(&task, groups[area].Name)
+		}
+
+		// Dependencies: mostly chains within the group, sometimes across groups
+		ready := true
+		if i > 0 && rng.Float64() < opts.Deps {
+			blocker := rng.Intn(i)
+			if prev, ok := lastInGroup[area]; ok && rng.Intn(4) > 0 {
+				blocker = prev
+			}
+			task.BlockedBy = append(task.BlockedBy, tasks[blocker].ID)
+			tasks[blocker].Blocks = append(tasks[blocker].Blocks, id)
+			ready = tasks[blocker].Status == "completed"
+		}
+		lastInGroup[area] = i
+
+		// Status: the earlier in the project, the more likely done
+		progress := float64(i) / float64(max(len(tasks), 1))
+		switch {
+		case !ready:
+			task.Status = "pending"
+		case rng.Float64() < 0.8*(1-progress):
+			task.Status = "completed"
+		case rng.Float64() < 0.2:
+			task.Status = "in_progress"
+			task.ActiveForm = verb[1] + " " + noun
+		default:
+			task.Status = "pending"
+		}
+
+		if task.Status == "in_progress" || rng.Intn(3) == 0 {
+			task.Owner = demoOwners[rng.Intn(len(demoOwners))]
+		}
+		if rng.Intn(2) == 0 {
+			SetTaskPriority(&task, Priorities[rng.Intn(len(Priorities))])
+		}
+		if task.Status != "completed" && rng.Intn(3) == 0 {
+			SetTaskDue(&task, today.AddDate(0, 0, rng.Intn(30)-3).Format(DateFormat))
+		}
+		if rng.Intn(3) == 0 {
+			SetTaskEstimate(&task, float64(1+rng.Intn(8)))
+		}
+		if rng.Intn(4) == 0 {
+			SetTaskTags(&task, []string{demoTags[rng.Intn(len(demoTags))]})
+		}
+		tasks[i] = task
+	}
+	return tasks, groups
+}
+
+// syntheticGroups returns n groups named after areas of a software project
+func syntheticGroups(n int) []TaskGroup {
+	groups := make([]TaskGroup, n)
+	for i := range groups {
+		name := syntheticAreas[i%len(syntheticAreas)]
+		if i >= len(syntheticAreas) {
+			name += " " + strconv.Itoa(i/len(syntheticAreas)+1)
+		}
+		groups[i] = TaskGroup{Name: name, Order: i, Color: DefaultColors[i%len(DefaultColors)]}
+	}
+	return groups
+}
+
+// WriteProject creates a project from tasks and groups, writing the files
+// directly without history or backups. The project must not exist yet.
+func WriteProject(projectName string, tasks []Task, groups []TaskGroup) error {
+	if err := checkProjectWritable(projectName); err != nil {
+		return err
+	}
+	projectDir, err := config.GetProjectDir(projectName)
+	if err != nil {
+		return err
+	}
+	if entries, err := os.ReadDir(projectDir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("project %s already exists", projectName)
+	}
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		content, err := json.MarshalIndent(task, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(projectDir, task.ID+".json"), content, 0644); err != nil {
+			return err
+		}
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	store := &GroupStore{ProjectName: projectName, Groups: groups}
+	return store.Save()
+}
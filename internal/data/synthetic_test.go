@@ -0,0 +1,100 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestSyntheticProject(t *testing.T) {
+	opts := SyntheticOptions{Tasks: 500, Groups: 10, Deps: 0.3, Seed: 7}
+	tasks, groups := SyntheticProject(opts)
+	if len(tasks) != 500 || len(groups) != 10 {
+		t.Fatalf("got %d tasks and %d groups", len(tasks), len(groups))
+	}
+	if groups[8].Name != "Backend 2" {
+		t.Errorf("groups[8] = %q, want names to stay unique", groups[8].Name)
+	}
+	if problems := ValidateReferences(tasks); len(problems) > 0 {
+		t.Errorf("inconsistent dependencies: %v", problems)
+	}
+
+	again, _ := SyntheticProject(opts)
+	if !reflect.DeepEqual(tasks, again) {
+		t.Error("the same seed should generate the same tasks")
+	}
+}
+
+func TestWriteProject(t *testing.T) {
+	testutil.IsolateHome(t)
+
+	tasks, groups := SyntheticProject(SyntheticOptions{Tasks: 20, Groups: 3, Deps: 0.5, Seed: 1})
+	if err := WriteProject("big", tasks, groups); err != nil {
+		t.Fatal(err)
+	}
+	store, err := LoadTasks("big")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Tasks) != 20 || len(store.Problems) > 0 {
+		t.Errorf("loaded %d tasks with problems %v", len(store.Tasks), store.Problems)
+	}
+	if groupStore, err := LoadGroups("big"); err != nil || len(groupStore.Groups) != 3 {
+		t.Errorf("groups not written: %v", err)
+	}
+
+	if err := WriteProject("big", tasks, groups); err == nil {
+		t.Error("expected an error writing over an existing project")
+	}
+}
+
+func BenchmarkLoadTasks(b *testing.B) {
+	testutil.IsolateHome(b)
+
+	tasks, groups := SyntheticProject(SyntheticOptions{Tasks: 10000, Groups: 8, Deps: 0.1, Seed: 1})
+	if err := WriteProject("bench", tasks, groups); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := LoadTasks("bench"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDemoProject(t *testing.T) {
+	tasks, groups := DemoProject(SyntheticOptions{Tasks: 300, Groups: 6, Deps: 0.4, Seed: 3})
+	if len(tasks) != 300 || len(groups) != 6 {
+		t.Fatalf("got %d tasks and %d groups", len(tasks), len(groups))
+	}
+	if problems := ValidateReferences(tasks); len(problems) > 0 {
+		t.Errorf("inconsistent dependencies: %v", problems)
+	}
+
+	byID := make(map[string]Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	counts := make(map[string]int)
+	for _, task := range tasks {
+		counts[task.Status]++
+		if task.Status == "pending" {
+			continue
+		}
+		for _, id := range task.BlockedBy {
+			if byID[id].Status != "completed" {
+				t.Errorf("#%s is %s but its blocker #%s is %s", task.ID, task.Status, id, byID[id].Status)
+			}
+		}
+		if task.Status == "in_progress" && task.ActiveForm == "" {
+			t.Errorf("#%s is in progress without an activeForm", task.ID)
+		}
+	}
+	for _, status := range []string{"pending", "in_progress", "completed"} {
+		if counts[status] == 0 {
+			t.Errorf("no %s tasks generated", status)
+		}
+	}
+}
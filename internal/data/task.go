@@ -1,8 +1,11 @@
 package data
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
@@ -24,22 +27,115 @@ type Task struct {
 	BlockedBy   []string               `json:"blockedBy"`
 	Owner       string                 `json:"owner,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+
+	// Extra holds JSON fields not known to cctasks (e.g. added by newer
+	// Claude Code versions) so they survive a load/save round-trip
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// knownTaskFields lists the JSON keys mapped to Task struct fields
+var knownTaskFields = map[string]bool{
+	"id":          true,
+	"subject":     true,
+	"description": true,
+	"activeForm":  true,
+	"status":      true,
+	"blocks":      true,
+	"blockedBy":   true,
+	"owner":       true,
+	"metadata":    true,
+}
+
+// taskFields has the same fields as Task but no JSON methods
+type taskFields Task
+
+// UnmarshalJSON decodes a task, keeping unknown fields in Extra
+func (t *Task) UnmarshalJSON(b []byte) error {
+	var fields taskFields
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*t = Task(fields)
+	t.Extra = nil
+	for key, value := range raw {
+		if knownTaskFields[key] {
+			continue
+		}
+		if t.Extra == nil {
+			t.Extra = make(map[string]json.RawMessage)
+		}
+		t.Extra[key] = value
+	}
+	return nil
+}
+
+// MarshalJSON encodes a task, appending unknown fields after the known ones
+func (t Task) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(taskFields(t))
+	if err != nil || len(t.Extra) == 0 {
+		return b, err
+	}
+
+	keys := make([]string, 0, len(t.Extra))
+	for key := range t.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Known fields always include "id", so the object is never empty
+	var buf bytes.Buffer
+	buf.Write(b[:len(b)-1])
+	for _, key := range keys {
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteByte(',')
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(t.Extra[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
 }
 
 // TaskStore handles task persistence
 type TaskStore struct {
 	ProjectName string
 	Tasks       []Task
-	projectDir  string    // cached project directory path
-	lastModTime time.Time // last modification time of project directory
+	Problems    []Problem            // schema problems found on load
+	Unparsable  []UnparsableFile     // task files that could not be parsed on load
+	layout      string               // LayoutSingle for a tasks.json project ("" is one file per task)
+	single      *singleFile          // the tasks.json as read, for the single-file layout
+	saved       []Task               // tasks as last loaded/saved, for change detection
+	trashed     map[string]bool      // IDs whose files were moved to the trash since the last save
+	projectDir  string               // cached project directory path
+	lastModTime time.Time            // last modification time of project directory
+	files       map[string]fileStamp // task files as of load/save, for change detection
+	lastChange  time.Time            // newest modification of the directory or a task file, as of load
+	scannedAt   time.Time            // when NeedsReload last compared the task files
+	reasons     map[string]string    // status change reasons by task ID, for the next save's history
+	key         []byte               // key of an encrypted project (nil when not encrypted)
+	loadedAt    time.Time            // when the load started
+	presence    []Presence           // collaborators' latest changes, as of load (collaboration mode)
+	noBackup    bool                 // never snapshot (stores built for tests)
 }
 
-// NewTaskStoreForTest creates a TaskStore for testing with a custom directory
+// NewTaskStoreForTest creates a TaskStore for testing with a custom directory.
+// Its saves are never snapshotted into the backup directory.
 func NewTaskStoreForTest(dir string, tasks []Task) (*TaskStore, error) {
 	store := &TaskStore{
 		ProjectName: "test",
 		Tasks:       tasks,
+		saved:       cloneTasks(tasks),
 		projectDir:  dir,
+		noBackup:    true,
 	}
 	// Save each task to file
 	for _, task := range tasks {
@@ -57,21 +153,40 @@ func NewTaskStoreForTest(dir string, tasks []Task) (*TaskStore, error) {
 
 // Project represents a project with task count
 type Project struct {
-	Name      string
-	TaskCount int
+	Name       string // "<project>", or "<root>/<project>" for additional roots
+	Root       string // name of the tasks root ("" for the primary tasks directory)
+	TaskCount  int
+	Pending    int
+	InProgress int
+	Completed  int
+	ModTime    time.Time // latest modification of the project directory or its task files
 }
 
-// ListProjects returns all projects in the tasks directory
+// ListProjects returns all projects in the tasks directories, primary root first
 func ListProjects() ([]Project, error) {
-	tasksDir, err := config.GetTasksDir()
+	roots, err := config.GetRoots()
 	if err != nil {
 		return nil, err
 	}
+	watchSSHRoots(roots)
 
-	entries, err := os.ReadDir(tasksDir)
+	projects := []Project{}
+	for _, root := range roots {
+		rootProjects, err := listRootProjects(root)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, rootProjects...)
+	}
+	return projects, nil
+}
+
+// listRootProjects returns the projects of one tasks root, sorted by name
+func listRootProjects(root config.Root) ([]Project, error) {
+	entries, err := os.ReadDir(root.Path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return []Project{}, nil
+			return nil, nil
 		}
 		return nil, err
 	}
@@ -83,18 +198,19 @@ func ListProjects() ([]Project, error) {
 		}
 
 		projectName := entry.Name()
-		projectDir := filepath.Join(tasksDir, projectName)
+		projectDir := filepath.Join(root.Path, projectName)
 
-		// Count task files (*.json except _groups.json)
-		taskCount := countTaskFiles(projectDir)
-		if taskCount == 0 {
+		project := scanProject(projectDir)
+		if project.TaskCount == 0 {
 			continue
 		}
 
-		projects = append(projects, Project{
-			Name:      projectName,
-			TaskCount: taskCount,
-		})
+		if root.Name != "" {
+			projectName = root.Name + "/" + projectName
+		}
+		project.Name = projectName
+		project.Root = root.Name
+		projects = append(projects, project)
 	}
 
 	// Sort by name
@@ -105,32 +221,80 @@ func ListProjects() ([]Project, error) {
 	return projects, nil
 }
 
-// countTaskFiles counts the number of task JSON files in a directory
-func countTaskFiles(dir string) int {
+// ProjectTask is a task together with the project it belongs to
+type ProjectTask struct {
+	Project string
+	Task    Task
+}
+
+// LoadAllTasks loads the tasks of every given project into one list, in
+// project order. Projects that fail to load are skipped and their errors
+// returned together with the tasks that did load.
+func LoadAllTasks(projects []Project) ([]ProjectTask, error) {
+	var tasks []ProjectTask
+	var errs []error
+	for _, project := range projects {
+		store, err := LoadTasks(project.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", project.Name, err))
+			continue
+		}
+		for _, task := range store.Tasks {
+			tasks = append(tasks, ProjectTask{Project: project.Name, Task: task})
+		}
+	}
+	return tasks, errors.Join(errs...)
+}
+
+// scanProject counts a project's tasks by status and finds its latest modification time
+func scanProject(dir string) Project {
+	var project Project
+	if info, err := os.Stat(dir); err == nil {
+		project.ModTime = info.ModTime()
+	}
+
+	if DetectLayout(dir) == LayoutSingle {
+		return scanSingleFile(dir, project)
+	}
+
 	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return 0
+		return project
 	}
-
-	count := 0
 	for _, entry := range entries {
-		if entry.IsDir() {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, "_") || !strings.HasSuffix(name, ".json") {
 			continue
 		}
-		name := entry.Name()
-		// Skip _groups.json and other non-task files
-		if strings.HasPrefix(name, "_") {
+		project.TaskCount++
+
+		if info, err := entry.Info(); err == nil && info.ModTime().After(project.ModTime) {
+			project.ModTime = info.ModTime()
+		}
+
+		var task struct {
+			Status string `json:"status"`
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || json.Unmarshal(data, &task) != nil {
 			continue
 		}
-		if strings.HasSuffix(name, ".json") {
-			count++
+		switch task.Status {
+		case "pending":
+			project.Pending++
+		case "in_progress":
+			project.InProgress++
+		case "completed":
+			project.Completed++
 		}
 	}
-	return count
+	return project
 }
 
 // LoadTasks loads tasks from individual JSON files in the project directory
 func LoadTasks(projectName string) (*TaskStore, error) {
+	loadedAt := time.Now()
+	watchSSHProject(projectName)
 	projectDir, err := config.GetProjectDir(projectName)
 	if err != nil {
 		return nil, err
@@ -155,7 +319,20 @@ func LoadTasks(projectName string) (*TaskStore, error) {
 		modTime = dirInfo.ModTime()
 	}
 
+	var key []byte
+	if IsEncrypted(projectDir) {
+		if key, err = projectKey(projectDir); err != nil {
+			return nil, err
+		}
+	}
+
+	layout := DetectLayout(projectDir)
+	var single *singleFile
 	var tasks []Task
+	var problems []Problem
+	var unparsable []UnparsableFile
+	files := make(map[string]fileStamp)
+	lastChange := modTime
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -166,51 +343,149 @@ func LoadTasks(projectName string) (*TaskStore, error) {
 			continue
 		}
 
+		if info, err := entry.Info(); err == nil {
+			files[name] = stampOf(info)
+			if info.ModTime().After(lastChange) {
+				lastChange = info.ModTime()
+			}
+		}
+
+		// In the single-file layout other task files are left alone
+		if layout == LayoutSingle && name != LegacyTasksFile {
+			continue
+		}
+
 		filePath := filepath.Join(projectDir, name)
 		data, err := os.ReadFile(filePath)
 		if err != nil {
+			problems = append(problems, Problem{File: name, Message: err.Error()})
 			continue
 		}
 
+		if layout == LayoutSingle {
+			f, fileTasks, fileProblems, err := parseSingleFile(data)
+			if err != nil {
+				problems = append(problems, Problem{File: name, Message: fmt.Sprintf("invalid JSON: %v", err)})
+				unparsable = append(unparsable, UnparsableFile{File: name, Error: err.Error(), Hash: contentHash(data)})
+				continue
+			}
+			single = f
+			tasks = append(tasks, fileTasks...)
+			problems = append(problems, fileProblems...)
+			continue
+		}
+
+		plain, err := openTaskData(key, data)
+		if err != nil {
+			problems = append(problems, Problem{File: name, Message: err.Error()})
+			unparsable = append(unparsable, UnparsableFile{File: name, Error: err.Error(), Hash: contentHash(data)})
+			continue
+		}
+		data = plain
+
+		// Validate against the schema (problems are reported, not fatal)
+		problems = append(problems, ValidateTaskData(name, data)...)
+
 		var task Task
 		if err := json.Unmarshal(data, &task); err != nil {
+			unparsable = append(unparsable, UnparsableFile{File: name, Error: err.Error(), Hash: contentHash(data)})
 			continue
 		}
 		tasks = append(tasks, task)
 	}
 
-	// Sort by ID (numeric)
+	// Sort by ID (numeric, UUID tasks by their alias)
 	sort.Slice(tasks, func(i, j int) bool {
-		idI, _ := strconv.Atoi(tasks[i].ID)
-		idJ, _ := strconv.Atoi(tasks[j].ID)
-		return idI < idJ
+		return lessTaskNumber(tasks[i], tasks[j])
 	})
 
+	for _, p := range ValidateReferences(tasks) {
+		if layout == LayoutSingle {
+			p = singleFileProblem(p)
+		}
+		problems = append(problems, p)
+	}
+	sortProblems(problems)
+
 	store := &TaskStore{
 		ProjectName: projectName,
 		Tasks:       tasks,
+		Problems:    problems,
+		Unparsable:  unparsable,
+		layout:      layout,
+		single:      single,
+		saved:       cloneTasks(tasks),
 		projectDir:  projectDir,
 		lastModTime: modTime,
+		files:       files,
+		lastChange:  lastChange,
+		key:         key,
+		loadedAt:    loadedAt,
 	}
-
-	// Backup all task files (only if source is newer)
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		name := entry.Name()
-		if strings.HasPrefix(name, "_") || !strings.HasSuffix(name, ".json") {
-			continue
-		}
-		store.backupFile(name)
+	if store.collaborating() {
+		store.loadPresence(loadedAt)
 	}
 
+	slog.Debug("tasks loaded", "project", projectName, "layout", layout, "tasks", len(tasks), "problems", len(problems), "unparsable", len(unparsable))
+
 	return store, nil
 }
 
-// Save saves all tasks to individual JSON files
+// dir returns the project directory, preferring the cached path
+func (s *TaskStore) dir() (string, error) {
+	if s.projectDir != "" {
+		return s.projectDir, nil
+	}
+	return config.GetProjectDir(s.ProjectName)
+}
+
+// TaskFilePath returns the path of the JSON file holding the given task
+func (s *TaskStore) TaskFilePath(id string) (string, error) {
+	if s.layout == LayoutSingle {
+		return s.FilePath(LegacyTasksFile)
+	}
+	return s.FilePath(id + ".json")
+}
+
+// RawTaskJSON reads the task file as written on disk and returns it
+// pretty-printed. Content that is not valid JSON is returned unchanged
+// along with the parse error.
+func (s *TaskStore) RawTaskJSON(id string) ([]byte, error) {
+	if s.layout == LayoutSingle {
+		return s.singleTaskJSON(id)
+	}
+	return s.RawFile(id + ".json")
+}
+
+// RawFile reads a file of the project directory like RawTaskJSON
+func (s *TaskStore) RawFile(name string) ([]byte, error) {
+	filePath, err := s.FilePath(name)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if raw, err = openTaskData(s.key, raw); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return raw, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Save writes the tasks changed since the last load or save to their JSON
+// files. Unchanged files are not touched, so saving one task neither bumps
+// the other files' mtimes (which Claude Code watches) nor overwrites their
+// newer content on disk; with nothing changed no backup is taken either.
 func (s *TaskStore) Save() error {
-	projectDir, err := config.GetProjectDir(s.ProjectName)
+	if err := checkProjectWritable(s.ProjectName); err != nil {
+		return err
+	}
+	projectDir, err := s.dir()
 	if err != nil {
 		return err
 	}
@@ -220,98 +495,155 @@ func (s *TaskStore) Save() error {
 		return err
 	}
 
-	// Save each task to its own file
-	for _, task := range s.Tasks {
-		if err := s.saveTask(task); err != nil {
+	if s.collaborating() {
+		if err := s.reconcile(); err != nil {
+			slog.Debug("merging with changes on disk failed", "project", s.ProjectName, "err", err)
 			return err
 		}
 	}
+	dirty := s.dirtyTasks()
+	if s.layout == LayoutSingle {
+		// One file holds every task: rewrite it when a task changed or was removed
+		if len(dirty) > 0 || len(s.Tasks) != len(s.saved) {
+			if err := s.saveSingleFile(); err != nil {
+				slog.Debug("save failed", "project", s.ProjectName, "file", LegacyTasksFile, "err", err)
+				return err
+			}
+		}
+	} else {
+		for _, task := range dirty {
+			if err := s.saveTask(task); err != nil {
+				slog.Debug("save failed", "project", s.ProjectName, "task", task.ID, "err", err)
+				return err
+			}
+		}
+	}
 
+	changes := DiffTasks(s.saved, s.Tasks)
+	slog.Debug("tasks saved", "project", s.ProjectName, "tasks", len(s.Tasks), "written", len(dirty), "changes", len(changes))
+	s.trashed = nil
+	if len(dirty) == 0 && len(changes) == 0 {
+		s.reasons = nil
+		return nil
+	}
+	s.recordHistory(s.saved, changes, time.Now(), false)
+	s.reasons = nil
+	s.notifyWebhooks(s.saved, changes, false)
+	s.runHooks(s.saved, changes, false)
+	s.saved = cloneTasks(s.Tasks)
+	s.lastChange = time.Now()
+
+	// Backup: snapshot only if content differs from the latest snapshot
+	s.snapshot()
+	s.PurgeExpiredTrash()
+	s.commitChanges(changes)
 	return nil
 }
 
+// dirtyTasks returns the tasks added or modified since the last load or save
+func (s *TaskStore) dirtyTasks() []Task {
+	saved := make(map[string]Task, len(s.saved))
+	for _, task := range s.saved {
+		saved[task.ID] = task
+	}
+	var dirty []Task
+	for _, task := range s.Tasks {
+		if prev, ok := saved[task.ID]; !ok || s.trashed[task.ID] || !tasksEqual(prev, task) {
+			dirty = append(dirty, task)
+		}
+	}
+	return dirty
+}
+
 // saveTask saves a single task to its JSON file
 func (s *TaskStore) saveTask(task Task) error {
-	projectDir, err := config.GetProjectDir(s.ProjectName)
+	filePath, err := s.TaskFilePath(task.ID)
 	if err != nil {
 		return err
 	}
 
-	filePath := filepath.Join(projectDir, task.ID+".json")
 	data, err := json.MarshalIndent(task, "", "  ")
 	if err != nil {
 		return err
 	}
-
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if data, err = sealTaskData(s.key, data); err != nil {
 		return err
 	}
 
-	// Backup: write only if content differs
-	s.backupTaskData(task.ID+".json", data)
-	return nil
-}
-
-// backupTaskData backs up task data to backup directory if content differs
-func (s *TaskStore) backupTaskData(filename string, data []byte) {
-	backupDir, err := config.GetBackupProjectDir(s.ProjectName)
-	if err != nil {
-		return
+	write := os.WriteFile
+	if s.collaborating() {
+		write = writeFileAtomic
 	}
-
-	if err := os.MkdirAll(backupDir, 0755); err != nil {
-		return
+	if err := write(filePath, data, 0644); err != nil {
+		return err
 	}
-
-	backupPath := filepath.Join(backupDir, filename)
-
-	// Check if backup exists and has same content
-	existing, err := os.ReadFile(backupPath)
-	if err == nil && string(existing) == string(data) {
-		return // Same content, skip write
+	// Our own write is not an external change
+	if info, err := os.Stat(filePath); err == nil && s.files != nil {
+		s.files[filepath.Base(filePath)] = stampOf(info)
 	}
-
-	os.WriteFile(backupPath, data, 0644)
+	return nil
 }
 
-// backupFile copies a file to backup directory if source is newer
-func (s *TaskStore) backupFile(filename string) {
-	projectDir, err := config.GetProjectDir(s.ProjectName)
-	if err != nil {
+// snapshot backs up the project directory (errors are ignored; backups are best-effort)
+func (s *TaskStore) snapshot() {
+	if s.noBackup {
 		return
 	}
-	backupDir, err := config.GetBackupProjectDir(s.ProjectName)
+	projectDir, err := s.dir()
 	if err != nil {
 		return
 	}
+	snapshotStore(s.ProjectName, projectDir)
+}
 
-	srcPath := filepath.Join(projectDir, filename)
-	dstPath := filepath.Join(backupDir, filename)
+// LastChange returns when the project's task files last changed on disk, as
+// of the last load
+func (s *TaskStore) LastChange() time.Time {
+	return s.lastChange
+}
 
-	srcInfo, err := os.Stat(srcPath)
+// TaskModTime returns when a task's file last changed on disk: as of the
+// last load or save, or else as the file is now. It is zero when the file
+// cannot be found.
+func (s *TaskStore) TaskModTime(id string) time.Time {
+	if stamp, ok := s.files[id+".json"]; ok {
+		return stamp.modTime
+	}
+	path, err := s.TaskFilePath(id)
 	if err != nil {
-		return
+		return time.Time{}
 	}
-
-	// Check if backup is up-to-date
-	dstInfo, err := os.Stat(dstPath)
-	if err == nil && !srcInfo.ModTime().After(dstInfo.ModTime()) {
-		return // Backup is up-to-date, skip
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
 	}
+	return info.ModTime()
+}
 
-	if err := os.MkdirAll(backupDir, 0755); err != nil {
-		return
-	}
+// fileStamp identifies one version of a task file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
 
-	data, err := os.ReadFile(srcPath)
-	if err != nil {
-		return
-	}
+func stampOf(info os.FileInfo) fileStamp {
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
 
-	os.WriteFile(dstPath, data, 0644)
+func (a fileStamp) equal(b fileStamp) bool {
+	return a.size == b.size && a.modTime.Equal(b.modTime)
 }
 
-// NeedsReload checks if the project directory has been modified since last load
+// fileScanInterval is the least time between two comparisons of the task
+// files in NeedsReload, which runs on every key press
+const fileScanInterval = time.Second
+
+// NeedsReload checks if task files were added, removed or modified since the
+// last load. The directory mtime catches added and removed files; files
+// edited in place are caught by their own mtime and size, since the directory
+// mtime does not change for them on most filesystems. Reading the directory
+// and every file's stamp is left for at most once per fileScanInterval, so
+// keys typed in a row cost one stat each.
 func (s *TaskStore) NeedsReload() bool {
 	if s.projectDir == "" {
 		return false
@@ -320,7 +652,35 @@ func (s *TaskStore) NeedsReload() bool {
 	if err != nil {
 		return false
 	}
-	return dirInfo.ModTime().After(s.lastModTime)
+	if dirInfo.ModTime().After(s.lastModTime) {
+		return true
+	}
+	if s.files == nil || time.Since(s.scannedAt) < fileScanInterval {
+		return false
+	}
+	s.scannedAt = time.Now()
+
+	entries, err := os.ReadDir(s.projectDir)
+	if err != nil {
+		return false
+	}
+	count := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, "_") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		count++
+		info, err := entry.Info()
+		if err != nil {
+			continue // removed since ReadDir
+		}
+		if stamp, ok := s.files[name]; !ok || !stamp.equal(stampOf(info)) {
+			slog.Debug("task file changed", "project", s.ProjectName, "file", name)
+			return true
+		}
+	}
+	return count != len(s.files)
 }
 
 // GetTask returns a task by ID
@@ -335,7 +695,12 @@ func (s *TaskStore) GetTask(id string) *Task {
 
 // AddTask adds a new task and returns the assigned ID
 func (s *TaskStore) AddTask(task Task) string {
-	task.ID = s.generateID()
+	if useUUIDs() {
+		task.ID = newUUID()
+		setMetadataString(&task, aliasKey, strconv.Itoa(s.nextTaskNumber()))
+	} else {
+		task.ID = s.generateID()
+	}
 	if task.Status == "" {
 		task.Status = "pending"
 	}
@@ -360,10 +725,15 @@ func (s *TaskStore) UpdateTask(task Task) error {
 	return fmt.Errorf("task not found: %s", task.ID)
 }
 
-// DeleteTask removes a task by ID
+// DeleteTask removes a task by ID, moving its file to the trash
 func (s *TaskStore) DeleteTask(id string) error {
+	if err := checkProjectWritable(s.ProjectName); err != nil {
+		return err
+	}
 	for i := range s.Tasks {
 		if s.Tasks[i].ID == id {
+			task := s.Tasks[i]
+
 			// Remove from blocks/blockedBy of other tasks
 			for j := range s.Tasks {
 				if j == i {
@@ -376,17 +746,12 @@ func (s *TaskStore) DeleteTask(id string) error {
 			// Remove from memory
 			s.Tasks = append(s.Tasks[:i], s.Tasks[i+1:]...)
 
-			// Delete the file
-			projectDir, err := config.GetProjectDir(s.ProjectName)
-			if err != nil {
-				return err
+			// Move the file to the trash (restorable from the Trash screen)
+			if s.trashed == nil {
+				s.trashed = make(map[string]bool)
 			}
-			filePath := filepath.Join(projectDir, id+".json")
-			if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
-				return err
-			}
-
-			return nil
+			s.trashed[id] = true
+			return s.moveToTrash(task, time.Now())
 		}
 	}
 	return fmt.Errorf("task not found: %s", id)
@@ -394,15 +759,8 @@ func (s *TaskStore) DeleteTask(id string) error {
 
 // generateID generates a new unique task ID
 func (s *TaskStore) generateID() string {
-	maxID := 0
-	for _, task := range s.Tasks {
-		if id, err := strconv.Atoi(task.ID); err == nil {
-			if id > maxID {
-				maxID = id
-			}
-		}
-	}
-	return strconv.Itoa(maxID + 1)
+	// Aliases of UUID tasks count too, so a new ID never looks like an alias
+	return strconv.Itoa(s.nextTaskNumber())
 }
 
 // GetTasksByStatus returns tasks filtered by status
@@ -432,17 +790,15 @@ func (s *TaskStore) GetTasksByGroup(group string) []Task {
 	return filtered
 }
 
-// SearchTasks returns tasks matching the search query in subject or description
+// SearchTasks returns tasks matching the search query in any field (see SearchTask)
 func (s *TaskStore) SearchTasks(query string) []Task {
 	if query == "" {
 		return s.Tasks
 	}
 
-	query = strings.ToLower(query)
 	var filtered []Task
 	for _, task := range s.Tasks {
-		if strings.Contains(strings.ToLower(task.Subject), query) ||
-			strings.Contains(strings.ToLower(task.Description), query) {
+		if MatchesSearch(task, query) {
 			filtered = append(filtered, task)
 		}
 	}
@@ -472,6 +828,98 @@ func SetTaskGroup(task *Task, group string) {
 	}
 }
 
+// GetTaskTags returns the tags from task metadata
+func GetTaskTags(task Task) []string {
+	if task.Metadata == nil {
+		return nil
+	}
+	switch tags := task.Metadata["tags"].(type) {
+	case []string:
+		return tags
+	case []interface{}:
+		var result []string
+		for _, t := range tags {
+			if tag, ok := t.(string); ok && tag != "" {
+				result = append(result, tag)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// SetTaskTags sets the tags in task metadata
+func SetTaskTags(task *Task, tags []string) {
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	if len(tags) == 0 {
+		delete(task.Metadata, "tags")
+	} else {
+		task.Metadata["tags"] = tags
+	}
+}
+
+// Priorities lists the valid priority values, highest first
+var Priorities = []string{"high", "medium", "low"}
+
+// IsValidPriority reports whether p is a known priority ("" means none)
+func IsValidPriority(p string) bool {
+	if p == "" {
+		return true
+	}
+	for _, valid := range Priorities {
+		if p == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTaskPriority returns the priority from task metadata
+func GetTaskPriority(task Task) string {
+	if task.Metadata == nil {
+		return ""
+	}
+	if priority, ok := task.Metadata["priority"].(string); ok {
+		return priority
+	}
+	return ""
+}
+
+// SetTaskPriority sets the priority in task metadata
+func SetTaskPriority(task *Task, priority string) {
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	if priority == "" {
+		delete(task.Metadata, "priority")
+	} else {
+		task.Metadata["priority"] = priority
+	}
+}
+
+// IsTaskStarred reports whether the task is starred (pinned to the top of the list)
+func IsTaskStarred(task Task) bool {
+	if task.Metadata == nil {
+		return false
+	}
+	starred, _ := task.Metadata["starred"].(bool)
+	return starred
+}
+
+// SetTaskStarred stars or unstars the task in metadata
+func SetTaskStarred(task *Task, starred bool) {
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	if starred {
+		task.Metadata["starred"] = true
+	} else {
+		delete(task.Metadata, "starred")
+	}
+}
+
 // GetAllGroups returns all unique group names from tasks
 func (s *TaskStore) GetAllGroups() []string {
 	groupSet := make(map[string]bool)
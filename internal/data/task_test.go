@@ -5,6 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/testutil"
 )
 
 func TestLoadTasks(t *testing.T) {
@@ -46,26 +49,8 @@ func TestLoadTasks(t *testing.T) {
 	}
 }
 
-func TestCountTaskFiles(t *testing.T) {
-	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
-	if err != nil {
-		t.Fatalf("Failed to create temp dir: %v", err)
-	}
-	defer os.RemoveAll(tmpDir)
-
-	// Create some files
-	os.WriteFile(filepath.Join(tmpDir, "1.json"), []byte("{}"), 0644)
-	os.WriteFile(filepath.Join(tmpDir, "2.json"), []byte("{}"), 0644)
-	os.WriteFile(filepath.Join(tmpDir, "_groups.json"), []byte("{}"), 0644)
-	os.WriteFile(filepath.Join(tmpDir, "readme.txt"), []byte(""), 0644)
-
-	count := countTaskFiles(tmpDir)
-	if count != 2 {
-		t.Errorf("Expected 2 task files, got %d", count)
-	}
-}
-
 func TestTaskStoreAddAndDelete(t *testing.T) {
+	testutil.IsolateHome(t)
 	store := &TaskStore{
 		ProjectName: "test",
 		Tasks:       []Task{},
@@ -294,6 +279,7 @@ func TestUpdateTask(t *testing.T) {
 }
 
 func TestDeleteTaskWithDependencies(t *testing.T) {
+	testutil.IsolateHome(t)
 	store := &TaskStore{
 		Tasks: []Task{
 			{ID: "1", Subject: "Task 1", Blocks: []string{"2"}, BlockedBy: []string{}},
@@ -420,3 +406,234 @@ func loadTasksFromDir(dir string) (*TaskStore, error) {
 
 	return &TaskStore{Tasks: tasks}, nil
 }
+
+func TestTaskUnknownFieldsRoundTrip(t *testing.T) {
+	input := `{
+  "id": "1",
+  "subject": "Task 1",
+  "description": "",
+  "status": "pending",
+  "blocks": [],
+  "blockedBy": [],
+  "createdAt": "2025-01-01T00:00:00Z",
+  "priority": {"level": 2, "tags": ["a", "b"]}
+}`
+
+	var task Task
+	if err := json.Unmarshal([]byte(input), &task); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if task.Subject != "Task 1" {
+		t.Errorf("Expected subject 'Task 1', got '%s'", task.Subject)
+	}
+	if len(task.Extra) != 2 {
+		t.Fatalf("Expected 2 extra fields, got %d", len(task.Extra))
+	}
+	if _, ok := task.Extra["subject"]; ok {
+		t.Error("Known field 'subject' should not be kept in Extra")
+	}
+
+	// Modify a known field and re-encode
+	task.Status = "completed"
+	out, err := json.MarshalIndent(task, "", "  ")
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("Re-decode failed: %v", err)
+	}
+	if decoded["status"] != "completed" {
+		t.Errorf("Expected status 'completed', got '%v'", decoded["status"])
+	}
+	if decoded["createdAt"] != "2025-01-01T00:00:00Z" {
+		t.Errorf("Expected createdAt to be preserved, got '%v'", decoded["createdAt"])
+	}
+	priority, ok := decoded["priority"].(map[string]interface{})
+	if !ok || priority["level"] != float64(2) {
+		t.Errorf("Expected nested priority object to be preserved, got %v", decoded["priority"])
+	}
+}
+
+func TestTaskWithoutUnknownFieldsMarshal(t *testing.T) {
+	task := Task{ID: "1", Subject: "Task 1", Status: "pending", Blocks: []string{}, BlockedBy: []string{}}
+
+	out, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	expected := `{"id":"1","subject":"Task 1","description":"","status":"pending","blocks":[],"blockedBy":[]}`
+	if string(out) != expected {
+		t.Errorf("Expected %s, got %s", expected, string(out))
+	}
+}
+
+func TestSavePreservesUnknownFields(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	input := `{"id":"1","subject":"Task 1","status":"pending","blocks":[],"blockedBy":[],"futureField":[1,2,3]}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "1.json"), []byte(input), 0644); err != nil {
+		t.Fatalf("Failed to write task file: %v", err)
+	}
+
+	store, err := loadTasksFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	store.projectDir = tmpDir
+
+	store.Tasks[0].Subject = "Renamed"
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, err := loadTasksFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if reloaded.Tasks[0].Subject != "Renamed" {
+		t.Errorf("Expected subject 'Renamed', got '%s'", reloaded.Tasks[0].Subject)
+	}
+	var future []int
+	if err := json.Unmarshal(reloaded.Tasks[0].Extra["futureField"], &future); err != nil || len(future) != 3 {
+		t.Errorf("Expected futureField to be preserved, got '%s'", reloaded.Tasks[0].Extra["futureField"])
+	}
+}
+
+func TestSaveWritesOnlyChangedTasks(t *testing.T) {
+	testutil.IsolateHome(t)
+	tmpDir := t.TempDir()
+	store, err := NewTaskStoreForTest(tmpDir, []Task{
+		{ID: "1", Subject: "Task 1", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+		{ID: "2", Subject: "Task 2", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Task 1 is rewritten on disk by someone else after the load
+	external := `{"id":"1","subject":"Changed elsewhere","status":"in_progress"}`
+	path1 := filepath.Join(tmpDir, "1.json")
+	if err := os.WriteFile(path1, []byte(external), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path1, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	store.Tasks[1].Status = "completed"
+	store.AddTask(Task{Subject: "Task 3"})
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	if b, _ := os.ReadFile(path1); string(b) != external {
+		t.Errorf("unchanged task 1 was rewritten: %s", b)
+	}
+	if info, err := os.Stat(path1); err != nil || !info.ModTime().Equal(old) {
+		t.Error("unchanged task 1 had its mtime bumped")
+	}
+	reloaded, err := loadTasksFromDir(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.Tasks) != 3 || reloaded.Tasks[1].Status != "completed" {
+		t.Errorf("changed and new tasks not written: %+v", reloaded.Tasks)
+	}
+}
+
+func TestNeedsReloadDetectsInPlaceEdits(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+
+	projectDir := filepath.Join(tasksDir, "proj")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path1 := filepath.Join(projectDir, "1.json")
+	for id, content := range map[string]string{
+		"1": `{"id":"1","subject":"Task 1","status":"pending"}`,
+		"2": `{"id":"2","subject":"Task 2","status":"pending"}`,
+	} {
+		if err := os.WriteFile(filepath.Join(projectDir, id+".json"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store, err := LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.NeedsReload() {
+		t.Fatal("NeedsReload right after load")
+	}
+
+	// Our own writes are not external changes (the directory mtime is
+	// reset since saving may create the history file)
+	store.Tasks[1].Status = "completed"
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(projectDir, store.lastModTime, store.lastModTime); err != nil {
+		t.Fatal(err)
+	}
+	if store.NeedsReload() {
+		t.Error("NeedsReload after saving")
+	}
+
+	// Rewrite a file in place, keeping the directory mtime
+	later := time.Now().Add(time.Minute)
+	if err := os.WriteFile(path1, []byte(`{"id":"1","subject":"Task 1","status":"completed"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path1, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(projectDir, store.lastModTime, store.lastModTime); err != nil {
+		t.Fatal(err)
+	}
+	if store.NeedsReload() {
+		t.Error("task files compared again within fileScanInterval")
+	}
+	store.scannedAt = time.Now().Add(-fileScanInterval)
+	if !store.NeedsReload() {
+		t.Error("in-place edit of 1.json not detected")
+	}
+}
+
+func TestRawTaskJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	raw := `{"id":"1","subject":"Task 1","status":"pending","extra":{"by":"agent"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "1.json"), []byte(raw), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "2.json"), []byte(`{"id":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := &TaskStore{projectDir: tmpDir}
+
+	got, err := store.RawTaskJSON("1")
+	if err != nil {
+		t.Fatalf("RawTaskJSON failed: %v", err)
+	}
+	want := "{\n  \"id\": \"1\",\n  \"subject\": \"Task 1\",\n  \"status\": \"pending\",\n  \"extra\": {\n    \"by\": \"agent\"\n  }\n}"
+	if string(got) != want {
+		t.Errorf("RawTaskJSON = %q, want %q (fields kept in file order)", got, want)
+	}
+
+	// Broken files come back as-is with the parse error
+	got, err = store.RawTaskJSON("2")
+	if err == nil || string(got) != `{"id":` {
+		t.Errorf("RawTaskJSON(broken) = %q, %v", got, err)
+	}
+
+	if _, err := store.RawTaskJSON("99"); err == nil {
+		t.Error("expected error for missing task file")
+	}
+}
@@ -0,0 +1,266 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// TrashDirName is the folder inside a project directory holding deleted tasks
+const TrashDirName = "_trash"
+
+// trashTimeFormat is the deletion timestamp embedded in trash file names
+const trashTimeFormat = "20060102T150405"
+
+// TrashItem is a deleted task waiting in the trash
+type TrashItem struct {
+	Task      Task
+	DeletedAt time.Time
+	File      string // file name inside the trash folder
+}
+
+// trashDir returns the project's trash folder
+func (s *TaskStore) trashDir() (string, error) {
+	projectDir, err := s.dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(projectDir, TrashDirName), nil
+}
+
+// moveToTrash moves a task file into the trash folder, named "<id>.<timestamp>.json".
+// In the single-file layout the task is written there instead; saving then
+// removes it from tasks.json. A store not loaded from a project directory
+// (built in memory) has no files to trash, so nothing is done.
+func (s *TaskStore) moveToTrash(task Task, now time.Time) error {
+	if err := checkProjectWritable(s.ProjectName); err != nil {
+		return err
+	}
+	if s.projectDir == "" {
+		return nil
+	}
+	trashDir := filepath.Join(s.projectDir, TrashDirName)
+	if err := os.MkdirAll(trashDir, 0755); err != nil {
+		return err
+	}
+
+	dst := trashPath(trashDir, task.ID, now)
+	if s.layout == LayoutSingle {
+		content, err := json.MarshalIndent(task, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dst, content, 0644)
+	}
+	src := filepath.Join(s.projectDir, task.ID+".json")
+	if err := os.Rename(src, dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// trashPath returns a free path in trashDir for a task deleted at now:
+// "<id>.<timestamp>.json", or "<id>.<timestamp>-<n>.json" when the same ID
+// was already trashed within that second (e.g. deleted, restored and deleted
+// again), so the earlier copy is not overwritten
+func trashPath(trashDir, id string, now time.Time) string {
+	stamp := now.Format(trashTimeFormat)
+	path := filepath.Join(trashDir, fmt.Sprintf("%s.%s.json", id, stamp))
+	for n := 2; ; n++ {
+		if _, err := os.Lstat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = filepath.Join(trashDir, fmt.Sprintf("%s.%s-%d.json", id, stamp, n))
+	}
+}
+
+// ListTrash returns the tasks in the trash, most recently deleted first
+func (s *TaskStore) ListTrash() ([]TrashItem, error) {
+	trashDir, err := s.trashDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(trashDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []TrashItem{}, nil
+		}
+		return nil, err
+	}
+
+	var items []TrashItem
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		deletedAt, ok := parseTrashFileName(entry.Name())
+		if !ok {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(trashDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		if content, err = openTaskData(s.key, content); err != nil {
+			continue
+		}
+		var task Task
+		if err := json.Unmarshal(content, &task); err != nil {
+			continue
+		}
+		items = append(items, TrashItem{Task: task, DeletedAt: deletedAt, File: entry.Name()})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+// parseTrashFileName extracts the deletion time from "<id>.<timestamp>.json"
+// or "<id>.<timestamp>-<n>.json"
+func parseTrashFileName(name string) (time.Time, bool) {
+	parts := strings.Split(strings.TrimSuffix(name, ".json"), ".")
+	if len(parts) != 2 || !strings.HasSuffix(name, ".json") {
+		return time.Time{}, false
+	}
+	stamp, _, _ := strings.Cut(parts[1], "-")
+	t, err := time.ParseInLocation(trashTimeFormat, stamp, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// RestoreFromTrash moves a trashed task back into the project and returns its ID.
+// If the original ID is taken, the task gets a new ID: a UUID task a new
+// UUID, keeping it out of the numeric IDs its alias stands for. A UUID task
+// whose alias another task now goes by gets the next free alias. Dependency
+// links to existing tasks are re-created on both sides.
+func (s *TaskStore) RestoreFromTrash(item TrashItem) (string, error) {
+	if err := checkProjectWritable(s.ProjectName); err != nil {
+		return "", err
+	}
+	task := cloneTask(item.Task)
+	if IsUUID(task.ID) {
+		if s.GetTask(task.ID) != nil {
+			task.ID = newUUID()
+		}
+		if alias := GetTaskAlias(task); alias != "" && s.numberTaken(alias) {
+			setMetadataString(&task, aliasKey, strconv.Itoa(s.nextTaskNumber()))
+		}
+	} else if s.GetTask(task.ID) != nil {
+		task.ID = s.generateID()
+	}
+
+	// Drop references to tasks that no longer exist
+	task.Blocks = filterExisting(s, task.Blocks)
+	task.BlockedBy = filterExisting(s, task.BlockedBy)
+	if task.Blocks == nil {
+		task.Blocks = []string{}
+	}
+	if task.BlockedBy == nil {
+		task.BlockedBy = []string{}
+	}
+
+	// Re-create the reverse side of each dependency
+	for _, id := range task.Blocks {
+		if other := s.GetTask(id); other != nil && !containsString(other.BlockedBy, task.ID) {
+			other.BlockedBy = append(other.BlockedBy, task.ID)
+		}
+	}
+	for _, id := range task.BlockedBy {
+		if other := s.GetTask(id); other != nil && !containsString(other.Blocks, task.ID) {
+			other.Blocks = append(other.Blocks, task.ID)
+		}
+	}
+
+	s.Tasks = append(s.Tasks, task)
+	if err := s.Save(); err != nil {
+		return "", err
+	}
+
+	trashDir, err := s.trashDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.Remove(filepath.Join(trashDir, item.File)); err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	return task.ID, nil
+}
+
+// PurgeFromTrash permanently deletes a trashed task
+func (s *TaskStore) PurgeFromTrash(item TrashItem) error {
+	if err := checkProjectWritable(s.ProjectName); err != nil {
+		return err
+	}
+	trashDir, err := s.trashDir()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(trashDir, item.File)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// PurgeExpiredTrash permanently deletes trashed tasks older than the
+// configured retention. Saves run it; projects that cannot be written to
+// are left alone.
+func (s *TaskStore) PurgeExpiredTrash() {
+	days := config.Current().Trash.RetentionDays
+	if days <= 0 || checkProjectWritable(s.ProjectName) != nil {
+		return
+	}
+	items, err := s.ListTrash()
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	for _, item := range items {
+		if item.DeletedAt.Before(cutoff) {
+			s.PurgeFromTrash(item)
+		}
+	}
+}
+
+// numberTaken reports whether a task goes by the sequential number n, as
+// its ID or its alias
+func (s *TaskStore) numberTaken(n string) bool {
+	for _, task := range s.Tasks {
+		if DisplayID(task) == n {
+			return true
+		}
+	}
+	return false
+}
+
+// filterExisting returns the IDs from ids that exist in the store
+func filterExisting(s *TaskStore, ids []string) []string {
+	var result []string
+	for _, id := range ids {
+		if s.GetTask(id) != nil {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// containsString reports whether slice contains item
+func containsString(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDeleteTaskMovesToTrash(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewTaskStoreForTest(tmpDir, []Task{
+		{ID: "1", Subject: "Task 1", Status: "pending", Blocks: []string{"2"}, BlockedBy: []string{}},
+		{ID: "2", Subject: "Task 2", Status: "pending", Blocks: []string{}, BlockedBy: []string{"1"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	if err := store.DeleteTask("1"); err != nil {
+		t.Fatalf("DeleteTask failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "1.json")); !os.IsNotExist(err) {
+		t.Error("Expected 1.json to be removed from the project")
+	}
+
+	items, err := store.ListTrash()
+	if err != nil {
+		t.Fatalf("ListTrash failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("Expected 1 trashed task, got %d", len(items))
+	}
+	if items[0].Task.ID != "1" || items[0].Task.Subject != "Task 1" {
+		t.Errorf("Unexpected trashed task: %+v", items[0].Task)
+	}
+	if time.Since(items[0].DeletedAt) > time.Minute {
+		t.Errorf("Expected recent deletion time, got %v", items[0].DeletedAt)
+	}
+
+	// Restoring re-creates the dependency on both sides
+	id, err := store.RestoreFromTrash(items[0])
+	if err != nil {
+		t.Fatalf("RestoreFromTrash failed: %v", err)
+	}
+	if id != "1" {
+		t.Errorf("Expected restored ID '1', got '%s'", id)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "1.json")); err != nil {
+		t.Error("Expected 1.json to be restored")
+	}
+	if task2 := store.GetTask("2"); len(task2.BlockedBy) != 1 || task2.BlockedBy[0] != "1" {
+		t.Errorf("Expected task 2 to be blocked by 1 again, got %v", task2.BlockedBy)
+	}
+	items, _ = store.ListTrash()
+	if len(items) != 0 {
+		t.Errorf("Expected empty trash after restore, got %d", len(items))
+	}
+}
+
+func TestRestoreFromTrashIDConflict(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewTaskStoreForTest(tmpDir, []Task{
+		{ID: "1", Subject: "Old", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	store.DeleteTask("1")
+
+	// A new task takes the freed ID
+	store.Tasks = append(store.Tasks, Task{ID: "1", Subject: "New", Status: "pending", Blocks: []string{}, BlockedBy: []string{}})
+	store.Save()
+
+	items, _ := store.ListTrash()
+	id, err := store.RestoreFromTrash(items[0])
+	if err != nil {
+		t.Fatalf("RestoreFromTrash failed: %v", err)
+	}
+	if id != "2" {
+		t.Errorf("Expected restored task to get ID '2', got '%s'", id)
+	}
+	if task := store.GetTask("2"); task == nil || task.Subject != "Old" {
+		t.Errorf("Expected task 2 to be the restored task, got %+v", task)
+	}
+}
+
+func TestRestoreUUIDTaskFromTrash(t *testing.T) {
+	const id = "0b9d4a6e-3f1c-4e2a-9c8b-5d7e6f1a2b3c"
+	store, err := NewTaskStoreForTest(t.TempDir(), []Task{
+		{ID: id, Subject: "Old", Status: "pending", Blocks: []string{}, BlockedBy: []string{}, Metadata: map[string]interface{}{"alias": "1"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	store.DeleteTask(id)
+
+	// A new task takes the alias's number
+	store.Tasks = append(store.Tasks, Task{ID: "1", Subject: "New", Status: "pending", Blocks: []string{}, BlockedBy: []string{}})
+	store.Save()
+
+	items, _ := store.ListTrash()
+	restored, err := store.RestoreFromTrash(items[0])
+	if err != nil {
+		t.Fatalf("RestoreFromTrash failed: %v", err)
+	}
+	if restored != id {
+		t.Errorf("Expected the UUID %s kept, got %s", id, restored)
+	}
+	if alias := GetTaskAlias(*store.GetTask(id)); alias != "2" {
+		t.Errorf("Expected the restored task to get alias 2, got %q", alias)
+	}
+	if GetTaskAlias(items[0].Task) != "1" {
+		t.Error("Expected the trash item left unchanged")
+	}
+
+	// A taken UUID is replaced by a new one, never by a number
+	store.DeleteTask(id)
+	store.Tasks = append(store.Tasks, Task{ID: id, Subject: "Same UUID", Status: "pending", Blocks: []string{}, BlockedBy: []string{}, Metadata: map[string]interface{}{"alias": "3"}})
+	store.Save()
+	items, _ = store.ListTrash()
+	restored, err = store.RestoreFromTrash(items[0])
+	if err != nil {
+		t.Fatalf("RestoreFromTrash failed: %v", err)
+	}
+	if restored == id || !IsUUID(restored) {
+		t.Errorf("Expected a new UUID, got %s", restored)
+	}
+	if alias := GetTaskAlias(*store.GetTask(restored)); alias != "2" {
+		t.Errorf("Expected the restored task to keep alias 2, got %q", alias)
+	}
+}
+
+func TestPurgeFromTrash(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	store, err := NewTaskStoreForTest(tmpDir, []Task{})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	trashDir := filepath.Join(tmpDir, TrashDirName)
+	os.MkdirAll(trashDir, 0755)
+	old := time.Now().Add(-90 * 24 * time.Hour).Format(trashTimeFormat)
+	recent := time.Now().Format(trashTimeFormat)
+	os.WriteFile(filepath.Join(trashDir, "1."+old+".json"), []byte(`{"id":"1","subject":"Old","status":"pending"}`), 0644)
+	os.WriteFile(filepath.Join(trashDir, "2."+recent+".json"), []byte(`{"id":"2","subject":"Recent","status":"pending"}`), 0644)
+
+	// Default retention is 30 days
+	store.PurgeExpiredTrash()
+	items, _ := store.ListTrash()
+	if len(items) != 1 || items[0].Task.ID != "2" {
+		t.Fatalf("Expected only task 2 to remain, got %+v", items)
+	}
+
+	if err := store.PurgeFromTrash(items[0]); err != nil {
+		t.Fatalf("PurgeFromTrash failed: %v", err)
+	}
+	items, _ = store.ListTrash()
+	if len(items) != 0 {
+		t.Errorf("Expected empty trash, got %d", len(items))
+	}
+}
+
+func TestMoveToTrashSameSecond(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewTaskStoreForTest(tmpDir, []Task{
+		{ID: "1", Subject: "First", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	// Deleted, re-created and deleted again within one second
+	now := time.Now()
+	if err := store.moveToTrash(store.Tasks[0], now); err != nil {
+		t.Fatalf("moveToTrash failed: %v", err)
+	}
+	os.WriteFile(filepath.Join(tmpDir, "1.json"), []byte(`{"id":"1","subject":"Second","status":"pending"}`), 0644)
+	if err := store.moveToTrash(Task{ID: "1"}, now); err != nil {
+		t.Fatalf("moveToTrash failed: %v", err)
+	}
+
+	items, _ := store.ListTrash()
+	if len(items) != 2 {
+		t.Fatalf("Expected both deletions in the trash, got %+v", items)
+	}
+	for _, item := range items {
+		if !item.DeletedAt.Equal(now.Truncate(time.Second)) {
+			t.Errorf("Expected %s deleted at %v, got %v", item.File, now, item.DeletedAt)
+		}
+	}
+}
+
+func TestSavePurgesExpiredTrash(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewTaskStoreForTest(tmpDir, []Task{
+		{ID: "1", Subject: "Task 1", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	trashDir := filepath.Join(tmpDir, TrashDirName)
+	os.MkdirAll(trashDir, 0755)
+	old := time.Now().Add(-90 * 24 * time.Hour).Format(trashTimeFormat)
+	os.WriteFile(filepath.Join(trashDir, "2."+old+".json"), []byte(`{"id":"2","subject":"Old","status":"pending"}`), 0644)
+
+	store.Tasks[0].Status = "completed"
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if items, _ := store.ListTrash(); len(items) != 0 {
+		t.Errorf("Expected the expired task purged on save, got %+v", items)
+	}
+}
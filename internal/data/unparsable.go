@@ -0,0 +1,101 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// UnparsableFile is a task file that could not be read as a task on load,
+// so its task is missing from the list
+type UnparsableFile struct {
+	File  string // file name in the project directory
+	Error string // why the file could not be parsed
+	Hash  string // content hash, telling whether an ignored file changed since
+}
+
+// contentHash returns the hex SHA-256 of a file's content
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// UnparsableFiles returns the task files that could not be parsed on load,
+// leaving out those ignored: ignored maps file names to the content hash
+// they were ignored with, so a file that changed since is listed again
+func (s *TaskStore) UnparsableFiles(ignored map[string]string) []UnparsableFile {
+	var files []UnparsableFile
+	for _, f := range s.Unparsable {
+		if hash, ok := ignored[f.File]; ok && hash == f.Hash {
+			continue
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+// FilePath returns the path of a file in the project directory
+func (s *TaskStore) FilePath(name string) (string, error) {
+	projectDir, err := s.dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(projectDir, name), nil
+}
+
+// RestoreFromBackup replaces an unparsable task file with its newest copy in
+// the project's backup snapshots that parses as a task, and returns the
+// snapshot it came from. The store must be reloaded to show the task.
+func (s *TaskStore) RestoreFromBackup(name string) (Snapshot, error) {
+	if err := checkProjectWritable(s.ProjectName); err != nil {
+		return Snapshot{}, err
+	}
+	projectDir, err := s.dir()
+	if err != nil {
+		return Snapshot{}, err
+	}
+	backupDir, err := config.GetBackupProjectDir(s.ProjectName)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return restoreFileFrom(backupDir, projectDir, name)
+}
+
+// parsesAs reports whether content can be read as the named file: the
+// tasks.json of the single-file layout or a task file
+func parsesAs(name string, content []byte) bool {
+	if name == LegacyTasksFile {
+		_, _, _, err := parseSingleFile(content)
+		return err == nil
+	}
+	var task Task
+	return json.Unmarshal(content, &task) == nil
+}
+
+// restoreFileFrom copies the newest parsable copy of a file in the snapshots
+// of backupDir back into projectDir
+func restoreFileFrom(backupDir, projectDir, name string) (Snapshot, error) {
+	snapshots, err := listSnapshotsIn(backupDir)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	for _, snapshot := range snapshots {
+		content, err := os.ReadFile(filepath.Join(snapshot.Path, name))
+		if err != nil {
+			continue
+		}
+		if !parsesAs(name, content) {
+			continue // a broken copy, backed up by an earlier save
+		}
+		if err := os.WriteFile(filepath.Join(projectDir, name), content, 0644); err != nil {
+			return Snapshot{}, err
+		}
+		return snapshot, nil
+	}
+	return Snapshot{}, fmt.Errorf("no backup of %s can be parsed", name)
+}
@@ -0,0 +1,70 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestUnparsableFiles(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+
+	projectDir := filepath.Join(tasksDir, "proj")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	good := `{"id":"1","subject":"Ship","status":"pending"}`
+	os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(good), 0644)
+	os.WriteFile(filepath.Join(projectDir, "2.json"), []byte(`{"id":"2","subject":"Test","status":"pending"}`), 0644)
+
+	// An earlier backup of the valid files
+	backupDir, _ := config.GetBackupProjectDir("proj")
+	if err := snapshotDir(projectDir, backupDir, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(`{"id":"1","subj`), 0644)
+	store, err := LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Tasks) != 1 || len(store.Unparsable) != 1 {
+		t.Fatalf("tasks = %d, unparsable = %+v, want 1 each", len(store.Tasks), store.Unparsable)
+	}
+	broken := store.Unparsable[0]
+	if broken.File != "1.json" || broken.Error == "" || broken.Hash == "" {
+		t.Errorf("unparsable file = %+v", broken)
+	}
+
+	// Ignoring hides the file until its content changes
+	ignored := map[string]string{"1.json": broken.Hash}
+	if got := store.UnparsableFiles(ignored); len(got) != 0 {
+		t.Errorf("UnparsableFiles(ignored) = %+v, want none", got)
+	}
+	if got := store.UnparsableFiles(map[string]string{"1.json": "stale"}); len(got) != 1 {
+		t.Errorf("UnparsableFiles(changed since ignored) = %+v, want the file", got)
+	}
+
+	// Restoring skips the backup of the broken copy taken on load
+	if _, err := store.RestoreFromBackup("1.json"); err != nil {
+		t.Fatalf("RestoreFromBackup failed: %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(projectDir, "1.json")); string(content) != good {
+		t.Errorf("restored content = %s, want %s", content, good)
+	}
+	store, err = LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Tasks) != 2 || len(store.Unparsable) != 0 {
+		t.Errorf("after restore: tasks = %d, unparsable = %d", len(store.Tasks), len(store.Unparsable))
+	}
+
+	if _, err := store.RestoreFromBackup("9.json"); err == nil {
+		t.Error("RestoreFromBackup should fail without a backup of the file")
+	}
+}
@@ -0,0 +1,218 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// Problem describes a schema violation found in a project file
+type Problem struct {
+	File    string // file name relative to the project directory
+	Message string
+}
+
+// String formats the problem as "file: message"
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.File, p.Message)
+}
+
+// ValidStatuses lists the task statuses accepted by the validator
+var ValidStatuses = []string{"pending", "in_progress", "completed"}
+
+// IsValidStatus reports whether status is one of ValidStatuses, or
+// needs_review when the review workflow is on
+func IsValidStatus(status string) bool {
+	for _, s := range Statuses() {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTaskData checks raw task file contents against the task schema
+func ValidateTaskData(filename string, data []byte) []Problem {
+	var problems []Problem
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, Problem{File: filename, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		add("invalid JSON: %v", err)
+		return problems
+	}
+
+	// Required string fields
+	strField := func(key string, required bool) (string, bool) {
+		value, ok := raw[key]
+		if !ok {
+			if required {
+				add("missing required field %q", key)
+			}
+			return "", false
+		}
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			add("field %q must be a string", key)
+			return "", false
+		}
+		return s, true
+	}
+
+	if id, ok := strField("id", true); ok {
+		if !isValidTaskID(id) {
+			add("invalid ID format %q (expected a positive integer or UUID)", id)
+		}
+		if expected := strings.TrimSuffix(filename, ".json"); id != expected {
+			add("ID %q does not match file name", id)
+		}
+	}
+	if subject, ok := strField("subject", true); ok && strings.TrimSpace(subject) == "" {
+		add("subject is empty")
+	}
+	if status, ok := strField("status", true); ok && !IsValidStatus(status) {
+		add("invalid status %q (expected one of %s)", status, strings.Join(Statuses(), ", "))
+	}
+	strField("description", false)
+	strField("activeForm", false)
+	strField("owner", false)
+
+	// Dependency arrays
+	for _, key := range []string{"blocks", "blockedBy"} {
+		value, ok := raw[key]
+		if !ok {
+			continue
+		}
+		var ids []string
+		if err := json.Unmarshal(value, &ids); err != nil {
+			add("field %q must be an array of strings", key)
+		}
+	}
+
+	if value, ok := raw["metadata"]; ok {
+		var metadata map[string]interface{}
+		if err := json.Unmarshal(value, &metadata); err != nil {
+			add("field \"metadata\" must be an object")
+		}
+	}
+
+	return problems
+}
+
+// ValidateReferences checks dependency references between loaded tasks
+func ValidateReferences(tasks []Task) []Problem {
+	var problems []Problem
+
+	ids := make(map[string]int)
+	for _, task := range tasks {
+		ids[task.ID]++
+	}
+
+	for _, task := range tasks {
+		filename := task.ID + ".json"
+		if ids[task.ID] > 1 {
+			problems = append(problems, Problem{File: filename, Message: fmt.Sprintf("duplicate task ID %q", task.ID)})
+		}
+		check := func(field string, refs []string) {
+			for _, ref := range refs {
+				if ref == task.ID {
+					problems = append(problems, Problem{File: filename, Message: fmt.Sprintf("%s references itself", field)})
+				} else if ids[ref] == 0 {
+					problems = append(problems, Problem{File: filename, Message: fmt.Sprintf("%s references unknown task %q", field, ref)})
+				}
+			}
+		}
+		check("blocks", task.Blocks)
+		check("blockedBy", task.BlockedBy)
+	}
+
+	return problems
+}
+
+// ValidateProject validates every task file in a project directory
+func ValidateProject(projectName string) ([]Problem, error) {
+	projectDir, err := config.GetProjectDir(projectName)
+	if err != nil {
+		return nil, err
+	}
+	return validateDir(projectDir)
+}
+
+// validateDir validates the task files in dir, including dependency references
+func validateDir(dir string) ([]Problem, error) {
+	if DetectLayout(dir) == LayoutSingle {
+		return validateSingleFile(dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var key []byte
+	if IsEncrypted(dir) {
+		if key, err = projectKey(dir); err != nil {
+			return []Problem{{File: EncryptionFileName, Message: err.Error()}}, nil
+		}
+	}
+
+	var problems []Problem
+	var tasks []Task
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if strings.HasPrefix(name, "_") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			data, err = openTaskData(key, data)
+		}
+		if err != nil {
+			problems = append(problems, Problem{File: name, Message: err.Error()})
+			continue
+		}
+
+		fileProblems := ValidateTaskData(name, data)
+		problems = append(problems, fileProblems...)
+
+		var task Task
+		if err := json.Unmarshal(data, &task); err == nil {
+			tasks = append(tasks, task)
+		}
+	}
+
+	problems = append(problems, ValidateReferences(tasks)...)
+	sortProblems(problems)
+	return problems, nil
+}
+
+// sortProblems orders problems by file name (numerically for task files)
+func sortProblems(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		idI, errI := strconv.Atoi(strings.TrimSuffix(problems[i].File, ".json"))
+		idJ, errJ := strconv.Atoi(strings.TrimSuffix(problems[j].File, ".json"))
+		if errI == nil && errJ == nil {
+			return idI < idJ
+		}
+		return problems[i].File < problems[j].File
+	})
+}
+
+// isValidTaskID reports whether id is a positive integer or a UUID
+func isValidTaskID(id string) bool {
+	if IsUUID(id) {
+		return true
+	}
+	n, err := strconv.Atoi(id)
+	return err == nil && n > 0 && strconv.Itoa(n) == id
+}
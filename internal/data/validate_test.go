@@ -0,0 +1,82 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateTaskData(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		input    string
+		expected []string // substrings expected in problem messages
+	}{
+		{"valid", "1.json", `{"id":"1","subject":"Task","status":"pending","blocks":[],"blockedBy":[]}`, nil},
+		{"invalid JSON", "1.json", `{"id":`, []string{"invalid JSON"}},
+		{"missing fields", "1.json", `{"id":"1"}`, []string{`"subject"`, `"status"`}},
+		{"bad status", "1.json", `{"id":"1","subject":"Task","status":"done"}`, []string{"invalid status"}},
+		{"bad ID", "abc.json", `{"id":"abc","subject":"Task","status":"pending"}`, []string{"invalid ID format"}},
+		{"ID mismatch", "2.json", `{"id":"1","subject":"Task","status":"pending"}`, []string{"does not match"}},
+		{"bad blocks", "1.json", `{"id":"1","subject":"Task","status":"pending","blocks":[1]}`, []string{`"blocks"`}},
+		{"empty subject", "1.json", `{"id":"1","subject":"  ","status":"pending"}`, []string{"subject is empty"}},
+	}
+
+	for _, tt := range tests {
+		problems := ValidateTaskData(tt.file, []byte(tt.input))
+		if len(problems) != len(tt.expected) {
+			t.Errorf("%s: expected %d problems, got %d: %v", tt.name, len(tt.expected), len(problems), problems)
+			continue
+		}
+		for i, substr := range tt.expected {
+			if !strings.Contains(problems[i].Message, substr) {
+				t.Errorf("%s: expected problem %d to contain %q, got %q", tt.name, i, substr, problems[i].Message)
+			}
+		}
+	}
+}
+
+func TestValidateReferences(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", Blocks: []string{"2"}, BlockedBy: []string{}},
+		{ID: "2", Blocks: []string{"2"}, BlockedBy: []string{"1", "99"}},
+	}
+
+	problems := ValidateReferences(tasks)
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %d: %v", len(problems), problems)
+	}
+	if !strings.Contains(problems[0].Message, "itself") {
+		t.Errorf("Expected self-reference problem, got %q", problems[0].Message)
+	}
+	if !strings.Contains(problems[1].Message, `unknown task "99"`) {
+		t.Errorf("Expected unknown reference problem, got %q", problems[1].Message)
+	}
+}
+
+func TestValidateDir(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	os.WriteFile(filepath.Join(tmpDir, "1.json"), []byte(`{"id":"1","subject":"A","status":"pending","blocks":[],"blockedBy":[]}`), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "10.json"), []byte(`not json`), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "2.json"), []byte(`{"id":"2","subject":"B","status":"wip","blocks":[],"blockedBy":[]}`), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "_groups.json"), []byte(`not json either`), 0644)
+
+	problems, err := validateDir(tmpDir)
+	if err != nil {
+		t.Fatalf("validateDir failed: %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %d: %v", len(problems), problems)
+	}
+	// Sorted numerically by file name
+	if problems[0].File != "2.json" || problems[1].File != "10.json" {
+		t.Errorf("Expected problems for 2.json then 10.json, got %s, %s", problems[0].File, problems[1].File)
+	}
+}
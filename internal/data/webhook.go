@@ -0,0 +1,201 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// Webhook event names (also used in the "events" filter of the config)
+const (
+	WebhookCreated   = "created"
+	WebhookCompleted = "completed"
+	WebhookBlocked   = "blocked"
+	WebhookAssigned  = "assigned" // only sent where the events filter lists it
+)
+
+// WebhookEvent is the JSON payload POSTed to webhook URLs. Text and Content
+// carry a one-line summary for Slack and Discord incoming webhooks.
+type WebhookEvent struct {
+	Event    string      `json:"event"`
+	Project  string      `json:"project"`
+	Task     WebhookTask `json:"task"`
+	External bool        `json:"external"` // changed outside cctasks (e.g. by Claude Code)
+	Time     time.Time   `json:"time"`
+	Text     string      `json:"text"`
+	Content  string      `json:"content"`
+
+	PreviousOwner string `json:"previousOwner,omitempty"` // assigned events
+}
+
+// WebhookTask is the task summary in a webhook payload
+type WebhookTask struct {
+	ID        string   `json:"id"`
+	DisplayID string   `json:"displayId"`
+	Subject   string   `json:"subject"`
+	Status    string   `json:"status"`
+	Owner     string   `json:"owner,omitempty"`
+	BlockedBy []string `json:"blockedBy,omitempty"` // open blockers
+}
+
+// webhookClient sends webhook requests; the timeout keeps a dead endpoint from piling up goroutines
+var webhookClient = &http.Client{Timeout: 5 * time.Second}
+
+// pendingWebhooks tracks requests still in flight
+var pendingWebhooks sync.WaitGroup
+
+// WebhookEvents returns the webhook events for changes from old to new:
+// tasks that were created, completed, assigned to another owner, or became
+// blocked by an open task
+func WebhookEvents(project string, old, new []Task, changes []Change, external bool, now time.Time) []WebhookEvent {
+	oldBlockers := openBlockers(old)
+	newBlockers := openBlockers(new)
+	byID := make(map[string]Task, len(new))
+	for _, task := range new {
+		byID[task.ID] = task
+	}
+	oldByID := make(map[string]Task, len(old))
+	for _, task := range old {
+		oldByID[task.ID] = task
+	}
+
+	var events []WebhookEvent
+	add := func(event string, task Task, prevOwner string) {
+		wt := WebhookTask{
+			ID:        task.ID,
+			DisplayID: DisplayID(task),
+			Subject:   task.Subject,
+			Status:    task.Status,
+			Owner:     task.Owner,
+		}
+		var refs []string
+		for _, t := range new {
+			if newBlockers[task.ID][t.ID] {
+				wt.BlockedBy = append(wt.BlockedBy, t.ID)
+				refs = append(refs, "#"+DisplayID(t))
+			}
+		}
+		text := fmt.Sprintf("[%s] %s #%s: %s", project, event, wt.DisplayID, task.Subject)
+		switch event {
+		case WebhookBlocked:
+			text += " (blocked by " + strings.Join(refs, ", ") + ")"
+		case WebhookAssigned:
+			text += " (" + ownerOrUnassigned(prevOwner) + " → " + ownerOrUnassigned(task.Owner) + ")"
+		}
+		events = append(events, WebhookEvent{
+			Event: event, Project: project, Task: wt, External: external, Time: now,
+			Text: text, Content: text, PreviousOwner: prevOwner,
+		})
+	}
+	for _, c := range changes {
+		task, ok := byID[c.TaskID]
+		if !ok {
+			continue
+		}
+		prev, existed := oldByID[task.ID]
+		switch {
+		case c.Kind == ChangeCreated:
+			add(WebhookCreated, task, "")
+		case c.Kind == ChangeStatus && c.To == "completed":
+			add(WebhookCompleted, task, "")
+		}
+		if existed && prev.Owner != task.Owner {
+			add(WebhookAssigned, task, prev.Owner)
+		}
+		if existed && len(oldBlockers[task.ID]) == 0 && len(newBlockers[task.ID]) > 0 {
+			add(WebhookBlocked, task, "")
+		}
+	}
+	return events
+}
+
+// ownerOrUnassigned returns the owner, or "unassigned" when there is none
+func ownerOrUnassigned(owner string) string {
+	if owner == "" {
+		return "unassigned"
+	}
+	return owner
+}
+
+// notifyWebhooks POSTs the events of the changes to the configured webhooks
+// in the background (errors are only logged; webhooks are best-effort)
+func (s *TaskStore) notifyWebhooks(old []Task, changes []Change, external bool) {
+	hooks := config.Current().Webhooks
+	if len(hooks) == 0 || len(changes) == 0 || s.ProjectName == "" {
+		return
+	}
+	events := WebhookEvents(s.ProjectName, old, s.Tasks, changes, external, time.Now())
+	for _, hook := range hooks {
+		if hook.URL == "" || !matchesFilter(hook.Projects, s.ProjectName) {
+			continue
+		}
+		for _, event := range events {
+			if !matchesEvent(hook.Events, event.Event) {
+				continue
+			}
+			body, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			pendingWebhooks.Add(1)
+			go postWebhook(hook.URL, body)
+		}
+	}
+}
+
+// postWebhook sends one webhook request
+func postWebhook(url string, body []byte) {
+	defer pendingWebhooks.Done()
+	resp, err := webhookClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		slog.Debug("webhook failed", "url", url, "err", err)
+		return
+	}
+	resp.Body.Close()
+	slog.Debug("webhook sent", "url", url, "status", resp.StatusCode)
+}
+
+// WaitWebhooks waits up to timeout for webhook requests and hook commands
+// still running, so notifications are not lost when cctasks exits right
+// after a save
+func WaitWebhooks(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		pendingWebhooks.Wait()
+		pendingHooks.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
+
+// matchesFilter reports whether value is in filter (an empty filter matches everything)
+func matchesFilter(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if f == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesEvent reports whether an events filter selects event; assigned
+// events are opt-in, so existing hooks are not flooded by reassignments
+func matchesEvent(filter []string, event string) bool {
+	if event == WebhookAssigned {
+		return containsString(filter, event)
+	}
+	return matchesFilter(filter, event)
+}
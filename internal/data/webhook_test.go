@@ -0,0 +1,110 @@
+package data
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+func TestWebhookEvents(t *testing.T) {
+	old := []Task{
+		{ID: "1", Subject: "Design", Status: "in_progress"},
+		{ID: "2", Subject: "Build", Status: "pending"},
+		{ID: "3", Subject: "Test", Status: "pending"},
+	}
+	new := cloneTasks(old)
+	new[0].Status = "completed"
+	new[2].BlockedBy = []string{"2"}
+	new = append(new, Task{ID: "4", Subject: "Ship", Status: "pending", BlockedBy: []string{"3"}})
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := WebhookEvents("web", old, new, DiffTasks(old, new), true, now)
+	got := make([]string, len(events))
+	for i, e := range events {
+		got[i] = e.Event + " " + e.Task.ID
+	}
+	want := []string{"completed 1", "blocked 3", "created 4"}
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("events = %v, want %v", got, want)
+			break
+		}
+	}
+	if e := events[1]; e.Text != "[web] blocked #3: Test (blocked by #2)" || e.Content != e.Text || !e.External {
+		t.Errorf("blocked event = %+v", e)
+	}
+}
+
+func TestAssignedWebhookEvent(t *testing.T) {
+	old := []Task{{ID: "1", Subject: "Design", Status: "pending", Owner: "alice"}}
+	new := cloneTasks(old)
+	new[0].Owner = ""
+
+	events := WebhookEvents("web", old, new, DiffTasks(old, new), false, time.Now())
+	if len(events) != 1 {
+		t.Fatalf("events = %+v, want one assigned event", events)
+	}
+	if e := events[0]; e.Event != WebhookAssigned || e.PreviousOwner != "alice" || e.Text != "[web] assigned #1: Design (alice → unassigned)" {
+		t.Errorf("assigned event = %+v", e)
+	}
+
+	if matchesEvent(nil, WebhookAssigned) || !matchesEvent([]string{WebhookAssigned}, WebhookAssigned) {
+		t.Error("assigned events should only match filters listing them")
+	}
+	if !matchesEvent(nil, WebhookCompleted) {
+		t.Error("an empty filter should match the other events")
+	}
+}
+
+func TestSaveNotifiesWebhooks(t *testing.T) {
+	var mu sync.Mutex
+	var received []WebhookEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e WebhookEvent
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Errorf("bad payload: %v", err)
+		}
+		mu.Lock()
+		received = append(received, e)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	cfg := config.Default()
+	cfg.Webhooks = []config.WebhookConfig{
+		{URL: srv.URL, Events: []string{WebhookCompleted}},
+		{URL: srv.URL, Projects: []string{"other"}},
+	}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	store, err := NewTaskStoreForTest(t.TempDir(), []Task{
+		{ID: "1", Subject: "Task 1", Status: "pending"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Tasks[0].Status = "completed"
+	store.AddTask(Task{Subject: "Task 2", Status: "pending"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	WaitWebhooks(5 * time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 {
+		t.Fatalf("received %d webhooks, want 1 (filtered by event and project): %+v", len(received), received)
+	}
+	if e := received[0]; e.Event != WebhookCompleted || e.Project != "test" || e.Task.ID != "1" || e.External {
+		t.Errorf("payload = %+v", e)
+	}
+}
@@ -0,0 +1,81 @@
+// Package i18n translates UI strings. English strings in the source are the
+// message IDs (the "en" catalog); other languages map them to translations,
+// falling back to English for missing entries.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Supported languages
+const (
+	English  = "en"
+	Japanese = "ja"
+)
+
+// catalogs maps languages to their translations of English messages
+var catalogs = map[string]map[string]string{
+	English:  {},
+	Japanese: ja,
+}
+
+// current is the language UI strings are translated to
+var current = English
+
+// Languages returns the supported language codes
+func Languages() []string {
+	return []string{English, Japanese}
+}
+
+// SetLanguage selects the UI language: a code from Languages, or "" (or
+// "auto") to detect it from the environment
+func SetLanguage(lang string) error {
+	if lang == "" || lang == "auto" {
+		lang = Detect()
+	}
+	if _, ok := catalogs[lang]; !ok {
+		return fmt.Errorf("unsupported language %q (expected %s)", lang, strings.Join(Languages(), ", "))
+	}
+	current = lang
+	return nil
+}
+
+// Language returns the current UI language
+func Language() string {
+	return current
+}
+
+// Detect returns the language of the user's locale ($LC_ALL, $LC_MESSAGES,
+// then $LANG), English when it is not supported
+func Detect() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(env)
+		if locale == "" {
+			continue
+		}
+		lang := strings.ToLower(locale)
+		if i := strings.IndexAny(lang, "_.-@"); i >= 0 {
+			lang = lang[:i]
+		}
+		if _, ok := catalogs[lang]; ok {
+			return lang
+		}
+		return English // the first locale variable set wins
+	}
+	return English
+}
+
+// T translates a message
+func T(msg string) string {
+	if s, ok := catalogs[current][msg]; ok {
+		return s
+	}
+	return msg
+}
+
+// Tf translates a format string and formats it
+func Tf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+)
+
+func withLanguage(t *testing.T, lang string) {
+	t.Helper()
+	prev := current
+	t.Cleanup(func() { current = prev })
+	if err := SetLanguage(lang); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	withLanguage(t, English)
+	if got := T("Quit"); got != "Quit" {
+		t.Errorf("en T(Quit) = %q", got)
+	}
+
+	withLanguage(t, Japanese)
+	if got := T("Quit"); got != "終了" {
+		t.Errorf("ja T(Quit) = %q", got)
+	}
+	if got := Tf("%d days left", 3); got != "残り 3 日" {
+		t.Errorf("ja Tf = %q", got)
+	}
+	if got := T("no translation for this"); got != "no translation for this" {
+		t.Errorf("missing entry should fall back to English, got %q", got)
+	}
+}
+
+func TestSetLanguageUnsupported(t *testing.T) {
+	withLanguage(t, English)
+	if err := SetLanguage("fr"); err == nil {
+		t.Error("expected error for unsupported language")
+	}
+	if Language() != English {
+		t.Errorf("language changed to %q after error", Language())
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		lcAll, lcMessages, lang string
+		want                    string
+	}{
+		{"", "", "", English},
+		{"", "", "ja_JP.UTF-8", Japanese},
+		{"", "", "en_US.UTF-8", English},
+		{"", "ja_JP", "en_US.UTF-8", Japanese},
+		{"C", "", "ja_JP.UTF-8", English},
+		{"", "", "fr_FR.UTF-8", English},
+	}
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.lcAll)
+		t.Setenv("LC_MESSAGES", tt.lcMessages)
+		t.Setenv("LANG", tt.lang)
+		if got := Detect(); got != tt.want {
+			t.Errorf("Detect(LC_ALL=%q LC_MESSAGES=%q LANG=%q) = %q, want %q",
+				tt.lcAll, tt.lcMessages, tt.lang, got, tt.want)
+		}
+	}
+}
+
+var (
+	verbPattern     = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+	argIndexPattern = regexp.MustCompile(`\[\d+\]`)
+)
+
+// verbs returns the formatting verbs of s, ignoring explicit argument indexes
+// so translations may reorder arguments
+func verbs(s string) []string {
+	var out []string
+	for _, v := range verbPattern.FindAllString(s, -1) {
+		out = append(out, argIndexPattern.ReplaceAllString(v, ""))
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestCatalogsKeepFormatVerbs(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for msg, translated := range catalog {
+			want, got := verbs(msg), verbs(translated)
+			if len(want) != len(got) {
+				t.Errorf("%s: %q has verbs %v, translation %q has %v", lang, msg, want, translated, got)
+				continue
+			}
+			for i := range want {
+				if want[i] != got[i] {
+					t.Errorf("%s: %q has verbs %v, translation %q has %v", lang, msg, want, translated, got)
+					break
+				}
+			}
+		}
+	}
+}
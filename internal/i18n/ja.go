@@ -0,0 +1,432 @@
+package i18n
+
+// ja is the Japanese catalog
+var ja = map[string]string{
+	"%d archived (A to show)":      "アーカイブ %d 件（A で表示）",
+	"%d awaiting review":           "レビュー待ち %d",
+	"%d completed":                 "完了 %d",
+	"%d days left":                 "残り %d 日",
+	"%d days overdue":              "%d 日超過",
+	"%d deleted task(s)":           "削除済みタスク %d 件",
+	"%d in progress":               "作業中 %d",
+	"%d inactive hidden":           "非アクティブ %d 件を非表示",
+	"%d lines above":               "上にあと %d 行",
+	"%d lines below":               "下にあと %d 行",
+	"%d more above":                "上にあと %d 件",
+	"%d more below":                "下にあと %d 件",
+	"%d more lines":                "ほか %d 行",
+	"%d of %d task(s) will change": "%d / %d 件のタスクが変更されます",
+	"%d of the selected tasks changed on disk; saving applies the edit to their latest version": "選択したタスクのうち %d 件がディスク上で変更されました。保存すると最新の内容に対して変更を適用します",
+	"%d open (%d in progress) / %d done":                              "未完了 %d (進行中 %d) / 完了 %d",
+	"%d open / %d done":                                               "未完了 %d / 完了 %d",
+	"%d pending":                                                      "未着手 %d",
+	"%d problem(s) found in task files":                               "タスクファイルに %d 件の問題があります",
+	"%d problem(s) found with the tasks directories":                  "タスクディレクトリに %d 件の問題があります",
+	"%d problem(s) in task files":                                     "タスクファイルに %d 件の問題",
+	"%d task file(s) could not be parsed":                             "%d 件のタスクファイルを解析できませんでした",
+	"%d task file(s) could not be parsed; their tasks are not listed": "%d 件のタスクファイルを解析できませんでした。これらのタスクは一覧に表示されません",
+	"%d task(s) in %d project(s)":                                     "%d 件のタスク（%d プロジェクト）",
+	"%d task(s) match the current filter":                             "現在のフィルタに一致するタスク: %d 件",
+	"%d task(s) without dates":                                        "日付なしのタスク %d 件",
+	"%d total":                                                        "合計 %d",
+	"%dd ago":                                                         "%d 日前",
+	"%dh ago":                                                         "%d 時間前",
+	"%dm ago":                                                         "%d 分前",
+	"%s assigned #%s %s":                                              "%s が #%s の担当者を変更（%s）",
+	"%s by %s on %s":                                                  "%[2]s が %[3]s に%[1]s",
+	"%s completed #%s %s":                                             "%s が #%s を完了（%s）",
+	"%s created #%s %s":                                               "%s が #%s を作成（%s）",
+	"%s deleted #%s %s":                                               "%s が #%s を削除（%s）",
+	"%s edited #%s %s":                                                "%s が #%s を編集（%s）",
+	"%s of %s":                                                        "%s / %s",
+	"%s owner overlap  %s starts before a blocker ends  %s today": "%s 担当者の重複  %s ブロック元の終了前に開始  %s 今日",
+	"%s priority":                  "優先度 %s",
+	"%s started #%s %s":            "%s が #%s に着手（%s）",
+	"%s → today   %d → %d open":    "%s → 今日   未完了 %d → %d",
+	"(+%d active)":                 "（ほか %d 人）",
+	"(cannot reference itself)":    "（自分自身は指定できません）",
+	"(creates a dependency cycle)": "（依存関係が循環します）",
+	"(empty clears the field)":     "（空欄でクリア）",
+	"(empty)":                      "（空）",
+	"(in %s)":                      "(%sで一致)",
+	"(must be a number)":           "（数値で入力してください）",
+	"(no archived projects)":       "（アーカイブ済みプロジェクトなし）",
+	"(no description)":             "（説明なし）",
+	"(no owners yet; list them in roster in the config)": "（担当者がいません。設定の roster で指定してください）",
+	"(no projects)":                "（プロジェクトなし）",
+	"(no tasks)":                   "（タスクなし）",
+	"(none)":                       "（なし）",
+	"(none, press / to add tasks)": "（なし。/ でタスクを追加）",
+	"(open)":                       "(表示中)",
+	"(required)":                   "（必須）",
+	"(s: cycle)":                   "（s: 切り替え）",
+	"(sync failed: %v)":            "(同期失敗: %v)",
+	"(tasks that wait for this)":   "（このタスクを待つタスク）",
+	"(tasks this waits for)":       "（このタスクが待つタスク）",
+	"(unknown task: %s)":           "（存在しないタスク: %s）",
+	"... and %d more":              "... ほか %d 件",
+	"1 day left":                   "残り 1 日",
+	"1 day overdue":                "1 日超過",
+	"1. Add the following to %s in your project:": "1. プロジェクトの %s に以下を追加:",
+	"2. Tasks are stored in %s":                   "2. タスクは %s に保存されます",
+	"[ / ] switch project":                        "[ / ] プロジェクト切替",
+	"[0] unassign  [Esc] cancel":                  "[0] 担当者なし  [Esc] キャンセル",
+	"[1-%d/Enter] open  [Esc] dismiss":            "[1-%d/Enter] 開く  [Esc] 閉じる",
+	"[Enter] confirm  [Esc] cancel":               "[Enter] 確定  [Esc] キャンセル",
+	"[Enter] open  [Esc] cancel":                  "[Enter] 開く  [Esc] キャンセル",
+	"[Enter] open  [Esc] dismiss":                 "[Enter] 開く  [Esc] 閉じる",
+	"[s] start  [Enter] view  [Esc] close":        "[s] 開始  [Enter] 表示  [Esc] 閉じる",
+	"[y] complete  [n] cancel":                    "[y] 完了  [n] キャンセル",
+	"Add":                                         "追加",
+	"Add Group":                                   "グループを追加",
+	"Add tag":                                     "タグを追加",
+	"Add the following to %s in your repository:": "リポジトリの %s に以下を追加してください:",
+	"agent":                     "エージェント",
+	"All":                       "すべて",
+	"all done":                  "完了済み",
+	"All Groups":                "すべてのグループ",
+	"All Projects":              "すべてのプロジェクト",
+	"All task files are valid.": "すべてのタスクファイルは正常です。",
+	"All tasks":                 "全タスク",
+	"Any time":                  "すべて",
+	"Apply":                     "適用",
+	"Apply Batch Edit":          "一括編集の適用",
+	"Approve":                   "承認",
+	"approved":                  "承認",
+	"Archive":                   "アーカイブ",
+	"Archived":                  "アーカイブ",
+	"Are you sure you want to delete group \"%s\"?": "グループ「%s」を削除しますか？",
+	"Assign":                                "担当",
+	"Assign #%s to:":                        "#%s の担当者:",
+	"At current pace (last %d days):":       "現在のペースでの完了予測（直近 %d 日間）:",
+	"Author":                                "作成者",
+	"Available: %s":                         "使用可能: %s",
+	"Back":                                  "戻る",
+	"Back to list":                          "一覧へ戻る",
+	"Batch Edit":                            "一括編集",
+	"blocked":                               "ブロック中",
+	"Blocked":                               "ブロック",
+	"Blocked By":                            "ブロック元",
+	"Blocked By:":                           "ブロック元:",
+	"blocked by: %s":                        "ブロック元: %s",
+	"blocked: %s":                           "ブロック中: %s",
+	"BlockedBy:":                            "ブロック元:",
+	"Blocks":                                "ブロック先",
+	"Blocks:":                               "ブロック先:",
+	"Burndown (open tasks, last %d days): ": "バーンダウン（未完了タスク、過去 %d 日）: ",
+	"Cancel":                                "キャンセル",
+	"cctasks quit unexpectedly at %s while a task was being edited.": "%s にタスクの編集中に cctasks が異常終了しました。",
+	"Change": "変更",
+	"Change status: [1/p] pending  [2/i] in_progress  [3/c] completed  [4/r] needs_review  [Esc] cancel": "ステータス変更: [1/p] 未着手  [2/i] 作業中  [3/c] 完了  [4/r] レビュー待ち  [Esc] キャンセル",
+	"Change status: [1/p] pending  [2/i] in_progress  [3/c] completed  [Esc] cancel":                     "ステータス変更: [1/p] 未着手  [2/i] 作業中  [3/c] 完了  [Esc] キャンセル",
+	"changed %s":                "更新 %s",
+	"Changed on disk: Task #%s": "ディスク上で変更: タスク #%s",
+	"Chart group":               "グラフのグループ",
+	"Check again":               "再チェック",
+	"Checklist":                 "チェックリスト",
+	"Checklist (%s):":           "チェックリスト (%s):",
+	"Checklist:":                "チェックリスト:",
+	"Choose":                    "選択",
+	"Choose Group":              "グループを選択",
+	"Claude Code Settings":      "Claude Code の設定",
+	"Clear filters":             "フィルタ解除",
+	"Close":                     "閉じる",
+	"Color":                     "色",
+	"Color:":                    "色:",
+	"Columns:":                  "列:",
+	"comfortable":               "ゆったり",
+	"compact":                   "コンパクト",
+	"Complete #%s with: [a] %d open it depends on  [d] %d open depending on it  [Esc] cancel": "#%s と一緒に完了: [a] 依存先の未完了 %d 件  [d] 依存元の未完了 %d 件  [Esc] キャンセル",
+	"Complete these %d task(s)?": "次の %d 件のタスクを完了しますか？",
+	"completed":                  "完了",
+	"Completed":                  "完了済み",
+	"Completions (last %d weeks): %d task(s)": "完了数（直近 %d 週）: %d 件",
+	"Confirm":                           "確認",
+	"Continue":                          "続行",
+	"Copied to clipboard":               "クリップボードにコピーしました",
+	"Copy":                              "コピー",
+	"Copy failed: %v":                   "コピーに失敗しました: %v",
+	"Could not fix %s: %v":              "%s を修復できませんでした: %v",
+	"Could not restore %s: %v":          "%s を復元できませんでした: %v",
+	"Create":                            "作成",
+	"Create new group \"%s\"":           "新しいグループ「%s」を作成",
+	"Create new group… (type its name)": "新しいグループを作成…（名前を入力）",
+	"Day":                               "日",
+	"Days":                              "日数",
+	"Debug Log":                         "デバッグログ",
+	"Debug logging is off. Start cctasks with --debug to record reloads, saves and key presses.": "デバッグログは無効です。再読み込み・保存・キー入力を記録するには --debug を付けて cctasks を起動してください。",
+	"Delete":           "削除",
+	"Delete forever":   "完全に削除",
+	"Delete Group":     "グループの削除",
+	"Delete Milestone": "マイルストーンの削除",
+	"Delete milestone \"%s\"? Its tasks will be unassigned.": "マイルストーン「%s」を削除しますか？ 所属タスクは未割り当てになります。",
+	"Delete Permanently":              "完全に削除",
+	"Delete Task":                     "タスクの削除",
+	"Density":                         "表示密度",
+	"Dependencies:":                   "依存関係:",
+	"Deps":                            "依存",
+	"Description":                     "説明",
+	"Description:":                    "説明:",
+	"Destination:":                    "出力先:",
+	"details":                         "詳細",
+	"Details: ":                       "詳細: ",
+	"Discard":                         "破棄",
+	"doing":                           "作業中",
+	"done":                            "完了",
+	"Done":                            "完了",
+	"Due":                             "期限",
+	"Due date":                        "期限",
+	"Edit":                            "編集",
+	"Edit Group":                      "グループの編集",
+	"Edit Milestone":                  "マイルストーンの編集",
+	"Edit Task":                       "タスクの編集",
+	"Edit Task #%s":                   "タスク #%s の編集",
+	"End date (YYYY-MM-DD, optional)": "終了日（YYYY-MM-DD、任意）",
+	"End:":                            "終了:",
+	"ends today":                      "今日が最終日",
+	"enter a destination path":        "出力先のパスを入力してください",
+	"Enter the Task List ID (project name). Tasks are stored in %s.": "Task List ID（プロジェクト名）を入力してください。タスクは %s に保存されます。",
+	"Error: %v":                          "エラー: %v",
+	"Estimate":                           "見積もり",
+	"Estimate (optional, e.g. 4 or 1.5)": "見積もり（任意、例: 4 や 1.5）",
+	"Estimate:":                          "見積もり:",
+	"Export":                             "エクスポート",
+	"Export Tasks":                       "タスクのエクスポート",
+	"Exported %d task(s) to %s":          "%d 件のタスクを %s に出力しました",
+	"Field:":                             "項目:",
+	"Filter":                             "フィルター",
+	"Filter tasks":                       "タスクを絞り込み",
+	"Filters":                            "フィルター",
+	"finishes in ~%d day(s) (%s) at %.1f/day": "あと約 %d 日で完了（%s、1 日 %.1f 件）",
+	"Fix": "修復",
+	"Fix the JSON in an editor, or delete the file. ! in the project's task list shows the details and can restore it from a backup.": "エディタで JSON を修正するか、ファイルを削除してください。プロジェクトのタスク一覧で ! を押すと詳細の確認とバックアップからの復元ができます。",
+	"Fixed %s":                     "%s を修復しました",
+	"Follow":                       "追従",
+	"following":                    "追従中",
+	"Format":                       "形式",
+	"Format:":                      "形式:",
+	"Git history is disabled.":     "git 履歴は無効です。",
+	"Group":                        "グループ",
+	"Group name":                   "グループ名",
+	"Group:":                       "グループ:",
+	"Groups":                       "グループ",
+	"Heatmap":                      "ヒートマップ",
+	"Help":                         "ヘルプ",
+	"Hide":                         "非表示",
+	"high":                         "高",
+	"History":                      "履歴",
+	"History: Task #%s":            "履歴: タスク #%s",
+	"human":                        "人",
+	"idle":                         "待機中",
+	"Ignore":                       "無視",
+	"in progress, last touched %s": "進行中のまま、最終更新 %s",
+	"in_progress":                  "作業中",
+	"Inactive filter":              "非アクティブ絞り込み",
+	"just now":                     "たった今",
+	"Keep editing (my save wins)":  "編集を続ける（自分の保存を優先）",
+	"Last %d days":                 "過去 %d 日",
+	"Last modified":                "最終更新",
+	"Less":                         "少",
+	"lines %d-%d of %d":            "%d-%d 行目 / %d 行",
+	"Loading history...":           "履歴を読み込み中...",
+	"Loading tasks...":             "タスクを読み込み中...",
+	"low":                          "低",
+	"Malformed task file":          "壊れたタスクファイル",
+	"Manual":                       "手動",
+	"medium":                       "中",
+	"Merge":                        "マージ",
+	"Merge #%s and #%s? The lower ID is kept.  [y] merge  [n] cancel": "#%s と #%s を統合しますか？ 小さい ID が残ります。  [y] 統合  [n] キャンセル",
+	"Merge #%s with: select a task and press [m/Enter], [Esc] cancel": "#%s の統合先: タスクを選んで [m/Enter]、[Esc] キャンセル",
+	"Milestone":                           "マイルストーン",
+	"Milestone name (e.g. Sprint 3)":      "マイルストーン名（例: Sprint 3）",
+	"Milestone:":                          "マイルストーン:",
+	"Milestones":                          "マイルストーン",
+	"Missing directory":                   "ディレクトリがありません",
+	"More":                                "多",
+	"Move task #%s to the trash?\n\"%s\"": "タスク #%s をゴミ箱に移動しますか？\n「%s」",
+	"Move the file away, or point tasksDir in the config file to the tasks directory.": "ファイルを移動するか、設定ファイルの tasksDir をタスクディレクトリに向けてください。",
+	"Name":                                   "名前",
+	"Name:":                                  "名前:",
+	"Navigate":                               "移動",
+	"needs_review":                           "レビュー待ち",
+	"never":                                  "なし",
+	"New":                                    "新規",
+	"New Group":                              "新規グループ",
+	"New item":                               "新しい項目",
+	"New Milestone":                          "新規マイルストーン",
+	"new task":                               "新規タスク",
+	"New Task":                               "新規タスク",
+	"Next":                                   "次へ",
+	"Next Field":                             "次の項目",
+	"Next list":                              "次のリスト",
+	"Next/Prev":                              "次/前",
+	"No changes recorded yet.":               "まだ変更は記録されていません。",
+	"no dates":                               "日付なし",
+	"No groups defined.":                     "グループが定義されていません。",
+	"No history recorded for this task yet.": "このタスクの履歴はまだありません。",
+	"No matching projects":                   "一致するプロジェクトはありません",
+	"No matching tasks.":                     "一致するタスクはありません。",
+	"No milestones defined.":                 "マイルストーンが定義されていません。",
+	"No projects found in %s":                "%s にプロジェクトがありません",
+	"No scheduled tasks.":                    "日付が設定されたタスクはありません。",
+	"no tasks completed in the last %d days": "直近 %d 日間に完了したタスクなし",
+	"No tasks found.":                        "タスクが見つかりません。",
+	"No tasks match the current filters — press C to clear them.": "現在のフィルタに一致するタスクはありません — C でフィルタを解除",
+	"No tasks viewed yet.": "まだ表示したタスクはありません。",
+	"Not a directory":      "ディレクトリではありません",
+	"Not readable":         "読み取れません",
+	"Not writable":         "書き込めません",
+	"Nothing logged yet.":  "まだ何も記録されていません。",
+	"On disk now":          "現在のディスク上",
+	"Open":                 "開く",
+	"Open dependency":      "依存先を開く",
+	"Open raw":             "生データを開く",
+	"Open task":            "タスクを開く",
+	"Owner":                "担当者",
+	"Owner (optional)":     "担当者（任意）",
+	"Owner:":               "担当者:",
+	"Owners":               "担当者",
+	"Passphrase":           "パスフレーズ",
+	"pending":              "未着手",
+	"Permanently delete task #%s?\n\"%s\"\nThis cannot be undone.": "タスク #%s を完全に削除しますか？\n「%s」\nこの操作は元に戻せません。",
+	"Permanently deleted #%s":                                      "#%s を完全に削除しました",
+	"Preset Colors:":                                               "プリセットカラー:",
+	"Press %s to run the setup wizard":                             "%s でセットアップウィザードを開きます",
+	"Press 'n' to create a new group.":                             "n キーで新しいグループを作成します。",
+	"Press 'n' to create a new milestone.":                         "n キーで新しいマイルストーンを作成します。",
+	"Press 'n' to create a new task.":                              "'n' で新しいタスクを作成します。",
+	"Press any key to return":                                      "任意のキーで戻ります",
+	"Press f to create it, or w to run the setup wizard. Check tasksDir in the config file if it should be elsewhere.": "f で作成するか、w でセットアップウィザードを実行してください。別の場所にあるはずなら設定ファイルの tasksDir を確認してください。",
+	"Press f to give your user read access, or run: chmod u+rwX <path>":                                                "f で自分のユーザーに読み取り権限を付与するか、次を実行してください: chmod u+rwX <path>",
+	"Press f to give your user write access, or run: chmod u+rwX <path>. Until then, changes cannot be saved.":         "f で自分のユーザーに書き込み権限を付与するか、次を実行してください: chmod u+rwX <path>。それまでは変更を保存できません。",
+	"Preview":                           "プレビュー",
+	"Priority":                          "優先度",
+	"Problems: %s":                      "問題: %s",
+	"Project":                           "プロジェクト",
+	"Project Name":                      "プロジェクト名",
+	"Projects":                          "プロジェクト",
+	"purged after %d days":              "%d 日後に完全削除",
+	"Quit":                              "終了",
+	"Raw JSON":                          "生の JSON",
+	"Raw JSON: %s":                      "生 JSON: %s",
+	"Raw JSON: Task #%s":                "生 JSON: タスク #%s",
+	"read-only":                         "読み取り専用",
+	"Reason":                            "理由",
+	"Reason for reopening #%s:":         "#%s を再開する理由:",
+	"Reason for reopening %d tasks:":    "%d 件のタスクを再開する理由:",
+	"Recent: %s":                        "最近: %s",
+	"Recently modified":                 "最近変更したタスク",
+	"Recently viewed":                   "最近見たタスク",
+	"Refresh":                           "更新",
+	"Reject":                            "差し戻し",
+	"rejected":                          "差し戻し",
+	"Reload (discard my edits)":         "再読み込み（自分の編集を破棄）",
+	"Remaining estimate":                "残り見積もり",
+	"Remove":                            "外す",
+	"Reopen the edit form":              "編集フォームを開き直す",
+	"Reorder":                           "並べ替え",
+	"Restore":                           "復元",
+	"Restore backup":                    "バックアップから復元",
+	"Restore Unsaved Edit":              "未保存の編集を復元",
+	"Restored #%s":                      "#%s を復元しました",
+	"Restored #%s as #%s":               "#%s を #%s として復元しました",
+	"Restored %s from the backup of %s": "%s を %s のバックアップから復元しました",
+	"Retry":                             "再試行",
+	"review":                            "レビュー",
+	"Review":                            "レビュー",
+	"Save":                              "保存",
+	"Scroll":                            "スクロール",
+	"Search":                            "検索",
+	"Search Tasks":                      "タスクを検索",
+	"Search...":                         "検索...",
+	"Search:":                           "検索:",
+	"Search: Type to filter, [Enter] confirm, [Esc] cancel": "検索: 入力して絞り込み、[Enter] 確定、[Esc] キャンセル",
+	"Select":              "選択",
+	"Select Group":        "グループを選択",
+	"Select Tasks for %s": "%s のタスクを選択",
+	"Set \"git\": {\"enabled\": true} in ~/.config/cctasks/config.json to record changes.": "変更を記録するには ~/.config/cctasks/config.json で \"git\": {\"enabled\": true} を設定してください。",
+	"Set %s to \"%s\" on %d task(s)?":                             "%[3]d 件のタスクの%[1]sを「%[2]s」に設定しますか？",
+	"Set start/due dates with batch edit (B) to show tasks here.": "一括編集 (B) で開始日・期限を設定するとここに表示されます。",
+	"Set Status":       "ステータスを設定",
+	"Setup":            "セットアップ",
+	"Setup Guide":      "セットアップガイド",
+	"Setup wizard":     "セットアップ",
+	"Show":             "表示",
+	"Show archived":    "アーカイブを表示",
+	"Show Diff":        "差分を表示",
+	"Show new version": "新しい版を表示",
+	"Showing %d of %d task(s) (%d hidden by filters)": "%d / %d 件のタスクを表示中（フィルタで %d 件非表示）",
+	"Showing all %d task(s)":                          "全 %d 件のタスクを表示中",
+	"Skip":                                            "スキップ",
+	"Sort":                                            "並び順",
+	"Sort (o): %s":                                    "並び順 (o): %s",
+	"stale":                                           "停滞",
+	"Stale":                                           "停滞",
+	"Star":                                            "スター",
+	"Start":                                           "開始",
+	"Start date":                                      "開始日",
+	"Start date (YYYY-MM-DD, optional)":               "開始日（YYYY-MM-DD、任意）",
+	"Start:":                                          "開始:",
+	"Stats: %s":                                       "統計: %s",
+	"Status":                                          "ステータス",
+	"Status (f): ":                                    "ステータス (f): ",
+	"Status filter":                                   "ステータス絞り込み",
+	"Status:":                                         "ステータス:",
+	"Step %d of 3":                                    "ステップ %d / 3",
+	"Subject":                                         "件名",
+	"Subject:":                                        "件名:",
+	"Switch Project":                                  "プロジェクト切替",
+	"Tags":                                            "タグ",
+	"Task":                                            "タスク",
+	"Task #%s":                                        "タスク #%s",
+	"Task #%s \"%s\" was deleted outside cctasks.": "タスク #%s「%s」は cctasks の外部で削除されました。",
+	"Task count":                      "タスク数",
+	"Task description...":             "タスクの説明...",
+	"Task subject":                    "タスクの件名",
+	"Tasks":                           "タスク",
+	"Tasks Directory":                 "タスクディレクトリ",
+	"Tasks Directory Check":           "タスクディレクトリのチェック",
+	"The tasks directories are fine.": "タスクディレクトリに問題はありません。",
+	"The tasks directory %s does not exist yet. Create it?":                  "タスクの保存先 %s がまだありません。作成しますか？",
+	"This group changed on disk; saving overwrites the change":               "このグループはディスク上で変更されました。保存するとその変更を上書きします",
+	"This project is encrypted.":                                             "このプロジェクトは暗号化されています。",
+	"This task blocks %d incomplete tasks:":                                  "このタスクが %d 件の未完了タスクをブロックしています:",
+	"This task blocks 1 incomplete task:":                                    "このタスクが 1 件の未完了タスクをブロックしています:",
+	"This task was changed outside cctasks while it was open.":               "開いている間にこのタスクが cctasks の外部で変更されました。",
+	"This wizard connects the Task List of Claude Code v2.1.16+ to cctasks.": "Claude Code v2.1.16+ の Task List を cctasks で表示するための設定を行います。",
+	"Timeline: %s": "タイムライン: %s",
+	"To enable the Task List of Claude Code v2.1.16+:": "Claude Code v2.1.16+ で Task List 機能を有効にする方法:",
+	"Today":                                 "今日",
+	"todo":                                  "未着手",
+	"toggle":                                "開閉",
+	"Toggle":                                "切り替え",
+	"Trash is empty.":                       "ゴミ箱は空です。",
+	"Trash: %s":                             "ゴミ箱: %s",
+	"Type to search or name a new group...": "グループを検索、または新しいグループ名を入力...",
+	"Type to search projects...":            "プロジェクトを検索...",
+	"Type to search tasks...":               "入力してタスクを検索...",
+	"Unassigned":                            "未割り当て",
+	"Unblocked %s":                          "%s のブロックが解除されました",
+	"unblocks %d":                           "%d 件のブロックを解除",
+	"Uncategorized":                         "未分類",
+	"Unlock":                                "解除",
+	"Unlocking...":                          "解除中...",
+	"updated %s":                            "更新 %s",
+	"Updated (t): %s":                       "更新 (t): %s",
+	"Value:":                                "値:",
+	"Viewed/Modified":                       "閲覧/更新",
+	"w: write to %s (other settings are kept)": "w: %s に書き込み（既存の設定は保持）",
+	"waiting for open dependencies":            "未完了の依存タスク待ち",
+	"Week":                                     "週",
+	"What's next? #%s %s%s":                    "次のタスク: #%s %s%s",
+	"What's next? No unblocked pending tasks.  [Esc] close": "次のタスク: 着手可能な未着手タスクはありません。  [Esc] 閉じる",
+	"When opened":               "開いた時点",
+	"Why is #%s blocked?":       "#%s がブロックされている理由:",
+	"Why is #%s rejected?":      "#%s を差し戻す理由:",
+	"Why?":                      "理由",
+	"working directory unknown": "作業ディレクトリが不明です",
+	"Write":                     "書き込み",
+	"Wrote %s":                  "%s に書き込みました",
+	"Σ %s left":                 "残り Σ %s",
+	"★ Starred":                 "★ スター付き",
+}
@@ -0,0 +1,121 @@
+// Package logging provides the debug log enabled by the --debug flag.
+//
+// Without --debug all log calls are discarded, so the TUI output is never
+// disturbed. With it, records are appended to ~/.config/cctasks/log/cctasks.log
+// and the most recent ones are kept in memory for the in-app log viewer.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// RecentLimit is the number of log lines kept for the log viewer
+const RecentLimit = 500
+
+var (
+	file    *os.File
+	path    string
+	enabled bool
+	recent  = &ring{limit: RecentLimit}
+)
+
+func init() {
+	// slog's default logger writes to stderr, which would corrupt the TUI
+	slog.SetDefault(slog.New(discardHandler{}))
+}
+
+// Enable starts writing debug logs to the log file
+func Enable() error {
+	logPath, err := config.GetLogFilePath()
+	if err != nil {
+		return err
+	}
+	return EnableFile(logPath)
+}
+
+// EnableFile starts writing debug logs to the given file
+func EnableFile(logPath string) error {
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	Close()
+	file, path, enabled = f, logPath, true
+	handler := slog.NewTextHandler(io.MultiWriter(f, recent), &slog.HandlerOptions{Level: slog.LevelDebug})
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// Close stops logging and closes the log file
+func Close() error {
+	slog.SetDefault(slog.New(discardHandler{}))
+	enabled = false
+	if file == nil {
+		return nil
+	}
+	err := file.Close()
+	file = nil
+	return err
+}
+
+// Enabled reports whether debug logging is on
+func Enabled() bool {
+	return enabled
+}
+
+// Path returns the log file path, or "" when logging is off
+func Path() string {
+	if !enabled {
+		return ""
+	}
+	return path
+}
+
+// Recent returns the most recent log lines, oldest first
+func Recent() []string {
+	return recent.lines()
+}
+
+// ring keeps the last limit lines written to it
+type ring struct {
+	mu    sync.Mutex
+	limit int
+	buf   []string
+}
+
+func (r *ring) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		r.buf = append(r.buf, line)
+	}
+	if over := len(r.buf) - r.limit; over > 0 {
+		r.buf = append([]string(nil), r.buf[over:]...)
+	}
+	return len(p), nil
+}
+
+func (r *ring) lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.buf...)
+}
+
+// discardHandler drops all records
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
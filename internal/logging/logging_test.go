@@ -0,0 +1,53 @@
+package logging
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnableFile(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "log", "cctasks.log")
+	slog.Debug("before enable")
+	if err := EnableFile(logPath); err != nil {
+		t.Fatalf("EnableFile failed: %v", err)
+	}
+	slog.Debug("tasks saved", "project", "demo")
+	if err := Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	slog.Debug("after close")
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if !strings.Contains(content, `msg="tasks saved" project=demo`) {
+		t.Errorf("log file missing record:\n%s", content)
+	}
+	if strings.Contains(content, "before enable") || strings.Contains(content, "after close") {
+		t.Errorf("records logged while disabled:\n%s", content)
+	}
+
+	lines := Recent()
+	if len(lines) == 0 || !strings.Contains(lines[len(lines)-1], "tasks saved") {
+		t.Errorf("Recent() = %v", lines)
+	}
+	if Enabled() || Path() != "" {
+		t.Error("logging should be off after Close")
+	}
+}
+
+func TestRingKeepsLastLines(t *testing.T) {
+	r := &ring{limit: 3}
+	r.Write([]byte("a\nb\n"))
+	r.Write([]byte("c\n"))
+	r.Write([]byte("d\n"))
+	got := strings.Join(r.lines(), ",")
+	if got != "b,c,d" {
+		t.Errorf("lines = %q, want b,c,d", got)
+	}
+}
@@ -0,0 +1,239 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// allTasksStatusFilters are the status filters cycled with "f"
+var allTasksStatusFilters = []string{"in_progress", "pending", "completed", ""}
+
+// AllTasksModel handles the combined task list of every project
+type AllTasksModel struct {
+	tasks        []data.ProjectTask
+	items        []data.ProjectTask // tasks matching the status filter
+	statusFilter string             // "" shows every status
+	err          error              // projects that failed to load
+	cursor       int
+	width        int
+	height       int
+	scrollOffset int
+}
+
+// NewAllTasksModel creates a new AllTasksModel, showing in-progress tasks first
+func NewAllTasksModel(tasks []data.ProjectTask, err error) AllTasksModel {
+	m := AllTasksModel{tasks: tasks, err: err, statusFilter: "in_progress"}
+	m.rebuildItems()
+	return m
+}
+
+// rebuildItems applies the status filter
+func (m *AllTasksModel) rebuildItems() {
+	m.items = nil
+	for _, pt := range m.tasks {
+		if m.statusFilter == "" || pt.Task.Status == m.statusFilter {
+			m.items = append(m.items, pt)
+		}
+	}
+	m.cursor = min(m.cursor, max(len(m.items)-1, 0))
+	m.ensureVisible()
+}
+
+// Init initializes the model
+func (m AllTasksModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m AllTasksModel) Update(msg tea.Msg) (AllTasksModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup":
+		m.moveCursor(-m.viewportHeight())
+	case "pgdown":
+		m.moveCursor(m.viewportHeight())
+	case "home":
+		m.moveCursor(-len(m.items))
+	case "end":
+		m.moveCursor(len(m.items))
+	case "f":
+		for i, status := range allTasksStatusFilters {
+			if status == m.statusFilter {
+				m.statusFilter = allTasksStatusFilters[(i+1)%len(allTasksStatusFilters)]
+				break
+			}
+		}
+		m.cursor = 0
+		m.scrollOffset = 0
+		m.rebuildItems()
+	case "enter", "right":
+		if m.cursor < len(m.items) {
+			pt := m.items[m.cursor]
+			return m, func() tea.Msg {
+				return OpenProjectTaskMsg{Project: pt.Project, TaskID: pt.Task.ID}
+			}
+		}
+	case "esc", "left", "p":
+		return m, func() tea.Msg {
+			return BackToProjectsMsg{}
+		}
+	case "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// viewportHeight returns the number of items that fit on screen
+func (m AllTasksModel) viewportHeight() int {
+	// header (3) + summary (2) + column header (1) + scroll indicators (2) + footer (3)
+	vh := m.height - 11
+	if m.err != nil {
+		vh -= 2
+	}
+	if vh < 5 {
+		vh = 5
+	}
+	return vh
+}
+
+// moveCursor moves the cursor by delta, clamped to the list
+func (m *AllTasksModel) moveCursor(delta int) {
+	m.cursor = min(max(m.cursor+delta, 0), max(len(m.items)-1, 0))
+	m.ensureVisible()
+}
+
+// ensureVisible adjusts the scroll offset so the cursor is on screen
+func (m *AllTasksModel) ensureVisible() {
+	vh := m.viewportHeight()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+vh {
+		m.scrollOffset = m.cursor - vh + 1
+	}
+	if m.scrollOffset < 0 {
+		m.scrollOffset = 0
+	}
+}
+
+// statusLabel returns the display name of the status filter
+func (m AllTasksModel) statusLabel() string {
+	if m.statusFilter == "" {
+		return i18n.T("All")
+	}
+	return i18n.T(m.statusFilter)
+}
+
+// View renders the combined task list
+func (m AllTasksModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ui.Header(i18n.T("All Projects"), m.width))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", strings.ReplaceAll(m.err.Error(), "\n", "; "))))
+		b.WriteString("\n\n")
+	}
+
+	projects := make(map[string]bool)
+	for _, pt := range m.items {
+		projects[pt.Project] = true
+	}
+	summary := i18n.Tf("%d task(s) in %d project(s)", len(m.items), len(projects))
+	b.WriteString(ui.MutedStyle.Render(summary))
+	b.WriteString(ui.MutedStyle.Render("  " + i18n.T("Status (f): ")))
+	b.WriteString(ui.ValueStyle.Render(m.statusLabel()))
+	b.WriteString("\n\n")
+
+	// Project column sized to the longest visible project name
+	projectHeader := i18n.T("Project")
+	projectWidth := lipgloss.Width(projectHeader)
+	for _, pt := range m.items {
+		projectWidth = max(projectWidth, lipgloss.Width(pt.Project))
+	}
+	projectWidth = min(projectWidth, 24)
+	maxSubjectLen := max(m.width-projectWidth-16, 20)
+
+	projectHeader = ui.PadRight(projectHeader, projectWidth)
+	b.WriteString(ui.MutedStyle.Render(fmt.Sprintf("    %s  %-5s %s", projectHeader, "ID", i18n.T("Subject"))))
+	b.WriteString("\n")
+
+	if len(m.items) == 0 {
+		b.WriteString(ui.MutedStyle.Render("  " + i18n.T("No matching tasks.")))
+		b.WriteString("\n")
+	}
+
+	vh := m.viewportHeight()
+	endIdx := min(m.scrollOffset+vh, len(m.items))
+	if m.scrollOffset > 0 {
+		b.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Up + " " + i18n.Tf("%d more above", m.scrollOffset)))
+		b.WriteString("\n")
+	}
+
+	for i := m.scrollOffset; i < endIdx; i++ {
+		pt := m.items[i]
+		project := ui.PadRight(ui.Truncate(pt.Project, projectWidth), projectWidth)
+		line := fmt.Sprintf("%s %s  %s %s",
+			ui.GetStatusStyle(pt.Task.Status).Render(ui.StatusIcon(pt.Task.Status)),
+			ui.SubtitleStyle.Render(project),
+			ui.MutedStyle.Render(fmt.Sprintf("%-5s", "#"+data.DisplayID(pt.Task))),
+			ui.Truncate(pt.Task.Subject, maxSubjectLen),
+		)
+		if i == m.cursor {
+			b.WriteString(ui.TaskSelectedStyle.Render(ui.Glyphs.Cursor + " " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+
+	if remaining := len(m.items) - endIdx; remaining > 0 {
+		b.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Down + " " + i18n.Tf("%d more below", remaining)))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"↑↓", "Navigate"},
+		{"Enter", "Open task"},
+		{"f", "Status filter"},
+		{"Esc", "Back"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
+
+// loadAllTasks loads the tasks of every unarchived project
+func loadAllTasks(state *config.State) AllTasksModel {
+	projects, err := data.ListProjects()
+	if err != nil {
+		return NewAllTasksModel(nil, err)
+	}
+	var active []data.Project
+	for _, project := range projects {
+		if state == nil || !state.IsArchived(project.Name) {
+			active = append(active, project)
+		}
+	}
+	tasks, err := data.LoadAllTasks(active)
+	return NewAllTasksModel(tasks, err)
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+func TestAllTasksModel_FilterAndOpen(t *testing.T) {
+	m := NewAllTasksModel([]data.ProjectTask{
+		{Project: "alpha", Task: data.Task{ID: "1", Subject: "Plan", Status: "pending"}},
+		{Project: "alpha", Task: data.Task{ID: "2", Subject: "Build", Status: "in_progress"}},
+		{Project: "team/api", Task: data.Task{ID: "7", Subject: "Deploy", Status: "in_progress"}},
+	}, nil)
+
+	// In-progress tasks of every project are shown first
+	if len(m.items) != 2 || m.items[1].Project != "team/api" {
+		t.Fatalf("Expected 2 in-progress tasks, got %+v", m.items)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected a command on Enter")
+	}
+	if msg, ok := cmd().(OpenProjectTaskMsg); !ok || msg.Project != "team/api" || msg.TaskID != "7" {
+		t.Errorf("Expected OpenProjectTaskMsg for team/api #7, got %#v", cmd())
+	}
+
+	// f cycles through pending, completed and all
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if m.statusFilter != "pending" || len(m.items) != 1 || m.cursor != 0 {
+		t.Errorf("Expected the pending task, got filter %q items %d cursor %d", m.statusFilter, len(m.items), m.cursor)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if m.statusFilter != "" || len(m.items) != 3 {
+		t.Errorf("Expected all tasks, got filter %q items %d", m.statusFilter, len(m.items))
+	}
+}
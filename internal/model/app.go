@@ -1,25 +1,87 @@
 package model
 
 import (
+	"fmt"
+	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"golang.org/x/term"
 
+	"github.com/jss826/cctasks/internal/config"
 	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
 )
 
-// checkSizeMsg is sent periodically to check for terminal resize (Windows workaround)
-type checkSizeMsg struct{}
+// followInterval is how often follow mode checks the tasks directory
+const followInterval = time.Second
 
-// checkSizeCmd returns a command that periodically checks terminal size
-func checkSizeCmd() tea.Cmd {
-	return tea.Tick(time.Second/10, func(t time.Time) tea.Msg {
-		return checkSizeMsg{}
+// followTickMsg is sent periodically while follow mode is on; ticks of an
+// earlier follow session (before it was toggled off and on) are dropped
+type followTickMsg struct {
+	session int
+}
+
+// followTickCmd schedules the next follow mode check after interval
+func followTickCmd(session int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return followTickMsg{session: session}
+	})
+}
+
+// sshSyncedMsg is sent when a background sync changed the mirror of an ssh root
+type sshSyncedMsg struct{}
+
+// waitSSHSync waits for the next background sync that changed the mirror of
+// an ssh root, or returns nil when no ssh roots are configured
+func waitSSHSync() tea.Cmd {
+	for _, root := range config.Current().Roots {
+		if root.SSH != "" {
+			return func() tea.Msg {
+				<-data.SSHSynced()
+				return sshSyncedMsg{}
+			}
+		}
+	}
+	return nil
+}
+
+// Terminal size polling backs off from minSizePoll to maxSizePoll while the
+// size stays the same and nothing is typed
+const (
+	minSizePoll = 100 * time.Millisecond
+	maxSizePoll = time.Second
+)
+
+// checkSizeMsg is sent periodically to check for terminal resize (Windows
+// workaround); checks of a replaced polling loop are dropped
+type checkSizeMsg struct {
+	seq int
+}
+
+// checkSizeCmd returns a command checking the terminal size after interval,
+// or nil where resize events arrive without polling
+func checkSizeCmd(seq int, interval time.Duration) tea.Cmd {
+	if !pollTerminalSize {
+		return nil
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return checkSizeMsg{seq: seq}
 	})
 }
 
+// nextSizePoll returns the interval until the next size check: short right
+// after a resize, doubling up to maxSizePoll while the size is unchanged
+func nextSizePoll(interval time.Duration, resized bool) time.Duration {
+	if resized || interval < minSizePoll {
+		return minSizePoll
+	}
+	return min(interval*2, maxSizePoll)
+}
+
 // AppVersion is set from main.go
 var AppVersion = "dev"
 
@@ -33,6 +95,24 @@ const (
 	ScreenEdit
 	ScreenGroups
 	ScreenGroupEdit
+	ScreenProblems
+	ScreenHistory
+	ScreenTrash
+	ScreenBatchEdit
+	ScreenTimeline
+	ScreenStats
+	ScreenMilestones
+	ScreenMilestoneEdit
+	ScreenRecent
+	ScreenAllTasks
+	ScreenConflict
+	ScreenRawJSON
+	ScreenLog
+	ScreenRecover
+	ScreenExport
+	ScreenSetup
+	ScreenLoading
+	ScreenHealth
 )
 
 // App is the main application model
@@ -50,32 +130,173 @@ type App struct {
 	edit      EditModel
 	groups    GroupsModel
 	groupEdit GroupEditModel
+	problems  ProblemsModel
+	history   HistoryModel
+	trash     TrashModel
+	batchEdit BatchEditModel
+	timeline  TimelineModel
+	stats     StatsModel
+
+	milestones    MilestonesModel
+	milestoneEdit MilestoneEditModel
+	recent        RecentModel
+	allTasks      AllTasksModel
+	conflict      ConflictModel
+	rawJSON       RawJSONModel
+	logView       LogModel
+	recoverEdit   RecoverModel
+	export        ExportModel
+	setup         SetupModel
+	loading       LoadingModel
+	health        HealthModel
 
 	// Shared data
-	taskStore  *data.TaskStore
-	groupStore *data.GroupStore
+	store          *projectStore // the open project's tasks and groups, shared by every screen
+	milestoneStore *data.MilestoneStore
 
 	// State
-	err error
+	state    *config.State // UI state persisted between runs
+	loadSeq  int           // incremented for each project load
+	sizePoll time.Duration // interval of the terminal size check (Windows)
+	sizeSeq  int           // identifies the running terminal size polling loop
+
+	// Idle detection: polling slows down while the user is away
+	lastActive time.Time
+
+	// Tabs: projects kept open with their task lists; the shown one is also
+	// in projectName, store and tasks
+	tabs       []workspaceTab
+	tab        int  // index of the shown tab
+	openingTab bool // Ctrl+T: the next project selected opens in a new tab
+
+	// Project switcher (Ctrl+O)
+	switcher projectSwitcher
+	reopen   Screen // screen to show for the project being switched to (ScreenProjects = the task list)
+
+	// Follow mode: open the task most recently set to in_progress
+	follow         bool
+	followSession  int             // incremented each time follow mode is turned on
+	followedTaskID string          // last task opened by follow mode
+	followedStore  *data.TaskStore // store followLatest last looked at
+
+	// Collaboration mode: the open shared project is polled for changes
+	collabPolling bool
+	collabSeq     int // identifies the running polling loop
+
+	// Launch target from the command line (overrides the last session's project)
+	launchProject string
+	launchTaskID  string               // also set when opening a task from the All Projects view
+	restoreEdit   *config.EditRecovery // edit form to reopen once its project is loaded
 }
 
 // NewApp creates a new App model
 func NewApp() App {
-	return App{
-		screen:   ScreenProjects,
-		projects: NewProjectsModel(),
+	state, _ := config.LoadState() // invalid state starts fresh
+	a := App{
+		screen:     ScreenProjects,
+		projects:   NewProjectsModel(state),
+		state:      state,
+		lastActive: time.Now(),
 	}
+	// Offer to restore an edit form saved when cctasks last crashed
+	if rec, _ := config.LoadRecovery(); rec != nil {
+		a.recoverEdit = NewRecoverModel(*rec)
+		a.screen = ScreenRecover
+	}
+	return a
+}
+
+// OpenOnLaunch makes the app open a project (and optionally one of its tasks)
+// on start instead of the project picker
+func (a *App) OpenOnLaunch(projectName, taskID string) {
+	a.launchProject = projectName
+	a.launchTaskID = taskID
 }
 
-// Init initializes the application
+// Init initializes the application, opening the launch project or the
+// project open at last quit
 func (a App) Init() tea.Cmd {
-	return tea.Batch(a.projects.Init(), checkSizeCmd())
+	cmds := []tea.Cmd{a.projects.Init(), checkSizeCmd(a.sizeSeq, minSizePoll), waitSSHSync()}
+	if a.screen != ScreenRecover {
+		cmds = append(cmds, a.launchCmd())
+	}
+	return tea.Batch(cmds...)
+}
+
+// launchCmd opens the launch project or the project open at last quit
+func (a App) launchCmd() tea.Cmd {
+	project := a.launchProject
+	if project == "" {
+		project = a.lastProject()
+	}
+	if project == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		return SelectProjectMsg{Name: project}
+	}
+}
+
+// lastProject returns the project to reopen on launch, if it still exists
+func (a App) lastProject() string {
+	if a.state == nil || a.state.LastProject == "" {
+		return ""
+	}
+	dir, err := config.GetProjectDir(a.state.LastProject)
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return a.state.LastProject
+}
+
+// rememberTaskList stores the task list's state for the open project
+func (a *App) rememberTaskList() {
+	if a.state == nil || a.projectName == "" || a.store == nil {
+		return
+	}
+	st := a.tasks.SessionState()
+	a.state.Project(a.projectName).TaskList = &st
+}
+
+// SaveSession persists the open project and its task list state so the next
+// launch resumes where this one left off
+func (a App) SaveSession() error {
+	if a.state == nil {
+		return nil
+	}
+	a.state.LastProject = ""
+	if a.screen != ScreenProjects && a.projectName != "" {
+		a.rememberTaskList()
+		a.state.LastProject = a.projectName
+	}
+	return a.state.Save()
 }
 
 // Update handles messages
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		if _, ok := msg.(tea.KeyMsg); ok && a.store != nil {
+			a.store.err = nil // a failed save is shown until the next key
+		}
+		if wake := a.wake(time.Now()); wake != nil {
+			model, cmd := a.update(msg)
+			return model, tea.Batch(cmd, wake)
+		}
+	}
+	return a.update(msg)
+}
+
+// update handles messages after user activity is recorded
+func (a App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case checkSizeMsg:
+		if msg.seq != a.sizeSeq {
+			return a, nil // a newer polling loop runs
+		}
 		// Poll terminal size (Windows workaround for no SIGWINCH)
 		// Try stdin first (works better with alt screen), fallback to stdout
 		fd := int(os.Stdin.Fd())
@@ -84,150 +305,257 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			fd = int(os.Stdout.Fd())
 			w, h, err = term.GetSize(fd)
 		}
-		if err != nil {
-			return a, checkSizeCmd()
-		}
-		if w != a.width || h != a.height {
+		resized := err == nil && (w != a.width || h != a.height)
+		a.sizePoll = nextSizePoll(a.sizePoll, resized)
+		interval := a.pollInterval(a.sizePoll, time.Now())
+		if resized {
 			a.width = w
 			a.height = h
 			// Propagate to sub-models
-			a.projects.width = w
-			a.projects.height = h
-			a.tasks.width = w
-			a.tasks.height = h
-			a.detail.width = w
-			a.detail.height = h
-			a.edit.width = w
-			a.edit.height = h
-			a.groups.width = w
-			a.groups.height = h
-			a.groupEdit.width = w
-			a.groupEdit.height = h
+			a.propagateSize()
 			// Clear screen and continue polling
 			return a, tea.Batch(
 				func() tea.Msg { return tea.ClearScreen() },
-				checkSizeCmd(),
+				checkSizeCmd(a.sizeSeq, interval),
 			)
 		}
-		return a, checkSizeCmd()
+		return a, checkSizeCmd(a.sizeSeq, interval)
 
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
 		// Propagate to sub-models
-		a.projects.width = msg.Width
-		a.projects.height = msg.Height
-		a.tasks.width = msg.Width
-		a.tasks.height = msg.Height
-		a.detail.width = msg.Width
-		a.detail.height = msg.Height
-		a.edit.SetSize(msg.Width, msg.Height)
-		a.groups.width = msg.Width
-		a.groups.height = msg.Height
-		a.groupEdit.width = msg.Width
-		a.groupEdit.height = msg.Height
+		a.propagateSize()
 		return a, nil
 
 	case tea.MouseMsg:
+		if a.switcher.active {
+			return a, nil
+		}
+		// Compare the open task with its file first, so changes are not applied silently
+		if a.checkOpenTaskChanged() {
+			return a, nil
+		}
 		// Auto-reload on mouse click if data has changed
-		if a.projectName != "" && a.taskStore != nil && a.screen != ScreenGroups && a.screen != ScreenGroupEdit && a.screen != ScreenEdit {
-			needsReload := a.taskStore.NeedsReload()
-			if a.groupStore != nil && a.groupStore.NeedsReload() {
-				needsReload = true
-			}
-			if needsReload {
-				a.taskStore, _ = data.LoadTasks(a.projectName)
-				a.groupStore, _ = data.LoadGroups(a.projectName)
-				switch a.screen {
-				case ScreenTasks:
-					a.tasks.ReloadData(a.taskStore, a.groupStore)
+		a.autoReload("mouse")
+
+		// Clicks on the sidebar switch projects; the task list gets the rest
+		if a.screen == ScreenTasks && a.sidebarShown() {
+			if msg.X < sidebarWidth {
+				name := a.sidebarProjectAt(msg.Y)
+				if msg.Action == tea.MouseActionRelease && msg.Button == tea.MouseButtonLeft && name != "" && name != a.projectName {
+					return a, func() tea.Msg {
+						return SelectProjectMsg{Name: name, InPlace: true}
+					}
 				}
+				return a, nil
 			}
+			msg.X -= sidebarWidth + 1
+			var cmd tea.Cmd
+			a.tasks, cmd = a.tasks.Update(msg)
+			return a, cmd
 		}
 
 	case tea.KeyMsg:
+		slog.Debug("key", "key", msg.String(), "screen", int(a.screen))
+		if (a.switcher.active && msg.String() != "ctrl+c") || (msg.String() == "ctrl+o" && !a.inForm()) {
+			return a.updateSwitcher(msg)
+		}
 		switch msg.String() {
 		case "ctrl+c":
 			return a, tea.Quit
 		case "ctrl+l":
 			// Manual screen refresh
 			return a, func() tea.Msg { return tea.ClearScreen() }
+		case "ctrl+g":
+			if a.screen != ScreenLog {
+				a.logView = NewLogModel()
+				a.logView.width = a.width
+				a.logView.height = a.contentHeight()
+				a.logView.returnTo = a.screen
+				a.screen = ScreenLog
+				return a, nil
+			}
+		}
+		if next, cmd, handled := a.updateTabs(msg); handled {
+			return next, cmd
+		}
+
+		// Compare the open task with its file first, so changes are not applied silently
+		if a.checkOpenTaskChanged() {
+			return a, nil
 		}
 
 		// Auto-reload on any key press if data has changed
-		// Skip reload on edit screens (Groups, GroupEdit, Edit) to avoid cursor/state reset
-		if a.projectName != "" && a.taskStore != nil && a.screen != ScreenGroups && a.screen != ScreenGroupEdit && a.screen != ScreenEdit {
-			needsReload := a.taskStore.NeedsReload()
-			if a.groupStore != nil && a.groupStore.NeedsReload() {
-				needsReload = true
-			}
-			if needsReload {
-				a.taskStore, _ = data.LoadTasks(a.projectName)
-				a.groupStore, _ = data.LoadGroups(a.projectName)
-				// Update current screen's data, preserving UI state
-				switch a.screen {
-				case ScreenTasks:
-					a.tasks.ReloadData(a.taskStore, a.groupStore)
-				}
-			}
+		a.autoReload("key")
+
+	case sshSyncedMsg:
+		// List what the sync brought in; the open project reloads like after other external changes
+		return a, tea.Batch(a.projects.Init(), waitSSHSync())
+
+	case projectsLoadedMsg:
+		// Listed for the sidebar too, whichever screen is shown
+		var cmd tea.Cmd
+		a.projects, cmd = a.projects.Update(msg)
+		a.tasks.width = a.taskListWidth()
+		return a, cmd
+
+	case CycleProjectMsg:
+		if !a.sidebarShown() {
+			return a, nil
+		}
+		name := adjacentProject(a.projects.sidebarProjects(), a.projectName, msg.Delta)
+		if name == "" {
+			return a, nil
+		}
+		return a, func() tea.Msg {
+			return SelectProjectMsg{Name: name, InPlace: true}
 		}
 
 	case SelectProjectMsg:
-		a.projectName = msg.Name
-		var err error
-		a.taskStore, err = data.LoadTasks(a.projectName)
-		if err != nil {
-			a.err = err
-			return a, nil
+		// A project open in another tab is shown as it was left
+		if i := a.findTab(msg.Name); i >= 0 && (i != a.tab || a.openingTab) && a.launchTaskID == "" {
+			cmd := a.showTab(i)
+			return a, tea.Batch(cmd, a.reopenCmd())
 		}
-		a.groupStore, err = data.LoadGroups(a.projectName)
-		if err != nil {
-			a.err = err
+		// Load in the background so slow disks and big projects keep the UI responsive
+		returnTo := a.screen
+		if returnTo == ScreenLoading {
+			returnTo = a.loading.returnTo
+		}
+		a.loadSeq++
+		a.loading = NewLoadingModel(msg.Name, a.loadSeq, returnTo)
+		a.loading.width = a.width
+		a.loading.height = a.height
+		if msg.InPlace && a.store != nil && a.screen != ScreenLoading {
+			// Keep the current screen until the project is loaded; the sidebar marks it
+			a.rememberTaskList()
+			return a, a.loading.Init()
+		}
+		a.screen = ScreenLoading
+		return a, a.loading.Init()
+
+	case CancelLoadingMsg:
+		a.loadSeq++ // drop the result if it is still loading
+		a.screen = msg.Screen
+		return a, nil
+
+	case loadingTickMsg:
+		// Keep the spinner's frame while another screen (e.g. the log) is shown
+		var cmd tea.Cmd
+		a.loading, cmd = a.loading.Update(msg)
+		return a, cmd
+
+	case projectLoadedMsg:
+		if msg.seq != a.loadSeq {
 			return a, nil
 		}
-		a.tasks = NewTasksModel(a.projectName, a.taskStore, a.groupStore)
-		a.tasks.width = a.width
-		a.tasks.height = a.height
-		a.screen = ScreenTasks
-		return a, a.tasks.Init()
+		if msg.err != nil {
+			slog.Debug("project load failed", "project", msg.name, "err", msg.err)
+			cmd := a.loading.fail(msg.err)
+			a.screen = ScreenLoading // also when it was loading in place
+			a.reopen = ScreenProjects
+			return a, cmd
+		}
+		a.loading = LoadingModel{} // stops the spinner
+		a.projectName = msg.name
+		data.RunOpenedHooks(a.projectName)
+		a.store = newProjectStore(msg.taskStore, msg.groupStore)
+		a.tasks = NewTasksModel(a.projectName, a.store)
+		a.tasks.width = a.taskListWidth()
+		a.tasks.height = a.contentHeight()
+		a.tasks.following = a.follow
+		a.followedTaskID = ""
+		if a.state != nil {
+			a.store.ignored = a.state.Project(a.projectName).IgnoredFiles
+			if st := a.state.Project(a.projectName).TaskList; st != nil {
+				a.tasks.RestoreSession(*st, a.loadMilestones().GetMilestone(st.Milestone))
+			}
+		}
+		a.placeTab()
+		if a.screen == ScreenHealth {
+			a.health.returnTo = ScreenTasks // shown once the diagnostics are closed
+		} else {
+			a.screen = ScreenTasks
+		}
+
+		// Reopen the edit form saved by a crash (once)
+		if rec := a.restoreEdit; rec != nil {
+			a.restoreEdit = nil
+			return a, a.openRecoveredEdit(*rec)
+		}
+
+		// Jump to the task requested on the command line or in All Projects
+		// (once); an unknown task is reported in the status bar
+		if ref := a.launchTaskID; ref != "" {
+			task := a.store.tasks.GetTask(a.store.tasks.ResolveTaskRef(ref))
+			a.launchTaskID = ""
+			if task != nil {
+				return a, func() tea.Msg {
+					return ViewTaskMsg{Task: task}
+				}
+			}
+			a.store.err = fmt.Errorf("task not found: #%s", ref)
+		}
+		if a.sidebarShown() {
+			return a, tea.Batch(a.tasks.Init(), a.projects.Init(), a.reopenCmd(), a.startCollabPolling()) // refresh the sidebar's counts
+		}
+		return a, tea.Batch(a.tasks.Init(), a.reopenCmd(), a.startCollabPolling())
+
+	case ShowAllTasksMsg:
+		a.allTasks = loadAllTasks(a.state)
+		a.allTasks.width = a.width
+		a.allTasks.height = a.height
+		a.screen = ScreenAllTasks
+		return a, a.allTasks.Init()
+
+	case OpenProjectTaskMsg:
+		// Open the project, then jump to the task like a launch target
+		a.launchTaskID = msg.TaskID
+		return a, func() tea.Msg {
+			return SelectProjectMsg{Name: msg.Project}
+		}
 
 	case BackToProjectsMsg:
+		a.rememberTaskList()
 		a.screen = ScreenProjects
 		return a, a.projects.Init()
 
 	case ViewTaskMsg:
-		a.detail = NewDetailModel(msg.Task, a.taskStore, a.groupStore)
+		a.recordViewed(msg.Task.ID)
+		a.detail = NewDetailModel(msg.Task, a.store)
 		a.detail.width = a.width
-		a.detail.height = a.height
+		a.detail.height = a.contentHeight()
+		a.detail.following = a.follow
+		a.detail.back = msg.Back
 		a.prevScreen = ScreenTasks
 		a.screen = ScreenDetail
 		return a, nil
 
 	case BackToTasksMsg:
 		// Reload tasks to reflect any changes, preserving UI state
-		a.taskStore, _ = data.LoadTasks(a.projectName)
-		a.groupStore, _ = data.LoadGroups(a.projectName)
-		a.tasks.ReloadData(a.taskStore, a.groupStore)
+		a.reloadProject()
 		a.screen = ScreenTasks
 		return a, nil
 
 	case EditTaskMsg:
-		a.edit = NewEditModel(msg.Task, a.taskStore, a.groupStore, false)
-		a.edit.SetSize(a.width, a.height)
+		a.edit = NewEditModel(msg.Task, a.store, false)
+		a.edit.SetMilestones(a.loadMilestones().GetMilestoneNames())
+		a.edit.SetSize(a.width, a.contentHeight())
 		a.prevScreen = a.screen
 		a.screen = ScreenEdit
 		return a, a.edit.Init()
 
 	case NewTaskMsg:
-		a.edit = NewEditModel(nil, a.taskStore, a.groupStore, true)
-		a.edit.SetSize(a.width, a.height)
+		a.edit = NewEditModel(nil, a.store, true)
+		a.edit.SetMilestones(a.loadMilestones().GetMilestoneNames())
+		a.edit.SetSize(a.width, a.contentHeight())
 		a.prevScreen = a.screen
 		a.screen = ScreenEdit
 		return a, a.edit.Init()
 
 	case TaskSavedMsg:
-		a.taskStore = msg.Store
-		a.tasks.ReloadData(a.taskStore, a.groupStore)
+		a.tasks.ReloadData()
 		a.screen = ScreenTasks
 		return a, nil
 
@@ -240,32 +568,32 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case ManageGroupsMsg:
-		a.groups = NewGroupsModel(a.groupStore)
+		a.groups = NewGroupsModel(a.store)
 		a.groups.width = a.width
-		a.groups.height = a.height
+		a.groups.height = a.contentHeight()
 		a.prevScreen = a.screen
 		a.screen = ScreenGroups
 		return a, a.groups.Init()
 
 	case BackFromGroupsMsg:
 		// Reload groups, preserving UI state
-		a.groupStore, _ = data.LoadGroups(a.projectName)
-		a.tasks.ReloadData(a.taskStore, a.groupStore)
+		if groupStore, err := data.LoadGroups(a.projectName); err == nil {
+			a.refreshStores(a.store.tasks, groupStore)
+		}
 		a.screen = ScreenTasks
 		return a, nil
 
 	case EditGroupMsg:
-		a.groupEdit = NewGroupEditModel(msg.Group, a.groupStore, msg.IsNew)
+		a.groupEdit = NewGroupEditModel(msg.Group, a.store, msg.IsNew)
 		a.groupEdit.width = a.width
-		a.groupEdit.height = a.height
+		a.groupEdit.height = a.contentHeight()
 		a.screen = ScreenGroupEdit
 		return a, a.groupEdit.Init()
 
 	case GroupSavedMsg:
-		a.groupStore = msg.Store
-		a.groups = NewGroupsModel(a.groupStore)
+		a.groups = NewGroupsModel(a.store)
 		a.groups.width = a.width
-		a.groups.height = a.height
+		a.groups.height = a.contentHeight()
 		a.screen = ScreenGroups
 		return a, a.groups.Init()
 
@@ -273,30 +601,253 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.screen = ScreenGroups
 		return a, nil
 
+	case ShowRecentMsg:
+		var viewed []string
+		if a.state != nil {
+			viewed = a.state.Project(a.projectName).RecentViewed
+		}
+		a.recent = NewRecentModel(a.projectName, a.store.tasks, viewed)
+		a.recent.width = a.width
+		a.recent.height = a.contentHeight()
+		a.screen = ScreenRecent
+		return a, a.recent.Init()
+
+	case ManageMilestonesMsg:
+		a.milestones = NewMilestonesModel(a.loadMilestones(), a.store, a.tasks.MilestoneFilter())
+		a.milestones.width = a.width
+		a.milestones.height = a.contentHeight()
+		a.screen = ScreenMilestones
+		return a, a.milestones.Init()
+
+	case EditMilestoneMsg:
+		a.milestoneEdit = NewMilestoneEditModel(msg.Milestone, a.milestoneStore, a.store, msg.IsNew)
+		a.milestoneEdit.width = a.width
+		a.milestoneEdit.height = a.contentHeight()
+		a.screen = ScreenMilestoneEdit
+		return a, a.milestoneEdit.Init()
+
+	case MilestoneSavedMsg:
+		a.milestoneStore = msg.Store
+		a.milestones = NewMilestonesModel(a.milestoneStore, a.store, a.tasks.MilestoneFilter())
+		a.milestones.width = a.width
+		a.milestones.height = a.contentHeight()
+		a.screen = ScreenMilestones
+		return a, a.milestones.Init()
+
+	case CancelMilestoneEditMsg:
+		a.screen = ScreenMilestones
+		return a, nil
+
+	case FilterMilestoneMsg:
+		a.tasks.SetMilestoneFilter(a.loadMilestones().GetMilestone(msg.Name))
+		a.reloadProject()
+		a.screen = ScreenTasks
+		return a, nil
+
+	case ShowHistoryMsg:
+		a.history = NewHistoryModel(a.projectName, msg.Task.ID, msg.Task.Subject)
+		a.history.width = a.width
+		a.history.height = a.contentHeight()
+		a.screen = ScreenHistory
+		return a, a.history.Init()
+
+	case ShowRawJSONMsg:
+		a.rawJSON = NewRawJSONModel(a.store.tasks, msg.Task)
+		a.rawJSON.width = a.width
+		a.rawJSON.height = a.contentHeight()
+		a.screen = ScreenRawJSON
+		return a, a.rawJSON.Init()
+
+	case RecoverEditMsg:
+		rec := a.recoverEdit.recovery
+		if !msg.Restore {
+			config.ClearRecovery() // best-effort
+			a.screen = ScreenProjects
+			return a, a.launchCmd()
+		}
+		a.restoreEdit = &rec
+		return a, func() tea.Msg {
+			return SelectProjectMsg{Name: rec.Project}
+		}
+
+	case CloseLogMsg:
+		a.screen = msg.Screen
+		return a, nil
+
+	case BackToDetailMsg:
+		a.screen = ScreenDetail
+		return a, nil
+
+	case ShowRawFileMsg:
+		a.rawJSON = NewRawFileModel(a.store.tasks, msg.File)
+		a.rawJSON.width = a.width
+		a.rawJSON.height = a.contentHeight()
+		a.screen = ScreenRawJSON
+		return a, a.rawJSON.Init()
+
+	case BackToProblemsMsg:
+		a.screen = ScreenProblems
+		return a, nil
+
+	case IgnoreFileMsg:
+		if a.state != nil {
+			ps := a.state.Project(a.projectName)
+			ps.IgnoreFile(msg.File, msg.Hash)
+			a.store.ignored = ps.IgnoredFiles
+			a.state.Save()
+		} else {
+			if a.store.ignored == nil {
+				a.store.ignored = make(map[string]string)
+			}
+			a.store.ignored[msg.File] = msg.Hash
+		}
+		return a, nil
+
+	case ShowProblemsMsg:
+		a.problems = NewProblemsModel(a.projectName, a.store)
+		a.problems.width = a.width
+		a.problems.height = a.contentHeight()
+		a.screen = ScreenProblems
+		return a, a.problems.Init()
+
+	case BatchEditMsg:
+		a.batchEdit = NewBatchEditModel(msg.TaskIDs, a.store)
+		a.batchEdit.width = a.width
+		a.batchEdit.height = a.contentHeight()
+		a.screen = ScreenBatchEdit
+		return a, a.batchEdit.Init()
+
+	case ExportTasksMsg:
+		a.export = NewExportModel(a.projectName, msg.TaskIDs, a.store)
+		a.export.width = a.width
+		a.export.height = a.contentHeight()
+		a.screen = ScreenExport
+		return a, a.export.Init()
+
+	case ShowHealthMsg:
+		a.health = NewHealthModel(msg.Issues, a.screen)
+		a.health.width = a.width
+		a.health.height = a.height
+		a.screen = ScreenHealth
+		return a, nil
+
+	case CloseHealthMsg:
+		a.screen = a.health.returnTo
+		return a, a.projects.Init() // list what the fixes made visible
+
+	case ShowSetupMsg:
+		a.setup = NewSetupModel()
+		a.setup.width = a.width
+		a.setup.height = a.height
+		a.screen = ScreenSetup
+		return a, a.setup.Init()
+
+	case SetupDoneMsg:
+		a.screen = ScreenProjects
+		return a, a.projects.Init() // list the new project
+
+	case ShowTimelineMsg:
+		a.timeline = NewTimelineModel(a.projectName, a.store)
+		a.timeline.width = a.width
+		a.timeline.height = a.contentHeight()
+		a.screen = ScreenTimeline
+		return a, a.timeline.Init()
+
+	case ShowStatsMsg:
+		a.stats = NewStatsModel(a.projectName, a.store)
+		a.stats.width = a.width
+		a.stats.height = a.contentHeight()
+		a.screen = ScreenStats
+		return a, a.stats.Init()
+
+	case ShowTrashMsg:
+		a.trash = NewTrashModel(a.projectName, a.store)
+		a.trash.width = a.width
+		a.trash.height = a.contentHeight()
+		a.screen = ScreenTrash
+		return a, a.trash.Init()
+
 	case RefreshMsg:
 		// Reload data, preserving UI state
 		if a.projectName != "" {
-			a.taskStore, _ = data.LoadTasks(a.projectName)
-			a.groupStore, _ = data.LoadGroups(a.projectName)
-			if a.screen == ScreenTasks {
-				a.tasks.ReloadData(a.taskStore, a.groupStore)
-			}
+			a.reloadProject()
+		}
+		return a, nil
+
+	case ResolveConflictMsg:
+		task := a.store.tasks.GetTask(a.conflict.task.ID)
+		switch {
+		case task == nil:
+			a.tasks.ReloadData()
+			a.screen = ScreenTasks
+		case a.conflict.editing && !msg.Reload:
+			a.screen = ScreenEdit // the edit form already saves into the reloaded store
+		case a.conflict.editing:
+			a.edit = NewEditModel(task, a.store, false)
+			a.edit.SetMilestones(a.loadMilestones().GetMilestoneNames())
+			a.edit.SetSize(a.width, a.contentHeight())
+			a.screen = ScreenEdit
+			return a, a.edit.Init()
+		default:
+			a.detail.reload()
+			a.screen = ScreenDetail
 		}
 		return a, nil
 
+	case GroupsCollapsedMsg:
+		if a.state != nil {
+			a.rememberTaskList()
+			a.state.Save() // best-effort, like the recent lists
+		}
+		return a, nil
+
+	case ToggleFollowMsg:
+		a.follow = !a.follow
+		a.tasks.following = a.follow
+		a.detail.following = a.follow
+		if !a.follow {
+			return a, nil
+		}
+		a.followSession++
+		a.followedTaskID, a.followedStore = "", nil
+		return a, tea.Batch(a.followLatest(), followTickCmd(a.followSession, a.pollInterval(followInterval, time.Now())))
+
+	case collabTickMsg:
+		return a.updateCollab(msg)
+
+	case followTickMsg:
+		if !a.follow || msg.session != a.followSession {
+			return a, nil // stop ticking
+		}
+		// Only track the agent while browsing tasks, never during edits
+		if a.projectName == "" || a.store == nil || (a.screen != ScreenTasks && a.screen != ScreenDetail) {
+			return a, followTickCmd(a.followSession, a.pollInterval(followInterval, time.Now()))
+		}
+		if a.store.tasks.NeedsReload() {
+			slog.Debug("change detected", "project", a.projectName, "trigger", "follow")
+			// Shows the latest version of the open task, keeping the scroll position
+			a.reloadProject()
+			a.lastActive = time.Now() // the agent is working; keep polling quickly
+		}
+		return a, tea.Batch(a.followLatest(), followTickCmd(a.followSession, a.pollInterval(followInterval, time.Now())))
+
 	case NextTaskMsg:
 		if next := a.tasks.GetAdjacentTask(msg.CurrentID, 1); next != nil {
-			a.detail = NewDetailModel(next, a.taskStore, a.groupStore)
+			a.recordViewed(next.ID)
+			a.detail = NewDetailModel(next, a.store)
 			a.detail.width = a.width
-			a.detail.height = a.height
+			a.detail.height = a.contentHeight()
+			a.detail.following = a.follow
 		}
 		return a, nil
 
 	case PrevTaskMsg:
 		if prev := a.tasks.GetAdjacentTask(msg.CurrentID, -1); prev != nil {
-			a.detail = NewDetailModel(prev, a.taskStore, a.groupStore)
+			a.recordViewed(prev.ID)
+			a.detail = NewDetailModel(prev, a.store)
 			a.detail.width = a.width
-			a.detail.height = a.height
+			a.detail.height = a.contentHeight()
+			a.detail.following = a.follow
 		}
 		return a, nil
 	}
@@ -316,49 +867,386 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.groups, cmd = a.groups.Update(msg)
 	case ScreenGroupEdit:
 		a.groupEdit, cmd = a.groupEdit.Update(msg)
+	case ScreenProblems:
+		a.problems, cmd = a.problems.Update(msg)
+	case ScreenHistory:
+		a.history, cmd = a.history.Update(msg)
+	case ScreenTrash:
+		a.trash, cmd = a.trash.Update(msg)
+	case ScreenBatchEdit:
+		a.batchEdit, cmd = a.batchEdit.Update(msg)
+	case ScreenTimeline:
+		a.timeline, cmd = a.timeline.Update(msg)
+	case ScreenStats:
+		a.stats, cmd = a.stats.Update(msg)
+	case ScreenMilestones:
+		a.milestones, cmd = a.milestones.Update(msg)
+	case ScreenMilestoneEdit:
+		a.milestoneEdit, cmd = a.milestoneEdit.Update(msg)
+	case ScreenRecent:
+		a.recent, cmd = a.recent.Update(msg)
+	case ScreenAllTasks:
+		a.allTasks, cmd = a.allTasks.Update(msg)
+	case ScreenConflict:
+		a.conflict, cmd = a.conflict.Update(msg)
+	case ScreenRawJSON:
+		a.rawJSON, cmd = a.rawJSON.Update(msg)
+	case ScreenLog:
+		a.logView, cmd = a.logView.Update(msg)
+	case ScreenRecover:
+		a.recoverEdit, cmd = a.recoverEdit.Update(msg)
+	case ScreenExport:
+		a.export, cmd = a.export.Update(msg)
+	case ScreenSetup:
+		a.setup, cmd = a.setup.Update(msg)
+	case ScreenLoading:
+		a.loading, cmd = a.loading.Update(msg)
+	case ScreenHealth:
+		a.health, cmd = a.health.Update(msg)
 	}
 
 	return a, cmd
 }
 
+// propagateSize copies the terminal size to all sub-models
+func (a *App) propagateSize() {
+	a.projects.width = a.width
+	a.projects.height = a.height
+	a.tasks.width = a.taskListWidth()
+	a.tasks.height = a.contentHeight()
+	a.detail.width = a.width
+	a.detail.height = a.contentHeight()
+	a.edit.SetSize(a.width, a.contentHeight())
+	a.groups.width = a.width
+	a.groups.height = a.contentHeight()
+	a.groupEdit.width = a.width
+	a.groupEdit.height = a.contentHeight()
+	a.problems.width = a.width
+	a.problems.height = a.contentHeight()
+	a.history.width = a.width
+	a.history.height = a.contentHeight()
+	a.trash.width = a.width
+	a.trash.height = a.contentHeight()
+	a.batchEdit.width = a.width
+	a.batchEdit.height = a.contentHeight()
+	a.timeline.width = a.width
+	a.timeline.height = a.contentHeight()
+	a.stats.width = a.width
+	a.stats.height = a.contentHeight()
+	a.milestones.width = a.width
+	a.milestones.height = a.contentHeight()
+	a.milestoneEdit.width = a.width
+	a.milestoneEdit.height = a.contentHeight()
+	a.recent.width = a.width
+	a.recent.height = a.contentHeight()
+	a.allTasks.width = a.width
+	a.allTasks.height = a.height
+	a.conflict.width = a.width
+	a.conflict.height = a.contentHeight()
+	a.rawJSON.width = a.width
+	a.rawJSON.height = a.contentHeight()
+	a.logView.width = a.width
+	a.logView.height = a.contentHeight()
+	a.recoverEdit.width = a.width
+	a.recoverEdit.height = a.height
+	a.export.width = a.width
+	a.export.height = a.contentHeight()
+	a.setup.width = a.width
+	a.setup.height = a.height
+	a.loading.width = a.width
+	a.loading.height = a.height
+	a.health.width = a.width
+	a.health.height = a.height
+}
+
+// recordViewed adds a task to the project's recently viewed list (best-effort)
+func (a *App) recordViewed(id string) {
+	if a.state == nil || a.projectName == "" {
+		return
+	}
+	a.state.Project(a.projectName).AddRecentViewed(id)
+	a.state.Save()
+}
+
+// openRecoveredEdit opens the edit form filled with content saved by a crash.
+// A task deleted in the meantime is restored as a new task.
+func (a *App) openRecoveredEdit(rec config.EditRecovery) tea.Cmd {
+	task := a.store.tasks.GetTask(rec.TaskID)
+	isNew := rec.IsNew || task == nil
+	if isNew {
+		task = nil
+	}
+	a.edit = NewEditModel(task, a.store, isNew)
+	a.edit.SetMilestones(a.loadMilestones().GetMilestoneNames())
+	a.edit.applyRecovery(rec)
+	a.edit.SetSize(a.width, a.contentHeight())
+	a.prevScreen = ScreenTasks
+	a.screen = ScreenEdit
+	config.ClearRecovery() // best-effort
+	return a.edit.Init()
+}
+
+// autoReload reloads the project if its files changed on disk. Every screen,
+// forms included, is pointed at the new data without losing its state, so a
+// form saved later never writes back stale copies. The conflict screen is
+// skipped while the user decides.
+func (a *App) autoReload(trigger string) {
+	if a.projectName == "" || a.store == nil || a.screen == ScreenConflict {
+		return
+	}
+	needsReload := a.store.tasks.NeedsReload()
+	if a.store.groups != nil && a.store.groups.NeedsReload() {
+		needsReload = true
+	}
+	if !needsReload {
+		return
+	}
+	slog.Debug("change detected", "project", a.projectName, "trigger", trigger)
+	a.reloadProject()
+}
+
+// reloadProject reloads the open project from disk into every screen,
+// keeping the current data if loading fails
+func (a *App) reloadProject() {
+	taskStore, err := data.LoadTasks(a.projectName)
+	if err != nil {
+		return
+	}
+	if a.store != nil {
+		taskStore.RecordExternalChanges(a.store.tasks)
+	}
+	groupStore, err := data.LoadGroups(a.projectName)
+	if err != nil {
+		groupStore = a.store.groups
+	}
+	a.refreshStores(taskStore, groupStore)
+}
+
+// refreshStores points every screen at freshly loaded stores, keeping each
+// screen's cursor, scroll position and form contents. Forms whose data
+// changed on disk show a warning; saving them applies the form on top of the
+// latest data.
+func (a *App) refreshStores(taskStore *data.TaskStore, groupStore *data.GroupStore) {
+	prevTasks, prevGroups := a.store.replace(taskStore, groupStore)
+
+	a.tasks.ReloadData()
+	if a.detail.store == a.store {
+		a.detail.reload()
+	}
+
+	if a.batchEdit.store == a.store {
+		if changed := changedTasks(prevTasks, taskStore, a.batchEdit.taskIDs); changed > 0 {
+			a.batchEdit.warning = i18n.Tf("%d of the selected tasks changed on disk; saving applies the edit to their latest version", changed)
+		}
+	}
+
+	a.groups.cursor = min(a.groups.cursor, max(len(groupStore.Groups)-1, 0))
+	if g := a.groupEdit.group; a.groupEdit.store == a.store && g != nil && !a.groupEdit.isNew && prevGroups != nil {
+		if old, cur := prevGroups.GetGroup(g.Name), groupStore.GetGroup(g.Name); old != nil && (cur == nil || *cur != *old) {
+			a.groupEdit.warning = i18n.T("This group changed on disk; saving overwrites the change")
+		}
+	}
+
+	if a.stats.store == a.store {
+		a.stats.history, _ = taskStore.History()
+	}
+	if a.timeline.store == a.store {
+		a.timeline.buildRows()
+		a.timeline.scroll(0)
+	}
+}
+
+// changedTasks counts the given tasks whose content differs between two stores
+func changedTasks(prev, cur *data.TaskStore, ids []string) int {
+	if prev == nil {
+		return 0
+	}
+	changed := 0
+	for _, id := range ids {
+		old, task := prev.GetTask(id), cur.GetTask(id)
+		if old != nil && (task == nil || len(data.DiffTaskFields(*old, *task)) > 0) {
+			changed++
+		}
+	}
+	return changed
+}
+
+// checkOpenTaskChanged reloads the project when its files changed while a
+// task is open in the detail or edit screen. If the open task itself changed,
+// the conflict screen is shown and true is returned; otherwise the open
+// screen is pointed at the reloaded data.
+func (a *App) checkOpenTaskChanged() bool {
+	if a.store == nil {
+		return false
+	}
+	var opened *data.Task
+	switch a.screen {
+	case ScreenDetail:
+		seen := a.detail.seen
+		opened = &seen
+	case ScreenEdit:
+		if task := a.store.tasks.GetTask(a.edit.task.ID); task != nil && !a.edit.isNew {
+			seen := *task
+			opened = &seen
+		}
+	default:
+		return false
+	}
+	if !a.store.tasks.NeedsReload() {
+		return false
+	}
+	slog.Debug("change detected", "project", a.projectName, "trigger", "open task")
+
+	store, err := data.LoadTasks(a.projectName)
+	if err != nil {
+		return false
+	}
+	store.RecordExternalChanges(a.store.tasks)
+	a.refreshStores(store, a.store.groups) // saving must not write back stale copies of other tasks
+
+	if opened == nil {
+		return false // new task: nothing to compare
+	}
+	current := a.store.tasks.GetTask(opened.ID)
+	if current != nil && len(data.DiffTaskFields(*opened, *current)) == 0 {
+		return false
+	}
+
+	slog.Debug("open task changed on disk", "task", opened.ID, "deleted", current == nil)
+	a.conflict = NewConflictModel(*opened, current, a.screen == ScreenEdit)
+	a.conflict.width = a.width
+	a.conflict.height = a.contentHeight()
+	a.screen = ScreenConflict
+	return true
+}
+
+// followLatest opens the task most recently moved to in_progress, unless
+// follow mode already opened it. The history log is read once per store, so
+// the follow ticks look again only after a reload or a project switch.
+func (a *App) followLatest() tea.Cmd {
+	if a.store == nil || a.store.tasks == a.followedStore {
+		return nil
+	}
+	a.followedStore = a.store.tasks
+	history, _ := a.store.tasks.History() // without history, the first in_progress task is followed
+	task := data.LatestInProgress(a.store.tasks.Tasks, history)
+	if task == nil || task.ID == a.followedTaskID {
+		return nil
+	}
+	a.followedTaskID = task.ID
+	return func() tea.Msg {
+		return ViewTaskMsg{Task: task}
+	}
+}
+
+// loadMilestones reloads the project's milestones, keeping the previous store on error
+func (a *App) loadMilestones() *data.MilestoneStore {
+	if store, err := data.LoadMilestones(a.projectName); err == nil {
+		a.milestoneStore = store
+	} else if a.milestoneStore == nil {
+		a.milestoneStore = &data.MilestoneStore{ProjectName: a.projectName}
+	}
+	return a.milestoneStore
+}
+
 // View renders the application
 func (a App) View() string {
+	if a.switcher.active {
+		return a.switcher.view(a.projects.projects, a.projectName, a.width, a.height)
+	}
+
 	var content string
 
-	if a.err != nil {
-		content = "Error: " + a.err.Error()
-	} else {
-		switch a.screen {
-		case ScreenProjects:
-			content = a.projects.View()
-		case ScreenTasks:
-			content = a.tasks.View()
-		case ScreenDetail:
-			content = a.detail.View()
-		case ScreenEdit:
-			content = a.edit.View()
-		case ScreenGroups:
-			content = a.groups.View()
-		case ScreenGroupEdit:
-			content = a.groupEdit.View()
-		default:
-			content = "Unknown screen"
+	switch a.screen {
+	case ScreenProjects:
+		content = a.projects.View()
+	case ScreenTasks:
+		content = a.tasks.View()
+		if a.sidebarShown() {
+			content = a.withSidebar(content)
 		}
+	case ScreenDetail:
+		content = a.detail.View()
+	case ScreenEdit:
+		content = a.edit.View()
+	case ScreenGroups:
+		content = a.groups.View()
+	case ScreenGroupEdit:
+		content = a.groupEdit.View()
+	case ScreenProblems:
+		content = a.problems.View()
+	case ScreenHistory:
+		content = a.history.View()
+	case ScreenTrash:
+		content = a.trash.View()
+	case ScreenBatchEdit:
+		content = a.batchEdit.View()
+	case ScreenTimeline:
+		content = a.timeline.View()
+	case ScreenStats:
+		content = a.stats.View()
+	case ScreenMilestones:
+		content = a.milestones.View()
+	case ScreenMilestoneEdit:
+		content = a.milestoneEdit.View()
+	case ScreenRecent:
+		content = a.recent.View()
+	case ScreenAllTasks:
+		content = a.allTasks.View()
+	case ScreenConflict:
+		content = a.conflict.View()
+	case ScreenRawJSON:
+		content = a.rawJSON.View()
+	case ScreenLog:
+		content = a.logView.View()
+	case ScreenRecover:
+		content = a.recoverEdit.View()
+	case ScreenExport:
+		content = a.export.View()
+	case ScreenSetup:
+		content = a.setup.View()
+	case ScreenLoading:
+		content = a.loading.View()
+	case ScreenHealth:
+		content = a.health.View()
+	default:
+		content = "Unknown screen"
+	}
+
+	if a.showsStatusBar() {
+		// Pin the status bar to the bottom of the screen
+		if pad := a.contentHeight() - strings.Count(content, "\n") - 1; pad > 0 {
+			content += strings.Repeat("\n", pad)
+		}
+		tabs := a.renderTabs()
+		content += "\n" + tabs + renderStatusBar(a.store, a.width-lipgloss.Width(tabs), time.Now())
 	}
 
 	return content
 }
 
+// showsStatusBar reports whether the current screen belongs to an open project
+func (a App) showsStatusBar() bool {
+	return a.store != nil && a.screen != ScreenProjects && a.screen != ScreenAllTasks && a.screen != ScreenSetup && a.screen != ScreenLoading && a.screen != ScreenHealth
+}
+
+// contentHeight returns the height available to project screens above the status bar
+func (a App) contentHeight() int {
+	return max(a.height-statusBarHeight, 0)
+}
+
 // Messages for screen transitions
 
 type SelectProjectMsg struct {
-	Name string
+	Name    string
+	InPlace bool // switched from the sidebar: the task list stays until the project is loaded
 }
 
 type BackToProjectsMsg struct{}
 
 type ViewTaskMsg struct {
 	Task *data.Task
+	Back []string // tasks to return to with Esc, when jumping between details
 }
 
 type BackToTasksMsg struct{}
@@ -369,9 +1257,7 @@ type EditTaskMsg struct {
 
 type NewTaskMsg struct{}
 
-type TaskSavedMsg struct {
-	Store *data.TaskStore
-}
+type TaskSavedMsg struct{}
 
 type CancelEditMsg struct{}
 
@@ -384,14 +1270,93 @@ type EditGroupMsg struct {
 	IsNew bool
 }
 
-type GroupSavedMsg struct {
-	Store *data.GroupStore
-}
+type GroupSavedMsg struct{}
 
 type CancelGroupEditMsg struct{}
 
 type RefreshMsg struct{}
 
+type ShowProblemsMsg struct{}
+
+type ShowTrashMsg struct{}
+
+type ShowTimelineMsg struct{}
+
+type ShowStatsMsg struct{}
+
+type ShowRecentMsg struct{}
+
+type ShowAllTasksMsg struct{}
+
+// ResolveConflictMsg closes the conflict screen; Reload switches to the version
+// on disk, otherwise editing continues with the user's unsaved changes
+type ResolveConflictMsg struct {
+	Reload bool
+}
+
+// GroupsCollapsedMsg reports that task list groups were collapsed or
+// expanded, so the list state is saved right away
+type GroupsCollapsedMsg struct{}
+
+// ToggleFollowMsg turns follow mode (auto-open the in_progress task) on or off
+type ToggleFollowMsg struct{}
+
+// OpenProjectTaskMsg opens a task of another project (from the All Projects view)
+type OpenProjectTaskMsg struct {
+	Project string
+	TaskID  string
+}
+
+type ManageMilestonesMsg struct{}
+
+type EditMilestoneMsg struct {
+	Milestone *data.Milestone
+	IsNew     bool
+}
+
+type MilestoneSavedMsg struct {
+	Store *data.MilestoneStore
+}
+
+type CancelMilestoneEditMsg struct{}
+
+// FilterMilestoneMsg filters the task list by milestone (empty shows all tasks)
+type FilterMilestoneMsg struct {
+	Name string
+}
+
+type BatchEditMsg struct {
+	TaskIDs []string
+}
+
+type ExportTasksMsg struct {
+	TaskIDs []string
+}
+
+type ShowSetupMsg struct{}
+
+type SetupDoneMsg struct {
+	Project string // project created by the wizard ("" if skipped)
+}
+
+type ShowHistoryMsg struct {
+	Task *data.Task
+}
+
+type ShowRawJSONMsg struct {
+	Task *data.Task
+}
+
+type BackToDetailMsg struct{}
+
+type RecoverEditMsg struct {
+	Restore bool
+}
+
+type CloseLogMsg struct {
+	Screen Screen
+}
+
 type NextTaskMsg struct {
 	CurrentID string
 }
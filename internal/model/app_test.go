@@ -0,0 +1,560 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestApp_FollowOpensInProgressTask(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	shared := newProjectStore(taskStore, groupStore)
+	a := App{
+		screen:      ScreenTasks,
+		projectName: "test",
+		store:       shared,
+		tasks:       NewTasksModel("test", shared),
+	}
+
+	model, cmd := a.Update(ToggleFollowMsg{})
+	a = model.(App)
+	if !a.follow || !a.tasks.following {
+		t.Fatal("Expected follow mode to be on")
+	}
+	if cmd == nil || a.followedTaskID != "2" {
+		t.Fatalf("Expected the in_progress task 2 to be opened, got %q", a.followedTaskID)
+	}
+
+	// The same task is not reopened on the next check, and the history of
+	// an unchanged store is not read again
+	if a.followLatest() != nil {
+		t.Error("Expected no command for an already followed task")
+	}
+	a.followedTaskID = ""
+	if a.followLatest() != nil {
+		t.Error("Expected no command before the store is reloaded")
+	}
+	a.followedStore = nil
+	if msg, ok := a.followLatest()().(ViewTaskMsg); !ok || msg.Task.ID != "2" {
+		t.Errorf("Expected ViewTaskMsg for task 2, got %#v", msg)
+	}
+
+	// Ticks from before follow mode was toggled off stop
+	model, _ = a.Update(ToggleFollowMsg{})
+	a = model.(App)
+	if _, cmd := a.Update(followTickMsg{session: a.followSession}); cmd != nil {
+		t.Error("Expected ticking to stop when follow mode is off")
+	}
+}
+
+func TestRenderStatusBar(t *testing.T) {
+	taskStore, _, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	taskStore.Tasks[1].ActiveForm = "Running tests"
+	bar := renderStatusBar(newProjectStore(taskStore, nil), 120, time.Now())
+	for _, want := range []string{"○2", "●1", "✓1", "Running tests", "changed"} {
+		if !strings.Contains(bar, want) {
+			t.Errorf("Expected %q in status bar %q", want, bar)
+		}
+	}
+
+	taskStore.Tasks[1].Status = "completed"
+	if bar := renderStatusBar(newProjectStore(taskStore, nil), 120, time.Now()); !strings.Contains(bar, "idle") {
+		t.Errorf("Expected idle status bar, got %q", bar)
+	}
+}
+
+func TestApp_ExternalChangeShowsConflict(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+
+	projectDir := filepath.Join(tasksDir, "proj")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := data.NewTaskStoreForTest(projectDir, []data.Task{
+		{ID: "1", Subject: "Fix login", Status: "pending"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	store, err := data.LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	groupStore, err := data.LoadGroups("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shared := newProjectStore(store, groupStore)
+	a := App{
+		screen:      ScreenDetail,
+		projectName: "proj",
+		store:       shared,
+		tasks:       NewTasksModel("proj", shared),
+		detail:      NewDetailModel(store.GetTask("1"), shared),
+	}
+
+	// Claude Code renames the task while it is open
+	changed := `{"id":"1","subject":"Fix login redirect","status":"in_progress"}`
+	if err := os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(changed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(projectDir, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	model, _ := a.Update(tea.KeyMsg{Type: tea.KeyDown})
+	a = model.(App)
+	if a.screen != ScreenConflict {
+		t.Fatalf("Expected the conflict screen, got %v", a.screen)
+	}
+	if len(a.conflict.changes) != 2 || a.conflict.changes[0].Field != "Subject" {
+		t.Errorf("Expected subject and status changes, got %+v", a.conflict.changes)
+	}
+
+	_, cmd := a.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model, _ = a.Update(cmd())
+	a = model.(App)
+	if a.screen != ScreenDetail || a.detail.task().Subject != "Fix login redirect" {
+		t.Errorf("Expected the detail screen with the new version, got screen %v subject %q", a.screen, a.detail.task().Subject)
+	}
+}
+
+func TestApp_CollaborationPolling(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+	cfg := config.Default()
+	cfg.Collaboration = config.CollaborationConfig{Projects: []string{"shared"}, User: "alice"}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	if err := os.MkdirAll(filepath.Join(tasksDir, "shared"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	store, err := data.LoadTasks("shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupStore, err := data.LoadGroups("shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared := newProjectStore(store, groupStore)
+	a := App{
+		screen:      ScreenTasks,
+		projectName: "shared",
+		store:       shared,
+		tasks:       NewTasksModel("shared", shared),
+	}
+	if a.startCollabPolling() == nil || a.startCollabPolling() != nil {
+		t.Fatal("Expected one polling loop for a shared project")
+	}
+
+	// Bob adds a task from his machine
+	cfg.Collaboration.User = "bob"
+	theirs, err := data.LoadTasks("shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	theirs.AddTask(data.Task{Subject: "Review the draft", Status: "pending"})
+	if err := theirs.Save(); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Collaboration.User = "alice"
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(tasksDir, "shared"), future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	model, cmd := a.Update(collabTickMsg{seq: a.collabSeq})
+	a = model.(App)
+	if cmd == nil || len(a.store.tasks.Tasks) != 1 {
+		t.Fatalf("Expected the poll to load Bob's task and keep polling, got %d task(s)", len(a.store.tasks.Tasks))
+	}
+	if bar := renderStatusBar(a.store, 120, time.Now()); !strings.Contains(bar, "bob created #1 just now") {
+		t.Errorf("Expected Bob's change in the status bar, got %q", bar)
+	}
+
+	// Polling stops once the project leaves collaboration mode
+	cfg.Collaboration.Projects = nil
+	if _, cmd := a.Update(collabTickMsg{seq: a.collabSeq}); cmd != nil {
+		t.Error("Expected polling to stop")
+	}
+}
+
+func TestApp_LogViewerReturnsToScreen(t *testing.T) {
+	a := App{screen: ScreenRecent, width: 80, height: 24}
+
+	model, _ := a.Update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	a = model.(App)
+	if a.screen != ScreenLog {
+		t.Fatalf("screen = %d, want log viewer", a.screen)
+	}
+	if !strings.Contains(a.View(), "Debug Log") {
+		t.Error("log viewer not rendered")
+	}
+
+	model, cmd := a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	a = model.(App)
+	model, _ = a.Update(cmd())
+	a = model.(App)
+	if a.screen != ScreenRecent {
+		t.Errorf("screen = %d, want the screen the viewer was opened from", a.screen)
+	}
+}
+
+func TestApp_ProjectSidebar(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	config.SetCurrent(config.Default())
+
+	shared := newProjectStore(taskStore, groupStore)
+	a := App{
+		screen:      ScreenTasks,
+		projectName: "test",
+		store:       shared,
+		tasks:       NewTasksModel("test", shared),
+		width:       200,
+		height:      30,
+	}
+	model, _ := a.Update(projectsLoadedMsg{projects: []data.Project{
+		{Name: "other", Pending: 5},
+		{Name: "test", Pending: 2, InProgress: 1},
+	}})
+	a = model.(App)
+	if a.tasks.width != 200-sidebarWidth-1 {
+		t.Errorf("task list width = %d, want the width beside the sidebar", a.tasks.width)
+	}
+	view := a.View()
+	for _, want := range []string{"Projects", "other", "5", "Backend"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the view with the sidebar", want)
+		}
+	}
+
+	// ] switches to the next project without leaving the task list
+	_, cmd := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+	model, cmd = a.Update(cmd())
+	a = model.(App)
+	msg, ok := cmd().(SelectProjectMsg)
+	if !ok || msg.Name != "other" || !msg.InPlace {
+		t.Fatalf("Expected SelectProjectMsg for other in place, got %#v", msg)
+	}
+	model, _ = a.Update(msg)
+	a = model.(App)
+	if a.screen != ScreenTasks || a.loading.projectName != "other" {
+		t.Errorf("Expected the task list while other loads, got screen %d loading %q", a.screen, a.loading.projectName)
+	}
+
+	// Narrow terminals have no sidebar
+	a.width = 120
+	a.tasks.width = a.taskListWidth()
+	if a.tasks.width != 120 || strings.Contains(a.View(), "other") {
+		t.Error("Expected no sidebar on a narrow terminal")
+	}
+	if _, cmd := a.Update(CycleProjectMsg{Delta: 1}); cmd != nil {
+		t.Error("Expected no project switch without the sidebar")
+	}
+}
+
+func TestApp_Tabs(t *testing.T) {
+	firstTasks, firstGroups, firstDir := setupTestTasks(t)
+	defer os.RemoveAll(firstDir)
+	secondTasks, secondGroups, secondDir := setupTestTasks(t)
+	defer os.RemoveAll(secondDir)
+
+	update := func(a App, msg tea.Msg) App {
+		t.Helper()
+		model, _ := a.Update(msg)
+		return model.(App)
+	}
+	a := App{width: 100, height: 30}
+	a = update(a, projectLoadedMsg{name: "first", taskStore: firstTasks, groupStore: firstGroups})
+
+	// Ctrl+T picks a project for a new tab
+	a = update(a, tea.KeyMsg{Type: tea.KeyCtrlT})
+	if a.screen != ScreenProjects || !a.openingTab {
+		t.Fatalf("Expected the project list for a new tab, got screen %d", a.screen)
+	}
+	a = update(a, projectLoadedMsg{name: "second", taskStore: secondTasks, groupStore: secondGroups})
+	if len(a.tabs) != 2 || a.tab != 1 || a.projectName != "second" {
+		t.Fatalf("Expected second in a second tab, got %d tabs, tab %d, %q", len(a.tabs), a.tab, a.projectName)
+	}
+	a = update(a, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	filter := a.tasks.statusFilter
+	if filter == "" {
+		t.Fatal("Expected f to set a status filter")
+	}
+	if !strings.Contains(a.View(), " 1 first ") {
+		t.Error("Expected the tab bar with two tabs")
+	}
+
+	// Each tab keeps its own filters
+	a = update(a, tea.KeyMsg{Type: tea.KeyCtrlPgUp})
+	if a.projectName != "first" || a.tasks.statusFilter != "" {
+		t.Errorf("Expected first with no filter, got %q filter %q", a.projectName, a.tasks.statusFilter)
+	}
+	a = update(a, tea.KeyMsg{Type: tea.KeyCtrlPgDown})
+	if a.projectName != "second" || a.tasks.statusFilter != filter {
+		t.Errorf("Expected second with filter %q, got %q filter %q", filter, a.projectName, a.tasks.statusFilter)
+	}
+
+	// Selecting a project open in a tab shows the tab without loading it
+	a = update(a, SelectProjectMsg{Name: "first"})
+	if a.screen != ScreenTasks || a.tab != 0 {
+		t.Errorf("Expected the first tab, got screen %d tab %d", a.screen, a.tab)
+	}
+
+	// Ctrl+W closes the tab; the last one stays
+	a = update(a, tea.KeyMsg{Type: tea.KeyCtrlW})
+	a = update(a, tea.KeyMsg{Type: tea.KeyCtrlW})
+	if len(a.tabs) != 1 || a.projectName != "second" {
+		t.Errorf("Expected only second left, got %d tabs and %q", len(a.tabs), a.projectName)
+	}
+}
+
+func TestApp_ProjectSwitcher(t *testing.T) {
+	firstTasks, firstGroups, firstDir := setupTestTasks(t)
+	defer os.RemoveAll(firstDir)
+	secondTasks, secondGroups, secondDir := setupTestTasks(t)
+	defer os.RemoveAll(secondDir)
+
+	update := func(a App, msg tea.Msg) (App, tea.Cmd) {
+		t.Helper()
+		model, cmd := a.Update(msg)
+		return model.(App), cmd
+	}
+	a := App{width: 100, height: 30}
+	a, _ = update(a, projectLoadedMsg{name: "first", taskStore: firstTasks, groupStore: firstGroups})
+	a, _ = update(a, projectsLoadedMsg{projects: []data.Project{{Name: "first"}, {Name: "sample"}, {Name: "second-app"}}})
+	a.screen = ScreenStats
+
+	a, _ = update(a, tea.KeyMsg{Type: tea.KeyCtrlO})
+	a, _ = update(a, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("sa")})
+	if got := switcherMatches(a.projects.projects, a.switcher.input.Value()); len(got) != 2 || got[0] != "sample" {
+		t.Errorf("Expected sample then second-app for \"sa\", got %v", got)
+	}
+	a, _ = update(a, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("pp")})
+	if view := a.View(); !strings.Contains(view, "second-app") || strings.Contains(view, "sample") {
+		t.Error("Expected only second-app listed for \"sapp\"")
+	}
+
+	// Enter loads the project in place, then shows its stats
+	a, cmd := update(a, tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok := cmd().(SelectProjectMsg)
+	if a.switcher.active || !ok || msg.Name != "second-app" || !msg.InPlace {
+		t.Fatalf("Expected the switcher to close and select second-app, got %#v", msg)
+	}
+	a, _ = update(a, msg)
+	if a.screen != ScreenStats {
+		t.Errorf("Expected the stats screen while loading, got %d", a.screen)
+	}
+	a, cmd = update(a, projectLoadedMsg{seq: a.loadSeq, name: "second-app", taskStore: secondTasks, groupStore: secondGroups})
+	if a.projectName != "second-app" {
+		t.Fatalf("Expected second-app open, got %q", a.projectName)
+	}
+	reopened := false
+	for _, c := range cmd().(tea.BatchMsg) {
+		if c != nil {
+			if _, ok := c().(ShowStatsMsg); ok {
+				reopened = true
+			}
+		}
+	}
+	if !reopened {
+		t.Error("Expected the stats screen to be reopened for second-app")
+	}
+}
+
+func TestApp_UnparsableFiles(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+
+	projectDir := filepath.Join(tasksDir, "proj")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := data.NewTaskStoreForTest(projectDir, []data.Task{{ID: "1", Subject: "Fix login", Status: "pending"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, "9.json"), []byte(`{"id":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	taskStore, err := data.LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupStore, err := data.LoadGroups("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(taskStore.Unparsable) != 1 {
+		t.Fatalf("Expected 9.json unparsable, got %+v", taskStore.Unparsable)
+	}
+	hash := taskStore.Unparsable[0].Hash
+
+	update := func(a App, msg tea.Msg) (App, tea.Cmd) {
+		t.Helper()
+		model, cmd := a.Update(msg)
+		return model.(App), cmd
+	}
+	a := App{width: 100, height: 30}
+	a, _ = update(a, projectLoadedMsg{name: "proj", taskStore: taskStore, groupStore: groupStore})
+	if view := a.View(); !strings.Contains(view, "1 task file(s) could not be parsed") {
+		t.Error("Expected the task list to warn about the unparsable file")
+	}
+
+	a, _ = update(a, ShowProblemsMsg{})
+	view := a.View()
+	if !strings.Contains(view, "9.json") || !strings.Contains(view, "unexpected end of JSON input") {
+		t.Error("Expected the unparsable file listed with its parse error")
+	}
+	if strings.Contains(view, "problem(s) found in task files") {
+		t.Error("Expected the file's schema problem to be left to the unparsable list")
+	}
+
+	// Enter opens the raw file; Esc comes back to the problems
+	a, cmd := update(a, tea.KeyMsg{Type: tea.KeyEnter})
+	a, _ = update(a, cmd())
+	if a.screen != ScreenRawJSON || !strings.Contains(a.View(), `{"id":`) {
+		t.Fatalf("Expected the raw file shown, got screen %d", a.screen)
+	}
+	a, cmd = update(a, tea.KeyMsg{Type: tea.KeyEsc})
+	a, _ = update(a, cmd())
+	if a.screen != ScreenProblems {
+		t.Fatalf("Expected Esc to return to the problems, got screen %d", a.screen)
+	}
+
+	// Ignoring hides the file until its content changes
+	a, cmd = update(a, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	a, _ = update(a, cmd())
+	if a.store.ignored["9.json"] != hash {
+		t.Errorf("Expected the file ignored with its hash, got %v", a.store.ignored)
+	}
+	if view := a.View(); !strings.Contains(view, "All task files are valid.") {
+		t.Error("Expected no problems listed once the file is ignored")
+	}
+	a, _ = update(a, BackToTasksMsg{})
+	if view := a.View(); strings.Contains(view, "could not be parsed") {
+		t.Error("Expected no warning for an ignored file")
+	}
+}
+
+func TestApp_LaunchTask(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	update := func(a App, msg tea.Msg) (App, tea.Cmd) {
+		t.Helper()
+		model, cmd := a.Update(msg)
+		return model.(App), cmd
+	}
+
+	a := App{width: 100, height: 30}
+	a.OpenOnLaunch("test", "2")
+	a, cmd := update(a, projectLoadedMsg{name: "test", taskStore: taskStore, groupStore: groupStore})
+	if cmd == nil {
+		t.Fatal("Expected the launch task to be opened")
+	}
+	if msg, ok := cmd().(ViewTaskMsg); !ok || msg.Task.ID != "2" {
+		t.Errorf("Expected ViewTaskMsg for task 2, got %#v", msg)
+	}
+
+	// An unknown task opens the list and says so
+	a = App{width: 100, height: 30}
+	a.OpenOnLaunch("test", "99")
+	a, _ = update(a, projectLoadedMsg{name: "test", taskStore: taskStore, groupStore: groupStore})
+	if a.screen != ScreenTasks || !strings.Contains(a.View(), "task not found: #99") {
+		t.Errorf("Expected the task list reporting the unknown task, got screen %d", a.screen)
+	}
+	if a.launchTaskID != "" {
+		t.Error("Expected the launch task to be cleared")
+	}
+}
+
+func TestApp_ReloadKeepsBatchEditForm(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+
+	projectDir := filepath.Join(tasksDir, "proj")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := data.NewTaskStoreForTest(projectDir, []data.Task{
+		{ID: "1", Subject: "Fix login", Status: "pending"},
+		{ID: "2", Subject: "Write docs", Status: "pending"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	store, err := data.LoadTasks("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupStore, err := data.LoadGroups("proj")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shared := newProjectStore(store, groupStore)
+	a := App{
+		screen:      ScreenBatchEdit,
+		projectName: "proj",
+		store:       shared,
+		tasks:       NewTasksModel("proj", shared),
+		batchEdit:   NewBatchEditModel([]string{"1", "2"}, shared),
+	}
+	a.batchEdit.valueInput.SetValue("alice")
+
+	// Claude Code updates task 1 while the form is open
+	changed := `{"id":"1","subject":"Fix login","status":"in_progress"}`
+	if err := os.WriteFile(filepath.Join(projectDir, "1.json"), []byte(changed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(projectDir, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	model, _ := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")})
+	a = model.(App)
+	if a.screen != ScreenBatchEdit {
+		t.Fatalf("screen = %d, want the batch edit form kept open", a.screen)
+	}
+	if a.batchEdit.store.tasks.GetTask("1").Status != "in_progress" {
+		t.Error("batch edit not pointed at the reloaded tasks")
+	}
+	if a.batchEdit.valueInput.Value() != "alice" {
+		t.Errorf("form value = %q, want it kept", a.batchEdit.valueInput.Value())
+	}
+	if !strings.Contains(a.batchEdit.View(), "1 of the selected tasks changed on disk") {
+		t.Error("expected a concurrent edit warning")
+	}
+}
+
+func TestNextSizePoll(t *testing.T) {
+	interval := nextSizePoll(0, false)
+	if interval != minSizePoll {
+		t.Fatalf("first interval = %v, want %v", interval, minSizePoll)
+	}
+	for i := 0; i < 10; i++ {
+		interval = nextSizePoll(interval, false)
+	}
+	if interval != maxSizePoll {
+		t.Errorf("idle interval = %v, want it capped at %v", interval, maxSizePoll)
+	}
+	if got := nextSizePoll(interval, true); got != minSizePoll {
+		t.Errorf("interval after resize = %v, want %v", got, minSizePoll)
+	}
+}
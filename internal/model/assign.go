@@ -0,0 +1,86 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// assignPrompt assigns a task to another owner (A): the configured roster
+// and the project's owners are numbered, a digit picks one and 0 removes
+// the owner
+type assignPrompt struct {
+	taskID string   // task being assigned ("" when closed)
+	owners []string // owners offered, at most assignLimit
+}
+
+// assignLimit is how many owners the prompt offers (one digit each)
+const assignLimit = 9
+
+// newAssignPrompt opens the prompt for a task
+func newAssignPrompt(store *data.TaskStore, taskID string) assignPrompt {
+	owners := data.AssignableOwners(store.Tasks)
+	return assignPrompt{taskID: taskID, owners: owners[:min(len(owners), assignLimit)]}
+}
+
+// active reports whether the prompt is shown
+func (p assignPrompt) active() bool {
+	return p.taskID != ""
+}
+
+// update handles a key while the prompt is shown; the prompt closes once an
+// owner is picked or on Esc
+func (p assignPrompt) update(msg tea.KeyMsg, store *projectStore) assignPrompt {
+	key := msg.String()
+	switch {
+	case key == "esc":
+		return assignPrompt{}
+	case key == "0":
+		p.assign(store, "")
+		return assignPrompt{}
+	case len(key) == 1 && key[0] >= '1' && key[0] <= '9':
+		if n := int(key[0] - '0'); n <= len(p.owners) {
+			p.assign(store, p.owners[n-1])
+			return assignPrompt{}
+		}
+	}
+	return p
+}
+
+// assign sets the task's owner and saves; the change is recorded in the
+// history and sent to webhooks listening for assignments
+func (p assignPrompt) assign(store *projectStore, owner string) {
+	task := store.tasks.GetTask(p.taskID)
+	if task == nil || task.Owner == owner {
+		return
+	}
+	task.Owner = owner
+	store.tasks.UpdateTask(*task)
+	store.save()
+}
+
+// view renders the numbered owners
+func (p assignPrompt) view(store *data.TaskStore, width int) string {
+	var b strings.Builder
+	b.WriteString(i18n.Tf("Assign #%s to:", store.DisplayRef(p.taskID)))
+	current := ""
+	if task := store.GetTask(p.taskID); task != nil {
+		current = task.Owner
+	}
+	for i, owner := range p.owners {
+		if strings.EqualFold(owner, current) {
+			owner += "*"
+		}
+		b.WriteString(fmt.Sprintf("  [%d] %s", i+1, owner))
+	}
+	if len(p.owners) == 0 {
+		b.WriteString("  " + i18n.T("(no owners yet; list them in roster in the config)"))
+	}
+	b.WriteString("  " + i18n.T("[0] unassign  [Esc] cancel"))
+	return ui.WarningStyle.Render(ui.Truncate(b.String(), max(width-2, 20)))
+}
@@ -0,0 +1,299 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// BatchEditModel handles the bulk-edit form for filtered tasks
+type BatchEditModel struct {
+	store   *projectStore
+	taskIDs []string
+	width   int
+	height  int
+
+	fieldIdx  int // index into data.BatchFields
+	optionIdx int // selected option for status/priority
+	focusIdx  int // 0=field, 1=value
+
+	valueInput textinput.Model
+
+	confirm bool
+	reason  reasonPrompt // reason for reopening completed tasks (config requireReason)
+	err     error
+	warning string // selected tasks changed on disk while the form was open
+}
+
+// NewBatchEditModel creates a new BatchEditModel for the given tasks
+func NewBatchEditModel(taskIDs []string, store *projectStore) BatchEditModel {
+	valueInput := textinput.New()
+	valueInput.Placeholder = i18n.T("(empty clears the field)")
+	valueInput.CharLimit = 50
+	valueInput.Width = 40
+	valueInput.Prompt = "> "
+
+	return BatchEditModel{
+		store:      store,
+		taskIDs:    taskIDs,
+		valueInput: valueInput,
+	}
+}
+
+// Init initializes the model
+func (m BatchEditModel) Init() tea.Cmd {
+	return nil
+}
+
+// field returns the selected field
+func (m BatchEditModel) field() data.BatchField {
+	return data.BatchFields[m.fieldIdx]
+}
+
+// options returns the fixed choices for the selected field, or nil for free text
+func (m BatchEditModel) options() []string {
+	switch m.field() {
+	case data.BatchStatus:
+		return data.Statuses()
+	case data.BatchPriority:
+		return append([]string{""}, data.Priorities...)
+	}
+	return nil
+}
+
+// change returns the batch change described by the form
+func (m BatchEditModel) change() data.BatchChange {
+	if options := m.options(); options != nil {
+		return data.BatchChange{Field: m.field(), Value: options[m.optionIdx]}
+	}
+	return data.BatchChange{Field: m.field(), Value: strings.TrimSpace(m.valueInput.Value())}
+}
+
+// affectedCount returns how many tasks the change would modify
+func (m BatchEditModel) affectedCount() int {
+	change := m.change()
+	count := 0
+	for _, id := range m.taskIDs {
+		if task := m.store.tasks.GetTask(id); task != nil && change.Changes(*task) {
+			count++
+		}
+	}
+	return count
+}
+
+// Update handles messages
+func (m BatchEditModel) Update(msg tea.Msg) (BatchEditModel, tea.Cmd) {
+	// Reason prompt, after confirming
+	if m.reason.active {
+		var reason string
+		var cmd tea.Cmd
+		m.reason, reason, cmd = m.reason.update(msg)
+		if reason != "" {
+			for _, id := range m.reason.ids {
+				m.store.tasks.SetStatusReason(id, reason)
+			}
+			return m, m.apply()
+		}
+		return m, cmd
+	}
+
+	// Confirmation mode
+	if m.confirm {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch msg.String() {
+			case "y", "Y":
+				if change := m.change(); change.Field == data.BatchStatus {
+					if ids := reasonsNeeded(m.store.tasks, m.taskIDs, change.Value); len(ids) > 0 {
+						m.confirm = false
+						return m, m.reason.open(reopenTitle(m.store.tasks, ids), ids, change.Value)
+					}
+				}
+				return m, m.apply()
+			case "n", "N", "esc":
+				m.confirm = false
+			}
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg {
+				return BackToTasksMsg{}
+			}
+		case "enter", "ctrl+s":
+			m.err = m.change().Validate()
+			if m.err == nil && m.affectedCount() > 0 {
+				m.confirm = true
+			}
+			return m, nil
+		case "tab", "shift+tab", "up", "down":
+			m.focusIdx = 1 - m.focusIdx
+			if m.focusIdx == 1 && m.options() == nil {
+				m.valueInput.Focus()
+				return m, textinput.Blink
+			}
+			m.valueInput.Blur()
+			return m, nil
+		case "left", "right":
+			delta := 1
+			if msg.String() == "left" {
+				delta = -1
+			}
+			if m.focusIdx == 0 {
+				m.fieldIdx = (m.fieldIdx + delta + len(data.BatchFields)) % len(data.BatchFields)
+				m.optionIdx = 0
+				m.valueInput.SetValue("")
+				m.err = nil
+				return m, nil
+			}
+			if options := m.options(); options != nil {
+				m.optionIdx = (m.optionIdx + delta + len(options)) % len(options)
+				return m, nil
+			}
+		}
+	}
+
+	if m.focusIdx == 1 && m.options() == nil {
+		m.valueInput, cmd = m.valueInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// apply writes the change to all tasks and returns to the task list
+func (m *BatchEditModel) apply() tea.Cmd {
+	change := m.change()
+	if _, err := m.store.tasks.ApplyBatch(m.taskIDs, change); err != nil {
+		m.err = err
+		m.confirm = false
+		return nil
+	}
+	if err := m.store.tasks.Save(); err != nil {
+		m.err = err
+		m.confirm = false
+		return nil
+	}
+	if change.Field == data.BatchGroup && change.Value != "" {
+		m.store.groups.EnsureGroupExists(change.Value)
+		m.store.groups.Save()
+	}
+	return func() tea.Msg {
+		return BackToTasksMsg{}
+	}
+}
+
+// View renders the batch edit form
+func (m BatchEditModel) View() string {
+	inputWidth := m.width - 6
+	if inputWidth < 30 {
+		inputWidth = 30
+	}
+	m.valueInput.Width = inputWidth
+	if f := m.field(); f == data.BatchStart || f == data.BatchDue {
+		m.valueInput.Placeholder = "YYYY-MM-DD " + i18n.T("(empty clears the field)")
+	}
+
+	var b strings.Builder
+
+	// Header
+	b.WriteString(ui.Header(i18n.T("Batch Edit"), m.width))
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.MutedStyle.Render(i18n.Tf("%d task(s) match the current filter", len(m.taskIDs))))
+	b.WriteString("\n\n")
+
+	// Field selector
+	fieldLabel := ui.InputLabelStyle.Render(i18n.T("Field:"))
+	if m.focusIdx == 0 {
+		fieldLabel = ui.SelectedStyle.Render(i18n.T("Field:"))
+	}
+	b.WriteString(fieldLabel)
+	b.WriteString(" ")
+	b.WriteString(ui.ActiveButtonStyle.Render(ui.Glyphs.Left + " " + i18n.T(m.field().Label()) + " " + ui.Glyphs.Right))
+	b.WriteString(ui.MutedStyle.Render(fmt.Sprintf("  %d/%d", m.fieldIdx+1, len(data.BatchFields))))
+	b.WriteString("\n\n")
+
+	// Value
+	valueLabel := ui.InputLabelStyle.Render(i18n.T("Value:"))
+	if m.focusIdx == 1 {
+		valueLabel = ui.SelectedStyle.Render(i18n.T("Value:"))
+	}
+	b.WriteString(valueLabel)
+	b.WriteString("\n")
+	if options := m.options(); options != nil {
+		for i, opt := range options {
+			label := opt
+			if label == "" {
+				label = i18n.T("(none)")
+			}
+			if i == m.optionIdx {
+				b.WriteString(ui.ActiveButtonStyle.Render(label))
+			} else {
+				b.WriteString(ui.ButtonStyle.Render(label))
+			}
+			b.WriteString(" ")
+		}
+	} else {
+		b.WriteString(m.valueInput.View())
+	}
+	b.WriteString("\n\n")
+
+	// Preview
+	affected := m.affectedCount()
+	b.WriteString(ui.LabelValue(i18n.T("Preview"), i18n.Tf("%d of %d task(s) will change", affected, len(m.taskIDs))))
+	b.WriteString("\n")
+
+	if m.warning != "" {
+		b.WriteString("\n")
+		b.WriteString(ui.WarningStyle.Render(ui.Glyphs.Warning + " " + m.warning))
+		b.WriteString("\n")
+	}
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+		b.WriteString("\n")
+	}
+
+	if m.reason.active {
+		b.WriteString("\n")
+		b.WriteString(m.reason.view())
+		b.WriteString("\n")
+	}
+
+	if m.confirm {
+		change := m.change()
+		value := change.Value
+		if value == "" {
+			value = i18n.T("(none)")
+		}
+		b.WriteString("\n")
+		b.WriteString(ui.Confirm(
+			i18n.T("Apply Batch Edit"),
+			i18n.Tf("Set %s to \"%s\" on %d task(s)?", i18n.T(change.Field.Label()), value, affected),
+			"y", "n",
+		))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"Tab", "Next"},
+		{"←→", "Choose"},
+		{"Enter", "Apply"},
+		{"Esc", "Cancel"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
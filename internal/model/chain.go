@@ -0,0 +1,123 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// chainPrompt completes a task together with its whole dependency chain (c):
+// first the direction is chosen, the tasks it depends on (a) or the tasks
+// depending on it (d), then the open tasks affected are listed for
+// confirmation.
+type chainPrompt struct {
+	taskID string   // task the chain starts from ("" when closed)
+	ids    []string // open tasks to complete, once the direction is chosen
+}
+
+// chainListLimit is how many affected tasks the confirmation lists
+const chainListLimit = 8
+
+// active reports whether the prompt is shown
+func (p chainPrompt) active() bool {
+	return p.taskID != ""
+}
+
+// openTasks returns the tasks among ids that are not completed
+func openTasks(store *data.TaskStore, ids []string) []string {
+	var open []string
+	for _, id := range ids {
+		if task := store.GetTask(id); task != nil && task.Status != "completed" {
+			open = append(open, id)
+		}
+	}
+	return open
+}
+
+// openChain returns the open tasks among the task and its chain in one direction
+func openChain(store *data.TaskStore, id string, upstream bool) []string {
+	return openTasks(store, append([]string{id}, store.DependencyChain(id, upstream)...))
+}
+
+// update handles a key while the prompt is shown; done is set once the
+// tasks were completed, and the prompt closes on completion or Esc
+func (p chainPrompt) update(msg tea.KeyMsg, store *projectStore) (next chainPrompt, done bool) {
+	if p.ids == nil {
+		switch msg.String() {
+		case "a", "d":
+			if ids := openChain(store.tasks, p.taskID, msg.String() == "a"); len(ids) > 0 {
+				p.ids = ids
+			}
+		case "esc", "n":
+			return chainPrompt{}, false
+		}
+		return p, false
+	}
+
+	switch msg.String() {
+	case "y", "Y":
+		for _, id := range p.ids {
+			if task := store.tasks.GetTask(id); task != nil {
+				task.Status = "completed"
+				store.tasks.UpdateTask(*task)
+			}
+		}
+		store.save()
+		return chainPrompt{}, true
+	case "n", "N", "esc":
+		return chainPrompt{}, false
+	}
+	return p, false
+}
+
+// lines returns how many lines the prompt takes, without the blank line after it
+func (p chainPrompt) lines() int {
+	if p.ids == nil {
+		return 1
+	}
+	n := min(len(p.ids), chainListLimit) + 2 // title, tasks, keys
+	if len(p.ids) > chainListLimit {
+		n++ // "and N more"
+	}
+	return n
+}
+
+// view renders the direction choice, or the tasks about to be completed
+func (p chainPrompt) view(store *data.TaskStore, width int) string {
+	ref := store.DisplayRef(p.taskID)
+	if p.ids == nil {
+		up := len(openTasks(store, store.DependencyChain(p.taskID, true)))
+		down := len(openTasks(store, store.DependencyChain(p.taskID, false)))
+		return ui.WarningStyle.Render(i18n.Tf("Complete #%s with: [a] %d open it depends on  [d] %d open depending on it  [Esc] cancel", ref, up, down))
+	}
+
+	var b strings.Builder
+	b.WriteString(ui.WarningStyle.Render(i18n.Tf("Complete these %d task(s)?", len(p.ids))))
+	for i, id := range p.ids {
+		if i == chainListLimit {
+			b.WriteString("\n" + ui.MutedStyle.Render("  "+i18n.Tf("... and %d more", len(p.ids)-chainListLimit)))
+			break
+		}
+		task := store.GetTask(id)
+		line := fmt.Sprintf("  %s #%s %s", ui.StatusIcon(task.Status), store.DisplayRef(id), task.Subject)
+		b.WriteString("\n" + ui.Truncate(line, max(width-2, 20)))
+	}
+	b.WriteString("\n" + ui.WarningStyle.Render(i18n.T("[y] complete  [n] cancel")))
+	return b.String()
+}
+
+// newlyUnblocked returns the open tasks blocked before a change but not after
+func newlyUnblocked(tasks []data.Task, before, after map[string]bool) []string {
+	var freed []string
+	for _, task := range tasks {
+		if before[task.ID] && !after[task.ID] && task.Status != "completed" {
+			freed = append(freed, task.ID)
+		}
+	}
+	return freed
+}
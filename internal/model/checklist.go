@@ -0,0 +1,160 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// checklistProgress returns a task's checklist progress, e.g. "3/5", or ""
+// when it has no checklist
+func checklistProgress(task data.Task) string {
+	done, total := data.ChecklistProgress(task)
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", done, total)
+}
+
+// newChecklistInput creates the input for adding a checklist item
+func newChecklistInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = i18n.T("New item")
+	input.CharLimit = 200
+	input.Width = 50
+	input.Prompt = "+ "
+	return input
+}
+
+// focusChecklist starts editing the checklist
+func (m *DetailModel) focusChecklist() tea.Cmd {
+	m.checkFocus = true
+	m.checkCursor = 0
+	if len(data.GetTaskChecklist(*m.task())) == 0 {
+		return m.startAddingItem()
+	}
+	return nil
+}
+
+// startAddingItem opens the input for a new checklist item
+func (m *DetailModel) startAddingItem() tea.Cmd {
+	m.checkAdding = true
+	m.checkInput = newChecklistInput()
+	m.checkInput.Focus()
+	return textinput.Blink
+}
+
+// updateChecklist handles messages while the checklist is focused
+func (m DetailModel) updateChecklist(msg tea.Msg) (DetailModel, tea.Cmd) {
+	items := data.GetTaskChecklist(*m.task())
+	if m.checkAdding {
+		if key, ok := msg.(tea.KeyMsg); ok {
+			switch key.String() {
+			case "esc":
+				m.checkAdding = false
+				if len(items) == 0 {
+					m.checkFocus = false
+				}
+				return m, nil
+			case "enter":
+				text := strings.TrimSpace(m.checkInput.Value())
+				if text == "" {
+					return m, nil
+				}
+				m.saveChecklist(append(items, data.ChecklistItem{Text: text}))
+				m.checkCursor = len(items)
+				m.checkInput.Reset() // keep adding until Esc
+				return m, nil
+			}
+		}
+		var cmd tea.Cmd
+		m.checkInput, cmd = m.checkInput.Update(msg)
+		return m, cmd
+	}
+
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.String() {
+	case "esc", "c":
+		m.checkFocus = false
+	case "up", "k":
+		if m.checkCursor > 0 {
+			m.checkCursor--
+		}
+	case "down", "j":
+		if m.checkCursor < len(items)-1 {
+			m.checkCursor++
+		}
+	case " ", "enter":
+		if m.checkCursor < len(items) {
+			items = append([]data.ChecklistItem(nil), items...)
+			items[m.checkCursor].Done = !items[m.checkCursor].Done
+			m.saveChecklist(items)
+		}
+	case "a":
+		return m, m.startAddingItem()
+	case "x", "delete":
+		if m.checkCursor < len(items) {
+			items = append(append([]data.ChecklistItem(nil), items[:m.checkCursor]...), items[m.checkCursor+1:]...)
+			m.saveChecklist(items)
+			if len(items) == 0 {
+				m.checkFocus = false
+			}
+		}
+	case "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// saveChecklist stores the task's checklist and saves
+func (m *DetailModel) saveChecklist(items []data.ChecklistItem) {
+	task := *m.task()
+	data.SetTaskChecklist(&task, items)
+	m.store.tasks.UpdateTask(task)
+	m.store.save()
+	m.reload()
+}
+
+// renderChecklist renders the checklist section, or "" when the task has no
+// checklist and it is not being edited
+func (m DetailModel) renderChecklist(task data.Task) string {
+	items := data.GetTaskChecklist(task)
+	if len(items) == 0 && !m.checkFocus {
+		return ""
+	}
+	var b strings.Builder
+	title := i18n.T("Checklist:")
+	if progress := checklistProgress(task); progress != "" {
+		title = i18n.Tf("Checklist (%s):", progress)
+	}
+	b.WriteString(ui.MutedStyle.Render(title))
+	for i, item := range items {
+		box := "[ ]"
+		if item.Done {
+			box = "[" + ui.Glyphs.Check + "]"
+		}
+		line := "  " + box + " " + ui.Truncate(item.Text, max(m.width-10, 20))
+		switch {
+		case m.checkFocus && !m.checkAdding && i == m.checkCursor:
+			line = ui.TaskSelectedStyle.Render(line)
+		case item.Done:
+			line = ui.CompletedStyle.Render(line)
+		}
+		b.WriteString("\n")
+		b.WriteString(line)
+	}
+	if m.checkAdding {
+		b.WriteString("\n  ")
+		b.WriteString(m.checkInput.View())
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// depChips is a dependency field of the edit form: the linked tasks shown as
+// chips ("#12 Fix login ✓"), one of which is selected with ←→ for removal.
+// Tasks are added through the task picker rather than typed.
+type depChips struct {
+	ids    []string // linked task IDs, in the order they were added
+	cursor int      // selected chip
+}
+
+// newDepChips creates a chip list of task IDs
+func newDepChips(ids []string) depChips {
+	return depChips{ids: append([]string{}, ids...)}
+}
+
+// move selects the previous (delta -1) or next (delta 1) chip
+func (c *depChips) move(delta int) {
+	c.cursor = max(0, min(c.cursor+delta, len(c.ids)-1))
+}
+
+// remove removes the selected chip
+func (c *depChips) remove() {
+	if c.cursor >= len(c.ids) {
+		return
+	}
+	ids := append([]string{}, c.ids[:c.cursor]...)
+	c.ids = append(ids, c.ids[c.cursor+1:]...)
+	c.move(0)
+}
+
+// set replaces the chips with the selected tasks, keeping the order of those
+// already listed and adding new ones in the order of tasks
+func (c *depChips) set(selected map[string]bool, tasks []data.Task) {
+	ids := []string{}
+	listed := make(map[string]bool)
+	for _, id := range c.ids {
+		if selected[id] {
+			ids = append(ids, id)
+			listed[id] = true
+		}
+	}
+	for _, task := range tasks {
+		if selected[task.ID] && !listed[task.ID] {
+			ids = append(ids, task.ID)
+		}
+	}
+	c.ids = ids
+	c.move(0)
+}
+
+// chipSubjectWidth is how much of a task's subject a chip shows
+const chipSubjectWidth = 24
+
+// view renders the chips, wrapped to width; the selected chip is highlighted
+// while the field has focus
+func (c depChips) view(store *data.TaskStore, focused bool, width int) string {
+	if len(c.ids) == 0 {
+		return "  " + ui.MutedStyle.Render(i18n.T("(none, press / to add tasks)"))
+	}
+
+	var lines []string
+	line := " "
+	for i, id := range c.ids {
+		var chip string
+		if task := store.GetTask(id); task != nil {
+			chip = "#" + data.DisplayID(*task) + " " + ui.Truncate(task.Subject, chipSubjectWidth) + " " + ui.StatusIcon(task.Status)
+		} else {
+			chip = "#" + id + " ?"
+		}
+		chip = "[" + chip + "]"
+		switch {
+		case focused && i == c.cursor:
+			chip = ui.SelectedStyle.Render(chip)
+		case store.GetTask(id) == nil:
+			chip = ui.ErrorStyle.Render(chip)
+		}
+
+		if width > 0 && line != " " && lipgloss.Width(line)+1+lipgloss.Width(chip) > width {
+			lines = append(lines, line)
+			line = " "
+		}
+		line += " " + chip
+	}
+	return strings.Join(append(lines, line), "\n")
+}
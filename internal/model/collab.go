@@ -0,0 +1,86 @@
+package model
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// collabTickMsg is sent periodically while a project in collaboration mode
+// is open; ticks of a replaced polling loop are dropped
+type collabTickMsg struct {
+	seq int
+}
+
+// collabTickCmd schedules the next check for collaborators' changes
+func collabTickCmd(seq int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return collabTickMsg{seq: seq}
+	})
+}
+
+// collabInterval returns the time until the next check for collaborators'
+// changes, stretched like the other polls in low-power mode and while idle
+func (a *App) collabInterval() time.Duration {
+	return a.pollInterval(config.Current().Collaboration.PollInterval(), time.Now())
+}
+
+// startCollabPolling starts checking the open project for collaborators'
+// changes when it is in collaboration mode and no check is running yet
+func (a *App) startCollabPolling() tea.Cmd {
+	if a.collabPolling || a.projectName == "" || !data.Collaborating(a.projectName) {
+		return nil
+	}
+	a.collabPolling = true
+	a.collabSeq++
+	return collabTickCmd(a.collabSeq, a.collabInterval())
+}
+
+// updateCollab reloads the open project when collaborators changed it; the
+// polling stops once a project outside collaboration mode is shown. Forms
+// are left alone until they are closed.
+func (a App) updateCollab(msg collabTickMsg) (App, tea.Cmd) {
+	if msg.seq != a.collabSeq {
+		return a, nil
+	}
+	if a.projectName == "" || !data.Collaborating(a.projectName) {
+		a.collabPolling = false
+		return a, nil
+	}
+	if a.showsStatusBar() && !a.inForm() && !a.checkOpenTaskChanged() {
+		a.autoReload("collaboration")
+	}
+	return a, collabTickCmd(a.collabSeq, a.collabInterval())
+}
+
+// renderPresence renders what the latest collaborator did, e.g. "alice
+// edited #14 2m ago", with a count of the others active in the last day
+func renderPresence(store *data.TaskStore, presence []data.Presence, now time.Time) string {
+	p := presence[0]
+	var format string
+	switch p.Action {
+	case "created":
+		format = "%s created #%s %s"
+	case "started":
+		format = "%s started #%s %s"
+	case "completed":
+		format = "%s completed #%s %s"
+	case "assigned":
+		format = "%s assigned #%s %s"
+	case "deleted":
+		format = "%s deleted #%s %s"
+	default:
+		format = "%s edited #%s %s"
+	}
+	text := i18n.Tf(format, p.User, store.DisplayRef(p.TaskID), ui.RelativeTime(p.Time, now))
+	if len(presence) > 1 {
+		text += " " + i18n.Tf("(+%d active)", len(presence)-1)
+	}
+	badge := data.GetOwnerBadge(p.User)
+	return ui.OwnerBadge(badge.Initials, badge.Color) + " " + ui.MutedStyle.Render(text)
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// conflictValueLines is the most lines shown per side of a changed field
+const conflictValueLines = 6
+
+// ConflictModel shows how a task that is being viewed or edited changed on
+// disk, e.g. because Claude Code updated it in the meantime
+type ConflictModel struct {
+	task    data.Task // version loaded when the task was opened
+	changes []data.FieldChange
+	deleted bool // the task file was removed
+	editing bool // unsaved edits are at stake
+	width   int
+	height  int
+}
+
+// NewConflictModel creates a new ConflictModel; current is nil when the task was deleted
+func NewConflictModel(opened data.Task, current *data.Task, editing bool) ConflictModel {
+	m := ConflictModel{task: opened, editing: editing, deleted: current == nil}
+	if current != nil {
+		m.changes = data.DiffTaskFields(opened, *current)
+	}
+	return m
+}
+
+// Init initializes the model
+func (m ConflictModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m ConflictModel) Update(msg tea.Msg) (ConflictModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "enter", "r":
+		return m, func() tea.Msg {
+			return ResolveConflictMsg{Reload: true}
+		}
+	case "k", "esc":
+		// Unsaved edits can be kept; a plain view always shows the new version
+		keep := m.editing && !m.deleted
+		return m, func() tea.Msg {
+			return ResolveConflictMsg{Reload: !keep}
+		}
+	case "q":
+		if !m.editing {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// View renders the field-by-field comparison
+func (m ConflictModel) View() string {
+	var b strings.Builder
+
+	title := i18n.Tf("Changed on disk: Task #%s", data.DisplayID(m.task))
+	b.WriteString(ui.Header(title, m.width))
+	b.WriteString("\n\n")
+
+	if m.deleted {
+		b.WriteString(ui.WarningStyle.Render(i18n.Tf("Task #%s \"%s\" was deleted outside cctasks.", data.DisplayID(m.task), m.task.Subject)))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(ui.WarningStyle.Render(i18n.T("This task was changed outside cctasks while it was open.")))
+		b.WriteString("\n\n")
+
+		labelWidth := 13
+		colWidth := max((m.width-labelWidth-5)/2, 20)
+		column := lipgloss.NewStyle().Width(colWidth)
+		sep := ui.MutedStyle.Render(" " + ui.Glyphs.VLine + " ")
+
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+			strings.Repeat(" ", labelWidth),
+			column.Render(ui.MutedStyle.Render(i18n.T("When opened"))), sep,
+			column.Render(ui.MutedStyle.Render(i18n.T("On disk now")))))
+		b.WriteString("\n")
+		b.WriteString(ui.HorizontalLine(m.width))
+		b.WriteString("\n")
+
+		for _, c := range m.changes {
+			label := lipgloss.NewStyle().Width(labelWidth).Render(ui.LabelStyle.Render(c.Field))
+			old := ui.ErrorStyle.Render(conflictValue(c.Old, colWidth))
+			new := ui.SuccessStyle.Render(conflictValue(c.New, colWidth))
+			b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, label, column.Render(old), sep, column.Render(new)))
+			b.WriteString("\n")
+		}
+	}
+
+	// Footer
+	b.WriteString("\n")
+	var keys [][]string
+	switch {
+	case m.deleted:
+		keys = [][]string{{"Enter", "Back to list"}}
+	case m.editing:
+		keys = [][]string{{"r/Enter", "Reload (discard my edits)"}, {"k/Esc", "Keep editing (my save wins)"}}
+	default:
+		keys = [][]string{{"Enter", "Show new version"}}
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
+
+// conflictValue wraps a field value to the column width, limited to conflictValueLines
+func conflictValue(value string, width int) string {
+	if value == "" {
+		return i18n.T("(empty)")
+	}
+	lines := strings.Split(ui.WordWrap(value, width), "\n")
+	if len(lines) > conflictValueLines {
+		lines = append(lines[:conflictValueLines-1], ui.Glyphs.Ellipsis+" "+i18n.Tf("%d more lines", len(lines)-conflictValueLines+1))
+	}
+	return strings.Join(lines, "\n")
+}
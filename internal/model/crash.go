@@ -0,0 +1,257 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// crashReportPath is the report written by the last recovered panic
+var crashReportPath string
+
+// CrashReportPath returns the crash report written during this run, or ""
+func CrashReportPath() string {
+	return crashReportPath
+}
+
+// CrashGuard wraps the App so that a panic is written to a crash report
+// (with the stack and a snapshot of the app state) and the program quits
+// cleanly, restoring the terminal. An open edit form is saved so it can be
+// restored on the next launch.
+type CrashGuard struct {
+	App App
+}
+
+// NewCrashGuard wraps app in a CrashGuard
+func NewCrashGuard(app App) CrashGuard {
+	return CrashGuard{App: app}
+}
+
+// Init initializes the wrapped app
+func (g CrashGuard) Init() tea.Cmd {
+	return g.App.Init()
+}
+
+// Update forwards msg to the app, quitting with a crash report on panic
+func (g CrashGuard) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.App.crashed(r, msg, debug.Stack())
+			model, cmd = g, tea.Quit
+		}
+	}()
+	next, cmd := g.App.Update(msg)
+	g.App = next.(App)
+	return g, cmd
+}
+
+// View renders the app. A panic while rendering is reported and re-raised
+// so Bubble Tea restores the terminal.
+func (g CrashGuard) View() string {
+	defer func() {
+		if r := recover(); r != nil {
+			g.App.crashed(r, nil, debug.Stack())
+			panic(r)
+		}
+	}()
+	return g.App.View()
+}
+
+// crashed saves the open edit form and writes the crash report. The form of
+// an encrypted project is not saved: the recovery file would keep its
+// subject and description in plain text.
+func (a App) crashed(r any, msg tea.Msg, stack []byte) {
+	slog.Error("panic", "err", r, "screen", int(a.screen), "project", a.projectName)
+	recovered := false
+	if a.screen == ScreenEdit && a.projectName != "" && !data.IsProjectEncrypted(a.projectName) {
+		rec := a.edit.recovery(a.projectName)
+		recovered = rec.Save() == nil
+	}
+	if path, err := writeCrashReport(a.crashReport(r, msg, recovered, stack)); err == nil {
+		crashReportPath = path
+	}
+}
+
+// crashReport describes the panic and the app state at the time
+func (a App) crashReport(r any, msg tea.Msg, recovered bool, stack []byte) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "cctasks %s crash report\n", AppVersion)
+	fmt.Fprintf(&b, "time:    %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "panic:   %v\n", r)
+	if msg != nil {
+		fmt.Fprintf(&b, "message: %T %v\n", msg, msg)
+	}
+	b.WriteString("\nApp state:\n")
+	fmt.Fprintf(&b, "  screen:  %d (previous %d)\n", a.screen, a.prevScreen)
+	fmt.Fprintf(&b, "  size:    %dx%d\n", a.width, a.height)
+	fmt.Fprintf(&b, "  project: %s\n", a.projectName)
+	if a.store != nil {
+		fmt.Fprintf(&b, "  tasks:   %d (%d problems)\n", len(a.store.tasks.Tasks), len(a.store.tasks.Problems))
+	}
+	if a.screen == ScreenDetail && a.detail.taskID != "" {
+		fmt.Fprintf(&b, "  task:    #%s\n", a.detail.taskID)
+	}
+	if a.screen == ScreenEdit && a.edit.task != nil {
+		fmt.Fprintf(&b, "  editing: #%s (new: %t, focus %d)\n", a.edit.task.ID, a.edit.isNew, a.edit.focusIdx)
+	}
+	fmt.Fprintf(&b, "  follow:  %t\n", a.follow)
+	if recovered {
+		b.WriteString("\nThe edit form was saved and will be offered for restoring on the next launch.\n")
+	}
+	b.WriteString("\nStack:\n")
+	b.Write(stack)
+	return b.String()
+}
+
+// writeCrashReport writes report to a new file in the crash directory
+func writeCrashReport(report string) (string, error) {
+	dir, err := config.GetCrashDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "crash-"+time.Now().Format("20060102-150405")+".txt")
+	return path, os.WriteFile(path, []byte(report), 0644)
+}
+
+// recovery captures the edit form's current content
+func (m EditModel) recovery(projectName string) config.EditRecovery {
+	r := config.EditRecovery{
+		Project:     projectName,
+		IsNew:       m.isNew,
+		Subject:     m.subjectInput.Value(),
+		Description: m.descInput.Value(),
+		Status:      itemAt(m.statuses, m.statusIdx),
+		Group:       itemAt(m.groups, m.groupIdx),
+		Owner:       m.ownerInput.Value(),
+		Blocks:      strings.Join(m.displayRefs(m.blocks.ids), ", "),
+		BlockedBy:   strings.Join(m.displayRefs(m.blockedBy.ids), ", "),
+		Estimate:    m.estimateInput.Value(),
+		Milestone:   itemAt(m.milestones, m.milestoneIdx),
+		SavedAt:     time.Now(),
+	}
+	if !m.isNew && m.task != nil {
+		r.TaskID = m.task.ID
+	}
+	return r
+}
+
+// applyRecovery fills the form with content saved by a crash
+func (m *EditModel) applyRecovery(r config.EditRecovery) {
+	m.subjectInput.SetValue(r.Subject)
+	m.descInput.SetValue(r.Description)
+	m.ownerInput.SetValue(r.Owner)
+	m.blocks = newDepChips(m.resolveRefs(parseTaskIDs(r.Blocks)))
+	m.blockedBy = newDepChips(m.resolveRefs(parseTaskIDs(r.BlockedBy)))
+	m.estimateInput.SetValue(r.Estimate)
+	for i, s := range m.statuses {
+		if s == r.Status {
+			m.statusIdx = i
+		}
+	}
+	for i, g := range m.groups {
+		if g == r.Group {
+			m.groupIdx = i
+		}
+	}
+	// Select the saved milestone, keeping the offered list
+	names := append([]string(nil), m.milestones[1:]...)
+	m.milestones, m.milestoneIdx = []string{""}, 0
+	if r.Milestone != "" {
+		m.milestones, m.milestoneIdx = []string{"", r.Milestone}, 1
+	}
+	m.SetMilestones(names)
+}
+
+// itemAt returns list[i], or "" if i is out of range
+func itemAt(list []string, i int) string {
+	if i < 0 || i >= len(list) {
+		return ""
+	}
+	return list[i]
+}
+
+// RecoverModel offers to restore an edit form saved by a crash
+type RecoverModel struct {
+	recovery config.EditRecovery
+	width    int
+	height   int
+}
+
+// NewRecoverModel creates a new RecoverModel
+func NewRecoverModel(r config.EditRecovery) RecoverModel {
+	return RecoverModel{recovery: r}
+}
+
+// Init initializes the model
+func (m RecoverModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m RecoverModel) Update(msg tea.Msg) (RecoverModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "y", "Y", "enter":
+		return m, func() tea.Msg { return RecoverEditMsg{Restore: true} }
+	case "n", "N", "esc":
+		return m, func() tea.Msg { return RecoverEditMsg{Restore: false} }
+	case "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// View renders the restore prompt
+func (m RecoverModel) View() string {
+	var b strings.Builder
+	r := m.recovery
+
+	b.WriteString(ui.Header(i18n.T("Restore Unsaved Edit"), m.width))
+	b.WriteString("\n\n")
+	b.WriteString(i18n.Tf("cctasks quit unexpectedly at %s while a task was being edited.",
+		r.SavedAt.Local().Format("2006-01-02 15:04")))
+	b.WriteString("\n\n")
+
+	task := i18n.T("new task")
+	if !r.IsNew {
+		task = "#" + r.TaskID
+	}
+	maxLen := max(m.width-16, 20)
+	b.WriteString(ui.LabelStyle.Render(i18n.T("Project")) + ui.ValueStyle.Render(r.Project) + "\n")
+	b.WriteString(ui.LabelStyle.Render(i18n.T("Task")) + ui.ValueStyle.Render(task) + "\n")
+	b.WriteString(ui.LabelStyle.Render(i18n.T("Subject")) + ui.ValueStyle.Render(ui.Truncate(r.Subject, maxLen)) + "\n")
+	if desc := strings.TrimSpace(r.Description); desc != "" {
+		firstLine, _, _ := strings.Cut(desc, "\n")
+		b.WriteString(ui.LabelStyle.Render(i18n.T("Description")) + ui.MutedStyle.Render(ui.Truncate(firstLine, maxLen)) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(ui.ActiveButtonStyle.Render(i18n.T("Restore") + " (y)"))
+	b.WriteString(" ")
+	b.WriteString(ui.ButtonStyle.Render(i18n.T("Discard") + " (n)"))
+	b.WriteString("\n\n")
+
+	keys := [][]string{
+		{"y/Enter", "Reopen the edit form"},
+		{"n/Esc", "Discard"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestCrashGuard_WritesReportAndRecovery(t *testing.T) {
+	testutil.IsolateHome(t)
+	defer func() { crashReportPath = "" }()
+
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	shared := newProjectStore(taskStore, groupStore)
+	edit := NewEditModel(taskStore.GetTask("1"), shared, false)
+	edit.subjectInput.SetValue("Task 1 (unsaved rename)")
+	g := NewCrashGuard(App{
+		screen:      ScreenEdit,
+		projectName: "test",
+		store:       shared,
+		edit:        edit,
+	})
+
+	// A nil task makes the handler panic
+	_, cmd := g.Update(ViewTaskMsg{Task: nil})
+	if cmd == nil {
+		t.Fatal("expected quit command after panic")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("CrashGuard should quit after a panic")
+	}
+
+	report, err := os.ReadFile(CrashReportPath())
+	if err != nil {
+		t.Fatalf("crash report not written: %v", err)
+	}
+	for _, want := range []string{"panic:", "project: test", "editing: #1", "Stack:"} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+
+	rec, err := config.LoadRecovery()
+	if err != nil || rec == nil {
+		t.Fatalf("recovery not saved: %v", err)
+	}
+	if rec.Project != "test" || rec.TaskID != "1" || rec.Subject != "Task 1 (unsaved rename)" || rec.Group != "Backend" {
+		t.Errorf("recovery = %+v", rec)
+	}
+}
+
+func TestCrashGuard_NoRecoveryForEncryptedProject(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+	defer func() { crashReportPath = "" }()
+	if err := os.MkdirAll(filepath.Join(tasksDir, "secret"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tasksDir, "secret", data.EncryptionFileName), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+	shared := newProjectStore(taskStore, groupStore)
+	edit := NewEditModel(taskStore.GetTask("1"), shared, false)
+	edit.subjectInput.SetValue("Secret rename")
+	g := NewCrashGuard(App{screen: ScreenEdit, projectName: "secret", store: shared, edit: edit})
+
+	g.Update(ViewTaskMsg{Task: nil})
+	if rec, _ := config.LoadRecovery(); rec != nil {
+		t.Errorf("the edit form of an encrypted project should not be saved: %+v", rec)
+	}
+	if report, _ := os.ReadFile(CrashReportPath()); strings.Contains(string(report), "Secret rename") {
+		t.Errorf("the crash report should not contain the form:\n%s", report)
+	}
+}
+
+func TestApp_RestoresEditAfterCrash(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+
+	projectDir := filepath.Join(tasksDir, "proj")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := data.NewTaskStoreForTest(projectDir, []data.Task{
+		{ID: "1", Subject: "Fix login", Status: "pending"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	rec := &config.EditRecovery{
+		Project:     "proj",
+		TaskID:      "1",
+		Subject:     "Fix login redirect",
+		Description: "half-written notes",
+		Status:      "in_progress",
+		Milestone:   "v2",
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewApp()
+	if a.screen != ScreenRecover {
+		t.Fatalf("screen = %d, want restore prompt", a.screen)
+	}
+
+	model, cmd := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	a = runCmds(model.(App), cmd, func(a App) bool { return a.screen == ScreenEdit })
+	if a.screen != ScreenEdit {
+		t.Fatalf("screen = %d, want edit form", a.screen)
+	}
+	if got := a.edit.subjectInput.Value(); got != "Fix login redirect" {
+		t.Errorf("subject = %q", got)
+	}
+	if got := a.edit.descInput.Value(); got != "half-written notes" {
+		t.Errorf("description = %q", got)
+	}
+	if a.edit.statuses[a.edit.statusIdx] != "in_progress" || a.edit.milestones[a.edit.milestoneIdx] != "v2" {
+		t.Errorf("status/milestone not restored: %s / %s", a.edit.statuses[a.edit.statusIdx], a.edit.milestones[a.edit.milestoneIdx])
+	}
+	if a.edit.isNew || a.edit.task.ID != "1" {
+		t.Error("should edit the existing task")
+	}
+
+	if again, _ := config.LoadRecovery(); again != nil {
+		t.Error("recovery file should be removed once restored")
+	}
+}
@@ -3,34 +3,111 @@ package model
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
+	"github.com/jss826/cctasks/internal/config"
 	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
 	"github.com/jss826/cctasks/internal/ui"
 )
 
 // DetailModel handles the task detail screen
 type DetailModel struct {
-	task       *data.Task
-	taskStore  *data.TaskStore
-	groupStore *data.GroupStore
-	width      int
-	height     int
+	taskID string
+	seen   data.Task // the task as last shown; kept if it is deleted on disk
+	store  *projectStore
+	width  int
+	height int
 
 	// Delete confirmation
 	confirmDelete bool
 
+	// Reason asked for a status change, and the last reason recorded
+	reason     reasonPrompt
+	lastReason *data.HistoryEntry
+
+	// Reason asked when rejecting a task under review
+	reject reasonPrompt
+
 	// Scrolling
 	scrollOffset int
+
+	// Checklist editing (c): focused, selected item and the new item input
+	checkFocus  bool
+	checkCursor int
+	checkAdding bool
+	checkInput  textinput.Model
+
+	// Dependency navigation (Tab): 0=off, 1=blocks, 2=blockedBy, 3=open
+	// tasks waiting for this one
+	depSection int
+	depCursor  int
+	depErr     error
+
+	// Task picker for adding dependencies
+	pickerActive   bool
+	pickerSearch   textinput.Model
+	pickerTasks    []data.Task
+	pickerCursor   int
+	pickerSelected map[string]bool
+
+	// Follow mode (F) is on; shown in the header
+	following bool
+
+	// Tasks unblocked by completing this one, shown until the next key
+	unblocked unblockedPrompt
+
+	// Details jumped from through dependencies; Esc returns to the last one
+	back []string
 }
 
 // NewDetailModel creates a new DetailModel
-func NewDetailModel(task *data.Task, taskStore *data.TaskStore, groupStore *data.GroupStore) DetailModel {
-	return DetailModel{
-		task:       task,
-		taskStore:  taskStore,
-		groupStore: groupStore,
+func NewDetailModel(task *data.Task, store *projectStore) DetailModel {
+	m := DetailModel{
+		taskID:         task.ID,
+		seen:           *task,
+		store:          store,
+		pickerSearch:   newPickerSearch(),
+		pickerSelected: make(map[string]bool),
+	}
+	m.loadReason()
+	return m
+}
+
+// task returns the shown task from the shared store, or the last version
+// seen if it has since been deleted
+func (m DetailModel) task() *data.Task {
+	if task := m.store.tasks.GetTask(m.taskID); task != nil {
+		return task
+	}
+	seen := m.seen
+	return &seen
+}
+
+// reload takes in a change to the shared store, keeping the scroll position
+// and dependency focus
+func (m *DetailModel) reload() {
+	if task := m.store.tasks.GetTask(m.taskID); task != nil {
+		m.seen = *task
+	}
+	if m.depCursor >= len(m.depIDs()) {
+		m.depCursor = max(len(m.depIDs())-1, 0)
+	}
+	if items := data.GetTaskChecklist(m.seen); m.checkCursor >= len(items) {
+		m.checkCursor = max(len(items)-1, 0)
+	}
+	m.loadReason()
+}
+
+// loadReason looks up the last status change reason of the task in the history
+func (m *DetailModel) loadReason() {
+	m.lastReason = nil
+	if entry, ok := m.store.tasks.LastStatusReason(m.taskID); ok {
+		m.lastReason = &entry
 	}
 }
 
@@ -41,6 +118,15 @@ func (m DetailModel) Init() tea.Cmd {
 
 // Update handles messages
 func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && m.unblocked.active() {
+		var id string
+		var handled bool
+		m.unblocked, id, handled = m.unblocked.update(key)
+		if handled {
+			return m, m.jumpTo(id)
+		}
+	}
+
 	// Delete confirmation mode
 	if m.confirmDelete {
 		switch msg := msg.(type) {
@@ -48,8 +134,15 @@ func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 			switch msg.String() {
 			case "y", "Y":
 				// Delete the task
-				m.taskStore.DeleteTask(m.task.ID)
-				m.taskStore.Save()
+				if err := m.store.tasks.DeleteTask(m.taskID); err != nil {
+					m.store.err = err
+					m.confirmDelete = false
+					return m, nil
+				}
+				if m.store.save() != nil {
+					m.confirmDelete = false
+					return m, nil
+				}
 				return m, func() tea.Msg {
 					return BackToTasksMsg{}
 				}
@@ -60,6 +153,48 @@ func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 		return m, nil
 	}
 
+	// Status change reason prompt
+	if m.reason.active {
+		var reason string
+		var cmd tea.Cmd
+		m.reason, reason, cmd = m.reason.update(msg)
+		if reason != "" {
+			m.store.err = m.reason.apply(m.store.tasks, reason)
+			m.reload()
+		}
+		return m, cmd
+	}
+
+	// Review rejection prompt
+	if m.reject.active {
+		var note string
+		var cmd tea.Cmd
+		m.reject, note, cmd = m.reject.update(msg)
+		if note != "" {
+			m.review(false, note)
+		}
+		return m, cmd
+	}
+
+	// Handle picker mode
+	if m.pickerActive {
+		return m.updatePicker(msg)
+	}
+
+	// Handle checklist editing
+	if m.checkFocus {
+		return m.updateChecklist(msg)
+	}
+
+	// Handle dependency navigation mode
+	if m.depSection != 0 {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			if handled, cmd := m.updateDependencies(msg); handled {
+				return m, cmd
+			}
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.MouseMsg:
 		switch msg.Button {
@@ -74,18 +209,21 @@ func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
+		if readOnlyDetailKeys[msg.String()] && m.store.readOnly() {
+			return m, nil
+		}
 		switch msg.String() {
 		case "esc", "left":
-			return m, func() tea.Msg {
-				return BackToTasksMsg{}
-			}
+			return m, m.goBack()
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			return m, m.jumpToDependency(int(msg.String()[0] - '0'))
 		case "j", "down":
-			taskID := m.task.ID
+			taskID := m.taskID
 			return m, func() tea.Msg {
 				return NextTaskMsg{CurrentID: taskID}
 			}
 		case "k", "up":
-			taskID := m.task.ID
+			taskID := m.taskID
 			return m, func() tea.Msg {
 				return PrevTaskMsg{CurrentID: taskID}
 			}
@@ -104,16 +242,47 @@ func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 			m.scrollOffset = m.maxScroll()
 			return m, nil
 		case "e":
+			task := m.task()
 			return m, func() tea.Msg {
-				return EditTaskMsg{Task: m.task}
+				return EditTaskMsg{Task: task}
 			}
 		case "s":
 			// Cycle status
-			m.cycleStatus()
+			return m, m.cycleStatus()
+		case "b":
+			return m, m.toggleBlocked()
+		case "c":
+			return m, m.focusChecklist()
+		case "A":
+			if m.task().Status == data.StatusNeedsReview {
+				m.review(true, "")
+			}
+			return m, nil
+		case "R":
+			if m.task().Status == data.StatusNeedsReview {
+				return m, m.reject.open(i18n.Tf("Why is #%s rejected?", data.DisplayID(*m.task())), []string{m.taskID}, "pending")
+			}
 			return m, nil
 		case "d":
 			m.confirmDelete = true
 			return m, nil
+		case "L":
+			task := m.task()
+			return m, func() tea.Msg {
+				return ShowHistoryMsg{Task: task}
+			}
+		case "F":
+			return m, func() tea.Msg {
+				return ToggleFollowMsg{}
+			}
+		case "J":
+			task := m.task()
+			return m, func() tea.Msg {
+				return ShowRawJSONMsg{Task: task}
+			}
+		case "tab":
+			m.focusDependencies(1)
+			return m, nil
 		case "q":
 			return m, tea.Quit
 		}
@@ -122,116 +291,537 @@ func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 	return m, nil
 }
 
-func (m *DetailModel) cycleStatus() {
-	statuses := []string{"pending", "in_progress", "completed"}
+// depIDs returns the dependency IDs of the focused section
+func (m DetailModel) depIDs() []string {
+	switch m.depSection {
+	case 1:
+		return m.task().Blocks
+	case 2:
+		return m.task().BlockedBy
+	case 3:
+		return m.waitingTasks()
+	}
+	return nil
+}
+
+// waitingTasks returns the open tasks waiting for this task
+func (m DetailModel) waitingTasks() []string {
+	waiting, _ := data.WaitingTasks(m.store.tasks.Tasks, m.taskID)
+	return waiting
+}
+
+// nextDepSection returns the dependency section after (delta 1) or before
+// (delta -1) the focused one; the waiting list is skipped when empty
+func (m DetailModel) nextDepSection(delta int) int {
+	sections := 3
+	if len(m.waitingTasks()) > 0 {
+		sections = 4
+	}
+	return (min(m.depSection, sections-1) + delta + sections) % sections
+}
+
+// numberedDependencies returns the Blocks entries followed by the BlockedBy
+// entries, numbered from 1 in that order for jumping
+func (m DetailModel) numberedDependencies() []string {
+	task := m.task()
+	return append(append([]string{}, task.Blocks...), task.BlockedBy...)
+}
+
+// jumpToDependency opens the numbered dependency n
+func (m DetailModel) jumpToDependency(n int) tea.Cmd {
+	if deps := m.numberedDependencies(); n <= len(deps) {
+		return m.jumpTo(deps[n-1])
+	}
+	return nil
+}
+
+// jumpTo opens a linked task's detail; Esc there comes back to this one
+func (m DetailModel) jumpTo(id string) tea.Cmd {
+	linked := m.store.tasks.GetTask(id)
+	if linked == nil {
+		return nil
+	}
+	back := append(append([]string{}, m.back...), m.taskID)
+	return func() tea.Msg {
+		return ViewTaskMsg{Task: linked, Back: back}
+	}
+}
+
+// goBack returns to the detail this one was jumped to from, skipping deleted
+// tasks, or to the task list
+func (m DetailModel) goBack() tea.Cmd {
+	for i := len(m.back) - 1; i >= 0; i-- {
+		if task := m.store.tasks.GetTask(m.back[i]); task != nil {
+			back := m.back[:i]
+			return func() tea.Msg {
+				return ViewTaskMsg{Task: task, Back: back}
+			}
+		}
+	}
+	return func() tea.Msg {
+		return BackToTasksMsg{}
+	}
+}
+
+// focusDependencies moves dependency focus to a section and scrolls it into view
+func (m *DetailModel) focusDependencies(section int) {
+	m.depSection = section
+	m.depCursor = 0
+	m.depErr = nil
+	m.scrollOffset = m.maxScroll() // dependencies are at the bottom of the body
+}
+
+// readOnlyDetailKeys are the detail screen keys that change the task,
+// refused for projects that can only be browsed
+var readOnlyDetailKeys = map[string]bool{
+	"e": true, "s": true, "b": true, "c": true, "A": true, "R": true, "d": true,
+}
+
+// updateDependencies handles keys while a dependency list is focused.
+// It reports whether the key was handled.
+func (m *DetailModel) updateDependencies(msg tea.KeyMsg) (bool, tea.Cmd) {
+	ids := m.depIDs()
+	switch msg.String() {
+	case "esc":
+		m.depSection = 0
+		m.depErr = nil
+	case "tab":
+		m.focusDependencies(m.nextDepSection(1))
+	case "shift+tab":
+		m.focusDependencies(m.nextDepSection(-1))
+	case "up", "k":
+		if m.depCursor > 0 {
+			m.depCursor--
+		}
+	case "down", "j":
+		if m.depCursor < len(ids)-1 {
+			m.depCursor++
+		}
+	case "enter", "right":
+		if m.depCursor < len(ids) {
+			return true, m.jumpTo(ids[m.depCursor])
+		}
+	case "x", "delete":
+		if m.store.readOnly() {
+			return true, nil
+		}
+		if m.depCursor < len(ids) && m.depSection != 3 {
+			m.removeDependency(ids[m.depCursor])
+		}
+	case "a", "+":
+		if m.store.readOnly() {
+			return true, nil
+		}
+		if m.depSection != 3 {
+			m.openPicker()
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// removeDependency removes the edge between this task and id in the focused section
+func (m *DetailModel) removeDependency(id string) {
+	if m.depSection == 1 {
+		m.store.tasks.RemoveDependency(m.taskID, id)
+	} else {
+		m.store.tasks.RemoveDependency(id, m.taskID)
+	}
+	m.depErr = m.store.tasks.Save()
+	m.reload()
+	if m.depCursor >= len(m.depIDs()) && m.depCursor > 0 {
+		m.depCursor--
+	}
+}
+
+// openPicker opens the task picker for the focused dependency section
+func (m *DetailModel) openPicker() {
+	m.pickerActive = true
+	m.pickerSearch.SetValue("")
+	m.pickerSearch.Focus()
+	m.pickerCursor = 0
+	m.pickerSelected = make(map[string]bool)
+	for _, id := range m.depIDs() {
+		m.pickerSelected[id] = true
+	}
+	m.filterPickerTasks()
+}
+
+func (m *DetailModel) filterPickerTasks() {
+	m.pickerTasks = pickerCandidates(m.store.tasks.Tasks, m.taskID, m.pickerSearch.Value())
+	if m.pickerCursor >= len(m.pickerTasks) {
+		m.pickerCursor = len(m.pickerTasks) - 1
+	}
+	if m.pickerCursor < 0 {
+		m.pickerCursor = 0
+	}
+}
+
+func (m DetailModel) updatePicker(msg tea.Msg) (DetailModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.pickerActive = false
+			m.pickerSearch.Blur()
+			return m, nil
+		case "enter":
+			// Toggle selection
+			if len(m.pickerTasks) > 0 && m.pickerCursor < len(m.pickerTasks) {
+				id := m.pickerTasks[m.pickerCursor].ID
+				m.pickerSelected[id] = !m.pickerSelected[id]
+			}
+			return m, nil
+		case "tab":
+			// Confirm and close picker
+			m.applyPickerSelection()
+			m.pickerActive = false
+			m.pickerSearch.Blur()
+			return m, nil
+		case "up":
+			if m.pickerCursor > 0 {
+				m.pickerCursor--
+			}
+			return m, nil
+		case "down":
+			if m.pickerCursor < len(m.pickerTasks)-1 {
+				m.pickerCursor++
+			}
+			return m, nil
+		}
+	}
+
+	// Update search input
+	m.pickerSearch, cmd = m.pickerSearch.Update(msg)
+	m.filterPickerTasks()
+
+	return m, cmd
+}
+
+// applyPickerSelection adds and removes edges so the focused section matches the picker
+func (m *DetailModel) applyPickerSelection() {
+	current := make(map[string]bool)
+	for _, id := range m.depIDs() {
+		current[id] = true
+	}
+
+	m.depErr = nil
+	for _, task := range m.store.tasks.Tasks {
+		id := task.ID
+		switch {
+		case m.pickerSelected[id] && !current[id]:
+			var err error
+			if m.depSection == 1 {
+				err = m.store.tasks.AddDependency(m.taskID, id)
+			} else {
+				err = m.store.tasks.AddDependency(id, m.taskID)
+			}
+			if err != nil && m.depErr == nil {
+				m.depErr = err
+			}
+		case !m.pickerSelected[id] && current[id]:
+			if m.depSection == 1 {
+				m.store.tasks.RemoveDependency(m.taskID, id)
+			} else {
+				m.store.tasks.RemoveDependency(id, m.taskID)
+			}
+		}
+	}
+	if err := m.store.tasks.Save(); err != nil {
+		m.depErr = err
+	}
+	m.reload()
+	m.depCursor = 0
+}
+
+// toggleBlocked clears the task's blocked flag, or asks why it is blocked
+// to set it
+func (m *DetailModel) toggleBlocked() tea.Cmd {
+	task := *m.task()
+	if data.GetTaskBlockedReason(task) != "" {
+		data.SetTaskBlockedReason(&task, "")
+		m.store.tasks.UpdateTask(task)
+		m.store.save()
+		m.reload()
+		return nil
+	}
+	if task.Status == "completed" {
+		return nil
+	}
+	return m.reason.open(i18n.Tf("Why is #%s blocked?", data.DisplayID(task)), []string{task.ID}, "")
+}
+
+// review approves (completes) or rejects (reopens) a task under review,
+// recording the reviewer
+func (m *DetailModel) review(approve bool, note string) {
+	task := *m.task()
+	_, freed := data.WaitingTasks(m.store.tasks.Tasks, task.ID)
+	if approve {
+		data.ApproveTask(&task, data.Reviewer(), time.Now())
+	} else {
+		data.RejectTask(&task, data.Reviewer(), note, time.Now())
+	}
+	m.store.tasks.UpdateTask(task)
+	m.store.save()
+	m.reload()
+	m.noteUnblocked(freed)
+}
+
+// noteUnblocked offers to open the tasks that waited only for this one,
+// once it is completed
+func (m *DetailModel) noteUnblocked(freed []string) {
+	if m.task().Status == "completed" {
+		m.unblocked.open(freed)
+	}
+}
+
+// cycleStatus moves the task to the next status, asking for a reason first
+// when the config requires one
+func (m *DetailModel) cycleStatus() tea.Cmd {
+	statuses := data.Statuses()
+	task := *m.task()
 	for i, s := range statuses {
-		if s == m.task.Status {
-			m.task.Status = statuses[(i+1)%len(statuses)]
-			m.taskStore.UpdateTask(*m.task)
-			m.taskStore.Save()
-			return
+		if s == task.Status {
+			next := statuses[(i+1)%len(statuses)]
+			if ids := reasonsNeeded(m.store.tasks, []string{task.ID}, next); len(ids) > 0 {
+				return m.reason.open(reopenTitle(m.store.tasks, ids), ids, next)
+			}
+			_, freed := data.WaitingTasks(m.store.tasks.Tasks, task.ID)
+			task.Status = next
+			m.store.tasks.UpdateTask(task)
+			m.store.save()
+			m.reload()
+			m.noteUnblocked(freed)
+			return nil
 		}
 	}
+	return nil
 }
 
 // buildBody builds the scrollable body content (everything between header and footer)
 func (m DetailModel) buildBody() string {
 	var b strings.Builder
+	task := m.task()
 
 	// Delete confirmation dialog
 	if m.confirmDelete {
 		dialog := ui.Confirm(
-			"Delete Task",
-			fmt.Sprintf("Are you sure you want to delete task #%s?\n\"%s\"", m.task.ID, m.task.Subject),
+			i18n.T("Delete Task"),
+			i18n.Tf("Move task #%s to the trash?\n\"%s\"", data.DisplayID(*task), task.Subject),
 			"y", "n",
 		)
 		b.WriteString(dialog)
 		b.WriteString("\n\n")
 	}
-
-	// Basic info
-	b.WriteString(ui.LabelValue("Subject", m.task.Subject))
-	b.WriteString("\n")
-
-	statusBadge := ui.StatusBadge(m.task.Status)
-	b.WriteString(ui.LabelStyle.Render("Status:") + " " + statusBadge)
-	b.WriteString(ui.MutedStyle.Render("  (s: cycle)"))
-	b.WriteString("\n")
-
-	group := data.GetTaskGroup(*m.task)
-	if group == "" {
-		group = "Uncategorized"
+	if m.reason.active {
+		b.WriteString(m.reason.view())
+		b.WriteString("\n\n")
+	}
+	if m.reject.active {
+		b.WriteString(m.reject.view())
+		b.WriteString("\n\n")
+	}
+	if m.unblocked.active() {
+		b.WriteString(m.unblocked.view(m.store.tasks, m.width))
+		b.WriteString("\n\n")
 	}
-	color := m.groupStore.GetGroupColor(group)
-	groupBadge := ui.GroupBadge(group, color)
-	b.WriteString(ui.LabelStyle.Render("Group:") + " " + groupBadge)
-	b.WriteString("\n")
 
-	if m.task.Owner != "" {
-		b.WriteString(ui.LabelValue("Owner", m.task.Owner))
+	// Basic info
+	if info, ok := executeTaskTemplate(config.Current().Templates.Detail, *task); ok {
+		b.WriteString(strings.TrimRight(info, "\n"))
 		b.WriteString("\n")
+	} else {
+		b.WriteString(m.renderInfo(task))
 	}
 
 	// Description section
 	b.WriteString("\n")
 	b.WriteString(ui.HorizontalLine(m.width))
 	b.WriteString("\n")
-	b.WriteString(ui.MutedStyle.Render("Description:"))
+	b.WriteString(ui.MutedStyle.Render(i18n.T("Description:")))
 	b.WriteString("\n")
 
-	if m.task.Description != "" {
-		desc := ui.WordWrap(m.task.Description, m.width-8)
+	if task.Description != "" {
+		desc := ui.WordWrap(task.Description, m.width-8)
 		b.WriteString(desc)
 	} else {
-		b.WriteString(ui.MutedStyle.Render("(no description)"))
+		b.WriteString(ui.MutedStyle.Render(i18n.T("(no description)")))
 	}
 	b.WriteString("\n")
 
+	// Checklist section
+	if checklist := m.renderChecklist(*task); checklist != "" {
+		b.WriteString("\n")
+		b.WriteString(ui.HorizontalLine(m.width))
+		b.WriteString("\n")
+		b.WriteString(checklist)
+		b.WriteString("\n")
+	}
+
 	// Dependencies section
 	b.WriteString("\n")
 	b.WriteString(ui.HorizontalLine(m.width))
 	b.WriteString("\n")
-	b.WriteString(ui.MutedStyle.Render("Dependencies:"))
+	b.WriteString(ui.MutedStyle.Render(i18n.T("Dependencies:")))
 	b.WriteString("\n")
 
-	// Blocks
-	b.WriteString("  Blocks:    ")
-	if len(m.task.Blocks) > 0 {
-		var blockStrs []string
-		for _, id := range m.task.Blocks {
-			task := m.taskStore.GetTask(id)
-			if task != nil {
-				blockStrs = append(blockStrs, fmt.Sprintf("#%s %s", id, task.Subject))
-			} else {
-				blockStrs = append(blockStrs, fmt.Sprintf("#%s", id))
-			}
+	blocksLabel, blockedByLabel := i18n.T("Blocks:"), i18n.T("BlockedBy:")
+	labelWidth := max(lipgloss.Width(blocksLabel), lipgloss.Width(blockedByLabel)) + 1
+	b.WriteString(m.renderDependencyList(ui.PadRight(blocksLabel, labelWidth), task.Blocks, 1))
+	b.WriteString("\n")
+	b.WriteString(m.renderDependencyList(ui.PadRight(blockedByLabel, labelWidth), task.BlockedBy, 2))
+	if waiting := m.waitingTasks(); len(waiting) > 0 {
+		b.WriteString("\n\n  ")
+		heading := i18n.Tf("This task blocks %d incomplete tasks:", len(waiting))
+		if len(waiting) == 1 {
+			heading = i18n.T("This task blocks 1 incomplete task:")
 		}
-		b.WriteString(strings.Join(blockStrs, ", "))
-	} else {
-		b.WriteString(ui.MutedStyle.Render("(none)"))
+		b.WriteString(ui.WarningStyle.Render(heading))
+		b.WriteString("\n")
+		b.WriteString(m.renderDependencyList(ui.PadRight("", labelWidth), waiting, 3))
+	}
+
+	if m.depErr != nil {
+		b.WriteString("\n")
+		b.WriteString(ui.ErrorStyle.Render(fmt.Sprintf("  %v", m.depErr)))
 	}
+
+	return b.String()
+}
+
+// renderInfo renders the built-in fields above the description
+func (m DetailModel) renderInfo(task *data.Task) string {
+	var b strings.Builder
+	b.WriteString(ui.LabelValueWrapped(i18n.T("Subject"), task.Subject, m.width-8))
 	b.WriteString("\n")
 
-	// BlockedBy
-	b.WriteString("  BlockedBy: ")
-	if len(m.task.BlockedBy) > 0 {
-		var blockedByStrs []string
-		for _, id := range m.task.BlockedBy {
-			task := m.taskStore.GetTask(id)
-			if task != nil {
-				blockedByStrs = append(blockedByStrs, fmt.Sprintf("#%s %s", id, task.Subject))
-			} else {
-				blockedByStrs = append(blockedByStrs, fmt.Sprintf("#%s", id))
-			}
+	statusBadge := ui.StatusBadge(task.Status)
+	b.WriteString(ui.LabelStyle.Render(i18n.T("Status")+":") + " " + statusBadge)
+	b.WriteString(ui.MutedStyle.Render("  " + i18n.T("(s: cycle)")))
+	b.WriteString("\n")
+	if reason := data.GetTaskBlockedReason(*task); task.Status != "completed" && reason != "" {
+		b.WriteString(ui.LabelStyle.Render(i18n.T("Blocked")+":") + " " + ui.BlockedStyle.Render(ui.StatusIcon("blocked")+" "+reason))
+		b.WriteString("\n")
+	} else if data.BlockedTasks(m.store.tasks.Tasks)[task.ID] {
+		b.WriteString(ui.LabelStyle.Render(i18n.T("Blocked")+":") + " " + ui.BlockedStyle.Render(ui.StatusIcon("blocked")+" "+i18n.T("waiting for open dependencies")))
+		b.WriteString("\n")
+	}
+	if now := time.Now(); m.store.tasks.StaleTasks(config.Current().TaskList.StaleDuration(), now)[task.ID] {
+		touched := ui.RelativeTime(m.store.tasks.TaskModTime(task.ID), now)
+		b.WriteString(ui.LabelStyle.Render(i18n.T("Stale")+":") + " " + ui.WarningStyle.Render(i18n.Tf("in progress, last touched %s", touched)))
+		b.WriteString("\n")
+	}
+	if review, ok := data.GetTaskReview(*task); ok {
+		text := i18n.Tf("%s by %s on %s", i18n.T(review.Result), review.Reviewer, review.Time.Local().Format(data.DateFormat))
+		if review.Note != "" {
+			text += ": " + review.Note
 		}
-		b.WriteString(strings.Join(blockedByStrs, ", "))
-	} else {
-		b.WriteString(ui.MutedStyle.Render("(none)"))
+		b.WriteString(ui.LabelValueWrapped(i18n.T("Review"), text, m.width-8))
+		b.WriteString("\n")
+	}
+	if r := m.lastReason; r != nil && r.To == task.Status {
+		b.WriteString(ui.LabelValueWrapped(i18n.T("Reason"), fmt.Sprintf("%s (%s, %s %s %s)", r.Reason, r.Time.Local().Format(data.DateFormat), i18n.T(r.From), ui.Glyphs.Arrow, i18n.T(r.To)), m.width-8))
+		b.WriteString("\n")
+	}
+
+	group := data.GetTaskGroup(*task)
+	if group == "" {
+		group = "Uncategorized"
+	}
+	color := m.store.groups.GetGroupColor(group)
+	groupBadge := ui.GroupBadge(displayGroupName(group), color)
+	b.WriteString(ui.LabelStyle.Render(i18n.T("Group")+":") + " " + groupBadge)
+	b.WriteString("\n")
+
+	owner := task.Owner
+	if data.IsAgentTask(*task) {
+		owner = strings.TrimSpace(owner + " " + ui.Glyphs.Agent + " " + i18n.T("agent"))
+	}
+	if owner != "" {
+		b.WriteString(ui.LabelStyle.Render(i18n.T("Owner") + ":"))
+		if task.Owner != "" {
+			badge := data.GetOwnerBadge(task.Owner)
+			b.WriteString(" " + ui.OwnerBadge(badge.Initials, badge.Color))
+		}
+		b.WriteString(" " + ui.ValueStyle.Render(owner))
+		b.WriteString("\n")
+	}
+
+	if priority := data.GetTaskPriority(*task); priority != "" {
+		b.WriteString(ui.LabelValue(i18n.T("Priority"), i18n.T(priority)))
+		b.WriteString("\n")
 	}
 
+	if start := data.GetTaskStart(*task); start != "" {
+		b.WriteString(ui.LabelValue(i18n.T("Start"), start))
+		b.WriteString("\n")
+	}
+
+	if due := data.GetTaskDue(*task); due != "" {
+		b.WriteString(ui.LabelValue(i18n.T("Due"), due))
+		b.WriteString("\n")
+	}
+
+	if milestone := data.GetTaskMilestone(*task); milestone != "" {
+		b.WriteString(ui.LabelValue(i18n.T("Milestone"), milestone))
+		b.WriteString("\n")
+	}
+
+	if tags := data.GetTaskTags(*task); len(tags) > 0 {
+		b.WriteString(ui.LabelValue(i18n.T("Tags"), strings.Join(tags, ", ")))
+		b.WriteString("\n")
+	}
 	return b.String()
 }
 
+// renderDependencyList renders one dependency list, one task per line,
+// highlighting the cursor when the section is focused. Blocks and BlockedBy
+// entries are numbered for jumping with 1-9.
+func (m DetailModel) renderDependencyList(label string, ids []string, section int) string {
+	first := 0 // number of the first entry (0 when unnumbered)
+	switch section {
+	case 1:
+		first = 1
+	case 2:
+		first = len(m.task().Blocks) + 1
+	}
+
+	focused := m.depSection == section
+	prefix := "  "
+	if focused {
+		prefix = ui.SelectedStyle.Render(ui.Glyphs.Cursor + " ")
+	}
+	if len(ids) == 0 {
+		return prefix + label + ui.MutedStyle.Render(i18n.T("(none)"))
+	}
+
+	indent := strings.Repeat(" ", lipgloss.Width(label)+2)
+	var lines []string
+	for i, id := range ids {
+		text := fmt.Sprintf("#%s", id)
+		if task := m.store.tasks.GetTask(id); task != nil {
+			text = fmt.Sprintf("#%s %s", data.DisplayID(*task), task.Subject)
+		}
+		if focused && i == m.depCursor {
+			text = ui.TaskSelectedStyle.Render(text)
+		}
+		if n := first + i; first > 0 && n <= 9 {
+			text = ui.KeyStyle.Render(fmt.Sprintf("%d", n)) + " " + text
+		} else if first > 0 {
+			text = "  " + text
+		}
+		if i == 0 {
+			lines = append(lines, prefix+label+text)
+		} else {
+			lines = append(lines, indent+text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // viewportHeight returns the number of lines available for body content
 func (m DetailModel) viewportHeight() int {
 	// header: 2 lines (title + horizontal line) + 1 empty line = 3
@@ -269,10 +859,21 @@ func (m *DetailModel) clampScroll() {
 
 // View renders the task detail screen
 func (m DetailModel) View() string {
+	if m.pickerActive {
+		fieldName := "Blocks"
+		if m.depSection == 2 {
+			fieldName = "Blocked By"
+		}
+		return renderTaskPicker(i18n.T(fieldName), m.pickerSearch, m.pickerTasks, m.pickerCursor, m.pickerSelected, m.width)
+	}
+
 	var result strings.Builder
 
 	// Header
-	title := fmt.Sprintf("Task #%s", m.task.ID)
+	title := i18n.Tf("Task #%s", data.DisplayID(*m.task()))
+	if m.following {
+		title += "  [" + i18n.T("following") + "]"
+	}
 	result.WriteString(ui.Header(title, m.width))
 	result.WriteString("\n\n")
 
@@ -304,7 +905,7 @@ func (m DetailModel) View() string {
 	} else {
 		// Top scroll indicator
 		if scrollOffset > 0 {
-			result.WriteString(ui.MutedStyle.Render(fmt.Sprintf("  ↑ %d lines above", scrollOffset)))
+			result.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Up + " " + i18n.Tf("%d lines above", scrollOffset)))
 			result.WriteString("\n")
 		}
 
@@ -328,13 +929,36 @@ func (m DetailModel) View() string {
 		// Bottom scroll indicator
 		remaining = totalLines - endIdx
 		if remaining > 0 {
-			result.WriteString(ui.MutedStyle.Render(fmt.Sprintf("  ↓ %d lines below", remaining)))
+			result.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Down + " " + i18n.Tf("%d lines below", remaining)))
 			result.WriteString("\n")
 		}
 	}
 
 	// Footer - context-aware
-	if m.confirmDelete {
+	if m.depSection != 0 {
+		hasDeps := len(m.depIDs()) > 0
+		editable := m.depSection != 3 // the waiting list follows the other tasks' dependencies
+		hints := []ui.KeyHint{
+			{Key: "↑↓", Desc: "Select", Enabled: hasDeps},
+			{Key: "Enter", Desc: "Open", Enabled: hasDeps},
+			{Key: "x", Desc: "Remove", Enabled: hasDeps && editable},
+			{Key: "a", Desc: "Add", Enabled: editable},
+			{Key: "Tab", Desc: "Next list", Enabled: true},
+			{Key: "Esc", Desc: "Done", Enabled: true},
+		}
+		result.WriteString(ui.FooterWithHints(hints, m.width))
+	} else if m.checkFocus {
+		hasItems := len(data.GetTaskChecklist(*m.task())) > 0
+		hints := []ui.KeyHint{
+			{Key: "↑↓", Desc: "Select", Enabled: hasItems && !m.checkAdding},
+			{Key: "Space", Desc: "Toggle", Enabled: hasItems && !m.checkAdding},
+			{Key: "a", Desc: "Add", Enabled: !m.checkAdding},
+			{Key: "x", Desc: "Remove", Enabled: hasItems && !m.checkAdding},
+			{Key: "Enter", Desc: "Add", Enabled: m.checkAdding},
+			{Key: "Esc", Desc: "Done", Enabled: true},
+		}
+		result.WriteString(ui.FooterWithHints(hints, m.width))
+	} else if m.confirmDelete {
 		hints := []ui.KeyHint{
 			{Key: "y", Desc: "Confirm", Enabled: true},
 			{Key: "n", Desc: "Cancel", Enabled: true},
@@ -348,7 +972,21 @@ func (m DetailModel) View() string {
 			// Task operations
 			{Key: "e", Desc: "Edit", Enabled: true},
 			{Key: "s", Desc: "Status", Enabled: true},
+			{Key: "b", Desc: "Blocked", Enabled: m.task().Status != "completed" || data.GetTaskBlockedReason(*m.task()) != ""},
+			{Key: "c", Desc: "Checklist", Enabled: true},
 			{Key: "d", Desc: "Delete", Enabled: true},
+			{Key: "L", Desc: "History", Enabled: true},
+			{Key: "J", Desc: "Raw JSON", Enabled: true},
+			{Key: "Tab", Desc: "Deps", Enabled: true},
+			{Key: "F", Desc: "Follow", Enabled: true},
+		}
+		if len(m.numberedDependencies()) > 0 {
+			hints = append(hints, ui.KeyHint{Key: "1-9", Desc: "Open dependency", Enabled: true})
+		}
+		if m.task().Status == data.StatusNeedsReview {
+			hints = append(hints,
+				ui.KeyHint{Key: "A", Desc: "Approve", Enabled: true},
+				ui.KeyHint{Key: "R", Desc: "Reject", Enabled: true})
 		}
 		if needsScroll {
 			hints = append(hints, ui.KeyHint{Key: "PgUp/Dn", Desc: "Scroll", Enabled: true})
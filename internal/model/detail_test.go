@@ -0,0 +1,398 @@
+package model
+
+import (
+	"os"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+func setupDetailTest(t *testing.T) (DetailModel, *data.TaskStore, string) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-detail-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := []data.Task{
+		{ID: "1", Subject: "Task 1", Status: "pending", Blocks: []string{"2", "3"}, BlockedBy: []string{}},
+		{ID: "2", Subject: "Task 2", Status: "pending", Blocks: []string{}, BlockedBy: []string{"1"}},
+		{ID: "3", Subject: "Task 3", Status: "pending", Blocks: []string{}, BlockedBy: []string{"1"}},
+		{ID: "4", Subject: "Task 4", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	}
+	taskStore, err := data.NewTaskStoreForTest(tmpDir, tasks)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatal(err)
+	}
+	groupStore, err := data.NewGroupStoreForTest(tmpDir, nil)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatal(err)
+	}
+
+	m := NewDetailModel(taskStore.GetTask("1"), newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 30
+	return m, taskStore, tmpDir
+}
+
+func TestDetailModel_DependencyNavigation(t *testing.T) {
+	m, _, tmpDir := setupDetailTest(t)
+	defer os.RemoveAll(tmpDir)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.depSection != 1 {
+		t.Fatalf("Expected Blocks section focused after Tab, got %d", m.depSection)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.depCursor != 1 {
+		t.Errorf("Expected depCursor 1, got %d", m.depCursor)
+	}
+
+	// Enter jumps to the linked task
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected command from Enter")
+	}
+	msg, ok := cmd().(ViewTaskMsg)
+	if !ok || msg.Task.ID != "3" {
+		t.Errorf("Expected ViewTaskMsg for task 3, got %#v", cmd())
+	}
+
+	// Esc leaves dependency mode
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.depSection != 0 {
+		t.Errorf("Expected dependency mode off after Esc, got %d", m.depSection)
+	}
+}
+
+func TestDetailModel_RemoveDependency(t *testing.T) {
+	m, taskStore, tmpDir := setupDetailTest(t)
+	defer os.RemoveAll(tmpDir)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+
+	if len(m.task().Blocks) != 1 || m.task().Blocks[0] != "3" {
+		t.Errorf("Expected blocks [3] after removing #2, got %v", m.task().Blocks)
+	}
+	if blocked := taskStore.GetTask("2").BlockedBy; len(blocked) != 0 {
+		t.Errorf("Expected reverse edge removed from task 2, got %v", blocked)
+	}
+}
+
+func TestDetailModel_AddDependencyViaPicker(t *testing.T) {
+	m, taskStore, tmpDir := setupDetailTest(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Focus BlockedBy and open the picker
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if !m.pickerActive {
+		t.Fatal("Expected picker to be active")
+	}
+
+	// Select task 4 and confirm
+	for i, task := range m.pickerTasks {
+		if task.ID == "4" {
+			m.pickerCursor = i
+		}
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+
+	if m.pickerActive {
+		t.Error("Expected picker closed after Tab")
+	}
+	if len(m.task().BlockedBy) != 1 || m.task().BlockedBy[0] != "4" {
+		t.Errorf("Expected blockedBy [4], got %v", m.task().BlockedBy)
+	}
+	if blocks := taskStore.GetTask("4").Blocks; len(blocks) != 1 || blocks[0] != "1" {
+		t.Errorf("Expected task 4 to block #1, got %v", blocks)
+	}
+}
+
+func TestDetailModel_Template(t *testing.T) {
+	m, _, tmpDir := setupDetailTest(t)
+	defer os.RemoveAll(tmpDir)
+
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	cfg := config.Default()
+	cfg.Templates.Detail = "{{.Subject}} ({{.StatusLabel}})\nblocks {{join .Blocks \", \"}}"
+	config.SetCurrent(cfg)
+
+	view := m.View()
+	if !containsStr(view, "Task 1 (pending)") || !containsStr(view, "blocks 2, 3") {
+		t.Errorf("detail template not used:\n%s", view)
+	}
+	if containsStr(view, "(s: cycle)") {
+		t.Error("the template should replace the built-in fields")
+	}
+	if !containsStr(view, "Description:") || !containsStr(view, "Dependencies:") {
+		t.Error("description and dependencies should stay below the template")
+	}
+}
+
+func TestDetailModel_ReopenAsksForReason(t *testing.T) {
+	m, taskStore, tmpDir := setupDetailTest(t)
+	defer os.RemoveAll(tmpDir)
+
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	cfg := config.Default()
+	cfg.RequireReason = []string{data.ReasonReopen}
+	config.SetCurrent(cfg)
+
+	taskStore.GetTask("1").Status = "completed"
+	taskStore.Save()
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if !m.reason.active {
+		t.Fatal("reopening should ask for a reason")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.reason.active || taskStore.GetTask("1").Status != "completed" {
+		t.Fatal("Esc should cancel the status change")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.reason.active {
+		t.Fatal("an empty reason should not be accepted")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Fails on Windows")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.reason.active || taskStore.GetTask("1").Status != "pending" {
+		t.Fatalf("status = %s, want pending after giving a reason", taskStore.GetTask("1").Status)
+	}
+	if !containsStr(m.View(), "Fails on Windows") {
+		t.Error("the reason should be shown with the status")
+	}
+}
+
+func TestDetailModel_ToggleBlocked(t *testing.T) {
+	m, taskStore, tmpDir := setupDetailTest(t)
+	defer os.RemoveAll(tmpDir)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if !m.reason.active {
+		t.Fatal("b should ask why the task is blocked")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Waiting for design")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := data.GetTaskBlockedReason(*taskStore.GetTask("1")); got != "Waiting for design" {
+		t.Fatalf("blocked reason = %q", got)
+	}
+	if taskStore.GetTask("1").Status != "pending" {
+		t.Error("flagging blocked should not change the status")
+	}
+	if !containsStr(m.View(), "Waiting for design") {
+		t.Error("the blocked reason should be shown")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if m.reason.active || data.GetTaskBlockedReason(*taskStore.GetTask("1")) != "" {
+		t.Error("b should clear the flag")
+	}
+}
+
+func TestDetailModel_Review(t *testing.T) {
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	cfg := config.Default()
+	cfg.Review = config.ReviewConfig{Enabled: true, Reviewer: "alice"}
+	config.SetCurrent(cfg)
+
+	m, taskStore, tmpDir := setupDetailTest(t)
+	defer os.RemoveAll(tmpDir)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	if taskStore.GetTask("1").Status != "pending" {
+		t.Fatal("A should only approve tasks awaiting review")
+	}
+
+	task := *taskStore.GetTask("1")
+	task.Status = data.StatusNeedsReview
+	taskStore.UpdateTask(task)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	if !m.reject.active {
+		t.Fatal("R should ask why the task is rejected")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Needs tests")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	review, ok := data.GetTaskReview(*taskStore.GetTask("1"))
+	if taskStore.GetTask("1").Status != "pending" || !ok || review.Result != data.ReviewRejected ||
+		review.Reviewer != "alice" || review.Note != "Needs tests" {
+		t.Fatalf("after reject: status %q, review %+v", taskStore.GetTask("1").Status, review)
+	}
+	if !containsStr(m.View(), "Needs tests") {
+		t.Error("the review note should be shown")
+	}
+
+	task = *taskStore.GetTask("1")
+	task.Status = data.StatusNeedsReview
+	taskStore.UpdateTask(task)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	review, _ = data.GetTaskReview(*taskStore.GetTask("1"))
+	if taskStore.GetTask("1").Status != "completed" || review.Result != data.ReviewApproved {
+		t.Errorf("after approve: status %q, review %+v", taskStore.GetTask("1").Status, review)
+	}
+}
+
+func TestDetailModel_Checklist(t *testing.T) {
+	m, taskStore, tmpDir := setupDetailTest(t)
+	defer os.RemoveAll(tmpDir)
+
+	// c on a task without a checklist starts adding items
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if !m.checkFocus || !m.checkAdding {
+		t.Fatal("c should start adding checklist items")
+	}
+	for _, text := range []string{"Write tests", "Update docs"} {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if items := data.GetTaskChecklist(*taskStore.GetTask("1")); len(items) != 2 || items[1].Text != "Update docs" {
+		t.Fatalf("checklist = %+v", items)
+	}
+
+	// Space toggles the selected item
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if done, total := data.ChecklistProgress(*taskStore.GetTask("1")); done != 1 || total != 2 {
+		t.Errorf("progress = %d/%d, want 1/2", done, total)
+	}
+	if !data.GetTaskChecklist(*taskStore.GetTask("1"))[0].Done {
+		t.Error("space should toggle the selected (first) item")
+	}
+	if !containsStr(m.View(), "(1/2)") {
+		t.Error("the checklist progress should be shown")
+	}
+
+	// x removes the selected item, Esc leaves the checklist
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if items := data.GetTaskChecklist(*taskStore.GetTask("1")); len(items) != 1 || items[0].Text != "Update docs" {
+		t.Errorf("checklist after remove = %+v", items)
+	}
+	if m.checkFocus {
+		t.Error("Esc should leave the checklist")
+	}
+}
+
+func TestDetailModel_WaitingTasks(t *testing.T) {
+	m, taskStore, tmpDir := setupDetailTest(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Task 3 also waits for task 4
+	task3 := taskStore.GetTask("3")
+	task3.BlockedBy = append(task3.BlockedBy, "4")
+
+	if !containsStr(m.View(), "This task blocks 2 incomplete tasks:") {
+		t.Errorf("Expected the waiting tasks in the view, got:\n%s", m.View())
+	}
+
+	// Tab reaches the waiting list after Blocks and BlockedBy, and Enter jumps
+	for i := 0; i < 3; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	if m.depSection != 3 {
+		t.Fatalf("Expected the waiting list focused, got section %d", m.depSection)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected command from Enter")
+	}
+	if msg, ok := cmd().(ViewTaskMsg); !ok || msg.Task.ID != "3" {
+		t.Errorf("Expected ViewTaskMsg for task 3, got %#v", cmd())
+	}
+
+	// The waiting list cannot be edited from here
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if len(taskStore.GetTask("3").BlockedBy) != 2 {
+		t.Error("Expected x to leave the waiting list alone")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// Completing the task reports the tasks it unblocked; 3 still waits for 4
+	taskStore.GetTask("1").Status = "in_progress"
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if m.task().Status != "completed" {
+		t.Fatalf("Expected task 1 completed, got %s", m.task().Status)
+	}
+	if len(m.unblocked.ids) != 1 || m.unblocked.ids[0] != "2" {
+		t.Errorf("Expected task 2 reported unblocked, got %v", m.unblocked.ids)
+	}
+	if view := m.View(); !containsStr(view, "Unblocked #2") || containsStr(view, "incomplete tasks") {
+		t.Errorf("Expected the unblocked prompt and no waiting tasks once completed, got:\n%s", view)
+	}
+
+	// The prompt goes away with the next key, which still does its job
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	if m.unblocked.active() {
+		t.Errorf("Expected the prompt dismissed, got %v", m.unblocked.ids)
+	}
+
+	// Enter opens the unblocked task, keeping the way back
+	taskStore.GetTask("1").Status = "in_progress"
+	m.unblocked.open([]string{"2"})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected command from Enter")
+	}
+	if msg, ok := cmd().(ViewTaskMsg); !ok || msg.Task.ID != "2" || len(msg.Back) != 1 || msg.Back[0] != "1" {
+		t.Errorf("Expected ViewTaskMsg for task 2 back to 1, got %#v", cmd())
+	}
+}
+
+func TestDetailModel_JumpToDependency(t *testing.T) {
+	m, taskStore, tmpDir := setupDetailTest(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Blocks entries are numbered first: 1 is #2, 2 is #3
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	if cmd == nil {
+		t.Fatal("Expected command from 2")
+	}
+	msg, ok := cmd().(ViewTaskMsg)
+	if !ok || msg.Task.ID != "3" || len(msg.Back) != 1 || msg.Back[0] != "1" {
+		t.Fatalf("Expected ViewTaskMsg for task 3 back to 1, got %#v", cmd())
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}}); cmd != nil {
+		t.Error("Expected no jump past the last dependency")
+	}
+
+	// From #3, jumping to its blocker (#1) and back unwinds the chain
+	m3 := NewDetailModel(msg.Task, m.store)
+	m3.back = msg.Back
+	_, cmd = m3.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	next := cmd().(ViewTaskMsg)
+	if next.Task.ID != "1" || len(next.Back) != 2 {
+		t.Fatalf("Expected task 1 with two tasks to go back to, got %s %v", next.Task.ID, next.Back)
+	}
+	m1 := NewDetailModel(next.Task, m.store)
+	m1.back = next.Back
+	_, cmd = m1.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if back, ok := cmd().(ViewTaskMsg); !ok || back.Task.ID != "3" || len(back.Back) != 1 {
+		t.Fatalf("Expected Esc to go back to task 3, got %#v", cmd())
+	}
+
+	// Deleted tasks are skipped, and an empty stack goes back to the list
+	taskStore.DeleteTask("3")
+	_, cmd = m1.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if back, ok := cmd().(ViewTaskMsg); !ok || back.Task.ID != "1" || len(back.Back) != 0 {
+		t.Fatalf("Expected Esc to skip the deleted task, got %#v", cmd())
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := cmd().(BackToTasksMsg); !ok {
+		t.Errorf("Expected Esc without history to go back to the list, got %#v", cmd())
+	}
+}
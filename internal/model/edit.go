@@ -2,42 +2,56 @@ package model
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
+	"github.com/jss826/cctasks/internal/config"
 	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
 	"github.com/jss826/cctasks/internal/ui"
 )
 
 // EditModel handles the task edit/create screen
 type EditModel struct {
-	task       *data.Task
-	taskStore  *data.TaskStore
-	groupStore *data.GroupStore
-	isNew      bool
-	width      int
-	height     int
+	task   *data.Task
+	store  *projectStore
+	isNew  bool
+	width  int
+	height int
 
 	// Form fields
-	subjectInput   textinput.Model
-	descInput      textarea.Model
-	ownerInput     textinput.Model
-	blocksInput    textinput.Model
-	blockedByInput textinput.Model
+	subjectInput  textinput.Model
+	descInput     textarea.Model
+	ownerInput    textinput.Model
+	estimateInput textinput.Model
+
+	// Dependency fields
+	blocks    depChips
+	blockedBy depChips
 
 	// Selectors
-	statusIdx int
-	groupIdx  int
+	statusIdx    int
+	groupIdx     int
+	milestoneIdx int
 
 	// Focus management
-	focusIdx int // 0=subject, 1=desc, 2=status, 3=group, 4=owner, 5=blocks, 6=blockedBy
+	focusIdx int // 0=subject, 1=desc, 2=status, 3=group, 4=owner, 5=blocks, 6=blockedBy, 7=estimate, 8=milestone
 
 	// Available options
-	statuses []string
-	groups   []string
+	statuses   []string
+	groups     []string
+	milestones []string // "" (none) followed by milestone names
+
+	// Reason asked before saving a reopened task (config requireReason)
+	reason reasonPrompt
+
+	// Set once saving was refused, so field errors show until fixed
+	validated bool
 
 	// Task picker mode (for blocks/blockedBy)
 	pickerActive   bool
@@ -46,13 +60,23 @@ type EditModel struct {
 	pickerTasks    []data.Task // filtered tasks
 	pickerCursor   int
 	pickerSelected map[string]bool // selected task IDs
+
+	// Group picker mode: a searchable list of groups that can also create one
+	groupPickerActive bool
+	groupSearch       textinput.Model
+	groupOptions      []string // "" (none) and the groups matching the search
+	groupCreate       string   // group offered for creation ("" when none)
+	groupCursor       int      // index into groupOptions, then the create row
 }
 
+// editFieldCount is the number of focusable fields in the edit form
+const editFieldCount = 9
+
 // NewEditModel creates a new EditModel
-func NewEditModel(task *data.Task, taskStore *data.TaskStore, groupStore *data.GroupStore, isNew bool) EditModel {
+func NewEditModel(task *data.Task, store *projectStore, isNew bool) EditModel {
 	// Subject input
 	subjectInput := textinput.New()
-	subjectInput.Placeholder = "Task subject"
+	subjectInput.Placeholder = i18n.T("Task subject")
 	subjectInput.CharLimit = 200
 	subjectInput.Width = 60
 	subjectInput.Prompt = "> "
@@ -60,7 +84,7 @@ func NewEditModel(task *data.Task, taskStore *data.TaskStore, groupStore *data.G
 
 	// Description input
 	descInput := textarea.New()
-	descInput.Placeholder = "Task description..."
+	descInput.Placeholder = i18n.T("Task description...")
 	descInput.CharLimit = 2000
 	descInput.SetWidth(60)
 	descInput.SetHeight(4)
@@ -69,51 +93,40 @@ func NewEditModel(task *data.Task, taskStore *data.TaskStore, groupStore *data.G
 
 	// Owner input
 	ownerInput := textinput.New()
-	ownerInput.Placeholder = "Owner (optional)"
+	ownerInput.Placeholder = i18n.T("Owner (optional)")
 	ownerInput.CharLimit = 50
 	ownerInput.Width = 40
 	ownerInput.Prompt = "> "
 
-	// Blocks input
-	blocksInput := textinput.New()
-	blocksInput.Placeholder = "Task IDs (comma-separated, e.g. 1,2,3)"
-	blocksInput.CharLimit = 100
-	blocksInput.Width = 40
-	blocksInput.Prompt = "> "
-
-	// BlockedBy input
-	blockedByInput := textinput.New()
-	blockedByInput.Placeholder = "Task IDs (comma-separated, e.g. 1,2,3)"
-	blockedByInput.CharLimit = 100
-	blockedByInput.Width = 40
-	blockedByInput.Prompt = "> "
+	// Estimate input
+	estimateInput := textinput.New()
+	estimateInput.Placeholder = i18n.T("Estimate (optional, e.g. 4 or 1.5)")
+	estimateInput.CharLimit = 10
+	estimateInput.Width = 40
+	estimateInput.Prompt = "> "
 
 	// Picker search input
-	pickerSearch := textinput.New()
-	pickerSearch.Placeholder = "Type to search tasks..."
-	pickerSearch.CharLimit = 50
-	pickerSearch.Width = 40
-	pickerSearch.Prompt = "/ "
+	pickerSearch := newPickerSearch()
 
 	// Statuses
-	statuses := []string{"pending", "in_progress", "completed"}
+	statuses := data.Statuses()
 
 	// Groups
-	groups := append([]string{""}, groupStore.GetGroupNames()...)
+	groups := append([]string{""}, store.groups.GetGroupNames()...)
 
 	m := EditModel{
-		taskStore:      taskStore,
-		groupStore:     groupStore,
+		store:          store,
 		isNew:          isNew,
 		subjectInput:   subjectInput,
 		descInput:      descInput,
 		ownerInput:     ownerInput,
-		blocksInput:    blocksInput,
-		blockedByInput: blockedByInput,
+		estimateInput:  estimateInput,
 		statuses:       statuses,
 		groups:         groups,
+		milestones:     []string{""},
 		pickerSearch:   pickerSearch,
 		pickerSelected: make(map[string]bool),
+		groupSearch:    newGroupSearch(),
 	}
 
 	if isNew {
@@ -132,8 +145,11 @@ func NewEditModel(task *data.Task, taskStore *data.TaskStore, groupStore *data.G
 		m.subjectInput.SetValue(task.Subject)
 		m.descInput.SetValue(task.Description)
 		m.ownerInput.SetValue(task.Owner)
-		m.blocksInput.SetValue(strings.Join(task.Blocks, ", "))
-		m.blockedByInput.SetValue(strings.Join(task.BlockedBy, ", "))
+		m.blocks = newDepChips(task.Blocks)
+		m.blockedBy = newDepChips(task.BlockedBy)
+		if estimate := data.GetTaskEstimate(*task); estimate > 0 {
+			m.estimateInput.SetValue(strconv.FormatFloat(estimate, 'f', -1, 64))
+		}
 
 		// Find status index
 		for i, s := range statuses {
@@ -143,19 +159,40 @@ func NewEditModel(task *data.Task, taskStore *data.TaskStore, groupStore *data.G
 			}
 		}
 
-		// Find group index
-		taskGroup := data.GetTaskGroup(*task)
-		for i, g := range groups {
-			if g == taskGroup {
-				m.groupIdx = i
-				break
-			}
+		// Find group index, keeping a group missing from the group list
+		if taskGroup := data.GetTaskGroup(*task); taskGroup != "" {
+			m.groupIdx = m.groupIndex(taskGroup)
+		}
+
+		// Keep the current milestone selectable until the list is set
+		if milestone := data.GetTaskMilestone(*task); milestone != "" {
+			m.milestones = append(m.milestones, milestone)
+			m.milestoneIdx = 1
 		}
 	}
 
 	return m
 }
 
+// SetMilestones sets the milestones offered by the milestone selector,
+// keeping the current selection (even if it is not in names)
+func (m *EditModel) SetMilestones(names []string) {
+	current := m.milestones[m.milestoneIdx]
+	m.milestones = append([]string{""}, names...)
+	m.milestoneIdx = 0
+	if current == "" {
+		return
+	}
+	for i, name := range m.milestones {
+		if name == current {
+			m.milestoneIdx = i
+			return
+		}
+	}
+	m.milestones = append(m.milestones, current)
+	m.milestoneIdx = len(m.milestones) - 1
+}
+
 // Init initializes the model
 func (m EditModel) Init() tea.Cmd {
 	return textinput.Blink
@@ -165,37 +202,83 @@ func (m EditModel) Init() tea.Cmd {
 func (m EditModel) Update(msg tea.Msg) (EditModel, tea.Cmd) {
 	var cmd tea.Cmd
 
+	// Handle the reason prompt; the task is saved once a reason is given
+	if m.reason.active {
+		var reason string
+		m.reason, reason, cmd = m.reason.update(msg)
+		if reason != "" {
+			m.store.tasks.SetStatusReason(m.task.ID, reason)
+			return m, m.save()
+		}
+		return m, cmd
+	}
+
 	// Handle picker mode
 	if m.pickerActive {
 		return m.updatePicker(msg)
 	}
+	if m.groupPickerActive {
+		return m.updateGroupPicker(msg)
+	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+s", "ctrl+enter":
+			if errs := m.validate(); len(errs) > 0 {
+				m.validated = true
+				m.focusIdx = firstErrorField(errs)
+				m.updateFocus()
+				return m, nil
+			}
+			if status := m.statuses[m.statusIdx]; !m.isNew {
+				if ids := reasonsNeeded(m.store.tasks, []string{m.task.ID}, status); len(ids) > 0 {
+					return m, m.reason.open(reopenTitle(m.store.tasks, ids), ids, status)
+				}
+			}
 			return m, m.save()
 		case "esc":
 			return m, func() tea.Msg {
 				return CancelEditMsg{}
 			}
-		case "/":
+		case "/", "enter":
 			// Open picker for blocks/blockedBy fields
 			if m.focusIdx == 5 || m.focusIdx == 6 {
 				m.openPicker(m.focusIdx)
 				return m, textinput.Blink
 			}
+			// Open the group picker
+			if m.focusIdx == 3 {
+				m.openGroupPicker("")
+				return m, textinput.Blink
+			}
+		case "left", "right":
+			// Select a dependency chip
+			if chips := m.focusedChips(); chips != nil {
+				if msg.String() == "left" {
+					chips.move(-1)
+				} else {
+					chips.move(1)
+				}
+				return m, nil
+			}
+		case "backspace", "delete":
+			// Remove the selected dependency chip
+			if chips := m.focusedChips(); chips != nil {
+				chips.remove()
+				return m, nil
+			}
 		case "tab", "shift+tab":
-			// Navigate fields (7 fields: 0-6)
+			// Navigate fields (0 to editFieldCount-1)
 			if msg.String() == "tab" {
-				m.focusIdx = (m.focusIdx + 1) % 7
+				m.focusIdx = (m.focusIdx + 1) % editFieldCount
 			} else {
-				m.focusIdx = (m.focusIdx + 6) % 7
+				m.focusIdx = (m.focusIdx + editFieldCount - 1) % editFieldCount
 			}
 			m.updateFocus()
 			return m, nil
 		case "up", "down":
-			// Handle selector navigation when focused on status or group
+			// Handle selector navigation when focused on status or milestone
 			if m.focusIdx == 2 {
 				// Status selector
 				if msg.String() == "up" && m.statusIdx > 0 {
@@ -204,18 +287,34 @@ func (m EditModel) Update(msg tea.Msg) (EditModel, tea.Cmd) {
 					m.statusIdx++
 				}
 				return m, nil
-			} else if m.focusIdx == 3 {
-				// Group selector
-				if msg.String() == "up" && m.groupIdx > 0 {
-					m.groupIdx--
-				} else if msg.String() == "down" && m.groupIdx < len(m.groups)-1 {
-					m.groupIdx++
+			} else if m.focusIdx == 8 {
+				// Milestone selector
+				if msg.String() == "up" && m.milestoneIdx > 0 {
+					m.milestoneIdx--
+				} else if msg.String() == "down" && m.milestoneIdx < len(m.milestones)-1 {
+					m.milestoneIdx++
 				}
 				return m, nil
 			}
 		}
 	}
 
+	// Status keys jump to a status
+	if key, ok := msg.(tea.KeyMsg); ok && m.focusIdx == 2 {
+		for i, status := range m.statuses {
+			if statusKeys[status] == key.String() {
+				m.statusIdx = i
+				return m, nil
+			}
+		}
+	}
+
+	// Typing on the group field starts searching the group picker
+	if key, ok := msg.(tea.KeyMsg); ok && m.focusIdx == 3 && key.Type == tea.KeyRunes {
+		m.openGroupPicker(string(key.Runes))
+		return m, textinput.Blink
+	}
+
 	// Update focused input
 	switch m.focusIdx {
 	case 0:
@@ -224,10 +323,8 @@ func (m EditModel) Update(msg tea.Msg) (EditModel, tea.Cmd) {
 		m.descInput, cmd = m.descInput.Update(msg)
 	case 4:
 		m.ownerInput, cmd = m.ownerInput.Update(msg)
-	case 5:
-		m.blocksInput, cmd = m.blocksInput.Update(msg)
-	case 6:
-		m.blockedByInput, cmd = m.blockedByInput.Update(msg)
+	case 7:
+		m.estimateInput, cmd = m.estimateInput.Update(msg)
 	}
 
 	return m, cmd
@@ -237,8 +334,7 @@ func (m *EditModel) updateFocus() {
 	m.subjectInput.Blur()
 	m.descInput.Blur()
 	m.ownerInput.Blur()
-	m.blocksInput.Blur()
-	m.blockedByInput.Blur()
+	m.estimateInput.Blur()
 
 	switch m.focusIdx {
 	case 0:
@@ -247,10 +343,8 @@ func (m *EditModel) updateFocus() {
 		m.descInput.Focus()
 	case 4:
 		m.ownerInput.Focus()
-	case 5:
-		m.blocksInput.Focus()
-	case 6:
-		m.blockedByInput.Focus()
+	case 7:
+		m.estimateInput.Focus()
 	}
 }
 
@@ -261,15 +355,9 @@ func (m *EditModel) openPicker(field int) {
 	m.pickerSearch.Focus()
 	m.pickerCursor = 0
 
-	// Initialize selected from current field value
+	// Initialize selected from the field's chips
 	m.pickerSelected = make(map[string]bool)
-	var currentIDs []string
-	if field == 5 {
-		currentIDs = parseTaskIDs(m.blocksInput.Value())
-	} else {
-		currentIDs = parseTaskIDs(m.blockedByInput.Value())
-	}
-	for _, id := range currentIDs {
+	for _, id := range m.chipsFor(field).ids {
 		m.pickerSelected[id] = true
 	}
 
@@ -277,25 +365,11 @@ func (m *EditModel) openPicker(field int) {
 }
 
 func (m *EditModel) filterPickerTasks() {
-	query := strings.ToLower(m.pickerSearch.Value())
-	m.pickerTasks = nil
-
-	for _, task := range m.taskStore.Tasks {
-		// Skip self
-		if !m.isNew && task.ID == m.task.ID {
-			continue
-		}
-
-		// Filter by query
-		if query != "" {
-			if !strings.Contains(strings.ToLower(task.Subject), query) &&
-				!strings.Contains(task.ID, query) {
-				continue
-			}
-		}
-
-		m.pickerTasks = append(m.pickerTasks, task)
+	excludeID := ""
+	if !m.isNew {
+		excludeID = m.task.ID
 	}
+	m.pickerTasks = pickerCandidates(m.store.tasks.Tasks, excludeID, m.pickerSearch.Value())
 
 	// Reset cursor if out of bounds
 	if m.pickerCursor >= len(m.pickerTasks) {
@@ -350,27 +424,202 @@ func (m EditModel) updatePicker(msg tea.Msg) (EditModel, tea.Cmd) {
 }
 
 func (m *EditModel) applyPickerSelection() {
-	var ids []string
-	for id, selected := range m.pickerSelected {
-		if selected {
-			ids = append(ids, id)
+	m.chipsFor(m.pickerForField).set(m.pickerSelected, m.store.tasks.Tasks)
+}
+
+// chipsFor returns the chips of a dependency field (5=blocks, 6=blockedBy)
+func (m *EditModel) chipsFor(field int) *depChips {
+	if field == 5 {
+		return &m.blocks
+	}
+	return &m.blockedBy
+}
+
+// focusedChips returns the chips of the focused dependency field, if any
+func (m *EditModel) focusedChips() *depChips {
+	if m.focusIdx == 5 || m.focusIdx == 6 {
+		return m.chipsFor(m.focusIdx)
+	}
+	return nil
+}
+
+// statusKeys are the keys choosing a status while the status field is focused
+var statusKeys = map[string]string{
+	"pending":              "p",
+	"in_progress":          "i",
+	"completed":            "c",
+	data.StatusNeedsReview: "r",
+}
+
+// renderStatusRadio renders the statuses as a radio list with their keys
+func (m EditModel) renderStatusRadio() string {
+	options := make([]string, len(m.statuses))
+	for i, status := range m.statuses {
+		mark := "( )"
+		style := ui.MutedStyle
+		if i == m.statusIdx {
+			mark = "(" + ui.Glyphs.Check + ")"
+			style = ui.GetStatusStyle(status)
+		}
+		option := style.Render(mark + " " + ui.StatusText(status))
+		if m.focusIdx == 2 {
+			option += " " + ui.KeyStyle.Render("("+statusKeys[status]+")")
 		}
+		options[i] = option
+	}
+	radio := strings.Join(options, "  ")
+	if m.width > 0 && lipgloss.Width(radio)+len(i18n.T("Status:"))+1 > m.width {
+		radio = "\n" + strings.Join(options, "\n")
 	}
-	value := strings.Join(ids, ", ")
+	return radio
+}
 
-	if m.pickerForField == 5 {
-		m.blocksInput.SetValue(value)
-	} else {
-		m.blockedByInput.SetValue(value)
+// groupIndex returns the index of a group in the group selector, adding it
+// when it is not listed yet
+func (m *EditModel) groupIndex(name string) int {
+	for i, g := range m.groups {
+		if g == name {
+			return i
+		}
 	}
+	m.groups = append(m.groups, name)
+	return len(m.groups) - 1
+}
+
+// openGroupPicker opens the group picker with query already typed
+func (m *EditModel) openGroupPicker(query string) {
+	m.groupPickerActive = true
+	m.groupSearch.SetValue(query)
+	m.groupSearch.CursorEnd()
+	m.groupSearch.Focus()
+	m.filterGroupPicker()
+
+	// Start on the current group unless searching
+	m.groupCursor = 0
+	if query == "" {
+		for i, g := range m.groupOptions {
+			if g == m.groups[m.groupIdx] {
+				m.groupCursor = i
+			}
+		}
+	}
+}
+
+func (m *EditModel) filterGroupPicker() {
+	m.groupOptions, m.groupCreate = groupCandidates(m.groups, m.groupSearch.Value())
+
+	count := len(m.groupOptions)
+	if m.groupCreate != "" {
+		count++
+	}
+	if m.groupCursor >= count {
+		m.groupCursor = count - 1
+	}
+	if m.groupCursor < 0 {
+		m.groupCursor = 0
+	}
+}
+
+func (m EditModel) updateGroupPicker(msg tea.Msg) (EditModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		last := len(m.groupOptions) - 1
+		if m.groupCreate != "" {
+			last++
+		}
+		switch key.String() {
+		case "esc":
+			m.groupPickerActive = false
+			return m, nil
+		case "enter", "tab":
+			if m.groupCursor < len(m.groupOptions) {
+				m.groupIdx = m.groupIndex(m.groupOptions[m.groupCursor])
+			} else if m.groupCreate != "" {
+				m.groupIdx = m.groupIndex(m.groupCreate)
+			}
+			m.groupPickerActive = false
+			return m, nil
+		case "up":
+			if m.groupCursor > 0 {
+				m.groupCursor--
+			}
+			return m, nil
+		case "down":
+			if m.groupCursor < last {
+				m.groupCursor++
+			}
+			return m, nil
+		}
+	}
+
+	m.groupSearch, cmd = m.groupSearch.Update(msg)
+	m.filterGroupPicker()
+	return m, cmd
+}
+
+// validate returns the errors of the form by field index
+func (m EditModel) validate() map[int]string {
+	errs := make(map[int]string)
+	if strings.TrimSpace(m.subjectInput.Value()) == "" {
+		errs[0] = i18n.T("(required)")
+	}
+	blocks, blockedBy := m.blocks.ids, m.blockedBy.ids
+	if msg := m.dependencyError(blocks); msg != "" {
+		errs[5] = msg
+	}
+	if msg := m.dependencyError(blockedBy); msg != "" {
+		errs[6] = msg
+	}
+	if errs[5] == "" && errs[6] == "" {
+		id := ""
+		if !m.isNew {
+			id = m.task.ID
+		}
+		if m.store.tasks.DependencyCycle(id, blocks, blockedBy) {
+			errs[6] = i18n.T("(creates a dependency cycle)")
+		}
+	}
+	if _, err := data.ParseEstimate(m.estimateInput.Value()); err != nil {
+		errs[7] = i18n.T("(must be a number)")
+	}
+	return errs
+}
+
+// dependencyError describes self references and unknown tasks among ids
+func (m EditModel) dependencyError(ids []string) string {
+	var unknown []string
+	for _, id := range ids {
+		if !m.isNew && id == m.task.ID {
+			return i18n.T("(cannot reference itself)")
+		}
+		if m.store.tasks.GetTask(id) == nil {
+			unknown = append(unknown, "#"+id)
+		}
+	}
+	if len(unknown) > 0 {
+		return i18n.Tf("(unknown task: %s)", strings.Join(unknown, ", "))
+	}
+	return ""
+}
+
+// firstErrorField returns the first field in form order with an error
+func firstErrorField(errs map[int]string) int {
+	for i := 0; i < editFieldCount; i++ {
+		if errs[i] != "" {
+			return i
+		}
+	}
+	return 0
 }
 
 func (m *EditModel) save() tea.Cmd {
 	// Validate
-	subject := strings.TrimSpace(m.subjectInput.Value())
-	if subject == "" {
-		return nil // Don't save without subject
+	if len(m.validate()) > 0 {
+		return nil
 	}
+	subject := strings.TrimSpace(m.subjectInput.Value())
+	estimate, _ := data.ParseEstimate(m.estimateInput.Value())
 
 	// Update task
 	m.task.Subject = subject
@@ -378,27 +627,35 @@ func (m *EditModel) save() tea.Cmd {
 	m.task.Status = m.statuses[m.statusIdx]
 	m.task.Owner = strings.TrimSpace(m.ownerInput.Value())
 
-	// Parse blocks
-	m.task.Blocks = parseTaskIDs(m.blocksInput.Value())
-	m.task.BlockedBy = parseTaskIDs(m.blockedByInput.Value())
+	// Dependencies
+	m.task.Blocks = append([]string{}, m.blocks.ids...)
+	m.task.BlockedBy = append([]string{}, m.blockedBy.ids...)
+
+	data.SetTaskEstimate(m.task, estimate)
+	data.SetTaskMilestone(m.task, m.milestones[m.milestoneIdx])
 
-	// Set group
+	// Set group, creating it when it was added from the group picker
 	if m.groupIdx > 0 {
-		data.SetTaskGroup(m.task, m.groups[m.groupIdx])
+		group := m.groups[m.groupIdx]
+		data.SetTaskGroup(m.task, group)
+		if m.store.groups.GetGroup(group) == nil {
+			m.store.groups.EnsureGroupExists(group)
+			m.store.saveGroups()
+		}
 	} else {
 		data.SetTaskGroup(m.task, "")
 	}
 
 	// Save
 	if m.isNew {
-		m.taskStore.AddTask(*m.task)
+		m.store.tasks.AddTask(*m.task)
 	} else {
-		m.taskStore.UpdateTask(*m.task)
+		m.store.tasks.UpdateTask(*m.task)
 	}
-	m.taskStore.Save()
+	m.store.save()
 
 	return func() tea.Msg {
-		return TaskSavedMsg{Store: m.taskStore}
+		return TaskSavedMsg{}
 	}
 }
 
@@ -408,7 +665,7 @@ func (m *EditModel) SetSize(width, height int) {
 	m.height = height
 
 	// Skip updating input widths if model is not yet initialized
-	if m.taskStore == nil {
+	if m.store.tasks == nil {
 		return
 	}
 
@@ -420,9 +677,9 @@ func (m *EditModel) SetSize(width, height int) {
 	m.subjectInput.Width = inputWidth
 	m.descInput.SetWidth(inputWidth)
 	m.ownerInput.Width = inputWidth
-	m.blocksInput.Width = inputWidth
-	m.blockedByInput.Width = inputWidth
+	m.estimateInput.Width = inputWidth
 	m.pickerSearch.Width = inputWidth
+	m.groupSearch.Width = inputWidth
 }
 
 // View renders the edit screen
@@ -430,11 +687,11 @@ func (m EditModel) View() string {
 	var b strings.Builder
 
 	// Header
-	title := "Edit Task"
+	title := i18n.T("Edit Task")
 	if m.isNew {
-		title = "New Task"
+		title = i18n.T("New Task")
 	} else {
-		title = fmt.Sprintf("Edit Task #%s", m.task.ID)
+		title = i18n.Tf("Edit Task #%s", data.DisplayID(*m.task))
 	}
 	b.WriteString(ui.Header(title, m.width))
 	b.WriteString("\n\n")
@@ -443,22 +700,37 @@ func (m EditModel) View() string {
 	if m.pickerActive {
 		return m.renderPicker()
 	}
+	if m.groupPickerActive {
+		return renderGroupPicker(m.groupSearch, m.groupOptions, m.groupCreate, m.groupCursor, m.groups[m.groupIdx], m.width)
+	}
+
+	if m.reason.active {
+		b.WriteString(m.reason.view())
+		b.WriteString("\n\n")
+	}
+
+	// Field errors, once saving was refused
+	var errs map[int]string
+	if m.validated {
+		errs = m.validate()
+	}
 
 	// Subject field
 	if m.focusIdx == 0 {
-		b.WriteString(ui.SelectedStyle.Render("Subject:"))
+		b.WriteString(ui.SelectedStyle.Render(i18n.T("Subject:")))
 	} else {
-		b.WriteString(ui.InputLabelStyle.Render("Subject:"))
+		b.WriteString(ui.InputLabelStyle.Render(i18n.T("Subject:")))
 	}
+	b.WriteString(renderFieldError(errs[0]))
 	b.WriteString("\n")
 	b.WriteString(m.subjectInput.View())
 	b.WriteString("\n\n")
 
 	// Description field
 	if m.focusIdx == 1 {
-		b.WriteString(ui.SelectedStyle.Render("Description:"))
+		b.WriteString(ui.SelectedStyle.Render(i18n.T("Description:")))
 	} else {
-		b.WriteString(ui.InputLabelStyle.Render("Description:"))
+		b.WriteString(ui.InputLabelStyle.Render(i18n.T("Description:")))
 	}
 	b.WriteString("\n")
 	b.WriteString(m.descInput.View())
@@ -466,37 +738,30 @@ func (m EditModel) View() string {
 
 	// Status selector
 	if m.focusIdx == 2 {
-		b.WriteString(ui.SelectedStyle.Render("Status:"))
+		b.WriteString(ui.SelectedStyle.Render(i18n.T("Status:")))
 	} else {
-		b.WriteString(ui.InputLabelStyle.Render("Status:"))
+		b.WriteString(ui.InputLabelStyle.Render(i18n.T("Status:")))
 	}
 	b.WriteString(" ")
 
-	statusText := m.statuses[m.statusIdx]
-	statusIcon := ui.StatusIcon(statusText)
-	statusStyle := ui.GetStatusStyle(statusText)
-	if m.focusIdx == 2 {
-		b.WriteString(statusStyle.Render(fmt.Sprintf("[%s %s] ↑↓", statusIcon, statusText)))
-	} else {
-		b.WriteString(statusStyle.Render(fmt.Sprintf(" %s %s", statusIcon, statusText)))
-	}
+	b.WriteString(m.renderStatusRadio())
 	b.WriteString("\n\n")
 
 	// Group selector
 	if m.focusIdx == 3 {
-		b.WriteString(ui.SelectedStyle.Render("Group:"))
+		b.WriteString(ui.SelectedStyle.Render(i18n.T("Group:")))
 	} else {
-		b.WriteString(ui.InputLabelStyle.Render("Group:"))
+		b.WriteString(ui.InputLabelStyle.Render(i18n.T("Group:")))
 	}
 	b.WriteString(" ")
 
-	groupText := "(none)"
+	groupText := i18n.T("(none)")
 	if m.groupIdx > 0 && m.groupIdx < len(m.groups) {
 		groupText = m.groups[m.groupIdx]
 	}
 
 	if m.focusIdx == 3 {
-		b.WriteString(fmt.Sprintf("[%s] ↑↓", groupText))
+		b.WriteString(fmt.Sprintf("[%s]", groupText))
 	} else {
 		b.WriteString(fmt.Sprintf(" %s", groupText))
 	}
@@ -504,9 +769,9 @@ func (m EditModel) View() string {
 
 	// Owner field
 	if m.focusIdx == 4 {
-		b.WriteString(ui.SelectedStyle.Render("Owner:"))
+		b.WriteString(ui.SelectedStyle.Render(i18n.T("Owner:")))
 	} else {
-		b.WriteString(ui.InputLabelStyle.Render("Owner:"))
+		b.WriteString(ui.InputLabelStyle.Render(i18n.T("Owner:")))
 	}
 	b.WriteString("\n")
 	b.WriteString(m.ownerInput.View())
@@ -514,24 +779,60 @@ func (m EditModel) View() string {
 
 	// Blocks field
 	if m.focusIdx == 5 {
-		b.WriteString(ui.SelectedStyle.Render("Blocks:"))
+		b.WriteString(ui.SelectedStyle.Render(i18n.T("Blocks:")))
 	} else {
-		b.WriteString(ui.InputLabelStyle.Render("Blocks:"))
+		b.WriteString(ui.InputLabelStyle.Render(i18n.T("Blocks:")))
 	}
-	b.WriteString(ui.MutedStyle.Render(" (tasks that wait for this)"))
+	b.WriteString(ui.MutedStyle.Render(" " + i18n.T("(tasks that wait for this)")))
+	b.WriteString(renderFieldError(errs[5]))
 	b.WriteString("\n")
-	b.WriteString(m.blocksInput.View())
+	b.WriteString(m.blocks.view(m.store.tasks, m.focusIdx == 5, m.width))
 	b.WriteString("\n\n")
 
 	// BlockedBy field
 	if m.focusIdx == 6 {
-		b.WriteString(ui.SelectedStyle.Render("Blocked By:"))
+		b.WriteString(ui.SelectedStyle.Render(i18n.T("Blocked By:")))
 	} else {
-		b.WriteString(ui.InputLabelStyle.Render("Blocked By:"))
+		b.WriteString(ui.InputLabelStyle.Render(i18n.T("Blocked By:")))
 	}
-	b.WriteString(ui.MutedStyle.Render(" (tasks this waits for)"))
+	b.WriteString(ui.MutedStyle.Render(" " + i18n.T("(tasks this waits for)")))
+	b.WriteString(renderFieldError(errs[6]))
 	b.WriteString("\n")
-	b.WriteString(m.blockedByInput.View())
+	b.WriteString(m.blockedBy.view(m.store.tasks, m.focusIdx == 6, m.width))
+	b.WriteString("\n\n")
+
+	// Estimate field
+	if m.focusIdx == 7 {
+		b.WriteString(ui.SelectedStyle.Render(i18n.T("Estimate:")))
+	} else {
+		b.WriteString(ui.InputLabelStyle.Render(i18n.T("Estimate:")))
+	}
+	if _, err := data.ParseEstimate(m.estimateInput.Value()); err != nil {
+		b.WriteString(ui.ErrorStyle.Render(" " + i18n.T("(must be a number)")))
+	} else {
+		b.WriteString(ui.MutedStyle.Render(fmt.Sprintf(" (%s)", config.Current().Estimates.Unit)))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.estimateInput.View())
+	b.WriteString("\n\n")
+
+	// Milestone selector
+	if m.focusIdx == 8 {
+		b.WriteString(ui.SelectedStyle.Render(i18n.T("Milestone:")))
+	} else {
+		b.WriteString(ui.InputLabelStyle.Render(i18n.T("Milestone:")))
+	}
+	b.WriteString(" ")
+
+	milestoneText := i18n.T("(none)")
+	if m.milestoneIdx > 0 {
+		milestoneText = m.milestones[m.milestoneIdx]
+	}
+	if m.focusIdx == 8 {
+		b.WriteString(fmt.Sprintf("[%s] %s%s", milestoneText, ui.Glyphs.Up, ui.Glyphs.Down))
+	} else {
+		b.WriteString(fmt.Sprintf(" %s", milestoneText))
+	}
 	b.WriteString("\n")
 
 	// Footer
@@ -541,6 +842,26 @@ func (m EditModel) View() string {
 		keys = [][]string{
 			{"Tab", "Next Field"},
 			{"/", "Search Tasks"},
+			{"←→", "Select"},
+			{"Backspace", "Remove"},
+			{"Ctrl+S", "Save"},
+			{"Esc", "Cancel"},
+		}
+	} else if m.focusIdx == 2 {
+		statusKeyList := make([]string, len(m.statuses))
+		for i, status := range m.statuses {
+			statusKeyList[i] = statusKeys[status]
+		}
+		keys = [][]string{
+			{"Tab", "Next Field"},
+			{strings.Join(statusKeyList, "/"), "Set Status"},
+			{"Ctrl+S", "Save"},
+			{"Esc", "Cancel"},
+		}
+	} else if m.focusIdx == 3 {
+		keys = [][]string{
+			{"Tab", "Next Field"},
+			{"Enter", "Choose Group"},
 			{"Ctrl+S", "Save"},
 			{"Esc", "Cancel"},
 		}
@@ -556,76 +877,39 @@ func (m EditModel) View() string {
 	return b.String()
 }
 
-func (m EditModel) renderPicker() string {
-	var b strings.Builder
+// renderFieldError renders a field's validation error after its label
+func renderFieldError(msg string) string {
+	if msg == "" {
+		return ""
+	}
+	return ui.ErrorStyle.Render(" " + msg)
+}
 
-	// Header
+func (m EditModel) renderPicker() string {
 	fieldName := "Blocks"
 	if m.pickerForField == 6 {
 		fieldName = "Blocked By"
 	}
-	b.WriteString(ui.Header(fmt.Sprintf("Select Tasks for %s", fieldName), m.width))
-	b.WriteString("\n\n")
-
-	// Search
-	b.WriteString(ui.InputLabelStyle.Render("Search:"))
-	b.WriteString("\n")
-	b.WriteString(m.pickerSearch.View())
-	b.WriteString("\n\n")
-
-	// Task list
-	b.WriteString(ui.HorizontalLine(m.width))
-	b.WriteString("\n")
-
-	if len(m.pickerTasks) == 0 {
-		b.WriteString(ui.MutedStyle.Render("No tasks found."))
-		b.WriteString("\n")
-	} else {
-		maxVisible := 10
-		startIdx := 0
-		if m.pickerCursor >= maxVisible {
-			startIdx = m.pickerCursor - maxVisible + 1
-		}
-		endIdx := startIdx + maxVisible
-		if endIdx > len(m.pickerTasks) {
-			endIdx = len(m.pickerTasks)
-		}
-
-		for i := startIdx; i < endIdx; i++ {
-			task := m.pickerTasks[i]
-			prefix := "  "
-			if i == m.pickerCursor {
-				prefix = "> "
-			}
-
-			checkbox := "[ ]"
-			if m.pickerSelected[task.ID] {
-				checkbox = "[✓]"
-			}
-
-			statusIcon := data.StatusIcon(task.Status)
-			line := fmt.Sprintf("%s%s #%s %s %s", prefix, checkbox, task.ID, statusIcon, task.Subject)
+	return renderTaskPicker(i18n.T(fieldName), m.pickerSearch, m.pickerTasks, m.pickerCursor, m.pickerSelected, m.width)
+}
 
-			if i == m.pickerCursor {
-				b.WriteString(ui.SelectedStyle.Render(line))
-			} else {
-				b.WriteString(line)
-			}
-			b.WriteString("\n")
-		}
+// displayRefs converts task IDs to the IDs shown to the user (aliases for
+// UUID tasks)
+func (m EditModel) displayRefs(ids []string) []string {
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		refs[i] = m.store.tasks.DisplayRef(id)
 	}
+	return refs
+}
 
-	// Footer
-	b.WriteString("\n")
-	keys := [][]string{
-		{"↑↓", "Navigate"},
-		{"Enter", "Toggle"},
-		{"Tab", "Confirm"},
-		{"Esc", "Cancel"},
+// resolveRefs converts displayed IDs or aliases back to task IDs
+func (m EditModel) resolveRefs(refs []string) []string {
+	ids := make([]string, len(refs))
+	for i, ref := range refs {
+		ids[i] = m.store.tasks.ResolveTaskRef(ref)
 	}
-	b.WriteString(ui.Footer(keys, m.width))
-
-	return b.String()
+	return ids
 }
 
 // parseTaskIDs parses comma-separated task IDs
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/ui"
 )
 
 func setupTestEdit(t *testing.T) (*data.TaskStore, *data.GroupStore, string) {
@@ -74,7 +75,7 @@ func TestEditModel_NewTask(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Should be new task mode
 	if !m.isNew {
@@ -106,7 +107,7 @@ func TestEditModel_EditExistingTask(t *testing.T) {
 		BlockedBy:   []string{"2"},
 	}
 
-	m := NewEditModel(task, taskStore, groupStore, false)
+	m := NewEditModel(task, newProjectStore(taskStore, groupStore), false)
 
 	// Should not be new task mode
 	if m.isNew {
@@ -122,12 +123,164 @@ func TestEditModel_EditExistingTask(t *testing.T) {
 		t.Errorf("Expected owner 'john', got '%s'", m.ownerInput.Value())
 	}
 
-	if m.blocksInput.Value() != "1" {
-		t.Errorf("Expected blocks '1', got '%s'", m.blocksInput.Value())
+	if len(m.blocks.ids) != 1 || m.blocks.ids[0] != "1" {
+		t.Errorf("Expected blocks [1], got %v", m.blocks.ids)
 	}
 
-	if m.blockedByInput.Value() != "2" {
-		t.Errorf("Expected blockedBy '2', got '%s'", m.blockedByInput.Value())
+	if len(m.blockedBy.ids) != 1 || m.blockedBy.ids[0] != "2" {
+		t.Errorf("Expected blockedBy [2], got %v", m.blockedBy.ids)
+	}
+}
+
+func TestEditModel_Validation(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestEdit(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewEditModel(taskStore.GetTask("1"), newProjectStore(taskStore, groupStore), false)
+	m.focusIdx = 4
+
+	// An empty subject is reported and focused instead of saving silently
+	m.subjectInput.SetValue("  ")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd != nil {
+		t.Fatal("Expected save to be refused")
+	}
+	if m.focusIdx != 0 {
+		t.Errorf("Expected focus on the subject, got field %d", m.focusIdx)
+	}
+	if !containsString(m.View(), "(required)") {
+		t.Error("Expected the subject error in the view")
+	}
+
+	m.subjectInput.SetValue("Task 1")
+	for _, tt := range []struct {
+		blocks, blockedBy []string
+		field             int
+		msg               string
+	}{
+		{[]string{"1"}, nil, 5, "(cannot reference itself)"},
+		{[]string{"2", "9"}, nil, 5, "(unknown task: #9)"},
+		{nil, []string{"8"}, 6, "(unknown task: #8)"},
+		{[]string{"3"}, nil, 6, "(creates a dependency cycle)"}, // 3 blocks 1
+	} {
+		m.blocks = newDepChips(tt.blocks)
+		m.blockedBy = newDepChips(tt.blockedBy)
+		errs := m.validate()
+		if errs[tt.field] != tt.msg {
+			t.Errorf("blocks %v blockedBy %v: expected %q on field %d, got %v", tt.blocks, tt.blockedBy, tt.msg, tt.field, errs)
+		}
+	}
+
+	// Errors disappear once fixed
+	m.blocks = newDepChips(nil)
+	m.blockedBy = newDepChips(nil)
+	if view := m.View(); containsString(view, "(required)") || containsString(view, "(unknown task") {
+		t.Error("Expected no errors after fixing the fields")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS}); cmd == nil {
+		t.Error("Expected the valid form to save")
+	}
+}
+
+func TestEditModel_DependencyChips(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestEdit(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
+	m.subjectInput.SetValue("New task")
+	m.focusIdx = 6
+	m.updateFocus()
+
+	// Enter opens the picker; tasks picked are added as chips in task order
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.pickerActive {
+		t.Fatal("Expected Enter to open the picker")
+	}
+	m.pickerSelected["3"] = true
+	m.pickerSelected["1"] = true
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if len(m.blockedBy.ids) != 2 || m.blockedBy.ids[0] != "1" || m.blockedBy.ids[1] != "3" {
+		t.Fatalf("Expected chips [1 3], got %v", m.blockedBy.ids)
+	}
+	view := m.View()
+	if !containsString(view, "[#1 Task 1 "+ui.StatusIcon("pending")+"]") || !containsString(view, "[#3 Task 3 "+ui.StatusIcon("completed")+"]") {
+		t.Errorf("Expected chips with subject and status icon, got:\n%s", view)
+	}
+
+	// Typing does not edit the field
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
+	if len(m.blockedBy.ids) != 2 {
+		t.Errorf("Expected typing to leave the chips alone, got %v", m.blockedBy.ids)
+	}
+
+	// Right selects the second chip and Backspace removes it
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if m.blockedBy.cursor != 1 {
+		t.Errorf("Expected the cursor to stop on the last chip, got %d", m.blockedBy.cursor)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if len(m.blockedBy.ids) != 1 || m.blockedBy.ids[0] != "1" || m.blockedBy.cursor != 0 {
+		t.Errorf("Expected chips [1] with cursor 0, got %v cursor %d", m.blockedBy.ids, m.blockedBy.cursor)
+	}
+
+	// Saving stores the chips
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS}); cmd == nil {
+		t.Fatal("Expected save command")
+	}
+	if task := taskStore.Tasks[len(taskStore.Tasks)-1]; len(task.BlockedBy) != 1 || task.BlockedBy[0] != "1" {
+		t.Errorf("Expected the new task blocked by 1, got %v", task.BlockedBy)
+	}
+}
+
+func TestEditModel_SaveEstimate(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestEdit(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewEditModel(taskStore.GetTask("1"), newProjectStore(taskStore, groupStore), false)
+
+	// Invalid estimates are not saved
+	m.estimateInput.SetValue("abc")
+	if cmd := m.save(); cmd != nil {
+		t.Error("Expected save to be rejected for invalid estimate")
+	}
+
+	m.estimateInput.SetValue("2.5")
+	if cmd := m.save(); cmd == nil {
+		t.Fatal("Expected save to succeed")
+	}
+	if estimate := data.GetTaskEstimate(*taskStore.GetTask("1")); estimate != 2.5 {
+		t.Errorf("Expected estimate 2.5, got %v", estimate)
+	}
+
+	// Reopening shows the saved estimate
+	m = NewEditModel(taskStore.GetTask("1"), newProjectStore(taskStore, groupStore), false)
+	if m.estimateInput.Value() != "2.5" {
+		t.Errorf("Expected estimate input '2.5', got '%s'", m.estimateInput.Value())
+	}
+}
+
+func TestEditModel_SaveMilestone(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestEdit(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewEditModel(taskStore.GetTask("1"), newProjectStore(taskStore, groupStore), false)
+	m.SetMilestones([]string{"Sprint 1", "Sprint 2"})
+	m.focusIdx = 8
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if cmd := m.save(); cmd == nil {
+		t.Fatal("Expected save to succeed")
+	}
+	if milestone := data.GetTaskMilestone(*taskStore.GetTask("1")); milestone != "Sprint 2" {
+		t.Errorf("Expected milestone 'Sprint 2', got %q", milestone)
+	}
+
+	// Reopening selects the saved milestone, even if it no longer exists
+	m = NewEditModel(taskStore.GetTask("1"), newProjectStore(taskStore, groupStore), false)
+	m.SetMilestones([]string{"Sprint 1"})
+	if got := m.milestones[m.milestoneIdx]; got != "Sprint 2" {
+		t.Errorf("Expected selected milestone 'Sprint 2', got %q", got)
 	}
 }
 
@@ -135,7 +288,7 @@ func TestEditModel_TabNavigation(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Initial focus is on subject (0)
 	if m.focusIdx != 0 {
@@ -149,7 +302,7 @@ func TestEditModel_TabNavigation(t *testing.T) {
 	}
 
 	// Continue tabbing through all fields
-	for i := 2; i <= 6; i++ {
+	for i := 2; i <= 8; i++ {
 		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
 		if m.focusIdx != i {
 			t.Errorf("Expected focusIdx %d after Tab, got %d", i, m.focusIdx)
@@ -167,12 +320,12 @@ func TestEditModel_ShiftTabNavigation(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Shift+Tab from first field should wrap to last
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
-	if m.focusIdx != 6 {
-		t.Errorf("Expected focusIdx 6 after Shift+Tab from 0, got %d", m.focusIdx)
+	if m.focusIdx != 8 {
+		t.Errorf("Expected focusIdx 8 after Shift+Tab from 0, got %d", m.focusIdx)
 	}
 }
 
@@ -180,7 +333,7 @@ func TestEditModel_StatusSelector(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Navigate to status field (index 2)
 	m.focusIdx = 2
@@ -209,11 +362,49 @@ func TestEditModel_StatusSelector(t *testing.T) {
 	}
 }
 
+func TestEditModel_StatusKeys(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestEdit(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
+	m.focusIdx = 2
+
+	for _, tt := range []struct {
+		key    rune
+		status string
+	}{
+		{'c', "completed"},
+		{'i', "in_progress"},
+		{'p', "pending"},
+	} {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{tt.key}})
+		if got := m.statuses[m.statusIdx]; got != tt.status {
+			t.Errorf("key %c: expected status %s, got %s", tt.key, tt.status, got)
+		}
+	}
+
+	// Every status is listed, with the keys shown while focused
+	view := m.View()
+	for _, label := range []string{"pending", "in_progress", "completed", "(p)", "(i)", "(c)"} {
+		if !containsString(view, label) {
+			t.Errorf("Expected %q in the status radio list", label)
+		}
+	}
+
+	// Status keys only apply to the status field
+	m.focusIdx = 0
+	m.updateFocus()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if m.statuses[m.statusIdx] != "pending" || m.subjectInput.Value() != "c" {
+		t.Errorf("Expected c typed into the subject, got status %s subject %q", m.statuses[m.statusIdx], m.subjectInput.Value())
+	}
+}
+
 func TestEditModel_GroupSelector(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Navigate to group field (index 3)
 	m.focusIdx = 3
@@ -223,10 +414,82 @@ func TestEditModel_GroupSelector(t *testing.T) {
 		t.Errorf("Expected initial groupIdx 0, got %d", m.groupIdx)
 	}
 
-	// Down arrow should change group
+	// Enter opens the picker on the current group
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.groupPickerActive {
+		t.Fatal("Expected group picker to be active after Enter")
+	}
+	if len(m.groupOptions) != 3 || m.groupCursor != 0 {
+		t.Errorf("Expected 3 options with cursor 0, got %v cursor %d", m.groupOptions, m.groupCursor)
+	}
+
+	// Down and Enter choose the first group
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
-	if m.groupIdx != 1 {
-		t.Errorf("Expected groupIdx 1 after Down, got %d", m.groupIdx)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.groupPickerActive {
+		t.Error("Expected group picker to close after choosing")
+	}
+	if m.groups[m.groupIdx] != "Backend" {
+		t.Errorf("Expected group Backend, got %q", m.groups[m.groupIdx])
+	}
+
+	// Typing opens the picker searching for the typed text
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if !m.groupPickerActive || m.groupSearch.Value() != "f" {
+		t.Fatalf("Expected picker searching %q, got active=%v search=%q", "f", m.groupPickerActive, m.groupSearch.Value())
+	}
+	if len(m.groupOptions) != 1 || m.groupOptions[0] != "Frontend" {
+		t.Errorf("Expected only Frontend to match, got %v", m.groupOptions)
+	}
+
+	// Esc keeps the previous group
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.groupPickerActive || m.groups[m.groupIdx] != "Backend" {
+		t.Errorf("Expected picker closed with Backend kept, got active=%v group=%q", m.groupPickerActive, m.groups[m.groupIdx])
+	}
+}
+
+func TestEditModel_GroupPickerCreate(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestEdit(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
+	m.subjectInput.SetValue("New task")
+	m.focusIdx = 3
+
+	// An existing name (in any case) is not offered for creation
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("backend")})
+	if m.groupCreate != "" {
+		t.Errorf("Expected no create option for an existing group, got %q", m.groupCreate)
+	}
+
+	m.groupSearch.SetValue("")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Docs")})
+	if m.groupCreate != "Docs" || len(m.groupOptions) != 0 {
+		t.Fatalf("Expected only the create option for Docs, got options %v create %q", m.groupOptions, m.groupCreate)
+	}
+	if view := m.View(); !containsString(view, `Create new group "Docs"`) {
+		t.Error("Expected the create option in the picker view")
+	}
+
+	// Choosing it selects the group, which is created when the task is saved
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.groups[m.groupIdx] != "Docs" {
+		t.Fatalf("Expected group Docs, got %q", m.groups[m.groupIdx])
+	}
+	if groupStore.GetGroup("Docs") != nil {
+		t.Error("Expected the group not to be created before saving")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("Expected save command")
+	}
+	if groupStore.GetGroup("Docs") == nil {
+		t.Error("Expected group Docs to be created on save")
+	}
+	if task := taskStore.Tasks[len(taskStore.Tasks)-1]; data.GetTaskGroup(task) != "Docs" {
+		t.Errorf("Expected the new task in Docs, got %q", data.GetTaskGroup(task))
 	}
 }
 
@@ -234,7 +497,7 @@ func TestEditModel_OpenPicker(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Navigate to blocks field (index 5)
 	m.focusIdx = 5
@@ -255,7 +518,7 @@ func TestEditModel_PickerNavigation(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Navigate to blockedBy field and open picker
 	m.focusIdx = 6
@@ -288,7 +551,7 @@ func TestEditModel_PickerToggleSelection(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Open picker
 	m.focusIdx = 5
@@ -322,7 +585,7 @@ func TestEditModel_PickerConfirm(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Open picker for blocks
 	m.focusIdx = 5
@@ -343,9 +606,9 @@ func TestEditModel_PickerConfirm(t *testing.T) {
 		t.Error("Expected picker to be closed after Tab")
 	}
 
-	// Blocks input should have the selected task ID
-	if m.blocksInput.Value() == "" {
-		t.Error("Expected blocksInput to have selected task ID")
+	// Blocks should have the selected task as a chip
+	if len(m.blocks.ids) != 1 || m.blocks.ids[0] != m.pickerTasks[0].ID {
+		t.Errorf("Expected the selected task as the only chip, got %v", m.blocks.ids)
 	}
 }
 
@@ -353,10 +616,10 @@ func TestEditModel_PickerCancel(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Set initial value
-	m.blocksInput.SetValue("1, 2")
+	m.blocks = newDepChips([]string{"1", "2"})
 
 	// Open picker
 	m.focusIdx = 5
@@ -377,8 +640,8 @@ func TestEditModel_PickerCancel(t *testing.T) {
 	}
 
 	// Original value should be preserved
-	if m.blocksInput.Value() != "1, 2" {
-		t.Errorf("Expected blocksInput to preserve original value, got '%s'", m.blocksInput.Value())
+	if len(m.blocks.ids) != 2 || m.blocks.ids[0] != "1" || m.blocks.ids[1] != "2" {
+		t.Errorf("Expected blocks to keep the original chips, got %v", m.blocks.ids)
 	}
 }
 
@@ -386,7 +649,7 @@ func TestEditModel_PickerSearch(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Open picker
 	m.focusIdx = 5
@@ -411,7 +674,7 @@ func TestEditModel_CancelEdit(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 
 	// Press Escape
 	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
@@ -431,7 +694,7 @@ func TestEditModel_View(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 	m.width = 80
 	m.height = 24
 
@@ -443,7 +706,7 @@ func TestEditModel_View(t *testing.T) {
 	}
 
 	// Should contain field labels
-	expectedLabels := []string{"Subject:", "Description:", "Status:", "Group:", "Owner:", "Blocks:", "Blocked By:"}
+	expectedLabels := []string{"Subject:", "Description:", "Status:", "Group:", "Owner:", "Blocks:", "Blocked By:", "Estimate:", "Milestone:"}
 	for _, label := range expectedLabels {
 		if !containsString(view, label) {
 			t.Errorf("Expected view to contain '%s'", label)
@@ -455,7 +718,7 @@ func TestEditModel_PickerView(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestEdit(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewEditModel(nil, taskStore, groupStore, true)
+	m := NewEditModel(nil, newProjectStore(taskStore, groupStore), true)
 	m.width = 80
 	m.height = 24
 
@@ -0,0 +1,243 @@
+package model
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// exportFormats are the table formats offered by the export dialog
+var exportFormats = []string{data.FormatCSV, data.FormatTSV}
+
+// ExportModel handles the dialog exporting the filtered tasks to a CSV/TSV file
+type ExportModel struct {
+	store   *projectStore
+	taskIDs []string
+	width   int
+	height  int
+
+	pathInput    textinput.Model
+	columnsInput textinput.Model
+	formatIdx    int
+	focusIdx     int // 0=path, 1=format, 2=columns
+
+	exported string // path written, once done
+	err      error
+}
+
+// NewExportModel creates a new ExportModel for the given tasks
+func NewExportModel(projectName string, taskIDs []string, store *projectStore) ExportModel {
+	pathInput := textinput.New()
+	pathInput.CharLimit = 500
+	pathInput.Width = 60
+	pathInput.Prompt = "> "
+	pathInput.SetValue(defaultExportPath(projectName))
+	pathInput.Focus()
+
+	columnsInput := textinput.New()
+	columnsInput.CharLimit = 200
+	columnsInput.Width = 60
+	columnsInput.Prompt = "> "
+	columnsInput.SetValue(strings.Join(data.DefaultExportColumns, ","))
+
+	return ExportModel{
+		store:        store,
+		taskIDs:      taskIDs,
+		pathInput:    pathInput,
+		columnsInput: columnsInput,
+	}
+}
+
+// defaultExportPath suggests <project>-tasks.csv in the working directory
+func defaultExportPath(projectName string) string {
+	name := strings.ReplaceAll(projectName, "/", "-") + "-tasks.csv"
+	if dir, err := os.Getwd(); err == nil {
+		return filepath.Join(dir, name)
+	}
+	return name
+}
+
+// Init initializes the model
+func (m ExportModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m ExportModel) Update(msg tea.Msg) (ExportModel, tea.Cmd) {
+	// Result shown: any key returns to the list
+	if m.exported != "" {
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m, func() tea.Msg {
+				return BackToTasksMsg{}
+			}
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			return m, func() tea.Msg {
+				return BackToTasksMsg{}
+			}
+		case "enter", "ctrl+s":
+			m.export()
+			return m, nil
+		case "tab", "down":
+			m.setFocus((m.focusIdx + 1) % 3)
+			return m, textinput.Blink
+		case "shift+tab", "up":
+			m.setFocus((m.focusIdx + 2) % 3)
+			return m, textinput.Blink
+		case "left", "right":
+			if m.focusIdx == 1 {
+				m.formatIdx = (m.formatIdx + 1) % len(exportFormats)
+				m.pathInput.SetValue(withFormatExt(m.pathInput.Value(), exportFormats[m.formatIdx]))
+				return m, nil
+			}
+		}
+	}
+
+	switch m.focusIdx {
+	case 0:
+		m.pathInput, cmd = m.pathInput.Update(msg)
+	case 2:
+		m.columnsInput, cmd = m.columnsInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// setFocus moves the focus to field idx
+func (m *ExportModel) setFocus(idx int) {
+	m.focusIdx = idx
+	m.pathInput.Blur()
+	m.columnsInput.Blur()
+	switch idx {
+	case 0:
+		m.pathInput.Focus()
+	case 2:
+		m.columnsInput.Focus()
+	}
+}
+
+// withFormatExt replaces a .csv/.tsv extension to match format
+func withFormatExt(path, format string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".csv" && ext != ".tsv" {
+		return path
+	}
+	return strings.TrimSuffix(path, filepath.Ext(path)) + "." + format
+}
+
+// export writes the file, recording the result or the error
+func (m *ExportModel) export() {
+	path := strings.TrimSpace(m.pathInput.Value())
+	if path == "" {
+		m.err = errors.New(i18n.T("enter a destination path"))
+		return
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	columns, err := data.ParseExportColumns(m.columnsInput.Value())
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	var tasks []data.Task
+	for _, id := range m.taskIDs {
+		if task := m.store.tasks.GetTask(id); task != nil {
+			tasks = append(tasks, *task)
+		}
+	}
+	if err := m.store.tasks.WriteTableFile(path, tasks, columns, exportFormats[m.formatIdx]); err != nil {
+		m.err = err
+		return
+	}
+	m.err = nil
+	m.exported = path
+}
+
+// View renders the export dialog
+func (m ExportModel) View() string {
+	inputWidth := max(m.width-6, 30)
+	m.pathInput.Width = inputWidth
+	m.columnsInput.Width = inputWidth
+
+	var b strings.Builder
+
+	b.WriteString(ui.Header(i18n.T("Export Tasks"), m.width))
+	b.WriteString("\n\n")
+
+	b.WriteString(ui.MutedStyle.Render(i18n.Tf("%d task(s) match the current filter", len(m.taskIDs))))
+	b.WriteString("\n\n")
+
+	labels := []string{i18n.T("Destination:"), i18n.T("Format:"), i18n.T("Columns:")}
+	label := func(i int) string {
+		if i == m.focusIdx {
+			return ui.SelectedStyle.Render(labels[i])
+		}
+		return ui.InputLabelStyle.Render(labels[i])
+	}
+
+	b.WriteString(label(0))
+	b.WriteString("\n")
+	b.WriteString(m.pathInput.View())
+	b.WriteString("\n\n")
+
+	b.WriteString(label(1))
+	b.WriteString(" ")
+	for i, format := range exportFormats {
+		if i == m.formatIdx {
+			b.WriteString(ui.ActiveButtonStyle.Render(strings.ToUpper(format)))
+		} else {
+			b.WriteString(ui.ButtonStyle.Render(strings.ToUpper(format)))
+		}
+		b.WriteString(" ")
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(label(2))
+	b.WriteString("\n")
+	b.WriteString(m.columnsInput.View())
+	b.WriteString("\n")
+	b.WriteString(ui.MutedStyle.Render(i18n.Tf("Available: %s", strings.Join(data.ExportColumns, ", "))))
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+		b.WriteString("\n")
+	}
+	if m.exported != "" {
+		b.WriteString("\n")
+		b.WriteString(ui.SuccessStyle.Render(i18n.Tf("Exported %d task(s) to %s", len(m.taskIDs), m.exported)))
+		b.WriteString("\n")
+		b.WriteString(ui.MutedStyle.Render(i18n.T("Press any key to return")))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"Tab", "Next"},
+		{"←→", "Format"},
+		{"Enter", "Export"},
+		{"Esc", "Cancel"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
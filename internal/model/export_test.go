@@ -0,0 +1,42 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestExportModel_WritesFilteredTasks(t *testing.T) {
+	taskStore, _, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewExportModel("test", []string{"1", "4"}, newProjectStore(taskStore, nil))
+	m.pathInput.SetValue(filepath.Join(tmpDir, "report.csv"))
+	m.columnsInput.SetValue("id,subject")
+
+	// Switching the format updates the file extension
+	m.setFocus(1)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if filepath.Ext(m.pathInput.Value()) != ".tsv" {
+		t.Errorf("path = %q, want .tsv extension", m.pathInput.Value())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.err != nil {
+		t.Fatalf("export failed: %v", m.err)
+	}
+	got, err := os.ReadFile(filepath.Join(tmpDir, "report.tsv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "id\tsubject\n1\tTask 1\n4\tTask 4\n"; string(got) != want {
+		t.Errorf("exported %q, want %q", got, want)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := cmd().(BackToTasksMsg); !ok {
+		t.Error("a key after exporting should return to the list")
+	}
+}
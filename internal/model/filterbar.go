@@ -0,0 +1,199 @@
+package model
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// filterSegment is a part of the task list's filter bar
+type filterSegment int
+
+const (
+	segmentStatus filterSegment = iota
+	segmentAuthor
+	segmentGroup
+	segmentSearch
+	segmentCompleted
+	segmentSort
+	filterSegmentCount
+)
+
+// filterBar is the focus state of the filter bar: Tab focuses it, ←→ move
+// between segments, ↑↓ change the focused filter and Enter opens its options
+type filterBar struct {
+	focused     bool
+	segment     filterSegment
+	open        bool // options dropdown shown
+	highlighted int  // option under the cursor in the dropdown
+}
+
+// filterSegmentNames are the dropdown titles of the segments
+var filterSegmentNames = map[filterSegment]string{
+	segmentStatus:    "Status",
+	segmentAuthor:    "Author",
+	segmentGroup:     "Group",
+	segmentSearch:    "Search",
+	segmentCompleted: "Completed",
+	segmentSort:      "Sort",
+}
+
+// filterOptions returns the values of a segment, their labels, and the index
+// of the current value
+func (m *TasksModel) filterOptions(segment filterSegment) (values, labels []string, current int) {
+	var value string
+	switch segment {
+	case segmentStatus:
+		values = append(append([]string{""}, data.Statuses()...), "blocked", "stale")
+		value = m.statusFilter
+	case segmentAuthor:
+		values = []string{"", "agent", "human"}
+		value = m.authorFilter
+	case segmentGroup:
+		values = append(append([]string{""}, m.store.groups.GetGroupNames()...), "Uncategorized")
+		value = m.groupFilter
+	case segmentCompleted:
+		values = []string{"show", "hide"}
+		value = "show"
+		if m.hideCompleted {
+			value = "hide"
+		}
+	case segmentSort:
+		values = []string{"", "status", "manual"}
+		value = m.sortMode
+	}
+
+	labels = make([]string, len(values))
+	for i, v := range values {
+		switch {
+		case v == "" && segment == segmentGroup:
+			labels[i] = i18n.T("All Groups")
+		case v == "" && segment == segmentSort:
+			labels[i] = "ID"
+		case v == "":
+			labels[i] = i18n.T("All")
+		case segment == segmentGroup:
+			labels[i] = displayGroupName(v)
+		case v == "show":
+			labels[i] = i18n.T("Show")
+		case v == "hide":
+			labels[i] = i18n.T("Hide")
+		case v == "status":
+			labels[i] = i18n.T("Status")
+		case v == "manual":
+			labels[i] = i18n.T("Manual")
+		default:
+			labels[i] = i18n.T(v)
+		}
+		if v == value {
+			current = i
+		}
+	}
+	return values, labels, current
+}
+
+// setFilter applies a segment's value and rebuilds the list
+func (m *TasksModel) setFilter(segment filterSegment, value string) {
+	switch segment {
+	case segmentStatus:
+		m.statusFilter = value
+	case segmentAuthor:
+		m.authorFilter = value
+	case segmentGroup:
+		m.groupFilter = value
+	case segmentCompleted:
+		m.hideCompleted = value == "hide"
+	case segmentSort:
+		m.sortMode = value
+	}
+	m.rebuildItems()
+}
+
+// updateFilterBar handles keys while the filter bar is focused
+func (m TasksModel) updateFilterBar(msg tea.KeyMsg) (TasksModel, tea.Cmd) {
+	values, _, current := m.filterOptions(m.bar.segment)
+
+	if m.bar.open {
+		switch msg.String() {
+		case "up", "k":
+			if m.bar.highlighted > 0 {
+				m.bar.highlighted--
+			}
+		case "down", "j":
+			if m.bar.highlighted < len(values)-1 {
+				m.bar.highlighted++
+			}
+		case "enter", " ":
+			m.setFilter(m.bar.segment, values[m.bar.highlighted])
+			m.bar.open = false
+		case "esc":
+			m.bar.open = false
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "tab", "esc", "shift+tab":
+		m.bar.focused = false
+	case "left", "h":
+		m.bar.segment = (m.bar.segment + filterSegmentCount - 1) % filterSegmentCount
+	case "right", "l":
+		m.bar.segment = (m.bar.segment + 1) % filterSegmentCount
+	case "up", "k", "down", "j":
+		if len(values) > 0 {
+			step := 1
+			if msg.String() == "up" || msg.String() == "k" {
+				step = len(values) - 1
+			}
+			m.setFilter(m.bar.segment, values[(current+step)%len(values)])
+		}
+	case "enter", " ":
+		if m.bar.segment == segmentSearch {
+			m.bar.focused = false
+			m.searchActive = true
+			m.searchInput.Focus()
+			return m, textinput.Blink
+		}
+		m.bar.open = true
+		m.bar.highlighted = current
+	case "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// barValue renders a filter bar value: highlighted when the filter narrows
+// the list, and reversed when the segment has focus
+func (m TasksModel) barValue(segment filterSegment, label string, active bool) string {
+	if m.bar.focused && m.bar.segment == segment {
+		return ui.SelectedStyle.Render("[" + label + "]")
+	}
+	return filterValue(label, active)
+}
+
+// barLabel renders a segment's name and key in the filter bar
+func (m TasksModel) barLabel(segment filterSegment, key string) string {
+	name := i18n.T(filterSegmentNames[segment])
+	if m.bar.focused && m.bar.segment == segment {
+		name = ui.SelectedStyle.Render(name)
+	}
+	return name + " " + ui.KeyStyle.Render("("+key+")")
+}
+
+// renderFilterDropdown renders the options of the open segment
+func (m TasksModel) renderFilterDropdown() string {
+	_, labels, current := m.filterOptions(m.bar.segment)
+	return ui.RenderDropdownExpanded(i18n.T(filterSegmentNames[m.bar.segment]), labels, current, m.bar.highlighted)
+}
+
+// filterDropdownHeight returns the lines taken by the open dropdown
+func (m TasksModel) filterDropdownHeight() int {
+	if !m.bar.open {
+		return 0
+	}
+	_, labels, _ := m.filterOptions(m.bar.segment)
+	return len(labels) + 3 // title, options, blank line
+}
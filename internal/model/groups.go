@@ -9,14 +9,15 @@ import (
 	"github.com/charmbracelet/bubbletea"
 
 	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
 	"github.com/jss826/cctasks/internal/ui"
 )
 
 // GroupsModel handles the group management screen
 type GroupsModel struct {
-	groupStore *data.GroupStore
-	width      int
-	height     int
+	store  *projectStore
+	width  int
+	height int
 
 	cursor        int
 	confirmDelete bool
@@ -27,9 +28,9 @@ type GroupsModel struct {
 }
 
 // NewGroupsModel creates a new GroupsModel
-func NewGroupsModel(groupStore *data.GroupStore) GroupsModel {
+func NewGroupsModel(store *projectStore) GroupsModel {
 	return GroupsModel{
-		groupStore: groupStore,
+		store: store,
 	}
 }
 
@@ -38,6 +39,12 @@ func (m GroupsModel) Init() tea.Cmd {
 	return nil
 }
 
+// readOnlyGroupKeys are the group list keys that change the groups, refused
+// for projects that can only be browsed
+var readOnlyGroupKeys = map[string]bool{
+	"enter": true, "e": true, "right": true, "n": true, "d": true, "K": true, "J": true,
+}
+
 // Update handles messages
 func (m GroupsModel) Update(msg tea.Msg) (GroupsModel, tea.Cmd) {
 	// Delete confirmation mode
@@ -46,12 +53,12 @@ func (m GroupsModel) Update(msg tea.Msg) (GroupsModel, tea.Cmd) {
 		case tea.KeyMsg:
 			switch msg.String() {
 			case "y", "Y":
-				if len(m.groupStore.Groups) > 0 {
-					groupName := m.groupStore.Groups[m.cursor].Name
-					m.groupStore.DeleteGroup(groupName)
-					m.groupStore.Save()
-					if m.cursor >= len(m.groupStore.Groups) {
-						m.cursor = len(m.groupStore.Groups) - 1
+				if len(m.store.groups.Groups) > 0 {
+					groupName := m.store.groups.Groups[m.cursor].Name
+					m.store.groups.DeleteGroup(groupName)
+					m.store.saveGroups()
+					if m.cursor >= len(m.store.groups.Groups) {
+						m.cursor = len(m.store.groups.Groups) - 1
 					}
 					if m.cursor < 0 {
 						m.cursor = 0
@@ -74,7 +81,7 @@ func (m GroupsModel) Update(msg tea.Msg) (GroupsModel, tea.Cmd) {
 				headerLines += 6 // Dialog lines
 			}
 			clickedIdx := msg.Y - headerLines
-			if clickedIdx >= 0 && clickedIdx < len(m.groupStore.Groups) {
+			if clickedIdx >= 0 && clickedIdx < len(m.store.groups.Groups) {
 				now := time.Now()
 				isDoubleClick := clickedIdx == m.lastClickIdx && now.Sub(m.lastClickTime) < 400*time.Millisecond
 
@@ -83,7 +90,10 @@ func (m GroupsModel) Update(msg tea.Msg) (GroupsModel, tea.Cmd) {
 					m.cursor = clickedIdx
 					m.lastClickTime = now
 					m.lastClickIdx = clickedIdx
-					group := &m.groupStore.Groups[m.cursor]
+					if m.store.readOnly() {
+						return m, nil
+					}
+					group := &m.store.groups.Groups[m.cursor]
 					return m, func() tea.Msg {
 						return EditGroupMsg{Group: group, IsNew: false}
 					}
@@ -97,18 +107,21 @@ func (m GroupsModel) Update(msg tea.Msg) (GroupsModel, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if readOnlyGroupKeys[msg.String()] && m.store.readOnly() {
+			return m, nil
+		}
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
 				m.cursor--
 			}
 		case "down", "j":
-			if m.cursor < len(m.groupStore.Groups)-1 {
+			if m.cursor < len(m.store.groups.Groups)-1 {
 				m.cursor++
 			}
 		case "enter", "e", "right":
-			if len(m.groupStore.Groups) > 0 {
-				group := &m.groupStore.Groups[m.cursor]
+			if len(m.store.groups.Groups) > 0 {
+				group := &m.store.groups.Groups[m.cursor]
 				return m, func() tea.Msg {
 					return EditGroupMsg{Group: group, IsNew: false}
 				}
@@ -118,22 +131,22 @@ func (m GroupsModel) Update(msg tea.Msg) (GroupsModel, tea.Cmd) {
 				return EditGroupMsg{Group: nil, IsNew: true}
 			}
 		case "d":
-			if len(m.groupStore.Groups) > 0 {
+			if len(m.store.groups.Groups) > 0 {
 				m.confirmDelete = true
 			}
 		case "K":
 			// Move group up (cursor follows the item)
-			if len(m.groupStore.Groups) > 1 && m.cursor > 0 {
-				if m.groupStore.MoveGroupUp(m.groupStore.Groups[m.cursor].Name) {
-					m.groupStore.Save()
+			if len(m.store.groups.Groups) > 1 && m.cursor > 0 {
+				if m.store.groups.MoveGroupUp(m.store.groups.Groups[m.cursor].Name) {
+					m.store.saveGroups()
 					m.cursor--
 				}
 			}
 		case "J":
 			// Move group down (cursor follows the item)
-			if len(m.groupStore.Groups) > 1 && m.cursor < len(m.groupStore.Groups)-1 {
-				if m.groupStore.MoveGroupDown(m.groupStore.Groups[m.cursor].Name) {
-					m.groupStore.Save()
+			if len(m.store.groups.Groups) > 1 && m.cursor < len(m.store.groups.Groups)-1 {
+				if m.store.groups.MoveGroupDown(m.store.groups.Groups[m.cursor].Name) {
+					m.store.saveGroups()
 					m.cursor++
 				}
 			}
@@ -154,15 +167,15 @@ func (m GroupsModel) View() string {
 	var b strings.Builder
 
 	// Header (subtract 4 for AppStyle padding)
-	b.WriteString(ui.Header("Groups", m.width))
+	b.WriteString(ui.Header(i18n.T("Groups"), m.width))
 	b.WriteString("\n\n")
 
 	// Delete confirmation
-	if m.confirmDelete && len(m.groupStore.Groups) > 0 {
-		groupName := m.groupStore.Groups[m.cursor].Name
+	if m.confirmDelete && len(m.store.groups.Groups) > 0 {
+		groupName := m.store.groups.Groups[m.cursor].Name
 		dialog := ui.Confirm(
-			"Delete Group",
-			fmt.Sprintf("Are you sure you want to delete group \"%s\"?", groupName),
+			i18n.T("Delete Group"),
+			i18n.Tf("Are you sure you want to delete group \"%s\"?", groupName),
 			"y", "n",
 		)
 		b.WriteString(dialog)
@@ -170,14 +183,14 @@ func (m GroupsModel) View() string {
 	}
 
 	// Group list
-	if len(m.groupStore.Groups) == 0 {
-		b.WriteString(ui.MutedStyle.Render("No groups defined."))
+	if len(m.store.groups.Groups) == 0 {
+		b.WriteString(ui.MutedStyle.Render(i18n.T("No groups defined.")))
 		b.WriteString("\n")
-		b.WriteString(ui.MutedStyle.Render("Press 'n' to create a new group."))
+		b.WriteString(ui.MutedStyle.Render(i18n.T("Press 'n' to create a new group.")))
 		b.WriteString("\n")
 	}
 
-	for i, group := range m.groupStore.Groups {
+	for i, group := range m.store.groups.Groups {
 		prefix := "  "
 		style := ui.NormalStyle
 		if i == m.cursor {
@@ -185,7 +198,7 @@ func (m GroupsModel) View() string {
 			style = ui.SelectedStyle
 		}
 
-		swatch := ui.ColorSwatchStyle(group.Color).Render("██")
+		swatch := ui.ColorSwatchStyle(group.Color).Render(strings.Repeat(ui.Glyphs.Block, 2))
 		line := fmt.Sprintf("%s%s %s", prefix, swatch, group.Name)
 		b.WriteString(style.Render(line))
 
@@ -193,10 +206,10 @@ func (m GroupsModel) View() string {
 		moveHint := ""
 		if i == m.cursor {
 			if i > 0 {
-				moveHint += " [K↑]"
+				moveHint += " [K" + ui.Glyphs.Up + "]"
 			}
-			if i < len(m.groupStore.Groups)-1 {
-				moveHint += " [J↓]"
+			if i < len(m.store.groups.Groups)-1 {
+				moveHint += " [J" + ui.Glyphs.Down + "]"
 			}
 		}
 		b.WriteString(ui.MutedStyle.Render(moveHint))
@@ -205,7 +218,7 @@ func (m GroupsModel) View() string {
 
 	// Add group option
 	b.WriteString("\n")
-	b.WriteString(ui.MutedStyle.Render("  [+ Add Group]"))
+	b.WriteString(ui.MutedStyle.Render("  [+ " + i18n.T("Add Group") + "]"))
 	b.WriteString("\n")
 
 	// Footer
@@ -229,30 +242,31 @@ func (m GroupsModel) View() string {
 
 // GroupEditModel handles the group edit dialog
 type GroupEditModel struct {
-	group      *data.TaskGroup
-	groupStore *data.GroupStore
-	isNew      bool
-	width      int
-	height     int
-
-	nameInput   textinput.Model
-	colorIdx    int
-	focusIdx    int // 0=name, 1=color
+	group  *data.TaskGroup
+	store  *projectStore
+	isNew  bool
+	width  int
+	height int
+
+	nameInput textinput.Model
+	colorIdx  int
+	focusIdx  int    // 0=name, 1=color
+	warning   string // the group changed on disk while the form was open
 }
 
 // NewGroupEditModel creates a new GroupEditModel
-func NewGroupEditModel(group *data.TaskGroup, groupStore *data.GroupStore, isNew bool) GroupEditModel {
+func NewGroupEditModel(group *data.TaskGroup, store *projectStore, isNew bool) GroupEditModel {
 	nameInput := textinput.New()
-	nameInput.Placeholder = "Group name"
+	nameInput.Placeholder = i18n.T("Group name")
 	nameInput.CharLimit = 50
 	nameInput.Width = 40
 	nameInput.Prompt = "> "
 	nameInput.Focus()
 
 	m := GroupEditModel{
-		groupStore: groupStore,
-		isNew:      isNew,
-		nameInput:  nameInput,
+		store:     store,
+		isNew:     isNew,
+		nameInput: nameInput,
 	}
 
 	if isNew {
@@ -332,22 +346,22 @@ func (m *GroupEditModel) save() tea.Cmd {
 	color := data.DefaultColors[m.colorIdx]
 
 	if m.isNew {
-		m.groupStore.AddGroup(data.TaskGroup{
+		m.store.groups.AddGroup(data.TaskGroup{
 			Name:  name,
 			Color: color,
 		})
 	} else {
 		oldName := m.group.Name
-		m.groupStore.UpdateGroup(oldName, data.TaskGroup{
+		m.store.groups.UpdateGroup(oldName, data.TaskGroup{
 			Name:  name,
 			Order: m.group.Order,
 			Color: color,
 		})
 	}
-	m.groupStore.Save()
+	m.store.saveGroups()
 
 	return func() tea.Msg {
-		return GroupSavedMsg{Store: m.groupStore}
+		return GroupSavedMsg{}
 	}
 }
 
@@ -363,41 +377,41 @@ func (m GroupEditModel) View() string {
 	var b strings.Builder
 
 	// Header
-	title := "New Group"
+	title := i18n.T("New Group")
 	if !m.isNew {
-		title = "Edit Group"
+		title = i18n.T("Edit Group")
 	}
 	b.WriteString(ui.Header(title, m.width))
 	b.WriteString("\n\n")
 
 	// Name field
 	if m.focusIdx == 0 {
-		b.WriteString(ui.SelectedStyle.Render("Name:"))
+		b.WriteString(ui.SelectedStyle.Render(i18n.T("Name:")))
 	} else {
-		b.WriteString(ui.InputLabelStyle.Render("Name:"))
+		b.WriteString(ui.InputLabelStyle.Render(i18n.T("Name:")))
 	}
 	b.WriteString("\n")
 	b.WriteString(m.nameInput.View())
 	b.WriteString("\n\n")
 
 	// Color field
-	colorLabel := ui.InputLabelStyle.Render("Color:")
+	colorLabel := ui.InputLabelStyle.Render(i18n.T("Color:"))
 	if m.focusIdx == 1 {
-		colorLabel = ui.SelectedStyle.Render("Color:")
+		colorLabel = ui.SelectedStyle.Render(i18n.T("Color:"))
 	}
 	b.WriteString(colorLabel)
 	b.WriteString(" ")
 
 	currentColor := data.DefaultColors[m.colorIdx]
-	b.WriteString(ui.ColorSwatchStyle(currentColor).Render("████"))
+	b.WriteString(ui.ColorSwatchStyle(currentColor).Render(strings.Repeat(ui.Glyphs.Block, 4)))
 	b.WriteString(" " + currentColor)
 	b.WriteString("\n\n")
 
 	// Color palette
-	b.WriteString(ui.MutedStyle.Render("Preset Colors:"))
+	b.WriteString(ui.MutedStyle.Render(i18n.T("Preset Colors:")))
 	b.WriteString("\n")
 	for i, color := range data.DefaultColors {
-		swatch := ui.ColorSwatchStyle(color).Render("██")
+		swatch := ui.ColorSwatchStyle(color).Render(strings.Repeat(ui.Glyphs.Block, 2))
 		if i == m.colorIdx && m.focusIdx == 1 {
 			b.WriteString("[" + swatch + "]")
 		} else {
@@ -406,6 +420,12 @@ func (m GroupEditModel) View() string {
 	}
 	b.WriteString("\n")
 
+	if m.warning != "" {
+		b.WriteString("\n")
+		b.WriteString(ui.WarningStyle.Render(ui.Glyphs.Warning + " " + m.warning))
+		b.WriteString("\n")
+	}
+
 	// Footer
 	b.WriteString("\n")
 	keys := [][]string{
@@ -35,18 +35,18 @@ func TestGroupsModel_MoveDown(t *testing.T) {
 	store, tmpDir := setupTestGroups(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewGroupsModel(store)
+	m := NewGroupsModel(newProjectStore(nil, store))
 	m.cursor = 0 // Start at Group1
 
 	// Press J to move Group1 down
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
 
 	// Verify: Group1 should now be at index 1
-	if m.groupStore.Groups[0].Name != "Group2" {
-		t.Errorf("Expected Group2 at index 0, got %s", m.groupStore.Groups[0].Name)
+	if m.store.groups.Groups[0].Name != "Group2" {
+		t.Errorf("Expected Group2 at index 0, got %s", m.store.groups.Groups[0].Name)
 	}
-	if m.groupStore.Groups[1].Name != "Group1" {
-		t.Errorf("Expected Group1 at index 1, got %s", m.groupStore.Groups[1].Name)
+	if m.store.groups.Groups[1].Name != "Group1" {
+		t.Errorf("Expected Group1 at index 1, got %s", m.store.groups.Groups[1].Name)
 	}
 	if m.cursor != 1 {
 		t.Errorf("Expected cursor at 1, got %d", m.cursor)
@@ -56,8 +56,8 @@ func TestGroupsModel_MoveDown(t *testing.T) {
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
 
 	// Verify: Group1 should now be at index 2
-	if m.groupStore.Groups[2].Name != "Group1" {
-		t.Errorf("Expected Group1 at index 2, got %s", m.groupStore.Groups[2].Name)
+	if m.store.groups.Groups[2].Name != "Group1" {
+		t.Errorf("Expected Group1 at index 2, got %s", m.store.groups.Groups[2].Name)
 	}
 	if m.cursor != 2 {
 		t.Errorf("Expected cursor at 2, got %d", m.cursor)
@@ -67,8 +67,8 @@ func TestGroupsModel_MoveDown(t *testing.T) {
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
 
 	// Verify: nothing should change
-	if m.groupStore.Groups[2].Name != "Group1" {
-		t.Errorf("Expected Group1 to stay at index 2, got %s", m.groupStore.Groups[2].Name)
+	if m.store.groups.Groups[2].Name != "Group1" {
+		t.Errorf("Expected Group1 to stay at index 2, got %s", m.store.groups.Groups[2].Name)
 	}
 	if m.cursor != 2 {
 		t.Errorf("Expected cursor to stay at 2, got %d", m.cursor)
@@ -79,15 +79,15 @@ func TestGroupsModel_MoveUp(t *testing.T) {
 	store, tmpDir := setupTestGroups(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewGroupsModel(store)
+	m := NewGroupsModel(newProjectStore(nil, store))
 	m.cursor = 2 // Start at Group3 (bottom)
 
 	// Press K to move Group3 up
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
 
 	// Verify: Group3 should now be at index 1
-	if m.groupStore.Groups[1].Name != "Group3" {
-		t.Errorf("Expected Group3 at index 1, got %s", m.groupStore.Groups[1].Name)
+	if m.store.groups.Groups[1].Name != "Group3" {
+		t.Errorf("Expected Group3 at index 1, got %s", m.store.groups.Groups[1].Name)
 	}
 	if m.cursor != 1 {
 		t.Errorf("Expected cursor at 1, got %d", m.cursor)
@@ -97,8 +97,8 @@ func TestGroupsModel_MoveUp(t *testing.T) {
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
 
 	// Verify: Group3 should now be at index 0
-	if m.groupStore.Groups[0].Name != "Group3" {
-		t.Errorf("Expected Group3 at index 0, got %s", m.groupStore.Groups[0].Name)
+	if m.store.groups.Groups[0].Name != "Group3" {
+		t.Errorf("Expected Group3 at index 0, got %s", m.store.groups.Groups[0].Name)
 	}
 	if m.cursor != 0 {
 		t.Errorf("Expected cursor at 0, got %d", m.cursor)
@@ -108,8 +108,8 @@ func TestGroupsModel_MoveUp(t *testing.T) {
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
 
 	// Verify: nothing should change
-	if m.groupStore.Groups[0].Name != "Group3" {
-		t.Errorf("Expected Group3 to stay at index 0, got %s", m.groupStore.Groups[0].Name)
+	if m.store.groups.Groups[0].Name != "Group3" {
+		t.Errorf("Expected Group3 to stay at index 0, got %s", m.store.groups.Groups[0].Name)
 	}
 	if m.cursor != 0 {
 		t.Errorf("Expected cursor to stay at 0, got %d", m.cursor)
@@ -120,7 +120,7 @@ func TestGroupsModel_MoveDownThenUp(t *testing.T) {
 	store, tmpDir := setupTestGroups(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewGroupsModel(store)
+	m := NewGroupsModel(newProjectStore(nil, store))
 	m.cursor = 0 // Start at Group1
 
 	// Move Group1 down twice
@@ -128,8 +128,8 @@ func TestGroupsModel_MoveDownThenUp(t *testing.T) {
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}})
 
 	// Group1 should be at bottom
-	if m.groupStore.Groups[2].Name != "Group1" {
-		t.Errorf("Expected Group1 at index 2, got %s", m.groupStore.Groups[2].Name)
+	if m.store.groups.Groups[2].Name != "Group1" {
+		t.Errorf("Expected Group1 at index 2, got %s", m.store.groups.Groups[2].Name)
 	}
 
 	// Move Group1 back up twice
@@ -137,8 +137,8 @@ func TestGroupsModel_MoveDownThenUp(t *testing.T) {
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
 
 	// Group1 should be back at top
-	if m.groupStore.Groups[0].Name != "Group1" {
-		t.Errorf("Expected Group1 at index 0, got %s", m.groupStore.Groups[0].Name)
+	if m.store.groups.Groups[0].Name != "Group1" {
+		t.Errorf("Expected Group1 at index 0, got %s", m.store.groups.Groups[0].Name)
 	}
 	if m.cursor != 0 {
 		t.Errorf("Expected cursor at 0, got %d", m.cursor)
@@ -0,0 +1,182 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// HealthModel is the diagnostic screen shown on launch when a tasks
+// directory is missing, not readable or writable, or holds malformed task
+// files: each issue is listed with how to fix it, and the fixable ones can
+// be repaired from the screen.
+type HealthModel struct {
+	issues   []data.HealthIssue
+	cursor   int
+	message  string // result of the last fix
+	failed   bool   // the last fix failed
+	returnTo Screen // screen shown once the diagnostics are closed
+	width    int
+	height   int
+}
+
+// ShowHealthMsg opens the diagnostic screen with the issues found on launch
+type ShowHealthMsg struct {
+	Issues []data.HealthIssue
+}
+
+// CloseHealthMsg leaves the diagnostic screen
+type CloseHealthMsg struct{}
+
+// healthCheckedMsg carries the issues found by a new check
+type healthCheckedMsg struct {
+	issues []data.HealthIssue
+}
+
+// NewHealthModel creates a HealthModel for the issues found
+func NewHealthModel(issues []data.HealthIssue, returnTo Screen) HealthModel {
+	return HealthModel{issues: issues, returnTo: returnTo}
+}
+
+// checkHealthCmd checks the tasks directories again
+func checkHealthCmd() tea.Msg {
+	issues, _ := data.CheckHealth()
+	return healthCheckedMsg{issues: issues}
+}
+
+// Init initializes the model
+func (m HealthModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m HealthModel) Update(msg tea.Msg) (HealthModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case healthCheckedMsg:
+		m.issues = msg.issues
+		m.cursor = min(m.cursor, max(len(m.issues)-1, 0))
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			m.cursor = max(m.cursor-1, 0)
+		case "down", "j":
+			m.cursor = min(m.cursor+1, max(len(m.issues)-1, 0))
+		case "f":
+			if m.cursor < len(m.issues) && m.issues[m.cursor].Fixable() {
+				issue := m.issues[m.cursor]
+				if err := data.FixHealthIssue(issue); err != nil {
+					m.message, m.failed = i18n.Tf("Could not fix %s: %v", issue.Path, err), true
+				} else {
+					m.message, m.failed = i18n.Tf("Fixed %s", issue.Path), false
+				}
+				return m, checkHealthCmd
+			}
+		case "r":
+			m.message = ""
+			return m, checkHealthCmd
+		case "w":
+			return m, func() tea.Msg { return ShowSetupMsg{} }
+		case "enter", "esc":
+			return m, func() tea.Msg { return CloseHealthMsg{} }
+		case "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// healthFix returns what the user can do about an issue
+func healthFix(issue data.HealthIssue) string {
+	switch issue.Kind {
+	case data.HealthMissing:
+		return i18n.T("Press f to create it, or w to run the setup wizard. Check tasksDir in the config file if it should be elsewhere.")
+	case data.HealthNotDir:
+		return i18n.T("Move the file away, or point tasksDir in the config file to the tasks directory.")
+	case data.HealthUnreadable:
+		return i18n.T("Press f to give your user read access, or run: chmod u+rwX <path>")
+	case data.HealthReadOnly:
+		return i18n.T("Press f to give your user write access, or run: chmod u+rwX <path>. Until then, changes cannot be saved.")
+	case data.HealthMalformed:
+		return i18n.T("Fix the JSON in an editor, or delete the file. ! in the project's task list shows the details and can restore it from a backup.")
+	}
+	return ""
+}
+
+// healthKindLabel names an issue's kind
+func healthKindLabel(kind string) string {
+	switch kind {
+	case data.HealthMissing:
+		return i18n.T("Missing directory")
+	case data.HealthNotDir:
+		return i18n.T("Not a directory")
+	case data.HealthUnreadable:
+		return i18n.T("Not readable")
+	case data.HealthReadOnly:
+		return i18n.T("Not writable")
+	case data.HealthMalformed:
+		return i18n.T("Malformed task file")
+	}
+	return kind
+}
+
+// View renders the diagnostic screen
+func (m HealthModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ui.Header(i18n.T("Tasks Directory Check"), m.width))
+	b.WriteString("\n\n")
+
+	if len(m.issues) == 0 {
+		b.WriteString(ui.SuccessStyle.Render(ui.Glyphs.Check + " " + i18n.T("The tasks directories are fine.")))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(ui.WarningStyle.Render(i18n.Tf("%d problem(s) found with the tasks directories", len(m.issues))))
+		b.WriteString("\n\n")
+
+		// Each issue takes three lines: kind and path, message, fix
+		visible := max((m.height-10)/3, 1)
+		start := max(0, m.cursor-visible+1)
+		for i := start; i < min(start+visible, len(m.issues)); i++ {
+			issue := m.issues[i]
+			cursor, kind := "  ", ui.LabelStyle.Render(healthKindLabel(issue.Kind))
+			if i == m.cursor {
+				cursor, kind = ui.Glyphs.Cursor+" ", ui.SelectedStyle.Render(healthKindLabel(issue.Kind))
+			}
+			b.WriteString(cursor + kind + "  " + ui.Truncate(issue.Path, max(m.width-30, 20)) + "\n")
+			b.WriteString("    " + ui.ErrorStyle.Render(ui.Truncate(issue.Message, max(m.width-6, 20))) + "\n")
+			fix := strings.ReplaceAll(healthFix(issue), "<path>", issue.Path)
+			b.WriteString("    " + ui.MutedStyle.Render(ui.Truncate(fix, max(m.width-6, 20))) + "\n")
+		}
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		if m.failed {
+			b.WriteString(ui.ErrorStyle.Render(m.message))
+		} else {
+			b.WriteString(ui.SuccessStyle.Render(m.message))
+		}
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	fixable := m.cursor < len(m.issues) && m.issues[m.cursor].Fixable()
+	hints := []ui.KeyHint{
+		{Key: "↑↓", Desc: "Navigate", Enabled: len(m.issues) > 1},
+		{Key: "f", Desc: "Fix", Enabled: fixable},
+		{Key: "r", Desc: "Check again", Enabled: true},
+		{Key: "w", Desc: "Setup wizard", Enabled: true},
+		{Key: "Enter", Desc: "Continue", Enabled: true},
+		{Key: "q", Desc: "Quit", Enabled: true},
+	}
+	b.WriteString(ui.FooterWithHints(hints, m.width))
+
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+func TestProjectsModel_ShowsHealthIssues(t *testing.T) {
+	issues := []data.HealthIssue{{Kind: data.HealthMissing, Path: "/nowhere/tasks", Message: "tasks directory does not exist"}}
+	m := NewProjectsModel(&config.State{})
+	m, cmd := m.Update(projectsLoadedMsg{health: issues})
+	if cmd == nil {
+		t.Fatal("Expected the diagnostics instead of an empty list")
+	}
+	if msg, ok := cmd().(ShowHealthMsg); !ok || len(msg.Issues) != 1 {
+		t.Errorf("Expected ShowHealthMsg with the issue, got %#v", msg)
+	}
+	if _, cmd = m.Update(projectsLoadedMsg{health: issues}); cmd != nil {
+		t.Error("Expected the diagnostics only on the first listing")
+	}
+}
+
+func TestHealthModel_Fix(t *testing.T) {
+	tasksDir := filepath.Join(t.TempDir(), "tasks")
+	config.SetTasksDirOverride(tasksDir)
+	defer config.SetTasksDirOverride("")
+
+	issues, _ := data.CheckHealth()
+	m := NewHealthModel(issues, ScreenProjects)
+	m.width, m.height = 100, 30
+	view := m.View()
+	for _, want := range []string{"Missing directory", tasksDir, "Press f to create it"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the diagnostics", want)
+		}
+	}
+
+	// f creates the directory and checks again
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if _, err := os.Stat(tasksDir); err != nil {
+		t.Fatalf("Expected the tasks directory to be created: %v", err)
+	}
+	m, _ = m.Update(cmd())
+	if len(m.issues) != 0 || !strings.Contains(m.View(), "The tasks directories are fine.") {
+		t.Errorf("Expected no issues left, got %+v", m.issues)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := cmd().(CloseHealthMsg); !ok {
+		t.Error("Expected Enter to continue")
+	}
+}
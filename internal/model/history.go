@@ -0,0 +1,252 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// HistoryModel shows the git history of a single task
+type HistoryModel struct {
+	projectName string
+	taskID      string
+	subject     string
+	width       int
+	height      int
+
+	entries []data.LogEntry
+	cursor  int
+	loading bool
+	err     error
+
+	// Diff of the selected commit
+	diff         string
+	diffHash     string
+	scrollOffset int
+}
+
+type historyLoadedMsg struct {
+	entries []data.LogEntry
+	err     error
+}
+
+type historyDiffMsg struct {
+	hash string
+	diff string
+	err  error
+}
+
+// NewHistoryModel creates a new HistoryModel
+func NewHistoryModel(projectName, taskID, subject string) HistoryModel {
+	return HistoryModel{
+		projectName: projectName,
+		taskID:      taskID,
+		subject:     subject,
+		loading:     true,
+	}
+}
+
+// Init loads the task's git log
+func (m HistoryModel) Init() tea.Cmd {
+	projectName, taskID := m.projectName, m.taskID
+	return func() tea.Msg {
+		entries, err := data.TaskLog(projectName, taskID)
+		return historyLoadedMsg{entries: entries, err: err}
+	}
+}
+
+// loadDiff returns a command loading the diff of the selected commit
+func (m HistoryModel) loadDiff() tea.Cmd {
+	if m.cursor >= len(m.entries) {
+		return nil
+	}
+	projectName, taskID, hash := m.projectName, m.taskID, m.entries[m.cursor].Hash
+	return func() tea.Msg {
+		diff, err := data.TaskDiff(projectName, taskID, hash)
+		return historyDiffMsg{hash: hash, diff: diff, err: err}
+	}
+}
+
+// Update handles messages
+func (m HistoryModel) Update(msg tea.Msg) (HistoryModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case historyLoadedMsg:
+		m.loading = false
+		m.entries = msg.entries
+		m.err = msg.err
+		return m, nil
+
+	case historyDiffMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.diff = msg.diff
+		m.diffHash = msg.hash
+		m.scrollOffset = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+		case "enter", "right":
+			return m, m.loadDiff()
+		case "pgdown":
+			m.scrollOffset += m.diffHeight()
+			m.clampScroll()
+		case "pgup":
+			m.scrollOffset -= m.diffHeight()
+			m.clampScroll()
+		case "esc", "left":
+			if m.diff != "" {
+				m.diff = ""
+				m.diffHash = ""
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return BackToDetailMsg{}
+			}
+		case "q":
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// diffHeight returns the number of diff lines shown at once
+func (m HistoryModel) diffHeight() int {
+	listLines := len(m.entries)
+	if listLines > 8 {
+		listLines = 8
+	}
+	h := m.height - listLines - 10
+	if h < 5 {
+		h = 5
+	}
+	return h
+}
+
+// clampScroll keeps the diff scroll offset within bounds
+func (m *HistoryModel) clampScroll() {
+	maxOff := len(strings.Split(m.diff, "\n")) - m.diffHeight()
+	if maxOff < 0 {
+		maxOff = 0
+	}
+	if m.scrollOffset > maxOff {
+		m.scrollOffset = maxOff
+	}
+	if m.scrollOffset < 0 {
+		m.scrollOffset = 0
+	}
+}
+
+// View renders the history screen
+func (m HistoryModel) View() string {
+	var b strings.Builder
+
+	title := i18n.Tf("History: Task #%s", m.taskID)
+	b.WriteString(ui.Header(title, m.width))
+	b.WriteString("\n")
+	b.WriteString(ui.MutedStyle.Render(m.subject))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.err != nil:
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+		b.WriteString("\n")
+	case m.loading:
+		b.WriteString(ui.MutedStyle.Render(ui.SpinnerFrames[0] + " " + i18n.T("Loading history...")))
+		b.WriteString("\n")
+	case !data.GitHistoryEnabled() && len(m.entries) == 0:
+		b.WriteString(ui.MutedStyle.Render(i18n.T("Git history is disabled.")))
+		b.WriteString("\n")
+		b.WriteString(ui.MutedStyle.Render(i18n.T(`Set "git": {"enabled": true} in ~/.config/cctasks/config.json to record changes.`)))
+		b.WriteString("\n")
+	case len(m.entries) == 0:
+		b.WriteString(ui.MutedStyle.Render(i18n.T("No history recorded for this task yet.")))
+		b.WriteString("\n")
+	default:
+		// Commit list (window of 8 around the cursor)
+		start := 0
+		if m.cursor >= 8 {
+			start = m.cursor - 7
+		}
+		end := start + 8
+		if end > len(m.entries) {
+			end = len(m.entries)
+		}
+		for i := start; i < end; i++ {
+			e := m.entries[i]
+			prefix := "  "
+			if i == m.cursor {
+				prefix = "> "
+			}
+			line := fmt.Sprintf("%s%s  %s  %s", prefix, e.Hash[:7], e.Time.Format("2006-01-02 15:04"), e.Subject)
+			if i == m.cursor {
+				b.WriteString(ui.SelectedStyle.Render(line))
+			} else {
+				b.WriteString(line)
+			}
+			b.WriteString("\n")
+		}
+
+		// Diff of the selected commit
+		if m.diff != "" {
+			b.WriteString("\n")
+			b.WriteString(ui.HorizontalLine(m.width))
+			b.WriteString("\n")
+			lines := strings.Split(m.diff, "\n")
+			endLine := m.scrollOffset + m.diffHeight()
+			if endLine > len(lines) {
+				endLine = len(lines)
+			}
+			for _, line := range lines[m.scrollOffset:endLine] {
+				b.WriteString(renderDiffLine(line))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"↑↓", "Navigate"},
+		{"Enter", "Show Diff"},
+	}
+	if m.diff != "" {
+		keys = append(keys, []string{"PgUp/Dn", "Scroll"})
+	}
+	keys = append(keys, []string{"Esc", "Back"}, []string{"q", "Quit"})
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
+
+// renderDiffLine colors a unified diff line
+func renderDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return ui.MutedStyle.Render(line)
+	case strings.HasPrefix(line, "+"):
+		return ui.SuccessStyle.Render(line)
+	case strings.HasPrefix(line, "-"):
+		return ui.ErrorStyle.Render(line)
+	case strings.HasPrefix(line, "@@"):
+		return ui.KeyStyle.Render(line)
+	default:
+		return line
+	}
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// Polling (follow mode, collaboration mode, terminal size) slows down after
+// idleAfter without input, sooner in low-power mode, and speeds up again on
+// the next key or mouse event or when a poll finds changed files
+const (
+	idleAfter         = time.Minute
+	lowPowerIdleAfter = 10 * time.Second
+	idleSlowdown      = 5 // idle polls are this many times further apart
+)
+
+// lowPower reports whether low-power mode (--low-power, config "lowPower") is on
+func lowPower() bool {
+	return config.Current().LowPower
+}
+
+// idle reports whether the user has been inactive long enough to slow polling
+func (a *App) idle(now time.Time) bool {
+	after := idleAfter
+	if lowPower() {
+		after = lowPowerIdleAfter
+	}
+	return !a.lastActive.IsZero() && now.Sub(a.lastActive) >= after
+}
+
+// pollInterval stretches a polling interval in low-power mode and while idle
+func (a *App) pollInterval(interval time.Duration, now time.Time) time.Duration {
+	if lowPower() {
+		interval *= 2
+	}
+	if a.idle(now) {
+		interval *= idleSlowdown
+	}
+	return interval
+}
+
+// wake records user activity. Coming out of idle, it restarts the slowed
+// polling loops at once, so the first input after a pause sees fresh data.
+func (a *App) wake(now time.Time) tea.Cmd {
+	wasIdle := a.idle(now)
+	a.lastActive = now
+	a.sizePoll = minSizePoll // notice a resize quickly while the user is active
+	if !wasIdle {
+		return nil
+	}
+	slog.Debug("wake from idle")
+
+	var cmds []tea.Cmd
+	if pollTerminalSize {
+		a.sizeSeq++
+		seq := a.sizeSeq
+		cmds = append(cmds, func() tea.Msg { return checkSizeMsg{seq: seq} })
+	}
+	if a.follow {
+		a.followSession++
+		session := a.followSession
+		cmds = append(cmds, func() tea.Msg { return followTickMsg{session: session} })
+	}
+	if a.collabPolling {
+		a.collabSeq++
+		seq := a.collabSeq
+		cmds = append(cmds, func() tea.Msg { return collabTickMsg{seq: seq} })
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+func TestApp_IdlePolling(t *testing.T) {
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	config.SetCurrent(config.Default())
+
+	now := time.Now()
+	a := App{lastActive: now}
+	if got := a.pollInterval(followInterval, now); got != followInterval {
+		t.Errorf("active interval = %v, want %v", got, followInterval)
+	}
+	later := now.Add(idleAfter)
+	if got := a.pollInterval(followInterval, later); got != idleSlowdown*followInterval {
+		t.Errorf("idle interval = %v, want %v", got, idleSlowdown*followInterval)
+	}
+
+	config.Current().LowPower = true
+	if !a.idle(now.Add(lowPowerIdleAfter)) {
+		t.Error("low-power mode should go idle sooner")
+	}
+	if got := a.pollInterval(followInterval, now); got != 2*followInterval {
+		t.Errorf("low-power interval = %v, want %v", got, 2*followInterval)
+	}
+}
+
+func TestApp_WakeRestartsFollowPolling(t *testing.T) {
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	config.SetCurrent(config.Default())
+
+	now := time.Now()
+	a := App{follow: true, followSession: 1, lastActive: now.Add(-2 * idleAfter)}
+	cmd := a.wake(now)
+	if cmd == nil {
+		t.Fatal("expected waking from idle to restart polling")
+	}
+	if a.followSession != 2 || a.idle(now) {
+		t.Errorf("session = %d, idle = %v; want a new session and active state", a.followSession, a.idle(now))
+	}
+	var found bool
+	msgs := []tea.Msg{cmd()}
+	if batch, ok := msgs[0].(tea.BatchMsg); ok {
+		msgs = nil
+		for _, c := range batch {
+			msgs = append(msgs, c())
+		}
+	}
+	for _, msg := range msgs {
+		if tick, ok := msg.(followTickMsg); ok && tick.session == 2 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an immediate follow check, got %v", msgs)
+	}
+
+	if a.wake(now.Add(time.Second)) != nil {
+		t.Error("input while active should not restart polling")
+	}
+}
@@ -0,0 +1,216 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// spinnerInterval is the time between spinner frames
+const spinnerInterval = 100 * time.Millisecond
+
+// LoadingModel shows a spinner while a project loads in the background, and
+// the error if loading fails. An encrypted project asks for its passphrase.
+type LoadingModel struct {
+	projectName string
+	seq         int    // identifies the load; results of abandoned loads are dropped
+	returnTo    Screen // screen shown before the load started
+	frame       int
+	err         error
+	passphrase  textinput.Model // shown when the project is encrypted
+	unlocking   bool            // the passphrase is being checked
+	width       int
+	height      int
+}
+
+// projectLoadedMsg carries the stores of a project loaded in the background
+type projectLoadedMsg struct {
+	seq        int
+	name       string
+	taskStore  *data.TaskStore
+	groupStore *data.GroupStore
+	err        error
+}
+
+type loadingTickMsg struct {
+	seq int
+}
+
+// projectUnlockedMsg reports whether a passphrase unlocked the project
+type projectUnlockedMsg struct {
+	seq int
+	err error
+}
+
+// CancelLoadingMsg returns to the screen shown before a project load started
+type CancelLoadingMsg struct {
+	Screen Screen
+}
+
+// NewLoadingModel creates a LoadingModel for one project load
+func NewLoadingModel(projectName string, seq int, returnTo Screen) LoadingModel {
+	return LoadingModel{
+		projectName: projectName,
+		seq:         seq,
+		returnTo:    returnTo,
+	}
+}
+
+// Init starts loading the project and the spinner
+func (m LoadingModel) Init() tea.Cmd {
+	return tea.Batch(loadProjectCmd(m.seq, m.projectName), m.tick())
+}
+
+// loadProjectCmd returns a command loading a project's tasks and groups
+func loadProjectCmd(seq int, name string) tea.Cmd {
+	return func() tea.Msg {
+		msg := projectLoadedMsg{seq: seq, name: name}
+		msg.taskStore, msg.err = data.LoadTasks(name)
+		if msg.err == nil {
+			msg.groupStore, msg.err = data.LoadGroups(name)
+		}
+		return msg
+	}
+}
+
+// fail shows why the project could not be loaded, asking for the
+// passphrase of an encrypted project
+func (m *LoadingModel) fail(err error) tea.Cmd {
+	m.err = err
+	if !errors.Is(err, data.ErrLocked) {
+		return nil
+	}
+	m.passphrase = textinput.New()
+	m.passphrase.Placeholder = i18n.T("Passphrase")
+	m.passphrase.EchoMode = textinput.EchoPassword
+	m.passphrase.EchoCharacter = '*'
+	m.passphrase.Width = 40
+	m.passphrase.Prompt = "> "
+	m.passphrase.Focus()
+	return textinput.Blink
+}
+
+// unlockCmd checks the passphrase in the background, as deriving the key
+// takes a moment
+func (m LoadingModel) unlockCmd(passphrase string) tea.Cmd {
+	seq, name := m.seq, m.projectName
+	return func() tea.Msg {
+		return projectUnlockedMsg{seq: seq, err: data.UnlockProject(name, passphrase)}
+	}
+}
+
+// tick returns a command advancing the spinner, slower in low-power mode
+func (m LoadingModel) tick() tea.Cmd {
+	seq := m.seq
+	interval := spinnerInterval
+	if lowPower() {
+		interval *= 4
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return loadingTickMsg{seq: seq}
+	})
+}
+
+// Update handles messages
+func (m LoadingModel) Update(msg tea.Msg) (LoadingModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case loadingTickMsg:
+		if msg.seq != m.seq || m.err != nil {
+			return m, nil
+		}
+		m.frame = (m.frame + 1) % len(ui.SpinnerFrames)
+		return m, m.tick()
+
+	case projectUnlockedMsg:
+		if msg.seq != m.seq {
+			return m, nil
+		}
+		m.unlocking = false
+		if msg.err != nil {
+			m.err = msg.err
+			m.passphrase.SetValue("")
+			return m, nil
+		}
+		name := m.projectName
+		return m, func() tea.Msg {
+			return SelectProjectMsg{Name: name}
+		}
+
+	case tea.KeyMsg:
+		if m.passphrase.Focused() && !m.unlocking && msg.String() != "esc" {
+			switch msg.String() {
+			case "enter":
+				if m.passphrase.Value() == "" {
+					return m, nil
+				}
+				m.unlocking = true
+				return m, m.unlockCmd(m.passphrase.Value())
+			default:
+				var cmd tea.Cmd
+				m.passphrase, cmd = m.passphrase.Update(msg)
+				return m, cmd
+			}
+		}
+		switch msg.String() {
+		case "esc":
+			screen := m.returnTo
+			return m, func() tea.Msg {
+				return CancelLoadingMsg{Screen: screen}
+			}
+		case "r":
+			if m.err != nil {
+				name := m.projectName
+				return m, func() tea.Msg {
+					return SelectProjectMsg{Name: name}
+				}
+			}
+		case "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// View renders the loading screen
+func (m LoadingModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ui.Header("cctasks: "+m.projectName, m.width))
+	b.WriteString("\n\n")
+
+	if m.passphrase.Focused() {
+		b.WriteString(ui.WarningStyle.Render(i18n.T("This project is encrypted.")))
+		b.WriteString("\n\n")
+		b.WriteString(m.passphrase.View())
+		b.WriteString("\n\n")
+		switch {
+		case m.unlocking:
+			b.WriteString(ui.MutedStyle.Render(i18n.T("Unlocking...")))
+			b.WriteString("\n\n")
+		case !errors.Is(m.err, data.ErrLocked):
+			b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+			b.WriteString("\n\n")
+		}
+		b.WriteString(ui.Footer([][]string{{"Enter", "Unlock"}, {"Esc", "Back"}}, m.width))
+		return b.String()
+	}
+
+	if m.err != nil {
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+		b.WriteString("\n\n")
+		b.WriteString(ui.Footer([][]string{{"r", "Retry"}, {"Esc", "Back"}, {"q", "Quit"}}, m.width))
+		return b.String()
+	}
+
+	b.WriteString(ui.MutedStyle.Render(ui.SpinnerFrames[m.frame] + " " + i18n.T("Loading tasks...")))
+	b.WriteString("\n\n")
+	b.WriteString(ui.Footer([][]string{{"Esc", "Cancel"}, {"q", "Quit"}}, m.width))
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+// runCmds feeds the messages of cmd, and of the commands they return, back
+// into the app until done reports true; batches are expanded and spinner
+// ticks dropped
+func runCmds(a App, cmd tea.Cmd, done func(App) bool) App {
+	queue := []tea.Cmd{cmd}
+	for i := 0; len(queue) > 0 && i < 20; i++ {
+		next := queue[0]
+		queue = queue[1:]
+		if next == nil {
+			continue
+		}
+		switch msg := next().(type) {
+		case tea.BatchMsg:
+			queue = append(queue, msg...)
+		case loadingTickMsg:
+		default:
+			model, c := a.Update(msg)
+			a = model.(App)
+			if done(a) {
+				return a
+			}
+			queue = append(queue, c)
+		}
+	}
+	return a
+}
+
+func setupLoadingProject(t *testing.T) {
+	t.Helper()
+	tasksDir := testutil.IsolateHome(t)
+
+	projectDir := filepath.Join(tasksDir, "proj")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := data.NewTaskStoreForTest(projectDir, []data.Task{
+		{ID: "1", Subject: "Fix login", Status: "pending"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApp_LoadsProjectInBackground(t *testing.T) {
+	setupLoadingProject(t)
+
+	a := App{screen: ScreenProjects}
+	model, cmd := a.Update(SelectProjectMsg{Name: "proj"})
+	a = model.(App)
+	if a.screen != ScreenLoading || a.store != nil {
+		t.Fatalf("screen = %d, want the loading screen before the project loads", a.screen)
+	}
+	if !containsStr(a.View(), "Loading tasks...") {
+		t.Error("expected the spinner while loading")
+	}
+
+	a = runCmds(a, cmd, func(a App) bool { return a.screen == ScreenTasks })
+	if a.screen != ScreenTasks || a.projectName != "proj" || a.store.tasks.GetTask("1") == nil {
+		t.Fatalf("project not opened: screen = %d", a.screen)
+	}
+}
+
+func TestApp_CancelledLoadIsDropped(t *testing.T) {
+	setupLoadingProject(t)
+
+	a := App{screen: ScreenProjects}
+	model, _ := a.Update(SelectProjectMsg{Name: "proj"})
+	a = model.(App)
+	loaded := loadProjectCmd(a.loadSeq, "proj")()
+
+	model, cmd := a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	a = runCmds(model.(App), cmd, func(a App) bool { return a.screen == ScreenProjects })
+	if a.screen != ScreenProjects {
+		t.Fatalf("screen = %d, want projects after Esc", a.screen)
+	}
+
+	model, _ = a.Update(loaded)
+	if a = model.(App); a.screen != ScreenProjects || a.store != nil {
+		t.Error("a cancelled load should not open the project")
+	}
+}
+
+func TestApp_LoadError(t *testing.T) {
+	setupLoadingProject(t)
+
+	a := App{screen: ScreenProjects}
+	model, _ := a.Update(SelectProjectMsg{Name: "proj"})
+	a = model.(App)
+	model, _ = a.Update(projectLoadedMsg{seq: a.loadSeq, name: "proj", err: os.ErrPermission})
+	a = model.(App)
+	if a.screen != ScreenLoading || !containsStr(a.View(), os.ErrPermission.Error()) {
+		t.Fatal("expected the error on the loading screen")
+	}
+
+	// r retries the load
+	model, cmd := a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	a = runCmds(model.(App), cmd, func(a App) bool { return a.screen == ScreenTasks })
+	if a.screen != ScreenTasks {
+		t.Errorf("screen = %d, want tasks after retry", a.screen)
+	}
+}
+
+func TestApp_UnlockEncryptedProject(t *testing.T) {
+	setupLoadingProject(t)
+	t.Setenv(data.PassphraseEnv, "")
+	if _, err := data.EncryptProject("proj", "s3cret"); err != nil {
+		t.Fatal(err)
+	}
+
+	a := App{screen: ScreenProjects}
+	model, cmd := a.Update(SelectProjectMsg{Name: "proj"})
+	a = runCmds(model.(App), cmd, func(a App) bool { return a.loading.passphrase.Focused() })
+	if a.screen != ScreenLoading || !containsStr(a.View(), "This project is encrypted.") {
+		t.Fatalf("expected the passphrase prompt, got screen %d", a.screen)
+	}
+
+	// A wrong passphrase keeps the prompt
+	model, _ = a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("nope")})
+	model, cmd = model.(App).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	a = runCmds(model.(App), cmd, func(a App) bool { return !a.loading.unlocking })
+	if a.screen != ScreenLoading || !containsStr(a.View(), data.ErrWrongPassphrase.Error()) {
+		t.Fatalf("expected the wrong passphrase error, got screen %d", a.screen)
+	}
+
+	model, _ = a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s3cret")})
+	model, cmd = model.(App).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	a = runCmds(model.(App), cmd, func(a App) bool { return a.screen == ScreenTasks })
+	if a.screen != ScreenTasks || a.store.tasks.GetTask("1") == nil {
+		t.Fatalf("project not opened after unlocking: screen = %d", a.screen)
+	}
+}
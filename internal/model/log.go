@@ -0,0 +1,120 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/logging"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// LogModel shows the recent debug log lines (Ctrl+G from any screen)
+type LogModel struct {
+	lines        []string
+	returnTo     Screen
+	scrollOffset int
+	width        int
+	height       int
+}
+
+// NewLogModel creates a LogModel scrolled to the newest lines
+func NewLogModel() LogModel {
+	m := LogModel{lines: logging.Recent()}
+	m.scrollOffset = len(m.lines)
+	return m
+}
+
+// viewHeight returns the number of log lines shown at once
+func (m LogModel) viewHeight() int {
+	return max(m.height-7, 3)
+}
+
+// clampScroll keeps the scroll offset within bounds
+func (m *LogModel) clampScroll() {
+	maxOff := max(len(m.lines)-m.viewHeight(), 0)
+	m.scrollOffset = min(max(m.scrollOffset, 0), maxOff)
+}
+
+// Init initializes the model
+func (m LogModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m LogModel) Update(msg tea.Msg) (LogModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		m.scrollOffset--
+	case "down", "j":
+		m.scrollOffset++
+	case "pgup":
+		m.scrollOffset -= m.viewHeight()
+	case "pgdown":
+		m.scrollOffset += m.viewHeight()
+	case "home", "g":
+		m.scrollOffset = 0
+	case "end", "G":
+		m.scrollOffset = len(m.lines)
+	case "r":
+		// Pick up lines logged since the viewer opened
+		m.lines = logging.Recent()
+		m.scrollOffset = len(m.lines)
+	case "esc", "ctrl+g":
+		screen := m.returnTo
+		return m, func() tea.Msg {
+			return CloseLogMsg{Screen: screen}
+		}
+	case "q":
+		return m, tea.Quit
+	}
+	m.clampScroll()
+	return m, nil
+}
+
+// View renders the log viewer
+func (m LogModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ui.Header(i18n.T("Debug Log"), m.width))
+	b.WriteString("\n")
+
+	if !logging.Enabled() {
+		b.WriteString("\n")
+		b.WriteString(ui.MutedStyle.Render(i18n.T("Debug logging is off. Start cctasks with --debug to record reloads, saves and key presses.")))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(ui.MutedStyle.Render(ui.Truncate(logging.Path(), max(m.width-2, 20))))
+		b.WriteString("\n\n")
+
+		m.clampScroll()
+		end := min(m.scrollOffset+m.viewHeight(), len(m.lines))
+		for _, line := range m.lines[m.scrollOffset:end] {
+			b.WriteString(ui.Truncate(line, max(m.width-2, 20)))
+			b.WriteString("\n")
+		}
+		if len(m.lines) == 0 {
+			b.WriteString(ui.MutedStyle.Render(i18n.T("Nothing logged yet.")))
+			b.WriteString("\n")
+		} else if len(m.lines) > m.viewHeight() {
+			b.WriteString(ui.MutedStyle.Render(i18n.Tf("lines %d-%d of %d", m.scrollOffset+1, end, len(m.lines))))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	keys := [][]string{
+		{"↑↓/PgUp/Dn", "Scroll"},
+		{"r", "Refresh"},
+		{"Esc", "Back"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
@@ -0,0 +1,447 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// MilestonesModel handles the milestone list screen
+type MilestonesModel struct {
+	milestoneStore *data.MilestoneStore
+	store          *projectStore
+	activeFilter   string // milestone currently filtering the task list
+	width          int
+	height         int
+
+	cursor        int
+	confirmDelete bool
+	err           error
+}
+
+// NewMilestonesModel creates a new MilestonesModel
+func NewMilestonesModel(milestoneStore *data.MilestoneStore, store *projectStore, activeFilter string) MilestonesModel {
+	m := MilestonesModel{
+		milestoneStore: milestoneStore,
+		store:          store,
+		activeFilter:   activeFilter,
+	}
+	// Start on the filtered milestone, if any
+	for i, ms := range milestoneStore.Milestones {
+		if ms.Name == activeFilter {
+			m.cursor = i
+		}
+	}
+	return m
+}
+
+// Init initializes the model
+func (m MilestonesModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m MilestonesModel) Update(msg tea.Msg) (MilestonesModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	milestones := m.milestoneStore.Milestones
+
+	// Delete confirmation mode
+	if m.confirmDelete {
+		switch keyMsg.String() {
+		case "y", "Y":
+			if len(milestones) > 0 {
+				name := milestones[m.cursor].Name
+				m.milestoneStore.DeleteMilestone(name)
+				m.err = m.milestoneStore.Save()
+				// Unassign tasks from the deleted milestone
+				if m.err == nil && m.store.tasks.RenameTaskMilestone(name, "") > 0 {
+					m.err = m.store.tasks.Save()
+				}
+				if name == m.activeFilter {
+					m.activeFilter = ""
+				}
+				if m.cursor >= len(m.milestoneStore.Milestones) {
+					m.cursor = max(len(m.milestoneStore.Milestones)-1, 0)
+				}
+			}
+			m.confirmDelete = false
+		case "n", "N", "esc":
+			m.confirmDelete = false
+		}
+		return m, nil
+	}
+
+	// New, edit and delete change the project
+	if key := keyMsg.String(); (key == "e" || key == "n" || key == "d") && m.store.readOnly() {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(milestones)-1 {
+			m.cursor++
+		}
+	case "enter", "right":
+		if len(milestones) > 0 {
+			name := milestones[m.cursor].Name
+			return m, func() tea.Msg {
+				return FilterMilestoneMsg{Name: name}
+			}
+		}
+	case "a":
+		return m, func() tea.Msg {
+			return FilterMilestoneMsg{Name: ""}
+		}
+	case "e":
+		if len(milestones) > 0 {
+			milestone := milestones[m.cursor]
+			return m, func() tea.Msg {
+				return EditMilestoneMsg{Milestone: &milestone}
+			}
+		}
+	case "n":
+		return m, func() tea.Msg {
+			return EditMilestoneMsg{IsNew: true}
+		}
+	case "d":
+		if len(milestones) > 0 {
+			m.confirmDelete = true
+		}
+	case "esc", "left", "M":
+		return m, func() tea.Msg {
+			return BackToTasksMsg{}
+		}
+	case "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// milestoneDaysLabel describes the time left until a milestone's end date
+func milestoneDaysLabel(milestone data.Milestone, completed, total int, now time.Time) string {
+	if total > 0 && completed == total {
+		return ui.SuccessStyle.Render(i18n.T("done"))
+	}
+	days, ok := milestone.DaysRemaining(now)
+	switch {
+	case !ok:
+		return ""
+	case days > 1:
+		return ui.MutedStyle.Render(i18n.Tf("%d days left", days))
+	case days == 1:
+		return ui.WarningStyle.Render(i18n.T("1 day left"))
+	case days == 0:
+		return ui.WarningStyle.Render(i18n.T("ends today"))
+	case days == -1:
+		return ui.ErrorStyle.Render(i18n.T("1 day overdue"))
+	default:
+		return ui.ErrorStyle.Render(i18n.Tf("%d days overdue", -days))
+	}
+}
+
+// renderMilestoneProgress renders "████░░░░  40% 2/5  5 days left" for a milestone
+func renderMilestoneProgress(milestone data.Milestone, tasks []data.Task, barWidth int) string {
+	completed, total := data.MilestoneProgress(tasks, milestone.Name)
+	ratio := 0.0
+	if total > 0 {
+		ratio = float64(completed) / float64(total)
+	}
+	line := fmt.Sprintf("%s %3.0f%% %s", ui.ProgressBar(ratio, barWidth), ratio*100,
+		ui.MutedStyle.Render(fmt.Sprintf("%d/%d", completed, total)))
+	if days := milestoneDaysLabel(milestone, completed, total, time.Now()); days != "" {
+		line += "  " + days
+	}
+	return line
+}
+
+// milestoneDateRange formats a milestone's dates as "2024-03-01 → 2024-03-14"
+func milestoneDateRange(milestone data.Milestone) string {
+	if milestone.Start == "" && milestone.End == "" {
+		return i18n.T("no dates")
+	}
+	start, end := milestone.Start, milestone.End
+	if start == "" {
+		start = ui.Glyphs.Ellipsis
+	}
+	if end == "" {
+		end = ui.Glyphs.Ellipsis
+	}
+	return start + " " + ui.Glyphs.Arrow + " " + end
+}
+
+// View renders the milestone list
+func (m MilestonesModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ui.Header(i18n.T("Milestones"), m.width))
+	b.WriteString("\n\n")
+
+	milestones := m.milestoneStore.Milestones
+	if m.confirmDelete && len(milestones) > 0 {
+		b.WriteString(ui.Confirm(
+			i18n.T("Delete Milestone"),
+			i18n.Tf("Delete milestone \"%s\"? Its tasks will be unassigned.", milestones[m.cursor].Name),
+			"y", "n",
+		))
+		b.WriteString("\n\n")
+	}
+
+	if len(milestones) == 0 {
+		b.WriteString(ui.MutedStyle.Render(i18n.T("No milestones defined.")))
+		b.WriteString("\n")
+		b.WriteString(ui.MutedStyle.Render(i18n.T("Press 'n' to create a new milestone.")))
+		b.WriteString("\n")
+	}
+
+	nameWidth := 12
+	for _, ms := range milestones {
+		nameWidth = max(nameWidth, lipgloss.Width(ms.Name))
+	}
+	nameWidth = min(nameWidth, 24)
+	barWidth := max(m.width/5, 10)
+
+	for i, ms := range milestones {
+		prefix := "  "
+		style := ui.NormalStyle
+		if i == m.cursor {
+			prefix = "> "
+			style = ui.SelectedStyle
+		}
+		name := ui.PadRight(ui.Truncate(ms.Name, nameWidth), nameWidth)
+		b.WriteString(style.Render(prefix + name))
+		if ms.Name == m.activeFilter {
+			b.WriteString(ui.WarningStyle.Render(" " + ui.Glyphs.Diamond))
+		} else {
+			b.WriteString("  ")
+		}
+		b.WriteString(" ")
+		b.WriteString(renderMilestoneProgress(ms, m.store.tasks.Tasks, barWidth))
+		b.WriteString("\n")
+		b.WriteString(ui.MutedStyle.Render("    " + milestoneDateRange(ms)))
+		b.WriteString("\n")
+	}
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"↑↓", "Navigate"},
+		{"Enter", "Filter tasks"},
+		{"a", "All tasks"},
+		{"Esc", "Back"},
+		{"n", "New"},
+		{"e", "Edit"},
+		{"d", "Delete"},
+		{"q", "Quit"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
+
+// MilestoneEditModel handles the milestone edit dialog
+type MilestoneEditModel struct {
+	milestone      data.Milestone
+	milestoneStore *data.MilestoneStore
+	store          *projectStore
+	isNew          bool
+	width          int
+	height         int
+
+	nameInput  textinput.Model
+	startInput textinput.Model
+	endInput   textinput.Model
+	focusIdx   int // 0=name, 1=start, 2=end
+	err        error
+}
+
+// NewMilestoneEditModel creates a new MilestoneEditModel
+func NewMilestoneEditModel(milestone *data.Milestone, milestoneStore *data.MilestoneStore, store *projectStore, isNew bool) MilestoneEditModel {
+	newInput := func(placeholder string, limit int) textinput.Model {
+		input := textinput.New()
+		input.Placeholder = placeholder
+		input.CharLimit = limit
+		input.Width = 40
+		input.Prompt = "> "
+		return input
+	}
+
+	m := MilestoneEditModel{
+		milestoneStore: milestoneStore,
+		store:          store,
+		isNew:          isNew,
+		nameInput:      newInput(i18n.T("Milestone name (e.g. Sprint 3)"), 50),
+		startInput:     newInput(i18n.T("Start date (YYYY-MM-DD, optional)"), 10),
+		endInput:       newInput(i18n.T("End date (YYYY-MM-DD, optional)"), 10),
+	}
+	m.nameInput.Focus()
+
+	if !isNew && milestone != nil {
+		m.milestone = *milestone
+		m.nameInput.SetValue(milestone.Name)
+		m.startInput.SetValue(milestone.Start)
+		m.endInput.SetValue(milestone.End)
+	}
+	return m
+}
+
+// Init initializes the model
+func (m MilestoneEditModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m MilestoneEditModel) Update(msg tea.Msg) (MilestoneEditModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter", "ctrl+s":
+			return m, m.save()
+		case "esc":
+			return m, func() tea.Msg {
+				return CancelMilestoneEditMsg{}
+			}
+		case "tab", "down":
+			m.focusIdx = (m.focusIdx + 1) % 3
+			m.updateFocus()
+			return m, nil
+		case "shift+tab", "up":
+			m.focusIdx = (m.focusIdx + 2) % 3
+			m.updateFocus()
+			return m, nil
+		}
+	}
+
+	switch m.focusIdx {
+	case 0:
+		m.nameInput, cmd = m.nameInput.Update(msg)
+	case 1:
+		m.startInput, cmd = m.startInput.Update(msg)
+	case 2:
+		m.endInput, cmd = m.endInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m *MilestoneEditModel) updateFocus() {
+	m.nameInput.Blur()
+	m.startInput.Blur()
+	m.endInput.Blur()
+	switch m.focusIdx {
+	case 0:
+		m.nameInput.Focus()
+	case 1:
+		m.startInput.Focus()
+	case 2:
+		m.endInput.Focus()
+	}
+}
+
+func (m *MilestoneEditModel) save() tea.Cmd {
+	milestone := data.Milestone{
+		Name:  strings.TrimSpace(m.nameInput.Value()),
+		Start: strings.TrimSpace(m.startInput.Value()),
+		End:   strings.TrimSpace(m.endInput.Value()),
+	}
+	if m.err = milestone.Validate(); m.err != nil {
+		return nil
+	}
+
+	if m.isNew {
+		m.err = m.milestoneStore.AddMilestone(milestone)
+	} else {
+		m.err = m.milestoneStore.UpdateMilestone(m.milestone.Name, milestone)
+	}
+	if m.err != nil {
+		return nil
+	}
+	if m.err = m.milestoneStore.Save(); m.err != nil {
+		return nil
+	}
+
+	// Keep task assignments pointing at the renamed milestone
+	if !m.isNew && milestone.Name != m.milestone.Name {
+		if m.store.tasks.RenameTaskMilestone(m.milestone.Name, milestone.Name) > 0 {
+			if m.err = m.store.tasks.Save(); m.err != nil {
+				return nil
+			}
+		}
+	}
+
+	return func() tea.Msg {
+		return MilestoneSavedMsg{Store: m.milestoneStore}
+	}
+}
+
+// View renders the milestone edit dialog
+func (m MilestoneEditModel) View() string {
+	inputWidth := max(m.width-6, 30)
+	m.nameInput.Width = inputWidth
+	m.startInput.Width = inputWidth
+	m.endInput.Width = inputWidth
+
+	var b strings.Builder
+
+	title := i18n.T("New Milestone")
+	if !m.isNew {
+		title = i18n.T("Edit Milestone")
+	}
+	b.WriteString(ui.Header(title, m.width))
+	b.WriteString("\n\n")
+
+	fields := []struct {
+		label string
+		input textinput.Model
+	}{
+		{i18n.T("Name:"), m.nameInput},
+		{i18n.T("Start:"), m.startInput},
+		{i18n.T("End:"), m.endInput},
+	}
+	for i, field := range fields {
+		if m.focusIdx == i {
+			b.WriteString(ui.SelectedStyle.Render(field.label))
+		} else {
+			b.WriteString(ui.InputLabelStyle.Render(field.label))
+		}
+		b.WriteString("\n")
+		b.WriteString(field.input.View())
+		b.WriteString("\n\n")
+	}
+
+	if m.err != nil {
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"Tab", "Next"},
+		{"Enter", "Save"},
+		{"Esc", "Cancel"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
@@ -0,0 +1,206 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// newPickerSearch creates the search input used by task pickers
+func newPickerSearch() textinput.Model {
+	pickerSearch := textinput.New()
+	pickerSearch.Placeholder = i18n.T("Type to search tasks...")
+	pickerSearch.CharLimit = 50
+	pickerSearch.Width = 40
+	pickerSearch.Prompt = "/ "
+	return pickerSearch
+}
+
+// pickerCandidates returns the tasks matching query by subject, ID or alias, excluding excludeID
+func pickerCandidates(tasks []data.Task, excludeID, query string) []data.Task {
+	query = strings.ToLower(query)
+	var result []data.Task
+	for _, task := range tasks {
+		// Skip self
+		if excludeID != "" && task.ID == excludeID {
+			continue
+		}
+
+		// Filter by query
+		if query != "" {
+			if !strings.Contains(strings.ToLower(task.Subject), query) &&
+				!strings.Contains(task.ID, query) && !strings.Contains(data.GetTaskAlias(task), query) {
+				continue
+			}
+		}
+
+		result = append(result, task)
+	}
+	return result
+}
+
+// renderTaskPicker renders the multi-select task picker for a dependency field
+func renderTaskPicker(fieldName string, search textinput.Model, tasks []data.Task, cursor int, selected map[string]bool, width int) string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(ui.Header(i18n.Tf("Select Tasks for %s", fieldName), width))
+	b.WriteString("\n\n")
+
+	// Search
+	b.WriteString(ui.InputLabelStyle.Render(i18n.T("Search:")))
+	b.WriteString("\n")
+	b.WriteString(search.View())
+	b.WriteString("\n\n")
+
+	// Task list
+	b.WriteString(ui.HorizontalLine(width))
+	b.WriteString("\n")
+
+	if len(tasks) == 0 {
+		b.WriteString(ui.MutedStyle.Render(i18n.T("No tasks found.")))
+		b.WriteString("\n")
+	} else {
+		maxVisible := 10
+		startIdx := 0
+		if cursor >= maxVisible {
+			startIdx = cursor - maxVisible + 1
+		}
+		endIdx := startIdx + maxVisible
+		if endIdx > len(tasks) {
+			endIdx = len(tasks)
+		}
+
+		for i := startIdx; i < endIdx; i++ {
+			task := tasks[i]
+			prefix := "  "
+			if i == cursor {
+				prefix = "> "
+			}
+
+			checkbox := "[ ]"
+			if selected[task.ID] {
+				checkbox = "[" + ui.Glyphs.Check + "]"
+			}
+
+			statusIcon := ui.StatusIcon(task.Status)
+			line := fmt.Sprintf("%s%s #%s %s %s", prefix, checkbox, data.DisplayID(task), statusIcon, task.Subject)
+
+			if i == cursor {
+				b.WriteString(ui.SelectedStyle.Render(line))
+			} else {
+				b.WriteString(line)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"↑↓", "Navigate"},
+		{"Enter", "Toggle"},
+		{"Tab", "Confirm"},
+		{"Esc", "Cancel"},
+	}
+	b.WriteString(ui.Footer(keys, width))
+
+	return b.String()
+}
+
+// newGroupSearch creates the search input of the group picker
+func newGroupSearch() textinput.Model {
+	groupSearch := textinput.New()
+	groupSearch.Placeholder = i18n.T("Type to search or name a new group...")
+	groupSearch.CharLimit = 50
+	groupSearch.Width = 40
+	groupSearch.Prompt = "/ "
+	return groupSearch
+}
+
+// groupCandidates returns the groups matching query ("" for none is listed
+// while not searching) and the group to offer for creation: the typed name
+// when no group has it
+func groupCandidates(groups []string, query string) (options []string, create string) {
+	query = strings.TrimSpace(query)
+	create = query
+	for _, group := range groups {
+		if query == "" || (group != "" && strings.Contains(strings.ToLower(group), strings.ToLower(query))) {
+			options = append(options, group)
+		}
+		if strings.EqualFold(group, query) {
+			create = ""
+		}
+	}
+	return options, create
+}
+
+// renderGroupPicker renders the single-select group picker of the edit form
+func renderGroupPicker(search textinput.Model, options []string, create string, cursor int, current string, width int) string {
+	var b strings.Builder
+
+	// Header
+	b.WriteString(ui.Header(i18n.T("Select Group"), width))
+	b.WriteString("\n\n")
+
+	// Search
+	b.WriteString(ui.InputLabelStyle.Render(i18n.T("Search:")))
+	b.WriteString("\n")
+	b.WriteString(search.View())
+	b.WriteString("\n\n")
+
+	// Group list, followed by the create row
+	b.WriteString(ui.HorizontalLine(width))
+	b.WriteString("\n")
+
+	rows := make([]string, len(options))
+	for i, group := range options {
+		mark := "( )"
+		if group == current {
+			mark = "(" + ui.Glyphs.Check + ")"
+		}
+		name := i18n.T("(none)")
+		if group != "" {
+			name = group
+		}
+		rows[i] = mark + " " + name
+	}
+	if create != "" {
+		rows = append(rows, "+ "+i18n.Tf("Create new group \"%s\"", create))
+	}
+
+	maxVisible := 10
+	startIdx := 0
+	if cursor >= maxVisible {
+		startIdx = cursor - maxVisible + 1
+	}
+	endIdx := min(startIdx+maxVisible, len(rows))
+	for i := startIdx; i < endIdx; i++ {
+		if i == cursor {
+			b.WriteString(ui.SelectedStyle.Render("> " + rows[i]))
+		} else {
+			b.WriteString("  " + rows[i])
+		}
+		b.WriteString("\n")
+	}
+	if create == "" && search.Value() == "" {
+		b.WriteString(ui.MutedStyle.Render("  + " + i18n.T("Create new group… (type its name)")))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"↑↓", "Navigate"},
+		{"Enter", "Select"},
+		{"Esc", "Cancel"},
+	}
+	b.WriteString(ui.Footer(keys, width))
+
+	return b.String()
+}
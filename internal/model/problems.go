@@ -0,0 +1,280 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// ProblemsModel handles the problems screen: the task files that could not
+// be parsed (their tasks are missing from the list), each of which can be
+// opened, restored from a backup or ignored, then the schema problems of
+// the other files
+type ProblemsModel struct {
+	projectName string
+	store       *projectStore
+	width       int
+	height      int
+
+	cursor  int    // selected unparsable file
+	message string // result of the last restore
+	failed  bool   // the last restore failed
+
+	// Scrolling
+	scrollOffset int
+}
+
+// ShowRawFileMsg opens an unparsable task file in the raw JSON inspector
+type ShowRawFileMsg struct {
+	File string
+}
+
+// BackToProblemsMsg returns from the raw JSON inspector to the problems screen
+type BackToProblemsMsg struct{}
+
+// IgnoreFileMsg stops listing an unparsable task file until its content changes
+type IgnoreFileMsg struct {
+	File string
+	Hash string
+}
+
+// unparsableListLimit is how many unparsable files are shown at once
+const unparsableListLimit = 8
+
+// NewProblemsModel creates a new ProblemsModel
+func NewProblemsModel(projectName string, store *projectStore) ProblemsModel {
+	return ProblemsModel{
+		projectName: projectName,
+		store:       store,
+	}
+}
+
+// Init initializes the model
+func (m ProblemsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m ProblemsModel) Update(msg tea.Msg) (ProblemsModel, tea.Cmd) {
+	files := m.store.unparsable()
+	m.cursor = min(m.cursor, max(len(files)-1, 0))
+
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.scroll(-3)
+		case tea.MouseButtonWheelDown:
+			m.scroll(3)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if len(files) > 0 {
+				m.cursor = max(m.cursor-1, 0)
+			} else {
+				m.scroll(-1)
+			}
+		case "down", "j":
+			if len(files) > 0 {
+				m.cursor = min(m.cursor+1, len(files)-1)
+			} else {
+				m.scroll(1)
+			}
+		case "pgup":
+			m.scroll(-m.viewportHeight())
+		case "pgdown":
+			m.scroll(m.viewportHeight())
+		case "home":
+			m.scrollOffset = 0
+		case "end":
+			m.scroll(len(m.store.problems()))
+		case "enter", "o":
+			if m.cursor < len(files) {
+				file := files[m.cursor].File
+				return m, func() tea.Msg {
+					return ShowRawFileMsg{File: file}
+				}
+			}
+		case "b":
+			if m.cursor < len(files) {
+				file := files[m.cursor].File
+				snapshot, err := m.store.tasks.RestoreFromBackup(file)
+				if err != nil {
+					m.message, m.failed = i18n.Tf("Could not restore %s: %v", file, err), true
+					return m, nil
+				}
+				m.message, m.failed = i18n.Tf("Restored %s from the backup of %s", file, snapshot.Time.Format("2006-01-02 15:04")), false
+				return m, func() tea.Msg {
+					return RefreshMsg{}
+				}
+			}
+		case "i":
+			if m.cursor < len(files) {
+				file := files[m.cursor]
+				m.message = ""
+				return m, func() tea.Msg {
+					return IgnoreFileMsg{File: file.File, Hash: file.Hash}
+				}
+			}
+		case "esc", "left", "!":
+			return m, func() tea.Msg {
+				return BackToTasksMsg{}
+			}
+		case "q":
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// unparsableLines returns the number of lines the unparsable files take
+func (m ProblemsModel) unparsableLines() int {
+	n := len(m.store.unparsable())
+	if n == 0 {
+		return 0
+	}
+	lines := min(n, unparsableListLimit)*2 + 3 // summary, blank, blank after
+	if n > unparsableListLimit {
+		lines++
+	}
+	return lines
+}
+
+// viewportHeight returns the number of problem lines that fit on screen
+func (m ProblemsModel) viewportHeight() int {
+	// header (3) + summary (2) + scroll indicators (2) + footer (3)
+	vh := m.height - 10 - m.unparsableLines()
+	if vh < 5 {
+		vh = 5
+	}
+	return vh
+}
+
+// scroll moves the scroll offset by delta lines, clamped to valid bounds
+func (m *ProblemsModel) scroll(delta int) {
+	m.scrollOffset += delta
+	maxOff := len(m.store.problems()) - m.viewportHeight()
+	if maxOff < 0 {
+		maxOff = 0
+	}
+	if m.scrollOffset > maxOff {
+		m.scrollOffset = maxOff
+	}
+	if m.scrollOffset < 0 {
+		m.scrollOffset = 0
+	}
+}
+
+// View renders the problems screen
+func (m ProblemsModel) View() string {
+	var b strings.Builder
+
+	// Header
+	title := i18n.Tf("Problems: %s", m.projectName)
+	b.WriteString(ui.Header(title, m.width))
+	b.WriteString("\n\n")
+
+	files := m.store.unparsable()
+	cursor := min(m.cursor, max(len(files)-1, 0))
+	if len(files) > 0 {
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("%d task file(s) could not be parsed; their tasks are not listed", len(files))))
+		b.WriteString("\n\n")
+
+		start := max(0, cursor-unparsableListLimit+1)
+		end := min(start+unparsableListLimit, len(files))
+		for i := start; i < end; i++ {
+			f := files[i]
+			name := ui.KeyStyle.Render(f.File)
+			prefix := "  "
+			if i == cursor {
+				prefix = ui.SelectedStyle.Render(ui.Glyphs.Cursor + " ")
+			}
+			b.WriteString(prefix + name + "\n")
+			b.WriteString("    " + ui.MutedStyle.Render(ui.Truncate(f.Error, max(m.width-6, 20))) + "\n")
+		}
+		if hidden := len(files) - (end - start); hidden > 0 {
+			b.WriteString(ui.MutedStyle.Render("  " + i18n.Tf("... and %d more", hidden)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	problems := m.store.problems()
+	if len(problems) == 0 {
+		if len(files) == 0 {
+			b.WriteString(ui.SuccessStyle.Render("✓ " + i18n.T("All task files are valid.")))
+			b.WriteString("\n")
+		}
+	} else {
+		b.WriteString(ui.WarningStyle.Render(i18n.Tf("%d problem(s) found in task files", len(problems))))
+		b.WriteString("\n\n")
+
+		vh := m.viewportHeight()
+		endIdx := m.scrollOffset + vh
+		if endIdx > len(problems) {
+			endIdx = len(problems)
+		}
+
+		if m.scrollOffset > 0 {
+			b.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Up + " " + i18n.Tf("%d more above", m.scrollOffset)))
+			b.WriteString("\n")
+		}
+
+		for _, p := range problems[m.scrollOffset:endIdx] {
+			maxMessageLen := m.width - lipgloss.Width(p.File) - 4
+			if maxMessageLen < 20 {
+				maxMessageLen = 20
+			}
+			file := ui.KeyStyle.Render(p.File)
+			b.WriteString(fmt.Sprintf("  %s %s", file, ui.Truncate(p.Message, maxMessageLen)))
+			b.WriteString("\n")
+		}
+
+		if remaining := len(problems) - endIdx; remaining > 0 {
+			b.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Down + " " + i18n.Tf("%d more below", remaining)))
+			b.WriteString("\n")
+		}
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		if m.failed {
+			b.WriteString(ui.ErrorStyle.Render(m.message))
+		} else {
+			b.WriteString(ui.SuccessStyle.Render(m.message))
+		}
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	if len(files) > 0 {
+		hints := []ui.KeyHint{
+			{Key: "↑↓", Desc: "Select", Enabled: len(files) > 1},
+			{Key: "PgUp/Dn", Desc: "Scroll", Enabled: len(problems) > 0},
+			{Key: "Enter", Desc: "Open raw", Enabled: true},
+			{Key: "b", Desc: "Restore backup", Enabled: true},
+			{Key: "i", Desc: "Ignore", Enabled: true},
+			{Key: "Esc", Desc: "Back", Enabled: true},
+		}
+		b.WriteString(ui.FooterWithHints(hints, m.width))
+		return b.String()
+	}
+	keys := [][]string{
+		{"↑↓", "Scroll"},
+		{"Esc", "Back"},
+		{"q", "Quit"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
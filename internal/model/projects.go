@@ -2,23 +2,34 @@ package model
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbletea"
 
+	"github.com/jss826/cctasks/internal/config"
 	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
 	"github.com/jss826/cctasks/internal/ui"
 )
 
 // ProjectsModel handles the project selection screen
 type ProjectsModel struct {
 	projects []data.Project
+	roots    []config.Root // tasks directories; sections are shown when there are several
+	state    *config.State // favorites and sort mode, persisted between runs
+	sortMode string        // "" (name), "modified" or "count"
+	maxAge   int           // hide projects untouched for more than N days (0 = show all)
 	cursor   int
 	width    int
 	height   int
 	err      error
 	showHelp bool
+	loaded   bool // projects were listed at least once
+	checked  bool // the tasks directories were checked (on the first listing)
+
+	showArchived bool // list archived projects in their own section
 
 	// Double-click detection
 	lastClickTime time.Time
@@ -26,32 +37,235 @@ type ProjectsModel struct {
 }
 
 // NewProjectsModel creates a new ProjectsModel
-func NewProjectsModel() ProjectsModel {
-	return ProjectsModel{}
+func NewProjectsModel(state *config.State) ProjectsModel {
+	m := ProjectsModel{state: state}
+	if state != nil {
+		m.sortMode = state.ProjectSort
+		m.maxAge = state.ProjectAge
+	}
+	return m
 }
 
-// Init initializes the model and loads projects
+// Init initializes the model and loads projects; the first listing also
+// checks the tasks directories
 func (m ProjectsModel) Init() tea.Cmd {
+	check := !m.checked
 	return func() tea.Msg {
+		roots, err := config.GetRoots()
+		if err != nil {
+			return projectsLoadedMsg{err: err}
+		}
+		var health []data.HealthIssue
+		if check {
+			health, _ = data.CheckHealth()
+		}
 		projects, err := data.ListProjects()
-		return projectsLoadedMsg{projects: projects, err: err}
+		return projectsLoadedMsg{projects: projects, roots: roots, health: health, err: err}
 	}
 }
 
 type projectsLoadedMsg struct {
 	projects []data.Project
+	roots    []config.Root
+	health   []data.HealthIssue // problems with the tasks directories (first listing only)
 	err      error
 }
 
+// allProjectsIndex is the cursor position of the "All Projects" entry
+const allProjectsIndex = -2
+
+// projectLine is one line of the project list: a project, a section header
+// or a placeholder
+type projectLine struct {
+	project int    // index into projects, allProjectsIndex, or -1 for headers and placeholders
+	header  string // section title
+	detail  string // muted text after the header, or the placeholder text
+}
+
+// listLines returns the lines of the project list: projects grouped into
+// sections when there are several roots, followed by the archived section
+// when it is shown
+func (m ProjectsModel) listLines() []projectLine {
+	var lines []projectLine
+	if len(m.projects)-m.archivedCount() > 1 {
+		lines = append(lines, projectLine{project: allProjectsIndex})
+	}
+	if len(m.roots) <= 1 {
+		for i, project := range m.projects {
+			if m.isListed(project) {
+				lines = append(lines, projectLine{project: i})
+			}
+		}
+	} else {
+		for _, root := range m.roots {
+			label := root.Name
+			if label == "" {
+				label = i18n.T("Tasks")
+			}
+			detail := root.Location()
+			if err := data.SSHRootError(root.Name); err != nil {
+				detail += "  " + i18n.Tf("(sync failed: %v)", err)
+			}
+			lines = append(lines, projectLine{project: -1, header: label, detail: detail})
+			found := false
+			for i, project := range m.projects {
+				if project.Root == root.Name && m.isListed(project) {
+					lines = append(lines, projectLine{project: i})
+					found = true
+				}
+			}
+			if !found {
+				lines = append(lines, projectLine{project: -1, detail: i18n.T("(no projects)")})
+			}
+		}
+	}
+
+	if m.showArchived {
+		lines = append(lines, projectLine{project: -1, header: i18n.T("Archived")})
+		found := false
+		for i, project := range m.projects {
+			if m.isArchived(project.Name) {
+				lines = append(lines, projectLine{project: i})
+				found = true
+			}
+		}
+		if !found {
+			lines = append(lines, projectLine{project: -1, detail: i18n.T("(no archived projects)")})
+		}
+	}
+	return lines
+}
+
+// isListed reports whether a project is shown in its root section: not
+// archived, and touched within the age filter
+func (m ProjectsModel) isListed(project data.Project) bool {
+	if m.isArchived(project.Name) {
+		return false
+	}
+	return m.maxAge == 0 || time.Since(project.ModTime) <= time.Duration(m.maxAge)*24*time.Hour
+}
+
+// visible returns the indices of the projects the cursor can reach, in list order
+func (m ProjectsModel) visible() []int {
+	var indices []int
+	for _, line := range m.listLines() {
+		if line.project >= 0 || line.project == allProjectsIndex {
+			indices = append(indices, line.project)
+		}
+	}
+	return indices
+}
+
+// moveCursor moves the cursor by delta among the visible projects
+func (m *ProjectsModel) moveCursor(delta int) {
+	visible := m.visible()
+	for pos, i := range visible {
+		if i == m.cursor {
+			pos = min(max(pos+delta, 0), len(visible)-1)
+			m.cursor = visible[pos]
+			return
+		}
+	}
+	m.clampCursor()
+}
+
+// clampCursor moves a cursor left on a hidden project to the next visible
+// one (or the last, if there is none after it)
+func (m *ProjectsModel) clampCursor() {
+	visible := m.visible()
+	if len(visible) == 0 {
+		m.cursor = 0
+		return
+	}
+	for _, i := range visible {
+		if i >= m.cursor {
+			m.cursor = i
+			return
+		}
+	}
+	m.cursor = visible[len(visible)-1]
+}
+
+// currentProject returns the listed project under the cursor, or nil when
+// the cursor is on the "All Projects" entry or the list is empty
+func (m ProjectsModel) currentProject() *data.Project {
+	if m.cursor < 0 {
+		return nil
+	}
+	for _, i := range m.visible() {
+		if i == m.cursor {
+			return &m.projects[i]
+		}
+	}
+	return nil
+}
+
+// selectCmd opens the project (or the combined list) under the cursor
+func (m ProjectsModel) selectCmd() tea.Cmd {
+	if m.cursor == allProjectsIndex {
+		return func() tea.Msg {
+			return ShowAllTasksMsg{}
+		}
+	}
+	project := m.currentProject()
+	if project == nil {
+		return nil
+	}
+	name := project.Name
+	return func() tea.Msg {
+		return SelectProjectMsg{Name: name}
+	}
+}
+
+// hiddenCount returns the number of unarchived projects hidden by the age filter
+func (m ProjectsModel) hiddenCount() int {
+	count := 0
+	for _, project := range m.projects {
+		if !m.isArchived(project.Name) && !m.isListed(project) {
+			count++
+		}
+	}
+	return count
+}
+
+// archivedCount returns the number of archived projects
+func (m ProjectsModel) archivedCount() int {
+	count := 0
+	for _, project := range m.projects {
+		if m.isArchived(project.Name) {
+			count++
+		}
+	}
+	return count
+}
+
 // Update handles messages
 func (m ProjectsModel) Update(msg tea.Msg) (ProjectsModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case projectsLoadedMsg:
+		// Problems with the tasks directories are shown instead of a silently empty list
+		var showHealth tea.Cmd
+		if len(msg.health) > 0 && !m.checked {
+			issues := msg.health
+			showHealth = func() tea.Msg { return ShowHealthMsg{Issues: issues} }
+		}
+		m.checked = true
 		if msg.err != nil {
 			m.err = msg.err
-			return m, nil
+			return m, showHealth
 		}
+		firstLoad := !m.loaded
+		m.loaded = true
 		m.projects = msg.projects
+		m.roots = msg.roots
+		m.sortProjects()
+		m.clampCursor()
+		if showHealth != nil {
+			return m, showHealth
+		}
+		if firstLoad && len(m.projects) == 0 {
+			return m, func() tea.Msg { return ShowSetupMsg{} } // first run
+		}
 		return m, nil
 
 	case tea.MouseMsg:
@@ -60,11 +274,14 @@ func (m ProjectsModel) Update(msg tea.Msg) (ProjectsModel, tea.Cmd) {
 			// Header(2: title+line) + empty(1) + Title(1) + Line(1) + empty(1) = 6 lines before list
 			// If help is shown, add more lines
 			headerLines := 6
-			if len(m.projects) == 0 || m.showHelp {
+			if m.showHelp {
 				headerLines += 18 // Help text lines
 			}
-			clickedIdx := msg.Y - headerLines
-			if clickedIdx >= 0 && clickedIdx < len(m.projects) {
+			clickedIdx := -1
+			if lines := m.listLines(); msg.Y-headerLines >= 0 && msg.Y-headerLines < len(lines) {
+				clickedIdx = lines[msg.Y-headerLines].project
+			}
+			if clickedIdx >= 0 || clickedIdx == allProjectsIndex {
 				now := time.Now()
 				isDoubleClick := clickedIdx == m.lastClickIdx && now.Sub(m.lastClickTime) < 400*time.Millisecond
 
@@ -73,9 +290,7 @@ func (m ProjectsModel) Update(msg tea.Msg) (ProjectsModel, tea.Cmd) {
 					m.cursor = clickedIdx
 					m.lastClickTime = now
 					m.lastClickIdx = clickedIdx
-					return m, func() tea.Msg {
-						return SelectProjectMsg{Name: m.projects[m.cursor].Name}
-					}
+					return m, m.selectCmd()
 				}
 				// Single click on different row: move cursor only
 				m.cursor = clickedIdx
@@ -88,25 +303,30 @@ func (m ProjectsModel) Update(msg tea.Msg) (ProjectsModel, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "up", "k":
-			if m.cursor > 0 {
-				m.cursor--
-			}
+			m.moveCursor(-1)
 		case "down", "j":
-			if m.cursor < len(m.projects)-1 {
-				m.cursor++
-			}
+			m.moveCursor(1)
 		case "enter", "right":
-			if len(m.projects) > 0 {
-				return m, func() tea.Msg {
-					return SelectProjectMsg{Name: m.projects[m.cursor].Name}
-				}
-			}
+			return m, m.selectCmd()
+		case "*":
+			m.toggleFavorite()
+		case "o":
+			m.cycleSortMode()
+		case "a":
+			m.toggleArchived()
+		case "A":
+			m.showArchived = !m.showArchived
+			m.clampCursor()
+		case "t":
+			m.cycleMaxAge()
 		case "q":
 			return m, tea.Quit
 		case "r":
 			return m, m.Init()
 		case "?":
 			m.showHelp = !m.showHelp
+		case "w":
+			return m, func() tea.Msg { return ShowSetupMsg{} }
 		}
 	}
 
@@ -123,30 +343,45 @@ func (m ProjectsModel) View() string {
 	b.WriteString("\n\n")
 
 	// Title
-	b.WriteString(ui.TitleStyle.Render("Projects"))
+	b.WriteString(ui.TitleStyle.Render(i18n.T("Projects")))
+	b.WriteString(ui.MutedStyle.Render("  " + i18n.Tf("Sort (o): %s", m.sortLabel())))
+	b.WriteString(ui.MutedStyle.Render("  " + i18n.Tf("Updated (t): %s", m.maxAgeLabel())))
+	if n := m.hiddenCount(); n > 0 {
+		b.WriteString(ui.MutedStyle.Render("  " + i18n.Tf("%d inactive hidden", n)))
+	}
+	if n := m.archivedCount(); n > 0 && !m.showArchived {
+		b.WriteString(ui.MutedStyle.Render("  " + i18n.Tf("%d archived (A to show)", n)))
+	}
 	b.WriteString("\n")
 	b.WriteString(ui.HorizontalLine(m.width))
 	b.WriteString("\n\n")
 
 	// Error display
 	if m.err != nil {
-		b.WriteString(ui.ErrorStyle.Render("Error: " + m.err.Error()))
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
 		b.WriteString("\n\n")
 	}
 
-	// No projects message or help
-	if len(m.projects) == 0 || m.showHelp {
-		if len(m.projects) == 0 {
-			b.WriteString(ui.MutedStyle.Render("No projects found in ~/.claude/tasks/"))
-			b.WriteString("\n\n")
+	// No projects message
+	if len(m.projects) == 0 && m.loaded {
+		tasksDir := "~/.claude/tasks/"
+		if dir, err := config.GetTasksDir(); err == nil {
+			tasksDir = dir
 		}
+		b.WriteString(ui.MutedStyle.Render(i18n.Tf("No projects found in %s", tasksDir)))
+		b.WriteString("\n")
+		b.WriteString(i18n.Tf("Press %s to run the setup wizard", ui.KeyStyle.Render("w")))
+		b.WriteString("\n\n")
+	}
 
-		b.WriteString(ui.SubtitleStyle.Render("Setup Guide"))
+	// Help
+	if m.showHelp {
+		b.WriteString(ui.SubtitleStyle.Render(i18n.T("Setup Guide")))
+		b.WriteString("\n\n")
+		b.WriteString(i18n.T("To enable the Task List of Claude Code v2.1.16+:"))
+		b.WriteString("\n\n")
+		b.WriteString(i18n.Tf("1. Add the following to %s in your project:", ui.KeyStyle.Render(".claude/settings.local.json")))
 		b.WriteString("\n\n")
-		b.WriteString("Claude Code v2.1.16+ で Task List 機能を有効にする方法:\n\n")
-		b.WriteString("1. プロジェクトの ")
-		b.WriteString(ui.KeyStyle.Render(".claude/settings.local.json"))
-		b.WriteString(" に以下を追加:\n\n")
 		b.WriteString(ui.MutedStyle.Render("   {\n"))
 		b.WriteString(ui.MutedStyle.Render("     \"env\": {\n"))
 		b.WriteString(ui.MutedStyle.Render("       \"CLAUDE_CODE_TASK_LIST_ID\": \""))
@@ -154,10 +389,9 @@ func (m ProjectsModel) View() string {
 		b.WriteString(ui.MutedStyle.Render("\"\n"))
 		b.WriteString(ui.MutedStyle.Render("     }\n"))
 		b.WriteString(ui.MutedStyle.Render("   }\n\n"))
-		b.WriteString("2. タスクは ")
-		b.WriteString(ui.KeyStyle.Render("~/.claude/tasks/your-project-name/"))
-		b.WriteString(" に保存されます\n\n")
-		b.WriteString(ui.MutedStyle.Render("詳細: "))
+		b.WriteString(i18n.Tf("2. Tasks are stored in %s", ui.KeyStyle.Render("~/.claude/tasks/your-project-name/")))
+		b.WriteString("\n\n")
+		b.WriteString(ui.MutedStyle.Render(i18n.T("Details: ")))
 		b.WriteString(ui.ValueStyle.Render("https://docs.anthropic.com/en/docs/claude-code/interactive-mode#task-list"))
 		b.WriteString("\n")
 
@@ -168,19 +402,21 @@ func (m ProjectsModel) View() string {
 		}
 	}
 
-	// Project list
-	for i, project := range m.projects {
-		cursor := "  "
-		style := ui.NormalStyle
-		if i == m.cursor {
-			cursor = "> "
-			style = ui.SelectedStyle
+	// Project list (grouped into sections when there are several roots)
+	for _, line := range m.listLines() {
+		switch {
+		case line.project == allProjectsIndex:
+			b.WriteString(m.renderAllProjects())
+		case line.project >= 0:
+			b.WriteString(m.renderProject(line.project))
+		case line.header != "":
+			b.WriteString(ui.SubtitleStyle.Render(line.header))
+			if line.detail != "" {
+				b.WriteString(ui.MutedStyle.Render("  " + line.detail))
+			}
+		default:
+			b.WriteString(ui.MutedStyle.Render("  " + line.detail))
 		}
-
-		count := ui.CountBadge(project.TaskCount)
-		name := style.Render(project.Name)
-		line := fmt.Sprintf("%s%s %s", cursor, name, count)
-		b.WriteString(line)
 		b.WriteString("\n")
 	}
 
@@ -192,6 +428,12 @@ func (m ProjectsModel) View() string {
 		{"Enter", "Select"},
 		{"?", "Help"},
 		// Operations
+		{"*", "Star"},
+		{"o", "Sort"},
+		{"a", "Archive"},
+		{"A", "Show archived"},
+		{"t", "Inactive filter"},
+		{"w", "Setup"},
 		{"r", "Refresh"},
 		// Exit
 		{"q", "Quit"},
@@ -200,3 +442,203 @@ func (m ProjectsModel) View() string {
 
 	return b.String()
 }
+
+// renderAllProjects renders the "All Projects" entry with the combined
+// counts of every unarchived project
+func (m ProjectsModel) renderAllProjects() string {
+	cursor := "  "
+	style := ui.NormalStyle
+	if m.cursor == allProjectsIndex {
+		cursor = "> "
+		style = ui.SelectedStyle
+	}
+	var total data.Project
+	for _, project := range m.projects {
+		if !m.isArchived(project.Name) {
+			total.Pending += project.Pending
+			total.InProgress += project.InProgress
+			total.Completed += project.Completed
+		}
+	}
+	return fmt.Sprintf("%s%s%s  %s", cursor, ui.SubtitleStyle.Render("◆ "), style.Render(i18n.T("All Projects")), renderStatusCounts(total))
+}
+
+// renderStatusCounts renders a project's pending / in progress / completed counts
+func renderStatusCounts(project data.Project) string {
+	return fmt.Sprintf("%s %s %s",
+		ui.PendingStyle.Render(ui.StatusCount("pending", project.Pending)),
+		ui.InProgressStyle.Render(ui.StatusCount("in_progress", project.InProgress)),
+		ui.CompletedStyle.Render(ui.StatusCount("completed", project.Completed)))
+}
+
+// renderProject renders one project line
+func (m ProjectsModel) renderProject(i int) string {
+	project := m.projects[i]
+	cursor := "  "
+	style := ui.NormalStyle
+	if i == m.cursor {
+		cursor = "> "
+		style = ui.SelectedStyle
+	}
+
+	star := "  "
+	if m.isFavorite(project.Name) {
+		star = ui.WarningStyle.Render(ui.Glyphs.Star) + " "
+	}
+
+	// Projects of additional roots are listed under their section without the
+	// prefix, except in the archived section which mixes roots
+	name := project.Name
+	if !m.isArchived(project.Name) {
+		_, name = config.SplitProjectName(project.Name)
+	}
+	counts := renderStatusCounts(project)
+	updated := ui.MutedStyle.Render(i18n.Tf("updated %s", ui.RelativeTime(project.ModTime, time.Now())))
+	return fmt.Sprintf("%s%s%s  %s  %s", cursor, star, style.Render(name), counts, updated)
+}
+
+// isFavorite reports whether a project is starred
+func (m ProjectsModel) isFavorite(name string) bool {
+	return m.state != nil && m.state.IsFavorite(name)
+}
+
+// isArchived reports whether a project is archived
+func (m ProjectsModel) isArchived(name string) bool {
+	return m.state != nil && m.state.IsArchived(name)
+}
+
+// toggleArchived archives or restores the project under the cursor. Archived
+// projects only leave the list; their files are untouched.
+func (m *ProjectsModel) toggleArchived() {
+	project := m.currentProject()
+	if m.state == nil || project == nil {
+		return
+	}
+	name := project.Name
+	ps := m.state.Project(name)
+	ps.Archived = !ps.Archived
+	m.state.Save() // best effort; the change still applies for this run
+	m.sortProjects()
+	m.selectProject(name)
+	m.clampCursor() // the project may have left the list
+}
+
+// toggleFavorite stars or unstars the project under the cursor; the cursor
+// follows the project as it moves to or from the top of its section
+func (m *ProjectsModel) toggleFavorite() {
+	project := m.currentProject()
+	if m.state == nil || project == nil {
+		return
+	}
+	name := project.Name
+	ps := m.state.Project(name)
+	ps.Favorite = !ps.Favorite
+	m.state.Save() // best effort; the star still applies for this run
+	m.sortProjects()
+	m.selectProject(name)
+}
+
+// cycleSortMode switches between name, last modified and task count order
+func (m *ProjectsModel) cycleSortMode() {
+	var name string
+	if project := m.currentProject(); project != nil {
+		name = project.Name
+	}
+	modes := []string{"", "modified", "count"}
+	next := ""
+	for i, mode := range modes {
+		if mode == m.sortMode {
+			next = modes[(i+1)%len(modes)]
+			break
+		}
+	}
+	m.sortMode = next
+	if m.state != nil {
+		m.state.ProjectSort = next
+		m.state.Save()
+	}
+	m.sortProjects()
+	m.selectProject(name)
+}
+
+// projectAgeFilters are the inactivity thresholds cycled with "t", in days
+var projectAgeFilters = []int{0, 7, 30, 90}
+
+// cycleMaxAge switches the filter hiding projects untouched for N days
+func (m *ProjectsModel) cycleMaxAge() {
+	next := 0
+	for i, days := range projectAgeFilters {
+		if days == m.maxAge {
+			next = projectAgeFilters[(i+1)%len(projectAgeFilters)]
+			break
+		}
+	}
+	m.maxAge = next
+	if m.state != nil {
+		m.state.ProjectAge = next
+		m.state.Save()
+	}
+	m.clampCursor()
+}
+
+// maxAgeLabel returns the display name of the inactivity filter
+func (m ProjectsModel) maxAgeLabel() string {
+	if m.maxAge == 0 {
+		return i18n.T("Any time")
+	}
+	return i18n.Tf("Last %d days", m.maxAge)
+}
+
+// sortLabel returns the display name of the sort mode
+func (m ProjectsModel) sortLabel() string {
+	switch m.sortMode {
+	case "modified":
+		return i18n.T("Last modified")
+	case "count":
+		return i18n.T("Task count")
+	default:
+		return i18n.T("Name")
+	}
+}
+
+// sortProjects orders projects with archived ones last, then by root section,
+// then favorites first, then by the sort mode (ties broken by name)
+func (m *ProjectsModel) sortProjects() {
+	rootIndex := make(map[string]int, len(m.roots))
+	for i, root := range m.roots {
+		rootIndex[root.Name] = i
+	}
+	sort.SliceStable(m.projects, func(i, j int) bool {
+		a, b := m.projects[i], m.projects[j]
+		if aa, ab := m.isArchived(a.Name), m.isArchived(b.Name); aa != ab {
+			return ab
+		}
+		if ra, rb := rootIndex[a.Root], rootIndex[b.Root]; ra != rb {
+			return ra < rb
+		}
+		if fa, fb := m.isFavorite(a.Name), m.isFavorite(b.Name); fa != fb {
+			return fa
+		}
+		switch m.sortMode {
+		case "modified":
+			if !a.ModTime.Equal(b.ModTime) {
+				return a.ModTime.After(b.ModTime)
+			}
+		case "count":
+			if a.TaskCount != b.TaskCount {
+				return a.TaskCount > b.TaskCount
+			}
+		}
+		return a.Name < b.Name
+	})
+}
+
+// selectProject moves the cursor to the named project
+func (m *ProjectsModel) selectProject(name string) {
+	for i, project := range m.projects {
+		if project.Name == name {
+			m.cursor = i
+			return
+		}
+	}
+}
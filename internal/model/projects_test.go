@@ -0,0 +1,161 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func projectNames(m ProjectsModel) []string {
+	var names []string
+	for _, p := range m.projects {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestProjectsModel_SortAndFavorites(t *testing.T) {
+	testutil.IsolateHome(t) // state.json is written on toggle
+
+	now := time.Now()
+	m := NewProjectsModel(&config.State{})
+	m, _ = m.Update(projectsLoadedMsg{projects: []data.Project{
+		{Name: "beta", TaskCount: 5, ModTime: now.Add(-time.Hour)},
+		{Name: "alpha", TaskCount: 1, ModTime: now.Add(-48 * time.Hour)},
+		{Name: "gamma", TaskCount: 3, ModTime: now},
+	}})
+
+	assertOrder := func(want ...string) {
+		t.Helper()
+		got := projectNames(m)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("order = %v, want %v", got, want)
+			}
+		}
+	}
+
+	assertOrder("alpha", "beta", "gamma")
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	assertOrder("gamma", "beta", "alpha")
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	assertOrder("beta", "gamma", "alpha")
+
+	// Starring the last project moves it (and the cursor) to the top
+	m.cursor = 2
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	assertOrder("alpha", "beta", "gamma")
+	if m.cursor != 0 {
+		t.Errorf("Expected cursor to follow the starred project, got %d", m.cursor)
+	}
+	if !m.state.IsFavorite("alpha") || m.state.ProjectSort != "count" {
+		t.Errorf("Expected favorite and sort mode in state, got %+v", m.state)
+	}
+}
+
+func TestProjectsModel_Archive(t *testing.T) {
+	testutil.IsolateHome(t)
+
+	m := NewProjectsModel(&config.State{})
+	m, _ = m.Update(projectsLoadedMsg{projects: []data.Project{
+		{Name: "alpha", TaskCount: 1},
+		{Name: "beta", TaskCount: 1},
+	}})
+
+	key := func(r rune) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	// Archiving hides the project from the list
+	key('a')
+	if !m.state.IsArchived("alpha") {
+		t.Fatal("Expected alpha to be archived")
+	}
+	lines := m.listLines()
+	if len(lines) != 1 || m.projects[lines[0].project].Name != "beta" {
+		t.Fatalf("Expected only beta to be listed, got %+v", lines)
+	}
+	if m.projects[m.cursor].Name != "beta" {
+		t.Errorf("Expected cursor on the only visible project, got %s", m.projects[m.cursor].Name)
+	}
+	key('j')
+	if m.projects[m.cursor].Name != "beta" {
+		t.Errorf("Cursor must not move onto a hidden project, got %s", m.projects[m.cursor].Name)
+	}
+
+	// The archived section lists it again and it can be restored
+	key('A')
+	lines = m.listLines()
+	if len(lines) != 3 || lines[1].header != "Archived" || m.projects[lines[2].project].Name != "alpha" {
+		t.Fatalf("Expected an Archived section with alpha, got %+v", lines)
+	}
+	key('j')
+	key('a')
+	if m.state.IsArchived("alpha") {
+		t.Error("Expected alpha to be restored")
+	}
+	if m.projects[m.cursor].Name != "alpha" {
+		t.Errorf("Expected cursor to follow the restored project, got %s", m.projects[m.cursor].Name)
+	}
+}
+
+func TestProjectsModel_InactiveFilter(t *testing.T) {
+	testutil.IsolateHome(t)
+
+	now := time.Now()
+	m := NewProjectsModel(&config.State{})
+	m, _ = m.Update(projectsLoadedMsg{projects: []data.Project{
+		{Name: "active", TaskCount: 1, ModTime: now.Add(-time.Hour)},
+		{Name: "stale", TaskCount: 1, ModTime: now.Add(-10 * 24 * time.Hour)},
+	}})
+	m.cursor = 1
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if m.maxAge != 7 || m.state.ProjectAge != 7 {
+		t.Fatalf("Expected a 7 day filter, got %d", m.maxAge)
+	}
+	if visible := m.visible(); len(visible) != 2 || visible[0] != allProjectsIndex || m.projects[visible[1]].Name != "active" {
+		t.Errorf("Expected All Projects and the active project, got %v", visible)
+	}
+	if m.projects[m.cursor].Name != "active" || m.hiddenCount() != 1 {
+		t.Errorf("Expected cursor moved off the hidden project, got %s", m.projects[m.cursor].Name)
+	}
+	if !strings.Contains(m.renderProject(m.cursor), "1h ago") {
+		t.Errorf("Expected last activity in %q", m.renderProject(m.cursor))
+	}
+}
+
+func TestProjectsModel_AllProjectsEntry(t *testing.T) {
+	m := NewProjectsModel(&config.State{})
+	m, _ = m.Update(projectsLoadedMsg{projects: []data.Project{
+		{Name: "alpha", TaskCount: 2, Pending: 1, InProgress: 1},
+		{Name: "beta", TaskCount: 1, InProgress: 1},
+	}})
+
+	// The cursor starts on the first project; the combined entry is above it
+	if m.cursor != 0 {
+		t.Fatalf("Expected cursor on the first project, got %d", m.cursor)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.cursor != allProjectsIndex {
+		t.Fatalf("Expected cursor on All Projects, got %d", m.cursor)
+	}
+	if !strings.Contains(m.renderAllProjects(), "●2") {
+		t.Errorf("Expected combined in-progress count in %q", m.renderAllProjects())
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected a command on Enter")
+	}
+	if _, ok := cmd().(ShowAllTasksMsg); !ok {
+		t.Errorf("Expected ShowAllTasksMsg, got %#v", cmd())
+	}
+}
@@ -0,0 +1,209 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// RawJSONModel shows the task file exactly as stored on disk
+type RawJSONModel struct {
+	title        string
+	back         tea.Msg // sent on Esc; nil returns to the task detail
+	path         string
+	content      string
+	lines        []string
+	err          error
+	message      string
+	scrollOffset int
+	width        int
+	height       int
+}
+
+// NewRawJSONModel reads the task file from disk
+func NewRawJSONModel(taskStore *data.TaskStore, task *data.Task) RawJSONModel {
+	m := RawJSONModel{title: i18n.Tf("Raw JSON: Task #%s", task.ID)}
+	m.path, _ = taskStore.TaskFilePath(task.ID)
+	raw, err := taskStore.RawTaskJSON(task.ID)
+	m.setContent(raw, err)
+	return m
+}
+
+// NewRawFileModel reads a file of the project directory that could not be
+// parsed as a task, for the problems screen
+func NewRawFileModel(taskStore *data.TaskStore, name string) RawJSONModel {
+	m := RawJSONModel{title: i18n.Tf("Raw JSON: %s", name), back: BackToProblemsMsg{}}
+	m.path, _ = taskStore.FilePath(name)
+	raw, err := taskStore.RawFile(name)
+	m.setContent(raw, err)
+	return m
+}
+
+// setContent shows the file content read, with the error reading or parsing it
+func (m *RawJSONModel) setContent(raw []byte, err error) {
+	m.err = err
+	m.content = string(raw)
+	if m.content != "" {
+		m.lines = strings.Split(strings.TrimRight(m.content, "\n"), "\n")
+	}
+}
+
+// Init initializes the model
+func (m RawJSONModel) Init() tea.Cmd {
+	return nil
+}
+
+// viewHeight returns the number of JSON lines shown at once
+func (m RawJSONModel) viewHeight() int {
+	return max(m.height-8, 3)
+}
+
+// scroll moves the view by delta lines, keeping it within bounds
+func (m *RawJSONModel) scroll(delta int) {
+	maxOff := max(len(m.lines)-m.viewHeight(), 0)
+	m.scrollOffset = min(max(m.scrollOffset+delta, 0), maxOff)
+}
+
+// Update handles messages
+func (m RawJSONModel) Update(msg tea.Msg) (RawJSONModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	m.message = ""
+	switch keyMsg.String() {
+	case "up", "k":
+		m.scroll(-1)
+	case "down", "j":
+		m.scroll(1)
+	case "pgup":
+		m.scroll(-m.viewHeight())
+	case "pgdown":
+		m.scroll(m.viewHeight())
+	case "home", "g":
+		m.scrollOffset = 0
+	case "end", "G":
+		m.scroll(len(m.lines))
+	case "c", "y":
+		if m.content == "" {
+			return m, nil
+		}
+		if err := clipboard.WriteAll(m.content); err != nil {
+			m.message = ui.ErrorStyle.Render(i18n.Tf("Copy failed: %v", err))
+		} else {
+			m.message = ui.SuccessStyle.Render(i18n.T("Copied to clipboard"))
+		}
+	case "esc", "left", "J":
+		var back tea.Msg = BackToDetailMsg{}
+		if m.back != nil {
+			back = m.back
+		}
+		return m, func() tea.Msg {
+			return back
+		}
+	case "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// View renders the raw JSON inspector
+func (m RawJSONModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ui.Header(m.title, m.width))
+	b.WriteString("\n")
+	b.WriteString(ui.MutedStyle.Render(ui.Truncate(m.path, max(m.width-2, 20))))
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+		b.WriteString("\n\n")
+	}
+
+	end := min(m.scrollOffset+m.viewHeight(), len(m.lines))
+	for _, line := range m.lines[m.scrollOffset:end] {
+		if m.err != nil {
+			// Not valid JSON: show the file as-is
+			b.WriteString(line)
+		} else {
+			b.WriteString(highlightJSON(line))
+		}
+		b.WriteString("\n")
+	}
+	if len(m.lines) > m.viewHeight() {
+		b.WriteString(ui.MutedStyle.Render(i18n.Tf("lines %d-%d of %d", m.scrollOffset+1, end, len(m.lines))))
+		b.WriteString("\n")
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		b.WriteString(m.message)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	keys := [][]string{
+		{"↑↓/PgUp/Dn", "Scroll"},
+		{"c", "Copy"},
+		{"Esc", "Back"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
+
+// highlightJSON colours one line of indented JSON: object keys, strings,
+// numbers and the true/false/null literals get their own styles
+func highlightJSON(line string) string {
+	var b strings.Builder
+	i := 0
+	for i < len(line) {
+		c := line[i]
+		switch {
+		case c == '"':
+			j := i + 1
+			for j < len(line) && line[j] != '"' {
+				if line[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(line))
+			token := line[i:j]
+			if strings.HasPrefix(strings.TrimLeft(line[j:], " "), ":") {
+				b.WriteString(ui.JSONKeyStyle.Render(token))
+			} else {
+				b.WriteString(ui.JSONStringStyle.Render(token))
+			}
+			i = j
+		case c == '-' || (c >= '0' && c <= '9'):
+			j := i + 1
+			for j < len(line) && strings.IndexByte("0123456789.eE+-", line[j]) >= 0 {
+				j++
+			}
+			b.WriteString(ui.JSONNumberStyle.Render(line[i:j]))
+			i = j
+		case c >= 'a' && c <= 'z':
+			j := i + 1
+			for j < len(line) && line[j] >= 'a' && line[j] <= 'z' {
+				j++
+			}
+			b.WriteString(ui.JSONLiteralStyle.Render(line[i:j]))
+			i = j
+		case c == ' ':
+			b.WriteByte(c)
+			i++
+		default:
+			b.WriteString(ui.MutedStyle.Render(string(c)))
+			i++
+		}
+	}
+	return b.String()
+}
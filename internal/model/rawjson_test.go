@@ -0,0 +1,46 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+func TestHighlightJSON(t *testing.T) {
+	line := `  "subject": "Fix \"quoted\" bug", "n": -1.5e3, "ok": true,`
+	got := highlightJSON(line)
+
+	for _, want := range []string{
+		ui.JSONKeyStyle.Render(`"subject"`),
+		ui.JSONStringStyle.Render(`"Fix \"quoted\" bug"`),
+		ui.JSONNumberStyle.Render(`-1.5e3`),
+		ui.JSONLiteralStyle.Render(`true`),
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("highlightJSON(%q) missing %q", line, want)
+		}
+	}
+}
+
+func TestRawJSONModel_Scroll(t *testing.T) {
+	m := RawJSONModel{height: 11}
+	for i := 0; i < 10; i++ {
+		m.lines = append(m.lines, "{}")
+	}
+	// viewHeight is 3, so the last offset is 7
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if m.scrollOffset != 7 {
+		t.Errorf("scrollOffset after G = %d, want 7", m.scrollOffset)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.scrollOffset != 7 {
+		t.Errorf("scrollOffset past end = %d, want 7", m.scrollOffset)
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := cmd().(BackToDetailMsg); !ok {
+		t.Error("esc should return to the detail view")
+	}
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// reasonPrompt asks for a reason: of a status change when the config
+// requires one (requireReason), or of flagging a task blocked. The change is
+// applied once a reason is given.
+type reasonPrompt struct {
+	active bool
+	input  textinput.Model
+	title  string
+	ids    []string // tasks whose status changes
+	status string   // new status ("" when flagging blocked)
+}
+
+// reasonsNeeded returns the tasks among ids whose change to status needs a reason
+func reasonsNeeded(store *data.TaskStore, ids []string, status string) []string {
+	var needed []string
+	for _, id := range ids {
+		if task := store.GetTask(id); task != nil && data.RequiresReason(task.Status, status) {
+			needed = append(needed, id)
+		}
+	}
+	return needed
+}
+
+// reopenTitle returns the prompt title for reopening tasks
+func reopenTitle(store *data.TaskStore, ids []string) string {
+	if len(ids) == 1 {
+		return i18n.Tf("Reason for reopening #%s:", store.DisplayRef(ids[0]))
+	}
+	return i18n.Tf("Reason for reopening %d tasks:", len(ids))
+}
+
+// open starts asking for the reason of changing the tasks to status
+func (p *reasonPrompt) open(title string, ids []string, status string) tea.Cmd {
+	p.input = textinput.New()
+	p.input.Placeholder = i18n.T("Why?")
+	p.input.CharLimit = 200
+	p.input.Width = 50
+	p.input.Prompt = "> "
+	p.input.Focus()
+	p.active, p.title, p.ids, p.status = true, title, ids, status
+	return textinput.Blink
+}
+
+// update handles a message while the prompt is open; reason is set once the
+// user confirms a non-empty reason, and the prompt closes on confirm or Esc
+func (p reasonPrompt) update(msg tea.Msg) (reasonPrompt, string, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "esc":
+			p.active = false
+			return p, "", nil
+		case "enter":
+			reason := strings.TrimSpace(p.input.Value())
+			if reason == "" {
+				return p, "", nil // a reason is required
+			}
+			p.active = false
+			return p, reason, nil
+		}
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return p, "", cmd
+}
+
+// apply sets the status and reason of the prompt's tasks, or flags them
+// blocked for the reason, and saves
+func (p reasonPrompt) apply(store *data.TaskStore, reason string) error {
+	for _, id := range p.ids {
+		if task := store.GetTask(id); task != nil {
+			if p.status == "" {
+				data.SetTaskBlockedReason(task, reason)
+			} else {
+				store.SetStatusReason(id, reason)
+				task.Status = p.status
+			}
+			store.UpdateTask(*task)
+		}
+	}
+	return store.Save()
+}
+
+// view renders the prompt line
+func (p reasonPrompt) view() string {
+	return ui.WarningStyle.Render(p.title) + " " + p.input.View() + "  " +
+		ui.MutedStyle.Render(i18n.T("[Enter] confirm  [Esc] cancel"))
+}
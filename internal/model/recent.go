@@ -0,0 +1,163 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// RecentModel handles the quick-access panel of recently viewed and modified tasks
+type RecentModel struct {
+	projectName string
+	viewed      []data.Task
+	modified    []data.Task
+	list        int // 0 = recently viewed, 1 = recently modified
+	cursor      int
+	width       int
+	height      int
+}
+
+// NewRecentModel creates a new RecentModel from the recently viewed task IDs
+// (most recent first); recently modified tasks come from the history log
+func NewRecentModel(projectName string, taskStore *data.TaskStore, viewedIDs []string) RecentModel {
+	m := RecentModel{projectName: projectName}
+	for _, id := range viewedIDs {
+		if task := taskStore.GetTask(id); task != nil {
+			m.viewed = append(m.viewed, *task)
+		}
+	}
+	if history, err := taskStore.History(); err == nil {
+		m.modified = data.RecentlyModified(taskStore.Tasks, history, config.RecentLimit)
+	}
+	// Open on recently modified when nothing has been viewed yet
+	if len(m.viewed) == 0 && len(m.modified) > 0 {
+		m.list = 1
+	}
+	return m
+}
+
+// current returns the tasks of the selected list
+func (m RecentModel) current() []data.Task {
+	if m.list == 1 {
+		return m.modified
+	}
+	return m.viewed
+}
+
+// Init initializes the model
+func (m RecentModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m RecentModel) Update(msg tea.Msg) (RecentModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	tasks := m.current()
+	switch keyMsg.String() {
+	case "tab", "shift+tab", "left", "right", "h", "l":
+		m.list = 1 - m.list
+		m.cursor = 0
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(tasks)-1 {
+			m.cursor++
+		}
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9", "0":
+		// Jump straight to the numbered entry (0 = 10th)
+		idx := int(keyMsg.String()[0]-'0') - 1
+		if idx < 0 {
+			idx = 9
+		}
+		if idx < len(tasks) {
+			task := tasks[idx]
+			return m, func() tea.Msg {
+				return ViewTaskMsg{Task: &task}
+			}
+		}
+	case "enter":
+		if m.cursor < len(tasks) {
+			task := tasks[m.cursor]
+			return m, func() tea.Msg {
+				return ViewTaskMsg{Task: &task}
+			}
+		}
+	case "esc", "'":
+		return m, func() tea.Msg {
+			return BackToTasksMsg{}
+		}
+	case "q":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// View renders the recent tasks panel
+func (m RecentModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ui.Header(i18n.Tf("Recent: %s", m.projectName), m.width))
+	b.WriteString("\n\n")
+
+	// List tabs
+	tabs := []string{i18n.T("Recently viewed"), i18n.T("Recently modified")}
+	for i, tab := range tabs {
+		if i == m.list {
+			b.WriteString(ui.ActiveButtonStyle.Render(tab))
+		} else {
+			b.WriteString(ui.ButtonStyle.Render(tab))
+		}
+		b.WriteString(" ")
+	}
+	b.WriteString("\n\n")
+
+	tasks := m.current()
+	if len(tasks) == 0 {
+		if m.list == 0 {
+			b.WriteString(ui.MutedStyle.Render(i18n.T("No tasks viewed yet.")))
+		} else {
+			b.WriteString(ui.MutedStyle.Render(i18n.T("No changes recorded yet.")))
+		}
+		b.WriteString("\n")
+	}
+
+	maxSubjectLen := max(m.width-20, 20)
+	for i, task := range tasks {
+		line := fmt.Sprintf("%s %s %s %s",
+			ui.KeyStyle.Render(fmt.Sprintf("%d", (i+1)%10)),
+			ui.GetStatusStyle(task.Status).Render(ui.StatusIcon(task.Status)),
+			ui.MutedStyle.Render("#"+data.DisplayID(task)),
+			ui.Truncate(task.Subject, maxSubjectLen),
+		)
+		if i == m.cursor {
+			b.WriteString(ui.TaskSelectedStyle.Render(ui.Glyphs.Cursor + " " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"↑↓", "Navigate"},
+		{"Enter/1-9", "Open"},
+		{"Tab", "Viewed/Modified"},
+		{"Esc", "Back"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
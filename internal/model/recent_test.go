@@ -0,0 +1,40 @@
+package model
+
+import (
+	"os"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRecentModel_OpenTask(t *testing.T) {
+	taskStore, _, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Unknown IDs (e.g. deleted tasks) are dropped
+	m := NewRecentModel("test", taskStore, []string{"3", "99", "1"})
+	if len(m.viewed) != 2 || m.viewed[0].ID != "3" || m.viewed[1].ID != "1" {
+		t.Fatalf("Expected viewed tasks [3 1], got %+v", m.viewed)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected a command on Enter")
+	}
+	if msg, ok := cmd().(ViewTaskMsg); !ok || msg.Task.ID != "1" {
+		t.Errorf("Expected ViewTaskMsg for task 1, got %#v", cmd())
+	}
+
+	// Number keys open entries directly
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	if msg, ok := cmd().(ViewTaskMsg); !ok || msg.Task.ID != "3" {
+		t.Errorf("Expected ViewTaskMsg for task 3, got %#v", cmd())
+	}
+
+	// Tab switches to the (empty) recently modified list
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.list != 1 || len(m.current()) != 0 {
+		t.Errorf("Expected empty modified list, got list=%d tasks=%d", m.list, len(m.current()))
+	}
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// RenderTaskList renders a project's task list once for non-interactive
+// output (cctasks render): all groups are expanded, no task is selected and
+// key hints are left out. A height of 0 fits every task.
+func RenderTaskList(projectName string, width, height int, showCompleted bool) (string, error) {
+	taskStore, err := data.LoadTasks(projectName)
+	if err != nil {
+		return "", err
+	}
+	groupStore, err := data.LoadGroups(projectName)
+	if err != nil {
+		return "", err
+	}
+
+	m := NewTasksModel(projectName, newProjectStore(taskStore, groupStore))
+	m.headless = true
+	m.hideCompleted = !showCompleted
+	m.collapsedGroups = make(map[string]bool)
+	m.rebuildItems()
+
+	m.width = width
+	if height <= 0 {
+		// The list gets the height minus 15 lines of header and footer
+		height = 15
+		for i := range m.items {
+			height += m.itemLineCount(i)
+		}
+	}
+	m.height = height
+	return m.View(), nil
+}
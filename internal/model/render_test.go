@@ -0,0 +1,60 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestRenderTaskList(t *testing.T) {
+	tasksDir := testutil.IsolateHome(t)
+
+	projectDir := filepath.Join(tasksDir, "proj")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	var tasks []data.Task
+	for i, status := range []string{"pending", "in_progress", "completed"} {
+		for j := 0; j < 10; j++ {
+			id := len(tasks) + 1
+			tasks = append(tasks, data.Task{
+				ID:       strconv.Itoa(id),
+				Subject:  status + " work " + strconv.Itoa(j),
+				Status:   status,
+				Metadata: map[string]interface{}{"group": []string{"Backend", "Frontend", "Docs"}[i]},
+			})
+		}
+	}
+	if _, err := data.NewTaskStoreForTest(projectDir, tasks); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := RenderTaskList("proj", 100, 0, false)
+	if err != nil {
+		t.Fatalf("RenderTaskList failed: %v", err)
+	}
+	// Groups are expanded and every open task fits without scrolling
+	for _, want := range []string{"pending work 9", "in_progress work 9"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"completed work", "more below", "Quit"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("output should not contain %q:\n%s", unwanted, out)
+		}
+	}
+
+	out, err = RenderTaskList("proj", 100, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "completed work 9") {
+		t.Error("--completed should include completed tasks")
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !windows
+
+package model
+
+// pollTerminalSize is true where bubbletea gets no resize events; elsewhere
+// SIGWINCH delivers tea.WindowSizeMsg
+const pollTerminalSize = false
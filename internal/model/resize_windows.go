@@ -0,0 +1,7 @@
+//go:build windows
+
+package model
+
+// pollTerminalSize is true where bubbletea gets no resize events: Windows
+// has no SIGWINCH, so the terminal size is polled
+const pollTerminalSize = true
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// matchContext is how many columns of a field are shown before a match
+const matchContext = 20
+
+// hiddenMatch returns the first field a search matched a task in when its
+// subject did not match, so the row can show why it is listed
+func hiddenMatch(task data.Task, query string) (data.SearchMatch, bool) {
+	if query == "" {
+		return data.SearchMatch{}, false
+	}
+	matches := data.SearchTask(task, query)
+	if len(matches) == 0 || matches[0].Field == "subject" {
+		return data.SearchMatch{}, false
+	}
+	return matches[0], true
+}
+
+// otherFieldMatch returns where a search matched a task when neither its
+// subject nor its description did; such matches are shown under the row
+func otherFieldMatch(task data.Task, query string) (data.SearchMatch, bool) {
+	match, ok := hiddenMatch(task, query)
+	if !ok || match.Field == "description" {
+		return data.SearchMatch{}, false
+	}
+	return match, true
+}
+
+// highlightMatch renders text with the first match of query highlighted and
+// the rest in style; a match cut off by truncation is not highlighted
+func highlightMatch(text, query string, style lipgloss.Style) string {
+	start, end := data.IndexFold(text, query)
+	if query == "" || start < 0 {
+		return style.Render(text)
+	}
+	return style.Render(text[:start]) + ui.SearchMatchStyle.Render(text[start:end]) + style.Render(text[end:])
+}
+
+// matchSnippet renders a field match as "field: text" on one line at most
+// width columns wide, with the matched substring highlighted; the field is
+// named by its JSON key
+func matchSnippet(match data.SearchMatch, width int) string {
+	oneLine := func(s string) string { return strings.ReplaceAll(s, "\n", " ") }
+	label := match.Field + ": "
+	before := oneLine(match.Text[:match.Start])
+	hit := oneLine(match.Text[match.Start:match.End])
+	after := oneLine(match.Text[match.End:])
+
+	// Keep the end of a long text before the match
+	if runes := []rune(before); lipgloss.Width(before) > matchContext {
+		for lipgloss.Width(string(runes)) > matchContext-1 {
+			runes = runes[1:]
+		}
+		before = ui.Glyphs.Ellipsis + string(runes)
+	}
+	rest := width - lipgloss.Width(label+before+hit)
+	if rest > 0 {
+		after = ui.Truncate(after, rest)
+	} else {
+		after = ""
+	}
+	return ui.MutedStyle.Render(label+before) + ui.SearchMatchStyle.Render(hit) + ui.MutedStyle.Render(after)
+}
@@ -0,0 +1,235 @@
+package model
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// Setup wizard steps
+const (
+	setupStepTasksDir = iota // create the tasks directory
+	setupStepName            // choose the project name
+	setupStepSnippet         // show, copy or write the settings snippet
+)
+
+// SetupModel is the first-run wizard that connects a repository's Claude
+// Code task list to cctasks
+type SetupModel struct {
+	step      int
+	tasksDir  string
+	repoDir   string // working directory, where settings.local.json can be written
+	nameInput textinput.Model
+	project   string
+	width     int
+	height    int
+
+	message string // result of the last action
+	err     error
+}
+
+// NewSetupModel creates a new SetupModel, skipping the tasks directory step
+// when the directory already exists
+func NewSetupModel() SetupModel {
+	m := SetupModel{}
+	m.tasksDir, m.err = config.GetTasksDir()
+	m.repoDir, _ = os.Getwd()
+
+	m.nameInput = textinput.New()
+	m.nameInput.CharLimit = 100
+	m.nameInput.Width = 40
+	m.nameInput.Prompt = "> "
+	m.nameInput.SetValue(defaultProjectName(m.repoDir))
+
+	if info, err := os.Stat(m.tasksDir); err == nil && info.IsDir() {
+		m.step = setupStepName
+		m.nameInput.Focus()
+	}
+	return m
+}
+
+// defaultProjectName suggests the repository directory's name
+func defaultProjectName(repoDir string) string {
+	if repoDir == "" {
+		return ""
+	}
+	return strings.ReplaceAll(filepath.Base(repoDir), " ", "-")
+}
+
+// Init initializes the model
+func (m SetupModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m SetupModel) Update(msg tea.Msg) (SetupModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		if m.step == setupStepName {
+			var cmd tea.Cmd
+			m.nameInput, cmd = m.nameInput.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if keyMsg.String() == "esc" {
+		return m, m.doneCmd()
+	}
+
+	switch m.step {
+	case setupStepTasksDir:
+		switch keyMsg.String() {
+		case "enter", "y":
+			if err := os.MkdirAll(m.tasksDir, 0755); err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.step = setupStepName
+			m.nameInput.Focus()
+			return m, textinput.Blink
+		case "n", "q":
+			return m, m.doneCmd()
+		}
+
+	case setupStepName:
+		if keyMsg.String() == "enter" {
+			name := m.nameInput.Value()
+			if err := config.ValidateProjectName(name); err != nil {
+				m.err = err
+				return m, nil
+			}
+			// An empty project directory lists the project before Claude Code adds tasks
+			if err := os.MkdirAll(filepath.Join(m.tasksDir, name), 0755); err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.project = name
+			m.nameInput.Blur()
+			m.step = setupStepSnippet
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.nameInput, cmd = m.nameInput.Update(msg)
+		return m, cmd
+
+	case setupStepSnippet:
+		switch keyMsg.String() {
+		case "w":
+			if m.repoDir == "" {
+				m.err = errors.New(i18n.T("working directory unknown"))
+				return m, nil
+			}
+			path, err := config.WriteTaskListID(m.repoDir, m.project)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.message = i18n.Tf("Wrote %s", path)
+		case "c", "y":
+			if err := clipboard.WriteAll(config.TaskListSnippet(m.project)); err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.message = i18n.T("Copied to clipboard")
+		case "b":
+			m.message = ""
+			m.step = setupStepName
+			m.nameInput.Focus()
+			return m, textinput.Blink
+		case "enter", "q":
+			return m, m.doneCmd()
+		}
+	}
+	return m, nil
+}
+
+// doneCmd closes the wizard
+func (m SetupModel) doneCmd() tea.Cmd {
+	project := m.project
+	return func() tea.Msg {
+		return SetupDoneMsg{Project: project}
+	}
+}
+
+// View renders the setup wizard
+func (m SetupModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(ui.Header(i18n.T("Setup"), m.width))
+	b.WriteString("\n\n")
+	b.WriteString(i18n.T("This wizard connects the Task List of Claude Code v2.1.16+ to cctasks."))
+	b.WriteString("\n")
+	b.WriteString(ui.MutedStyle.Render(i18n.Tf("Step %d of 3", m.step+1)))
+	b.WriteString("\n\n")
+
+	switch m.step {
+	case setupStepTasksDir:
+		b.WriteString(ui.SubtitleStyle.Render(i18n.T("Tasks Directory")))
+		b.WriteString("\n\n")
+		b.WriteString(i18n.Tf("The tasks directory %s does not exist yet. Create it?", ui.KeyStyle.Render(m.tasksDir)))
+		b.WriteString("\n")
+
+	case setupStepName:
+		b.WriteString(ui.SubtitleStyle.Render(i18n.T("Project Name")))
+		b.WriteString("\n\n")
+		b.WriteString(i18n.Tf("Enter the Task List ID (project name). Tasks are stored in %s.", ui.KeyStyle.Render(filepath.Join(m.tasksDir, "<name>"))))
+		b.WriteString("\n\n")
+		b.WriteString(m.nameInput.View())
+		b.WriteString("\n")
+
+	case setupStepSnippet:
+		b.WriteString(ui.SubtitleStyle.Render(i18n.T("Claude Code Settings")))
+		b.WriteString("\n\n")
+		b.WriteString(i18n.Tf("Add the following to %s in your repository:", ui.KeyStyle.Render(".claude/settings.local.json")))
+		b.WriteString("\n\n")
+		for _, line := range strings.Split(config.TaskListSnippet(m.project), "\n") {
+			b.WriteString(ui.MutedStyle.Render("  " + line))
+			b.WriteString("\n")
+		}
+		if m.repoDir != "" {
+			b.WriteString("\n")
+			b.WriteString(ui.MutedStyle.Render(i18n.Tf("w: write to %s (other settings are kept)", config.ClaudeSettingsPath(m.repoDir))))
+			b.WriteString("\n")
+		}
+	}
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+		b.WriteString("\n")
+	}
+	if m.message != "" {
+		b.WriteString("\n")
+		b.WriteString(ui.SuccessStyle.Render(m.message))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	var keys [][]string
+	switch m.step {
+	case setupStepTasksDir:
+		keys = [][]string{{"Enter", "Create"}, {"Esc", "Skip"}}
+	case setupStepName:
+		keys = [][]string{{"Enter", "Next"}, {"Esc", "Skip"}}
+	case setupStepSnippet:
+		keys = [][]string{{"w", "Write"}, {"c", "Copy"}, {"b", "Back"}, {"Enter", "Done"}}
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
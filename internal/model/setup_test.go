@@ -0,0 +1,89 @@
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestSetupModel_CreatesProjectAndWritesSettings(t *testing.T) {
+	tasksDir := filepath.Join(testutil.IsolateHome(t), "tasks")
+	config.SetTasksDirOverride(tasksDir)
+
+	repoDir := t.TempDir()
+	settingsPath := config.ClaudeSettingsPath(repoDir)
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settingsPath, []byte(`{"env": {"FOO": "1"}, "model": "opus"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewSetupModel()
+	m.repoDir = repoDir
+	if m.step != setupStepTasksDir {
+		t.Fatalf("step = %d, want tasks directory step for a missing directory", m.step)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if info, err := os.Stat(tasksDir); err != nil || !info.IsDir() {
+		t.Fatalf("tasks directory not created: %v", err)
+	}
+
+	m.nameInput.SetValue("a/b")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.err == nil || m.step != setupStepName {
+		t.Fatalf("invalid name accepted (step %d)", m.step)
+	}
+	m.nameInput.SetValue("my-app")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.step != setupStepSnippet {
+		t.Fatalf("step = %d, want snippet step (err %v)", m.step, m.err)
+	}
+	if _, err := os.Stat(filepath.Join(tasksDir, "my-app")); err != nil {
+		t.Errorf("project directory not created: %v", err)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if m.err != nil {
+		t.Fatalf("write failed: %v", m.err)
+	}
+	b, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var settings struct {
+		Env   map[string]string `json:"env"`
+		Model string            `json:"model"`
+	}
+	if err := json.Unmarshal(b, &settings); err != nil {
+		t.Fatalf("settings are not valid JSON: %v\n%s", err, b)
+	}
+	if settings.Env[config.TaskListEnv] != "my-app" || settings.Env["FOO"] != "1" || settings.Model != "opus" {
+		t.Errorf("settings = %s", b)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(SetupDoneMsg); !ok || msg.Project != "my-app" {
+		t.Errorf("enter sent %#v, want SetupDoneMsg{my-app}", msg)
+	}
+}
+
+func TestProjectsModel_OffersSetupOnFirstRun(t *testing.T) {
+	m := NewProjectsModel(&config.State{})
+	m, cmd := m.Update(projectsLoadedMsg{})
+	if cmd == nil {
+		t.Fatal("empty first load should open the setup wizard")
+	}
+	if _, ok := cmd().(ShowSetupMsg); !ok {
+		t.Error("expected ShowSetupMsg")
+	}
+	if _, cmd = m.Update(projectsLoadedMsg{}); cmd != nil {
+		t.Error("the wizard should only open automatically once")
+	}
+}
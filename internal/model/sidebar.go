@@ -0,0 +1,162 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// sidebarWidth is the width of the project sidebar, without the line beside it
+const sidebarWidth = 28
+
+// sidebarHeaderLines is the number of lines above the sidebar's projects
+const sidebarHeaderLines = 2
+
+// CycleProjectMsg switches the task list to the previous (-1) or next (1)
+// project of the sidebar
+type CycleProjectMsg struct {
+	Delta int
+}
+
+// sidebarShown reports whether the task list shares the screen with the
+// project sidebar: the terminal is wide enough and the projects were listed
+func (a App) sidebarShown() bool {
+	from := config.Current().TaskList.SidebarMinWidth()
+	return from > 0 && a.width >= from && a.projects.loaded
+}
+
+// taskListWidth returns the width left to the task list beside the sidebar
+func (a App) taskListWidth() int {
+	if a.sidebarShown() {
+		return a.width - sidebarWidth - 1
+	}
+	return a.width
+}
+
+// sidebarProjects returns the projects of the sidebar, in project list order
+func (m ProjectsModel) sidebarProjects() []data.Project {
+	var projects []data.Project
+	for _, i := range m.visible() {
+		if i >= 0 {
+			projects = append(projects, m.projects[i])
+		}
+	}
+	return projects
+}
+
+// adjacentProject returns the project delta places from the named one in
+// the sidebar, wrapping around, or "" when there is no other project
+func adjacentProject(projects []data.Project, name string, delta int) string {
+	if len(projects) < 2 {
+		return ""
+	}
+	for i, project := range projects {
+		if project.Name == name {
+			return projects[(i+delta%len(projects)+len(projects))%len(projects)].Name
+		}
+	}
+	return projects[0].Name
+}
+
+// sidebarOffset returns the first project shown when rows of them fit,
+// scrolled so the current project is visible
+func sidebarOffset(projects []data.Project, current string, rows int) int {
+	for i, project := range projects {
+		if project.Name == current {
+			return max(0, i-rows+1)
+		}
+	}
+	return 0
+}
+
+// sidebarProjectAt returns the project on a line of the sidebar, or "" for
+// lines without one
+func (a App) sidebarProjectAt(y int) string {
+	projects := a.projects.sidebarProjects()
+	rows := a.sidebarRows()
+	row := y - sidebarHeaderLines
+	if row < 0 || row >= rows {
+		return ""
+	}
+	if i := sidebarOffset(projects, a.projectName, rows) + row; i < len(projects) {
+		return projects[i].Name
+	}
+	return ""
+}
+
+// sidebarRows returns how many projects fit in the sidebar, leaving a line
+// for the key hint
+func (a App) sidebarRows() int {
+	return max(a.contentHeight()-sidebarHeaderLines-1, 1)
+}
+
+// renderSidebar renders the project sidebar as one line per row of the
+// content area: the open project is highlighted, the one loading is marked,
+// and each project shows its open task count
+func (a App) renderSidebar() []string {
+	height := a.contentHeight()
+	projects := a.projects.sidebarProjects()
+	rows := a.sidebarRows()
+	loading := ""
+	if a.loading.projectName != "" && a.loading.err == nil {
+		loading = a.loading.projectName
+	}
+
+	lines := []string{
+		ui.TitleStyle.Render(ui.Truncate(i18n.T("Projects"), sidebarWidth)),
+		ui.MutedStyle.Render(strings.Repeat(ui.Glyphs.Line, sidebarWidth)),
+	}
+	offset := sidebarOffset(projects, a.projectName, rows)
+	for _, project := range projects[min(offset, len(projects)):min(offset+rows, len(projects))] {
+		count := fmt.Sprint(project.Pending + project.InProgress)
+		if project.Name == loading {
+			count = ui.Glyphs.Ellipsis
+		}
+		countWidth := lipgloss.Width(count)
+		name := ui.Truncate(project.Name, sidebarWidth-countWidth-3)
+		line := " " + ui.PadRight(name, sidebarWidth-countWidth-2) + count + " "
+		switch project.Name {
+		case a.projectName:
+			line = ui.SelectedStyle.Render(line)
+		case loading:
+			line = ui.WarningStyle.Render(line)
+		default:
+			line = ui.NormalStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, ui.MutedStyle.Render(ui.Truncate(i18n.T("[ / ] switch project"), sidebarWidth)))
+	return lines
+}
+
+// withSidebar places the sidebar left of a screen's content, separated by a
+// vertical line
+func (a App) withSidebar(content string) string {
+	sidebar := a.renderSidebar()
+	lines := strings.Split(content, "\n")
+	sep := ui.MutedStyle.Render(ui.Glyphs.VLine)
+	var b strings.Builder
+	for i := 0; i < max(len(sidebar), len(lines)); i++ {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		left := ""
+		if i < len(sidebar) {
+			left = sidebar[i]
+		}
+		b.WriteString(ui.PadRight(left, sidebarWidth) + sep)
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+// Snapshot tests render screens at several widths and compare them with
+// golden files in testdata/snapshots. After an intended layout change,
+// rewrite the golden files and review the diff:
+//
+//	go test ./internal/model -run Snapshot -update
+var updateSnapshots = flag.Bool("update", false, "rewrite the snapshot golden files")
+
+// snapshotWidths are the terminal widths every screen is rendered at
+var snapshotWidths = []int{60, 80, 120}
+
+const snapshotHeight = 24
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[a-zA-Z]`)
+
+// assertSnapshot compares a rendered screen with testdata/snapshots/<name>.golden
+func assertSnapshot(t *testing.T, name, view string) {
+	t.Helper()
+	lines := strings.Split(ansiEscape.ReplaceAllString(view, ""), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	got := strings.Join(lines, "\n") + "\n"
+
+	path := filepath.Join("testdata", "snapshots", name+".golden")
+	if *updateSnapshots {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s does not match the snapshot (run with -update after reviewing):\n--- want\n%s\n--- got\n%s", name, want, got)
+	}
+}
+
+// setupSnapshotStore returns a store of fixed tasks that render the same on every run
+func setupSnapshotStore(t *testing.T) *projectStore {
+	t.Helper()
+	testutil.IsolateHome(t)
+
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+	if err := i18n.SetLanguage("en"); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := []data.Task{
+		{ID: "1", Subject: "Design the database schema", Status: "completed", Blocks: []string{"2", "3"}, BlockedBy: []string{},
+			Metadata: map[string]interface{}{"group": "Backend", "priority": "high"}},
+		{ID: "2", Subject: "Implement the user API with pagination and filtering", Status: "in_progress", Owner: "alice",
+			ActiveForm: "Implementing the user API", Blocks: []string{"4"}, BlockedBy: []string{"1"},
+			Description: "Endpoints: list, get, create, update. List supports cursor pagination.",
+			Metadata:    map[string]interface{}{"group": "Backend"}},
+		{ID: "3", Subject: "Write migration scripts", Status: "pending", Blocks: []string{}, BlockedBy: []string{"1"},
+			Metadata: map[string]interface{}{"group": "Backend", "priority": "low"}},
+		{ID: "4", Subject: "Build the settings page", Status: "pending", Owner: "bob", Blocks: []string{}, BlockedBy: []string{"2"},
+			Metadata: map[string]interface{}{"group": "Frontend"}},
+		{ID: "5", Subject: "Update the README", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	}
+	groups := []data.TaskGroup{
+		{Name: "Backend", Order: 0, Color: "#8b5cf6"},
+		{Name: "Frontend", Order: 1, Color: "#3b82f6"},
+	}
+	dir := t.TempDir()
+	taskStore, err := data.NewTaskStoreForTest(dir, tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupStore, err := data.NewGroupStoreForTest(dir, groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newProjectStore(taskStore, groupStore)
+}
+
+func TestSnapshots(t *testing.T) {
+	store := setupSnapshotStore(t)
+
+	screens := []struct {
+		name string
+		view func(width int) string
+	}{
+		{"tasks", func(width int) string {
+			m := NewTasksModel("demo", store)
+			m.width, m.height = width, snapshotHeight
+			m.hideCompleted = false
+			m.collapsedGroups = make(map[string]bool)
+			m.rebuildItems()
+			return m.View()
+		}},
+		{"detail", func(width int) string {
+			m := NewDetailModel(store.tasks.GetTask("2"), store)
+			m.width, m.height = width, snapshotHeight
+			return m.View()
+		}},
+		{"edit", func(width int) string {
+			m := NewEditModel(store.tasks.GetTask("2"), store, false)
+			m.SetSize(width, snapshotHeight)
+			return m.View()
+		}},
+		{"groups", func(width int) string {
+			m := NewGroupsModel(store)
+			m.width, m.height = width, snapshotHeight
+			return m.View()
+		}},
+		{"batch", func(width int) string {
+			m := NewBatchEditModel([]string{"3", "4", "5"}, store)
+			m.width, m.height = width, snapshotHeight
+			return m.View()
+		}},
+	}
+	for _, screen := range screens {
+		for _, width := range snapshotWidths {
+			name := screen.name + "-" + strconv.Itoa(width)
+			t.Run(name, func(t *testing.T) {
+				assertSnapshot(t, name, screen.view(width))
+			})
+		}
+	}
+}
@@ -0,0 +1,503 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// StatsModel handles the project statistics screen
+type StatsModel struct {
+	projectName string
+	store       *projectStore
+	history     []data.HistoryEntry
+	chartGroup  int  // 0 = whole project, otherwise index+1 into the group list
+	chartRange  int  // index into burndownRanges
+	heatmap     bool // completion heatmap shown instead of the burndown chart
+	width       int
+	height      int
+}
+
+// burndownRanges are the selectable burndown chart lengths in days
+var burndownRanges = []int{7, 14, 30}
+
+// burndownHeight is the number of rows of the burndown chart
+const burndownHeight = 6
+
+// heatmapMaxWeeks is how many weeks the completion heatmap covers at most
+const heatmapMaxWeeks = 52
+
+// heatColors are the colors of the non-empty heatmap levels, lightest first
+var heatColors = []string{"#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+// groupStats holds task counts and estimates for one group
+type groupStats struct {
+	name       string
+	pending    int
+	inProgress int
+	completed  int
+	remaining  float64
+	total      float64
+}
+
+// NewStatsModel creates a new StatsModel
+func NewStatsModel(projectName string, store *projectStore) StatsModel {
+	history, _ := store.tasks.History()
+	return StatsModel{
+		projectName: projectName,
+		store:       store,
+		history:     history,
+		chartRange:  1,
+	}
+}
+
+// Init initializes the model
+func (m StatsModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m StatsModel) Update(msg tea.Msg) (StatsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "left", "S":
+			return m, func() tea.Msg {
+				return BackToTasksMsg{}
+			}
+		case "tab":
+			m.chartGroup = (m.chartGroup + 1) % (len(m.chartGroups()) + 1)
+		case "shift+tab":
+			n := len(m.chartGroups()) + 1
+			m.chartGroup = (m.chartGroup + n - 1) % n
+		case "d":
+			m.chartRange = (m.chartRange + 1) % len(burndownRanges)
+		case "h":
+			m.heatmap = !m.heatmap
+		case "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// collectGroupStats returns per-group statistics in group order
+func (m StatsModel) collectGroupStats() []groupStats {
+	byGroup := make(map[string]*groupStats)
+	var order []string
+	add := func(name string) *groupStats {
+		if gs, ok := byGroup[name]; ok {
+			return gs
+		}
+		gs := &groupStats{name: name}
+		byGroup[name] = gs
+		order = append(order, name)
+		return gs
+	}
+
+	for _, name := range m.store.groups.GetGroupNames() {
+		add(name)
+	}
+	for _, task := range m.store.tasks.Tasks {
+		group := data.GetTaskGroup(task)
+		if group == "" {
+			group = "Uncategorized"
+		}
+		gs := add(group)
+		estimate := data.GetTaskEstimate(task)
+		gs.total += estimate
+		switch task.Status {
+		case "pending":
+			gs.pending++
+			gs.remaining += estimate
+		case "in_progress", data.StatusNeedsReview:
+			gs.inProgress++
+			gs.remaining += estimate
+		case "completed":
+			gs.completed++
+		}
+	}
+
+	var result []groupStats
+	for _, name := range order {
+		gs := byGroup[name]
+		if gs.pending+gs.inProgress+gs.completed > 0 {
+			result = append(result, *gs)
+		}
+	}
+	return result
+}
+
+// chartGroups returns the groups selectable for the burndown chart
+func (m StatsModel) chartGroups() []string {
+	var names []string
+	for _, gs := range m.collectGroupStats() {
+		names = append(names, gs.name)
+	}
+	return names
+}
+
+// burndown returns the open task counts for the selected chart group and range
+func (m StatsModel) burndown() (string, []int) {
+	days := burndownRanges[m.chartRange]
+	groups := m.chartGroups()
+	if m.chartGroup == 0 || m.chartGroup > len(groups) {
+		return i18n.T("All"), data.Burndown(m.store.tasks.Tasks, m.history, days, time.Now(), nil)
+	}
+	name := groups[m.chartGroup-1]
+	return name, data.Burndown(m.store.tasks.Tasks, m.history, days, time.Now(), groupMatcher(name))
+}
+
+// groupMatcher matches the history entries of a stats group (ungrouped
+// tasks are in Uncategorized)
+func groupMatcher(name string) func(group string) bool {
+	return func(group string) bool {
+		if group == "" {
+			group = "Uncategorized"
+		}
+		return group == name
+	}
+}
+
+// forecast describes when open tasks are done at the pace of the last days
+// days: "finishes in ~6 days (10/24) at 0.8/day"
+func forecast(open int, pace float64, days int, now time.Time) string {
+	if open == 0 {
+		return i18n.T("all done")
+	}
+	eta, ok := data.FinishETA(open, pace)
+	if !ok {
+		return i18n.Tf("no tasks completed in the last %d days", days)
+	}
+	date := now.AddDate(0, 0, eta).Format("01/02")
+	return i18n.Tf("finishes in ~%d day(s) (%s) at %.1f/day", eta, date, pace)
+}
+
+// renderOwners renders the owners legend with each owner's workload; it is
+// empty when no task has an owner
+func renderOwners(tasks []data.Task) string {
+	workloads := data.OwnerWorkloads(tasks)
+	if len(workloads) == 0 || workloads[0].Owner == "" {
+		return ""
+	}
+	names := make([]string, len(workloads))
+	nameWidth := 0
+	for i, w := range workloads {
+		names[i] = w.Owner
+		if w.Owner == "" {
+			names[i] = i18n.T("Unassigned")
+		}
+		nameWidth = max(nameWidth, min(lipgloss.Width(names[i]), 24))
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(ui.MutedStyle.Render(i18n.T("Owners") + ":"))
+	b.WriteString("\n")
+	for i, w := range workloads {
+		badge := ui.OwnerBadge("?", "#6b7280")
+		if w.Owner != "" {
+			owner := data.GetOwnerBadge(w.Owner)
+			badge = ui.OwnerBadge(owner.Initials, owner.Color)
+		}
+		line := fmt.Sprintf("  %s %s  %s", badge, ui.PadRight(ui.Truncate(names[i], nameWidth), nameWidth),
+			ui.MutedStyle.Render(i18n.Tf("%d open (%d in progress) / %d done", w.Open(), w.InProgress, w.Completed)))
+		if w.Remaining > 0 {
+			line += ui.MutedStyle.Render("  " + i18n.Tf("Σ %s left", data.FormatEstimate(w.Remaining)))
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderBurndown draws counts as a block-character column chart with a y-axis
+func renderBurndown(counts []int, height, width int) string {
+	levels := ui.Glyphs.Sparks
+
+	peak := 0
+	for _, c := range counts {
+		peak = max(peak, c)
+	}
+	labelWidth := len(fmt.Sprint(peak))
+	colWidth := 1
+	if len(counts) > 0 {
+		colWidth = max(1, min(3, (width-labelWidth-4)/len(counts)))
+	}
+
+	var b strings.Builder
+	for row := height - 1; row >= 0; row-- {
+		label := ""
+		switch row {
+		case height - 1:
+			label = fmt.Sprint(peak)
+		case 0:
+			label = "0"
+		}
+		b.WriteString(ui.MutedStyle.Render(fmt.Sprintf("  %*s %s", labelWidth, label, ui.Glyphs.AxisTee)))
+		for _, c := range counts {
+			fill := 0
+			if peak > 0 {
+				fill = int(math.Round(float64(c)/float64(peak)*float64(height*8))) - row*8
+			}
+			fill = max(0, min(8, fill))
+			cell := strings.Repeat(levels[fill], max(1, colWidth-1))
+			if colWidth > 1 {
+				cell += " "
+			}
+			b.WriteString(cell)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(ui.MutedStyle.Render(fmt.Sprintf("  %*s %s%s", labelWidth, "", ui.Glyphs.Corner, strings.Repeat(ui.Glyphs.Line, len(counts)*colWidth))))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// heatLevel returns the heatmap level (0-4) of n completions out of a peak
+func heatLevel(n, peak int) int {
+	if n <= 0 || peak <= 0 {
+		return 0
+	}
+	return (n*4 + peak - 1) / peak
+}
+
+// heatCell renders one heatmap cell of the given level
+func heatCell(level int) string {
+	glyph := ui.Glyphs.Heat[level]
+	switch {
+	case level == 0:
+		return ui.MutedStyle.Render(glyph)
+	case ui.NoColor():
+		return glyph
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(heatColors[level-1])).Render(glyph)
+}
+
+// renderHeatmap draws completions per day as a GitHub-style grid: a column
+// per week, oldest first, and a row per weekday from Sunday, with the months
+// above and a legend below
+func renderHeatmap(cells [][7]int, now time.Time) string {
+	peak := 0
+	for _, week := range cells {
+		for _, n := range week {
+			peak = max(peak, n)
+		}
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	start := today.AddDate(0, 0, -int(today.Weekday())-7*(len(cells)-1))
+
+	// Month labels over the first week of each month
+	var months strings.Builder
+	months.WriteString("      ")
+	used := 0
+	for i := range cells {
+		week := start.AddDate(0, 0, 7*i)
+		if (i == 0 || week.Day() <= 7) && 2*i >= used {
+			label := week.Format("Jan")
+			months.WriteString(strings.Repeat(" ", 2*i-used) + label)
+			used = 2*i + len(label) + 1
+			months.WriteString(" ")
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(ui.MutedStyle.Render(strings.TrimRight(months.String(), " ")))
+	b.WriteString("\n")
+	for day := 0; day < 7; day++ {
+		label := ""
+		if day%2 == 1 { // Mon, Wed, Fri
+			label = time.Weekday(day).String()[:3]
+		}
+		b.WriteString(ui.MutedStyle.Render(fmt.Sprintf("  %-3s ", label)))
+		for i, week := range cells {
+			if week[day] >= 0 {
+				b.WriteString(heatCell(heatLevel(week[day], peak)))
+			}
+			if i < len(cells)-1 {
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	legend := make([]string, len(ui.Glyphs.Heat))
+	for level := range legend {
+		legend[level] = heatCell(level)
+	}
+	b.WriteString(ui.MutedStyle.Render("      "+i18n.T("Less")+" ") + strings.Join(legend, " ") + ui.MutedStyle.Render(" "+i18n.T("More")))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// View renders the statistics screen
+func (m StatsModel) View() string {
+	var b strings.Builder
+
+	// Header
+	title := i18n.Tf("Stats: %s", m.projectName)
+	b.WriteString(ui.Header(title, m.width))
+	b.WriteString("\n\n")
+
+	tasks := m.store.tasks.Tasks
+	pending := len(m.store.tasks.GetTasksByStatus("pending"))
+	inProgress := len(m.store.tasks.GetTasksByStatus("in_progress"))
+	completed := len(m.store.tasks.GetTasksByStatus("completed"))
+	total := len(tasks)
+
+	barWidth := m.width / 3
+	if barWidth < 10 {
+		barWidth = 10
+	}
+
+	// Project summary
+	b.WriteString(ui.MutedStyle.Render(i18n.T("Project") + ":"))
+	b.WriteString("\n")
+	count := func(status, format string, n int) string {
+		text := i18n.Tf(format, n)
+		if !ui.NoColor() { // the icon is a status word in monochrome mode
+			text = ui.StatusIcon(status) + " " + text
+		}
+		return ui.GetStatusStyle(status).Render(text)
+	}
+	summary := count("pending", "%d pending", pending) + "  " + count("in_progress", "%d in progress", inProgress)
+	if data.ReviewEnabled() {
+		review := len(m.store.tasks.GetTasksByStatus(data.StatusNeedsReview))
+		summary += "  " + count(data.StatusNeedsReview, "%d awaiting review", review)
+	}
+	b.WriteString(fmt.Sprintf("  %s  %s  (%s)\n",
+		summary,
+		count("completed", "%d completed", completed),
+		i18n.Tf("%d total", total)))
+	if total > 0 {
+		ratio := float64(completed) / float64(total)
+		b.WriteString(fmt.Sprintf("  %s %3.0f%%\n", ui.ProgressBar(ratio, barWidth), ratio*100))
+	}
+	if estimate := data.TotalEstimate(tasks); estimate > 0 {
+		b.WriteString("  ")
+		b.WriteString(ui.LabelValue(i18n.T("Remaining estimate"), i18n.Tf("%s of %s",
+			data.FormatEstimate(data.RemainingEstimate(tasks)), data.FormatEstimate(estimate))))
+		b.WriteString("\n")
+	}
+
+	// Per-group table
+	b.WriteString("\n")
+	b.WriteString(ui.HorizontalLine(m.width))
+	b.WriteString("\n")
+	b.WriteString(ui.MutedStyle.Render(i18n.T("Groups") + ":"))
+	b.WriteString("\n")
+
+	stats := m.collectGroupStats()
+	nameWidth := 12
+	for _, gs := range stats {
+		if w := lipgloss.Width(displayGroupName(gs.name)); w > nameWidth {
+			nameWidth = w
+		}
+	}
+	if nameWidth > 24 {
+		nameWidth = 24
+	}
+	groupBarWidth := barWidth / 2
+	if groupBarWidth < 10 {
+		groupBarWidth = 10
+	}
+
+	for _, gs := range stats {
+		color := m.store.groups.GetGroupColor(gs.name)
+		if gs.name == "Uncategorized" {
+			color = "#6b7280"
+		}
+		name := ui.PadRight(ui.Truncate(displayGroupName(gs.name), nameWidth), nameWidth)
+		groupTotal := gs.pending + gs.inProgress + gs.completed
+		ratio := float64(gs.completed) / float64(groupTotal)
+
+		line := fmt.Sprintf("  %s %s %s %3.0f%%  %s",
+			ui.ColorSwatchStyle(color).Render(ui.Glyphs.Swatch),
+			name,
+			ui.ProgressBar(ratio, groupBarWidth),
+			ratio*100,
+			ui.MutedStyle.Render(i18n.Tf("%d open / %d done", gs.pending+gs.inProgress, gs.completed)),
+		)
+		if gs.total > 0 {
+			line += ui.MutedStyle.Render("  " + i18n.Tf("Σ %s left", data.FormatEstimate(gs.remaining)))
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if len(stats) == 0 {
+		b.WriteString(ui.MutedStyle.Render("  " + i18n.T("(no tasks)")))
+		b.WriteString("\n")
+	}
+	b.WriteString(renderOwners(tasks))
+
+	// Completion forecast at the pace of the chart's range
+	if len(stats) > 0 {
+		days := burndownRanges[m.chartRange]
+		now := time.Now()
+		b.WriteString("\n")
+		b.WriteString(ui.MutedStyle.Render(i18n.Tf("At current pace (last %d days):", days)))
+		b.WriteString("\n")
+		open := pending + inProgress
+		if data.ReviewEnabled() {
+			open += len(m.store.tasks.GetTasksByStatus(data.StatusNeedsReview))
+		}
+		pace := data.CompletionPace(m.history, days, now, nil)
+		b.WriteString(fmt.Sprintf("  %s %s\n", ui.PadRight(i18n.T("Project"), nameWidth+2), forecast(open, pace, days, now)))
+		for _, gs := range stats {
+			name := ui.PadRight(ui.Truncate(displayGroupName(gs.name), nameWidth), nameWidth+2)
+			pace := data.CompletionPace(m.history, days, now, groupMatcher(gs.name))
+			b.WriteString(fmt.Sprintf("  %s %s\n", name, ui.MutedStyle.Render(forecast(gs.pending+gs.inProgress, pace, days, now))))
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(ui.HorizontalLine(m.width))
+	b.WriteString("\n")
+	if m.heatmap {
+		// Completion heatmap, as many weeks as fit
+		now := time.Now()
+		weeks := max(4, min(heatmapMaxWeeks, (m.width-6)/2))
+		cells := data.ActivityHeatmap(m.history, weeks, now)
+		done := 0
+		for _, week := range cells {
+			for _, n := range week {
+				done += max(n, 0)
+			}
+		}
+		b.WriteString(ui.MutedStyle.Render(i18n.Tf("Completions (last %d weeks): %d task(s)", weeks, done)))
+		b.WriteString("\n")
+		b.WriteString(renderHeatmap(cells, now))
+	} else {
+		// Burndown chart
+		chartName, counts := m.burndown()
+		days := len(counts)
+		b.WriteString(ui.MutedStyle.Render(i18n.Tf("Burndown (open tasks, last %d days): ", days)))
+		b.WriteString(displayGroupName(chartName))
+		b.WriteString("\n")
+		b.WriteString(renderBurndown(counts, burndownHeight, m.width))
+		start := time.Now().AddDate(0, 0, 1-days).Format("01/02")
+		b.WriteString(ui.MutedStyle.Render("  " + i18n.Tf("%s → today   %d → %d open", start, counts[0], counts[days-1])))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"Tab", "Chart group"},
+		{"d", "Days"},
+		{"h", "Heatmap"},
+		{"Esc", "Back"},
+		{"q", "Quit"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
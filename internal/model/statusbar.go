@@ -0,0 +1,64 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// statusBarHeight is the number of lines reserved for the status bar
+const statusBarHeight = 1
+
+// renderStatusBar renders the one-line summary of the open project: status
+// counts, what the in-progress task is doing, and the time since the task
+// files last changed (in collaboration mode, who changed them last), or why
+// the last change could not be saved
+func renderStatusBar(ps *projectStore, width int, now time.Time) string {
+	store := ps.tasks
+	var counts data.Project
+	var active []data.Task
+	for _, task := range store.Tasks {
+		switch task.Status {
+		case "pending":
+			counts.Pending++
+		case "in_progress":
+			counts.InProgress++
+			active = append(active, task)
+		case "completed":
+			counts.Completed++
+		}
+	}
+
+	sep := ui.MutedStyle.Render(" " + ui.Glyphs.VLine + " ")
+	parts := []string{renderStatusCounts(counts)}
+
+	if len(active) > 0 {
+		// ActiveForm is what Claude Code shows while working ("Running tests")
+		doing := active[0].ActiveForm
+		if doing == "" {
+			doing = active[0].Subject
+		}
+		if len(active) > 1 {
+			doing += fmt.Sprintf(" (+%d)", len(active)-1)
+		}
+		doing = ui.Truncate(doing, max(width-45, 10)) // room for counts and time
+		parts = append(parts, ui.InProgressStyle.Render(ui.StatusIcon("in_progress")+" "+doing))
+	} else {
+		parts = append(parts, ui.MutedStyle.Render(i18n.T("idle")))
+	}
+
+	if ps.err != nil {
+		parts = append(parts, ui.ErrorStyle.Render(ui.Truncate(ps.err.Error(), max(width-45, 10))))
+	} else if presence := store.Presence(); len(presence) > 0 {
+		// Collaboration mode: who else changed the project last
+		parts = append(parts, renderPresence(store, presence, now))
+	} else {
+		parts = append(parts, ui.MutedStyle.Render(i18n.Tf("changed %s", ui.RelativeTime(store.LastChange(), now))))
+	}
+
+	return " " + strings.Join(parts, sep)
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// projectStore is the open project's data. App owns the only instance and
+// hands it to every screen; reloads swap the task and group stores inside it,
+// so no screen is left reading a stale copy. Screens keep task IDs rather than
+// *data.Task pointers across updates and are told about reloads by App.
+type projectStore struct {
+	tasks  *data.TaskStore
+	groups *data.GroupStore
+
+	// ignored holds the unparsable task files the user chose to ignore, by
+	// file name with their content hash (the project's state)
+	ignored map[string]string
+
+	// err is the last failed save, shown in the status bar until the next key
+	err error
+}
+
+// newProjectStore creates a projectStore for a loaded project
+func newProjectStore(tasks *data.TaskStore, groups *data.GroupStore) *projectStore {
+	return &projectStore{tasks: tasks, groups: groups}
+}
+
+// unparsable returns the task files that could not be parsed on load, less
+// those ignored
+func (s *projectStore) unparsable() []data.UnparsableFile {
+	return s.tasks.UnparsableFiles(s.ignored)
+}
+
+// problems returns the schema problems of the task files that were parsed;
+// unparsable files are listed on their own, or not at all once ignored
+func (s *projectStore) problems() []data.Problem {
+	skip := make(map[string]bool, len(s.tasks.Unparsable))
+	for _, f := range s.tasks.Unparsable {
+		skip[f.File] = true
+	}
+	var problems []data.Problem
+	for _, p := range s.tasks.Problems {
+		if !skip[p.File] {
+			problems = append(problems, p)
+		}
+	}
+	return problems
+}
+
+// replace swaps in freshly loaded stores and returns the previous ones
+func (s *projectStore) replace(tasks *data.TaskStore, groups *data.GroupStore) (*data.TaskStore, *data.GroupStore) {
+	prevTasks, prevGroups := s.tasks, s.groups
+	s.tasks, s.groups = tasks, groups
+	return prevTasks, prevGroups
+}
+
+// save saves the tasks, keeping a failure for the status bar
+func (s *projectStore) save() error {
+	s.err = s.tasks.Save()
+	return s.err
+}
+
+// saveGroups saves the groups, keeping a failure for the status bar
+func (s *projectStore) saveGroups() error {
+	s.err = s.groups.Save()
+	return s.err
+}
+
+// readOnly reports whether the project can only be browsed (it is on an ssh
+// root); keys that would change it call this and show why they do nothing
+func (s *projectStore) readOnly() bool {
+	name := ""
+	if s.tasks != nil {
+		name = s.tasks.ProjectName
+	} else if s.groups != nil {
+		name = s.groups.ProjectName
+	}
+	if !data.IsReadOnly(name) {
+		return false
+	}
+	s.err = data.ErrReadOnlyRoot
+	return true
+}
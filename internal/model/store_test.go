@@ -0,0 +1,93 @@
+package model
+
+import (
+	"os"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func TestApp_ReloadUpdatesOpenDetailInPlace(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	shared := newProjectStore(taskStore, groupStore)
+	a := App{
+		screen:      ScreenDetail,
+		projectName: "test",
+		store:       shared,
+		tasks:       NewTasksModel("test", shared),
+		detail:      NewDetailModel(taskStore.GetTask("1"), shared),
+	}
+	a.detail.scrollOffset = 2
+	a.detail.depSection = 1
+
+	tasks := append([]data.Task(nil), taskStore.Tasks...)
+	tasks[0].Subject = "Task 1 (renamed)"
+	reloaded, err := data.NewTaskStoreForTest(tmpDir, tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.refreshStores(reloaded, groupStore)
+
+	if a.detail.store.tasks != reloaded || a.tasks.store.tasks != reloaded {
+		t.Fatal("screens should share the reloaded store")
+	}
+	if got := a.detail.task().Subject; got != "Task 1 (renamed)" {
+		t.Errorf("detail subject = %q, want the reloaded version", got)
+	}
+	if a.detail.scrollOffset != 2 || a.detail.depSection != 1 {
+		t.Errorf("detail state lost: scroll %d, section %d", a.detail.scrollOffset, a.detail.depSection)
+	}
+
+	// A task deleted on disk keeps showing its last version
+	gone, err := data.NewTaskStoreForTest(tmpDir, tasks[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.refreshStores(gone, groupStore)
+	if got := a.detail.task().Subject; got != "Task 1 (renamed)" {
+		t.Errorf("deleted task subject = %q, want the last version seen", got)
+	}
+}
+
+func TestApp_CollapsedGroupsSavedOnToggle(t *testing.T) {
+	testutil.IsolateHome(t)
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	shared := newProjectStore(taskStore, groupStore)
+	a := App{
+		screen:      ScreenTasks,
+		projectName: "test",
+		store:       shared,
+		tasks:       NewTasksModel("test", shared),
+		state:       &config.State{},
+	}
+	a.tasks.cursor = 0
+	group := a.tasks.items[0].groupName
+
+	var cmd tea.Cmd
+	a.tasks, cmd = a.tasks.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	if cmd == nil {
+		t.Fatal("z should report the collapsed groups")
+	}
+	msg := cmd()
+	if _, ok := msg.(GroupsCollapsedMsg); !ok {
+		t.Fatalf("z sent %T, want GroupsCollapsedMsg", msg)
+	}
+	a.Update(msg)
+
+	state, err := config.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := state.Project("test").TaskList
+	if st == nil || st.CollapsedGroups[group] {
+		t.Errorf("saved list state = %+v, want %s expanded", st, group)
+	}
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// projectSwitcher is the Ctrl+O popup: a fuzzy search over the project
+// names; Enter opens the highlighted project in place of the open one.
+type projectSwitcher struct {
+	active bool
+	input  textinput.Model
+	cursor int
+}
+
+// switcherListLimit is how many matching projects the popup lists
+const switcherListLimit = 10
+
+// newProjectSwitcher creates an open switcher with an empty search
+func newProjectSwitcher() projectSwitcher {
+	input := textinput.New()
+	input.Placeholder = i18n.T("Type to search projects...")
+	input.CharLimit = 50
+	input.Width = 40
+	input.Prompt = "> "
+	input.Focus()
+	return projectSwitcher{active: true, input: input}
+}
+
+// switcherMatches returns the project names matching query, best match first
+// (all names in list order for an empty query)
+func switcherMatches(projects []data.Project, query string) []string {
+	type match struct {
+		name  string
+		score int
+	}
+	var matches []match
+	for _, project := range projects {
+		if score, ok := data.FuzzyScore(project.Name, query); ok {
+			matches = append(matches, match{project.Name, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m.name
+	}
+	return names
+}
+
+// update handles a key while the popup is shown; chosen is the project to
+// open once Enter is pressed, and the popup closes on Enter or Esc
+func (s projectSwitcher) update(msg tea.KeyMsg, projects []data.Project) (next projectSwitcher, chosen string) {
+	matches := switcherMatches(projects, s.input.Value())
+	switch msg.String() {
+	case "esc", "ctrl+o":
+		return projectSwitcher{}, ""
+	case "enter":
+		if s.cursor < len(matches) {
+			return projectSwitcher{}, matches[s.cursor]
+		}
+		return s, ""
+	case "up", "ctrl+p":
+		s.cursor = max(s.cursor-1, 0)
+		return s, ""
+	case "down", "ctrl+n":
+		s.cursor = min(s.cursor+1, max(min(len(matches), switcherListLimit)-1, 0))
+		return s, ""
+	}
+	s.input, _ = s.input.Update(msg)
+	s.cursor = 0 // the best match of the new query
+	return s, ""
+}
+
+// view renders the popup centered on the screen, marking the open project
+func (s projectSwitcher) view(projects []data.Project, current string, width, height int) string {
+	var b strings.Builder
+	b.WriteString(ui.DialogTitleStyle.Render(i18n.T("Switch Project")))
+	b.WriteString("\n\n")
+	b.WriteString(s.input.View())
+	b.WriteString("\n")
+
+	matches := switcherMatches(projects, s.input.Value())
+	for i, name := range matches {
+		if i == switcherListLimit {
+			b.WriteString("\n" + ui.MutedStyle.Render("  "+i18n.Tf("... and %d more", len(matches)-switcherListLimit)))
+			break
+		}
+		line := ui.Truncate(name, 48)
+		if name == current {
+			line += ui.MutedStyle.Render(" " + i18n.T("(open)"))
+		}
+		if i == s.cursor {
+			line = ui.SelectedStyle.Render(ui.Glyphs.Cursor + " " + line)
+		} else {
+			line = "  " + line
+		}
+		b.WriteString("\n" + line)
+	}
+	if len(matches) == 0 {
+		b.WriteString("\n" + ui.MutedStyle.Render("  "+i18n.T("No matching projects")))
+	}
+	b.WriteString("\n\n" + ui.MutedStyle.Render(i18n.T("[Enter] open  [Esc] cancel")))
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, ui.DialogBoxStyle.Render(b.String()))
+}
+
+// inForm reports whether a form is shown whose unsaved input switching
+// projects would lose
+func (a App) inForm() bool {
+	switch a.screen {
+	case ScreenEdit, ScreenGroupEdit, ScreenMilestoneEdit, ScreenBatchEdit, ScreenExport, ScreenSetup, ScreenRecover, ScreenConflict:
+		return true
+	}
+	return false
+}
+
+// updateSwitcher opens the project switcher (Ctrl+O) or passes it a key;
+// choosing a project loads it in place and then shows the same kind of
+// screen for it
+func (a App) updateSwitcher(msg tea.KeyMsg) (App, tea.Cmd) {
+	if !a.switcher.active {
+		a.switcher = newProjectSwitcher()
+		return a, tea.Batch(textinput.Blink, a.projects.Init())
+	}
+	var name string
+	a.switcher, name = a.switcher.update(msg, a.projects.projects)
+	if name == "" || (name == a.projectName && a.screen != ScreenProjects && a.screen != ScreenAllTasks) {
+		return a, nil
+	}
+	a.reopen = a.screen
+	return a, func() tea.Msg {
+		return SelectProjectMsg{Name: name, InPlace: true}
+	}
+}
+
+// reopenCmd returns the command showing, for the project just opened, the
+// screen the switcher was used on; screens of a single task (and screens
+// outside a project) give way to the task list
+func (a *App) reopenCmd() tea.Cmd {
+	screen := a.reopen
+	a.reopen = ScreenProjects
+	var msg tea.Msg
+	switch screen {
+	case ScreenStats:
+		msg = ShowStatsMsg{}
+	case ScreenTimeline:
+		msg = ShowTimelineMsg{}
+	case ScreenMilestones:
+		msg = ManageMilestonesMsg{}
+	case ScreenGroups:
+		msg = ManageGroupsMsg{}
+	case ScreenTrash:
+		msg = ShowTrashMsg{}
+	case ScreenRecent:
+		msg = ShowRecentMsg{}
+	case ScreenProblems:
+		msg = ShowProblemsMsg{}
+	default:
+		return nil
+	}
+	return func() tea.Msg { return msg }
+}
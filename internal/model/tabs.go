@@ -0,0 +1,168 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// workspaceTab is a project open in a tab of the workspace, with its task
+// list (filters, cursor, collapsed groups) kept while another tab is shown
+type workspaceTab struct {
+	projectName string
+	store       *projectStore
+	tasks       TasksModel
+}
+
+// tabNameWidth is how much of each project name the tab bar shows
+const tabNameWidth = 16
+
+// typing reports whether a text input has the focus, where Ctrl+T and Ctrl+W
+// edit the text instead of the tabs
+func (a App) typing() bool {
+	switch a.screen {
+	case ScreenEdit, ScreenGroupEdit, ScreenMilestoneEdit, ScreenBatchEdit, ScreenExport, ScreenSetup, ScreenRecover:
+		return true
+	case ScreenTasks:
+		return a.tasks.searchInput.Focused() || a.tasks.reason.active
+	case ScreenDetail:
+		return a.detail.checkInput.Focused() || a.detail.pickerSearch.Focused()
+	case ScreenLoading:
+		return a.loading.passphrase.Focused()
+	}
+	return false
+}
+
+// updateTabs handles the workspace keys: Ctrl+T opens a project in a new
+// tab, Ctrl+PgDn / Ctrl+PgUp (Ctrl+Tab where the terminal reports it) cycle
+// the tabs and Ctrl+W closes the shown one. handled is false for other keys.
+func (a App) updateTabs(msg tea.KeyMsg) (next App, cmd tea.Cmd, handled bool) {
+	if a.typing() {
+		return a, nil, false
+	}
+	switch msg.String() {
+	case "ctrl+t":
+		if a.store == nil || a.screen == ScreenProjects {
+			return a, nil, true
+		}
+		a.saveTab()
+		a.rememberTaskList()
+		a.openingTab = true
+		a.screen = ScreenProjects
+		return a, a.projects.Init(), true
+	case "ctrl+pgdown", "ctrl+tab":
+		return a, a.cycleTab(1), true
+	case "ctrl+pgup", "ctrl+shift+tab":
+		return a, a.cycleTab(-1), true
+	case "ctrl+w":
+		return a, a.closeTab(), true
+	}
+	return a, nil, false
+}
+
+// findTab returns the index of the tab showing a project, or -1
+func (a App) findTab(projectName string) int {
+	for i, tab := range a.tabs {
+		if tab.projectName == projectName {
+			return i
+		}
+	}
+	return -1
+}
+
+// saveTab stores the shown project's task list into its tab
+func (a *App) saveTab() {
+	if a.tab < len(a.tabs) && a.store != nil {
+		a.tabs[a.tab] = workspaceTab{projectName: a.projectName, store: a.store, tasks: a.tasks}
+	}
+}
+
+// placeTab puts a project just loaded into the shown tab, or into a new tab
+// when one was asked for with Ctrl+T
+func (a *App) placeTab() {
+	tab := workspaceTab{projectName: a.projectName, store: a.store, tasks: a.tasks}
+	if a.openingTab || len(a.tabs) == 0 {
+		a.tabs = append(a.tabs, tab)
+		a.tab = len(a.tabs) - 1
+		a.openingTab = false
+		return
+	}
+	a.tabs[a.tab] = tab
+}
+
+// showTab switches to a tab's task list, as it was left
+func (a *App) showTab(i int) tea.Cmd {
+	if !a.openingTab {
+		a.saveTab()
+	}
+	a.rememberTaskList()
+	a.loadTab(i)
+	return a.startCollabPolling()
+}
+
+// loadTab makes a tab's project the open one, without saving the shown tab
+func (a *App) loadTab(i int) {
+	tab := a.tabs[i]
+	a.tab = i
+	a.openingTab = false
+	a.projectName = tab.projectName
+	a.store = tab.store
+	a.tasks = tab.tasks
+	a.tasks.width = a.taskListWidth()
+	a.tasks.height = a.contentHeight()
+	a.tasks.following = a.follow
+	a.milestoneStore = nil
+	a.followedTaskID = ""
+	a.screen = ScreenTasks
+	a.autoReload("tab") // pick up what changed while the tab was hidden
+}
+
+// cycleTab shows the tab delta places from the shown one, wrapping around;
+// while a new tab is being opened it returns to the tab it was opened from
+func (a *App) cycleTab(delta int) tea.Cmd {
+	n := len(a.tabs)
+	if n == 0 {
+		return nil
+	}
+	if a.openingTab {
+		return a.showTab(a.tab)
+	}
+	if n == 1 {
+		return nil
+	}
+	return a.showTab(((a.tab+delta)%n + n) % n)
+}
+
+// closeTab closes the shown tab and shows the one after it (the last tab
+// cannot be closed)
+func (a *App) closeTab() tea.Cmd {
+	if len(a.tabs) < 2 || a.openingTab {
+		return nil
+	}
+	a.rememberTaskList()
+	a.tabs = append(a.tabs[:a.tab], a.tabs[a.tab+1:]...)
+	a.loadTab(min(a.tab, len(a.tabs)-1))
+	return nil
+}
+
+// renderTabs renders the tab bar shown before the status bar, or "" with a
+// single tab
+func (a App) renderTabs() string {
+	if len(a.tabs) < 2 {
+		return ""
+	}
+	var b strings.Builder
+	for i, tab := range a.tabs {
+		label := fmt.Sprintf(" %d %s ", i+1, ui.Truncate(tab.projectName, tabNameWidth))
+		if i == a.tab {
+			b.WriteString(ui.SelectedStyle.Render(label))
+		} else {
+			b.WriteString(ui.MutedStyle.Render(label))
+		}
+	}
+	b.WriteString(ui.MutedStyle.Render(" " + ui.Glyphs.VLine))
+	return b.String()
+}
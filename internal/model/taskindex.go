@@ -0,0 +1,82 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// taskIndex caches per-task values that rebuildItems needs on every search
+// keystroke, so typing in a large project does not lower-case and flatten
+// every task's fields again. The entries are derived on first use and
+// dropped by reset whenever the list is rebuilt for anything but a change
+// of the search query, so loads, reloads and edits are picked up.
+type taskIndex struct {
+	search  map[string]string // lower-cased data.SearchText by task ID
+	flagged bool              // blocked and stale are computed
+	blocked map[string]bool   // blocked open tasks
+	stale   map[string]bool   // in_progress tasks untouched for taskList.staleAfter
+}
+
+func newTaskIndex() *taskIndex {
+	return &taskIndex{search: make(map[string]string)}
+}
+
+// reset drops the cached values, for tasks that may have changed
+func (x *taskIndex) reset() {
+	x.search = make(map[string]string)
+	x.flagged, x.blocked, x.stale = false, nil, nil
+}
+
+// flags returns the blocked and stale tasks of the store, computed once
+// between resets
+func (x *taskIndex) flags(store *data.TaskStore) (blocked, stale map[string]bool) {
+	if !x.flagged {
+		x.blocked = data.BlockedTasks(store.Tasks)
+		x.stale = store.StaleTasks(config.Current().TaskList.StaleDuration(), time.Now())
+		x.flagged = true
+	}
+	return x.blocked, x.stale
+}
+
+// matches reports whether the task contains query, which must already be
+// lower-case, in any searchable field
+func (x *taskIndex) matches(task data.Task, query string) bool {
+	text, ok := x.search[task.ID]
+	if !ok {
+		text = data.SearchText(task)
+		x.search[task.ID] = text
+	}
+	return strings.Contains(text, query)
+}
+
+// groupBuckets splits tasks by group in display order: groups in the group
+// store's order first, then the remaining groups (including Uncategorized) in
+// the order their first task appears
+func groupBuckets(tasks []data.Task, groupOrder []string) (names []string, buckets [][]data.Task) {
+	slot := make(map[string]int, len(groupOrder))
+	for _, name := range groupOrder {
+		if _, ok := slot[name]; !ok {
+			slot[name] = len(names)
+			names = append(names, name)
+		}
+	}
+	buckets = make([][]data.Task, len(names))
+	for _, task := range tasks {
+		group := data.GetTaskGroup(task)
+		if group == "" {
+			group = "Uncategorized"
+		}
+		i, ok := slot[group]
+		if !ok {
+			i = len(names)
+			slot[group] = i
+			names = append(names, group)
+			buckets = append(buckets, nil)
+		}
+		buckets[i] = append(buckets[i], task)
+	}
+	return names, buckets
+}
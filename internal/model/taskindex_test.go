@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+func TestTaskIndex_Matches(t *testing.T) {
+	x := newTaskIndex()
+	task := data.Task{ID: "1", Subject: "Fix Parser", Description: "Handle 日本語 input"}
+
+	if !x.matches(task, "parser") || !x.matches(task, "日本語") {
+		t.Error("expected case-insensitive matches in subject and description")
+	}
+	if x.matches(task, "parser handle") || x.matches(task, "lexer") {
+		t.Error("unexpected match")
+	}
+
+	// The other fields are cached too
+	task = data.Task{ID: "2", Subject: "Review", Owner: "Alice", Metadata: map[string]interface{}{"note": "Ask Bob"}}
+	if !x.matches(task, "alice") || !x.matches(task, "ask bob") {
+		t.Error("expected matches in the owner and metadata")
+	}
+
+	// Edits are picked up after a reset
+	task.Owner = "Carol"
+	if !x.matches(task, "alice") {
+		t.Error("expected the cached text until the index is reset")
+	}
+	x.reset()
+	if !x.matches(task, "carol") || x.matches(task, "alice") {
+		t.Error("cached text not refreshed after reset")
+	}
+}
+
+func TestGroupBuckets(t *testing.T) {
+	tasks := []data.Task{
+		{ID: "1", Metadata: map[string]interface{}{"group": "Zeta"}},
+		{ID: "2"},
+		{ID: "3", Metadata: map[string]interface{}{"group": "Backend"}},
+		{ID: "4", Metadata: map[string]interface{}{"group": "Alpha"}},
+		{ID: "5", Metadata: map[string]interface{}{"group": "Zeta"}},
+	}
+	names, buckets := groupBuckets(tasks, []string{"Frontend", "Backend"})
+
+	want := []string{"Frontend", "Backend", "Zeta", "Uncategorized", "Alpha"}
+	if len(names) != len(want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("names = %v, want %v", names, want)
+		}
+	}
+	if len(buckets[0]) != 0 || len(buckets[2]) != 2 || buckets[2][1].ID != "5" {
+		t.Errorf("unexpected buckets %v", buckets)
+	}
+}
+
+func TestTaskIndex_Flags(t *testing.T) {
+	store := &data.TaskStore{Tasks: []data.Task{
+		{ID: "1", Status: "pending"},
+		{ID: "2", Status: "pending", BlockedBy: []string{"1"}},
+	}}
+	x := newTaskIndex()
+	if blocked, _ := x.flags(store); !blocked["2"] || blocked["1"] {
+		t.Fatalf("blocked = %v, want task 2", blocked)
+	}
+
+	// Kept until the index is reset
+	store.Tasks[0].Status = "completed"
+	if blocked, _ := x.flags(store); !blocked["2"] {
+		t.Error("expected the cached flags until the index is reset")
+	}
+	x.reset()
+	if blocked, _ := x.flags(store); blocked["2"] {
+		t.Errorf("blocked = %v after reset, want none", blocked)
+	}
+}
@@ -10,15 +10,16 @@ import (
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/jss826/cctasks/internal/config"
 	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
 	"github.com/jss826/cctasks/internal/ui"
 )
 
 // TasksModel handles the task list screen
 type TasksModel struct {
 	projectName string
-	taskStore   *data.TaskStore
-	groupStore  *data.GroupStore
+	store       *projectStore
 	width       int
 	height      int
 
@@ -27,11 +28,18 @@ type TasksModel struct {
 	items  []taskListItem // Flattened list of groups and tasks
 
 	// Filtering
-	statusFilter  string // "", "pending", "in_progress", "completed"
-	groupFilter   string // "", or group name
-	hideCompleted bool   // hide completed tasks
+	statusFilter  string          // "", "pending", "in_progress", "completed", "blocked", "stale"
+	groupFilter   string          // "", or group name
+	authorFilter  string          // "", "agent" or "human"
+	bar           filterBar       // filter bar focus (Tab)
+	milestone     *data.Milestone // milestone filter (nil = all tasks)
+	hideCompleted bool            // hide completed tasks
 	searchInput   textinput.Model
 	searchActive  bool
+	index         *taskIndex      // shared by copies of the model
+	blocked       map[string]bool // blocked open tasks, as of the last rebuild
+	stale         map[string]bool // in_progress tasks untouched for taskList.staleAfter, as of the last rebuild
+	listed        int             // tasks in the list (matching the filters or starred), collapsed or not
 
 	// Sorting: "id" (default), "status"
 	sortMode string
@@ -39,14 +47,80 @@ type TasksModel struct {
 	// Group collapsed state
 	collapsedGroups map[string]bool
 
+	// List density (v): densityNormal, densityCompact or densityComfortable
+	density string
+
+	// Description previews under task rows (P); always on when comfortable
+	showPreview bool
+
 	// Quick status change mode
 	statusChangeMode bool
 
+	// Reason asked for a status change (config requireReason)
+	reason reasonPrompt
+
+	// Tasks unblocked by the last completion, shown until the next key
+	unblocked unblockedPrompt
+
+	// Completing a task with its dependency chain (c)
+	chain chainPrompt
+
+	// Assigning a task to another owner (A)
+	assign assignPrompt
+
+	// Merge mode: source task picked with 'm', target awaiting confirmation
+	mergeSourceID string
+	mergeTargetID string
+
+	// "What's next?" suggestion (w)
+	nextActive bool
+	nextTask   *data.Task
+
+	// Follow mode (F) is on; shown in the header
+	following bool
+
+	// Rendered once to stdout (cctasks render): no cursor, no key hints
+	headless bool
+
 	// Double-click detection
 	lastClickTime time.Time
 	lastClickIdx  int
 }
 
+// Task list densities: compact fits one task per line without blocked-by
+// sublines or filter bar spacing, comfortable adds a blank line after each
+// task and a preview of its description
+const (
+	densityNormal      = ""
+	densityCompact     = "compact"
+	densityComfortable = "comfortable"
+)
+
+// taskColumns are the columns a task row can show
+var taskColumns = []string{"id", "subject", "group", "owner", "due", "status"}
+
+// defaultTaskColumns are shown when the config does not list any; the group
+// is left out as tasks are listed under their group header
+var defaultTaskColumns = []string{"id", "subject", "owner", "due", "status"}
+
+// visibleTaskColumns returns the configured task row columns, ignoring
+// unknown names
+func visibleTaskColumns() []string {
+	var columns []string
+	for _, name := range config.Current().TaskList.Columns {
+		for _, known := range taskColumns {
+			if name == known {
+				columns = append(columns, name)
+				break
+			}
+		}
+	}
+	if len(columns) == 0 {
+		return defaultTaskColumns
+	}
+	return columns
+}
+
 // taskListItem represents an item in the flattened task list
 type taskListItem struct {
 	isGroup   bool
@@ -55,29 +129,31 @@ type taskListItem struct {
 }
 
 // NewTasksModel creates a new TasksModel
-func NewTasksModel(projectName string, taskStore *data.TaskStore, groupStore *data.GroupStore) TasksModel {
+func NewTasksModel(projectName string, store *projectStore) TasksModel {
 	ti := textinput.New()
-	ti.Placeholder = "Search..."
+	ti.Placeholder = i18n.T("Search...")
 	ti.CharLimit = 50
 	ti.Width = 30
 
 	m := TasksModel{
 		projectName:     projectName,
-		taskStore:       taskStore,
-		groupStore:      groupStore,
+		store:           store,
 		searchInput:     ti,
+		index:           newTaskIndex(),
 		collapsedGroups: make(map[string]bool),
 		hideCompleted:   true, // Hide completed tasks by default
 	}
 	m.rebuildItems()
 
-	// Collapse all groups by default
-	for _, item := range m.items {
-		if item.isGroup {
-			m.collapsedGroups[item.groupName] = true
+	// Collapse all groups unless configured otherwise (starred tasks stay visible)
+	if config.Current().TaskList.GroupsCollapsed(projectName) {
+		for _, item := range m.items {
+			if item.isGroup && item.groupName != starredGroup {
+				m.collapsedGroups[item.groupName] = true
+			}
 		}
+		m.rebuildItems()
 	}
-	m.rebuildItems()
 
 	return m
 }
@@ -87,11 +163,9 @@ func (m TasksModel) Init() tea.Cmd {
 	return nil
 }
 
-// ReloadData reloads task/group data while preserving UI state (cursor, filters, collapsed groups)
-func (m *TasksModel) ReloadData(taskStore *data.TaskStore, groupStore *data.GroupStore) {
-	m.taskStore = taskStore
-	m.groupStore = groupStore
-
+// ReloadData rebuilds the list from the shared store after its data changed,
+// preserving UI state (cursor, filters, collapsed groups)
+func (m *TasksModel) ReloadData() {
 	// Remember current task ID if on a task
 	var currentTaskID string
 	if m.cursor < len(m.items) && !m.items[m.cursor].isGroup && m.items[m.cursor].task != nil {
@@ -120,15 +194,98 @@ func (m *TasksModel) ReloadData(taskStore *data.TaskStore, groupStore *data.Grou
 	}
 }
 
-// rebuildItems rebuilds the flattened list based on current filters
-func (m *TasksModel) rebuildItems() {
-	m.items = nil
+// SetMilestoneFilter filters the list to a milestone's tasks (nil shows all tasks)
+func (m *TasksModel) SetMilestoneFilter(milestone *data.Milestone) {
+	if milestone == nil {
+		m.milestone = nil
+		return
+	}
+	ms := *milestone
+	m.milestone = &ms
+}
+
+// MilestoneFilter returns the name of the milestone filtering the list, or ""
+func (m *TasksModel) MilestoneFilter() string {
+	if m.milestone == nil {
+		return ""
+	}
+	return m.milestone.Name
+}
+
+// SessionState returns the list's cursor, filters and layout for persisting between runs
+func (m *TasksModel) SessionState() config.TaskListState {
+	st := config.TaskListState{
+		StatusFilter:    m.statusFilter,
+		GroupFilter:     m.groupFilter,
+		AuthorFilter:    m.authorFilter,
+		Milestone:       m.MilestoneFilter(),
+		Search:          m.searchInput.Value(),
+		ShowCompleted:   !m.hideCompleted,
+		SortMode:        m.sortMode,
+		Density:         m.density,
+		ShowPreview:     m.showPreview,
+		CollapsedGroups: make(map[string]bool, len(m.collapsedGroups)),
+	}
+	for name, collapsed := range m.collapsedGroups {
+		st.CollapsedGroups[name] = collapsed
+	}
+	if m.cursor < len(m.items) {
+		if item := m.items[m.cursor]; item.task != nil {
+			st.CursorTaskID = item.task.ID
+		} else {
+			st.CursorGroup = item.groupName
+		}
+	}
+	return st
+}
+
+// RestoreSession applies a persisted list state; milestone is the filter's
+// milestone (nil if unset or no longer defined)
+func (m *TasksModel) RestoreSession(st config.TaskListState, milestone *data.Milestone) {
+	m.statusFilter = st.StatusFilter
+	m.groupFilter = st.GroupFilter
+	m.authorFilter = st.AuthorFilter
+	m.SetMilestoneFilter(milestone)
+	m.searchInput.SetValue(st.Search)
+	m.hideCompleted = !st.ShowCompleted
+	m.sortMode = st.SortMode
+	m.density = st.Density
+	m.showPreview = st.ShowPreview
+	for name, collapsed := range st.CollapsedGroups {
+		m.collapsedGroups[name] = collapsed
+	}
+	m.rebuildItems()
+
+	for i, item := range m.items {
+		if (st.CursorTaskID != "" && item.task != nil && item.task.ID == st.CursorTaskID) ||
+			(st.CursorGroup != "" && item.isGroup && item.groupName == st.CursorGroup) {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// filteredTasks returns the tasks matching the current filters, in ID order
+func (m *TasksModel) filteredTasks() []data.Task {
+	if m.index == nil {
+		m.index = newTaskIndex()
+	}
+	query := strings.ToLower(m.searchInput.Value())
+	m.blocked, m.stale = m.index.flags(m.store.tasks)
 
-	// Get filtered tasks
 	var tasks []data.Task
-	for _, task := range m.taskStore.Tasks {
-		// Status filter
-		if m.statusFilter != "" && task.Status != m.statusFilter {
+	for _, task := range m.store.tasks.Tasks {
+		// Status filter ("blocked" matches blocked open tasks of any status,
+		// "stale" untouched in_progress tasks)
+		if m.statusFilter == "blocked" {
+			if !m.blocked[task.ID] {
+				continue
+			}
+		} else if m.statusFilter == "stale" {
+			if !m.stale[task.ID] {
+				continue
+			}
+		} else if m.statusFilter != "" && task.Status != m.statusFilter {
 			continue
 		}
 
@@ -143,54 +300,108 @@ func (m *TasksModel) rebuildItems() {
 			continue
 		}
 
+		// Author filter
+		if m.authorFilter != "" && data.IsAgentTask(task) != (m.authorFilter == "agent") {
+			continue
+		}
+
+		// Milestone filter
+		if m.milestone != nil && data.GetTaskMilestone(task) != m.milestone.Name {
+			continue
+		}
+
 		// Search filter
-		if m.searchInput.Value() != "" {
-			query := strings.ToLower(m.searchInput.Value())
-			if !strings.Contains(strings.ToLower(task.Subject), query) &&
-				!strings.Contains(strings.ToLower(task.Description), query) {
-				continue
-			}
+		if query != "" && !m.index.matches(task, query) {
+			continue
 		}
 
 		tasks = append(tasks, task)
 	}
+	return tasks
+}
+
+// FilteredTaskIDs returns the IDs of all tasks matching the current filters,
+// including tasks in collapsed groups
+func (m *TasksModel) FilteredTaskIDs() []string {
+	var ids []string
+	for _, task := range m.filteredTasks() {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+// starredGroup is the pseudo-group of starred tasks shown at the top of the list
+const starredGroup = "★ Starred"
+
+// displayGroupName returns the name shown for a group, translating the
+// built-in Uncategorized and Starred groups
+func displayGroupName(name string) string {
+	switch name {
+	case "Uncategorized":
+		return i18n.T(name)
+	case starredGroup:
+		return strings.Replace(i18n.T(name), "★", ui.Glyphs.Star, 1)
+	}
+	return name
+}
 
-	// Sort tasks
+// sortTasks orders tasks by the current sort mode
+func (m *TasksModel) sortTasks(tasks []data.Task) {
 	if m.sortMode == "status" {
-		statusOrder := map[string]int{"pending": 0, "in_progress": 1, "completed": 2}
+		statusOrder := map[string]int{"pending": 0, "in_progress": 1, data.StatusNeedsReview: 2, "completed": 3}
 		sort.SliceStable(tasks, func(i, j int) bool {
 			return statusOrder[tasks[i].Status] < statusOrder[tasks[j].Status]
 		})
 	}
+	if m.sortMode == "manual" {
+		data.SortManual(tasks)
+	}
 	// Default: sorted by ID (already in file order, which is ID order)
+}
 
-	// Group tasks by group name
-	groupedTasks := make(map[string][]data.Task)
-	for _, task := range tasks {
-		group := data.GetTaskGroup(task)
-		if group == "" {
-			group = "Uncategorized"
-		}
-		groupedTasks[group] = append(groupedTasks[group], task)
+// rebuildItems rebuilds the flattened list based on current filters, for
+// tasks that may have changed since the last rebuild
+func (m *TasksModel) rebuildItems() {
+	if m.index != nil {
+		m.index.reset()
 	}
+	m.relist()
+}
+
+// relist rebuilds the flattened list from the cached task index; search
+// keystrokes call it directly, since typing changes no task
+func (m *TasksModel) relist() {
+	m.items = nil
 
-	// Get group order from groupStore
-	groupOrder := m.groupStore.GetGroupNames()
+	// Starred tasks are pinned to the top regardless of filters
+	var starred []data.Task
+	for _, task := range m.store.tasks.Tasks {
+		if data.IsTaskStarred(task) {
+			starred = append(starred, task)
+		}
+	}
+	if len(starred) > 0 {
+		m.sortTasks(starred)
+		m.addGroupToItems(starredGroup, starred)
+	}
 
-	// Add groups in order
-	processedGroups := make(map[string]bool)
+	tasks := m.filteredTasks()
+	m.sortTasks(tasks)
 
-	for _, groupName := range groupOrder {
-		if tasks, ok := groupedTasks[groupName]; ok {
-			m.addGroupToItems(groupName, tasks)
-			processedGroups[groupName] = true
+	// Starred tasks are already listed under Starred
+	unstarred := make([]data.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !data.IsTaskStarred(task) {
+			unstarred = append(unstarred, task)
 		}
 	}
 
-	// Add remaining groups (including Uncategorized)
-	for groupName, tasks := range groupedTasks {
-		if !processedGroups[groupName] {
-			m.addGroupToItems(groupName, tasks)
+	m.listed = len(starred) + len(unstarred)
+
+	names, buckets := groupBuckets(unstarred, m.store.groups.GetGroupNames())
+	for i, groupName := range names {
+		if len(buckets[i]) > 0 {
+			m.addGroupToItems(groupName, buckets[i])
 		}
 	}
 
@@ -262,12 +473,42 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 			case "enter":
 				m.searchActive = false
 				m.searchInput.Blur()
-				m.rebuildItems()
+				m.relist()
 				return m, nil
 			}
 		}
+		query := m.searchInput.Value()
 		m.searchInput, cmd = m.searchInput.Update(msg)
-		m.rebuildItems()
+		// Cursor blinks and moves leave the query unchanged
+		if m.searchInput.Value() != query {
+			m.relist()
+		}
+		return m, cmd
+	}
+
+	// Offer to open the tasks the last completion unblocked
+	if key, ok := msg.(tea.KeyMsg); ok && m.unblocked.active() {
+		var id string
+		var handled bool
+		m.unblocked, id, handled = m.unblocked.update(key)
+		if task := m.store.tasks.GetTask(id); task != nil {
+			return m, func() tea.Msg {
+				return ViewTaskMsg{Task: task}
+			}
+		}
+		if handled {
+			return m, nil
+		}
+	}
+
+	// Handle the status change reason prompt
+	if m.reason.active {
+		var reason string
+		m.reason, reason, cmd = m.reason.update(msg)
+		if reason != "" {
+			m.store.err = m.reason.apply(m.store.tasks, reason)
+			m.rebuildItems()
+		}
 		return m, cmd
 	}
 
@@ -277,38 +518,144 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 		case tea.KeyMsg:
 			switch msg.String() {
 			case "1", "p":
-				m.setCurrentTaskStatus("pending")
+				cmd = m.setCurrentTaskStatus("pending")
 				m.statusChangeMode = false
 			case "2", "i":
-				m.setCurrentTaskStatus("in_progress")
+				cmd = m.setCurrentTaskStatus("in_progress")
 				m.statusChangeMode = false
 			case "3", "c":
-				m.setCurrentTaskStatus("completed")
+				cmd = m.setCurrentTaskStatus("completed")
 				m.statusChangeMode = false
+			case "4", "r":
+				if data.ReviewEnabled() {
+					cmd = m.setCurrentTaskStatus(data.StatusNeedsReview)
+					m.statusChangeMode = false
+				}
 			case "esc":
 				m.statusChangeMode = false
 			}
 		}
+		return m, cmd
+	}
+
+	// Handle "What's next?" prompt
+	if m.nextActive {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.String() {
+			case "s", "y":
+				if m.nextTask != nil && !m.store.readOnly() {
+					if task := m.store.tasks.GetTask(m.nextTask.ID); task != nil {
+						task.Status = "in_progress"
+						m.store.save()
+						m.rebuildItems()
+					}
+				}
+				m.nextActive = false
+			case "enter":
+				m.nextActive = false
+				if m.nextTask != nil {
+					if task := m.store.tasks.GetTask(m.nextTask.ID); task != nil {
+						return m, func() tea.Msg {
+							return ViewTaskMsg{Task: task}
+						}
+					}
+				}
+			case "esc", "n", "w":
+				m.nextActive = false
+			}
+		}
+		return m, nil
+	}
+
+	// Handle assigning an owner
+	if m.assign.active() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			m.assign = m.assign.update(msg, m.store)
+			m.rebuildItems()
+		}
+		return m, nil
+	}
+
+	// Handle completing a dependency chain
+	if m.chain.active() {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			blocked := data.BlockedTasks(m.store.tasks.Tasks)
+			var done bool
+			m.chain, done = m.chain.update(msg, m.store)
+			if done {
+				m.rebuildItems()
+				m.unblocked.open(newlyUnblocked(m.store.tasks.Tasks, blocked, m.blocked))
+			}
+		}
+		return m, nil
+	}
+
+	// Handle merge confirmation
+	if m.mergeTargetID != "" {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.String() {
+			case "y", "Y":
+				if _, err := m.store.tasks.MergeTasks(m.mergeSourceID, m.mergeTargetID); err != nil {
+					m.store.err = err
+				}
+				m.mergeSourceID = ""
+				m.mergeTargetID = ""
+				m.rebuildItems()
+			case "n", "N", "esc":
+				m.mergeTargetID = ""
+			}
+		}
 		return m, nil
 	}
 
+	// Handle merge mode: navigate to the second task and press m/Enter
+	if m.mergeSourceID != "" {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch msg.String() {
+			case "esc":
+				m.mergeSourceID = ""
+				return m, nil
+			case "m", "enter":
+				if task := m.currentTask(); task != nil && task.ID != m.mergeSourceID {
+					m.mergeTargetID = task.ID
+				}
+				return m, nil
+			}
+		}
+	}
+
+	// Handle the focused filter bar
+	if m.bar.focused {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			return m.updateFilterBar(msg)
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.MouseMsg:
 		if msg.Action == tea.MouseActionRelease && msg.Button == tea.MouseButtonLeft {
 			// Calculate header lines (empirically determined)
 			headerLines := 9
-			if m.statusChangeMode {
+			if m.density == densityCompact {
+				headerLines -= 3 // no blank line after each filter bar line
+			}
+			if m.statusChangeMode || m.reason.active || m.unblocked.active() || m.mergeSourceID != "" || m.nextActive || m.assign.active() {
 				headerLines += 2
 			}
+			if m.chain.active() {
+				headerLines += m.chain.lines() + 1
+			}
 			if m.searchActive {
 				headerLines += 2
 			}
+			if m.milestone != nil {
+				headerLines++ // milestone progress line
+			}
 
 			// Calculate scroll offset (same logic as View)
-			maxLines := m.height - 15
-			if maxLines < 5 {
-				maxLines = 10
-			}
+			maxLines := m.listHeight()
 			startIdx := 0
 			{
 				lines := 0
@@ -355,6 +702,7 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 							// Toggle collapse
 							m.collapsedGroups[item.groupName] = !m.collapsedGroups[item.groupName]
 							m.rebuildItems()
+							return m, groupsCollapsed
 						} else if item.task != nil {
 							return m, func() tea.Msg {
 								return ViewTaskMsg{Task: item.task}
@@ -372,6 +720,9 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if readOnlyTaskKeys[msg.String()] && m.store.readOnly() {
+			return m, nil
+		}
 		switch msg.String() {
 		case "up", "k":
 			if m.cursor > 0 {
@@ -394,6 +745,7 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 					// Toggle collapse
 					m.collapsedGroups[item.groupName] = !m.collapsedGroups[item.groupName]
 					m.rebuildItems()
+					return m, groupsCollapsed
 				} else if item.task != nil {
 					return m, func() tea.Msg {
 						return ViewTaskMsg{Task: item.task}
@@ -427,15 +779,74 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 			if len(m.items) > 0 && m.items[m.cursor].task != nil {
 				m.statusChangeMode = true
 			}
+		case "A":
+			if task := m.currentTask(); task != nil {
+				m.assign = newAssignPrompt(m.store.tasks, task.ID)
+			}
+		case "*":
+			m.toggleCurrentTaskStar()
+		case "F":
+			return m, func() tea.Msg {
+				return ToggleFollowMsg{}
+			}
+		case "c":
+			if task := m.currentTask(); task != nil {
+				m.chain = chainPrompt{taskID: task.ID}
+			}
+		case "m":
+			if task := m.currentTask(); task != nil {
+				m.mergeSourceID = task.ID
+			}
+		case "w":
+			m.nextActive = true
+			m.nextTask = data.NextTask(m.store.tasks.Tasks)
+		case "v":
+			m.cycleDensity()
+		case "P":
+			m.showPreview = !m.showPreview
+		case "B":
+			if ids := m.FilteredTaskIDs(); len(ids) > 0 {
+				return m, func() tea.Msg {
+					return BatchEditMsg{TaskIDs: ids}
+				}
+			}
+		case "X":
+			if ids := m.FilteredTaskIDs(); len(ids) > 0 {
+				return m, func() tea.Msg {
+					return ExportTasksMsg{TaskIDs: ids}
+				}
+			}
+		case "tab":
+			m.bar.focused = true
+		case "K", "shift+up":
+			m.moveCurrentTask(-1)
+		case "J", "shift+down":
+			m.moveCurrentTask(1)
+		case "z":
+			m.toggleCurrentGroup()
+			return m, groupsCollapsed
+		case "Z":
+			m.toggleAllGroups()
+			return m, groupsCollapsed
 		case "f":
 			m.cycleStatusFilter()
 			m.rebuildItems()
+		case "0", "1", "2", "3", "4":
+			if status, ok := quickStatusFilter(msg.String()); ok {
+				m.statusFilter = status
+				m.rebuildItems()
+			}
 		case "g":
 			m.cycleGroupFilter()
 			m.rebuildItems()
+		case "a":
+			m.cycleAuthorFilter()
+			m.rebuildItems()
 		case "h":
 			m.hideCompleted = !m.hideCompleted
 			m.rebuildItems()
+		case "C":
+			m.clearFilters()
 		case "o":
 			m.cycleSortMode()
 			m.rebuildItems()
@@ -443,6 +854,32 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 			return m, func() tea.Msg {
 				return ManageGroupsMsg{}
 			}
+		case "D":
+			return m, func() tea.Msg {
+				return ShowTrashMsg{}
+			}
+		case "M":
+			return m, func() tea.Msg {
+				return ManageMilestonesMsg{}
+			}
+		case "'":
+			return m, func() tea.Msg {
+				return ShowRecentMsg{}
+			}
+		case "T":
+			return m, func() tea.Msg {
+				return ShowTimelineMsg{}
+			}
+		case "S":
+			return m, func() tea.Msg {
+				return ShowStatsMsg{}
+			}
+		case "!":
+			if len(m.store.problems()) > 0 || len(m.store.unparsable()) > 0 {
+				return m, func() tea.Msg {
+					return ShowProblemsMsg{}
+				}
+			}
 		case "/":
 			m.searchActive = true
 			m.searchInput.Focus()
@@ -451,6 +888,14 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 			return m, func() tea.Msg {
 				return BackToProjectsMsg{}
 			}
+		case "[", "]":
+			delta := 1
+			if msg.String() == "[" {
+				delta = -1
+			}
+			return m, func() tea.Msg {
+				return CycleProjectMsg{Delta: delta}
+			}
 		case "r":
 			return m, func() tea.Msg {
 				return RefreshMsg{}
@@ -463,8 +908,15 @@ func (m TasksModel) Update(msg tea.Msg) (TasksModel, tea.Cmd) {
 	return m, nil
 }
 
+// readOnlyTaskKeys are the task list keys that change the project, refused
+// for projects that can only be browsed
+var readOnlyTaskKeys = map[string]bool{
+	"n": true, "e": true, "s": true, "A": true, "*": true, "c": true, "m": true,
+	"B": true, "K": true, "J": true, "shift+up": true, "shift+down": true,
+}
+
 func (m *TasksModel) cycleStatusFilter() {
-	statuses := []string{"", "pending", "in_progress", "completed"}
+	statuses := append(append([]string{""}, data.Statuses()...), "blocked", "stale")
 	for i, s := range statuses {
 		if s == m.statusFilter {
 			m.statusFilter = statuses[(i+1)%len(statuses)]
@@ -474,8 +926,79 @@ func (m *TasksModel) cycleStatusFilter() {
 	m.statusFilter = ""
 }
 
+// filterValue renders a filter bar value in brackets, highlighted when the
+// filter narrows the list
+func filterValue(label string, active bool) string {
+	value := "[" + label + "]"
+	if active {
+		return ui.ActiveFilterStyle.Render(value)
+	}
+	return value
+}
+
+// filtersHide reports whether the filters or the search hide any task
+func (m TasksModel) filtersHide() bool {
+	return m.listed < len(m.store.tasks.Tasks)
+}
+
+// clearFilters resets every filter and the search so all tasks are listed,
+// completed ones included
+func (m *TasksModel) clearFilters() {
+	m.statusFilter = ""
+	m.groupFilter = ""
+	m.authorFilter = ""
+	m.milestone = nil
+	m.hideCompleted = false
+	m.searchInput.SetValue("")
+	m.rebuildItems()
+}
+
+// countSummary returns how many tasks the list shows out of the project's,
+// highlighted when filters or the search hide some (completed tasks are
+// hidden by default)
+func (m TasksModel) countSummary() string {
+	total := len(m.store.tasks.Tasks)
+	if total == 0 {
+		return ""
+	}
+	hidden := total - m.listed
+	if !m.filtersHide() {
+		return ui.MutedStyle.Render(i18n.Tf("Showing all %d task(s)", total))
+	}
+	return ui.ActiveFilterStyle.Render(i18n.Tf("Showing %d of %d task(s) (%d hidden by filters)", m.listed, total, hidden))
+}
+
+// quickStatusFilter returns the status filter of a number key, numbered as
+// in the status change prompt; 0 shows all tasks
+func quickStatusFilter(key string) (string, bool) {
+	switch key {
+	case "0":
+		return "", true
+	case "1":
+		return "pending", true
+	case "2":
+		return "in_progress", true
+	case "3":
+		return "completed", true
+	case "4":
+		return data.StatusNeedsReview, data.ReviewEnabled()
+	}
+	return "", false
+}
+
+func (m *TasksModel) cycleAuthorFilter() {
+	switch m.authorFilter {
+	case "":
+		m.authorFilter = "agent"
+	case "agent":
+		m.authorFilter = "human"
+	default:
+		m.authorFilter = ""
+	}
+}
+
 func (m *TasksModel) cycleGroupFilter() {
-	groups := append([]string{""}, m.groupStore.GetGroupNames()...)
+	groups := append([]string{""}, m.store.groups.GetGroupNames()...)
 	groups = append(groups, "Uncategorized")
 
 	for i, g := range groups {
@@ -488,7 +1011,7 @@ func (m *TasksModel) cycleGroupFilter() {
 }
 
 func (m *TasksModel) cycleSortMode() {
-	modes := []string{"", "status"}
+	modes := []string{"", "status", "manual"}
 	for i, mode := range modes {
 		if mode == m.sortMode {
 			m.sortMode = modes[(i+1)%len(modes)]
@@ -498,25 +1021,204 @@ func (m *TasksModel) cycleSortMode() {
 	m.sortMode = ""
 }
 
-func (m *TasksModel) setCurrentTaskStatus(status string) {
-	if len(m.items) == 0 {
+// currentTask returns the task under the cursor, or nil if a group is selected
+func (m *TasksModel) currentTask() *data.Task {
+	if len(m.items) == 0 || m.cursor >= len(m.items) {
+		return nil
+	}
+	return m.items[m.cursor].task
+}
+
+// moveCurrentTask moves the task under the cursor up (-1) or down (+1)
+// past the next visible task of its group, saving the new manual order and
+// switching to the manual sort
+func (m *TasksModel) moveCurrentTask(delta int) {
+	task, target := m.currentTask(), m.cursor+delta
+	if task == nil || target < 0 || target >= len(m.items) || m.items[target].task == nil {
+		return // group headers bound the move
+	}
+	neighbor := m.items[target].task.ID
+	groupName := m.items[m.currentGroup()].groupName
+
+	// Renumber the whole group, hidden tasks included, in the order shown
+	var group []data.Task
+	for _, t := range m.store.tasks.Tasks {
+		if listGroup(t) == groupName || (groupName == starredGroup && data.IsTaskStarred(t)) {
+			group = append(group, t)
+		}
+	}
+	m.sortTasks(group)
+	ids := make([]string, len(group))
+	for i, t := range group {
+		ids[i] = t.ID
+	}
+	i, j := indexOf(ids, task.ID), indexOf(ids, neighbor)
+	if i < 0 || j < 0 {
+		return
+	}
+	ids[i], ids[j] = ids[j], ids[i]
+	m.store.tasks.SetManualOrder(ids)
+	m.store.save()
+
+	id := task.ID
+	m.sortMode = "manual"
+	m.rebuildItems()
+	for k, item := range m.items {
+		if item.task != nil && item.task.ID == id {
+			m.cursor = k
+			return
+		}
+	}
+}
+
+// listGroup returns the group a task is listed under (starred tasks are
+// listed under Starred only)
+func listGroup(task data.Task) string {
+	if data.IsTaskStarred(task) {
+		return starredGroup
+	}
+	if group := data.GetTaskGroup(task); group != "" {
+		return group
+	}
+	return "Uncategorized"
+}
+
+// indexOf returns the index of s in list, or -1
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// groupsCollapsed tells the app that groups were collapsed or expanded
+func groupsCollapsed() tea.Msg {
+	return GroupsCollapsedMsg{}
+}
+
+// currentGroup returns the index of the group header the cursor is on or
+// under, or -1
+func (m *TasksModel) currentGroup() int {
+	for i := min(m.cursor, len(m.items)-1); i >= 0; i-- {
+		if m.items[i].isGroup {
+			return i
+		}
+	}
+	return -1
+}
+
+// toggleCurrentGroup collapses or expands the group under the cursor,
+// moving the cursor to its header
+func (m *TasksModel) toggleCurrentGroup() {
+	i := m.currentGroup()
+	if i < 0 {
+		return
+	}
+	name := m.items[i].groupName
+	m.collapsedGroups[name] = !m.collapsedGroups[name]
+	m.rebuildItems()
+	m.cursor = i
+}
+
+// toggleAllGroups collapses every group, or expands them all when they are
+// already collapsed; the cursor stays on the current group
+func (m *TasksModel) toggleAllGroups() {
+	var current string
+	if i := m.currentGroup(); i >= 0 {
+		current = m.items[i].groupName
+	}
+	collapse := false
+	for _, item := range m.items {
+		if item.isGroup && !m.collapsedGroups[item.groupName] {
+			collapse = true
+			break
+		}
+	}
+	for _, item := range m.items {
+		if item.isGroup {
+			m.collapsedGroups[item.groupName] = collapse
+		}
+	}
+	m.rebuildItems()
+	for i, item := range m.items {
+		if item.isGroup && item.groupName == current {
+			m.cursor = i
+			return
+		}
+	}
+	m.cursor = 0
+}
+
+// toggleCurrentTaskStar stars or unstars the current task, keeping the cursor on it
+func (m *TasksModel) toggleCurrentTaskStar() {
+	task := m.currentTask()
+	if task == nil {
 		return
 	}
+
+	data.SetTaskStarred(task, !data.IsTaskStarred(*task))
+	m.store.tasks.UpdateTask(*task)
+	m.store.save()
+
+	id := task.ID
+	m.rebuildItems()
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == id {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// setCurrentTaskStatus changes the status of the task under the cursor, or
+// asks for a reason first when the config requires one
+func (m *TasksModel) setCurrentTaskStatus(status string) tea.Cmd {
+	if len(m.items) == 0 {
+		return nil
+	}
 	item := m.items[m.cursor]
 	if item.task == nil {
-		return
+		return nil
+	}
+	if ids := reasonsNeeded(m.store.tasks, []string{item.task.ID}, status); len(ids) > 0 {
+		return m.reason.open(reopenTitle(m.store.tasks, ids), ids, status)
 	}
 
+	_, freed := data.WaitingTasks(m.store.tasks.Tasks, item.task.ID)
 	item.task.Status = status
-	m.taskStore.UpdateTask(*item.task)
-	m.taskStore.Save()
+	m.store.tasks.UpdateTask(*item.task)
+	m.store.save()
+	cursor := m.cursor
 	m.rebuildItems()
+	if status == "completed" {
+		m.unblocked.open(freed)
+		if config.Current().TaskList.AutoAdvance {
+			m.advanceToActionable(cursor)
+		}
+	}
+	return nil
+}
+
+// advanceToActionable moves the cursor to the first unblocked open task
+// listed from row from on, wrapping around to the top; the cursor stays put
+// when there is none
+func (m *TasksModel) advanceToActionable(from int) {
+	for n := 0; n < len(m.items); n++ {
+		i := (from + n) % len(m.items)
+		if task := m.items[i].task; task != nil && task.Status != "completed" && !m.blocked[task.ID] {
+			m.cursor = i
+			return
+		}
+	}
 }
 
 // View renders the task list screen
 func (m TasksModel) View() string {
 	// Update search input width based on terminal width
-	searchWidth := m.width - 20 // margin for "Search (/): " prefix
+	summary := m.countSummary()
+	searchWidth := m.width - 20 - lipgloss.Width(summary) // margin for "Search (/): " prefix
 	if searchWidth < 20 {
 		searchWidth = 20
 	}
@@ -526,80 +1228,183 @@ func (m TasksModel) View() string {
 
 	// Header
 	title := fmt.Sprintf("cctasks: %s", m.projectName)
+	if remaining := data.RemainingEstimate(m.store.tasks.Tasks); remaining > 0 {
+		title += "  (" + i18n.Tf("Σ %s left", data.FormatEstimate(remaining)) + ")"
+	}
+	if m.following {
+		title += "  [" + i18n.T("following") + "]"
+	}
+	if data.IsReadOnly(m.projectName) {
+		title += "  [" + i18n.T("read-only") + "]"
+	}
+	if m.density != densityNormal {
+		title += "  [" + i18n.T(m.density) + "]"
+	}
 	b.WriteString(ui.Header(title, m.width))
 	b.WriteString("\n")
 
+	filterBarStyle := ui.FilterBarStyle
+	if m.density == densityCompact {
+		filterBarStyle = filterBarStyle.PaddingBottom(0)
+	}
+
 	// Filter bar - line 1: Status and Group filters
-	statusLabel := "All"
+	statusLabel := i18n.T("All")
 	if m.statusFilter != "" {
-		statusLabel = m.statusFilter
+		statusLabel = i18n.T(m.statusFilter)
 	}
-	groupLabel := "All Groups"
+	groupLabel := i18n.T("All Groups")
 	if m.groupFilter != "" {
-		groupLabel = m.groupFilter
+		groupLabel = displayGroupName(m.groupFilter)
+	}
+
+	authorLabel := i18n.T("All")
+	if m.authorFilter != "" {
+		authorLabel = i18n.T(m.authorFilter)
 	}
 
 	// Pad status to fixed width (max: "in_progress" = 11 chars), centered
-	filterLine := fmt.Sprintf("Status %s: [%s]    Group %s: [%s]",
-		ui.KeyStyle.Render("(f)"), ui.CenterPad(statusLabel, 11),
-		ui.KeyStyle.Render("(g)"), groupLabel)
-	b.WriteString(ui.FilterBarStyle.Render(filterLine))
+	filterLine := fmt.Sprintf("%s: %s    %s: %s    %s: %s",
+		m.barLabel(segmentStatus, "f"), m.barValue(segmentStatus, ui.CenterPad(statusLabel, 11), m.statusFilter != ""),
+		m.barLabel(segmentAuthor, "a"), m.barValue(segmentAuthor, ui.CenterPad(authorLabel, 5), m.authorFilter != ""),
+		m.barLabel(segmentGroup, "g"), m.barValue(segmentGroup, groupLabel, m.groupFilter != ""))
+	b.WriteString(filterBarStyle.Render(filterLine))
 	b.WriteString("\n")
 
 	// Filter bar - line 2: Search
-	searchLine := fmt.Sprintf("Search %s: %s", ui.KeyStyle.Render("(/)"), m.searchInput.View())
-	b.WriteString(ui.FilterBarStyle.Render(searchLine))
+	searchLine := fmt.Sprintf("%s: %s", m.barLabel(segmentSearch, "/"), m.searchInput.View())
+	if summary != "" {
+		searchLine += "  " + summary
+	}
+	b.WriteString(filterBarStyle.Render(searchLine))
 	b.WriteString("\n")
 
 	// Filter bar - line 3: Completed and Sort
-	hideLabel := "Show"
+	hideLabel := i18n.T("Show")
 	if m.hideCompleted {
-		hideLabel = "Hide"
+		hideLabel = i18n.T("Hide")
 	}
 	sortLabel := "ID"
-	if m.sortMode == "status" {
-		sortLabel = "Status"
+	switch m.sortMode {
+	case "status":
+		sortLabel = i18n.T("Status")
+	case "manual":
+		sortLabel = i18n.T("Manual")
+	}
+	milestoneLabel := i18n.T("All")
+	if m.milestone != nil {
+		milestoneLabel = ui.Truncate(m.milestone.Name, 20)
 	}
-	optionsLine := fmt.Sprintf("Completed %s: [%s]    Sort %s: [%s]",
-		ui.KeyStyle.Render("(h)"), hideLabel,
-		ui.KeyStyle.Render("(o)"), ui.CenterPad(sortLabel, 6))
-	b.WriteString(ui.FilterBarStyle.Render(optionsLine))
+	optionsLine := fmt.Sprintf("%s: %s    %s: %s    %s %s: [%s]",
+		m.barLabel(segmentCompleted, "h"), m.barValue(segmentCompleted, hideLabel, false),
+		m.barLabel(segmentSort, "o"), m.barValue(segmentSort, ui.CenterPad(sortLabel, 6), false),
+		i18n.T("Milestone"), ui.KeyStyle.Render("(M)"), milestoneLabel)
+	b.WriteString(filterBarStyle.Render(optionsLine))
 	b.WriteString("\n")
 
+	// Filter bar - line 4: Milestone progress (only when filtered by milestone)
+	if m.milestone != nil {
+		progress := renderMilestoneProgress(*m.milestone, m.store.tasks.Tasks, max(m.width/5, 10))
+		b.WriteString(filterBarStyle.Render(fmt.Sprintf("%s  %s", m.milestone.Name, progress)))
+		b.WriteString("\n")
+	}
+
 	b.WriteString(ui.HorizontalLine(m.width))
 	b.WriteString("\n")
 
+	// Options of the filter bar segment being changed
+	if m.bar.open {
+		b.WriteString(m.renderFilterDropdown())
+		b.WriteString("\n\n")
+	}
+
 	// Status change mode indicator
 	if m.statusChangeMode {
-		b.WriteString(ui.WarningStyle.Render("Change status: [1/p] pending  [2/i] in_progress  [3/c] completed  [Esc] cancel"))
+		prompt := i18n.T("Change status: [1/p] pending  [2/i] in_progress  [3/c] completed  [Esc] cancel")
+		if data.ReviewEnabled() {
+			prompt = i18n.T("Change status: [1/p] pending  [2/i] in_progress  [3/c] completed  [4/r] needs_review  [Esc] cancel")
+		}
+		b.WriteString(ui.WarningStyle.Render(prompt))
+		b.WriteString("\n\n")
+	}
+
+	// Owner assignment prompt
+	if m.assign.active() {
+		b.WriteString(m.assign.view(m.store.tasks, m.width))
+		b.WriteString("\n\n")
+	}
+
+	// Status change reason prompt
+	if m.reason.active {
+		b.WriteString(m.reason.view())
+		b.WriteString("\n\n")
+	}
+
+	// Tasks unblocked by the last completion
+	if m.unblocked.active() {
+		b.WriteString(m.unblocked.view(m.store.tasks, m.width))
+		b.WriteString("\n\n")
+	}
+
+	// "What's next?" indicator
+	if m.nextActive {
+		if m.nextTask == nil {
+			b.WriteString(ui.WarningStyle.Render(i18n.T("What's next? No unblocked pending tasks.  [Esc] close")))
+		} else {
+			info := ""
+			if priority := data.GetTaskPriority(*m.nextTask); priority != "" {
+				info += ", " + i18n.Tf("%s priority", i18n.T(priority))
+			}
+			if n := data.BlockedCount(m.store.tasks.Tasks, m.nextTask.ID); n > 0 {
+				info += ", " + i18n.Tf("unblocks %d", n)
+			}
+			line := i18n.Tf("What's next? #%s %s%s", data.DisplayID(*m.nextTask), m.nextTask.Subject, info)
+			b.WriteString(ui.WarningStyle.Render(ui.Truncate(line, max(m.width-40, 20))))
+			b.WriteString(ui.WarningStyle.Render("  " + i18n.T("[s] start  [Enter] view  [Esc] close")))
+		}
+		b.WriteString("\n\n")
+	}
+
+	// Dependency chain completion
+	if m.chain.active() {
+		b.WriteString(m.chain.view(m.store.tasks, m.width))
+		b.WriteString("\n\n")
+	}
+
+	// Merge mode indicator
+	if m.mergeTargetID != "" {
+		b.WriteString(ui.WarningStyle.Render(i18n.Tf("Merge #%s and #%s? The lower ID is kept.  [y] merge  [n] cancel", m.store.tasks.DisplayRef(m.mergeSourceID), m.store.tasks.DisplayRef(m.mergeTargetID))))
+		b.WriteString("\n\n")
+	} else if m.mergeSourceID != "" {
+		b.WriteString(ui.WarningStyle.Render(i18n.Tf("Merge #%s with: select a task and press [m/Enter], [Esc] cancel", m.store.tasks.DisplayRef(m.mergeSourceID))))
 		b.WriteString("\n\n")
 	}
 
 	// Search mode indicator
 	if m.searchActive {
-		b.WriteString(ui.WarningStyle.Render("Search: Type to filter, [Enter] confirm, [Esc] cancel"))
+		b.WriteString(ui.WarningStyle.Render(i18n.T("Search: Type to filter, [Enter] confirm, [Esc] cancel")))
 		b.WriteString("\n\n")
 	}
 
 	// Task list
-	if len(m.items) == 0 {
-		b.WriteString(ui.MutedStyle.Render("No tasks found."))
+	if len(m.items) == 0 && m.filtersHide() {
+		b.WriteString(ui.MutedStyle.Render(i18n.T("No tasks match the current filters — press C to clear them.")))
+		b.WriteString("\n")
+	} else if len(m.items) == 0 {
+		b.WriteString(ui.MutedStyle.Render(i18n.T("No tasks found.")))
 		b.WriteString("\n")
-		b.WriteString(ui.MutedStyle.Render("Press 'n' to create a new task."))
+		b.WriteString(ui.MutedStyle.Render(i18n.T("Press 'n' to create a new task.")))
 		b.WriteString("\n")
 	}
 
 	// Calculate visible area (in lines, not items)
-	maxLines := m.height - 15
-	if maxLines < 5 {
-		maxLines = 10
-	}
+	maxLines := m.listHeight()
 
 	// Find startIdx: walk backward from cursor to fill viewport
 	startIdx := 0
 	{
 		lines := 0
-		for i := m.cursor; i >= 0; i-- {
+		for i := min(m.cursor, len(m.items)-1); i >= 0; i-- {
 			l := m.itemLineCount(i)
 			if lines+l > maxLines {
 				break
@@ -625,18 +1430,19 @@ func (m TasksModel) View() string {
 
 	// Scroll indicator - top
 	if startIdx > 0 {
-		b.WriteString(ui.MutedStyle.Render(fmt.Sprintf("  ↑ %d more above", startIdx)))
+		b.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Up + " " + i18n.Tf("%d more above", startIdx)))
 		b.WriteString("\n")
 	}
 
+	layout := m.columnLayout()
 	for i := startIdx; i < endIdx; i++ {
 		item := m.items[i]
-		isSelected := i == m.cursor
+		isSelected := i == m.cursor && !m.headless
 
 		if item.isGroup {
 			b.WriteString(m.renderGroupHeader(item.groupName, isSelected))
 		} else if item.task != nil {
-			b.WriteString(m.renderTaskItem(item.task, isSelected))
+			b.WriteString(m.renderTaskItem(item.task, isSelected, layout))
 		}
 		b.WriteString("\n")
 	}
@@ -644,10 +1450,27 @@ func (m TasksModel) View() string {
 	// Scroll indicator - bottom
 	remaining := len(m.items) - endIdx
 	if remaining > 0 {
-		b.WriteString(ui.MutedStyle.Render(fmt.Sprintf("  ↓ %d more below", remaining)))
+		b.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Down + " " + i18n.Tf("%d more below", remaining)))
+		b.WriteString("\n")
+	}
+
+	// Unparsable files and schema problems found on load
+	if files := m.store.unparsable(); len(files) > 0 {
+		b.WriteString("\n")
+		b.WriteString(ui.ErrorStyle.Render(ui.Glyphs.Warning + " " + i18n.Tf("%d task file(s) could not be parsed", len(files))))
+		b.WriteString(ui.MutedStyle.Render(" (!: " + i18n.T("details") + ")"))
+		b.WriteString("\n")
+	} else if problems := m.store.problems(); len(problems) > 0 {
+		b.WriteString("\n")
+		b.WriteString(ui.WarningStyle.Render(ui.Glyphs.Warning + " " + i18n.Tf("%d problem(s) in task files", len(problems))))
+		b.WriteString(ui.MutedStyle.Render(" (!: " + i18n.T("details") + ")"))
 		b.WriteString("\n")
 	}
 
+	if m.headless {
+		return b.String()
+	}
+
 	// Footer - context-aware hints
 	b.WriteString("\n")
 
@@ -657,6 +1480,18 @@ func (m TasksModel) View() string {
 		taskSelected = m.items[m.cursor].task != nil
 	}
 
+	if m.bar.focused {
+		hints := []ui.KeyHint{
+			{Key: "←→", Desc: "Filter", Enabled: !m.bar.open},
+			{Key: "↑↓", Desc: "Change", Enabled: true},
+			{Key: "Enter", Desc: "Choose", Enabled: true},
+			{Key: "Tab", Desc: "Back to list", Enabled: !m.bar.open},
+			{Key: "Esc", Desc: "Close", Enabled: m.bar.open},
+		}
+		b.WriteString(ui.FooterWithHints(hints, m.width))
+		return b.String()
+	}
+
 	hints := []ui.KeyHint{
 		// Navigation
 		{Key: "↑↓", Desc: "Navigate", Enabled: len(m.items) > 0},
@@ -666,8 +1501,18 @@ func (m TasksModel) View() string {
 		{Key: "n", Desc: "New", Enabled: true},
 		{Key: "e", Desc: "Edit", Enabled: taskSelected},
 		{Key: "s", Desc: "Status", Enabled: taskSelected},
+		{Key: "A", Desc: "Assign", Enabled: taskSelected},
+		{Key: "*", Desc: "Star", Enabled: taskSelected},
+		{Key: "m", Desc: "Merge", Enabled: taskSelected},
+		{Key: "w", Desc: "Next", Enabled: true},
+		{Key: "F", Desc: "Follow", Enabled: true},
+		{Key: "v", Desc: "Density", Enabled: true},
+		{Key: "P", Desc: "Preview", Enabled: true},
+		{Key: "Tab", Desc: "Filters", Enabled: true},
+		{Key: "C", Desc: "Clear filters", Enabled: m.filtersHide()},
 		// Management
 		{Key: "G", Desc: "Groups", Enabled: true},
+		{Key: "X", Desc: "Export", Enabled: len(m.items) > 0},
 		// Exit
 		{Key: "q", Desc: "Quit", Enabled: true},
 	}
@@ -676,49 +1521,113 @@ func (m TasksModel) View() string {
 	return b.String()
 }
 
+// listHeight returns the number of lines available to list items
+func (m *TasksModel) listHeight() int {
+	maxLines := m.height - 15 - m.filterDropdownHeight()
+	if m.chain.active() {
+		maxLines -= m.chain.lines() - 1 // the first line fits like other prompts
+	}
+	if m.density == densityCompact {
+		maxLines += 3 // filter bar lines without blank separators
+	}
+	if maxLines < 5 {
+		maxLines = 10
+	}
+	return maxLines
+}
+
+// cycleDensity switches between the normal, compact and comfortable layouts
+func (m *TasksModel) cycleDensity() {
+	switch m.density {
+	case densityNormal:
+		m.density = densityCompact
+	case densityCompact:
+		m.density = densityComfortable
+	default:
+		m.density = densityNormal
+	}
+}
+
 // itemLineCount returns the number of display lines an item at index i takes
 func (m *TasksModel) itemLineCount(i int) int {
 	item := m.items[i]
-	if item.isGroup {
+	if item.isGroup || item.task == nil {
 		return 1
 	}
-	if item.task != nil && len(item.task.BlockedBy) > 0 {
-		return 2 // task line + "blocked by" line
+	lines := 1
+	if m.density != densityCompact && len(item.task.BlockedBy) > 0 {
+		lines++ // "blocked by" line
+	}
+	if m.previewShown() && descriptionPreview(*item.task) != "" {
+		lines++
 	}
-	return 1
+	if _, ok := otherFieldMatch(*item.task, m.searchInput.Value()); ok && m.density != densityCompact {
+		lines++ // where the search matched
+	}
+	if m.density == densityComfortable {
+		lines++ // blank line after the task
+	}
+	return lines
+}
+
+// previewShown reports whether task rows show their description preview
+func (m *TasksModel) previewShown() bool {
+	return m.showPreview || m.density == densityComfortable
+}
+
+// descriptionPreview returns the first non-empty line of a task's description
+func descriptionPreview(task data.Task) string {
+	for _, line := range strings.Split(task.Description, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
 }
 
 func (m *TasksModel) renderGroupHeader(groupName string, selected bool) string {
 	// Count tasks by status for this group
-	pending, inProgress, completed := 0, 0, 0
-	for _, task := range m.taskStore.Tasks {
+	pending, inProgress, review, completed := 0, 0, 0, 0
+	remaining := 0.0
+	for _, task := range m.store.tasks.Tasks {
 		tg := data.GetTaskGroup(task)
 		if tg == "" {
 			tg = "Uncategorized"
 		}
+		if groupName == starredGroup && data.IsTaskStarred(task) {
+			tg = starredGroup
+		}
 		if tg == groupName {
+			if task.Status != "completed" {
+				remaining += data.GetTaskEstimate(task)
+			}
 			switch task.Status {
 			case "pending":
 				pending++
 			case "in_progress":
 				inProgress++
+			case data.StatusNeedsReview:
+				review++
 			case "completed":
 				completed++
 			}
 		}
 	}
-	total := pending + inProgress + completed
+	total := pending + inProgress + review + completed
 
 	// Get group color
-	color := m.groupStore.GetGroupColor(groupName)
-	if groupName == "Uncategorized" {
+	color := m.store.groups.GetGroupColor(groupName)
+	switch groupName {
+	case "Uncategorized":
 		color = "#6b7280"
+	case starredGroup:
+		color = "#f59e0b"
 	}
 
 	// Collapse indicator
-	collapseIcon := "▼"
+	collapseIcon := ui.Glyphs.Expanded
 	if m.collapsedGroups[groupName] {
-		collapseIcon = "▶"
+		collapseIcon = ui.Glyphs.Collapsed
 	}
 
 	prefix := "  "
@@ -728,76 +1637,178 @@ func (m *TasksModel) renderGroupHeader(groupName string, selected bool) string {
 		style = ui.SelectedStyle
 	}
 
-	swatch := ui.ColorSwatchStyle(color).Render("●")
+	swatch := ui.ColorSwatchStyle(color).Render(ui.Glyphs.Swatch)
 
 	// Build status summary: ○2 ●1 ✓3
 	var statusParts []string
 	if pending > 0 {
-		statusParts = append(statusParts, ui.PendingStyle.Render(fmt.Sprintf("○%d", pending)))
+		statusParts = append(statusParts, ui.PendingStyle.Render(ui.StatusCount("pending", pending)))
 	}
 	if inProgress > 0 {
-		statusParts = append(statusParts, ui.InProgressStyle.Render(fmt.Sprintf("●%d", inProgress)))
+		statusParts = append(statusParts, ui.InProgressStyle.Render(ui.StatusCount("in_progress", inProgress)))
+	}
+	if review > 0 {
+		statusParts = append(statusParts, ui.ReviewStyle.Render(ui.StatusCount(data.StatusNeedsReview, review)))
 	}
 	if completed > 0 {
-		statusParts = append(statusParts, ui.CompletedStyle.Render(fmt.Sprintf("✓%d", completed)))
+		statusParts = append(statusParts, ui.CompletedStyle.Render(ui.StatusCount("completed", completed)))
 	}
 	statusSummary := strings.Join(statusParts, " ")
 
-	header := fmt.Sprintf("%s%s %s %s (%d)", prefix, collapseIcon, swatch, groupName, total)
+	header := fmt.Sprintf("%s%s %s %s (%d)", prefix, collapseIcon, swatch, displayGroupName(groupName), total)
 	result := style.Render(header)
 
 	// Add status summary
 	if statusSummary != "" {
 		result += "  " + statusSummary
 	}
+	if remaining > 0 {
+		result += ui.MutedStyle.Render("  Σ" + data.FormatEstimate(remaining))
+	}
 
 	// Show hint when selected
 	if selected {
-		hint := " (Enter: toggle)"
+		hint := " (Enter: " + i18n.T("toggle") + ")"
 		result += ui.MutedStyle.Render(hint)
 	}
 
 	return result
 }
 
-func (m *TasksModel) renderTaskItem(task *data.Task, selected bool) string {
-	prefix := "  "
-	if selected {
-		prefix = "> "
-	}
+// taskRowLayout is the column layout shared by the task rows of one render
+type taskRowLayout struct {
+	names     []string
+	columns   []ui.Column
+	iconWidth int // widest status icon
+}
 
-	statusIcon := data.StatusIcon(task.Status)
-	statusStyle := ui.GetStatusStyle(task.Status)
-	statusBadge := statusStyle.Render(fmt.Sprintf("[%s]", task.Status))
+// columnLayout sizes the visible columns to the project's tasks: fixed
+// columns fit their widest value (columns without values are hidden) and the
+// subject takes the remaining width
+func (m *TasksModel) columnLayout() taskRowLayout {
+	layout := taskRowLayout{names: visibleTaskColumns()}
+	for _, status := range []string{"pending", "in_progress", data.StatusNeedsReview, "completed", "blocked"} {
+		layout.iconWidth = max(layout.iconWidth, lipgloss.Width(ui.StatusIcon(status)))
+	}
 
-	// Calculate available width for subject
-	statusWidth := lipgloss.Width(statusBadge)
-	maxSubjectLen := m.width - 25 - statusWidth
-	if maxSubjectLen < 20 {
-		maxSubjectLen = 20
+	layout.columns = make([]ui.Column, len(layout.names))
+	for i, name := range layout.names {
+		column := &layout.columns[i]
+		if name == "subject" {
+			column.Flex = true
+			continue
+		}
+		for _, task := range m.store.tasks.Tasks {
+			column.Width = max(column.Width, lipgloss.Width(taskCell(task, name).Text))
+		}
+		switch name {
+		case "group":
+			column.Width = min(column.Width, 16)
+		case "owner":
+			column.Width = min(column.Width, 15)
+		case "status":
+			column.Right = true
+		}
 	}
-	subject := ui.Truncate(task.Subject, maxSubjectLen)
 
-	// Build left part (without styling yet)
-	leftContent := fmt.Sprintf("%s%s #%s %s",
-		prefix,
-		statusStyle.Render(statusIcon),
-		task.ID,
-		subject,
-	)
+	// Rows start with the cursor prefix and the status icon
+	totalWidth := max(m.width, 60)
+	ui.FitColumns(layout.columns, totalWidth-2-layout.iconWidth-1, 20)
+	return layout
+}
 
-	// Calculate padding using lipgloss.Width for accurate measurement
-	leftWidth := lipgloss.Width(leftContent)
-	totalWidth := m.width
-	if totalWidth < 60 {
-		totalWidth = 60
+// decorateSubject badges a stale task in its subject cell and highlights
+// the search match; when the subject did not match, a marker names the
+// field that did (the description, or any field in compact rows, which have
+// no line for other matches)
+func (m *TasksModel) decorateSubject(cell *ui.Cell, task data.Task) {
+	query := m.searchInput.Value()
+	var suffix, rendered string
+	if m.stale[task.ID] {
+		badge := " [" + i18n.T("stale") + "]"
+		suffix, rendered = badge, ui.WarningStyle.Render(badge)
+	}
+	if match, ok := hiddenMatch(task, query); ok && (match.Field == "description" || m.density == densityCompact) {
+		marker := " " + i18n.Tf("(in %s)", match.Field)
+		suffix, rendered = suffix+marker, rendered+ui.MutedStyle.Render(marker)
 	}
-	padding := totalWidth - leftWidth - statusWidth
-	if padding < 1 {
-		padding = 1
+	if query == "" && suffix == "" {
+		return
+	}
+	cell.Text += suffix
+	style := cell.Style
+	cell.Render = func(text string) string {
+		if suffix != "" && strings.HasSuffix(text, suffix) {
+			return highlightMatch(strings.TrimSuffix(text, suffix), query, style) + rendered
+		}
+		return highlightMatch(text, query, style)
 	}
+}
 
-	line := leftContent + strings.Repeat(" ", padding) + statusBadge
+// taskCell returns a task's value for a task list column
+func taskCell(task data.Task, name string) ui.Cell {
+	switch name {
+	case "id":
+		return ui.Cell{Text: "#" + data.DisplayID(task)}
+	case "subject":
+		text := task.Subject
+		if data.IsAgentTask(task) {
+			text = ui.Glyphs.Agent + " " + text
+		}
+		if progress := checklistProgress(task); progress != "" {
+			text += " (" + progress + ")"
+		}
+		return ui.Cell{Text: text}
+	case "group":
+		group := data.GetTaskGroup(task)
+		if group != "" {
+			group = displayGroupName(group)
+		}
+		return ui.Cell{Text: group, Style: ui.MutedStyle}
+	case "owner":
+		if task.Owner == "" {
+			return ui.Cell{Style: ui.MutedStyle}
+		}
+		badge := data.GetOwnerBadge(task.Owner)
+		initials := ui.PadRight(badge.Initials, 2)
+		return ui.Cell{Text: initials + " " + task.Owner, Style: ui.MutedStyle, Render: func(text string) string {
+			if name, ok := strings.CutPrefix(text, initials+" "); ok {
+				return ui.OwnerBadge(initials, badge.Color) + " " + ui.MutedStyle.Render(name)
+			}
+			return ui.MutedStyle.Render(text)
+		}}
+	case "due":
+		return ui.Cell{Text: data.GetTaskDue(task), Style: ui.MutedStyle}
+	case "status":
+		return ui.Cell{Text: fmt.Sprintf("[%s]", i18n.T(task.Status)), Style: ui.GetStatusStyle(task.Status)}
+	}
+	return ui.Cell{}
+}
+
+func (m *TasksModel) renderTaskItem(task *data.Task, selected bool, layout taskRowLayout) string {
+	prefix := "  "
+	if selected {
+		prefix = "> "
+	}
+
+	var line string
+	if row, ok := renderTemplateRow(config.Current().Templates.Row, *task, max(m.width, 60)-2); ok {
+		line = prefix + row
+	} else {
+		iconStatus := task.Status
+		if m.blocked[task.ID] {
+			iconStatus = "blocked"
+		}
+		statusIcon := ui.PadRight(ui.StatusIcon(iconStatus), layout.iconWidth)
+		cells := make([]ui.Cell, len(layout.names))
+		for i, name := range layout.names {
+			cells[i] = taskCell(*task, name)
+			if name == "subject" {
+				m.decorateSubject(&cells[i], *task)
+			}
+		}
+		line = prefix + ui.GetStatusStyle(iconStatus).Render(statusIcon) + " " + ui.TableRow(layout.columns, cells)
+	}
 
 	var result string
 	if selected {
@@ -807,10 +1818,28 @@ func (m *TasksModel) renderTaskItem(task *data.Task, selected bool) string {
 	}
 
 	// Add blocked by indicator
-	if len(task.BlockedBy) > 0 {
-		blockedByStr := fmt.Sprintf("      └─ blocked by: %s", strings.Join(task.BlockedBy, ", "))
+	if m.density != densityCompact && len(task.BlockedBy) > 0 {
+		blockedByStr := "      " + ui.Glyphs.Corner + ui.Glyphs.Line + " " + i18n.Tf("blocked by: %s", strings.Join(task.BlockedBy, ", "))
 		result += "\n" + ui.BlockedByStyle.Render(blockedByStr)
 	}
+	if reason := data.GetTaskBlockedReason(*task); m.density != densityCompact && reason != "" && task.Status != "completed" {
+		blockedStr := "      " + ui.Glyphs.Corner + ui.Glyphs.Line + " " + i18n.Tf("blocked: %s", reason)
+		result += "\n" + ui.BlockedByStyle.Render(ui.Truncate(blockedStr, max(m.width-6, 20)))
+	}
+
+	if match, ok := otherFieldMatch(*task, m.searchInput.Value()); ok && m.density != densityCompact {
+		prefix := "      " + ui.Glyphs.Corner + ui.Glyphs.Line + " "
+		result += "\n" + ui.MutedStyle.Render(prefix) + matchSnippet(match, max(m.width-8, 20)-lipgloss.Width(prefix))
+	}
+
+	if m.previewShown() {
+		if preview := descriptionPreview(*task); preview != "" {
+			result += "\n" + highlightMatch("      "+ui.Truncate(preview, max(m.width-8, 20)), m.searchInput.Value(), ui.MutedStyle)
+		}
+	}
+	if m.density == densityComfortable {
+		result += "\n"
+	}
 
 	return result
 }
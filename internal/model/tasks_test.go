@@ -1,12 +1,20 @@
 package model
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 
+	"github.com/jss826/cctasks/internal/config"
 	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/testutil"
+	"github.com/jss826/cctasks/internal/ui"
 )
 
 func setupTestTasks(t *testing.T) (*data.TaskStore, *data.GroupStore, string) {
@@ -46,7 +54,7 @@ func TestTasksModel_Navigation(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -78,7 +86,7 @@ func TestTasksModel_JKNavigation(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -99,7 +107,7 @@ func TestTasksModel_HomeEnd(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -126,7 +134,7 @@ func TestTasksModel_StatusFilter(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -152,7 +160,19 @@ func TestTasksModel_StatusFilter(t *testing.T) {
 		t.Error("Expected statusFilter to change on third 'f'")
 	}
 
-	// Fourth press should cycle back
+	// Fourth press shows blocked tasks
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if m.statusFilter != "blocked" {
+		t.Errorf("Expected the blocked filter after completed, got '%s'", m.statusFilter)
+	}
+
+	// Fifth press shows stale tasks
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if m.statusFilter != "stale" {
+		t.Errorf("Expected the stale filter after blocked, got '%s'", m.statusFilter)
+	}
+
+	// Sixth press should cycle back
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
 	if m.statusFilter != initialFilter {
 		t.Errorf("Expected statusFilter to cycle back to initial '%s', got '%s'", initialFilter, m.statusFilter)
@@ -163,7 +183,7 @@ func TestTasksModel_GroupFilter(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -182,7 +202,7 @@ func TestTasksModel_HideCompleted(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -222,7 +242,7 @@ func TestTasksModel_SortMode(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -235,6 +255,12 @@ func TestTasksModel_SortMode(t *testing.T) {
 		t.Error("Expected sortMode to change after 'o'")
 	}
 
+	// Third mode is the manual order
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if m.sortMode != "manual" {
+		t.Errorf("Expected the manual sort after status, got '%s'", m.sortMode)
+	}
+
 	// Press o again to cycle back
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
 	if m.sortMode != initialMode {
@@ -246,7 +272,7 @@ func TestTasksModel_Search(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -266,11 +292,442 @@ func TestTasksModel_Search(t *testing.T) {
 	}
 }
 
+func TestTasksModel_SearchOtherFields(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	taskStore.GetTask("2").Owner = "alice"
+	taskStore.GetTask("4").Description = "Ask alice about it"
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 24
+	m.hideCompleted = false
+	for groupName := range m.collapsedGroups {
+		m.collapsedGroups[groupName] = false
+	}
+	m.searchInput.SetValue("ALICE")
+	m.rebuildItems()
+
+	var ids []string
+	for _, item := range m.items {
+		if item.task != nil {
+			ids = append(ids, item.task.ID)
+		}
+	}
+	if len(ids) != 2 || ids[0] != "2" || ids[1] != "4" {
+		t.Fatalf("Expected tasks 2 (owner) and 4 (description), got %v", ids)
+	}
+
+	// The owner match is shown under its row; the description match is not
+	view := m.View()
+	if !containsStr(view, "owner: alice") {
+		t.Errorf("Expected the owner match under task 2, got:\n%s", view)
+	}
+	if containsStr(view, "description:") {
+		t.Error("Expected no snippet for a description match")
+	}
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == "2" && m.itemLineCount(i) != 2 {
+			t.Errorf("Expected task 2 to take 2 lines, got %d", m.itemLineCount(i))
+		}
+	}
+}
+
+func TestTasksModel_SearchHighlight(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	taskStore.GetTask("2").Owner = "alice"
+	taskStore.GetTask("4").Description = "Ask alice about it"
+	taskStore.GetTask("1").Subject = "Call Alice"
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 24
+	m.hideCompleted = false
+	for groupName := range m.collapsedGroups {
+		m.collapsedGroups[groupName] = false
+	}
+	m.searchInput.SetValue("alice")
+	m.rebuildItems()
+
+	// The subject match is highlighted in place
+	view := m.View()
+	if !containsStr(view, ui.SearchMatchStyle.Render("Alice")) {
+		t.Errorf("Expected the subject match to be highlighted, got:\n%s", view)
+	}
+	// A description match is marked on the row, other fields get their own line
+	if !containsStr(view, "(in description)") || containsStr(view, "(in owner)") {
+		t.Errorf("Expected a description marker only, got:\n%s", view)
+	}
+
+	// Compact rows mark every field inline
+	m.density = densityCompact
+	view = m.View()
+	if !containsStr(view, "(in owner)") || containsStr(view, "owner: alice") {
+		t.Errorf("Expected an inline owner marker in compact rows, got:\n%s", view)
+	}
+}
+
+func TestTasksModel_CountSummary(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 100
+	m.height = 24
+
+	// Completed tasks are hidden by default, collapsed groups still count
+	if view := m.View(); !containsStr(view, "Showing 3 of 4 task(s) (1 hidden by filters)") {
+		t.Errorf("Expected the hidden completed task to be counted, got:\n%s", view)
+	}
+
+	m.hideCompleted = false
+	m.rebuildItems()
+	if view := m.View(); !containsStr(view, "Showing all 4 task(s)") {
+		t.Errorf("Expected all tasks to be shown, got:\n%s", view)
+	}
+
+	m.groupFilter = "Backend"
+	m.rebuildItems()
+	if view := m.View(); !containsStr(view, "Showing 2 of 4 task(s) (2 hidden by filters)") {
+		t.Errorf("Expected the group filter to hide 2 tasks, got:\n%s", view)
+	}
+}
+
+func TestTasksModel_ClearFilters(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 100
+	m.height = 24
+	m.groupFilter = "Frontend"
+	m.statusFilter = "completed"
+	m.searchInput.SetValue("nothing")
+	m.rebuildItems()
+
+	// The list is empty only because of the filters
+	view := m.View()
+	if !containsStr(view, "No tasks match the current filters") || containsStr(view, "No tasks found.") {
+		t.Errorf("Expected the filtered empty state, got:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if m.statusFilter != "" || m.groupFilter != "" || m.searchInput.Value() != "" || m.hideCompleted {
+		t.Errorf("Expected all filters cleared, got status %q group %q search %q hideCompleted %v",
+			m.statusFilter, m.groupFilter, m.searchInput.Value(), m.hideCompleted)
+	}
+	if len(m.FilteredTaskIDs()) != 4 {
+		t.Errorf("Expected all 4 tasks listed, got %v", m.FilteredTaskIDs())
+	}
+}
+
+func TestTasksModel_UnblockedPrompt(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Tasks 1 and 4 wait for task 2; task 1 also waits for task 4
+	taskStore.GetTask("2").Blocks = []string{"1", "4"}
+	taskStore.GetTask("1").BlockedBy = []string{"2", "4"}
+	taskStore.GetTask("4").BlockedBy = []string{"2"}
+	taskStore.GetTask("4").Blocks = []string{"1"}
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 100
+	m.height = 30
+	for groupName := range m.collapsedGroups {
+		m.collapsedGroups[groupName] = false
+	}
+	m.rebuildItems()
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == "2" {
+			m.cursor = i
+		}
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if taskStore.GetTask("2").Status != "completed" {
+		t.Fatalf("Expected task 2 completed, got %s", taskStore.GetTask("2").Status)
+	}
+	// Only task 4 is free now; task 1 still waits for it
+	if len(m.unblocked.ids) != 1 || m.unblocked.ids[0] != "4" {
+		t.Fatalf("Expected task 4 reported unblocked, got %v", m.unblocked.ids)
+	}
+	if view := m.View(); !containsStr(view, "Unblocked #4 Task 4") {
+		t.Errorf("Expected the unblocked prompt, got:\n%s", view)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	if cmd == nil {
+		t.Fatal("Expected command from 1")
+	}
+	if msg, ok := cmd().(ViewTaskMsg); !ok || msg.Task.ID != "4" {
+		t.Errorf("Expected ViewTaskMsg for task 4, got %#v", cmd())
+	}
+
+	// Any other key dismisses the prompt and does its usual job
+	m.unblocked.open([]string{"4"})
+	cursor := m.cursor
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if m.unblocked.active() || m.cursor == cursor {
+		t.Errorf("Expected the prompt dismissed and the cursor moved, got %v at %d", m.unblocked.ids, m.cursor)
+	}
+}
+
+func TestTasksModel_AutoAdvance(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	cfg := config.Default()
+	cfg.TaskList.AutoAdvance = true
+	config.SetCurrent(cfg)
+
+	// Task 2 waits for task 4, so the next actionable task after 1 is 4
+	taskStore.GetTask("2").BlockedBy = []string{"4"}
+	taskStore.GetTask("4").Blocks = []string{"2"}
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 100
+	m.height = 30
+	for groupName := range m.collapsedGroups {
+		m.collapsedGroups[groupName] = false
+	}
+	m.rebuildItems()
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == "1" {
+			m.cursor = i
+		}
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if task := m.currentTask(); task == nil || task.ID != "4" {
+		t.Errorf("Expected the cursor on task 4, got %+v", task)
+	}
+
+	// Without the option the cursor stays where the task was
+	cfg.TaskList.AutoAdvance = false
+	m.hideCompleted = false
+	m.rebuildItems()
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == "2" {
+			m.cursor = i
+		}
+	}
+	cursor := m.cursor
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if m.cursor != cursor {
+		t.Errorf("Expected the cursor to stay at %d, got %d", cursor, m.cursor)
+	}
+}
+
+func TestTasksModel_CompleteChain(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	// 4 -> 2 -> 1: task 1 waits for 2, which waits for 4
+	taskStore.GetTask("1").BlockedBy = []string{"2"}
+	taskStore.GetTask("2").Blocks = []string{"1"}
+	taskStore.GetTask("2").BlockedBy = []string{"4"}
+	taskStore.GetTask("4").Blocks = []string{"2"}
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 100
+	m.height = 30
+	for groupName := range m.collapsedGroups {
+		m.collapsedGroups[groupName] = false
+	}
+	m.rebuildItems()
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == "1" {
+			m.cursor = i
+		}
+	}
+
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	m, _ = m.Update(key('c'))
+	if view := m.View(); !containsStr(view, "[a] 2 open it depends on  [d] 0 open depending on it") {
+		t.Errorf("Expected the chain sizes offered, got:\n%s", view)
+	}
+
+	// The dependencies of task 1 are listed before anything changes
+	m, _ = m.Update(key('a'))
+	view := m.View()
+	if !containsStr(view, "Complete these 3 task(s)?") || !containsStr(view, "#4 Task 4") {
+		t.Errorf("Expected the affected tasks listed, got:\n%s", view)
+	}
+	if taskStore.GetTask("4").Status != "pending" {
+		t.Fatal("Expected nothing completed before confirming")
+	}
+
+	m, _ = m.Update(key('y'))
+	for _, id := range []string{"1", "2", "4"} {
+		if status := taskStore.GetTask(id).Status; status != "completed" {
+			t.Errorf("Expected task %s completed, got %s", id, status)
+		}
+	}
+	if m.chain.active() {
+		t.Error("Expected the prompt closed")
+	}
+
+	// Esc cancels at either step
+	taskStore.GetTask("4").Status = "pending"
+	m.chain = chainPrompt{taskID: "4"}
+	m, _ = m.Update(key('d'))
+	if len(m.chain.ids) != 1 || m.chain.ids[0] != "4" {
+		t.Errorf("Expected only task 4 open in its chain, got %v", m.chain.ids)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.chain.active() || taskStore.GetTask("4").Status != "pending" {
+		t.Error("Expected Esc to cancel without changes")
+	}
+}
+
+func TestTasksModel_AssignOwner(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+	cfg := config.Default()
+	cfg.Roster = []string{"alice", "bob"}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 120
+	m.height = 30
+	for groupName := range m.collapsedGroups {
+		m.collapsedGroups[groupName] = false
+	}
+	m.rebuildItems()
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == "1" {
+			m.cursor = i
+		}
+	}
+
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	m, _ = m.Update(key('A'))
+	if view := m.View(); !containsStr(view, "Assign #1 to:  [1] alice  [2] bob") {
+		t.Errorf("Expected the roster offered, got:\n%s", view)
+	}
+	m, _ = m.Update(key('2'))
+	if owner := taskStore.GetTask("1").Owner; owner != "bob" || m.assign.active() {
+		t.Fatalf("Expected task 1 assigned to bob and the prompt closed, got %q", owner)
+	}
+	history, _ := taskStore.History()
+	if len(history) == 0 || history[len(history)-1].Kind != data.ChangeAssigned || history[len(history)-1].To != "bob" {
+		t.Errorf("Expected the assignment in the history, got %+v", history)
+	}
+
+	// 0 removes the owner
+	m, _ = m.Update(key('A'))
+	m, _ = m.Update(key('0'))
+	if owner := taskStore.GetTask("1").Owner; owner != "" {
+		t.Errorf("Expected task 1 unassigned, got %q", owner)
+	}
+}
+
+func TestTasksModel_ReadOnlyProject(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+	testutil.IsolateHome(t)
+	cfg := config.Default()
+	cfg.Roots = []config.RootConfig{{Name: "build", Path: "~/.claude/tasks", SSH: "ci@build"}}
+	config.SetCurrent(cfg)
+	defer config.SetCurrent(nil)
+	taskStore.ProjectName = "build/app"
+
+	store := newProjectStore(taskStore, groupStore)
+	m := NewTasksModel("build/app", store)
+	m.width = 120
+	m.height = 30
+	for groupName := range m.collapsedGroups {
+		m.collapsedGroups[groupName] = false
+	}
+	m.rebuildItems()
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == "1" {
+			m.cursor = i
+		}
+	}
+
+	key := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}} }
+	m, _ = m.Update(key('*'))
+	m, _ = m.Update(key('s'))
+	if m.statusChangeMode || data.IsTaskStarred(*taskStore.GetTask("1")) {
+		t.Error("Expected the changes refused on a read-only project")
+	}
+	if !errors.Is(store.err, data.ErrReadOnlyRoot) {
+		t.Errorf("Expected the read-only error for the status bar, got %v", store.err)
+	}
+	if bar := renderStatusBar(store, 160, time.Now()); !containsStr(bar, "read-only") {
+		t.Errorf("Expected the error in the status bar, got %q", bar)
+	}
+	if view := m.View(); !containsStr(view, "[read-only]") {
+		t.Errorf("Expected the read-only marker in the header, got:\n%s", view)
+	}
+
+	// Browsing still works
+	hidden := m.hideCompleted
+	m, _ = m.Update(key('h'))
+	if m.hideCompleted == hidden {
+		t.Error("Expected the filters to work on a read-only project")
+	}
+}
+
+func TestTasksModel_StaleTasks(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Task 2 (in_progress) was last touched a week ago
+	old := time.Now().Add(-7 * 24 * time.Hour)
+	if err := os.Chtimes(filepath.Join(tmpDir, "2.json"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 100
+	m.height = 30
+	for groupName := range m.collapsedGroups {
+		m.collapsedGroups[groupName] = false
+	}
+	m.rebuildItems()
+	if view := m.View(); !containsStr(view, "Task 2 [stale]") || containsStr(view, "Task 1 [stale]") {
+		t.Errorf("Expected only task 2 badged stale, got:\n%s", view)
+	}
+
+	m.statusFilter = "stale"
+	m.rebuildItems()
+	if ids := m.FilteredTaskIDs(); len(ids) != 1 || ids[0] != "2" {
+		t.Errorf("Expected the stale filter to list task 2, got %v", ids)
+	}
+
+	// A longer threshold makes nothing stale
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	cfg := config.Default()
+	cfg.TaskList.StaleAfter = "10d"
+	config.SetCurrent(cfg)
+	m.rebuildItems()
+	if ids := m.FilteredTaskIDs(); len(ids) != 0 {
+		t.Errorf("Expected nothing stale after 10 days, got %v", ids)
+	}
+}
+
 func TestTasksModel_ToggleGroup(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -314,7 +771,7 @@ func TestTasksModel_Items(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -354,7 +811,7 @@ func TestTasksModel_QuickStatusChange(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -385,7 +842,7 @@ func TestTasksModel_QuickStatusChange(t *testing.T) {
 			}
 
 			// Verify status changed in taskStore
-			task := m.taskStore.GetTask(taskID)
+			task := m.store.tasks.GetTask(taskID)
 			if task != nil && task.Status != "in_progress" {
 				t.Errorf("Expected status 'in_progress', got '%s'", task.Status)
 			}
@@ -402,7 +859,7 @@ func TestTasksModel_QuickStatusChangeCancel(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -431,7 +888,7 @@ func TestTasksModel_QuickStatusChangeCancel(t *testing.T) {
 			}
 
 			// Status should not have changed
-			task := m.taskStore.GetTask(item.task.ID)
+			task := m.store.tasks.GetTask(item.task.ID)
 			if task != nil && task.Status != originalStatus {
 				t.Errorf("Expected status to remain '%s', got '%s'", originalStatus, task.Status)
 			}
@@ -444,7 +901,7 @@ func TestTasksModel_ViewOutput(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -465,7 +922,7 @@ func TestTasksModel_CursorBounds(t *testing.T) {
 	taskStore, groupStore, tmpDir := setupTestTasks(t)
 	defer os.RemoveAll(tmpDir)
 
-	m := NewTasksModel("test", taskStore, groupStore)
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
 	m.width = 80
 	m.height = 24
 
@@ -508,3 +965,553 @@ func containsStr(s, substr string) bool {
 	}
 	return false
 }
+
+func TestTasksModel_WhatsNextStart(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 24
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if !m.nextActive || m.nextTask == nil {
+		t.Fatal("Expected a next task suggestion after 'w'")
+	}
+	if m.nextTask.ID != "1" {
+		t.Errorf("Expected #1 to be suggested, got #%s", m.nextTask.ID)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if m.nextActive {
+		t.Error("Expected prompt closed after starting the task")
+	}
+	if status := taskStore.GetTask("1").Status; status != "in_progress" {
+		t.Errorf("Expected #1 in_progress, got %s", status)
+	}
+}
+
+func TestTasksModel_StarPinsToTop(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 24
+	m.statusFilter = "pending"
+	m.collapsedGroups = make(map[string]bool)
+	m.rebuildItems()
+
+	// Star task 2 (in_progress, hidden by the status filter) directly in the store
+	data.SetTaskStarred(taskStore.GetTask("2"), true)
+	// Star task 4 from the list
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == "4" {
+			m.cursor = i
+		}
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+
+	if !m.items[0].isGroup || m.items[0].groupName != starredGroup {
+		t.Fatalf("Expected Starred group first, got %+v", m.items[0])
+	}
+	if m.items[1].task == nil || m.items[1].task.ID != "2" || m.items[2].task == nil || m.items[2].task.ID != "4" {
+		t.Errorf("Expected starred tasks 2 and 4 regardless of filters, got %+v %+v", m.items[1], m.items[2])
+	}
+	if task := m.currentTask(); task == nil || task.ID != "4" {
+		t.Errorf("Expected cursor to follow task 4, got %+v", task)
+	}
+
+	// Starred tasks are not repeated in their own group
+	count := 0
+	for _, item := range m.items {
+		if item.task != nil && item.task.ID == "4" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected task 4 listed once, got %d", count)
+	}
+
+	// Unstarring returns the task to its group and persists
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	if data.IsTaskStarred(*taskStore.GetTask("4")) {
+		t.Error("Expected task 4 to be unstarred")
+	}
+	if task := m.currentTask(); task == nil || task.ID != "4" {
+		t.Errorf("Expected cursor to stay on task 4, got %+v", task)
+	}
+}
+
+func TestTasksModel_SessionRoundTrip(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.collapsedGroups["Backend"] = false
+	m.hideCompleted = false
+	m.sortMode = "status"
+	m.groupFilter = "Backend"
+	m.rebuildItems()
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == "3" {
+			m.cursor = i
+		}
+	}
+	st := m.SessionState()
+
+	restored := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	restored.RestoreSession(st, nil)
+	if restored.groupFilter != "Backend" || restored.sortMode != "status" || restored.hideCompleted {
+		t.Errorf("Filters not restored: group=%q sort=%q hideCompleted=%v",
+			restored.groupFilter, restored.sortMode, restored.hideCompleted)
+	}
+	if restored.collapsedGroups["Backend"] {
+		t.Error("Expected Backend to stay expanded")
+	}
+	if task := restored.currentTask(); task == nil || task.ID != "3" {
+		t.Errorf("Expected cursor on task 3, got %+v", task)
+	}
+
+	// Cursor on a group header is restored by name
+	restored.cursor = 0
+	st = restored.SessionState()
+	if st.CursorGroup != "Backend" || st.CursorTaskID != "" {
+		t.Errorf("Expected cursor group Backend, got %+v", st)
+	}
+}
+
+func TestTasksModel_Density(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	taskStore.Tasks[0].BlockedBy = []string{"2"}
+	taskStore.Tasks[0].Description = "\nFirst line of the description\nsecond line"
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 40
+	m.collapsedGroups = make(map[string]bool)
+	m.rebuildItems()
+
+	idx := -1
+	for i, item := range m.items {
+		if item.task != nil && item.task.ID == "1" {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		t.Fatal("task 1 not listed")
+	}
+
+	if got := m.itemLineCount(idx); got != 2 {
+		t.Errorf("normal: itemLineCount = %d, want 2 (task + blocked by)", got)
+	}
+	if containsStr(m.View(), "First line") {
+		t.Error("normal: description preview should be hidden")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if m.density != densityCompact {
+		t.Fatalf("density = %q, want compact", m.density)
+	}
+	if got := m.itemLineCount(idx); got != 1 {
+		t.Errorf("compact: itemLineCount = %d, want 1", got)
+	}
+	if view := m.View(); containsStr(view, "blocked by") {
+		t.Error("compact: blocked-by line should be hidden")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if m.density != densityComfortable {
+		t.Fatalf("density = %q, want comfortable", m.density)
+	}
+	if got := m.itemLineCount(idx); got != 4 {
+		t.Errorf("comfortable: itemLineCount = %d, want 4 (task, blocked by, preview, blank)", got)
+	}
+	view := m.View()
+	if !containsStr(view, "First line of the description") || containsStr(view, "second line") {
+		t.Error("comfortable: expected only the first description line as preview")
+	}
+	if st := m.SessionState(); st.Density != densityComfortable {
+		t.Errorf("session density = %q", st.Density)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if m.density != densityNormal {
+		t.Errorf("density = %q, want normal after a full cycle", m.density)
+	}
+}
+
+func TestTasksModel_DescriptionPreview(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	taskStore.Tasks[3].Description = "Check the parser\nmore details"
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 40
+	m.collapsedGroups = make(map[string]bool)
+	m.rebuildItems()
+
+	lines := func() int {
+		total := 0
+		for i := range m.items {
+			total += m.itemLineCount(i)
+		}
+		return total
+	}
+	before := lines()
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if !m.showPreview {
+		t.Fatal("P should turn previews on")
+	}
+	if got := lines(); got != before+1 {
+		t.Errorf("list lines = %d, want %d (one task has a description)", got, before+1)
+	}
+	view := m.View()
+	if !containsStr(view, "Check the parser") || containsStr(view, "more details") {
+		t.Error("expected the first description line under the task")
+	}
+	if st := m.SessionState(); !st.ShowPreview {
+		t.Error("preview toggle not saved in the session state")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if containsStr(m.View(), "Check the parser") {
+		t.Error("P should turn previews off again")
+	}
+}
+
+func TestTasksModel_Columns(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	cfg := config.Default()
+	config.SetCurrent(cfg)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 100
+	m.height = 40
+	m.collapsedGroups = make(map[string]bool)
+	m.rebuildItems()
+
+	// Owner and due columns are hidden while no task has a value
+	layout := m.columnLayout()
+	for i, name := range layout.names {
+		if (name == "owner" || name == "due") && layout.columns[i].Width != 0 {
+			t.Errorf("%s column shown without values", name)
+		}
+	}
+
+	taskStore.Tasks[0].Owner = "alice"
+	m.rebuildItems()
+	if !containsStr(m.View(), "alice") {
+		t.Error("expected the owner column once a task has an owner")
+	}
+
+	cfg.TaskList.Columns = []string{"status", "bogus", "id"}
+	layout = m.columnLayout()
+	if len(layout.names) != 2 || layout.names[0] != "status" || layout.names[1] != "id" {
+		t.Errorf("columns = %v, want [status id]", layout.names)
+	}
+	if containsStr(m.View(), "Task 1") {
+		t.Error("subject column should be hidden by the config")
+	}
+}
+
+func TestTasksModel_RowTemplate(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	cfg := config.Default()
+	cfg.Templates.Row = `{{.Icon}} {{upper .StatusLabel}} #{{.ID}} {{.Subject}}{{if .Owner}} @{{.Owner}}{{end}}`
+	config.SetCurrent(cfg)
+
+	taskStore.Tasks[0].Owner = "alice"
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 100
+	m.height = 40
+	m.collapsedGroups = make(map[string]bool)
+	m.rebuildItems()
+	if view := m.View(); !containsStr(view, "PENDING #1 Task 1 @alice") {
+		t.Errorf("row template not used:\n%s", view)
+	}
+
+	// A broken template falls back to the columns
+	cfg.Templates.Row = `{{.Nope}`
+	if view := m.View(); !containsStr(view, "#1") || !containsStr(view, "Task 1") || containsStr(view, "PENDING") {
+		t.Errorf("broken template should fall back to the columns:\n%s", view)
+	}
+}
+
+// benchTasksModel returns a task list of 10k synthetic tasks
+func benchTasksModel(b *testing.B) TasksModel {
+	tasks, groups := data.SyntheticProject(data.SyntheticOptions{Tasks: 10000, Groups: 8, Deps: 0.1, Seed: 1})
+	dir := b.TempDir()
+	taskStore, err := data.NewTaskStoreForTest(dir, tasks)
+	if err != nil {
+		b.Fatal(err)
+	}
+	groupStore, err := data.NewGroupStoreForTest(dir, groups)
+	if err != nil {
+		b.Fatal(err)
+	}
+	m := NewTasksModel("bench", newProjectStore(taskStore, groupStore))
+	m.width = 120
+	m.height = 40
+	return m
+}
+
+func BenchmarkTasksModel_RebuildItems(b *testing.B) {
+	m := benchTasksModel(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.rebuildItems()
+	}
+}
+
+func BenchmarkTasksModel_Search(b *testing.B) {
+	m := benchTasksModel(b)
+	m.searchInput.SetValue("cache")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.rebuildItems()
+	}
+}
+
+func BenchmarkTasksModel_View(b *testing.B) {
+	m := benchTasksModel(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m.View()
+	}
+}
+
+func TestTasksModel_AuthorFilter(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	task := *taskStore.GetTask("2")
+	task.ActiveForm = "Working on task 2"
+	taskStore.UpdateTask(task)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 24
+	m.hideCompleted = false
+	if cell := taskCell(*taskStore.GetTask("2"), "subject"); cell.Text != ui.Glyphs.Agent+" Task 2" {
+		t.Errorf("agent task subject = %q, want a badge", cell.Text)
+	}
+	if cell := taskCell(*taskStore.GetTask("1"), "subject"); cell.Text != "Task 1" {
+		t.Errorf("human task subject = %q, want no badge", cell.Text)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if ids := m.FilteredTaskIDs(); len(ids) != 1 || ids[0] != "2" {
+		t.Errorf("agent filter = %v, want [2]", ids)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if ids := m.FilteredTaskIDs(); len(ids) != 3 || ids[0] != "1" || ids[1] != "3" || ids[2] != "4" {
+		t.Errorf("human filter = %v, want every task but 2", ids)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if m.authorFilter != "" || len(m.FilteredTaskIDs()) != 4 {
+		t.Error("a third press should show every task again")
+	}
+}
+
+func TestTaskCell_ChecklistProgress(t *testing.T) {
+	task := data.Task{ID: "1", Subject: "Release"}
+	data.SetTaskChecklist(&task, []data.ChecklistItem{{Text: "Tag", Done: true}, {Text: "Publish"}, {Text: "Announce"}})
+	if cell := taskCell(task, "subject"); cell.Text != "Release (1/3)" {
+		t.Errorf("subject cell = %q, want the checklist progress", cell.Text)
+	}
+}
+
+func TestTasksModel_QuickStatusFilter(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 24
+
+	for _, tt := range []struct {
+		key  rune
+		want string
+	}{{'2', "in_progress"}, {'3', "completed"}, {'1', "pending"}, {'4', "pending"}, {'0', ""}} {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{tt.key}})
+		if m.statusFilter != tt.want {
+			t.Errorf("after %c: statusFilter = %q, want %q", tt.key, m.statusFilter, tt.want)
+		}
+	}
+
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	cfg := config.Default()
+	cfg.Review.Enabled = true
+	config.SetCurrent(cfg)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'4'}})
+	if m.statusFilter != data.StatusNeedsReview {
+		t.Errorf("with the review workflow 4 should filter needs_review, got %q", m.statusFilter)
+	}
+}
+
+func TestTasksModel_FilterBar(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 24
+	key := func(k tea.KeyMsg) { m, _ = m.Update(k) }
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	key(tea.KeyMsg{Type: tea.KeyTab})
+	if !m.bar.focused || m.bar.segment != segmentStatus {
+		t.Fatal("Tab should focus the status filter")
+	}
+
+	// ↓ steps to the next status
+	key(tea.KeyMsg{Type: tea.KeyDown})
+	if m.statusFilter != "pending" {
+		t.Errorf("↓ on status: filter = %q, want pending", m.statusFilter)
+	}
+
+	// → → moves to the group filter; Enter opens its options
+	key(tea.KeyMsg{Type: tea.KeyRight})
+	key(tea.KeyMsg{Type: tea.KeyRight})
+	key(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.bar.open || m.bar.segment != segmentGroup {
+		t.Fatal("Enter should open the group options")
+	}
+	if !containsStr(m.View(), "Frontend") {
+		t.Error("the dropdown should list the groups")
+	}
+	key(tea.KeyMsg{Type: tea.KeyDown})
+	key(tea.KeyMsg{Type: tea.KeyDown})
+	key(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.bar.open || m.groupFilter != "Frontend" {
+		t.Errorf("choosing the second group: open = %v, group = %q", m.bar.open, m.groupFilter)
+	}
+
+	// Letter keys do not reach the list while the bar has focus
+	key(runes("n"))
+	if !m.bar.focused {
+		t.Error("keys should stay in the filter bar until Tab or Esc")
+	}
+	key(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.bar.focused {
+		t.Error("Esc should leave the filter bar")
+	}
+
+	// Enter on the search segment starts searching
+	key(tea.KeyMsg{Type: tea.KeyTab})
+	key(tea.KeyMsg{Type: tea.KeyRight})
+	key(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.searchActive || m.bar.focused {
+		t.Error("Enter on search should start the search input")
+	}
+}
+
+func TestTasksModel_CollapseGroups(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 24
+	groupsOnly := func() bool {
+		for _, item := range m.items {
+			if !item.isGroup {
+				return false
+			}
+		}
+		return true
+	}
+	if !groupsOnly() {
+		t.Fatal("groups should start collapsed by default")
+	}
+
+	// Z expands every group, and collapses them all again
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Z'}})
+	if groupsOnly() {
+		t.Fatal("Z should expand all groups")
+	}
+	// z on a task collapses its group and moves to the header
+	m.cursor = 1
+	group := m.items[0].groupName
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	if !m.collapsedGroups[group] || m.cursor != 0 {
+		t.Errorf("z: collapsed = %v, cursor = %d", m.collapsedGroups[group], m.cursor)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Z'}})
+	if !groupsOnly() {
+		t.Error("Z with some groups expanded should collapse them all")
+	}
+
+	prev := config.Current()
+	t.Cleanup(func() { config.SetCurrent(prev) })
+	cfg := config.Default()
+	cfg.TaskList.Groups = "expanded"
+	cfg.TaskList.ProjectGroups = map[string]string{"big": "collapsed"}
+	config.SetCurrent(cfg)
+	if m := NewTasksModel("test", newProjectStore(taskStore, groupStore)); len(m.collapsedGroups) != 0 {
+		t.Error("groups should start expanded when configured")
+	}
+	if m := NewTasksModel("big", newProjectStore(taskStore, groupStore)); len(m.collapsedGroups) == 0 {
+		t.Error("the per-project setting should override the default")
+	}
+}
+
+func TestTasksModel_ManualOrder(t *testing.T) {
+	taskStore, groupStore, tmpDir := setupTestTasks(t)
+	defer os.RemoveAll(tmpDir)
+
+	m := NewTasksModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 80
+	m.height = 24
+	m.hideCompleted = false
+	m.collapsedGroups = map[string]bool{}
+	m.rebuildItems()
+
+	// Backend lists #1 then #3; move #3 above #1
+	order := func() string {
+		var ids string
+		for _, item := range m.items {
+			if item.task != nil {
+				ids += item.task.ID
+			}
+		}
+		return ids
+	}
+	if got := order(); got != "1324" {
+		t.Fatalf("initial order = %s", got)
+	}
+	m.cursor = 2 // #3
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	if m.sortMode != "manual" || order() != "3124" {
+		t.Errorf("after K: sort %q, order %s, want manual 3124", m.sortMode, order())
+	}
+	if task := m.currentTask(); task == nil || task.ID != "3" {
+		t.Error("the cursor should follow the moved task")
+	}
+
+	// Moves stop at the group header
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	if order() != "3124" {
+		t.Errorf("moving past the group header changed the order to %s", order())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	if order() != "1324" {
+		t.Errorf("after shift+down: order %s, want 1324", order())
+	}
+	if data.GetTaskOrder(*taskStore.GetTask("3")) != 2 {
+		t.Error("the manual order should be stored in metadata")
+	}
+}
@@ -0,0 +1,128 @@
+package model
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/muesli/reflow/truncate"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// taskTemplateData is what a configured row or detail template sees as "."
+type taskTemplateData struct {
+	ID          string // display ID, without "#"
+	Subject     string
+	Description string
+	ActiveForm  string
+	Status      string // pending, in_progress or completed
+	StatusLabel string // translated status
+	Icon        string // status icon
+	Blocked     string // reason the task is flagged blocked, if any
+	Agent       bool   // managed by an agent rather than a person
+	Checklist   string // checklist progress, e.g. "3/5"
+	Group       string
+	Owner       string
+	Priority    string
+	Start       string
+	Due         string
+	Milestone   string
+	Estimate    string
+	Tags        []string
+	Blocks      []string
+	BlockedBy   []string
+}
+
+// newTaskTemplateData collects a task's fields for a template
+func newTaskTemplateData(task data.Task) taskTemplateData {
+	d := taskTemplateData{
+		ID:          data.DisplayID(task),
+		Subject:     task.Subject,
+		Description: task.Description,
+		ActiveForm:  task.ActiveForm,
+		Status:      task.Status,
+		StatusLabel: i18n.T(task.Status),
+		Icon:        ui.StatusIcon(task.Status),
+		Blocked:     data.GetTaskBlockedReason(task),
+		Agent:       data.IsAgentTask(task),
+		Checklist:   checklistProgress(task),
+		Owner:       task.Owner,
+		Priority:    data.GetTaskPriority(task),
+		Start:       data.GetTaskStart(task),
+		Due:         data.GetTaskDue(task),
+		Milestone:   data.GetTaskMilestone(task),
+		Tags:        data.GetTaskTags(task),
+		Blocks:      task.Blocks,
+		BlockedBy:   task.BlockedBy,
+	}
+	if group := data.GetTaskGroup(task); group != "" {
+		d.Group = displayGroupName(group)
+	}
+	if estimate := data.GetTaskEstimate(task); estimate > 0 {
+		d.Estimate = data.FormatEstimate(estimate)
+	}
+	return d
+}
+
+// templateFuncs are the functions available to row and detail templates
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"t":     i18n.T,
+	"pad":   func(width int, s string) string { return ui.PadRight(s, width) },
+	"trunc": func(width int, s string) string { return ui.Truncate(s, width) },
+	"muted": func(s string) string { return ui.MutedStyle.Render(s) },
+	"label": func(s string) string { return ui.LabelStyle.Render(s) },
+	"status": func(status, s string) string {
+		return ui.GetStatusStyle(status).Render(s)
+	},
+}
+
+// compiledTemplates caches parsed templates by source; nil marks a template
+// that failed to parse
+var compiledTemplates sync.Map
+
+// executeTaskTemplate renders a task with a configured template. ok is false
+// when the template is empty or broken, and the caller falls back to the
+// built-in layout (errors go to the debug log).
+func executeTaskTemplate(source string, task data.Task) (out string, ok bool) {
+	if source == "" {
+		return "", false
+	}
+	cached, found := compiledTemplates.Load(source)
+	if !found {
+		tmpl, err := template.New("task").Funcs(templateFuncs).Parse(source)
+		if err != nil {
+			slog.Debug("invalid task template", "err", err)
+			tmpl = nil
+		}
+		compiledTemplates.Store(source, tmpl)
+		cached = tmpl
+	}
+	tmpl := cached.(*template.Template)
+	if tmpl == nil {
+		return "", false
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, newTaskTemplateData(task)); err != nil {
+		slog.Debug("task template failed", "task", task.ID, "err", err)
+		return "", false
+	}
+	return b.String(), true
+}
+
+// renderTemplateRow renders a task row with a row template, on one line and
+// at most width columns wide
+func renderTemplateRow(source string, task data.Task, width int) (string, bool) {
+	out, ok := executeTaskTemplate(source, task)
+	if !ok {
+		return "", false
+	}
+	out = strings.TrimRight(strings.ReplaceAll(out, "\n", " "), " ")
+	return truncate.StringWithTail(out, uint(max(width, 0)), "..."), true
+}
@@ -0,0 +1,339 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// TimelineModel handles the Gantt-style timeline screen
+type TimelineModel struct {
+	projectName string
+	store       *projectStore
+	width       int
+	height      int
+
+	rows       []timelineRow
+	undated    int
+	overlaps   map[string]bool
+	violations map[string]bool
+
+	// Visible window
+	viewStart    time.Time // first visible day
+	scrollOffset int       // first visible row
+	today        time.Time
+}
+
+// timelineRow is a group header or a scheduled task
+type timelineRow struct {
+	isGroup bool
+	group   string
+	task    data.Task
+	start   time.Time
+	end     time.Time
+}
+
+// NewTimelineModel creates a new TimelineModel
+func NewTimelineModel(projectName string, store *projectStore) TimelineModel {
+	now := time.Now()
+	m := TimelineModel{
+		projectName: projectName,
+		store:       store,
+		today:       time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local),
+	}
+	m.buildRows()
+	return m
+}
+
+// buildRows groups scheduled tasks in group order, sorted by start date
+func (m *TimelineModel) buildRows() {
+	m.rows = nil
+	m.undated = 0
+	m.overlaps, m.violations = data.ScheduleIssues(m.store.tasks.Tasks)
+
+	byGroup := make(map[string][]timelineRow)
+	var earliest time.Time
+	for _, task := range m.store.tasks.Tasks {
+		start, end, ok := data.TaskSpan(task)
+		if !ok {
+			m.undated++
+			continue
+		}
+		group := data.GetTaskGroup(task)
+		if group == "" {
+			group = "Uncategorized"
+		}
+		byGroup[group] = append(byGroup[group], timelineRow{group: group, task: task, start: start, end: end})
+		if earliest.IsZero() || start.Before(earliest) {
+			earliest = start
+		}
+	}
+
+	groupNames := m.store.groups.GetGroupNames()
+	var extra []string
+	for name := range byGroup {
+		if m.store.groups.GetGroup(name) == nil {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	groupNames = append(groupNames, extra...)
+
+	for _, name := range groupNames {
+		rows := byGroup[name]
+		if len(rows) == 0 {
+			continue
+		}
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].start.Before(rows[j].start)
+		})
+		m.rows = append(m.rows, timelineRow{isGroup: true, group: name})
+		m.rows = append(m.rows, rows...)
+	}
+
+	// Start the view a day before the earliest task, or at today
+	m.viewStart = m.today.AddDate(0, 0, -1)
+	if !earliest.IsZero() {
+		m.viewStart = earliest.AddDate(0, 0, -1)
+	}
+}
+
+// Init initializes the model
+func (m TimelineModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m TimelineModel) Update(msg tea.Msg) (TimelineModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.scroll(-3)
+		case tea.MouseButtonWheelDown:
+			m.scroll(3)
+		case tea.MouseButtonWheelLeft:
+			m.viewStart = m.viewStart.AddDate(0, 0, -3)
+		case tea.MouseButtonWheelRight:
+			m.viewStart = m.viewStart.AddDate(0, 0, 3)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			m.scroll(-1)
+		case "down", "j":
+			m.scroll(1)
+		case "left", "h":
+			m.viewStart = m.viewStart.AddDate(0, 0, -1)
+		case "right", "l":
+			m.viewStart = m.viewStart.AddDate(0, 0, 1)
+		case "H", "pgup":
+			m.viewStart = m.viewStart.AddDate(0, 0, -7)
+		case "L", "pgdown":
+			m.viewStart = m.viewStart.AddDate(0, 0, 7)
+		case "t":
+			m.viewStart = m.today.AddDate(0, 0, -3)
+		case "esc", "T":
+			return m, func() tea.Msg {
+				return BackToTasksMsg{}
+			}
+		case "q":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// viewportHeight returns the number of rows that fit on screen
+func (m TimelineModel) viewportHeight() int {
+	// header (3) + axis (2) + scroll indicators (2) + legend (2) + footer (3)
+	vh := m.height - 12
+	if vh < 5 {
+		vh = 5
+	}
+	return vh
+}
+
+// scroll moves the row offset by delta, clamped to valid bounds
+func (m *TimelineModel) scroll(delta int) {
+	m.scrollOffset += delta
+	maxOff := len(m.rows) - m.viewportHeight()
+	if maxOff < 0 {
+		maxOff = 0
+	}
+	if m.scrollOffset > maxOff {
+		m.scrollOffset = maxOff
+	}
+	if m.scrollOffset < 0 {
+		m.scrollOffset = 0
+	}
+}
+
+// labelWidth returns the width of the task label column
+func (m TimelineModel) labelWidth() int {
+	w := m.width / 3
+	if w > 32 {
+		w = 32
+	}
+	if w < 16 {
+		w = 16
+	}
+	return w
+}
+
+// chartDays returns the number of visible days (one column per day)
+func (m TimelineModel) chartDays() int {
+	days := m.width - m.labelWidth() - 2
+	if days < 10 {
+		days = 10
+	}
+	return days
+}
+
+// padLabel truncates and pads a label to the label column width
+func (m TimelineModel) padLabel(s string) string {
+	w := m.labelWidth()
+	return ui.PadRight(ui.Truncate(s, w), w)
+}
+
+// renderAxis renders the date labels (every Monday) and the tick line
+func (m TimelineModel) renderAxis() string {
+	days := m.chartDays()
+	labels := []rune(strings.Repeat(" ", days+6))
+	ticks := make([]string, days)
+	for i := 0; i < days; i++ {
+		day := m.viewStart.AddDate(0, 0, i)
+		switch {
+		case day.Equal(m.today):
+			ticks[i] = ui.WarningStyle.Render(ui.Glyphs.Expanded)
+		case day.Weekday() == time.Monday:
+			ticks[i] = ui.MutedStyle.Render("|")
+		default:
+			ticks[i] = ui.MutedStyle.Render(ui.Glyphs.Tick)
+		}
+		if day.Weekday() == time.Monday || i == 0 {
+			copy(labels[i:], []rune(day.Format("01/02")))
+		}
+	}
+	padding := strings.Repeat(" ", m.labelWidth()+1)
+	labelLine := string(labels[:days])
+	return padding + ui.MutedStyle.Render(labelLine) + "\n" + padding + strings.Join(ticks, "")
+}
+
+// renderBar renders a task's bar across the visible days
+func (m TimelineModel) renderBar(row timelineRow) string {
+	style := ui.GetStatusStyle(row.task.Status)
+	if m.overlaps[row.task.ID] {
+		style = ui.WarningStyle
+	}
+	if m.violations[row.task.ID] {
+		style = ui.ErrorStyle
+	}
+
+	days := m.chartDays()
+	viewEnd := m.viewStart.AddDate(0, 0, days-1)
+	var b strings.Builder
+	for i := 0; i < days; i++ {
+		day := m.viewStart.AddDate(0, 0, i)
+		inSpan := !day.Before(row.start) && !day.After(row.end)
+		switch {
+		case inSpan && i == 0 && row.start.Before(m.viewStart):
+			b.WriteString(style.Render(ui.Glyphs.Left))
+		case inSpan && i == days-1 && row.end.After(viewEnd):
+			b.WriteString(style.Render(ui.Glyphs.Right))
+		case inSpan:
+			b.WriteString(style.Render(ui.Glyphs.Block))
+		case day.Equal(m.today):
+			b.WriteString(ui.MutedStyle.Render(ui.Glyphs.VLine))
+		default:
+			b.WriteString(" ")
+		}
+	}
+	return b.String()
+}
+
+// View renders the timeline screen
+func (m TimelineModel) View() string {
+	var b strings.Builder
+
+	// Header
+	title := i18n.Tf("Timeline: %s", m.projectName)
+	b.WriteString(ui.Header(title, m.width))
+	b.WriteString("\n\n")
+
+	if len(m.rows) == 0 {
+		b.WriteString(ui.MutedStyle.Render(i18n.T("No scheduled tasks.")))
+		b.WriteString("\n")
+		b.WriteString(ui.MutedStyle.Render(i18n.T("Set start/due dates with batch edit (B) to show tasks here.")))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(m.renderAxis())
+		b.WriteString("\n")
+
+		vh := m.viewportHeight()
+		endIdx := m.scrollOffset + vh
+		if endIdx > len(m.rows) {
+			endIdx = len(m.rows)
+		}
+
+		if m.scrollOffset > 0 {
+			b.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Up + " " + i18n.Tf("%d more above", m.scrollOffset)))
+			b.WriteString("\n")
+		}
+
+		for _, row := range m.rows[m.scrollOffset:endIdx] {
+			if row.isGroup {
+				color := m.store.groups.GetGroupColor(row.group)
+				b.WriteString(ui.GroupBadge(displayGroupName(row.group), color))
+				b.WriteString("\n")
+				continue
+			}
+			marker := " "
+			if m.violations[row.task.ID] {
+				marker = ui.ErrorStyle.Render(ui.Glyphs.Cross)
+			} else if m.overlaps[row.task.ID] {
+				marker = ui.WarningStyle.Render("!")
+			}
+			label := m.padLabel(fmt.Sprintf("%s #%s %s", ui.StatusIcon(row.task.Status), data.DisplayID(row.task), row.task.Subject))
+			b.WriteString(marker + label + " " + m.renderBar(row))
+			b.WriteString("\n")
+		}
+
+		if remaining := len(m.rows) - endIdx; remaining > 0 {
+			b.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Down + " " + i18n.Tf("%d more below", remaining)))
+			b.WriteString("\n")
+		}
+	}
+
+	// Legend
+	b.WriteString("\n")
+	legend := i18n.Tf("%s owner overlap  %s starts before a blocker ends  %s today",
+		ui.WarningStyle.Render("!"), ui.ErrorStyle.Render(ui.Glyphs.Cross), ui.WarningStyle.Render(ui.Glyphs.Expanded))
+	if m.undated > 0 {
+		legend += ui.MutedStyle.Render("  (" + i18n.Tf("%d task(s) without dates", m.undated) + ")")
+	}
+	b.WriteString(legend)
+	b.WriteString("\n")
+
+	// Footer
+	b.WriteString("\n")
+	keys := [][]string{
+		{"↑↓", "Scroll"},
+		{"←→", "Day"},
+		{"H/L", "Week"},
+		{"t", "Today"},
+		{"Esc", "Back"},
+	}
+	b.WriteString(ui.Footer(keys, m.width))
+
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+func TestTimelineModel_View(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "cctasks-timeline-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tasks := []data.Task{
+		{ID: "1", Subject: "Design", Status: "completed", Blocks: []string{"2"}, BlockedBy: []string{},
+			Metadata: map[string]interface{}{"startDate": "2025-03-03", "dueDate": "2025-03-07"}},
+		{ID: "2", Subject: "Build", Status: "pending", Blocks: []string{}, BlockedBy: []string{"1"},
+			Metadata: map[string]interface{}{"startDate": "2025-03-05", "dueDate": "2025-03-10", "group": "Backend"}},
+		{ID: "3", Subject: "Someday", Status: "pending", Blocks: []string{}, BlockedBy: []string{}},
+	}
+	taskStore, err := data.NewTaskStoreForTest(tmpDir, tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupStore, err := data.NewGroupStoreForTest(tmpDir, []data.TaskGroup{{Name: "Backend"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewTimelineModel("test", newProjectStore(taskStore, groupStore))
+	m.width = 100
+	m.height = 30
+
+	if len(m.rows) != 4 {
+		t.Fatalf("Expected 2 group rows and 2 task rows, got %d", len(m.rows))
+	}
+	if m.rows[0].group != "Backend" {
+		t.Errorf("Expected configured group first, got %s", m.rows[0].group)
+	}
+
+	view := m.View()
+	for _, want := range []string{"#1 Design", "#2 Build", "█", "✗", "1 task(s) without dates"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected view to contain %q", want)
+		}
+	}
+
+	// Scrolling right moves the window
+	start := m.viewStart
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if !m.viewStart.Equal(start.AddDate(0, 0, 1)) {
+		t.Errorf("Expected view to move one day right")
+	}
+}
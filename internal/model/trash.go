@@ -0,0 +1,274 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// TrashModel handles the trash screen
+type TrashModel struct {
+	projectName string
+	store       *projectStore
+	items       []data.TrashItem
+	cursor      int
+	width       int
+	height      int
+
+	// Scrolling
+	scrollOffset int
+
+	// Purge confirmation
+	confirmPurge bool
+
+	// Last action result
+	message string
+	err     error
+}
+
+// NewTrashModel creates a new TrashModel
+func NewTrashModel(projectName string, store *projectStore) TrashModel {
+	m := TrashModel{
+		projectName: projectName,
+		store:       store,
+	}
+	m.reload()
+	return m
+}
+
+// reload re-reads the trash folder
+func (m *TrashModel) reload() {
+	items, err := m.store.tasks.ListTrash()
+	if err != nil {
+		m.err = err
+		items = []data.TrashItem{}
+	}
+	m.items = items
+	if m.cursor >= len(m.items) {
+		m.cursor = len(m.items) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.ensureVisible()
+}
+
+// Init initializes the model
+func (m TrashModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m TrashModel) Update(msg tea.Msg) (TrashModel, tea.Cmd) {
+	// Purge confirmation mode
+	if m.confirmPurge {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			switch msg.String() {
+			case "y", "Y":
+				item := m.items[m.cursor]
+				if err := m.store.tasks.PurgeFromTrash(item); err != nil {
+					m.err = err
+				} else {
+					m.err = nil
+					m.message = i18n.Tf("Permanently deleted #%s", data.DisplayID(item.Task))
+				}
+				m.confirmPurge = false
+				m.reload()
+			case "n", "N", "esc":
+				m.confirmPurge = false
+			}
+		}
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.moveCursor(-1)
+		case tea.MouseButtonWheelDown:
+			m.moveCursor(1)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			m.moveCursor(-1)
+		case "down", "j":
+			m.moveCursor(1)
+		case "home":
+			m.cursor = 0
+			m.ensureVisible()
+		case "end":
+			m.moveCursor(len(m.items))
+		case "r", "enter":
+			if len(m.items) > 0 {
+				item := m.items[m.cursor]
+				id, err := m.store.tasks.RestoreFromTrash(item)
+				if err != nil {
+					m.err = err
+				} else {
+					m.err = nil
+					if id != item.Task.ID {
+						m.message = i18n.Tf("Restored #%s as #%s", item.Task.ID, id)
+					} else {
+						m.message = i18n.Tf("Restored #%s", id)
+					}
+				}
+				m.reload()
+			}
+		case "d", "delete":
+			if len(m.items) > 0 {
+				m.confirmPurge = true
+			}
+		case "esc", "left", "D":
+			return m, func() tea.Msg {
+				return BackToTasksMsg{}
+			}
+		case "q":
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// viewportHeight returns the number of items that fit on screen
+func (m TrashModel) viewportHeight() int {
+	// header (3) + summary (2) + scroll indicators (2) + message (2) + footer (3)
+	vh := m.height - 12
+	if vh < 5 {
+		vh = 5
+	}
+	return vh
+}
+
+// moveCursor moves the cursor by delta, clamped to the list
+func (m *TrashModel) moveCursor(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.items) {
+		m.cursor = len(m.items) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.ensureVisible()
+}
+
+// ensureVisible adjusts the scroll offset so the cursor is on screen
+func (m *TrashModel) ensureVisible() {
+	vh := m.viewportHeight()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+vh {
+		m.scrollOffset = m.cursor - vh + 1
+	}
+	if m.scrollOffset < 0 {
+		m.scrollOffset = 0
+	}
+}
+
+// View renders the trash screen
+func (m TrashModel) View() string {
+	var b strings.Builder
+
+	// Header
+	title := i18n.Tf("Trash: %s", m.projectName)
+	b.WriteString(ui.Header(title, m.width))
+	b.WriteString("\n\n")
+
+	if m.confirmPurge && m.cursor < len(m.items) {
+		item := m.items[m.cursor]
+		dialog := ui.Confirm(
+			i18n.T("Delete Permanently"),
+			i18n.Tf("Permanently delete task #%s?\n\"%s\"\nThis cannot be undone.", data.DisplayID(item.Task), item.Task.Subject),
+			"y", "n",
+		)
+		b.WriteString(dialog)
+		b.WriteString("\n\n")
+	}
+
+	if len(m.items) == 0 {
+		b.WriteString(ui.MutedStyle.Render(i18n.T("Trash is empty.")))
+		b.WriteString("\n")
+	} else {
+		summary := i18n.Tf("%d deleted task(s)", len(m.items))
+		if days := config.Current().Trash.RetentionDays; days > 0 {
+			summary += " " + ui.Glyphs.Tick + " " + i18n.Tf("purged after %d days", days)
+		}
+		b.WriteString(ui.MutedStyle.Render(summary))
+		b.WriteString("\n\n")
+
+		vh := m.viewportHeight()
+		endIdx := m.scrollOffset + vh
+		if endIdx > len(m.items) {
+			endIdx = len(m.items)
+		}
+
+		if m.scrollOffset > 0 {
+			b.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Up + " " + i18n.Tf("%d more above", m.scrollOffset)))
+			b.WriteString("\n")
+		}
+
+		for i := m.scrollOffset; i < endIdx; i++ {
+			item := m.items[i]
+			deleted := item.DeletedAt.Format("2006-01-02 15:04")
+			id := fmt.Sprintf("#%s", data.DisplayID(item.Task))
+			maxSubjectLen := m.width - lipgloss.Width(id) - len(deleted) - 10
+			if maxSubjectLen < 20 {
+				maxSubjectLen = 20
+			}
+			line := fmt.Sprintf("%s %s %s  %s",
+				ui.StatusIcon(item.Task.Status),
+				ui.MutedStyle.Render(id),
+				ui.Truncate(item.Task.Subject, maxSubjectLen),
+				ui.MutedStyle.Render(deleted),
+			)
+			if i == m.cursor {
+				b.WriteString(ui.TaskSelectedStyle.Render(ui.Glyphs.Cursor + " " + line))
+			} else {
+				b.WriteString("  " + line)
+			}
+			b.WriteString("\n")
+		}
+
+		if remaining := len(m.items) - endIdx; remaining > 0 {
+			b.WriteString(ui.MutedStyle.Render("  " + ui.Glyphs.Down + " " + i18n.Tf("%d more below", remaining)))
+			b.WriteString("\n")
+		}
+	}
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(ui.ErrorStyle.Render(i18n.Tf("Error: %v", m.err)))
+		b.WriteString("\n")
+	} else if m.message != "" {
+		b.WriteString("\n")
+		b.WriteString(ui.SuccessStyle.Render(m.message))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	hasItems := len(m.items) > 0
+	hints := []ui.KeyHint{
+		{Key: "↑↓", Desc: "Navigate", Enabled: hasItems},
+		{Key: "r", Desc: "Restore", Enabled: hasItems},
+		{Key: "d", Desc: "Delete forever", Enabled: hasItems},
+		{Key: "Esc", Desc: "Back", Enabled: true},
+		{Key: "q", Desc: "Quit", Enabled: true},
+	}
+	b.WriteString(ui.FooterWithHints(hints, m.width))
+
+	return b.String()
+}
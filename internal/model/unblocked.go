@@ -0,0 +1,74 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/ui"
+)
+
+// unblockedPrompt tells which tasks completing a task unblocked (open tasks
+// that were waiting only for it) and offers to open one of them: 1-9 open
+// the numbered task, Enter the first, and any other key dismisses it.
+type unblockedPrompt struct {
+	ids []string
+}
+
+// open shows the prompt for the tasks freed by completing a task; nothing
+// is shown when none were freed
+func (p *unblockedPrompt) open(freed []string) {
+	p.ids = freed
+}
+
+// active reports whether the prompt is shown
+func (p unblockedPrompt) active() bool {
+	return len(p.ids) > 0
+}
+
+// update handles a key while the prompt is shown. The prompt closes on any
+// key; id is the task to open, and handled is false when the key should
+// still do what it normally does.
+func (p unblockedPrompt) update(msg tea.KeyMsg) (next unblockedPrompt, id string, handled bool) {
+	ids := p.ids
+	key := msg.String()
+	switch {
+	case key == "enter":
+		return unblockedPrompt{}, ids[0], true
+	case key == "esc":
+		return unblockedPrompt{}, "", true
+	case len(key) == 1 && key[0] >= '1' && key[0] <= '9':
+		if n := int(key[0] - '0'); n <= len(ids) {
+			return unblockedPrompt{}, ids[n-1], true
+		}
+	}
+	return unblockedPrompt{}, "", false
+}
+
+// unblockedSubjectWidth is how much of each unblocked task's subject is shown
+const unblockedSubjectWidth = 24
+
+// view renders the prompt on one line at most width columns wide
+func (p unblockedPrompt) view(store *data.TaskStore, width int) string {
+	refs := make([]string, 0, len(p.ids))
+	for i, id := range p.ids {
+		ref := "#" + store.DisplayRef(id)
+		if task := store.GetTask(id); task != nil {
+			ref += " " + ui.Truncate(task.Subject, unblockedSubjectWidth)
+		}
+		if len(p.ids) > 1 && i < 9 {
+			ref = fmt.Sprintf("[%d] %s", i+1, ref)
+		}
+		refs = append(refs, ref)
+	}
+	hint := i18n.T("[Enter] open  [Esc] dismiss")
+	if len(p.ids) > 1 {
+		hint = i18n.Tf("[1-%d/Enter] open  [Esc] dismiss", min(len(p.ids), 9))
+	}
+	text := ui.Glyphs.Check + " " + i18n.Tf("Unblocked %s", strings.Join(refs, ", "))
+	text = ui.Truncate(text, max(width-len(hint)-2, 20))
+	return ui.SuccessStyle.Render(text) + "  " + ui.MutedStyle.Render(hint)
+}
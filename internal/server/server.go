@@ -0,0 +1,116 @@
+// Package server implements serve mode: a small HTTP server exposing
+// cctasks data to other tools, such as an iCalendar feed of due dates.
+package server
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// Options configures serve mode
+type Options struct {
+	Token        string // when set, calendar feeds need ?token=<Token>
+	SlackSecret  string // Slack signing secret; enables POST /slack
+	SlackProject string // project of Slack commands that name none
+}
+
+// server holds the options shared by the handlers
+type server struct {
+	opts Options
+}
+
+// Handler returns the HTTP handler of serve mode:
+//
+//	GET  /calendar.ics             due dates of all (unarchived) projects
+//	GET  /calendar/<project>.ics   due dates of one project
+//	POST /slack                    Slack slash commands (signed requests only)
+//
+// Calendar feeds accept ?kind=todo for VTODO entries instead of events.
+func Handler(opts Options) http.Handler {
+	s := &server{opts: opts}
+	mux := http.NewServeMux()
+	mux.Handle("/calendar.ics", withToken(opts.Token, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveCalendar(w, r, "")
+	})))
+	mux.Handle("/calendar/", withToken(opts.Token, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		project := strings.TrimPrefix(r.URL.Path, "/calendar/")
+		if !strings.HasSuffix(project, ".ics") {
+			http.NotFound(w, r)
+			return
+		}
+		serveCalendar(w, r, strings.TrimSuffix(project, ".ics"))
+	})))
+	mux.HandleFunc("/slack", s.handleSlack)
+	return mux
+}
+
+// withToken rejects requests without the token (if one is configured)
+func withToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// serveCalendar writes the iCalendar feed of a project ("" for all projects)
+func serveCalendar(w http.ResponseWriter, r *http.Request, project string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	kind := r.URL.Query().Get("kind")
+	if kind == "" {
+		kind = data.ICalEvent
+	}
+	tasks, err := CalendarTasks(project)
+	if err != nil {
+		slog.Debug("calendar feed failed", "project", project, "err", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if err := data.WriteICal(w, tasks, kind, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
+
+// CalendarTasks loads the tasks of a project, or of all unarchived projects
+// when project is ""
+func CalendarTasks(project string) ([]data.ProjectTask, error) {
+	if project != "" {
+		store, err := data.LoadTasks(project)
+		if err != nil {
+			return nil, err
+		}
+		tasks := make([]data.ProjectTask, len(store.Tasks))
+		for i, task := range store.Tasks {
+			tasks[i] = data.ProjectTask{Project: project, Task: task}
+		}
+		return tasks, nil
+	}
+
+	projects, err := data.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	state, _ := config.LoadState() // invalid state shows every project
+	var active []data.Project
+	for _, p := range projects {
+		if !state.IsArchived(p.Name) {
+			active = append(active, p)
+		}
+	}
+	return data.LoadAllTasks(active)
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/testutil"
+)
+
+func setupProjects(t *testing.T) {
+	t.Helper()
+	tasksDir := testutil.IsolateHome(t)
+
+	for project, subject := range map[string]string{"web": "Launch site", "api": "Freeze API"} {
+		dir := filepath.Join(tasksDir, project)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		tasks := []data.Task{{ID: "1", Subject: subject, Status: "pending",
+			Metadata: map[string]interface{}{"dueDate": "2026-03-01"}}}
+		if _, err := data.NewTaskStoreForTest(dir, tasks); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func get(t *testing.T, h http.Handler, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestHandler_Calendar(t *testing.T) {
+	setupProjects(t)
+	h := Handler(Options{})
+
+	rec := get(t, h, "/calendar.ics")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/calendar") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "Launch site") || !strings.Contains(body, "Freeze API") {
+		t.Errorf("feed should include every project:\n%s", body)
+	}
+
+	body = get(t, h, "/calendar/web.ics?kind=todo").Body.String()
+	if !strings.Contains(body, "BEGIN:VTODO") || strings.Contains(body, "Freeze API") {
+		t.Errorf("project feed should only include web todos:\n%s", body)
+	}
+
+	if rec := get(t, h, "/calendar/web.txt"); rec.Code != http.StatusNotFound {
+		t.Errorf("non-.ics path status = %d, want 404", rec.Code)
+	}
+	if rec := get(t, h, "/calendar.ics?kind=journal"); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown kind status = %d, want 400", rec.Code)
+	}
+}
+
+func TestHandler_Token(t *testing.T) {
+	setupProjects(t)
+	h := Handler(Options{Token: "s3cret"})
+
+	if rec := get(t, h, "/calendar.ics"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("status without token = %d, want 401", rec.Code)
+	}
+	if rec := get(t, h, "/calendar.ics?token=s3cret"); rec.Code != http.StatusOK {
+		t.Errorf("status with token = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,298 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+)
+
+// slackMaxAge is how old a signed Slack request may be (replay protection)
+const slackMaxAge = 5 * time.Minute
+
+// slackMaxTasks limits the tasks listed in one response (Slack allows 50 blocks)
+const slackMaxTasks = 40
+
+// slackUsage is shown for "help" and unknown commands
+const slackUsage = "*Usage*\n" +
+	"`/cctasks list [project]` open tasks\n" +
+	"`/cctasks show [project] <id>` task details\n" +
+	"`/cctasks start [project] <id>` mark a task in_progress\n" +
+	"`/cctasks done [project] <id>` mark a task completed"
+
+// slackResponse is a slash command response message
+type slackResponse struct {
+	ResponseType string       `json:"response_type"` // "ephemeral" (only the caller) or "in_channel"
+	Text         string       `json:"text"`          // fallback for notifications
+	Blocks       []slackBlock `json:"blocks,omitempty"`
+}
+
+// slackBlock is a Block Kit layout block
+type slackBlock struct {
+	Type     string      `json:"type"` // "section", "context" or "divider"
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+// slackText is a Block Kit text object
+type slackText struct {
+	Type string `json:"type"` // "mrkdwn"
+	Text string `json:"text"`
+}
+
+func mrkdwn(text string) *slackText {
+	return &slackText{Type: "mrkdwn", Text: text}
+}
+
+// handleSlack serves Slack slash commands (POST /slack)
+func (s *server) handleSlack(w http.ResponseWriter, r *http.Request) {
+	if s.opts.SlackSecret == "" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := verifySlackSignature(s.opts.SlackSecret, r.Header, body, time.Now()); err != nil {
+		slog.Debug("slack request rejected", "err", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	text := form.Get("text")
+	slog.Debug("slack command", "user", form.Get("user_name"), "text", text)
+	resp := s.runSlackCommand(strings.Fields(text), form.Get("user_name"))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// verifySlackSignature checks the X-Slack-Signature of a request body
+// against the signing secret (https://api.slack.com/authentication/verifying-requests-from-slack)
+func verifySlackSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	ts := header.Get("X-Slack-Request-Timestamp")
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("missing request timestamp")
+	}
+	if age := now.Sub(time.Unix(sec, 0)); age > slackMaxAge || age < -slackMaxAge {
+		return fmt.Errorf("stale request timestamp")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:%s", ts, body)
+	want := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(header.Get("X-Slack-Signature")), []byte(want)) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// runSlackCommand runs a slash command and builds its response
+func (s *server) runSlackCommand(args []string, user string) slackResponse {
+	if len(args) == 0 || args[0] == "help" {
+		return slackMessage(slackUsage)
+	}
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "list":
+		if len(args) > 1 {
+			return slackMessage(slackUsage)
+		}
+		project, err := s.slackProject(args)
+		if err != nil {
+			return slackMessage(err.Error())
+		}
+		return slackTaskList(project)
+	case "show", "start", "done":
+		if len(args) == 0 || len(args) > 2 {
+			return slackMessage(slackUsage)
+		}
+		project, err := s.slackProject(args[:len(args)-1])
+		if err != nil {
+			return slackMessage(err.Error())
+		}
+		ref := strings.TrimPrefix(args[len(args)-1], "#")
+		if cmd == "show" {
+			return slackTaskDetail(project, ref)
+		}
+		status := "completed"
+		if cmd == "start" {
+			status = "in_progress"
+		}
+		return slackSetStatus(project, ref, status, user)
+	}
+	return slackMessage(fmt.Sprintf("Unknown command `%s`\n%s", cmd, slackUsage))
+}
+
+// slackProject returns the named project, or the default one: the serve
+// --project flag, or the only unarchived project
+func (s *server) slackProject(args []string) (string, error) {
+	projects, err := data.ListProjects()
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 1 {
+		for _, p := range projects {
+			if p.Name == args[0] {
+				return p.Name, nil
+			}
+		}
+		return "", fmt.Errorf("No project `%s`", args[0])
+	}
+	if s.opts.SlackProject != "" {
+		return s.opts.SlackProject, nil
+	}
+	state, _ := config.LoadState() // invalid state shows every project
+	var active []string
+	for _, p := range projects {
+		if !state.IsArchived(p.Name) {
+			active = append(active, p.Name)
+		}
+	}
+	if len(active) != 1 {
+		return "", fmt.Errorf("Which project? Projects: %s", strings.Join(active, ", "))
+	}
+	return active[0], nil
+}
+
+// slackTaskList lists a project's open tasks, in progress first
+func slackTaskList(project string) slackResponse {
+	store, err := data.LoadTasks(project)
+	if err != nil {
+		return slackMessage(err.Error())
+	}
+	var open []data.Task
+	for _, status := range []string{"in_progress", "pending"} {
+		open = append(open, store.GetTasksByStatus(status)...)
+	}
+	completed := len(store.Tasks) - len(open)
+
+	header := fmt.Sprintf("*%s* — %d open, %d completed", escapeSlack(project), len(open), completed)
+	resp := slackResponse{
+		ResponseType: "ephemeral",
+		Text:         fmt.Sprintf("%s: %d open tasks", project, len(open)),
+		Blocks:       []slackBlock{{Type: "section", Text: mrkdwn(header)}, {Type: "divider"}},
+	}
+	if len(open) == 0 {
+		resp.Blocks = append(resp.Blocks, slackBlock{Type: "section", Text: mrkdwn("No open tasks :tada:")})
+		return resp
+	}
+	for i, task := range open {
+		if i == slackMaxTasks {
+			resp.Blocks = append(resp.Blocks, slackBlock{Type: "context",
+				Elements: []slackText{*mrkdwn(fmt.Sprintf("and %d more", len(open)-i))}})
+			break
+		}
+		resp.Blocks = append(resp.Blocks, slackBlock{Type: "section", Text: mrkdwn(slackTaskLine(task))})
+	}
+	return resp
+}
+
+// slackTaskLine formats a task as "icon *#12* Subject  _group · owner_"
+func slackTaskLine(task data.Task) string {
+	line := fmt.Sprintf("%s *#%s* %s", data.StatusIcon(task.Status), data.DisplayID(task), escapeSlack(task.Subject))
+	var meta []string
+	for _, v := range []string{data.GetTaskGroup(task), task.Owner, data.GetTaskDue(task)} {
+		if v != "" {
+			meta = append(meta, escapeSlack(v))
+		}
+	}
+	if len(meta) > 0 {
+		line += "  _" + strings.Join(meta, " · ") + "_"
+	}
+	return line
+}
+
+// slackTaskDetail shows one task
+func slackTaskDetail(project, ref string) slackResponse {
+	store, err := data.LoadTasks(project)
+	if err != nil {
+		return slackMessage(err.Error())
+	}
+	task := store.GetTask(store.ResolveTaskRef(ref))
+	if task == nil {
+		return slackMessage(fmt.Sprintf("No task #%s in %s", ref, project))
+	}
+	resp := slackResponse{
+		ResponseType: "ephemeral",
+		Text:         fmt.Sprintf("#%s %s", data.DisplayID(*task), task.Subject),
+		Blocks:       []slackBlock{{Type: "section", Text: mrkdwn(slackTaskLine(*task))}},
+	}
+	if task.Description != "" {
+		resp.Blocks = append(resp.Blocks, slackBlock{Type: "section", Text: mrkdwn(escapeSlack(task.Description))})
+	}
+	var refs []string
+	for _, id := range task.BlockedBy {
+		refs = append(refs, "#"+store.DisplayRef(id))
+	}
+	context := fmt.Sprintf("%s · %s", escapeSlack(project), task.Status)
+	if len(refs) > 0 {
+		context += " · blocked by " + strings.Join(refs, ", ")
+	}
+	resp.Blocks = append(resp.Blocks, slackBlock{Type: "context", Elements: []slackText{*mrkdwn(context)}})
+	return resp
+}
+
+// slackSetStatus changes a task's status and announces it in the channel
+func slackSetStatus(project, ref, status, user string) slackResponse {
+	store, err := data.LoadTasks(project)
+	if err != nil {
+		return slackMessage(err.Error())
+	}
+	task := store.GetTask(store.ResolveTaskRef(ref))
+	if task == nil {
+		return slackMessage(fmt.Sprintf("No task #%s in %s", ref, project))
+	}
+	if task.Status == status {
+		return slackMessage(fmt.Sprintf("#%s is already %s", data.DisplayID(*task), status))
+	}
+	task.Status = status
+	if err := store.Save(); err != nil {
+		return slackMessage(fmt.Sprintf("Could not save %s: %v", project, err))
+	}
+
+	verb := "completed"
+	if status == "in_progress" {
+		verb = "started"
+	}
+	text := fmt.Sprintf("%s %s #%s in %s", user, verb, data.DisplayID(*task), project)
+	return slackResponse{
+		ResponseType: "in_channel",
+		Text:         text,
+		Blocks: []slackBlock{
+			{Type: "section", Text: mrkdwn(slackTaskLine(*task))},
+			{Type: "context", Elements: []slackText{*mrkdwn(escapeSlack(text))}},
+		},
+	}
+}
+
+// slackMessage is a plain response only shown to the caller
+func slackMessage(text string) slackResponse {
+	return slackResponse{ResponseType: "ephemeral", Text: text}
+}
+
+// escapeSlack escapes the characters Slack treats as control sequences
+func escapeSlack(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
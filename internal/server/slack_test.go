@@ -0,0 +1,101 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jss826/cctasks/internal/data"
+)
+
+const testSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+// slackRequest builds a slash command request signed with secret
+func slackRequest(secret, text string, now time.Time) *http.Request {
+	body := url.Values{"command": {"/cctasks"}, "text": {text}, "user_name": {"ana"}}.Encode()
+	ts := strconv.FormatInt(now.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + ts + ":" + body))
+
+	r := httptest.NewRequest(http.MethodPost, "/slack", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Slack-Request-Timestamp", ts)
+	r.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return r
+}
+
+func runSlack(t *testing.T, h http.Handler, text string) slackResponse {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, slackRequest(testSecret, text, time.Now()))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%q: status = %d: %s", text, rec.Code, rec.Body.String())
+	}
+	var resp slackResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("%q: bad response: %v", text, err)
+	}
+	return resp
+}
+
+func TestSlack_Commands(t *testing.T) {
+	setupProjects(t)
+	h := Handler(Options{SlackSecret: testSecret, SlackProject: "web"})
+
+	resp := runSlack(t, h, "list api")
+	if len(resp.Blocks) != 3 || !strings.Contains(resp.Blocks[2].Text.Text, "*#1* Freeze API") {
+		t.Errorf("list api = %+v", resp)
+	}
+
+	resp = runSlack(t, h, "done 1")
+	if resp.ResponseType != "in_channel" || resp.Text != "ana completed #1 in web" {
+		t.Errorf("done = %+v", resp)
+	}
+	store, err := data.LoadTasks("web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := store.GetTask("1").Status; got != "completed" {
+		t.Errorf("status after done = %q, want completed", got)
+	}
+
+	if resp := runSlack(t, h, "list"); !strings.Contains(resp.Blocks[0].Text.Text, "0 open, 1 completed") {
+		t.Errorf("list (default project) = %+v", resp)
+	}
+	if resp := runSlack(t, h, "show nope 1"); resp.Text != "No project `nope`" {
+		t.Errorf("show unknown project = %+v", resp)
+	}
+	if resp := runSlack(t, h, "fly"); !strings.HasPrefix(resp.Text, "Unknown command `fly`") {
+		t.Errorf("unknown command = %+v", resp)
+	}
+}
+
+func TestSlack_RejectsUnsignedRequests(t *testing.T) {
+	setupProjects(t)
+	h := Handler(Options{SlackSecret: testSecret})
+
+	for name, r := range map[string]*http.Request{
+		"wrong secret": slackRequest("other", "list web", time.Now()),
+		"stale":        slackRequest(testSecret, "list web", time.Now().Add(-10*time.Minute)),
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", name, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	Handler(Options{}).ServeHTTP(rec, slackRequest(testSecret, "list web", time.Now()))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("without a signing secret: status = %d, want 404", rec.Code)
+	}
+}
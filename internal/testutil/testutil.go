@@ -0,0 +1,23 @@
+// Package testutil holds helpers shared by the tests of the other packages.
+package testutil
+
+import (
+	"testing"
+
+	"github.com/jss826/cctasks/internal/config"
+)
+
+// IsolateHome points the home directory and the tasks directory at temp
+// directories for the rest of the test, so that backups, history, trash and
+// state files never land in the real ~/.claude or ~/.config. It returns the
+// tasks directory, which exists and is empty.
+func IsolateHome(tb testing.TB) string {
+	tb.Helper()
+	home := tb.TempDir()
+	tb.Setenv("HOME", home)
+	tb.Setenv("USERPROFILE", home)
+	tasksDir := tb.TempDir()
+	config.SetTasksDirOverride(tasksDir)
+	tb.Cleanup(func() { config.SetTasksDirOverride("") })
+	return tasksDir
+}
@@ -3,8 +3,14 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
+
+	"github.com/jss826/cctasks/internal/i18n"
 )
 
 // Header renders the application header
@@ -13,37 +19,15 @@ func Header(title string, width int) string {
 	return titleText + "\n" + HorizontalLine(width)
 }
 
-// Footer renders the help footer with auto line wrapping
+// Footer renders the help footer with auto line wrapping (descriptions are translated)
 func Footer(keys [][]string, width int) string {
 	var parts []string
 	for _, pair := range keys {
-		key := KeyStyle.Render(fmt.Sprintf("[%s]", pair[0]))
-		desc := MutedStyle.Render(pair[1])
+		key := KeyStyle.Render(fmt.Sprintf("[%s]", KeyLabel(pair[0])))
+		desc := MutedStyle.Render(i18n.T(pair[1]))
 		parts = append(parts, fmt.Sprintf("%s %s", key, desc))
 	}
-
-	// Wrap parts into lines based on width
-	var lines []string
-	var currentLine string
-	for i, part := range parts {
-		partWidth := lipgloss.Width(part)
-		currentWidth := lipgloss.Width(currentLine)
-
-		if currentLine == "" {
-			currentLine = part
-		} else if currentWidth+2+partWidth <= width {
-			currentLine += "  " + part
-		} else {
-			lines = append(lines, currentLine)
-			currentLine = part
-		}
-
-		if i == len(parts)-1 && currentLine != "" {
-			lines = append(lines, currentLine)
-		}
-	}
-
-	return HorizontalLine(width) + "\n" + strings.Join(lines, "\n")
+	return HorizontalLine(width) + "\n" + wrapParts(parts, width)
 }
 
 // KeyHint represents a key binding with enabled state
@@ -58,57 +42,93 @@ func FooterWithHints(hints []KeyHint, width int) string {
 	var parts []string
 	for _, hint := range hints {
 		if hint.Enabled {
-			key := KeyStyle.Render(fmt.Sprintf("[%s]", hint.Key))
-			desc := MutedStyle.Render(hint.Desc)
+			key := KeyStyle.Render(fmt.Sprintf("[%s]", KeyLabel(hint.Key)))
+			desc := MutedStyle.Render(i18n.T(hint.Desc))
 			parts = append(parts, fmt.Sprintf("%s %s", key, desc))
 		} else {
 			// Disabled - fully grayed out
-			key := DisabledStyle.Render(fmt.Sprintf("[%s]", hint.Key))
-			desc := DisabledStyle.Render(hint.Desc)
+			key := DisabledStyle.Render(fmt.Sprintf("[%s]", KeyLabel(hint.Key)))
+			desc := DisabledStyle.Render(i18n.T(hint.Desc))
 			parts = append(parts, fmt.Sprintf("%s %s", key, desc))
 		}
 	}
+	return HorizontalLine(width) + "\n" + wrapParts(parts, width)
+}
 
-	// Wrap parts into lines based on width
+// wrapParts joins footer parts with two spaces, starting a new line before a
+// part that would overflow width display columns
+func wrapParts(parts []string, width int) string {
 	var lines []string
 	var currentLine string
-	for i, part := range parts {
-		partWidth := lipgloss.Width(part)
-		currentWidth := lipgloss.Width(currentLine)
-
+	for _, part := range parts {
 		if currentLine == "" {
 			currentLine = part
-		} else if currentWidth+2+partWidth <= width {
+		} else if lipgloss.Width(currentLine)+2+lipgloss.Width(part) <= width {
 			currentLine += "  " + part
 		} else {
 			lines = append(lines, currentLine)
 			currentLine = part
 		}
-
-		if i == len(parts)-1 && currentLine != "" {
-			lines = append(lines, currentLine)
-		}
 	}
-
-	return HorizontalLine(width) + "\n" + strings.Join(lines, "\n")
+	if currentLine != "" {
+		lines = append(lines, currentLine)
+	}
+	return strings.Join(lines, "\n")
 }
 
 // StatusBadge renders a status badge with icon
 func StatusBadge(status string) string {
-	icon := StatusIcon(status)
-	style := GetStatusStyle(status)
-	return style.Render(fmt.Sprintf("%s %s", icon, status))
+	return GetStatusStyle(status).Render(StatusText(status))
 }
 
-// StatusIcon returns the icon for a status
+// StatusText returns the icon and name of a status ("● in_progress"), or
+// only the name in monochrome mode where the icon is a word already
+func StatusText(status string) string {
+	if noColor {
+		return i18n.T(status)
+	}
+	return fmt.Sprintf("%s %s", StatusIcon(status), i18n.T(status))
+}
+
+// StatusCount renders a task count with its status icon ("●3", or "3 doing"
+// in monochrome mode)
+func StatusCount(status string, count int) string {
+	if noColor {
+		return fmt.Sprintf("%d %s", count, StatusIcon(status))
+	}
+	return fmt.Sprintf("%s%d", StatusIcon(status), count)
+}
+
+// StatusIcon returns the icon for a status, a short word in monochrome mode.
+// "blocked" is not a task status but is drawn like one for blocked open tasks.
 func StatusIcon(status string) string {
+	if noColor {
+		switch status {
+		case "pending":
+			return i18n.T("todo")
+		case "in_progress":
+			return i18n.T("doing")
+		case "completed":
+			return i18n.T("done")
+		case "needs_review":
+			return i18n.T("review")
+		case "blocked":
+			return i18n.T("blocked")
+		default:
+			return "?"
+		}
+	}
 	switch status {
 	case "pending":
-		return "○"
+		return Glyphs.Pending
 	case "in_progress":
-		return "●"
+		return Glyphs.InProgress
 	case "completed":
-		return "✓"
+		return Glyphs.Completed
+	case "needs_review":
+		return Glyphs.Review
+	case "blocked":
+		return Glyphs.Blocked
 	default:
 		return "?"
 	}
@@ -116,35 +136,100 @@ func StatusIcon(status string) string {
 
 // GroupBadge renders a colored group badge
 func GroupBadge(name string, color string) string {
-	swatch := ColorSwatchStyle(color).Render("██")
+	if noColor {
+		return name
+	}
+	swatch := ColorSwatchStyle(color).Render(strings.Repeat(Glyphs.Block, 2))
 	return fmt.Sprintf("%s %s", swatch, name)
 }
 
+// OwnerBadge renders an owner's initials on the owner's color (reversed in
+// monochrome mode); initials are at least 2 columns wide so badges line up
+func OwnerBadge(initials string, color string) string {
+	initials = PadRight(initials, 2)
+	if noColor {
+		return lipgloss.NewStyle().Reverse(true).Render(initials)
+	}
+	return lipgloss.NewStyle().
+		Background(lipgloss.Color(color)).
+		Foreground(lipgloss.Color("#111827")).
+		Bold(true).
+		Render(initials)
+}
+
 // CountBadge renders a count badge
 func CountBadge(count int) string {
 	return MutedStyle.Render(fmt.Sprintf("[%d]", count))
 }
 
-// Truncate truncates a string to max length with ellipsis
-func Truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
+// RelativeTime renders how long ago t was, e.g. "3h ago"; times older than
+// four weeks are shown as a date
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case t.IsZero():
+		return i18n.T("never")
+	case d < time.Minute:
+		return i18n.T("just now")
+	case d < time.Hour:
+		return i18n.Tf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return i18n.Tf("%dh ago", int(d.Hours()))
+	case d < 28*24*time.Hour:
+		return i18n.Tf("%dd ago", int(d.Hours()/24))
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+// ProgressBar renders a bar of the given width filled to ratio (0.0-1.0)
+func ProgressBar(ratio float64, width int) string {
+	if ratio < 0 {
+		ratio = 0
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+	filled := int(ratio*float64(width) + 0.5)
+	return CompletedStyle.Render(strings.Repeat(Glyphs.Block, filled)) +
+		MutedStyle.Render(strings.Repeat(Glyphs.Shade, width-filled))
+}
+
+// Truncate shortens s to at most maxWidth display columns, ending it with an
+// ellipsis; wide runes (CJK, emoji) count as two columns and are never split
+func Truncate(s string, maxWidth int) string {
+	if runewidth.StringWidth(s) <= maxWidth {
 		return s
 	}
-	if maxLen <= 3 {
-		return s[:maxLen]
+	if maxWidth <= 3 {
+		return runewidth.Truncate(s, maxWidth, "")
 	}
-	return s[:maxLen-3] + "..."
+	return runewidth.Truncate(s, maxWidth, "...")
+}
+
+// PadRight pads s with spaces to the given display width
+func PadRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
+
+// PadLeft right-aligns s in the given display width
+func PadLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-lipgloss.Width(s), 0)) + s
 }
 
+// dialogTextWidth is the text width inside DialogBoxStyle (60 columns less
+// the horizontal padding)
+const dialogTextWidth = 56
+
 // Confirm renders a confirmation dialog
 func Confirm(title, message string, confirmKey, cancelKey string) string {
 	content := DialogTitleStyle.Render(title) + "\n\n"
-	content += message + "\n\n"
+	content += WordWrap(message, dialogTextWidth) + "\n\n"
 	content += fmt.Sprintf("%s %s  %s %s",
 		KeyStyle.Render(fmt.Sprintf("[%s]", confirmKey)),
-		MutedStyle.Render("Confirm"),
+		MutedStyle.Render(i18n.T("Confirm")),
 		KeyStyle.Render(fmt.Sprintf("[%s]", cancelKey)),
-		MutedStyle.Render("Cancel"),
+		MutedStyle.Render(i18n.T("Cancel")),
 	)
 	return DialogBoxStyle.Render(content)
 }
@@ -163,7 +248,7 @@ func RenderDropdown(label string, options []string, selected int, focused bool)
 		selectedText = options[selected]
 	}
 
-	content := fmt.Sprintf("%s ▼", selectedText)
+	content := fmt.Sprintf("%s %s", selectedText, Glyphs.Expanded)
 	return fmt.Sprintf("%s\n%s",
 		InputLabelStyle.Render(label+":"),
 		style.Render(content),
@@ -176,7 +261,7 @@ func RenderDropdownExpanded(label string, options []string, selected int, highli
 	for i, opt := range options {
 		prefix := "  "
 		if i == selected {
-			prefix = "✓ "
+			prefix = Glyphs.Check + " "
 		}
 		if i == highlighted {
 			lines = append(lines, SelectedStyle.Render(prefix+opt))
@@ -200,6 +285,21 @@ func LabelValue(label, value string) string {
 	)
 }
 
+// LabelValueWrapped renders a label: value pair whose value wraps to width
+// columns, continuation lines aligned under the first
+func LabelValueWrapped(label, value string, width int) string {
+	label = LabelStyle.Render(label + ":")
+	indent := lipgloss.Width(label) + 1
+	lines := strings.Split(WordWrap(value, max(width-indent, 10)), "\n")
+	for i, line := range lines {
+		lines[i] = ValueStyle.Render(line)
+		if i > 0 {
+			lines[i] = strings.Repeat(" ", indent) + lines[i]
+		}
+	}
+	return label + " " + strings.Join(lines, "\n")
+}
+
 // Section renders a section with title
 func Section(title string, content string, width int) string {
 	header := MutedStyle.Render(title)
@@ -210,39 +310,14 @@ func Section(title string, content string, width int) string {
 // Spinner characters for loading animation
 var SpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
-// WordWrap wraps text to fit within width
+// WordWrap wraps text to width display columns, breaking at spaces and
+// hard-breaking words longer than a line (such as runs of Japanese text);
+// ANSI escape sequences take no width
 func WordWrap(text string, width int) string {
 	if width <= 0 {
 		return text
 	}
-
-	var result strings.Builder
-	lines := strings.Split(text, "\n")
-
-	for i, line := range lines {
-		if i > 0 {
-			result.WriteString("\n")
-		}
-
-		words := strings.Fields(line)
-		if len(words) == 0 {
-			continue
-		}
-
-		currentLine := words[0]
-		for _, word := range words[1:] {
-			if len(currentLine)+1+len(word) > width {
-				result.WriteString(currentLine)
-				result.WriteString("\n")
-				currentLine = word
-			} else {
-				currentLine += " " + word
-			}
-		}
-		result.WriteString(currentLine)
-	}
-
-	return result.String()
+	return wrap.String(wordwrap.String(text, width), width)
 }
 
 // CenterText centers text within a given width
@@ -257,7 +332,7 @@ func CenterText(text string, width int) string {
 
 // CenterPad centers text within a given width with padding on both sides
 func CenterPad(text string, width int) string {
-	textLen := len(text)
+	textLen := lipgloss.Width(text)
 	if textLen >= width {
 		return text
 	}
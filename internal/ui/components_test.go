@@ -3,6 +3,9 @@ package ui
 import (
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestFooter(t *testing.T) {
@@ -76,6 +79,14 @@ func TestTruncate(t *testing.T) {
 		{"exact", 5, "exact"},
 		{"ab", 2, "ab"},
 		{"abc", 2, "ab"},
+		// wide runes take two columns and are never cut in half
+		{"日本語のタスク", 14, "日本語のタスク"},
+		{"日本語のタスク", 10, "日本語..."},
+		{"日本語のタスク", 8, "日本..."},
+		{"日本語", 3, "日"},
+		{"🎉 release party", 10, "🎉 rele..."},
+		{"🎉🎉🎉", 5, "🎉..."},
+		{"🎉🎉", 3, "🎉"},
 	}
 
 	for _, tt := range tests {
@@ -83,6 +94,31 @@ func TestTruncate(t *testing.T) {
 		if result != tt.expected {
 			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.input, tt.maxLen, result, tt.expected)
 		}
+		if w := lipgloss.Width(result); w > tt.maxLen {
+			t.Errorf("Truncate(%q, %d) is %d columns wide", tt.input, tt.maxLen, w)
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	tests := []struct {
+		input string
+		width int
+		right string
+		left  string
+	}{
+		{"ab", 4, "ab  ", "  ab"},
+		{"日本", 6, "日本  ", "  日本"},
+		{"🎉", 3, "🎉 ", " 🎉"},
+		{"toolong", 3, "toolong", "toolong"},
+	}
+	for _, tt := range tests {
+		if got := PadRight(tt.input, tt.width); got != tt.right {
+			t.Errorf("PadRight(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.right)
+		}
+		if got := PadLeft(tt.input, tt.width); got != tt.left {
+			t.Errorf("PadLeft(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.left)
+		}
 	}
 }
 
@@ -208,6 +244,43 @@ func TestWordWrapMultiline(t *testing.T) {
 	}
 }
 
+func TestWordWrapWideAndStyled(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"japanese", "日本語の説明文はスペースを含まないので単語の途中でも折り返す"},
+		{"mixed", "Fix the 日本語 rendering of long task subjects in the list"},
+		{"emoji", "🎉 ship the release 🎉 and celebrate with everyone 🎉"},
+		{"ansi", KeyStyle.Render("highlighted") + " words keep their color codes " + ErrorStyle.Render("intact")},
+	}
+	for _, tt := range tests {
+		result := WordWrap(tt.text, 16)
+		for _, line := range strings.Split(result, "\n") {
+			if w := lipgloss.Width(line); w > 16 {
+				t.Errorf("%s: line %q is %d columns wide", tt.name, line, w)
+			}
+		}
+		if strings.ReplaceAll(strings.ReplaceAll(result, "\n", ""), " ", "") != strings.ReplaceAll(tt.text, " ", "") {
+			t.Errorf("%s: wrapping changed the text: %q", tt.name, result)
+		}
+	}
+}
+
+func TestLabelValueWrapped(t *testing.T) {
+	result := LabelValueWrapped("Subject", "日本語のとても長いタスクの件名を折り返して表示する", 30)
+	lines := strings.Split(result, "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected the value to wrap, got %q", result)
+	}
+	indent := lipgloss.Width(LabelStyle.Render("Subject:")) + 1
+	for _, line := range lines[1:] {
+		if !strings.HasPrefix(line, strings.Repeat(" ", indent)) {
+			t.Errorf("continuation line %q not aligned under the value", line)
+		}
+	}
+}
+
 func TestWordWrapZeroWidth(t *testing.T) {
 	text := "Some text"
 	result := WordWrap(text, 0)
@@ -278,3 +351,38 @@ func TestHeader(t *testing.T) {
 		t.Error("Expected header to contain title")
 	}
 }
+
+func TestProgressBar(t *testing.T) {
+	result := ProgressBar(0.5, 10)
+	if strings.Count(result, "█") != 5 || strings.Count(result, "░") != 5 {
+		t.Errorf("Expected half-filled bar, got %q", result)
+	}
+
+	// Ratios are clamped
+	if strings.Count(ProgressBar(2, 4), "█") != 4 {
+		t.Error("Expected full bar for ratio > 1")
+	}
+	if strings.Count(ProgressBar(-1, 4), "░") != 4 {
+		t.Error("Expected empty bar for ratio < 0")
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 30, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{now.Add(-10 * time.Second), "just now"},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(-3 * time.Hour), "3h ago"},
+		{now.Add(-50 * time.Hour), "2d ago"},
+		{now.Add(-40 * 24 * time.Hour), "2024-02-19"},
+		{time.Time{}, "never"},
+	}
+	for _, tt := range tests {
+		if got := RelativeTime(tt.t, now); got != tt.want {
+			t.Errorf("RelativeTime(%v) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
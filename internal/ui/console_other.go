@@ -0,0 +1,9 @@
+//go:build !windows
+
+package ui
+
+// LegacyConsole reports whether the UI runs in the legacy Windows console
+// (conhost) rather than Windows Terminal or another modern terminal
+func LegacyConsole() bool {
+	return false
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package ui
+
+import "os"
+
+// LegacyConsole reports whether the UI runs in the legacy Windows console
+// (conhost) rather than Windows Terminal or another modern terminal
+func LegacyConsole() bool {
+	for _, env := range []string{"WT_SESSION", "TERM_PROGRAM", "TERM", "ConEmuANSI"} {
+		if os.Getenv(env) != "" {
+			return false
+		}
+	}
+	return true
+}
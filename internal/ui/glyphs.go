@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// GlyphSet holds the non-ASCII characters drawn by the UI
+type GlyphSet struct {
+	Pending    string // status icons
+	InProgress string
+	Completed  string
+	Review     string // needs_review
+	Blocked    string // open task with open blockers or flagged blocked
+	Expanded   string // group and dropdown arrows
+	Collapsed  string
+	Block      string // progress and timeline bars, color swatches
+	Shade      string // empty part of progress bars
+	Swatch     string // group color dot in headers
+	Tick       string // timeline day ticks
+	Line       string // horizontal rules
+	VLine      string // column separators
+	AxisTee    string // chart y-axis
+	Corner     string // chart origin and tree branches
+	Star       string
+	Agent      string // badge of agent-managed tasks
+	Diamond    string
+	Cursor     string // focus marker in lists
+	Check      string // selected checkbox or dropdown option
+	Cross      string
+	Warning    string
+	Ellipsis   string
+	Arrow      string // date ranges
+	Left       string // bars continuing outside the timeline
+	Right      string
+	Up         string // scroll indicators
+	Down       string
+	Sparks     []string // burndown chart levels from empty to full
+	Heat       []string // activity heatmap levels from none to most
+	Border     lipgloss.Border
+
+	keys *strings.Replacer // rewrites arrow keys in footers
+}
+
+// UnicodeGlyphs is the default glyph set
+var UnicodeGlyphs = GlyphSet{
+	Pending:    "○",
+	InProgress: "●",
+	Completed:  "✓",
+	Review:     "◎",
+	Blocked:    "⊘",
+	Expanded:   "▼",
+	Collapsed:  "▶",
+	Block:      "█",
+	Shade:      "░",
+	Swatch:     "●",
+	Tick:       "·",
+	Line:       "─",
+	VLine:      "│",
+	AxisTee:    "┤",
+	Corner:     "└",
+	Star:       "★",
+	Agent:      "🤖",
+	Diamond:    "◆",
+	Cursor:     "▸",
+	Check:      "✓",
+	Cross:      "✗",
+	Warning:    "⚠",
+	Ellipsis:   "…",
+	Arrow:      "→",
+	Left:       "◀",
+	Right:      "▶",
+	Up:         "↑",
+	Down:       "↓",
+	Sparks:     []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"},
+	Heat:       []string{"·", "░", "▒", "▓", "█"},
+	Border:     lipgloss.RoundedBorder(),
+	keys:       strings.NewReplacer(),
+}
+
+// ASCIIGlyphs replaces every glyph with ASCII for terminals and fonts that
+// render Unicode poorly
+var ASCIIGlyphs = GlyphSet{
+	Pending:    "[ ]",
+	InProgress: "[~]",
+	Completed:  "[x]",
+	Review:     "[?]",
+	Blocked:    "[!]",
+	Expanded:   "v",
+	Collapsed:  ">",
+	Block:      "#",
+	Shade:      ".",
+	Swatch:     "#",
+	Tick:       ".",
+	Line:       "-",
+	VLine:      "|",
+	AxisTee:    "|",
+	Corner:     "`",
+	Star:       "*",
+	Agent:      "(bot)",
+	Diamond:    "+",
+	Cursor:     ">",
+	Check:      "x",
+	Cross:      "x",
+	Warning:    "!",
+	Ellipsis:   "...",
+	Arrow:      "->",
+	Left:       "<",
+	Right:      ">",
+	Up:         "^",
+	Down:       "v",
+	Sparks:     []string{" ", ".", ".", ":", ":", "=", "=", "#", "#"},
+	Heat:       []string{".", ":", "+", "*", "#"},
+	Border: lipgloss.Border{
+		Top: "-", Bottom: "-", Left: "|", Right: "|",
+		TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+	},
+	keys: strings.NewReplacer("↑", "^", "↓", "v", "←", "<", "→", ">"),
+}
+
+// ConsoleGlyphs replaces the glyphs missing from the fonts of the legacy
+// Windows console (Consolas, Lucida Console), which draws them as boxes
+var ConsoleGlyphs = func() GlyphSet {
+	g := UnicodeGlyphs
+	g.Completed = "√"
+	g.Review = "◊"
+	g.Blocked = "Θ"
+	g.Collapsed = "►"
+	g.Star = "*"
+	g.Agent = "(bot)"
+	g.Diamond = "♦"
+	g.Cursor = "►"
+	g.Check = "√"
+	g.Cross = "x"
+	g.Warning = "!"
+	g.Left = "◄"
+	g.Right = "►"
+	g.Sparks = []string{" ", "_", "_", "▄", "▄", "▄", "█", "█", "█"}
+	g.Border = lipgloss.NormalBorder()
+	return g
+}()
+
+// Glyphs is the active glyph set
+var Glyphs = UnicodeGlyphs
+
+// SetASCII switches between the Unicode and ASCII glyph sets
+func SetASCII(enabled bool) {
+	if enabled {
+		SetGlyphs(ASCIIGlyphs)
+	} else {
+		SetGlyphs(UnicodeGlyphs)
+	}
+}
+
+// SetGlyphs makes set the active glyph set
+func SetGlyphs(set GlyphSet) {
+	Glyphs = set
+	for _, style := range []*lipgloss.Style{&BoxStyle, &DialogBoxStyle} {
+		*style = style.BorderStyle(Glyphs.Border)
+		if saved, ok := colorStyles[style]; ok {
+			colorStyles[style] = saved.BorderStyle(Glyphs.Border)
+		}
+	}
+}
+
+// KeyLabel returns a key name for footers, e.g. "↑↓" ("^v" in ASCII mode)
+func KeyLabel(key string) string {
+	return Glyphs.keys.Replace(key)
+}
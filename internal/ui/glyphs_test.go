@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > 127 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSetASCII(t *testing.T) {
+	SetASCII(true)
+	t.Cleanup(func() { SetASCII(false) })
+
+	for _, s := range []string{
+		StatusIcon("pending"),
+		StatusIcon("in_progress"),
+		StatusIcon("completed"),
+		ProgressBar(0.5, 10),
+		HorizontalLine(5),
+		GroupBadge("Backend", "#7aa2f7"),
+		RenderDropdown("Group", []string{"A", "B"}, 0, true),
+		Confirm("Delete", "Sure?", "y", "n"),
+		Footer([][]string{{"↑↓", "Navigate"}, {"←→", "Day"}}, 80),
+	} {
+		if !isASCII(s) {
+			t.Errorf("non-ASCII output in ASCII mode: %q", s)
+		}
+	}
+	if got := StatusIcon("in_progress"); got != "[~]" {
+		t.Errorf("StatusIcon(in_progress) = %q", got)
+	}
+	if got := KeyLabel("↑↓"); got != "^v" {
+		t.Errorf("KeyLabel = %q", got)
+	}
+
+	SetASCII(false)
+	if got := StatusIcon("completed"); got != "✓" {
+		t.Errorf("StatusIcon(completed) = %q after restore", got)
+	}
+	if got := DialogBoxStyle.GetBorderStyle(); got != UnicodeGlyphs.Border {
+		t.Errorf("dialog border not restored: %+v", got)
+	}
+}
+
+func TestSetGlyphsConsole(t *testing.T) {
+	SetGlyphs(ConsoleGlyphs)
+	t.Cleanup(func() { SetASCII(false) })
+
+	if got := StatusIcon("completed"); got != "√" {
+		t.Errorf("StatusIcon(completed) = %q", got)
+	}
+	if got := BoxStyle.GetBorderStyle(); got != lipgloss.NormalBorder() {
+		t.Errorf("box border = %+v, want the square border", got)
+	}
+	if UnicodeGlyphs.Completed != "✓" {
+		t.Error("ConsoleGlyphs must not modify UnicodeGlyphs")
+	}
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
+)
+
+// noColor is true in monochrome mode (--no-color or $NO_COLOR)
+var noColor bool
+
+// colorStyles keeps the themed styles so monochrome mode can be turned off
+var colorStyles map[*lipgloss.Style]lipgloss.Style
+
+// NoColor reports whether monochrome mode is on
+func NoColor() bool {
+	return noColor
+}
+
+// monochromeStyles returns attribute-only replacements for the themed styles:
+// selection uses reverse video, emphasis bold, errors bold and underlined
+func monochromeStyles() map[*lipgloss.Style]lipgloss.Style {
+	plain := lipgloss.NewStyle
+	return map[*lipgloss.Style]lipgloss.Style{
+		&TitleStyle:        plain().Bold(true),
+		&SubtitleStyle:     plain().Italic(true),
+		&BoxStyle:          plain().Border(Glyphs.Border).Padding(1, 2),
+		&SelectedStyle:     plain().Bold(true).Reverse(true),
+		&NormalStyle:       plain(),
+		&MutedStyle:        plain(),
+		&DisabledStyle:     plain().Faint(true),
+		&HelpStyle:         plain().Padding(1, 0),
+		&KeyStyle:          plain().Bold(true),
+		&ValueStyle:        plain(),
+		&LabelStyle:        plain().Width(12),
+		&ErrorStyle:        plain().Bold(true).Underline(true),
+		&SuccessStyle:      plain().Bold(true),
+		&WarningStyle:      plain().Underline(true),
+		&JSONKeyStyle:      plain().Bold(true),
+		&JSONStringStyle:   plain(),
+		&JSONNumberStyle:   plain(),
+		&JSONLiteralStyle:  plain().Italic(true),
+		&PendingStyle:      plain(),
+		&InProgressStyle:   plain().Bold(true),
+		&CompletedStyle:    plain(),
+		&ReviewStyle:       plain().Underline(true),
+		&BlockedStyle:      plain().Italic(true),
+		&GroupHeaderStyle:  plain().Bold(true).Underline(true),
+		&TaskSelectedStyle: plain().Bold(true).Reverse(true),
+		&BlockedByStyle:    plain().PaddingLeft(4).Italic(true),
+		&FilterBarStyle:    plain().Padding(0, 0, 1, 0),
+		&ActiveFilterStyle: plain().Bold(true).Underline(true),
+		&SearchMatchStyle:  plain().Reverse(true),
+		&DialogBoxStyle:    plain().Border(Glyphs.Border).Padding(1, 2).Width(60),
+		&DialogTitleStyle:  plain().Bold(true).MarginBottom(1),
+		&ButtonStyle:       plain().Padding(0, 2),
+		&ActiveButtonStyle: plain().Reverse(true).Padding(0, 2),
+		&InputStyle:        plain(),
+		&FocusedInputStyle: plain(),
+		&InputLabelStyle:   plain().MarginBottom(0),
+	}
+}
+
+// SetNoColor switches between the colored theme and monochrome mode, which
+// replaces colors with bold/underline/reverse attributes and status icons
+// with words for screen readers
+func SetNoColor(enabled bool) {
+	mono := monochromeStyles()
+	if colorStyles == nil {
+		colorStyles = make(map[*lipgloss.Style]lipgloss.Style, len(mono))
+		for style := range mono {
+			colorStyles[style] = *style
+		}
+	}
+	for style, replacement := range mono {
+		if enabled {
+			*style = replacement
+		} else {
+			*style = colorStyles[style]
+		}
+	}
+	noColor = enabled
+
+	// termenv drops every attribute, not only colors, when $NO_COLOR is set;
+	// keep bold/underline/reverse on terminals
+	if enabled && lipgloss.ColorProfile() == termenv.Ascii && term.IsTerminal(int(os.Stdout.Fd())) {
+		lipgloss.SetColorProfile(termenv.ANSI)
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSetNoColor(t *testing.T) {
+	colored := SelectedStyle
+	SetNoColor(true)
+	t.Cleanup(func() { SetNoColor(false) })
+
+	if !NoColor() {
+		t.Fatal("NoColor() = false after SetNoColor(true)")
+	}
+	if !SelectedStyle.GetReverse() || !SelectedStyle.GetBold() {
+		t.Error("selection should use bold reverse video in monochrome mode")
+	}
+	if SelectedStyle.GetForeground() != (lipgloss.NoColor{}) {
+		t.Error("selection should have no foreground color in monochrome mode")
+	}
+	if got := StatusIcon("in_progress"); got != "doing" {
+		t.Errorf("StatusIcon(in_progress) = %q, want a word", got)
+	}
+	if got := StatusCount("completed", 3); got != "3 done" {
+		t.Errorf("StatusCount = %q", got)
+	}
+	if got := StatusText("pending"); got != "pending" {
+		t.Errorf("StatusText = %q", got)
+	}
+	if got := GroupBadge("Backend", "#7aa2f7"); got != "Backend" {
+		t.Errorf("GroupBadge = %q, want the name only", got)
+	}
+	if line := HorizontalLine(5); strings.Contains(line, "\x1b") {
+		t.Errorf("HorizontalLine contains escape codes: %q", line)
+	}
+
+	SetNoColor(false)
+	if SelectedStyle.GetForeground() != colored.GetForeground() || SelectedStyle.GetReverse() {
+		t.Error("colored theme not restored")
+	}
+	if got := StatusIcon("in_progress"); got != "●" {
+		t.Errorf("StatusIcon(in_progress) = %q after restore", got)
+	}
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // Tokyo Night Light variant
@@ -23,6 +24,8 @@ var (
 	PendingColor    = lipgloss.Color("#9aa5ce") // muted (brighter)
 	InProgressColor = lipgloss.Color("#7aa2f7") // blue
 	CompletedColor  = lipgloss.Color("#9ece6a") // green
+	ReviewColor     = lipgloss.Color("#e0af68") // yellow
+	BlockedColor    = lipgloss.Color("#f7768e") // red
 )
 
 // Base styles
@@ -100,6 +103,14 @@ var (
 			Foreground(Warning)
 )
 
+// JSON syntax highlighting styles
+var (
+	JSONKeyStyle     = lipgloss.NewStyle().Foreground(Primary)
+	JSONStringStyle  = lipgloss.NewStyle().Foreground(Success)
+	JSONNumberStyle  = lipgloss.NewStyle().Foreground(Warning)
+	JSONLiteralStyle = lipgloss.NewStyle().Foreground(InProgressColor)
+)
+
 // Status styles
 var (
 	PendingStyle = lipgloss.NewStyle().
@@ -110,6 +121,12 @@ var (
 
 	CompletedStyle = lipgloss.NewStyle().
 			Foreground(CompletedColor)
+
+	ReviewStyle = lipgloss.NewStyle().
+			Foreground(ReviewColor)
+
+	BlockedStyle = lipgloss.NewStyle().
+			Foreground(BlockedColor)
 )
 
 // GetStatusStyle returns the appropriate style for a status
@@ -121,6 +138,10 @@ func GetStatusStyle(status string) lipgloss.Style {
 		return InProgressStyle
 	case "completed":
 		return CompletedStyle
+	case "needs_review":
+		return ReviewStyle
+	case "blocked":
+		return BlockedStyle
 	default:
 		return MutedStyle
 	}
@@ -145,10 +166,22 @@ var (
 			Italic(true)
 )
 
-// Filter bar style
-var FilterBarStyle = lipgloss.NewStyle().
-	Foreground(Muted).
-	Padding(0, 0, 1, 0)
+// Filter bar styles
+var (
+	FilterBarStyle = lipgloss.NewStyle().
+			Foreground(Muted).
+			Padding(0, 0, 1, 0)
+
+	// ActiveFilterStyle highlights a filter that narrows the list
+	ActiveFilterStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(Primary)
+
+	// SearchMatchStyle highlights the text a search query matched
+	SearchMatchStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(Warning)
+)
 
 // Dialog styles
 var (
@@ -187,8 +220,11 @@ var (
 			MarginBottom(0)
 )
 
-// Color swatch style
+// Color swatch style (plain in monochrome mode)
 func ColorSwatchStyle(color string) lipgloss.Style {
+	if noColor {
+		return lipgloss.NewStyle().Width(2)
+	}
 	return lipgloss.NewStyle().
 		Background(lipgloss.Color(color)).
 		Foreground(lipgloss.Color(color)).
@@ -197,7 +233,10 @@ func ColorSwatchStyle(color string) lipgloss.Style {
 
 // Horizontal line (avoid lipgloss.Render to prevent width miscalculation)
 func HorizontalLine(width int) string {
-	line := repeatString("─", width)
+	line := repeatString(Glyphs.Line, width)
+	if noColor || lipgloss.ColorProfile() == termenv.Ascii {
+		return line
+	}
 	// BorderColor is #6b7089 = RGB(107, 112, 137)
 	return "\x1b[38;2;107;112;137m" + line + "\x1b[0m"
 }
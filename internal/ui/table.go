@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ColumnGap is the space between table columns
+const ColumnGap = "  "
+
+// Column is one column of a table: Width is its display width (0 hides the
+// column) and a Flex column takes the width the fixed columns leave
+type Column struct {
+	Width int
+	Flex  bool
+	Right bool // right-aligned
+}
+
+// Cell is a table cell; Text is truncated to the column before Style applies,
+// or before Render when it is set (to style parts of the text)
+type Cell struct {
+	Text   string
+	Style  lipgloss.Style
+	Render func(text string) string
+}
+
+// render styles the cell's truncated text
+func (c Cell) render(text string) string {
+	if c.Render != nil {
+		return c.Render(text)
+	}
+	return c.Style.Render(text)
+}
+
+// FitColumns sizes the flexible columns so a row fills width, each getting at
+// least minFlex; hidden columns take no gap
+func FitColumns(columns []Column, width, minFlex int) {
+	fixed, flex, shown := 0, 0, 0
+	for _, c := range columns {
+		switch {
+		case c.Flex:
+			flex++
+			shown++
+		case c.Width > 0:
+			fixed += c.Width
+			shown++
+		}
+	}
+	if flex == 0 {
+		return
+	}
+	if shown > 1 {
+		fixed += (shown - 1) * lipgloss.Width(ColumnGap)
+	}
+	each := max((width-fixed)/flex, minFlex)
+	for i := range columns {
+		if columns[i].Flex {
+			columns[i].Width = each
+		}
+	}
+}
+
+// TableRow renders cells into their columns, truncating and padding each to
+// the column width
+func TableRow(columns []Column, cells []Cell) string {
+	var parts []string
+	for i, c := range columns {
+		if c.Width <= 0 || i >= len(cells) {
+			continue
+		}
+		text := Truncate(cells[i].Text, c.Width)
+		if c.Right {
+			parts = append(parts, PadLeft(cells[i].render(text), c.Width))
+		} else {
+			parts = append(parts, PadRight(cells[i].render(text), c.Width))
+		}
+	}
+	return strings.Join(parts, ColumnGap)
+}
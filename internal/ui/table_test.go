@@ -0,0 +1,27 @@
+package ui
+
+import "testing"
+
+func TestFitColumns(t *testing.T) {
+	columns := []Column{{Width: 4}, {Flex: true}, {Width: 0}, {Width: 6}}
+	FitColumns(columns, 40, 10)
+	// 40 - 4 - 6 - two gaps
+	if got := columns[1].Width; got != 26 {
+		t.Errorf("flex width = %d, want 26", got)
+	}
+
+	FitColumns(columns, 12, 10)
+	if got := columns[1].Width; got != 10 {
+		t.Errorf("flex width = %d, want the minimum 10", got)
+	}
+}
+
+func TestTableRow(t *testing.T) {
+	columns := []Column{{Width: 3}, {Width: 8}, {Width: 0}, {Width: 5, Right: true}}
+	cells := []Cell{{Text: "#1"}, {Text: "A long subject"}, {Text: "hidden"}, {Text: "ok"}}
+	got := TableRow(columns, cells)
+	want := "#1 " + ColumnGap + "A lon..." + ColumnGap + "   ok"
+	if got != want {
+		t.Errorf("TableRow = %q, want %q", got, want)
+	}
+}
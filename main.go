@@ -2,19 +2,33 @@ package main
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"runtime/debug"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/mattn/go-runewidth"
 
+	"github.com/jss826/cctasks/internal/cli"
+	"github.com/jss826/cctasks/internal/config"
+	"github.com/jss826/cctasks/internal/data"
+	"github.com/jss826/cctasks/internal/i18n"
+	"github.com/jss826/cctasks/internal/logging"
 	"github.com/jss826/cctasks/internal/model"
+	"github.com/jss826/cctasks/internal/ui"
 )
 
 // Version is set at build time via -ldflags
 var Version = "dev"
 
 func main() {
+	os.Exit(run())
+}
+
+// run runs cctasks and returns the exit code, so the deferred waits for
+// webhooks, uploads and the ssh sync run before main exits
+func run() int {
 	// Disable East Asian Width to fix box drawing character width
 	runewidth.DefaultCondition.EastAsianWidth = false
 
@@ -28,17 +42,78 @@ func main() {
 	// Handle --version flag
 	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "-v") {
 		fmt.Printf("cctasks %s\n", Version)
-		return
+		return 0
+	}
+
+	// NO_COLOR (https://no-color.org) has the same effect as --no-color
+	if os.Getenv("NO_COLOR") != "" {
+		ui.SetNoColor(true)
+	}
+	if config.Current().ASCII {
+		ui.SetASCII(true)
+	} else if ui.LegacyConsole() {
+		ui.SetGlyphs(ui.ConsoleGlyphs)
+	}
+
+	// Global flags (e.g. --dir) apply to subcommands and the TUI alike
+	args, err := cli.ApplyGlobalFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	defer logging.Close()
+	stopProfiling, err := cli.StartProfiling()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer stopProfiling()
+	if err := i18n.SetLanguage(config.Current().Language); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err) // keep English
+	}
+	defer data.WaitWebhooks(5 * time.Second) // deliver notifications of the last saves
+	defer data.WaitUploads(30 * time.Second) // upload the backups of the last saves
+	defer data.StopSSHSync()
+	slog.Debug("start", "version", Version, "args", args)
+	if err := data.MigrateBackupLayout(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err) // the backups stay where they were
+	}
+
+	// Handle non-interactive subcommands (e.g. "cctasks validate")
+	if handled, err := cli.Run(args); handled {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
 	}
 
 	model.AppVersion = Version
 
+	opts, err := cli.ParseLaunchArgs(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
 	app := model.NewApp()
+	app.OpenOnLaunch(opts.Project, opts.TaskID)
 
-	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	p := tea.NewProgram(model.NewCrashGuard(app), tea.WithAltScreen(), tea.WithMouseCellMotion())
 
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if report := model.CrashReportPath(); report != "" {
+		fmt.Fprintf(os.Stderr, "cctasks crashed. A report was written to %s\n", report)
+		return 1
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		return 1
+	}
+
+	// Remember the open project and list state for the next launch
+	if guard, ok := final.(model.CrashGuard); ok {
+		guard.App.SaveSession() // best-effort
 	}
+	return 0
 }